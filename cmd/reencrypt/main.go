@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+)
+
+// reencrypt encrypts the plaintext emails and phones of the users, the emails of the security events and identities,
+// and re-encrypts the ones encrypted by retired keys with the active key.
+// It runs in batches next to the server, so a key can be retired once it is done.
+func main() {
+	batchSize := flag.Int("batch-size", 100, "number of rows read per batch")
+	pause := flag.Duration("pause", 100*time.Millisecond, "pause between batches to limit the load on the database")
+	flag.Parse()
+
+	// Create DB connection
+	dbConn, err := db.DBConnect(os.Getenv("DB_URL"))
+	if err != nil {
+		log.Fatal("DB connection error ", err)
+	}
+	fmt.Println("DB connection success")
+
+	keys, err := encryption.KeyRingFromEnv()
+	if err != nil {
+		log.Fatal("Encryption keys error ", err)
+	}
+
+	repo := repository.New(dbConn, keys)
+	ctx := context.Background()
+	tables := []struct {
+		name      string
+		reencrypt func(ctx context.Context, afterID int, limit int) (user.ReencryptResult, error)
+	}{
+		{name: "users", reencrypt: repo.User().ReencryptUsers},
+		{name: "security events", reencrypt: repo.SecurityEvent().ReencryptSecurityEvents},
+		{name: "identities", reencrypt: repo.Identity().ReencryptIdentities},
+	}
+	for _, table := range tables {
+		total := reencryptAll(ctx, table.name, table.reencrypt, *batchSize, *pause)
+		fmt.Printf("Re-encrypted %d %s\n", total, table.name)
+	}
+}
+
+// reencryptAll runs reencrypt batch by batch until the last row and returns the number of re-encrypted rows.
+func reencryptAll(ctx context.Context, name string, reencrypt func(ctx context.Context, afterID int, limit int) (user.ReencryptResult, error), batchSize int, pause time.Duration) int64 {
+	var lastID int
+	var total int64
+	for {
+		result, err := reencrypt(ctx, lastID, batchSize)
+		if err != nil {
+			log.Fatalf("Re-encryption error of %s after id %d: %v", name, lastID, err)
+		}
+		lastID = result.LastID
+		total += result.Reencrypted
+		if result.Scanned < batchSize {
+			return total
+		}
+		time.Sleep(pause)
+	}
+}
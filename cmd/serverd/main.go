@@ -8,6 +8,7 @@ import (
 
 	"github.com/vinhnv1/s3corp-golang-fresher/cmd/serverd/router"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
 )
 
 func main() {
@@ -18,8 +19,14 @@ func main() {
 	}
 	fmt.Println("DB connection success")
 
+	// Load the encryption keys once, they are shared by the repositories
+	keys, err := encryption.KeyRingFromEnv()
+	if err != nil {
+		log.Fatal("Encryption keys error ", err)
+	}
+
 	// Create routes
-	r := router.InitRouter(dbConn)
+	r := router.InitRouter(dbConn, keys)
 
 	fmt.Println("Server is running on port 5000")
 
@@ -16,11 +16,13 @@ import (
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/service/order"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
 )
 
 // InitRouter return all handler
-func InitRouter(db *sql.DB) *chi.Mux {
-	repo := repository.New(db)
+func InitRouter(db *sql.DB, keys *encryption.KeyRing) *chi.Mux {
+	repo := repository.New(db, keys)
 	userServ := user.New(repo)
 	productServ := product.New(repo)
 	orderServ := order.New(repo)
@@ -30,11 +32,12 @@ func InitRouter(db *sql.DB) *chi.Mux {
 
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
+	r.Use(v1.WithClient)
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", v1.APIKeyHeader, v1.OrganizationHeader},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
@@ -44,42 +47,170 @@ func InitRouter(db *sql.DB) *chi.Mux {
 		w.Write([]byte("OK"))
 	})
 
-	r.Handle("/api/v1/graphql", graphRouter(resolver))
+	r.Get("/.well-known/jwks.json", h.GetJWKS)
+	r.With(h.Authenticate).Handle("/api/v1/graphql", graphRouter(resolver))
 	r.Route("/api/v1", func(api chi.Router) {
+		api.Use(h.Authenticate)
 		api.Route("/products", productRouter(h))
+		api.Route("/categories", categoryRouter(h))
 		api.Route("/users", userRouter(h))
+		api.Route("/me", meRouter(h))
 		api.Route("/orders", orderRouter(h))
 		api.Route("/files", fileRouter(h))
-		api.Get("/statistics", h.GetStatistics)
+		api.Route("/roles", roleRouter(h))
+		api.Route("/organizations", organizationRouter(h))
+		api.With(v1.RequirePermission(auth.PermRoleRead)).Get("/permissions", h.GetPermissions)
+		api.With(v1.RequirePermission(auth.PermStatisticsRead)).Get("/statistics", h.GetStatistics)
 	})
 	return r
 }
 
 func productRouter(h v1.Handler) func(r chi.Router) {
 	return func(r chi.Router) {
-		r.Get("/export/csv", h.ExportProductsCSV)
 		r.Get("/{id}", h.GetProduct)
+		r.Get("/{id}/categories", h.GetProductCategories)
+		r.Get("/{id}/images", h.GetProductImages)
+		r.Get("/{id}/images/{imageID}/{size}", h.GetProductImageFile)
 		r.Get("/", h.GetProducts)
-		r.Post("/", h.CreateProduct)
-		r.Post("/import-csv", h.ImportProductCSV)
-		r.Put("/{id}", h.UpdateProduct)
-		r.Delete("/{id}", h.DeleteProduct)
+
+		r.Group(func(r chi.Router) {
+			r.Use(v1.RequireAuth)
+			r.Get("/export/csv", h.ExportProductsCSV)
+			r.Post("/", h.CreateProduct)
+			r.Post("/import-csv", h.ImportProductCSV)
+			r.Put("/{id}", h.UpdateProduct)
+			r.Put("/{id}/categories", h.UpdateProductCategories)
+			r.Put("/{id}/options", h.UpdateProductOptions)
+			r.Post("/{id}/variants", h.CreateVariant)
+			r.Put("/{id}/variants/{variantID}", h.UpdateVariant)
+			r.Delete("/{id}/variants/{variantID}", h.DeleteVariant)
+			r.Post("/{id}/images", h.UploadProductImage)
+			r.Put("/{id}/images/order", h.UpdateProductImageOrder)
+			r.Put("/{id}/images/{imageID}/primary", h.SetPrimaryProductImage)
+			r.Delete("/{id}/images/{imageID}", h.DeleteProductImage)
+			r.Delete("/{id}", h.DeleteProduct)
+		})
+	}
+}
+
+func categoryRouter(h v1.Handler) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", h.GetCategories)
+		r.Get("/{id}", h.GetCategory)
+
+		r.Group(func(r chi.Router) {
+			r.Use(v1.RequirePermission(auth.PermCategoryWrite))
+			r.Post("/", h.CreateCategory)
+			r.Put("/{id}", h.UpdateCategory)
+			r.Delete("/{id}", h.DeleteCategory)
+		})
 	}
 }
 
 func userRouter(h v1.Handler) func(r chi.Router) {
 	return func(r chi.Router) {
 		r.Post("/login", h.Login)
+		r.Post("/login/2fa", h.LoginTwoFactor)
+		r.Get("/oidc/login", h.StartOIDCLogin)
+		r.Get("/oidc/callback", h.OIDCCallback)
+		r.Post("/token/refresh", h.RefreshToken)
+		r.With(v1.RequireAuth).Post("/logout", h.Logout)
+		r.Post("/password/forgot", h.ForgotPassword)
+		r.Post("/password/reset", h.ResetPassword)
+		r.Get("/email/verify", h.VerifyEmail)
+		r.Post("/email/verify/resend", h.ResendVerificationEmail)
 		r.Post("/", h.CreateUser)
-		r.Get("/", h.GetUsers)
-		r.Get("/{id}", h.GetUser)
-		r.Put("/{id}", h.UpdateUser)
-		r.Delete("/{id}", h.DeleteUser)
+
+		r.Group(func(r chi.Router) {
+			r.Use(v1.RequireAuth)
+			r.Get("/api-keys", h.GetAPIKeys)
+			r.Post("/api-keys", h.CreateAPIKey)
+			r.Delete("/api-keys/{id}", h.RevokeAPIKey)
+		})
+
+		r.Route("/{id}/addresses", func(r chi.Router) {
+			r.Use(v1.RequireAuth)
+			r.Get("/", h.GetAddresses)
+			r.Post("/", h.CreateAddress)
+			r.Get("/{addressID}", h.GetAddress)
+			r.Put("/{addressID}", h.UpdateAddress)
+			r.Delete("/{addressID}", h.DeleteAddress)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(v1.RequirePermission(auth.PermUserRead))
+			r.Get("/", h.GetUsers)
+			r.Get("/deleted", h.GetDeletedUsers)
+			r.Get("/export/csv", h.ExportUsersCSV)
+			r.Get("/{id}", h.GetUser)
+			r.Get("/{id}/roles", h.GetUserRoles)
+			r.Get("/{id}/data-export", h.ExportUserData)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(v1.RequirePermission(auth.PermUserWrite))
+			r.Post("/import-csv", h.ImportUsersCSV)
+			r.Put("/{id}", h.UpdateUser)
+			r.Delete("/{id}", h.DeleteUser)
+			r.Post("/{id}/unlock", h.UnlockUser)
+			r.Post("/{id}/restore", h.RestoreUser)
+			r.Put("/{id}/roles", h.UpdateUserRoles)
+			r.Post("/{id}/erase", h.EraseUser)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(v1.RequirePermission(auth.PermTwoFactorWrite))
+			r.Post("/2fa/enroll", h.EnrollTwoFactor)
+			r.Post("/2fa/confirm", h.ConfirmTwoFactor)
+		})
+
+		r.With(v1.RequirePermission(auth.PermImpersonationRead)).Get("/impersonations", h.GetImpersonations)
+		r.With(v1.RequirePermission(auth.PermImpersonationWrite)).Post("/{id}/impersonate", h.StartImpersonation)
+		r.With(v1.RequirePermission(auth.PermDataRequestRead)).Get("/data-requests", h.GetDataRequests)
+		r.With(v1.RequirePermission(auth.PermSecurityEventReadAny)).Get("/security-events", h.GetSecurityEvents)
+	}
+}
+
+func meRouter(h v1.Handler) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Use(v1.RequireAuth)
+		r.Get("/", h.GetProfile)
+		r.Put("/", h.UpdateProfile)
+		r.Put("/password", h.ChangePassword)
+		r.Get("/organization", h.GetCurrentOrganization)
+		r.Get("/data-export", h.ExportCurrentUserData)
+		r.Get("/security-events", h.GetCurrentUserSecurityEvents)
+		r.Delete("/impersonation", h.EndImpersonation)
+	}
+}
+
+func organizationRouter(h v1.Handler) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.With(v1.RequirePermission(auth.PermOrganizationRead)).Get("/", h.GetOrganizations)
+		r.With(v1.RequirePermission(auth.PermOrganizationRead)).Get("/{id}", h.GetOrganization)
+		r.With(v1.RequirePermission(auth.PermOrganizationWrite)).Post("/", h.CreateOrganization)
+	}
+}
+
+func roleRouter(h v1.Handler) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(v1.RequirePermission(auth.PermRoleRead))
+			r.Get("/", h.GetRoles)
+			r.Get("/{id}", h.GetRole)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(v1.RequirePermission(auth.PermRoleWrite))
+			r.Post("/", h.CreateRole)
+			r.Put("/{id}", h.UpdateRole)
+			r.Delete("/{id}", h.DeleteRole)
+		})
 	}
 }
 func fileRouter(h v1.Handler) func(r chi.Router) {
 	return func(r chi.Router) {
-		r.Get("/{filename}", h.DownloadCSVFile)
+		r.With(v1.RequireAuth).Get("/{filename}", h.DownloadCSVFile)
 	}
 }
 
@@ -89,6 +220,7 @@ func graphRouter(resolver graph.Resolver) *handler.Server {
 
 func orderRouter(h v1.Handler) func(r chi.Router) {
 	return func(r chi.Router) {
+		r.Use(v1.RequireAuth)
 		r.Post("/", h.CreateOrder)
 		r.Get("/", h.GetOrders)
 	}
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "revoked_access_tokens";
+
+DROP TABLE IF EXISTS "refresh_tokens";
+
+END;
@@ -0,0 +1,28 @@
+-- Create tables refresh tokens and revoked access tokens and create indexes for them.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "refresh_tokens"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL,
+    "token_hash" TEXT NOT NULL,
+    "family_id" TEXT NOT NULL, -- all tokens rotated from the same login share a family
+    "expires_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "revoked_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "token_hash_on_refresh_tokens" ON "refresh_tokens"("token_hash");
+
+CREATE INDEX IF NOT EXISTS "family_id_on_refresh_tokens" ON "refresh_tokens"("family_id");
+
+CREATE TABLE IF NOT EXISTS "revoked_access_tokens"
+(
+    "jti" TEXT PRIMARY KEY,
+    "expires_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+END;
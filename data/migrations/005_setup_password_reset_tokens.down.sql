@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "sessions_revoked_at";
+
+DROP TABLE IF EXISTS "password_reset_tokens";
+
+END;
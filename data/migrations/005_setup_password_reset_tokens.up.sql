@@ -0,0 +1,21 @@
+-- Create table password reset tokens and add sessions revoked time to users.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "password_reset_tokens"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL,
+    "token_hash" TEXT NOT NULL,
+    "expires_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "used_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "token_hash_on_password_reset_tokens" ON "password_reset_tokens"("token_hash");
+
+-- Access tokens issued before this time are rejected
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "sessions_revoked_at" TIMESTAMP WITH TIME ZONE;
+
+END;
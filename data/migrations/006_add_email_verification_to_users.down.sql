@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "verification_sent_at";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "email_verified_at";
+
+END;
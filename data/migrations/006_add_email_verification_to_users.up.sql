@@ -0,0 +1,10 @@
+-- Add email verification columns to users, existing users are treated as verified.
+BEGIN;
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "email_verified_at" TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "verification_sent_at" TIMESTAMP WITH TIME ZONE;
+
+UPDATE "users" SET "email_verified_at" = NOW() WHERE "email_verified_at" IS NULL;
+
+END;
@@ -0,0 +1,18 @@
+-- Create table login failures to track failed login attempts per account and per IP address.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "login_failures"
+(
+    "id" SERIAL PRIMARY KEY,
+    "scope" VARCHAR(10) NOT NULL, -- ACCOUNT or IP
+    "key" VARCHAR(255) NOT NULL, -- the email for ACCOUNT, the IP address for IP
+    "failed_attempts" INT NOT NULL DEFAULT 0,
+    "last_failed_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "locked_until" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "scope_key_on_login_failures" ON "login_failures"("scope", "key");
+
+END;
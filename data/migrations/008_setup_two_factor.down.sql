@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "backup_codes";
+
+DROP TABLE IF EXISTS "totp_secrets";
+
+END;
@@ -0,0 +1,31 @@
+-- Create tables TOTP secrets and backup codes for two-factor authentication.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "totp_secrets"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL,
+    "secret" TEXT NOT NULL,
+    "confirmed_at" TIMESTAMP WITH TIME ZONE, -- two-factor authentication is enabled once confirmed
+    "last_used_step" BIGINT, -- the time step of the last accepted code, so a code cannot be reused
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "user_id_on_totp_secrets" ON "totp_secrets"("user_id");
+
+CREATE TABLE IF NOT EXISTS "backup_codes"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL,
+    "code_hash" TEXT NOT NULL,
+    "used_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "user_id_on_backup_codes" ON "backup_codes"("user_id");
+
+END;
@@ -0,0 +1,24 @@
+-- Create table api keys for machine-to-machine access and create indexes for it.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "api_keys"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    "prefix" VARCHAR(20) NOT NULL, -- the beginning of the key to recognize it, the key itself is not stored
+    "key_hash" TEXT NOT NULL,
+    "scope" VARCHAR(10) NOT NULL, -- read or write
+    "last_used_at" TIMESTAMP WITH TIME ZONE,
+    "expires_at" TIMESTAMP WITH TIME ZONE,
+    "revoked_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "key_hash_on_api_keys" ON "api_keys"("key_hash");
+
+CREATE INDEX IF NOT EXISTS "user_id_on_api_keys" ON "api_keys"("user_id");
+
+END;
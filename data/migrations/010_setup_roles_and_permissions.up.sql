@@ -0,0 +1,82 @@
+-- Create tables roles and permissions, seed the ADMIN and GUEST roles and map users onto them.
+-- users.role is the primary role of the user, user_roles assigns additional roles.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "roles"
+(
+    "id" SERIAL PRIMARY KEY,
+    "name" VARCHAR(50) NOT NULL,
+    "description" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "name_on_roles" ON "roles"("name");
+
+CREATE TABLE IF NOT EXISTS "permissions"
+(
+    "id" SERIAL PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL, -- resource:action or resource:action:any for resources of other users
+    "description" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "name_on_permissions" ON "permissions"("name");
+
+CREATE TABLE IF NOT EXISTS "role_permissions"
+(
+    "role_id" INT NOT NULL,
+    "permission_id" INT NOT NULL,
+    PRIMARY KEY ("role_id", "permission_id"),
+    FOREIGN KEY ("role_id") REFERENCES "roles"("id") ON DELETE CASCADE,
+    FOREIGN KEY ("permission_id") REFERENCES "permissions"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "user_roles"
+(
+    "user_id" INT NOT NULL,
+    "role_id" INT NOT NULL,
+    PRIMARY KEY ("user_id", "role_id"),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    FOREIGN KEY ("role_id") REFERENCES "roles"("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "role_id_on_user_roles" ON "user_roles"("role_id");
+
+INSERT INTO "permissions" ("name", "description") VALUES
+('user:read', 'Get users'),
+('user:write', 'Create, update, delete and unlock users and assign their roles'),
+('role:read', 'Get roles and permissions'),
+('role:write', 'Create, update and delete roles'),
+('product:write', 'Create, update, delete and import own products'),
+('product:write:any', 'Create, update, delete and import products of other users'),
+('order:read', 'Get own orders'),
+('order:read:any', 'Get orders of other users'),
+('order:write', 'Create own orders'),
+('order:write:any', 'Create orders for other users'),
+('statistics:read', 'Get statistics'),
+('api_key:write:any', 'Revoke API keys of other users')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "roles" ("name", "description") VALUES
+('ADMIN', 'Administrator'),
+('GUEST', 'Customer and seller')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT "roles"."id", "permissions"."id" FROM "roles", "permissions"
+WHERE "roles"."name" = 'ADMIN'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT "roles"."id", "permissions"."id" FROM "roles", "permissions"
+WHERE "roles"."name" = 'GUEST' AND "permissions"."name" IN ('product:write', 'order:read', 'order:write')
+ON CONFLICT DO NOTHING;
+
+-- Existing users keep their role as the primary role, any other value of the free-text column becomes a role without permissions
+INSERT INTO "roles" ("name")
+SELECT DISTINCT "role" FROM "users"
+ON CONFLICT ("name") DO NOTHING;
+
+END;
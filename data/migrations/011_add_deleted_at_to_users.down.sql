@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "deleted_at_on_users";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "deleted_at";
+
+END;
@@ -0,0 +1,8 @@
+-- Soft delete users, deleted users keep their products and orders and can be restored.
+BEGIN;
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS "deleted_at_on_users" ON "users" ("deleted_at");
+
+END;
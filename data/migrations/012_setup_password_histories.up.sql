@@ -0,0 +1,15 @@
+-- Create table password histories to keep the replaced password hashes, so the last passwords cannot be reused.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "password_histories"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL,
+    "password" TEXT NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "user_id_created_at_on_password_histories" ON "password_histories"("user_id", "created_at");
+
+END;
@@ -0,0 +1,20 @@
+-- Create table identities to link the users of an OpenID Connect issuer to users, and create indexes for it.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "identities"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL,
+    "issuer" TEXT NOT NULL,
+    "subject" VARCHAR(255) NOT NULL, -- the sub claim, it identifies the user at the issuer
+    "email" VARCHAR(255) NOT NULL, -- the email of the user at the issuer when the identity was linked
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "issuer_subject_on_identities" ON "identities"("issuer", "subject");
+
+CREATE INDEX IF NOT EXISTS "user_id_on_identities" ON "identities"("user_id");
+
+END;
@@ -0,0 +1,11 @@
+BEGIN;
+
+DELETE FROM "permissions" WHERE "name" IN ('organization:read', 'organization:write');
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "organization_id";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "organization_id";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "organization_id";
+
+DROP TABLE IF EXISTS "organizations";
+
+END;
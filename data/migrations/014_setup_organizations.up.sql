@@ -0,0 +1,39 @@
+-- Create table organizations and add the organization of users, products and orders, so several shops can run on one deployment.
+-- The existing data belongs to the default organization.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "organizations"
+(
+    "id" SERIAL PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "name_on_organizations" ON "organizations"("name");
+
+INSERT INTO "organizations" ("id", "name") VALUES (1, 'Default')
+ON CONFLICT DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('organizations', 'id'), (SELECT MAX("id") FROM "organizations"));
+
+-- users.organization_id is the membership of the user, the user can only access the data of this organization
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "organization_id" INT NOT NULL DEFAULT 1 REFERENCES "organizations"("id");
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "organization_id" INT NOT NULL DEFAULT 1 REFERENCES "organizations"("id");
+ALTER TABLE "orders" ADD COLUMN IF NOT EXISTS "organization_id" INT NOT NULL DEFAULT 1 REFERENCES "organizations"("id");
+
+CREATE INDEX IF NOT EXISTS "organization_id_on_users" ON "users"("organization_id");
+CREATE INDEX IF NOT EXISTS "organization_id_on_products" ON "products"("organization_id");
+CREATE INDEX IF NOT EXISTS "organization_id_on_orders" ON "orders"("organization_id");
+
+INSERT INTO "permissions" ("name", "description") VALUES
+('organization:read', 'Get organizations'),
+('organization:write', 'Create organizations')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT "roles"."id", "permissions"."id" FROM "roles", "permissions"
+WHERE "roles"."name" = 'ADMIN' AND "permissions"."name" IN ('organization:read', 'organization:write')
+ON CONFLICT DO NOTHING;
+
+END;
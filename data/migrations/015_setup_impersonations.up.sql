@@ -0,0 +1,22 @@
+-- Create table impersonations to audit the sessions in which an admin acts as another user, and create indexes for it.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "impersonations"
+(
+    "id" SERIAL PRIMARY KEY,
+    "actor_id" INT NOT NULL, -- the admin who impersonates
+    "subject_id" INT NOT NULL, -- the user who is impersonated
+    "token_id" VARCHAR(64) NOT NULL UNIQUE, -- the jti of the impersonation token
+    "reason" TEXT NOT NULL DEFAULT '',
+    "expires_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "ended_at" TIMESTAMP WITH TIME ZONE NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(), -- the start of the session
+    FOREIGN KEY ("actor_id") REFERENCES "users"("id"),
+    FOREIGN KEY ("subject_id") REFERENCES "users"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "actor_id_on_impersonations" ON "impersonations"("actor_id");
+
+CREATE INDEX IF NOT EXISTS "subject_id_on_impersonations" ON "impersonations"("subject_id");
+
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "order_addresses";
+
+DROP TABLE IF EXISTS "addresses";
+
+END;
@@ -0,0 +1,51 @@
+-- Create table addresses for the address book of users, and table order_addresses for the addresses of orders at purchase time.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "addresses"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL,
+    "name" TEXT NOT NULL, -- the recipient
+    "phone" TEXT NOT NULL,
+    "line1" TEXT NOT NULL,
+    "line2" TEXT NOT NULL DEFAULT '',
+    "city" TEXT NOT NULL,
+    "state" TEXT NOT NULL DEFAULT '',
+    "postal_code" TEXT NOT NULL DEFAULT '', -- empty for countries without postal codes
+    "country" CHAR(2) NOT NULL, -- ISO 3166-1 alpha-2 code
+    "is_default_shipping" BOOLEAN NOT NULL DEFAULT FALSE,
+    "is_default_billing" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "user_id_on_addresses" ON "addresses"("user_id");
+
+-- A user has at most one default shipping address and one default billing address
+CREATE UNIQUE INDEX IF NOT EXISTS "default_shipping_on_addresses" ON "addresses"("user_id") WHERE "is_default_shipping";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "default_billing_on_addresses" ON "addresses"("user_id") WHERE "is_default_billing";
+
+-- The addresses are copied to the order, so later changes of the address book do not change the order
+CREATE TABLE IF NOT EXISTS "order_addresses"
+(
+    "id" SERIAL PRIMARY KEY,
+    "order_id" INT NOT NULL,
+    "type" TEXT NOT NULL, -- SHIPPING, BILLING
+    "name" TEXT NOT NULL,
+    "phone" TEXT NOT NULL,
+    "line1" TEXT NOT NULL,
+    "line2" TEXT NOT NULL DEFAULT '',
+    "city" TEXT NOT NULL,
+    "state" TEXT NOT NULL DEFAULT '',
+    "postal_code" TEXT NOT NULL DEFAULT '',
+    "country" CHAR(2) NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("order_id") REFERENCES "orders"("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "order_id_type_on_order_addresses" ON "order_addresses"("order_id", "type");
+
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "erased_at";
+
+DROP TABLE IF EXISTS "data_requests";
+
+END;
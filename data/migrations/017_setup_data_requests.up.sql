@@ -0,0 +1,19 @@
+-- Create table data_requests to log the personal data exports and erasures of users, and mark the erased users.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "data_requests"
+(
+    "id" SERIAL PRIMARY KEY,
+    "actor_id" INT NOT NULL, -- the user who made the request
+    "subject_id" INT NOT NULL, -- the user whose personal data is exported or erased
+    "type" VARCHAR(10) NOT NULL, -- EXPORT or ERASURE
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("actor_id") REFERENCES "users"("id"),
+    FOREIGN KEY ("subject_id") REFERENCES "users"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "subject_id_on_data_requests" ON "data_requests"("subject_id");
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "erased_at" TIMESTAMP WITH TIME ZONE;
+
+END;
@@ -0,0 +1,20 @@
+-- Create table security_events to record the logins and the sensitive operations of users, and create indexes for it.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "security_events"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NULL, -- NULL for failed logins of unknown emails, no foreign key so the events are kept as they happened
+    "organization_id" INT NOT NULL,
+    "type" VARCHAR(30) NOT NULL, -- LOGIN_SUCCEEDED, LOGIN_FAILED, PASSWORD_CHANGED, ROLE_CHANGED or TOKEN_REVOKED
+    "email" VARCHAR(255) NOT NULL DEFAULT '', -- the email used to login
+    "ip_address" VARCHAR(45) NOT NULL DEFAULT '',
+    "user_agent" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "user_id_created_at_on_security_events" ON "security_events"("user_id", "created_at");
+
+CREATE INDEX IF NOT EXISTS "organization_id_created_at_on_security_events" ON "security_events"("organization_id", "created_at");
+
+END;
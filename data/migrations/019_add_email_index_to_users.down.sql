@@ -0,0 +1,8 @@
+-- The encrypted emails and phones are not decrypted, they cannot be read without the keys anymore.
+BEGIN;
+
+DROP INDEX IF EXISTS "email_index_on_users";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "email_index";
+
+END;
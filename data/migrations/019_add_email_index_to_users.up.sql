@@ -0,0 +1,9 @@
+-- The email and the phone of the users are encrypted by the application, the blind index of the email finds the users by email.
+-- Existing rows have no index until they are encrypted by the re-encryption command, email_on_users keeps their plaintext emails unique meanwhile.
+BEGIN;
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "email_index" VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "email_index_on_users" ON "users" ("email_index");
+
+END;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DELETE FROM "permissions" WHERE "name" = 'category:write';
+
+DROP TABLE IF EXISTS "product_categories";
+
+DROP TABLE IF EXISTS "categories";
+
+END;
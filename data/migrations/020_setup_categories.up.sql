@@ -0,0 +1,45 @@
+-- Create table categories for the category tree of the products, and table product_categories which assigns products to categories.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "categories"
+(
+    "id" SERIAL PRIMARY KEY,
+    "organization_id" INT NOT NULL,
+    "parent_id" INT NULL, -- NULL for the root categories
+    "name" VARCHAR(255) NOT NULL,
+    "slug" VARCHAR(255) NOT NULL,
+    "sort_order" INT NOT NULL DEFAULT 0, -- the order among the siblings
+    "path" TEXT NOT NULL DEFAULT '', -- the ids from the root to the category, e.g. /1/4/, the descendants are the categories with this prefix
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("organization_id") REFERENCES "organizations"("id"),
+    FOREIGN KEY ("parent_id") REFERENCES "categories"("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "organization_id_slug_on_categories" ON "categories"("organization_id", "slug");
+
+CREATE INDEX IF NOT EXISTS "parent_id_on_categories" ON "categories"("parent_id");
+
+CREATE INDEX IF NOT EXISTS "path_on_categories" ON "categories"("path" text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS "product_categories"
+(
+    "product_id" INT NOT NULL,
+    "category_id" INT NOT NULL,
+    PRIMARY KEY ("product_id", "category_id"),
+    FOREIGN KEY ("product_id") REFERENCES "products"("id") ON DELETE CASCADE,
+    FOREIGN KEY ("category_id") REFERENCES "categories"("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "category_id_on_product_categories" ON "product_categories"("category_id");
+
+INSERT INTO "permissions" ("name", "description") VALUES
+('category:write', 'Create, update and delete categories')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT "roles"."id", "permissions"."id" FROM "roles", "permissions"
+WHERE "roles"."name" = 'ADMIN' AND "permissions"."name" = 'category:write'
+ON CONFLICT DO NOTHING;
+
+END;
@@ -0,0 +1,15 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "order_id_product_id_variant_sku_on_order_items";
+CREATE UNIQUE INDEX IF NOT EXISTS "order_id_product_id_on_order_items" ON "order_items"("order_id", "product_id");
+
+ALTER TABLE "order_items" DROP COLUMN IF EXISTS "variant_sku";
+ALTER TABLE "order_items" DROP COLUMN IF EXISTS "variant_id";
+
+DROP TABLE IF EXISTS "product_variant_options";
+
+DROP TABLE IF EXISTS "product_variants";
+
+DROP TABLE IF EXISTS "product_options";
+
+END;
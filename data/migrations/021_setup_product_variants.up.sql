@@ -0,0 +1,55 @@
+-- Create tables product_options and product_variants for the variants of a product (e.g. the sizes and colours of a shirt),
+-- and let order items reference the variant which is ordered.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "product_options"
+(
+    "id" SERIAL PRIMARY KEY,
+    "product_id" INT NOT NULL,
+    "name" VARCHAR(255) NOT NULL, -- e.g. Size, Colour
+    "position" INT NOT NULL DEFAULT 0, -- the order of the options of the product
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("product_id") REFERENCES "products"("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "product_id_name_on_product_options" ON "product_options"("product_id", "name");
+
+CREATE TABLE IF NOT EXISTS "product_variants"
+(
+    "id" SERIAL PRIMARY KEY,
+    "organization_id" INT NOT NULL DEFAULT 1,
+    "product_id" INT NOT NULL,
+    "sku" VARCHAR(255) NOT NULL,
+    "price" FLOAT NULL, -- NULL for the price of the product
+    "quantity" INT NOT NULL DEFAULT 0, -- the stock of the variant
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("organization_id") REFERENCES "organizations"("id"),
+    FOREIGN KEY ("product_id") REFERENCES "products"("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "organization_id_sku_on_product_variants" ON "product_variants"("organization_id", "sku");
+
+CREATE INDEX IF NOT EXISTS "product_id_on_product_variants" ON "product_variants"("product_id");
+
+-- product_variant_options has the value of each option of the product for the variant, e.g. Size M
+CREATE TABLE IF NOT EXISTS "product_variant_options"
+(
+    "variant_id" INT NOT NULL,
+    "option_id" INT NOT NULL,
+    "value" VARCHAR(255) NOT NULL,
+    PRIMARY KEY ("variant_id", "option_id"),
+    FOREIGN KEY ("variant_id") REFERENCES "product_variants"("id") ON DELETE CASCADE,
+    FOREIGN KEY ("option_id") REFERENCES "product_options"("id") ON DELETE CASCADE
+);
+
+-- The variant is kept in the order as a snapshot of its sku, the order item is kept if the variant is deleted
+ALTER TABLE "order_items" ADD COLUMN IF NOT EXISTS "variant_id" INT NULL REFERENCES "product_variants"("id") ON DELETE SET NULL;
+ALTER TABLE "order_items" ADD COLUMN IF NOT EXISTS "variant_sku" VARCHAR(255) NOT NULL DEFAULT '';
+
+-- The same product can be ordered in several variants
+DROP INDEX IF EXISTS "order_id_product_id_on_order_items";
+CREATE UNIQUE INDEX IF NOT EXISTS "order_id_product_id_variant_sku_on_order_items" ON "order_items"("order_id", "product_id", "variant_sku");
+
+END;
@@ -0,0 +1,29 @@
+-- Create table product_images for the images of a product, the files are kept in the storage and the table has their keys
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "product_images"
+(
+    "id" SERIAL PRIMARY KEY,
+    "organization_id" INT NOT NULL DEFAULT 1,
+    "product_id" INT NOT NULL,
+    "storage_key" VARCHAR(255) NOT NULL, -- the prefix of the keys of the original image and its thumbnails
+    "content_type" VARCHAR(50) NOT NULL, -- the sniffed type of the original image, e.g. image/png
+    "size" INT NOT NULL, -- the size of the original image in bytes
+    "width" INT NOT NULL,
+    "height" INT NOT NULL,
+    "position" INT NOT NULL DEFAULT 0, -- the order of the images of the product
+    "is_primary" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("organization_id") REFERENCES "organizations"("id"),
+    FOREIGN KEY ("product_id") REFERENCES "products"("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "storage_key_on_product_images" ON "product_images"("storage_key");
+
+CREATE INDEX IF NOT EXISTS "product_id_position_on_product_images" ON "product_images"("product_id", "position");
+
+-- A product has at most one primary image
+CREATE UNIQUE INDEX IF NOT EXISTS "product_id_on_product_images_where_is_primary" ON "product_images"("product_id") WHERE "is_primary";
+
+END;
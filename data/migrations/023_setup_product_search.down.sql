@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "search_vector_on_products";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "search_vector";
+
+END;
@@ -0,0 +1,12 @@
+-- Add the full-text search vector of the title and the description of products, the title weighs more than the description.
+-- The text search configuration must match searchConfig of internal/repository/product.
+BEGIN;
+
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "search_vector" TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english'::regconfig, coalesce("title", '')), 'A') ||
+    setweight(to_tsvector('english'::regconfig, coalesce("description", '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS "search_vector_on_products" ON "products" USING GIN ("search_vector");
+
+END;
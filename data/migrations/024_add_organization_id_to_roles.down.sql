@@ -0,0 +1,20 @@
+-- The roles of the other organizations are merged into the roles of the default organization with the same name.
+BEGIN;
+
+INSERT INTO "roles" ("organization_id", "name", "description")
+SELECT DISTINCT ON ("name") 1, "name", "description" FROM "roles" WHERE "organization_id" <> 1
+ON CONFLICT DO NOTHING;
+
+UPDATE "user_roles" SET "role_id" = "roles"."id"
+FROM "roles" "copies", "roles"
+WHERE "copies"."id" = "user_roles"."role_id" AND "copies"."organization_id" <> 1
+    AND "roles"."organization_id" = 1 AND "roles"."name" = "copies"."name";
+
+DELETE FROM "roles" WHERE "organization_id" <> 1;
+
+DROP INDEX IF EXISTS "organization_id_name_on_roles";
+CREATE UNIQUE INDEX IF NOT EXISTS "name_on_roles" ON "roles"("name");
+
+ALTER TABLE "roles" DROP COLUMN IF EXISTS "organization_id";
+
+END;
@@ -0,0 +1,32 @@
+-- Add the organization of roles, so the admins of an organization manage the roles of their organization only.
+-- The existing roles belong to the default organization, every other organization gets a copy of them.
+-- organization:read and organization:write are only kept by the roles of the default organization, which operates the platform.
+BEGIN;
+
+ALTER TABLE "roles" ADD COLUMN IF NOT EXISTS "organization_id" INT NOT NULL DEFAULT 1 REFERENCES "organizations"("id") ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS "name_on_roles";
+CREATE UNIQUE INDEX IF NOT EXISTS "organization_id_name_on_roles" ON "roles"("organization_id", "name");
+
+INSERT INTO "roles" ("organization_id", "name", "description")
+SELECT "organizations"."id", "roles"."name", "roles"."description" FROM "organizations", "roles"
+WHERE "organizations"."id" <> 1 AND "roles"."organization_id" = 1
+ON CONFLICT DO NOTHING;
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT "copies"."id", "role_permissions"."permission_id"
+FROM "roles" "copies"
+JOIN "roles" ON "roles"."organization_id" = 1 AND "roles"."name" = "copies"."name"
+JOIN "role_permissions" ON "role_permissions"."role_id" = "roles"."id"
+JOIN "permissions" ON "permissions"."id" = "role_permissions"."permission_id"
+WHERE "copies"."organization_id" <> 1 AND "permissions"."name" NOT IN ('organization:read', 'organization:write')
+ON CONFLICT DO NOTHING;
+
+-- The additional roles of the users of other organizations are moved to the copies in their organization
+UPDATE "user_roles" SET "role_id" = "copies"."id"
+FROM "users", "roles", "roles" "copies"
+WHERE "users"."id" = "user_roles"."user_id" AND "roles"."id" = "user_roles"."role_id"
+    AND "copies"."organization_id" = "users"."organization_id" AND "copies"."name" = "roles"."name"
+    AND "roles"."organization_id" <> "users"."organization_id";
+
+END;
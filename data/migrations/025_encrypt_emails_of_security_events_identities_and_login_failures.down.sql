@@ -0,0 +1,12 @@
+-- The encrypted emails are not decrypted, the ones longer than VARCHAR(255) are cleared since they cannot be read without the keys anymore.
+BEGIN;
+
+DELETE FROM "login_failures" WHERE "scope" = 'ACCOUNT';
+
+UPDATE "security_events" SET "email" = '' WHERE LENGTH("email") > 255;
+ALTER TABLE "security_events" ALTER COLUMN "email" TYPE VARCHAR(255);
+
+DELETE FROM "identities" WHERE LENGTH("email") > 255;
+ALTER TABLE "identities" ALTER COLUMN "email" TYPE VARCHAR(255);
+
+END;
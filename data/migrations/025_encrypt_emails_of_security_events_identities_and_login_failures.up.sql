@@ -0,0 +1,13 @@
+-- The emails of the security events and the identities are encrypted by the application, the encrypted values do not fit in VARCHAR(255).
+-- Existing rows keep their plaintext emails until they are encrypted by the re-encryption command.
+-- The keys of the account login failures are the blind indexes of the emails, the plaintext keys cannot be hashed in SQL,
+-- so the failures are cleared and the lockouts of the accounts restart.
+BEGIN;
+
+ALTER TABLE "security_events" ALTER COLUMN "email" TYPE TEXT;
+
+ALTER TABLE "identities" ALTER COLUMN "email" TYPE TEXT;
+
+DELETE FROM "login_failures" WHERE "scope" = 'ACCOUNT';
+
+END;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DELETE FROM "permissions" WHERE "name" IN ('two_factor:write', 'impersonation:read', 'impersonation:write', 'data_request:read', 'security_event:read:any');
+
+END;
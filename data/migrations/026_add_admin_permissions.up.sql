@@ -0,0 +1,18 @@
+-- Add the permissions of the admin APIs which were limited to the ADMIN role, and grant them to the ADMIN roles of all organizations.
+BEGIN;
+
+INSERT INTO "permissions" ("name", "description") VALUES
+('two_factor:write', 'Enroll in two-factor authentication'),
+('impersonation:read', 'Get impersonation sessions'),
+('impersonation:write', 'Impersonate other users'),
+('data_request:read', 'Get data export and erasure requests'),
+('security_event:read:any', 'Get security events of other users')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT "roles"."id", "permissions"."id" FROM "roles", "permissions"
+WHERE "roles"."name" = 'ADMIN'
+    AND "permissions"."name" IN ('two_factor:write', 'impersonation:read', 'impersonation:write', 'data_request:read', 'security_event:read:any')
+ON CONFLICT DO NOTHING;
+
+END;
@@ -16,6 +16,10 @@ var (
 	errInvalidLimit        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_limit", Desc: "limit is invalid"}
 	errInvalidID           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_id", Desc: "id is invalid"}
 	errInvalidPriceRange   = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_price_range", Desc: "price range is invalid"}
+	errInvalidSearchQuery  = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_search_query", Desc: "search query must be at most 256 characters"}
+	errInvalidPriceBuckets = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_price_buckets", Desc: "price buckets must be at most 20 ascending non-negative prices"}
 	errInvalidOrderBy      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_order_by", Desc: "order by is invalid"}
+	errProductNotFound     = utils.ErrorResponse{Status: http.StatusNotFound, Code: "product_not_found", Desc: "product not found"}
+	errPermissionDenied    = utils.ErrorResponse{Status: http.StatusForbidden, Code: "permission_denied", Desc: "permission denied"}
 	errInternalServerError = utils.ErrorResponse{Status: http.StatusInternalServerError, Code: "internal_server_error", Desc: "internal server error"}
 )
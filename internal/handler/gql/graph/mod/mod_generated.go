@@ -9,6 +9,13 @@ import (
 	"time"
 )
 
+type CategoryCount struct {
+	CategoryID   int    `json:"categoryID"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	ProductCount int64  `json:"productCount"`
+}
+
 type CreateProductInput struct {
 	Title       string     `json:"title"`
 	Description *string    `json:"description"`
@@ -19,18 +26,26 @@ type CreateProductInput struct {
 }
 
 type GetProductsInput struct {
-	ID         *int             `json:"id"`
-	Title      *string          `json:"title"`
-	PriceRange *PriceRange      `json:"priceRange"`
-	IsActive   *bool            `json:"isActive"`
-	UserID     *int             `json:"userID"`
-	OrderBy    *OrderBy         `json:"orderBy"`
-	Pagination *PaginationInput `json:"pagination"`
+	ID           *int             `json:"id"`
+	Title        *string          `json:"title"`
+	Q            *string          `json:"q"`
+	PriceRange   *PriceRange      `json:"priceRange"`
+	IsActive     *bool            `json:"isActive"`
+	UserID       *int             `json:"userID"`
+	OrderBy      *OrderBy         `json:"orderBy"`
+	Pagination   *PaginationInput `json:"pagination"`
+	PriceBuckets []float64        `json:"priceBuckets"`
 }
 
 type GetProductsOutput struct {
-	Products   []*Product  `json:"products"`
-	Pagination *Pagination `json:"pagination"`
+	Products   []*Product     `json:"products"`
+	Pagination *Pagination    `json:"pagination"`
+	Facets     *ProductFacets `json:"facets"`
+}
+
+type IsActiveCount struct {
+	Active   int64 `json:"active"`
+	Inactive int64 `json:"inactive"`
 }
 
 type OrderBy struct {
@@ -51,21 +66,69 @@ type PaginationInput struct {
 	Limit *int `json:"limit"`
 }
 
+type PriceBucket struct {
+	From         float64  `json:"from"`
+	To           *float64 `json:"to"`
+	ProductCount int64    `json:"productCount"`
+}
+
 type PriceRange struct {
 	MinPrice float64 `json:"minPrice"`
 	MaxPrice float64 `json:"maxPrice"`
 }
 
 type Product struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Quantity    int       `json:"quantity"`
-	IsActive    bool      `json:"isActive"`
-	UserID      int       `json:"userID"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          int               `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Price       float64           `json:"price"`
+	Quantity    int               `json:"quantity"`
+	IsActive    bool              `json:"isActive"`
+	UserID      int               `json:"userID"`
+	Options     []*ProductOption  `json:"options"`
+	Variants    []*ProductVariant `json:"variants"`
+	Highlight   *ProductHighlight `json:"highlight"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+type ProductFacets struct {
+	PriceBuckets []*PriceBucket   `json:"priceBuckets"`
+	Sellers      []*SellerCount   `json:"sellers"`
+	IsActive     *IsActiveCount   `json:"isActive"`
+	Categories   []*CategoryCount `json:"categories"`
+}
+
+type ProductHighlight struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type ProductOption struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type ProductVariant struct {
+	ID        int              `json:"id"`
+	Sku       string           `json:"sku"`
+	Price     *float64         `json:"price"`
+	Quantity  int              `json:"quantity"`
+	Options   []*VariantOption `json:"options"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+type SellerCount struct {
+	UserID       int    `json:"userID"`
+	Name         string `json:"name"`
+	ProductCount int64  `json:"productCount"`
+}
+
+type VariantOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 type ActiveType string
@@ -5,8 +5,12 @@ package graph
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/volatiletech/null/v8"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/handler/gql/graph/mod"
@@ -43,6 +47,8 @@ func (m *mutationResolver) CreateProduct(ctx context.Context, input mod.CreatePr
 		switch err {
 		case productServ.ErrUserNotExist:
 			return nil, errUserNotExist
+		case productServ.ErrPermissionDenied:
+			return nil, errPermissionDenied
 		default:
 			return nil, errInternalServerError
 		}
@@ -56,6 +62,10 @@ func (m *mutationResolver) CreateProduct(ctx context.Context, input mod.CreatePr
 		Quantity:    result.Quantity,
 		IsActive:    result.IsActive,
 		UserID:      result.UserID,
+		Options:     []*mod.ProductOption{},
+		Variants:    []*mod.ProductVariant{},
+		CreatedAt:   result.CreatedAt,
+		UpdatedAt:   result.UpdatedAt,
 	}, nil
 
 }
@@ -141,6 +151,14 @@ func validGetProductsInput(input mod.GetProductsInput) (productServ.GetProductsI
 		servInput.Title = strings.TrimSpace(*input.Title)
 	}
 
+	// Validate search query if any
+	if input.Q != nil {
+		servInput.Query = strings.TrimSpace(*input.Q)
+		if utf8.RuneCountInString(servInput.Query) > productServ.MaxSearchQueryLength {
+			return productServ.GetProductsInput{}, errInvalidSearchQuery
+		}
+	}
+
 	// Validate price range filter field if any
 	if input.PriceRange != nil {
 		if (input.PriceRange.MinPrice != 0 || input.PriceRange.MaxPrice != 0) &&
@@ -202,6 +220,17 @@ func validGetProductsInput(input mod.GetProductsInput) (productServ.GetProductsI
 		}
 	}
 
+	// Validate price buckets if any
+	if len(input.PriceBuckets) > productServ.MaxPriceBuckets {
+		return productServ.GetProductsInput{}, errInvalidPriceBuckets
+	}
+	for i, bound := range input.PriceBuckets {
+		if bound < 0 || (i > 0 && bound <= input.PriceBuckets[i-1]) {
+			return productServ.GetProductsInput{}, errInvalidPriceBuckets
+		}
+	}
+	servInput.PriceBuckets = input.PriceBuckets
+
 	// Validate pagination
 	if input.Pagination != nil {
 		pageArgs, err := validateProductPagination(*input.Pagination)
@@ -233,6 +262,16 @@ func (q *queryResolver) GetProducts(ctx context.Context, input mod.GetProductsIn
 		return &mod.GetProductsOutput{}, err
 	}
 
+	// Count the filtered products of each price bucket, seller, status and category, only if the facets are selected
+	var facets *mod.ProductFacets
+	if isFieldSelected(ctx, "facets") {
+		productFacets, err := q.productServ.GetProductFacets(ctx, getProductsInput)
+		if err != nil {
+			return &mod.GetProductsOutput{}, err
+		}
+		facets = toFacetsOutput(productFacets)
+	}
+
 	productsOutput := make([]*mod.Product, len(products))
 	for i, p := range products {
 		productsOutput[i] = &mod.Product{
@@ -243,9 +282,14 @@ func (q *queryResolver) GetProducts(ctx context.Context, input mod.GetProductsIn
 			Quantity:    p.Quantity,
 			IsActive:    p.IsActive,
 			UserID:      p.User.ID,
+			Options:     toOptionsOutput(p.Options),
+			Variants:    toVariantsOutput(p.Variants),
 			CreatedAt:   p.CreatedAt,
 			UpdatedAt:   p.UpdatedAt,
 		}
+		if p.Highlight != nil {
+			productsOutput[i].Highlight = &mod.ProductHighlight{Title: p.Highlight.Title, Description: p.Highlight.Description}
+		}
 	}
 	return &mod.GetProductsOutput{
 		Products: productsOutput,
@@ -254,5 +298,104 @@ func (q *queryResolver) GetProducts(ctx context.Context, input mod.GetProductsIn
 			Limit:       &getProductsInput.Pagination.Limit,
 			TotalCount:  &totalCount,
 		},
+		Facets: facets,
+	}, nil
+}
+
+// isFieldSelected returns true if the query selects the field of the result of the resolver
+func isFieldSelected(ctx context.Context, name string) bool {
+	for _, field := range graphql.CollectFieldsCtx(ctx, nil) {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// toFacetsOutput converts the facets of the service to the output
+func toFacetsOutput(facets productServ.ProductFacets) *mod.ProductFacets {
+	result := &mod.ProductFacets{
+		PriceBuckets: make([]*mod.PriceBucket, len(facets.PriceBuckets)),
+		Sellers:      make([]*mod.SellerCount, len(facets.Sellers)),
+		IsActive:     &mod.IsActiveCount{Active: facets.Active, Inactive: facets.Inactive},
+		Categories:   make([]*mod.CategoryCount, len(facets.Categories)),
+	}
+	for i, b := range facets.PriceBuckets {
+		result.PriceBuckets[i] = &mod.PriceBucket{From: b.From, To: b.To.Ptr(), ProductCount: b.ProductCount}
+	}
+	for i, s := range facets.Sellers {
+		result.Sellers[i] = &mod.SellerCount{UserID: s.UserID, Name: s.Name, ProductCount: s.ProductCount}
+	}
+	for i, c := range facets.Categories {
+		result.Categories[i] = &mod.CategoryCount{
+			CategoryID:   c.CategoryID,
+			Name:         c.Name,
+			Slug:         c.Slug,
+			ProductCount: c.ProductCount,
+		}
+	}
+	return result
+}
+
+// GetProduct is the resolver for the GetProduct field.
+func (q *queryResolver) GetProduct(ctx context.Context, id int) (*mod.Product, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+
+	// 1. Get the product
+	product, err := q.productServ.GetProduct(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errProductNotFound
+	} else if err != nil {
+		return nil, errInternalServerError
+	}
+
+	// 2. Get the options and the variants of the product
+	variants, err := q.productServ.GetProductVariants(ctx, []int{id})
+	if err != nil {
+		return nil, errInternalServerError
+	}
+
+	return &mod.Product{
+		ID:          product.ID,
+		Title:       product.Title,
+		Description: product.Description,
+		Price:       product.Price,
+		Quantity:    product.Quantity,
+		IsActive:    product.IsActive,
+		UserID:      product.UserID,
+		Options:     toOptionsOutput(variants[id].Options),
+		Variants:    toVariantsOutput(variants[id].Variants),
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
 	}, nil
 }
+
+func toOptionsOutput(options []productServ.Option) []*mod.ProductOption {
+	result := make([]*mod.ProductOption, len(options))
+	for i, o := range options {
+		result[i] = &mod.ProductOption{ID: o.ID, Name: o.Name, Position: o.Position}
+	}
+	return result
+}
+
+func toVariantsOutput(variants []productServ.Variant) []*mod.ProductVariant {
+	result := make([]*mod.ProductVariant, len(variants))
+	for i, v := range variants {
+		options := make([]*mod.VariantOption, len(v.Options))
+		for j, o := range v.Options {
+			options[j] = &mod.VariantOption{Name: o.Name, Value: o.Value}
+		}
+		result[i] = &mod.ProductVariant{
+			ID:        v.ID,
+			Sku:       v.SKU,
+			Price:     v.Price.Ptr(),
+			Quantity:  v.Quantity,
+			Options:   options,
+			CreatedAt: v.CreatedAt,
+			UpdatedAt: v.UpdatedAt,
+		}
+	}
+	return result
+}
@@ -2,8 +2,12 @@ package graph
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 	"testing"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/volatiletech/null/v8"
 
 	"github.com/stretchr/testify/require"
@@ -65,6 +69,8 @@ func TestProductResolver_CreateProduct(t *testing.T) {
 					Quantity: 100,
 					IsActive: false,
 					UserID:   2,
+					Options:  []*mod.ProductOption{},
+					Variants: []*mod.ProductVariant{},
 				},
 			},
 		},
@@ -184,13 +190,22 @@ func TestProductResolver_GetProducts(t *testing.T) {
 		products   []productServ.ProductItem
 		err        error
 		totalCount int64
+		facets     productServ.ProductFacets
 	}
 
 	type givenData struct {
 		input mod.GetProductsInput
-		mock  mockData
+		// withoutFacets is true if the query does not select the facets
+		withoutFacets bool
+		mock          mockData
 	}
 
+	noFacets := &mod.ProductFacets{
+		PriceBuckets: []*mod.PriceBucket{},
+		Sellers:      []*mod.SellerCount{},
+		IsActive:     &mod.IsActiveCount{},
+		Categories:   []*mod.CategoryCount{},
+	}
 	tcs := map[string]struct {
 		given     givenData
 		expResult mod.GetProductsOutput
@@ -257,6 +272,15 @@ func TestProductResolver_GetProducts(t *testing.T) {
 						},
 					},
 					totalCount: 2,
+					facets: productServ.ProductFacets{
+						PriceBuckets: []productServ.PriceBucket{
+							{From: 0, To: null.Float64From(100000), ProductCount: 2},
+							{From: 100000, ProductCount: 0},
+						},
+						Sellers:    []productServ.SellerCount{{UserID: 1, Name: "admin", ProductCount: 2}},
+						Active:     2,
+						Categories: []productServ.CategoryCount{{CategoryID: 3, Name: "Phones", Slug: "phones", ProductCount: 2}},
+					},
 				},
 			},
 			expResult: mod.GetProductsOutput{
@@ -269,6 +293,8 @@ func TestProductResolver_GetProducts(t *testing.T) {
 						Quantity:    10,
 						UserID:      1,
 						IsActive:    true,
+						Options:     []*mod.ProductOption{},
+						Variants:    []*mod.ProductVariant{},
 					},
 					{
 						ID:          2,
@@ -278,6 +304,8 @@ func TestProductResolver_GetProducts(t *testing.T) {
 						Quantity:    10,
 						UserID:      1,
 						IsActive:    true,
+						Options:     []*mod.ProductOption{},
+						Variants:    []*mod.ProductVariant{},
 					},
 				},
 				Pagination: &mod.Pagination{
@@ -285,8 +313,136 @@ func TestProductResolver_GetProducts(t *testing.T) {
 					Limit:       intToPtr(20),
 					TotalCount:  int64ToPtr(2),
 				},
+				Facets: &mod.ProductFacets{
+					PriceBuckets: []*mod.PriceBucket{
+						{From: 0, To: null.Float64From(100000).Ptr(), ProductCount: 2},
+						{From: 100000, ProductCount: 0},
+					},
+					Sellers:    []*mod.SellerCount{{UserID: 1, Name: "admin", ProductCount: 2}},
+					IsActive:   &mod.IsActiveCount{Active: 2},
+					Categories: []*mod.CategoryCount{{CategoryID: 3, Name: "Phones", Slug: "phones", ProductCount: 2}},
+				},
+			},
+		},
+		"without_facets": {
+			given: givenData{
+				input:         mod.GetProductsInput{},
+				withoutFacets: true,
+				mock: mockData{
+					input: productServ.GetProductsInput{
+						Pagination: productServ.Pagination{
+							Limit: 20,
+							Page:  1,
+						},
+					},
+					products: []productServ.ProductItem{
+						{
+							ID:          1,
+							Title:       "test",
+							Description: "test",
+							Price:       10000,
+							Quantity:    10,
+							IsActive:    true,
+							User:        productServ.CreatedBy{ID: 1},
+						},
+					},
+					totalCount: 1,
+				},
+			},
+			expResult: mod.GetProductsOutput{
+				Products: []*mod.Product{
+					{
+						ID:          1,
+						Title:       "test",
+						Description: "test",
+						Price:       10000,
+						Quantity:    10,
+						UserID:      1,
+						IsActive:    true,
+						Options:     []*mod.ProductOption{},
+						Variants:    []*mod.ProductVariant{},
+					},
+				},
+				Pagination: &mod.Pagination{
+					CurrentPage: intToPtr(1),
+					Limit:       intToPtr(20),
+					TotalCount:  int64ToPtr(1),
+				},
 			},
 		},
+		"search": {
+			given: givenData{
+				input: mod.GetProductsInput{
+					Q: stringToPtr(" red shirt "),
+				},
+				mock: mockData{
+					input: productServ.GetProductsInput{
+						Query: "red shirt",
+						Pagination: productServ.Pagination{
+							Limit: 20,
+							Page:  1,
+						},
+					},
+					products: []productServ.ProductItem{
+						{
+							ID:          1,
+							Title:       "Red shirt",
+							Description: "A red cotton shirt",
+							Price:       100,
+							Quantity:    10,
+							IsActive:    true,
+							User:        productServ.CreatedBy{ID: 1},
+							Highlight: &productServ.Highlight{
+								Title:       "<mark>Red</mark> <mark>shirt</mark>",
+								Description: "A <mark>red</mark> cotton <mark>shirt</mark>",
+							},
+						},
+					},
+					totalCount: 1,
+				},
+			},
+			expResult: mod.GetProductsOutput{
+				Products: []*mod.Product{
+					{
+						ID:          1,
+						Title:       "Red shirt",
+						Description: "A red cotton shirt",
+						Price:       100,
+						Quantity:    10,
+						UserID:      1,
+						IsActive:    true,
+						Options:     []*mod.ProductOption{},
+						Variants:    []*mod.ProductVariant{},
+						Highlight: &mod.ProductHighlight{
+							Title:       "<mark>Red</mark> <mark>shirt</mark>",
+							Description: "A <mark>red</mark> cotton <mark>shirt</mark>",
+						},
+					},
+				},
+				Pagination: &mod.Pagination{
+					CurrentPage: intToPtr(1),
+					Limit:       intToPtr(20),
+					TotalCount:  int64ToPtr(1),
+				},
+				Facets: noFacets,
+			},
+		},
+		"invalid_search_query": {
+			given: givenData{
+				input: mod.GetProductsInput{
+					Q: stringToPtr(strings.Repeat("a", 257)),
+				},
+			},
+			expErr: errInvalidSearchQuery,
+		},
+		"invalid_price_buckets": {
+			given: givenData{
+				input: mod.GetProductsInput{
+					PriceBuckets: []float64{0, 50, 10},
+				},
+			},
+			expErr: errInvalidPriceBuckets,
+		},
 		"invalid_id": {
 			given: givenData{
 				input: mod.GetProductsInput{
@@ -376,6 +532,8 @@ func TestProductResolver_GetProducts(t *testing.T) {
 						Quantity:    10,
 						UserID:      1,
 						IsActive:    true,
+						Options:     []*mod.ProductOption{},
+						Variants:    []*mod.ProductVariant{},
 					},
 					{
 						ID:          2,
@@ -385,6 +543,8 @@ func TestProductResolver_GetProducts(t *testing.T) {
 						Quantity:    10,
 						UserID:      1,
 						IsActive:    true,
+						Options:     []*mod.ProductOption{},
+						Variants:    []*mod.ProductVariant{},
 					},
 				},
 				Pagination: &mod.Pagination{
@@ -392,6 +552,7 @@ func TestProductResolver_GetProducts(t *testing.T) {
 					Limit:       intToPtr(20),
 					TotalCount:  int64ToPtr(2),
 				},
+				Facets: noFacets,
 			},
 		},
 		"pagination:": {
@@ -471,6 +632,8 @@ func TestProductResolver_GetProducts(t *testing.T) {
 						Quantity:    10,
 						UserID:      1,
 						IsActive:    true,
+						Options:     []*mod.ProductOption{},
+						Variants:    []*mod.ProductVariant{},
 					},
 					{
 						ID:          2,
@@ -480,6 +643,8 @@ func TestProductResolver_GetProducts(t *testing.T) {
 						Quantity:    10,
 						UserID:      1,
 						IsActive:    true,
+						Options:     []*mod.ProductOption{},
+						Variants:    []*mod.ProductVariant{},
 					},
 				},
 				Pagination: &mod.Pagination{
@@ -487,6 +652,7 @@ func TestProductResolver_GetProducts(t *testing.T) {
 					Limit:       intToPtr(20),
 					TotalCount:  int64ToPtr(2),
 				},
+				Facets: noFacets,
 			},
 		},
 	}
@@ -494,12 +660,18 @@ func TestProductResolver_GetProducts(t *testing.T) {
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
 			// GIVEN
+			fields := []string{"products", "pagination", "facets"}
+			if tc.given.withoutFacets {
+				fields = fields[:2]
+			}
+			ctx := selectedFieldsContext(fields...)
 			serviceMock := new(productServ.Mock)
-			serviceMock.On("GetProducts", context.Background(), tc.given.mock.input).Return(tc.given.mock.products, tc.given.mock.totalCount, tc.given.mock.err)
+			serviceMock.On("GetProducts", ctx, tc.given.mock.input).Return(tc.given.mock.products, tc.given.mock.totalCount, tc.given.mock.err)
+			serviceMock.On("GetProductFacets", ctx, tc.given.mock.input).Return(tc.given.mock.facets, nil)
 			resolver := NewResolver(nil, serviceMock)
 
 			// WHEN
-			result, err := resolver.Query().GetProducts(context.Background(), tc.given.input)
+			result, err := resolver.Query().GetProducts(ctx, tc.given.input)
 
 			// THEN
 			if tc.expErr != nil {
@@ -512,7 +684,78 @@ func TestProductResolver_GetProducts(t *testing.T) {
 				require.Equal(t, *tc.expResult.Pagination.CurrentPage, *result.Pagination.CurrentPage)
 				require.Equal(t, *tc.expResult.Pagination.Limit, *result.Pagination.Limit)
 				require.Equal(t, *tc.expResult.Pagination.TotalCount, *result.Pagination.TotalCount)
+				require.Equal(t, tc.expResult.Facets, result.Facets)
+				if tc.given.withoutFacets {
+					serviceMock.AssertNotCalled(t, "GetProductFacets", ctx, tc.given.mock.input)
+				}
+			}
+		})
+	}
+}
+
+// selectedFieldsContext returns the context of a resolver whose result is queried with the given fields
+func selectedFieldsContext(fields ...string) context.Context {
+	selections := make(ast.SelectionSet, len(fields))
+	for i, name := range fields {
+		selections[i] = &ast.Field{Name: name, Alias: name}
+	}
+	ctx := graphql.WithOperationContext(context.Background(), &graphql.OperationContext{})
+	return graphql.WithFieldContext(ctx, &graphql.FieldContext{Field: graphql.CollectedField{Selections: selections}})
+}
+
+func TestProductResolver_GetProduct(t *testing.T) {
+	tcs := map[string]struct {
+		id          int
+		mockProduct model.Product
+		mockErr     error
+		expResult   *mod.Product
+		expErr      error
+	}{
+		"success": {
+			id:          1,
+			mockProduct: model.Product{ID: 1, Title: "shirt", Price: 100, Quantity: 10, UserID: 2},
+			expResult: &mod.Product{
+				ID: 1, Title: "shirt", Price: 100, Quantity: 10, UserID: 2,
+				Options: []*mod.ProductOption{{ID: 3, Name: "Size", Position: 1}},
+				Variants: []*mod.ProductVariant{
+					{ID: 5, Sku: "SHIRT-M", Quantity: 4, Options: []*mod.VariantOption{{Name: "Size", Value: "M"}}},
+				},
+			},
+		},
+		"invalid_id": {
+			id:     0,
+			expErr: errInvalidID,
+		},
+		"product_not_found": {
+			id:      1,
+			mockErr: sql.ErrNoRows,
+			expErr:  errProductNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("GetProduct", context.Background(), tc.id).Return(tc.mockProduct, tc.mockErr)
+			serviceMock.On("GetProductVariants", context.Background(), []int{tc.id}).Return(map[int]productServ.ProductVariants{
+				1: {
+					Options:  []productServ.Option{{ID: 3, Name: "Size", Position: 1}},
+					Variants: []productServ.Variant{{ID: 5, SKU: "SHIRT-M", Quantity: 4, Options: []productServ.VariantOption{{Name: "Size", Value: "M"}}}},
+				},
+			}, nil)
+			resolver := NewResolver(nil, serviceMock)
+
+			// WHEN
+			result, err := resolver.Query().GetProduct(context.Background(), tc.id)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
 			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expResult, result)
 		})
 	}
 }
@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/address"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+type AddressRequest struct {
+	Name              string `json:"name"`
+	Phone             string `json:"phone"`
+	Line1             string `json:"line1"`
+	Line2             string `json:"line2"`
+	City              string `json:"city"`
+	State             string `json:"state"`
+	PostalCode        string `json:"postal_code"`
+	Country           string `json:"country"`
+	IsDefaultShipping bool   `json:"is_default_shipping"`
+	IsDefaultBilling  bool   `json:"is_default_billing"`
+}
+
+func validateAddressID(id string) (int, error) {
+	result, err := strconv.Atoi(id)
+	if err != nil || result <= 0 {
+		return 0, ErrInvalidAddressID
+	}
+	return result, nil
+}
+
+// validateAddressReq validates the address, the country and the postal code are converted to upper case
+func validateAddressReq(req AddressRequest) (userServ.AddressInput, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return userServ.AddressInput{}, ErrNameCannotBeBlank
+	}
+	phone := strings.TrimSpace(req.Phone)
+	if phone == "" {
+		return userServ.AddressInput{}, ErrPhoneCannotBeBlank
+	}
+	line1 := strings.TrimSpace(req.Line1)
+	if line1 == "" {
+		return userServ.AddressInput{}, ErrLine1CannotBeBlank
+	}
+	city := strings.TrimSpace(req.City)
+	if city == "" {
+		return userServ.AddressInput{}, ErrCityCannotBeBlank
+	}
+	country := strings.ToUpper(strings.TrimSpace(req.Country))
+	if !address.IsValidCountry(country) {
+		return userServ.AddressInput{}, ErrInvalidCountry
+	}
+	postalCode := strings.ToUpper(strings.TrimSpace(req.PostalCode))
+	if !address.IsValidPostalCode(country, postalCode) {
+		return userServ.AddressInput{}, ErrInvalidPostalCode
+	}
+
+	return userServ.AddressInput{
+		Name:              name,
+		Phone:             phone,
+		Line1:             line1,
+		Line2:             strings.TrimSpace(req.Line2),
+		City:              city,
+		State:             strings.TrimSpace(req.State),
+		PostalCode:        postalCode,
+		Country:           country,
+		IsDefaultShipping: req.IsDefaultShipping,
+		IsDefaultBilling:  req.IsDefaultBilling,
+	}, nil
+}
+
+// GetAddresses handle request to get the addresses of a user
+func (h Handler) GetAddresses(w http.ResponseWriter, r *http.Request) {
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	result, err := h.userServ.GetAddresses(r.Context(), userID)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// GetAddress handle request to get an address of a user
+func (h Handler) GetAddress(w http.ResponseWriter, r *http.Request) {
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	id, err := validateAddressID(chi.URLParam(r, "addressID"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	result, err := h.userServ.GetAddress(r.Context(), userID, id)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// CreateAddress handle request to add an address to the address book of a user
+func (h Handler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	// 1. Get user ID from url param
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req AddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+	input, err := validateAddressReq(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	input.UserID = userID
+
+	// 3. Create address
+	result, err := h.userServ.CreateAddress(r.Context(), input)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, result)
+}
+
+// UpdateAddress handle request to update an address of a user
+func (h Handler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	// 1. Get user ID and address ID from url params
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	id, err := validateAddressID(chi.URLParam(r, "addressID"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req AddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+	input, err := validateAddressReq(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	input.ID = id
+	input.UserID = userID
+
+	// 3. Update address
+	if err := h.userServ.UpdateAddress(r.Context(), input); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUpdateAddress,
+	})
+}
+
+// DeleteAddress handle request to delete an address of a user
+func (h Handler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	id, err := validateAddressID(chi.URLParam(r, "addressID"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	if err := h.userServ.DeleteAddress(r.Context(), userID, id); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgDeleteAddress,
+	})
+}
@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+)
+
+func TestHandler_CreateAddress(t *testing.T) {
+	tcs := map[string]struct {
+		userID        string
+		reqBody       string
+		mockInput     userServ.AddressInput
+		mockResult    userServ.Address
+		mockResultErr error
+		statusCode    int
+		err           error
+	}{
+		"success": {
+			userID:     "2",
+			reqBody:    `{"name":"Mai","phone":"0987654321","line1":"1 Le Loi","city":"Ho Chi Minh City","postal_code":"70000","country":"vn","is_default_shipping":true}`,
+			mockInput:  userServ.AddressInput{UserID: 2, Name: "Mai", Phone: "0987654321", Line1: "1 Le Loi", City: "Ho Chi Minh City", PostalCode: "70000", Country: "VN", IsDefaultShipping: true},
+			mockResult: userServ.Address{ID: 1, UserID: 2, Country: "VN"},
+			statusCode: http.StatusCreated,
+		},
+		"success_country_without_postal_code": {
+			userID:     "2",
+			reqBody:    `{"name":"Mai","phone":"0987654321","line1":"1 Queen's Road","city":"Hong Kong","country":"HK"}`,
+			mockInput:  userServ.AddressInput{UserID: 2, Name: "Mai", Phone: "0987654321", Line1: "1 Queen's Road", City: "Hong Kong", Country: "HK"},
+			mockResult: userServ.Address{ID: 1, UserID: 2, Country: "HK"},
+			statusCode: http.StatusCreated,
+		},
+		"invalid_user_id": {
+			userID:     "abc",
+			reqBody:    `{}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidUserID,
+		},
+		"line1_can_not_be_blank": {
+			userID:     "2",
+			reqBody:    `{"name":"Mai","phone":"0987654321","line1":" ","city":"Ho Chi Minh City","postal_code":"70000","country":"VN"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrLine1CannotBeBlank,
+		},
+		"invalid_country": {
+			userID:     "2",
+			reqBody:    `{"name":"Mai","phone":"0987654321","line1":"1 Le Loi","city":"Ho Chi Minh City","postal_code":"70000","country":"XX"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidCountry,
+		},
+		"invalid_postal_code": {
+			userID:     "2",
+			reqBody:    `{"name":"John","phone":"5551234567","line1":"1 Main St","city":"Springfield","postal_code":"1234","country":"US"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidPostalCode,
+		},
+		"postal_code_required": {
+			userID:     "2",
+			reqBody:    `{"name":"Mai","phone":"0987654321","line1":"1 Le Loi","city":"Ho Chi Minh City","country":"VN"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidPostalCode,
+		},
+		"permission_denied": {
+			userID:        "2",
+			reqBody:       `{"name":"Mai","phone":"0987654321","line1":"1 Le Loi","city":"Ho Chi Minh City","postal_code":"70000","country":"VN"}`,
+			mockInput:     userServ.AddressInput{UserID: 2, Name: "Mai", Phone: "0987654321", Line1: "1 Le Loi", City: "Ho Chi Minh City", PostalCode: "70000", Country: "VN"},
+			mockResultErr: userServ.ErrPermissionDenied,
+			statusCode:    http.StatusForbidden,
+			err:           ErrPermissionDenied,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+tc.userID+"/addresses", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.userID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("CreateAddress", r.Context(), tc.mockInput).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.CreateAddress(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Contains(t, w.Body.String(), `"country":"`+tc.mockInput.Country+`"`)
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateAddress(t *testing.T) {
+	tcs := map[string]struct {
+		addressID     string
+		mockInput     userServ.AddressInput
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			addressID:  "5",
+			mockInput:  userServ.AddressInput{ID: 5, UserID: 2, Name: "Mai", Phone: "0987654321", Line1: "1 Le Loi", City: "Ho Chi Minh City", PostalCode: "70000", Country: "VN"},
+			statusCode: http.StatusOK,
+			body:       "{\"success\":true,\"msg\":\"Update address successfully\"}",
+		},
+		"invalid_address_id": {
+			addressID:  "abc",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidAddressID,
+		},
+		"address_not_found": {
+			addressID:     "5",
+			mockInput:     userServ.AddressInput{ID: 5, UserID: 2, Name: "Mai", Phone: "0987654321", Line1: "1 Le Loi", City: "Ho Chi Minh City", PostalCode: "70000", Country: "VN"},
+			mockResultErr: userServ.ErrAddressNotFound,
+			statusCode:    http.StatusNotFound,
+			err:           ErrAddressNotFound,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			reqBody := `{"name":"Mai","phone":"0987654321","line1":"1 Le Loi","city":"Ho Chi Minh City","postal_code":"70000","country":"VN"}`
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/users/2/addresses/"+tc.addressID, strings.NewReader(reqBody))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "2")
+			rctx.URLParams.Add("addressID", tc.addressID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("UpdateAddress", r.Context(), tc.mockInput).Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.UpdateAddress(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_DeleteAddress(t *testing.T) {
+	tcs := map[string]struct {
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			statusCode: http.StatusOK,
+			body:       "{\"success\":true,\"msg\":\"Delete address successfully\"}",
+		},
+		"address_not_found": {
+			mockResultErr: userServ.ErrAddressNotFound,
+			statusCode:    http.StatusNotFound,
+			err:           ErrAddressNotFound,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/users/2/addresses/5", nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "2")
+			rctx.URLParams.Add("addressID", "5")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("DeleteAddress", r.Context(), 2, 5).Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.DeleteAddress(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
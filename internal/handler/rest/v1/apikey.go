@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/volatiletech/null/v8"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+type CreateAPIKeyRequest struct {
+	Name      string    `json:"name"`
+	Scope     string    `json:"scope"`
+	ExpiresAt null.Time `json:"expires_at"`
+}
+
+func validateAPIKeyID(id string) (int, error) {
+	result, err := strconv.Atoi(id)
+	if err != nil || result < 0 {
+		return 0, ErrInvalidID
+	}
+	return result, nil
+}
+
+func isValidScope(scope string) bool {
+	return scope == auth.ScopeRead || scope == auth.ScopeWrite
+}
+
+func validateCreateAPIKeyReq(req CreateAPIKeyRequest) (userServ.CreateAPIKeyInput, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return userServ.CreateAPIKeyInput{}, ErrNameCannotBeBlank
+	}
+	if !isValidScope(req.Scope) {
+		return userServ.CreateAPIKeyInput{}, ErrInvalidScope
+	}
+	if req.ExpiresAt.Valid && !req.ExpiresAt.Time.After(time.Now()) {
+		return userServ.CreateAPIKeyInput{}, ErrInvalidExpiresAt
+	}
+
+	return userServ.CreateAPIKeyInput{
+		Name:      name,
+		Scope:     req.Scope,
+		ExpiresAt: req.ExpiresAt,
+	}, nil
+}
+
+// CreateAPIKey handle request to create an API key of the current user
+func (h Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	// 1. Decode
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// 2. Validate request
+	input, err := validateCreateAPIKeyReq(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Create API key
+	result, err := h.userServ.CreateAPIKey(r.Context(), input)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 4. Return result, the key is only shown in this response
+	utils.WriteJSONResponse(w, http.StatusCreated, result)
+}
+
+// GetAPIKeys handle request to get the API keys of the current user
+func (h Handler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.GetAPIKeys(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// RevokeAPIKey handle request to revoke an API key
+func (h Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	// 1. Get API key ID from url param
+	id, err := validateAPIKeyID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Revoke API key using "id"
+	if err := h.userServ.RevokeAPIKey(r.Context(), id); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgRevokeAPIKey,
+	})
+}
@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestHandler_CreateAPIKey(t *testing.T) {
+	createdAt := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		reqBody       string
+		mockInput     userServ.CreateAPIKeyInput
+		mockResult    userServ.APIKey
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			reqBody:    `{"name":" warehouse ","scope":"write"}`,
+			mockInput:  userServ.CreateAPIKeyInput{Name: "warehouse", Scope: auth.ScopeWrite},
+			mockResult: userServ.APIKey{ID: 1, Name: "warehouse", Prefix: "sk_abcdefgh", Scope: auth.ScopeWrite, Key: "sk_abcdefghijk", CreatedAt: createdAt},
+			statusCode: http.StatusCreated,
+			body:       "{\"id\":1,\"name\":\"warehouse\",\"prefix\":\"sk_abcdefgh\",\"scope\":\"write\",\"key\":\"sk_abcdefghijk\",\"last_used_at\":null,\"expires_at\":null,\"revoked_at\":null,\"created_at\":\"2022-07-01T00:00:00Z\"}",
+		},
+		"name_can_not_be_blank": {
+			reqBody:    `{"name":"","scope":"write"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrNameCannotBeBlank,
+		},
+		"invalid_scope": {
+			reqBody:    `{"name":"warehouse","scope":"admin"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidScope,
+		},
+		"expires_at_in_the_past": {
+			reqBody:    `{"name":"warehouse","scope":"read","expires_at":"2020-01-01T00:00:00Z"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidExpiresAt,
+		},
+		"authenticated_by_api_key": {
+			reqBody:       `{"name":"warehouse","scope":"read"}`,
+			mockInput:     userServ.CreateAPIKeyInput{Name: "warehouse", Scope: auth.ScopeRead},
+			mockResultErr: userServ.ErrPermissionDenied,
+			statusCode:    http.StatusForbidden,
+			err:           ErrPermissionDenied,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/api-keys", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("CreateAPIKey", r.Context(), tc.mockInput).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.CreateAPIKey(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_RevokeAPIKey(t *testing.T) {
+	tcs := map[string]struct {
+		id            string
+		mockInput     int
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			id:         "1",
+			mockInput:  1,
+			statusCode: http.StatusOK,
+			body:       "{\"success\":true,\"msg\":\"Revoke API key successfully\"}",
+		},
+		"invalid_id": {
+			id:         "abc",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidID,
+		},
+		"not_found": {
+			id:            "2",
+			mockInput:     2,
+			mockResultErr: userServ.ErrAPIKeyNotFound,
+			statusCode:    http.StatusNotFound,
+			err:           ErrAPIKeyNotFound,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/users/api-keys/"+tc.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("RevokeAPIKey", mock.Anything, tc.mockInput).Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.RevokeAPIKey(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/jwtauth/v5"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+// APIKeyHeader is the header which carries the API key of machine-to-machine requests
+const APIKeyHeader = "X-API-Key"
+
+// OrganizationHeader is the header which selects the organization of anonymous requests, e.g. the shop of a storefront
+const OrganizationHeader = "X-Organization-ID"
+
+// Authenticate verifies the bearer token or the API key of the request and puts the authenticated user into the request context.
+// Requests without a token are passed as anonymous, the route policies decide whether they are allowed.
+// Read-only API keys are rejected for any method other than GET, HEAD and OPTIONS.
+// The data of authenticated requests is limited to the organization of the user, anonymous requests may select one by OrganizationHeader.
+// Requests made with an impersonation token are logged with the admin who impersonates the user.
+func (h Handler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			user auth.User
+			err  error
+		)
+		if token := jwtauth.TokenFromHeader(r); token != "" {
+			user, err = h.userServ.VerifyAccessToken(r.Context(), token)
+		} else if key := r.Header.Get(APIKeyHeader); key != "" {
+			user, err = h.userServ.VerifyAPIKey(r.Context(), key)
+		} else {
+			ctx, err := tenantFromHeader(r)
+			if err != nil {
+				utils.WriteJSONResponse(w, ErrInvalidOrganization.Status, ErrInvalidOrganization)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		if err != nil {
+			handleUserError(w, err)
+			return
+		}
+
+		if !user.CanWrite() && !isReadMethod(r.Method) {
+			utils.WriteJSONResponse(w, ErrInsufficientScope.Status, ErrInsufficientScope)
+			return
+		}
+
+		if user.IsImpersonated() {
+			log.Printf("Impersonated request: actor %d as user %d %s %s\n", user.ActorID, user.ID, r.Method, r.URL.Path)
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), user)))
+	})
+}
+
+// tenantFromHeader returns the request context scoped to the organization of OrganizationHeader,
+// the repositories limit it to the default organization without the header
+func tenantFromHeader(r *http.Request) (context.Context, error) {
+	value := r.Header.Get(OrganizationHeader)
+	if value == "" {
+		return r.Context(), nil
+	}
+	organizationID, err := strconv.Atoi(value)
+	if err != nil || organizationID <= 0 {
+		return nil, ErrInvalidOrganization
+	}
+	return auth.NewTenantContext(r.Context(), organizationID), nil
+}
+
+// isReadMethod returns true if the HTTP method does not modify data
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// RequireAuth rejects anonymous requests
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.FromContext(r.Context()); !ok {
+			utils.WriteJSONResponse(w, ErrUnauthorized.Status, ErrUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequirePermission rejects requests whose user does not have one of the given permissions
+func RequirePermission(permissions ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := auth.FromContext(r.Context())
+			if !ok {
+				utils.WriteJSONResponse(w, ErrUnauthorized.Status, ErrUnauthorized)
+				return
+			}
+
+			for _, permission := range permissions {
+				if user.HasPermission(permission) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			utils.WriteJSONResponse(w, ErrPermissionDenied.Status, ErrPermissionDenied)
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestHandler_Authenticate(t *testing.T) {
+	type mockData struct {
+		token  string
+		apiKey string
+		result auth.User
+		err    error
+	}
+
+	type givenData struct {
+		method        string
+		authorization string
+		apiKey        string
+		organization  string
+		mock          mockData
+	}
+
+	type expectedData struct {
+		statusCode int
+		user       auth.User
+		hasUser    bool
+		tenant     int
+		hasTenant  bool
+	}
+
+	tcs := map[string]struct {
+		given     givenData
+		expResult expectedData
+		expErr    error
+	}{
+		"success": {
+			given: givenData{
+				authorization: "Bearer valid-token",
+				mock: mockData{
+					token:  "valid-token",
+					result: auth.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin},
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusOK,
+				user:       auth.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin},
+				hasUser:    true,
+			},
+		},
+		"anonymous": {
+			given: givenData{},
+			expResult: expectedData{
+				statusCode: http.StatusOK,
+			},
+		},
+		"success_organization": {
+			given: givenData{
+				authorization: "Bearer valid-token",
+				organization:  "3",
+				mock: mockData{
+					token:  "valid-token",
+					result: auth.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin, OrganizationID: 2},
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusOK,
+				user:       auth.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin, OrganizationID: 2},
+				hasUser:    true,
+				tenant:     2,
+				hasTenant:  true,
+			},
+		},
+		"anonymous_organization": {
+			given: givenData{
+				organization: "3",
+			},
+			expResult: expectedData{
+				statusCode: http.StatusOK,
+				tenant:     3,
+				hasTenant:  true,
+			},
+		},
+		"anonymous_invalid_organization": {
+			given: givenData{
+				organization: "abc",
+			},
+			expResult: expectedData{
+				statusCode: http.StatusBadRequest,
+			},
+			expErr: ErrInvalidOrganization,
+		},
+		"invalid_token": {
+			given: givenData{
+				authorization: "Bearer invalid-token",
+				mock: mockData{
+					token: "invalid-token",
+					err:   userServ.ErrInvalidToken,
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusUnauthorized,
+			},
+			expErr: ErrInvalidToken,
+		},
+		"success_api_key": {
+			given: givenData{
+				method: http.MethodPost,
+				apiKey: "sk_write",
+				mock: mockData{
+					apiKey: "sk_write",
+					result: auth.User{ID: 2, Email: "guest@example.com", Role: auth.RoleGuest, Scope: auth.ScopeWrite},
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusOK,
+				user:       auth.User{ID: 2, Email: "guest@example.com", Role: auth.RoleGuest, Scope: auth.ScopeWrite},
+				hasUser:    true,
+			},
+		},
+		"success_read_only_api_key_get": {
+			given: givenData{
+				apiKey: "sk_read",
+				mock: mockData{
+					apiKey: "sk_read",
+					result: auth.User{ID: 2, Email: "guest@example.com", Role: auth.RoleGuest, Scope: auth.ScopeRead},
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusOK,
+				user:       auth.User{ID: 2, Email: "guest@example.com", Role: auth.RoleGuest, Scope: auth.ScopeRead},
+				hasUser:    true,
+			},
+		},
+		"read_only_api_key_post": {
+			given: givenData{
+				method: http.MethodPost,
+				apiKey: "sk_read",
+				mock: mockData{
+					apiKey: "sk_read",
+					result: auth.User{ID: 2, Email: "guest@example.com", Role: auth.RoleGuest, Scope: auth.ScopeRead},
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusForbidden,
+			},
+			expErr: ErrInsufficientScope,
+		},
+		"invalid_api_key": {
+			given: givenData{
+				apiKey: "sk_invalid",
+				mock: mockData{
+					apiKey: "sk_invalid",
+					err:    userServ.ErrInvalidAPIKey,
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusUnauthorized,
+			},
+			expErr: ErrInvalidAPIKey,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			method := tc.given.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			r := httptest.NewRequest(method, "/api/v1/products", nil)
+			if tc.given.authorization != "" {
+				r.Header.Set("Authorization", tc.given.authorization)
+			}
+			if tc.given.apiKey != "" {
+				r.Header.Set(APIKeyHeader, tc.given.apiKey)
+			}
+			if tc.given.organization != "" {
+				r.Header.Set(OrganizationHeader, tc.given.organization)
+			}
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			if tc.given.mock.token != "" {
+				serviceMock.On("VerifyAccessToken", r.Context(), tc.given.mock.token).Return(tc.given.mock.result, tc.given.mock.err)
+			}
+			if tc.given.mock.apiKey != "" {
+				serviceMock.On("VerifyAPIKey", r.Context(), tc.given.mock.apiKey).Return(tc.given.mock.result, tc.given.mock.err)
+			}
+
+			var (
+				user      auth.User
+				hasUser   bool
+				tenant    int
+				hasTenant bool
+			)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, hasUser = auth.FromContext(r.Context())
+				tenant, hasTenant = auth.TenantFromContext(r.Context())
+			})
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// When
+			handler.Authenticate(next).ServeHTTP(w, r)
+
+			// Then
+			require.Equal(t, tc.expResult.statusCode, w.Code)
+			if tc.expErr != nil {
+				require.EqualError(t, tc.expErr, w.Body.String())
+			} else {
+				require.Equal(t, tc.expResult.hasUser, hasUser)
+				require.Equal(t, tc.expResult.user, user)
+				require.Equal(t, tc.expResult.hasTenant, hasTenant)
+				require.Equal(t, tc.expResult.tenant, tenant)
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRequireAuth(t *testing.T) {
+	tcs := map[string]struct {
+		user       *auth.User
+		statusCode int
+		expErr     error
+	}{
+		"success": {
+			user:       &auth.User{ID: 2, Role: auth.RoleGuest},
+			statusCode: http.StatusOK,
+		},
+		"anonymous": {
+			statusCode: http.StatusUnauthorized,
+			expErr:     ErrUnauthorized,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+			if tc.user != nil {
+				r = r.WithContext(auth.NewContext(r.Context(), *tc.user))
+			}
+			w := httptest.NewRecorder()
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+			// When
+			RequireAuth(next).ServeHTTP(w, r)
+
+			// Then
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.expErr != nil {
+				require.EqualError(t, tc.expErr, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	tcs := map[string]struct {
+		user        *auth.User
+		permissions []string
+		statusCode  int
+		expErr      error
+	}{
+		"success": {
+			user:        &auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserRead, auth.PermUserWrite}},
+			permissions: []string{auth.PermUserWrite},
+			statusCode:  http.StatusOK,
+		},
+		"success_one_of_permissions": {
+			user:        &auth.User{ID: 3, Role: "REPORTER", Permissions: []string{auth.PermStatisticsRead}},
+			permissions: []string{auth.PermUserRead, auth.PermStatisticsRead},
+			statusCode:  http.StatusOK,
+		},
+		"anonymous": {
+			permissions: []string{auth.PermUserRead},
+			statusCode:  http.StatusUnauthorized,
+			expErr:      ErrUnauthorized,
+		},
+		"permission_denied": {
+			user:        &auth.User{ID: 2, Role: auth.RoleGuest, Permissions: []string{auth.PermOrderRead}},
+			permissions: []string{auth.PermUserRead},
+			statusCode:  http.StatusForbidden,
+			expErr:      ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+			if tc.user != nil {
+				r = r.WithContext(auth.NewContext(r.Context(), *tc.user))
+			}
+			w := httptest.NewRecorder()
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+			// When
+			RequirePermission(tc.permissions...)(next).ServeHTTP(w, r)
+
+			// Then
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.expErr != nil {
+				require.EqualError(t, tc.expErr, w.Body.String())
+			}
+		})
+	}
+}
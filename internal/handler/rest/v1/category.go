@@ -0,0 +1,270 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/volatiletech/null/v8"
+
+	productServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+const (
+	MsgUpdateCategory          = "Update category successfully"
+	MsgDeleteCategory          = "Delete category successfully"
+	MsgUpdateProductCategories = "Update product categories successfully"
+)
+
+var (
+	slugPattern       = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	nonSlugCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+type categoryRequest struct {
+	ParentID  int    `json:"parent_id"` // default 0, a root category
+	Name      string `json:"name"`      // required
+	Slug      string `json:"slug"`      // default the name in lowercase with hyphens
+	SortOrder int    `json:"sort_order"`
+}
+
+type productCategoriesRequest struct {
+	CategoryIDs []int `json:"category_ids"`
+}
+
+type categoryResponse struct {
+	ID        int                `json:"id"`
+	ParentID  null.Int           `json:"parent_id"`
+	Name      string             `json:"name"`
+	Slug      string             `json:"slug"`
+	SortOrder int                `json:"sort_order"`
+	Path      string             `json:"path"`
+	Children  []categoryResponse `json:"children,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type categoryCountResponse struct {
+	CategoryID   int    `json:"category_id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	ProductCount int64  `json:"product_count"`
+}
+
+// toCategoryResponse converts the category and its children to the response
+func toCategoryResponse(category productServ.Category) categoryResponse {
+	var children []categoryResponse
+	for _, c := range category.Children {
+		children = append(children, toCategoryResponse(c))
+	}
+	return categoryResponse{
+		ID:        category.ID,
+		ParentID:  category.ParentID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		SortOrder: category.SortOrder,
+		Path:      category.Path,
+		Children:  children,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+// toCategoryResponses converts the categories to the response
+func toCategoryResponses(categories []productServ.Category) []categoryResponse {
+	result := make([]categoryResponse, len(categories))
+	for i, c := range categories {
+		result[i] = toCategoryResponse(c)
+	}
+	return result
+}
+
+func validateCategoryID(id string) (int, error) {
+	result, err := strconv.Atoi(id)
+	if err != nil || result <= 0 {
+		return 0, ErrInvalidCategoryID
+	}
+	return result, nil
+}
+
+// validateCategoryReq validates the category, the slug is generated from the name if it is not given
+func validateCategoryReq(req categoryRequest) (productServ.CategoryInput, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return productServ.CategoryInput{}, ErrNameCannotBeBlank
+	}
+	slug := strings.TrimSpace(req.Slug)
+	if slug == "" {
+		slug = strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	}
+	if !slugPattern.MatchString(slug) {
+		return productServ.CategoryInput{}, ErrInvalidSlug
+	}
+	if req.ParentID < 0 {
+		return productServ.CategoryInput{}, ErrInvalidParentCategory
+	}
+
+	return productServ.CategoryInput{
+		ParentID:  req.ParentID,
+		Name:      name,
+		Slug:      slug,
+		SortOrder: req.SortOrder,
+	}, nil
+}
+
+// GetCategories handle request to get the category tree
+func (h Handler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	result, err := h.productServ.GetCategories(r.Context())
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, toCategoryResponses(result))
+}
+
+// GetCategory handle request to get a category
+func (h Handler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := validateCategoryID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	result, err := h.productServ.GetCategory(r.Context(), id)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, toCategoryResponse(result))
+}
+
+// CreateCategory handle request to create a category
+func (h Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	// 1. Decode and validate request body
+	var req categoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	input, err := validateCategoryReq(req)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Create category
+	result, err := h.productServ.CreateCategory(r.Context(), input)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, toCategoryResponse(result))
+}
+
+// UpdateCategory handle request to update a category, the category is moved if parent_id is changed
+func (h Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	// 1. Get category ID from url param
+	id, err := validateCategoryID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req categoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	input, err := validateCategoryReq(req)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 3. Update category
+	if err := h.productServ.UpdateCategory(r.Context(), id, input); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUpdateCategory,
+	})
+}
+
+// DeleteCategory handle request to delete a category
+func (h Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := validateCategoryID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	if err := h.productServ.DeleteCategory(r.Context(), id); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgDeleteCategory,
+	})
+}
+
+// GetProductCategories handle request to get the categories of a product
+func (h Handler) GetProductCategories(w http.ResponseWriter, r *http.Request) {
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	result, err := h.productServ.GetProductCategories(r.Context(), productID)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, toCategoryResponses(result))
+}
+
+// UpdateProductCategories handle request to replace the categories of a product
+func (h Handler) UpdateProductCategories(w http.ResponseWriter, r *http.Request) {
+	// 1. Get product ID from url param
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req productCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	for _, id := range req.CategoryIDs {
+		if id <= 0 {
+			handleProductError(w, ErrInvalidCategoryID)
+			return
+		}
+	}
+
+	// 3. Replace the categories
+	if err := h.productServ.SetProductCategories(r.Context(), productID, req.CategoryIDs); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUpdateProductCategories,
+	})
+}
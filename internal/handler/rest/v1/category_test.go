@@ -0,0 +1,286 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	productServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
+)
+
+// withURLParam adds the url param to the route context of the request
+func withURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_GetCategories(t *testing.T) {
+	// GIVEN
+	createdAt := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/categories", nil)
+	w := httptest.NewRecorder()
+
+	serviceMock := new(productServ.Mock)
+	serviceMock.On("GetCategories", r.Context()).Return([]productServ.Category{
+		{ID: 1, Name: "Electronics", Slug: "electronics", Path: "/1/", CreatedAt: createdAt, UpdatedAt: createdAt, Children: []productServ.Category{
+			{ID: 2, ParentID: null.IntFrom(1), Name: "Phones", Slug: "phones", Path: "/1/2/", CreatedAt: createdAt, UpdatedAt: createdAt, Children: []productServ.Category{}},
+		}},
+	}, nil)
+
+	handler := NewHandler(nil, serviceMock, nil)
+
+	// WHEN
+	handler.GetCategories(w, r)
+
+	// THEN
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `[{"id":1,"parent_id":null,"name":"Electronics","slug":"electronics","sort_order":0,"path":"/1/","children":[{"id":2,"parent_id":1,"name":"Phones","slug":"phones","sort_order":0,"path":"/1/2/","created_at":"2022-01-01T10:00:00Z","updated_at":"2022-01-01T10:00:00Z"}],"created_at":"2022-01-01T10:00:00Z","updated_at":"2022-01-01T10:00:00Z"}]`, w.Body.String())
+}
+
+func TestHandler_CreateCategory(t *testing.T) {
+	tcs := map[string]struct {
+		body       string
+		mockInput  productServ.CategoryInput
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			body:       `{"parent_id": 1, "name": "Phones", "slug": "phones", "sort_order": 2}`,
+			mockInput:  productServ.CategoryInput{ParentID: 1, Name: "Phones", Slug: "phones", SortOrder: 2},
+			statusCode: http.StatusCreated,
+		},
+		"success_slug_from_name": {
+			body:       `{"name": "  Phones & Tablets "}`,
+			mockInput:  productServ.CategoryInput{Name: "Phones & Tablets", Slug: "phones-tablets"},
+			statusCode: http.StatusCreated,
+		},
+		"invalid_request_body": {
+			body:       `{{abc`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidBodyRequest,
+		},
+		"blank_name": {
+			body:       `{"name": " "}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrNameCannotBeBlank,
+		},
+		"invalid_slug": {
+			body:       `{"name": "Phones", "slug": "Phones_1"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidSlug,
+		},
+		"invalid_parent_id": {
+			body:       `{"name": "Phones", "parent_id": -1}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidParentCategory,
+		},
+		"slug_existed": {
+			body:       `{"name": "Phones"}`,
+			mockInput:  productServ.CategoryInput{Name: "Phones", Slug: "phones"},
+			mockErr:    productServ.ErrCategorySlugExisted,
+			statusCode: http.StatusBadRequest,
+			err:        ErrCategorySlugExisted,
+		},
+		"parent_not_exist": {
+			body:       `{"name": "Phones", "parent_id": 9}`,
+			mockInput:  productServ.CategoryInput{ParentID: 9, Name: "Phones", Slug: "phones"},
+			mockErr:    productServ.ErrParentCategoryNotExist,
+			statusCode: http.StatusBadRequest,
+			err:        ErrParentCategoryNotExist,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/categories", strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("CreateCategory", r.Context(), tc.mockInput).Return(productServ.Category{ID: 2, Name: tc.mockInput.Name}, tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.CreateCategory(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_UpdateCategory(t *testing.T) {
+	tcs := map[string]struct {
+		id         string
+		body       string
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			id:         "2",
+			body:       `{"parent_id": 5, "name": "Phones"}`,
+			statusCode: http.StatusOK,
+		},
+		"invalid_id": {
+			id:         "abc",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidCategoryID,
+		},
+		"not_found": {
+			id:         "2",
+			body:       `{"parent_id": 5, "name": "Phones"}`,
+			mockErr:    productServ.ErrCategoryNotFound,
+			statusCode: http.StatusNotFound,
+			err:        ErrCategoryNotFound,
+		},
+		"invalid_parent": {
+			id:         "2",
+			body:       `{"parent_id": 5, "name": "Phones"}`,
+			mockErr:    productServ.ErrInvalidParentCategory,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidParentCategory,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withURLParam(httptest.NewRequest(http.MethodPut, "/api/v1/categories/"+tc.id, strings.NewReader(tc.body)), "id", tc.id)
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("UpdateCategory", r.Context(), 2, productServ.CategoryInput{ParentID: 5, Name: "Phones", Slug: "phones"}).Return(tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.UpdateCategory(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, `{"success":true,"msg":"Update category successfully"}`, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_DeleteCategory(t *testing.T) {
+	tcs := map[string]struct {
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			statusCode: http.StatusOK,
+		},
+		"has_children": {
+			mockErr:    productServ.ErrCategoryHasChildren,
+			statusCode: http.StatusConflict,
+			err:        ErrCategoryHasChildren,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withURLParam(httptest.NewRequest(http.MethodDelete, "/api/v1/categories/2", nil), "id", "2")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("DeleteCategory", r.Context(), 2).Return(tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.DeleteCategory(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateProductCategories(t *testing.T) {
+	tcs := map[string]struct {
+		body       string
+		mockIDs    []int
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			body:       `{"category_ids": [2, 3]}`,
+			mockIDs:    []int{2, 3},
+			statusCode: http.StatusOK,
+		},
+		"invalid_category_id": {
+			body:       `{"category_ids": [2, 0]}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidCategoryID,
+		},
+		"category_not_exist": {
+			body:       `{"category_ids": [9]}`,
+			mockIDs:    []int{9},
+			mockErr:    productServ.ErrCategoryNotExist,
+			statusCode: http.StatusBadRequest,
+			err:        ErrCategoryNotExist,
+		},
+		"permission_denied": {
+			body:       `{"category_ids": [2]}`,
+			mockIDs:    []int{2},
+			mockErr:    productServ.ErrPermissionDenied,
+			statusCode: http.StatusForbidden,
+			err:        ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withURLParam(httptest.NewRequest(http.MethodPut, "/api/v1/products/10/categories", strings.NewReader(tc.body)), "id", "10")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("SetProductCategories", r.Context(), 10, tc.mockIDs).Return(tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.UpdateProductCategories(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				if tc.mockIDs == nil {
+					serviceMock.AssertNotCalled(t, "SetProductCategories", mock.Anything, mock.Anything, mock.Anything)
+				}
+				return
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+// Formats of the personal data archive
+const (
+	exportFormatJSON = "json"
+	exportFormatZIP  = "zip"
+)
+
+// ExportUserData handle request to download everything tied to a user
+func (h Handler) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	h.exportUserData(w, r, userID)
+}
+
+// ExportCurrentUserData handle request of the current user to download everything tied to them
+func (h Handler) ExportCurrentUserData(w http.ResponseWriter, r *http.Request) {
+	user, _ := auth.FromContext(r.Context())
+	h.exportUserData(w, r, user.ID)
+}
+
+// exportUserData writes the personal data archive of the user as a JSON or a ZIP attachment
+func (h Handler) exportUserData(w http.ResponseWriter, r *http.Request, userID int) {
+	// 1. Validate the format, the default is json
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatJSON
+	}
+	if format != exportFormatJSON && format != exportFormatZIP {
+		handleUserError(w, ErrInvalidExportFormat)
+		return
+	}
+
+	// 2. Export the data
+	data, err := h.userServ.ExportUserData(r.Context(), userID)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Write the archive, it is encoded before the headers are written so errors can still be reported
+	var buf bytes.Buffer
+	contentType := "application/json"
+	if format == exportFormatZIP {
+		contentType = "application/zip"
+		err = data.WriteZip(&buf)
+	} else {
+		encoder := json.NewEncoder(&buf)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(data)
+	}
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=user_%d_data_%s.%s", userID, time.Now().Format("20060102"), format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// EraseUser handle request to anonymize the personal data of a user
+func (h Handler) EraseUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	if err = h.userServ.EraseUser(r.Context(), userID); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgEraseUser,
+	})
+}
+
+// GetDataRequests handle request to get the log of personal data exports and erasures
+func (h Handler) GetDataRequests(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.GetDataRequests(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
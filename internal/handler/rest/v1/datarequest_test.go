@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+)
+
+func TestHandler_ExportUserData(t *testing.T) {
+	exportedAt := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		userID         string
+		format         string
+		mockResultErr  error
+		statusCode     int
+		expContentType string
+		err            error
+	}{
+		"success_json": {
+			userID:         "10",
+			statusCode:     http.StatusOK,
+			expContentType: "application/json",
+		},
+		"success_zip": {
+			userID:         "10",
+			format:         "zip",
+			statusCode:     http.StatusOK,
+			expContentType: "application/zip",
+		},
+		"invalid_user_id": {
+			userID:     "abc",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidUserID,
+		},
+		"invalid_format": {
+			userID:     "10",
+			format:     "xml",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidExportFormat,
+		},
+		"permission_denied": {
+			userID:        "10",
+			mockResultErr: userServ.ErrPermissionDenied,
+			statusCode:    http.StatusForbidden,
+			err:           ErrPermissionDenied,
+		},
+		"user_not_found": {
+			userID:        "10",
+			mockResultErr: userServ.ErrUserNotFound,
+			statusCode:    http.StatusNotFound,
+			err:           ErrUserNotFound,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+tc.userID+"/data-export?format="+tc.format, nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.userID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("ExportUserData", r.Context(), 10).Return(userServ.UserData{
+				ExportedAt: exportedAt,
+				Profile:    userServ.Profile{ID: 10, Email: "mai@example.com"},
+			}, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.ExportUserData(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, tc.expContentType, w.Header().Get("Content-Type"))
+			require.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=user_10_data_")
+			if tc.expContentType == "application/json" {
+				require.Contains(t, w.Body.String(), `"email": "mai@example.com"`)
+			}
+		})
+	}
+}
+
+func TestHandler_EraseUser(t *testing.T) {
+	tcs := map[string]struct {
+		userID        string
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			userID:     "10",
+			statusCode: http.StatusOK,
+			body:       "{\"success\":true,\"msg\":\"Erase user successfully\"}",
+		},
+		"invalid_user_id": {
+			userID:     "abc",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidUserID,
+		},
+		"user_cannot_be_erased": {
+			userID:        "10",
+			mockResultErr: userServ.ErrUserCannotBeErased,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrUserCannotBeErased,
+		},
+		"user_not_found": {
+			userID:        "10",
+			mockResultErr: userServ.ErrUserNotFound,
+			statusCode:    http.StatusNotFound,
+			err:           ErrUserNotFound,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+tc.userID+"/erase", nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.userID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("EraseUser", r.Context(), 10).Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.EraseUser(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				if tc.err == ErrInvalidUserID {
+					serviceMock.AssertNotCalled(t, "EraseUser", mock.Anything, mock.Anything)
+				}
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 
 	productServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
@@ -9,43 +10,119 @@ import (
 )
 
 var (
-	ErrNameCannotBeBlank      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "name cannot be blank"}
-	ErrEmailCannotBeBlank     = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "email cannot be blank"}
-	ErrPhoneCannotBeBlank     = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "phone cannot be blank"}
-	ErrPasswordCannotBeBlank  = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "password cannot be blank"}
-	ErrRoleCannotBeBlank      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "role cannot be blank"}
-	ErrInvalidEmail           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_email", Desc: "email is invalid"}
-	ErrInvalidRole            = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_role", Desc: "role is invalid"}
-	ErrInvalidSortField       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_sort_field", Desc: "sort field is invalid"}
-	ErrInvalidSortType        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_sort_type", Desc: "sort type is invalid"}
-	ErrUserIDExisted          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "user_id_existed", Desc: "user id is already exists"}
-	ErrEmailExisted           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "email_existed", Desc: "email is already exists"}
-	ErrInvalidBodyRequest     = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_body_request", Desc: "body request is invalid"}
-	ErrInvalidID              = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_id", Desc: "id is invalid"}
-	ErrInvalidPrice           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_price", Desc: "price is invalid"}
-	ErrTitleCannotBeBlank     = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "title cannot be blank"}
-	ErrInvalidQuantity        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_quantity", Desc: "quantity is invalid"}
-	ErrInvalidUserID          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_user_id", Desc: "user id is invalid"}
-	ErrInvalidPaginationPage  = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_page", Desc: "page is invalid"}
-	ErrInvalidPaginationLimit = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_limit", Desc: "limit is invalid"}
-	ErrInvalidOrderBy         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_order_by", Desc: "order by is invalid"}
-	ErrInvalidPriceRange      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_price_range", Desc: "price range is invalid"}
-	ErrFileSizeTooLarge       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "file_size_too_large", Desc: "file size too large"}
-	ErrInvalidFileType        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_file_type", Desc: "file type is invalid"}
-	ErrItemsCannotBeBlank     = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "items cannot be blank"}
-	ErrInvalidProductID       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_product_id", Desc: "product id is invalid"}
-	ErrInvalidDiscount        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_discount", Desc: "discount is invalid"}
-	ErrInvalidFileName        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_file_name", Desc: "file name is invalid"}
-	ErrInvalidOrderID         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_order_id", Desc: "order id is invalid"}
-	ErrInvalidOrderStatus     = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_order_status", Desc: "order status is invalid"}
-	ErrPasswordIncorrect      = utils.ErrorResponse{Status: http.StatusUnauthorized, Code: "incorrect_password", Desc: "password is incorrect"}
-	ErrEmailNotExist          = utils.ErrorResponse{Status: http.StatusUnauthorized, Code: "email_not_exist", Desc: "email does not exist"}
-	ErrFileNotExist           = utils.ErrorResponse{Status: http.StatusNotFound, Code: "file_not_exist", Desc: "file does not exist"}
-	ErrUserNotExist           = utils.ErrorResponse{Status: http.StatusNotFound, Code: "user_not_exist", Desc: "user does not exist"}
-	ErrProductNotFound        = utils.ErrorResponse{Status: http.StatusNotFound, Code: "product_not_found", Desc: "product is not found"}
-	ErrUserNotFound           = utils.ErrorResponse{Status: http.StatusNotFound, Code: "user_not_found", Desc: "user is not found"}
-	ErrInternalServerError    = utils.ErrorResponse{Status: http.StatusInternalServerError, Code: "internal_error", Desc: "internal server error"}
-	ErrFileCannotBeCreated    = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "file_cannot_be_created", Desc: "file cannot be created"}
+	ErrNameCannotBeBlank        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "name cannot be blank"}
+	ErrEmailCannotBeBlank       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "email cannot be blank"}
+	ErrPhoneCannotBeBlank       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "phone cannot be blank"}
+	ErrPasswordCannotBeBlank    = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "password cannot be blank"}
+	ErrRoleCannotBeBlank        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "role cannot be blank"}
+	ErrTokenCannotBeBlank       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "token cannot be blank"}
+	ErrCodeCannotBeBlank        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "code cannot be blank"}
+	ErrReasonCannotBeBlank      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "reason cannot be blank"}
+	ErrLine1CannotBeBlank       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "line1 cannot be blank"}
+	ErrCityCannotBeBlank        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "city cannot be blank"}
+	ErrInvalidCountry           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_country", Desc: "country must be an ISO 3166-1 alpha-2 code"}
+	ErrInvalidPostalCode        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_postal_code", Desc: "postal code is invalid for the country"}
+	ErrInvalidCSVHeader         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_csv_header", Desc: "csv header is invalid, name and email columns are required"}
+	ErrInvalidCSVFile           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_csv_file", Desc: "csv file is invalid"}
+	ErrTooManyCSVRows           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "too_many_csv_rows", Desc: "csv file has too many rows"}
+	ErrInvalidAddressID         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_address_id", Desc: "address id is invalid"}
+	ErrAddressNotExist          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "address_not_exist", Desc: "address does not exist"}
+	ErrTwoFactorNotEnrolled     = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "two_factor_not_enrolled", Desc: "two-factor authentication is not enrolled"}
+	ErrInvalidScope             = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_scope", Desc: "scope is invalid"}
+	ErrInvalidExpiresAt         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_expires_at", Desc: "expires at must be in the future"}
+	ErrInvalidEmail             = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_email", Desc: "email is invalid"}
+	ErrInvalidRole              = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_role", Desc: "role is invalid"}
+	ErrInvalidPermission        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_permission", Desc: "permission is invalid"}
+	ErrRoleExisted              = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "role_existed", Desc: "role is already exists"}
+	ErrInvalidOrganization      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_organization", Desc: "organization is invalid"}
+	ErrOrganizationExisted      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "organization_existed", Desc: "organization is already exists"}
+	ErrUserCannotBeImpersonated = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "user_cannot_be_impersonated", Desc: "user cannot be impersonated"}
+	ErrUserCannotBeErased       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "user_cannot_be_erased", Desc: "user cannot erase themselves"}
+	ErrInvalidExportFormat      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_export_format", Desc: "format must be json or zip"}
+	ErrInvalidSecurityEventType = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_security_event_type", Desc: "security event type is invalid"}
+	ErrInvalidTimeRange         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_time_range", Desc: "from must be before to"}
+	ErrInvalidSortField         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_sort_field", Desc: "sort field is invalid"}
+	ErrInvalidSortType          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_sort_type", Desc: "sort type is invalid"}
+	ErrUserIDExisted            = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "user_id_existed", Desc: "user id is already exists"}
+	ErrEmailExisted             = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "email_existed", Desc: "email is already exists"}
+	ErrInvalidBodyRequest       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_body_request", Desc: "body request is invalid"}
+	ErrInvalidID                = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_id", Desc: "id is invalid"}
+	ErrInvalidPrice             = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_price", Desc: "price is invalid"}
+	ErrTitleCannotBeBlank       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "title cannot be blank"}
+	ErrInvalidQuantity          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_quantity", Desc: "quantity is invalid"}
+	ErrInvalidUserID            = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_user_id", Desc: "user id is invalid"}
+	ErrInvalidPaginationPage    = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_page", Desc: "page is invalid"}
+	ErrInvalidPaginationLimit   = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_limit", Desc: "limit is invalid"}
+	ErrInvalidOrderBy           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_order_by", Desc: "order by is invalid"}
+	ErrInvalidPriceRange        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_price_range", Desc: "price range is invalid"}
+	ErrIncorrectPassword        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "incorrect_password", Desc: "current password is incorrect"}
+	ErrPasswordReused           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "password_reused", Desc: "password was used recently, please choose another password"}
+	ErrWeakPassword             = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "weak_password", Desc: "password does not satisfy the password policy"}
+	ErrFileSizeTooLarge         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "file_size_too_large", Desc: "file size too large"}
+	ErrInvalidFileType          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_file_type", Desc: "file type is invalid"}
+	ErrItemsCannotBeBlank       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "items cannot be blank"}
+	ErrInvalidProductID         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_product_id", Desc: "product id is invalid"}
+	ErrInvalidDiscount          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_discount", Desc: "discount is invalid"}
+	ErrInvalidFileName          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_file_name", Desc: "file name is invalid"}
+	ErrInvalidOrderID           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_order_id", Desc: "order id is invalid"}
+	ErrInvalidOrderStatus       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_order_status", Desc: "order status is invalid"}
+	ErrInvalidSearchQuery       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_search_query", Desc: "search query must be at most 256 characters"}
+	ErrInvalidPriceBuckets      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_price_buckets", Desc: "price buckets must be at most 20 ascending non-negative prices"}
+	ErrInvalidCategoryID        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_category_id", Desc: "category id is invalid"}
+	ErrInvalidSlug              = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_slug", Desc: "slug must only contain lowercase letters, digits and hyphens"}
+	ErrInvalidParentCategory    = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_parent_category", Desc: "parent category cannot be the category or one of its descendants"}
+	ErrParentCategoryNotExist   = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "parent_category_not_exist", Desc: "parent category does not exist"}
+	ErrCategoryNotExist         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "category_not_exist", Desc: "category does not exist"}
+	ErrCategorySlugExisted      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "category_slug_existed", Desc: "category slug is already exists"}
+	ErrInvalidVariantID         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_variant_id", Desc: "variant id is invalid"}
+	ErrSKUCannotBeBlank         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_input", Desc: "sku cannot be blank"}
+	ErrInvalidOptionName        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_option_name", Desc: "option name cannot be blank"}
+	ErrInvalidOptionValue       = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_option_value", Desc: "option value cannot be blank"}
+	ErrDuplicateOption          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "duplicate_option", Desc: "option names must be unique"}
+	ErrInvalidVariantOptions    = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_variant_options", Desc: "variant must have a value for each option of the product"}
+	ErrVariantSKUExisted        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "variant_sku_existed", Desc: "variant sku is already exists"}
+	ErrVariantExisted           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "variant_existed", Desc: "variant with the same option values is already exists"}
+	ErrVariantRequired          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "variant_required", Desc: "variant is required for the product with variants"}
+	ErrVariantNotExist          = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "variant_not_exist", Desc: "variant does not exist"}
+	ErrInvalidImageID           = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_image_id", Desc: "image id is invalid"}
+	ErrInvalidImage             = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_image", Desc: "image cannot be decoded"}
+	ErrInvalidImageSize         = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_image_size", Desc: "image size must be original, small, medium or large"}
+	ErrInvalidImageOrder        = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_image_order", Desc: "image order must contain every image of the product once"}
+	ErrInvalidCredentials       = utils.ErrorResponse{Status: http.StatusUnauthorized, Code: "invalid_credentials", Desc: "email or password is incorrect"}
+	ErrInvalidToken             = utils.ErrorResponse{Status: http.StatusUnauthorized, Code: "invalid_token", Desc: "token is invalid"}
+	ErrInvalidTwoFactorCode     = utils.ErrorResponse{Status: http.StatusUnauthorized, Code: "invalid_two_factor_code", Desc: "two-factor code is invalid"}
+	ErrInvalidAPIKey            = utils.ErrorResponse{Status: http.StatusUnauthorized, Code: "invalid_api_key", Desc: "API key is invalid"}
+	ErrInvalidOIDCLogin         = utils.ErrorResponse{Status: http.StatusUnauthorized, Code: "invalid_oidc_login", Desc: "OpenID Connect login is invalid, please try again"}
+	ErrUnauthorized             = utils.ErrorResponse{Status: http.StatusUnauthorized, Code: "unauthorized", Desc: "authentication is required"}
+	ErrPermissionDenied         = utils.ErrorResponse{Status: http.StatusForbidden, Code: "permission_denied", Desc: "permission denied"}
+	ErrInsufficientScope        = utils.ErrorResponse{Status: http.StatusForbidden, Code: "insufficient_scope", Desc: "the scope of the API key does not allow this operation"}
+	ErrImpersonationNotAllowed  = utils.ErrorResponse{Status: http.StatusForbidden, Code: "impersonation_not_allowed", Desc: "this action is not allowed while impersonating a user"}
+	ErrEmailNotVerified         = utils.ErrorResponse{Status: http.StatusForbidden, Code: "email_not_verified", Desc: "email is not verified"}
+	ErrUserInactive             = utils.ErrorResponse{Status: http.StatusForbidden, Code: "user_inactive", Desc: "user is inactive"}
+	ErrTooManyRequests          = utils.ErrorResponse{Status: http.StatusTooManyRequests, Code: "too_many_requests", Desc: "too many requests, please try again later"}
+	ErrTooManyLoginAttempts     = utils.ErrorResponse{Status: http.StatusTooManyRequests, Code: "too_many_login_attempts", Desc: "too many failed login attempts, please try again later"}
+	ErrFileNotExist             = utils.ErrorResponse{Status: http.StatusNotFound, Code: "file_not_exist", Desc: "file does not exist"}
+	ErrUserNotExist             = utils.ErrorResponse{Status: http.StatusNotFound, Code: "user_not_exist", Desc: "user does not exist"}
+	ErrProductNotFound          = utils.ErrorResponse{Status: http.StatusNotFound, Code: "product_not_found", Desc: "product is not found"}
+	ErrUserNotFound             = utils.ErrorResponse{Status: http.StatusNotFound, Code: "user_not_found", Desc: "user is not found"}
+	ErrAPIKeyNotFound           = utils.ErrorResponse{Status: http.StatusNotFound, Code: "api_key_not_found", Desc: "API key is not found"}
+	ErrOrganizationNotFound     = utils.ErrorResponse{Status: http.StatusNotFound, Code: "organization_not_found", Desc: "organization is not found"}
+	ErrRoleNotFound             = utils.ErrorResponse{Status: http.StatusNotFound, Code: "role_not_found", Desc: "role is not found"}
+	ErrAddressNotFound          = utils.ErrorResponse{Status: http.StatusNotFound, Code: "address_not_found", Desc: "address is not found"}
+	ErrCategoryNotFound         = utils.ErrorResponse{Status: http.StatusNotFound, Code: "category_not_found", Desc: "category is not found"}
+	ErrVariantNotFound          = utils.ErrorResponse{Status: http.StatusNotFound, Code: "variant_not_found", Desc: "variant is not found"}
+	ErrImageNotFound            = utils.ErrorResponse{Status: http.StatusNotFound, Code: "image_not_found", Desc: "image is not found"}
+	ErrTwoFactorEnabled         = utils.ErrorResponse{Status: http.StatusConflict, Code: "two_factor_enabled", Desc: "two-factor authentication is already enabled"}
+	ErrRoleInUse                = utils.ErrorResponse{Status: http.StatusConflict, Code: "role_in_use", Desc: "role is the primary role of users"}
+	ErrBuiltInRole              = utils.ErrorResponse{Status: http.StatusConflict, Code: "built_in_role", Desc: "built-in role cannot be renamed or deleted"}
+	ErrCategoryHasChildren      = utils.ErrorResponse{Status: http.StatusConflict, Code: "category_has_children", Desc: "category has child categories, move or delete them first"}
+	ErrProductHasVariants       = utils.ErrorResponse{Status: http.StatusConflict, Code: "product_has_variants", Desc: "options cannot be changed while the product has variants, delete them first"}
+	ErrVariantOutOfStock        = utils.ErrorResponse{Status: http.StatusConflict, Code: "variant_out_of_stock", Desc: "variant is out of stock"}
+	ErrImageTooLarge            = utils.ErrorResponse{Status: http.StatusRequestEntityTooLarge, Code: "image_too_large", Desc: "image must be at most 5 MB and 25 megapixels"}
+	ErrUnsupportedImageType     = utils.ErrorResponse{Status: http.StatusUnsupportedMediaType, Code: "unsupported_image_type", Desc: "image must be a jpeg, png or gif image"}
+	ErrOIDCNotConfigured        = utils.ErrorResponse{Status: http.StatusNotImplemented, Code: "oidc_not_configured", Desc: "OpenID Connect login is not configured"}
+	ErrInternalServerError      = utils.ErrorResponse{Status: http.StatusInternalServerError, Code: "internal_error", Desc: "internal server error"}
+	ErrFileCannotBeCreated      = utils.ErrorResponse{Status: http.StatusBadRequest, Code: "file_cannot_be_created", Desc: "file cannot be created"}
 )
 
 // handleUserError handle error and write to response
@@ -53,18 +130,77 @@ func handleUserError(w http.ResponseWriter, err error) {
 	var v, ok = err.(utils.ErrorResponse)
 	if ok {
 		utils.WriteJSONResponse(w, v.Status, v)
+	} else if errors.Is(err, userServ.ErrWeakPassword) {
+		// The description tells which rule of the password policy is broken
+		utils.WriteJSONResponse(w, ErrWeakPassword.Status, utils.ErrorResponse{Status: ErrWeakPassword.Status, Code: ErrWeakPassword.Code, Desc: err.Error()})
 	} else {
 		switch err {
-		case userServ.ErrEmailNotExist:
-			utils.WriteJSONResponse(w, ErrEmailNotExist.Status, ErrEmailNotExist)
+		case userServ.ErrInvalidCredentials:
+			utils.WriteJSONResponse(w, ErrInvalidCredentials.Status, ErrInvalidCredentials)
 		case userServ.ErrEmailExisted:
 			utils.WriteJSONResponse(w, ErrEmailExisted.Status, ErrEmailExisted)
 		case userServ.ErrUserIDExisted:
 			utils.WriteJSONResponse(w, ErrUserIDExisted.Status, ErrUserIDExisted)
 		case userServ.ErrUserNotFound:
 			utils.WriteJSONResponse(w, ErrUserNotFound.Status, ErrUserNotFound)
-		case userServ.ErrPasswordIncorrect:
-			utils.WriteJSONResponse(w, ErrPasswordIncorrect.Status, ErrPasswordIncorrect)
+		case userServ.ErrTooManyLoginAttempts:
+			utils.WriteJSONResponse(w, ErrTooManyLoginAttempts.Status, ErrTooManyLoginAttempts)
+		case userServ.ErrTwoFactorEnabled:
+			utils.WriteJSONResponse(w, ErrTwoFactorEnabled.Status, ErrTwoFactorEnabled)
+		case userServ.ErrTwoFactorNotEnrolled:
+			utils.WriteJSONResponse(w, ErrTwoFactorNotEnrolled.Status, ErrTwoFactorNotEnrolled)
+		case userServ.ErrInvalidTwoFactorCode:
+			utils.WriteJSONResponse(w, ErrInvalidTwoFactorCode.Status, ErrInvalidTwoFactorCode)
+		case userServ.ErrInvalidToken:
+			utils.WriteJSONResponse(w, ErrInvalidToken.Status, ErrInvalidToken)
+		case userServ.ErrPermissionDenied:
+			utils.WriteJSONResponse(w, ErrPermissionDenied.Status, ErrPermissionDenied)
+		case userServ.ErrEmailNotVerified:
+			utils.WriteJSONResponse(w, ErrEmailNotVerified.Status, ErrEmailNotVerified)
+		case userServ.ErrUserInactive:
+			utils.WriteJSONResponse(w, ErrUserInactive.Status, ErrUserInactive)
+		case userServ.ErrTooManyRequests:
+			utils.WriteJSONResponse(w, ErrTooManyRequests.Status, ErrTooManyRequests)
+		case userServ.ErrInvalidAPIKey:
+			utils.WriteJSONResponse(w, ErrInvalidAPIKey.Status, ErrInvalidAPIKey)
+		case userServ.ErrAPIKeyNotFound:
+			utils.WriteJSONResponse(w, ErrAPIKeyNotFound.Status, ErrAPIKeyNotFound)
+		case userServ.ErrRoleNotFound:
+			utils.WriteJSONResponse(w, ErrRoleNotFound.Status, ErrRoleNotFound)
+		case userServ.ErrRoleExisted:
+			utils.WriteJSONResponse(w, ErrRoleExisted.Status, ErrRoleExisted)
+		case userServ.ErrRoleInUse:
+			utils.WriteJSONResponse(w, ErrRoleInUse.Status, ErrRoleInUse)
+		case userServ.ErrBuiltInRole:
+			utils.WriteJSONResponse(w, ErrBuiltInRole.Status, ErrBuiltInRole)
+		case userServ.ErrInvalidPermission:
+			utils.WriteJSONResponse(w, ErrInvalidPermission.Status, ErrInvalidPermission)
+		case userServ.ErrIncorrectPassword:
+			utils.WriteJSONResponse(w, ErrIncorrectPassword.Status, ErrIncorrectPassword)
+		case userServ.ErrPasswordReused:
+			utils.WriteJSONResponse(w, ErrPasswordReused.Status, ErrPasswordReused)
+		case userServ.ErrInvalidOIDCLogin:
+			utils.WriteJSONResponse(w, ErrInvalidOIDCLogin.Status, ErrInvalidOIDCLogin)
+		case userServ.ErrOrganizationNotFound:
+			utils.WriteJSONResponse(w, ErrOrganizationNotFound.Status, ErrOrganizationNotFound)
+		case userServ.ErrOrganizationExisted:
+			utils.WriteJSONResponse(w, ErrOrganizationExisted.Status, ErrOrganizationExisted)
+		case userServ.ErrImpersonationNotAllowed:
+			utils.WriteJSONResponse(w, ErrImpersonationNotAllowed.Status, ErrImpersonationNotAllowed)
+		case userServ.ErrUserCannotBeImpersonated:
+			utils.WriteJSONResponse(w, ErrUserCannotBeImpersonated.Status, ErrUserCannotBeImpersonated)
+		case userServ.ErrUserCannotBeErased:
+			utils.WriteJSONResponse(w, ErrUserCannotBeErased.Status, ErrUserCannotBeErased)
+		case userServ.ErrOIDCNotConfigured:
+			utils.WriteJSONResponse(w, ErrOIDCNotConfigured.Status, ErrOIDCNotConfigured)
+		case userServ.ErrAddressNotFound:
+			utils.WriteJSONResponse(w, ErrAddressNotFound.Status, ErrAddressNotFound)
+		case userServ.ErrInvalidCSVHeader:
+			utils.WriteJSONResponse(w, ErrInvalidCSVHeader.Status, ErrInvalidCSVHeader)
+		case userServ.ErrInvalidCSVFile:
+			utils.WriteJSONResponse(w, ErrInvalidCSVFile.Status, ErrInvalidCSVFile)
+		case userServ.ErrTooManyCSVRows:
+			utils.WriteJSONResponse(w, ErrTooManyCSVRows.Status, ErrTooManyCSVRows)
 		default:
 			utils.WriteJSONResponse(w, ErrInternalServerError.Status, ErrInternalServerError)
 		}
@@ -86,6 +222,44 @@ func handleProductError(w http.ResponseWriter, err error) {
 			utils.WriteJSONResponse(w, ErrFileCannotBeCreated.Status, ErrFileCannotBeCreated)
 		case productServ.ErrFileCannotBeRead:
 			utils.WriteJSONResponse(w, ErrFileNotExist.Status, ErrFileNotExist)
+		case productServ.ErrPermissionDenied:
+			utils.WriteJSONResponse(w, ErrPermissionDenied.Status, ErrPermissionDenied)
+		case productServ.ErrCategoryNotFound:
+			utils.WriteJSONResponse(w, ErrCategoryNotFound.Status, ErrCategoryNotFound)
+		case productServ.ErrCategoryNotExist:
+			utils.WriteJSONResponse(w, ErrCategoryNotExist.Status, ErrCategoryNotExist)
+		case productServ.ErrParentCategoryNotExist:
+			utils.WriteJSONResponse(w, ErrParentCategoryNotExist.Status, ErrParentCategoryNotExist)
+		case productServ.ErrInvalidParentCategory:
+			utils.WriteJSONResponse(w, ErrInvalidParentCategory.Status, ErrInvalidParentCategory)
+		case productServ.ErrCategorySlugExisted:
+			utils.WriteJSONResponse(w, ErrCategorySlugExisted.Status, ErrCategorySlugExisted)
+		case productServ.ErrCategoryHasChildren:
+			utils.WriteJSONResponse(w, ErrCategoryHasChildren.Status, ErrCategoryHasChildren)
+		case productServ.ErrProductHasVariants:
+			utils.WriteJSONResponse(w, ErrProductHasVariants.Status, ErrProductHasVariants)
+		case productServ.ErrDuplicateOption:
+			utils.WriteJSONResponse(w, ErrDuplicateOption.Status, ErrDuplicateOption)
+		case productServ.ErrVariantNotFound:
+			utils.WriteJSONResponse(w, ErrVariantNotFound.Status, ErrVariantNotFound)
+		case productServ.ErrInvalidVariantOptions:
+			utils.WriteJSONResponse(w, ErrInvalidVariantOptions.Status, ErrInvalidVariantOptions)
+		case productServ.ErrVariantSKUExisted:
+			utils.WriteJSONResponse(w, ErrVariantSKUExisted.Status, ErrVariantSKUExisted)
+		case productServ.ErrVariantExisted:
+			utils.WriteJSONResponse(w, ErrVariantExisted.Status, ErrVariantExisted)
+		case productServ.ErrImageNotFound:
+			utils.WriteJSONResponse(w, ErrImageNotFound.Status, ErrImageNotFound)
+		case productServ.ErrUnsupportedImageType:
+			utils.WriteJSONResponse(w, ErrUnsupportedImageType.Status, ErrUnsupportedImageType)
+		case productServ.ErrInvalidImage:
+			utils.WriteJSONResponse(w, ErrInvalidImage.Status, ErrInvalidImage)
+		case productServ.ErrImageTooLarge:
+			utils.WriteJSONResponse(w, ErrImageTooLarge.Status, ErrImageTooLarge)
+		case productServ.ErrInvalidImageSize:
+			utils.WriteJSONResponse(w, ErrInvalidImageSize.Status, ErrInvalidImageSize)
+		case productServ.ErrInvalidImageOrder:
+			utils.WriteJSONResponse(w, ErrInvalidImageOrder.Status, ErrInvalidImageOrder)
 		default:
 			utils.WriteJSONResponse(w, ErrInternalServerError.Status, ErrInternalServerError)
 		}
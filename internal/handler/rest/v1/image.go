@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	productServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+const (
+	MsgUpdateImageOrder  = "Update image order successfully"
+	MsgSetPrimaryImage   = "Set primary image successfully"
+	MsgDeleteImage       = "Delete image successfully"
+	maxImageFormOverhead = 1024 * 1024 // the other fields and the headers of the multipart form
+)
+
+type imageOrderRequest struct {
+	ImageIDs []int `json:"image_ids"` // every image of the product in the new order
+}
+
+type imageResponse struct {
+	ID          int               `json:"id"`
+	ContentType string            `json:"content_type"`
+	Size        int               `json:"size"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	Position    int               `json:"position"`
+	IsPrimary   bool              `json:"is_primary"`
+	URLs        map[string]string `json:"urls"` // size name -> download url
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// toImageResponse converts the image of the service to the response with the download url of each size
+func toImageResponse(image productServ.Image) imageResponse {
+	urls := make(map[string]string, len(image.Sizes))
+	for _, size := range image.Sizes {
+		urls[size] = fmt.Sprintf("%s/api/v1/products/%d/images/%d/%s", os.Getenv("APP_URL"), image.ProductID, image.ID, size)
+	}
+	return imageResponse{
+		ID:          image.ID,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		Width:       image.Width,
+		Height:      image.Height,
+		Position:    image.Position,
+		IsPrimary:   image.IsPrimary,
+		URLs:        urls,
+		CreatedAt:   image.CreatedAt,
+		UpdatedAt:   image.UpdatedAt,
+	}
+}
+
+func validateImageID(id string) (int, error) {
+	result, err := strconv.Atoi(id)
+	if err != nil || result <= 0 {
+		return 0, ErrInvalidImageID
+	}
+	return result, nil
+}
+
+// GetProductImages handle request to get the images of a product
+func (h Handler) GetProductImages(w http.ResponseWriter, r *http.Request) {
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	images, err := h.productServ.GetProductImages(r.Context(), productID)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	result := make([]imageResponse, len(images))
+	for i, image := range images {
+		result[i] = toImageResponse(image)
+	}
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// GetProductImageFile handle request to download the original or a thumbnail of an image
+func (h Handler) GetProductImageFile(w http.ResponseWriter, r *http.Request) {
+	// 1. Get product ID, image ID and size from url param
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+	id, err := validateImageID(chi.URLParam(r, "imageID"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Get the file
+	file, err := h.productServ.GetProductImageFile(r.Context(), productID, id, chi.URLParam(r, "size"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+	defer file.Body.Close()
+
+	// 3. The files of an image never change, a new upload has a new id
+	w.Header().Set("Content-Type", file.ContentType)
+	if file.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+	}
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, file.Body); err != nil {
+		log.Printf("Error when write image %d: %v\n", id, err)
+	}
+}
+
+// UploadProductImage handle request to upload an image of a product as the multipart form field "image",
+// the form value "is_primary" makes it the primary image
+func (h Handler) UploadProductImage(w http.ResponseWriter, r *http.Request) {
+	// 1. Get product ID from url param
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Check max size of the form, the image is checked again after it is read
+	r.Body = http.MaxBytesReader(w, r.Body, productServ.MaxImageSize+maxImageFormOverhead)
+	if err := r.ParseMultipartForm(productServ.MaxImageSize); err != nil {
+		if r.ContentLength > productServ.MaxImageSize+maxImageFormOverhead {
+			handleProductError(w, ErrImageTooLarge)
+			return
+		}
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// 3. Get the image and the primary flag
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, productServ.MaxImageSize+1))
+	if err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	if len(data) > productServ.MaxImageSize {
+		handleProductError(w, ErrImageTooLarge)
+		return
+	}
+	isPrimary := false
+	if value := r.FormValue("is_primary"); value != "" {
+		if isPrimary, err = strconv.ParseBool(value); err != nil {
+			handleProductError(w, ErrInvalidBodyRequest)
+			return
+		}
+	}
+
+	// 4. Store the image, its content type is sniffed by the service
+	result, err := h.productServ.UploadProductImage(r.Context(), productID, productServ.ImageInput{Data: data, IsPrimary: isPrimary})
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, toImageResponse(result))
+}
+
+// UpdateProductImageOrder handle request to reorder the images of a product
+func (h Handler) UpdateProductImageOrder(w http.ResponseWriter, r *http.Request) {
+	// 1. Get product ID from url param
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req imageOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	for _, id := range req.ImageIDs {
+		if id <= 0 {
+			handleProductError(w, ErrInvalidImageID)
+			return
+		}
+	}
+
+	// 3. Reorder the images
+	if err := h.productServ.SetProductImagePositions(r.Context(), productID, req.ImageIDs); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUpdateImageOrder,
+	})
+}
+
+// SetPrimaryProductImage handle request to make an image the primary image of its product
+func (h Handler) SetPrimaryProductImage(w http.ResponseWriter, r *http.Request) {
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+	id, err := validateImageID(chi.URLParam(r, "imageID"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	if err := h.productServ.SetPrimaryProductImage(r.Context(), productID, id); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgSetPrimaryImage,
+	})
+}
+
+// DeleteProductImage handle request to delete an image of a product
+func (h Handler) DeleteProductImage(w http.ResponseWriter, r *http.Request) {
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+	id, err := validateImageID(chi.URLParam(r, "imageID"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	if err := h.productServ.DeleteProductImage(r.Context(), productID, id); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgDeleteImage,
+	})
+}
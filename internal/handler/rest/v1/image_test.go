@@ -0,0 +1,363 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	productServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/storage"
+)
+
+// withImageURLParams adds the url params of the routes of an image
+func withImageURLParams(r *http.Request, imageID, size string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "10")
+	rctx.URLParams.Add("imageID", imageID)
+	rctx.URLParams.Add("size", size)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_GetProductImages(t *testing.T) {
+	// GIVEN
+	t.Setenv("APP_URL", "http://localhost:3000")
+	createdAt := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	r := withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/products/10/images", nil), "id", "10")
+	w := httptest.NewRecorder()
+
+	serviceMock := new(productServ.Mock)
+	serviceMock.On("GetProductImages", r.Context(), 10).Return([]productServ.Image{{
+		ID: 7, ProductID: 10, ContentType: "image/png", Size: 2048, Width: 800, Height: 600, IsPrimary: true,
+		Sizes: []string{"original", "small"}, CreatedAt: createdAt, UpdatedAt: createdAt,
+	}}, nil)
+
+	handler := NewHandler(nil, serviceMock, nil)
+
+	// WHEN
+	handler.GetProductImages(w, r)
+
+	// THEN
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `[{"id":7,"content_type":"image/png","size":2048,"width":800,"height":600,"position":0,"is_primary":true,`+
+		`"urls":{"original":"http://localhost:3000/api/v1/products/10/images/7/original","small":"http://localhost:3000/api/v1/products/10/images/7/small"},`+
+		`"created_at":"2022-01-01T10:00:00Z","updated_at":"2022-01-01T10:00:00Z"}]`, w.Body.String())
+}
+
+func TestHandler_GetProductImageFile(t *testing.T) {
+	tcs := map[string]struct {
+		imageID    string
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			imageID:    "7",
+			statusCode: http.StatusOK,
+		},
+		"invalid_image_id": {
+			imageID:    "abc",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidImageID,
+		},
+		"invalid_image_size": {
+			imageID:    "7",
+			mockErr:    productServ.ErrInvalidImageSize,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidImageSize,
+		},
+		"image_not_found": {
+			imageID:    "7",
+			mockErr:    productServ.ErrImageNotFound,
+			statusCode: http.StatusNotFound,
+			err:        ErrImageNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withImageURLParams(httptest.NewRequest(http.MethodGet, "/api/v1/products/10/images/"+tc.imageID+"/small", nil), tc.imageID, "small")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("GetProductImageFile", r.Context(), 10, 7, "small").Return(storage.Object{
+				Body: io.NopCloser(strings.NewReader("thumbnail")), ContentType: "image/png", Size: 9,
+			}, tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.GetProductImageFile(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, "thumbnail", w.Body.String())
+			require.Equal(t, "image/png", w.Header().Get("Content-Type"))
+			require.Equal(t, "9", w.Header().Get("Content-Length"))
+			require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		})
+	}
+}
+
+func TestHandler_UploadProductImage(t *testing.T) {
+	createdAt := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		fieldName    string
+		data         []byte
+		isPrimary    string
+		mockInput    productServ.ImageInput
+		mockErr      error
+		isCallToServ bool
+		statusCode   int
+		expBody      string
+		err          error
+	}{
+		"success": {
+			fieldName:    "image",
+			data:         []byte("png data"),
+			isPrimary:    "true",
+			mockInput:    productServ.ImageInput{Data: []byte("png data"), IsPrimary: true},
+			isCallToServ: true,
+			statusCode:   http.StatusCreated,
+			expBody: `{"id":7,"content_type":"image/png","size":8,"width":1,"height":1,"position":0,"is_primary":true,` +
+				`"urls":{"original":"/api/v1/products/10/images/7/original"},"created_at":"2022-01-01T10:00:00Z","updated_at":"2022-01-01T10:00:00Z"}`,
+		},
+		"missing_image": {
+			fieldName:  "file",
+			data:       []byte("png data"),
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidBodyRequest,
+		},
+		"invalid_is_primary": {
+			fieldName:  "image",
+			data:       []byte("png data"),
+			isPrimary:  "maybe",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidBodyRequest,
+		},
+		"image_too_large": {
+			fieldName:  "image",
+			data:       make([]byte, productServ.MaxImageSize+1),
+			statusCode: http.StatusRequestEntityTooLarge,
+			err:        ErrImageTooLarge,
+		},
+		"unsupported_image_type": {
+			fieldName:    "image",
+			data:         []byte("%PDF-1.4"),
+			mockInput:    productServ.ImageInput{Data: []byte("%PDF-1.4")},
+			mockErr:      productServ.ErrUnsupportedImageType,
+			isCallToServ: true,
+			statusCode:   http.StatusUnsupportedMediaType,
+			err:          ErrUnsupportedImageType,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("APP_URL", "")
+			reqBody := new(bytes.Buffer)
+			mw := multipart.NewWriter(reqBody)
+			formWriter, err := mw.CreateFormFile(tc.fieldName, "photo.png")
+			require.NoError(t, err)
+			_, err = formWriter.Write(tc.data)
+			require.NoError(t, err)
+			if tc.isPrimary != "" {
+				require.NoError(t, mw.WriteField("is_primary", tc.isPrimary))
+			}
+			mw.Close()
+
+			r := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/products/10/images", reqBody), "id", "10")
+			r.Header.Set("Content-Type", mw.FormDataContentType())
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("UploadProductImage", r.Context(), 10, tc.mockInput).Return(productServ.Image{
+				ID: 7, ProductID: 10, ContentType: "image/png", Size: len(tc.data), Width: 1, Height: 1, IsPrimary: true,
+				Sizes: []string{"original"}, CreatedAt: createdAt, UpdatedAt: createdAt,
+			}, tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.UploadProductImage(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if !tc.isCallToServ {
+				serviceMock.AssertNotCalled(t, "UploadProductImage", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, tc.expBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_UpdateProductImageOrder(t *testing.T) {
+	tcs := map[string]struct {
+		body       string
+		mockIDs    []int
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			body:       `{"image_ids": [3, 1, 2]}`,
+			mockIDs:    []int{3, 1, 2},
+			statusCode: http.StatusOK,
+		},
+		"invalid_request_body": {
+			body:       `{{abc`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidBodyRequest,
+		},
+		"invalid_image_id": {
+			body:       `{"image_ids": [3, 0]}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidImageID,
+		},
+		"invalid_image_order": {
+			body:       `{"image_ids": [3]}`,
+			mockIDs:    []int{3},
+			mockErr:    productServ.ErrInvalidImageOrder,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidImageOrder,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withURLParam(httptest.NewRequest(http.MethodPut, "/api/v1/products/10/images/order", strings.NewReader(tc.body)), "id", "10")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("SetProductImagePositions", r.Context(), 10, tc.mockIDs).Return(tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.UpdateProductImageOrder(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, `{"success":true,"msg":"Update image order successfully"}`, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_SetPrimaryProductImage(t *testing.T) {
+	tcs := map[string]struct {
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			statusCode: http.StatusOK,
+		},
+		"image_not_found": {
+			mockErr:    productServ.ErrImageNotFound,
+			statusCode: http.StatusNotFound,
+			err:        ErrImageNotFound,
+		},
+		"permission_denied": {
+			mockErr:    productServ.ErrPermissionDenied,
+			statusCode: http.StatusForbidden,
+			err:        ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withImageURLParams(httptest.NewRequest(http.MethodPut, "/api/v1/products/10/images/7/primary", nil), "7", "")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("SetPrimaryProductImage", r.Context(), 10, 7).Return(tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.SetPrimaryProductImage(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, `{"success":true,"msg":"Set primary image successfully"}`, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_DeleteProductImage(t *testing.T) {
+	tcs := map[string]struct {
+		imageID    string
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			imageID:    "7",
+			statusCode: http.StatusOK,
+		},
+		"invalid_image_id": {
+			imageID:    "-1",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidImageID,
+		},
+		"image_not_found": {
+			imageID:    "7",
+			mockErr:    productServ.ErrImageNotFound,
+			statusCode: http.StatusNotFound,
+			err:        ErrImageNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withImageURLParams(httptest.NewRequest(http.MethodDelete, "/api/v1/products/10/images/"+tc.imageID, nil), tc.imageID, "")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("DeleteProductImage", r.Context(), 10, 7).Return(tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.DeleteProductImage(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, `{"success":true,"msg":"Delete image successfully"}`, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+type ImpersonationRequest struct {
+	// Reason is recorded in the audit trail of the session
+	Reason string `json:"reason"`
+}
+
+// StartImpersonation handle request of an admin to get a short-lived access token of another user
+func (h Handler) StartImpersonation(w http.ResponseWriter, r *http.Request) {
+	// 1. Get user ID from url param
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req ImpersonationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		handleUserError(w, ErrReasonCannotBeBlank)
+		return
+	}
+
+	// 3. Start impersonation
+	result, err := h.userServ.StartImpersonation(r.Context(), userServ.ImpersonationInput{
+		SubjectID: userID,
+		Reason:    reason,
+	})
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// EndImpersonation handle request to end the impersonation session of the bearer token
+func (h Handler) EndImpersonation(w http.ResponseWriter, r *http.Request) {
+	if err := h.userServ.EndImpersonation(r.Context(), jwtauth.TokenFromHeader(r)); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgEndImpersonation,
+	})
+}
+
+// GetImpersonations handle request to get the audit trail of impersonation sessions
+func (h Handler) GetImpersonations(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.GetImpersonations(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
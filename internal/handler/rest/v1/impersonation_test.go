@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+)
+
+func TestHandler_StartImpersonation(t *testing.T) {
+	tcs := map[string]struct {
+		userID        string
+		reqBody       string
+		mockInput     userServ.ImpersonationInput
+		mockResult    userServ.LoginResponse
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			userID:     "10",
+			reqBody:    `{"reason":" order looks wrong "}`,
+			mockInput:  userServ.ImpersonationInput{SubjectID: 10, Reason: "order looks wrong"},
+			mockResult: userServ.LoginResponse{AccessToken: "token", Scope: "GUEST", ExpiresIn: 900, TokenType: "Bearer"},
+			statusCode: http.StatusOK,
+			body:       "{\"access_token\":\"token\",\"scope\":\"GUEST\",\"expires_in\":900,\"token_type\":\"Bearer\"}",
+		},
+		"invalid_user_id": {
+			userID:     "abc",
+			reqBody:    `{"reason":"order looks wrong"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidUserID,
+		},
+		"reason_can_not_be_blank": {
+			userID:     "10",
+			reqBody:    `{"reason":" "}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrReasonCannotBeBlank,
+		},
+		"user_cannot_be_impersonated": {
+			userID:        "10",
+			reqBody:       `{"reason":"order looks wrong"}`,
+			mockInput:     userServ.ImpersonationInput{SubjectID: 10, Reason: "order looks wrong"},
+			mockResultErr: userServ.ErrUserCannotBeImpersonated,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrUserCannotBeImpersonated,
+		},
+		"already_impersonating": {
+			userID:        "10",
+			reqBody:       `{"reason":"order looks wrong"}`,
+			mockInput:     userServ.ImpersonationInput{SubjectID: 10, Reason: "order looks wrong"},
+			mockResultErr: userServ.ErrImpersonationNotAllowed,
+			statusCode:    http.StatusForbidden,
+			err:           ErrImpersonationNotAllowed,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+tc.userID+"/impersonate", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.userID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("StartImpersonation", r.Context(), tc.mockInput).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.StartImpersonation(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_EndImpersonation(t *testing.T) {
+	tcs := map[string]struct {
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			statusCode: http.StatusOK,
+			body:       "{\"success\":true,\"msg\":\"End impersonation successfully\"}",
+		},
+		"not_impersonation_token": {
+			mockResultErr: userServ.ErrInvalidToken,
+			statusCode:    http.StatusUnauthorized,
+			err:           ErrInvalidToken,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/me/impersonation", nil)
+			r.Header.Set("Authorization", "Bearer impersonation-token")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("EndImpersonation", r.Context(), "impersonation-token").Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.EndImpersonation(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
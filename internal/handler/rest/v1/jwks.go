@@ -0,0 +1,20 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+// GetJWKS handle request to get the public keys which verify the access tokens
+func (h Handler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.GetJWKS(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// Verifiers may cache the keys, a new key is published before it signs tokens
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+)
+
+func TestHandler_GetJWKS(t *testing.T) {
+	publicKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public()
+	key, err := jwk.New(publicKey)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, "2022-07"))
+	require.NoError(t, key.Set(jwk.AlgorithmKey, "EdDSA"))
+	keys := jwk.NewSet()
+	keys.Add(key)
+
+	tcs := map[string]struct {
+		mockResult    jwk.Set
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			mockResult: keys,
+			statusCode: http.StatusOK,
+			body:       "{\"keys\":[{\"alg\":\"EdDSA\",\"crv\":\"Ed25519\",\"kid\":\"2022-07\",\"kty\":\"OKP\",\"x\":\"O2onvM62pC1io6jQKm8Nc2UyFXcd4kOmOsBIoYtZ2ik\"}]}",
+		},
+		"error_load_keys": {
+			mockResult:    jwk.NewSet(),
+			mockResultErr: errors.New("active key \"2022-07\" is not found"),
+			statusCode:    http.StatusInternalServerError,
+			err:           ErrInternalServerError,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("GetJWKS", r.Context()).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.GetJWKS(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+				require.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
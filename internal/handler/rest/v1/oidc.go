@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+const (
+	// oidcLoginCookie keeps the started login request in the browser until the issuer redirects back to the callback
+	oidcLoginCookie     = "oidc_login"
+	oidcLoginCookiePath = "/api/v1/users/oidc"
+	oidcLoginExpireTime = 10 * time.Minute
+)
+
+// oidcLoginState is the content of the login cookie
+type oidcLoginState struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// StartOIDCLogin handle request to login with the OpenID Connect issuer, it redirects to the issuer
+func (h Handler) StartOIDCLogin(w http.ResponseWriter, r *http.Request) {
+	// Call start OIDC login func of service
+	request, err := h.userServ.StartOIDCLogin(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// Keep the state, nonce and code verifier in a cookie which only the callback receives
+	value, err := json.Marshal(oidcLoginState{
+		State:        request.State,
+		Nonce:        request.Nonce,
+		CodeVerifier: request.CodeVerifier,
+	})
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcLoginCookie,
+		Value:    base64.RawURLEncoding.EncodeToString(value),
+		Path:     oidcLoginCookiePath,
+		MaxAge:   int(oidcLoginExpireTime.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode, // the cookie is sent when the issuer redirects back
+	})
+
+	http.Redirect(w, r, request.AuthURL, http.StatusFound)
+}
+
+// OIDCCallback handle the redirect of the OpenID Connect issuer and returns the tokens of the user
+func (h Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	// The login request can only be completed once
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcLoginCookie,
+		Path:     oidcLoginCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// The issuer returns an error if the user cancelled the login
+	query := r.URL.Query()
+	if query.Get("error") != "" {
+		handleUserError(w, ErrInvalidOIDCLogin)
+		return
+	}
+	code := strings.TrimSpace(query.Get("code"))
+	if code == "" {
+		handleUserError(w, ErrCodeCannotBeBlank)
+		return
+	}
+
+	// Get the login request from the cookie
+	cookie, err := r.Cookie(oidcLoginCookie)
+	if err != nil {
+		handleUserError(w, ErrInvalidOIDCLogin)
+		return
+	}
+	value, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		handleUserError(w, ErrInvalidOIDCLogin)
+		return
+	}
+	var state oidcLoginState
+	if err = json.Unmarshal(value, &state); err != nil {
+		handleUserError(w, ErrInvalidOIDCLogin)
+		return
+	}
+
+	// Call OIDC callback func of service
+	result, err := h.userServ.OIDCCallback(r.Context(), userServ.OIDCCallbackInput{
+		Code:  code,
+		State: query.Get("state"),
+		Request: userServ.OIDCLoginRequest{
+			State:        state.State,
+			Nonce:        state.Nonce,
+			CodeVerifier: state.CodeVerifier,
+		},
+	})
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
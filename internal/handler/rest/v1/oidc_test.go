@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+)
+
+func TestHandler_StartOIDCLogin(t *testing.T) {
+	tcs := map[string]struct {
+		mockResult    userServ.OIDCLoginRequest
+		mockResultErr error
+		statusCode    int
+		expCookie     bool
+		err           error
+	}{
+		"success": {
+			mockResult: userServ.OIDCLoginRequest{
+				AuthURL:      "https://sso.example.com/authorize?state=state",
+				State:        "state",
+				Nonce:        "nonce",
+				CodeVerifier: "verifier",
+			},
+			statusCode: http.StatusFound,
+			expCookie:  true,
+		},
+		"not_configured": {
+			mockResultErr: userServ.ErrOIDCNotConfigured,
+			statusCode:    http.StatusNotImplemented,
+			err:           ErrOIDCNotConfigured,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users/oidc/login", nil)
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("StartOIDCLogin", r.Context()).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.StartOIDCLogin(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				require.Empty(t, w.Result().Cookies())
+				return
+			}
+			require.Equal(t, tc.mockResult.AuthURL, w.Header().Get("Location"))
+			cookies := w.Result().Cookies()
+			require.Len(t, cookies, 1)
+			require.Equal(t, oidcLoginCookie, cookies[0].Name)
+			require.True(t, cookies[0].HttpOnly)
+			require.Equal(t, oidcLoginCookiePath, cookies[0].Path)
+			value, err := base64.RawURLEncoding.DecodeString(cookies[0].Value)
+			require.NoError(t, err)
+			var state oidcLoginState
+			require.NoError(t, json.Unmarshal(value, &state))
+			require.Equal(t, oidcLoginState{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}, state)
+		})
+	}
+}
+
+func TestHandler_OIDCCallback(t *testing.T) {
+	loginCookie := func(state oidcLoginState) *http.Cookie {
+		value, _ := json.Marshal(state)
+		return &http.Cookie{Name: oidcLoginCookie, Value: base64.RawURLEncoding.EncodeToString(value)}
+	}
+
+	tcs := map[string]struct {
+		query         string
+		cookie        *http.Cookie
+		mockInput     userServ.OIDCCallbackInput
+		mockResult    userServ.LoginResponse
+		mockResultErr error
+		statusCode    int
+		body          userServ.LoginResponse
+		err           error
+	}{
+		"success": {
+			query:  "?code=code&state=state",
+			cookie: loginCookie(oidcLoginState{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}),
+			mockInput: userServ.OIDCCallbackInput{
+				Code:    "code",
+				State:   "state",
+				Request: userServ.OIDCLoginRequest{State: "state", Nonce: "nonce", CodeVerifier: "verifier"},
+			},
+			mockResult: userServ.LoginResponse{AccessToken: "access", RefreshToken: "refresh", Scope: "GUEST", ExpiresIn: 1800, TokenType: "Bearer"},
+			statusCode: http.StatusOK,
+			body:       userServ.LoginResponse{AccessToken: "access", RefreshToken: "refresh", Scope: "GUEST", ExpiresIn: 1800, TokenType: "Bearer"},
+		},
+		"issuer_error": {
+			query:      "?error=access_denied&state=state",
+			cookie:     loginCookie(oidcLoginState{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}),
+			statusCode: http.StatusUnauthorized,
+			err:        ErrInvalidOIDCLogin,
+		},
+		"code_can_not_be_blank": {
+			query:      "?state=state",
+			cookie:     loginCookie(oidcLoginState{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}),
+			statusCode: http.StatusBadRequest,
+			err:        ErrCodeCannotBeBlank,
+		},
+		"missing_cookie": {
+			query:      "?code=code&state=state",
+			statusCode: http.StatusUnauthorized,
+			err:        ErrInvalidOIDCLogin,
+		},
+		"invalid_cookie": {
+			query:      "?code=code&state=state",
+			cookie:     &http.Cookie{Name: oidcLoginCookie, Value: "not json"},
+			statusCode: http.StatusUnauthorized,
+			err:        ErrInvalidOIDCLogin,
+		},
+		"email_existed": {
+			query:  "?code=code&state=state",
+			cookie: loginCookie(oidcLoginState{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}),
+			mockInput: userServ.OIDCCallbackInput{
+				Code:    "code",
+				State:   "state",
+				Request: userServ.OIDCLoginRequest{State: "state", Nonce: "nonce", CodeVerifier: "verifier"},
+			},
+			mockResultErr: userServ.ErrEmailExisted,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrEmailExisted,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users/oidc/callback"+tc.query, nil)
+			if tc.cookie != nil {
+				r.AddCookie(tc.cookie)
+			}
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("OIDCCallback", r.Context(), tc.mockInput).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.OIDCCallback(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			cookies := w.Result().Cookies()
+			require.Len(t, cookies, 1)
+			require.Equal(t, -1, cookies[0].MaxAge) // the login cookie is always removed
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				if tc.mockResultErr == nil {
+					serviceMock.AssertNotCalled(t, "OIDCCallback", r.Context(), mock.Anything)
+				}
+				return
+			}
+			var result userServ.LoginResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			require.Equal(t, tc.body, result)
+		})
+	}
+}
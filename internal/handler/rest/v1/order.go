@@ -6,6 +6,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/volatiletech/null/v8"
+
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/service/order"
 	orderServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/order"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
@@ -13,6 +15,7 @@ import (
 
 type OrderItemRequest struct {
 	ProductID int     `json:"product_id"`
+	VariantID int     `json:"variant_id"` // required if the product has variants
 	Quantity  int     `json:"quantity"`
 	Discount  float64 `json:"discount"`
 	Note      string  `json:"note"`
@@ -22,12 +25,19 @@ type OrderRequest struct {
 	Note   string             `json:"note"`
 	UserID int                `json:"user_id"`
 	Items  []OrderItemRequest `json:"items"`
+
+	// ShippingAddressID and BillingAddressID are optional, the default addresses of the user are used if they are omitted
+	ShippingAddressID int `json:"shipping_address_id"`
+	BillingAddressID  int `json:"billing_address_id"`
 }
 
 func validateOrderRequest(r OrderRequest) (orderServ.OrderInput, error) {
 	if r.UserID <= 0 {
 		return orderServ.OrderInput{}, ErrInvalidUserID
 	}
+	if r.ShippingAddressID < 0 || r.BillingAddressID < 0 {
+		return orderServ.OrderInput{}, ErrInvalidAddressID
+	}
 	if len(r.Items) == 0 {
 		return order.OrderInput{}, ErrItemsCannotBeBlank
 	}
@@ -36,6 +46,9 @@ func validateOrderRequest(r OrderRequest) (orderServ.OrderInput, error) {
 		if item.ProductID <= 0 {
 			return orderServ.OrderInput{}, ErrInvalidProductID
 		}
+		if item.VariantID < 0 {
+			return orderServ.OrderInput{}, ErrInvalidVariantID
+		}
 		if item.Quantity <= 0 {
 			return orderServ.OrderInput{}, ErrInvalidQuantity
 		}
@@ -44,15 +57,18 @@ func validateOrderRequest(r OrderRequest) (orderServ.OrderInput, error) {
 		}
 		items[i] = orderServ.OrderItemInput{
 			ProductID: item.ProductID,
+			VariantID: item.VariantID,
 			Quantity:  item.Quantity,
 			Discount:  item.Discount,
 			Note:      item.Note,
 		}
 	}
 	return orderServ.OrderInput{
-		Note:   r.Note,
-		UserID: r.UserID,
-		Items:  items,
+		Note:              r.Note,
+		UserID:            r.UserID,
+		Items:             items,
+		ShippingAddressID: r.ShippingAddressID,
+		BillingAddressID:  r.BillingAddressID,
 	}, nil
 }
 
@@ -63,6 +79,16 @@ func handleOrderError(w http.ResponseWriter, err error) {
 		utils.WriteJSONResponse(w, http.StatusBadRequest, ErrUserNotExist)
 	case order.ErrProductNotExist:
 		utils.WriteJSONResponse(w, http.StatusBadRequest, ErrProductNotFound)
+	case order.ErrAddressNotExist:
+		utils.WriteJSONResponse(w, http.StatusBadRequest, ErrAddressNotExist)
+	case order.ErrVariantRequired:
+		utils.WriteJSONResponse(w, http.StatusBadRequest, ErrVariantRequired)
+	case order.ErrVariantNotExist:
+		utils.WriteJSONResponse(w, http.StatusBadRequest, ErrVariantNotExist)
+	case order.ErrVariantOutOfStock:
+		utils.WriteJSONResponse(w, http.StatusConflict, ErrVariantOutOfStock)
+	case order.ErrPermissionDenied:
+		utils.WriteJSONResponse(w, http.StatusForbidden, ErrPermissionDenied)
 	default:
 		utils.WriteJSONResponse(w, http.StatusInternalServerError, ErrInternalServerError)
 	}
@@ -176,6 +202,8 @@ func validGetOrdersReq(req ordersRequest) (orderServ.OrdersInput, error) {
 type OrderItem struct {
 	ID           int       `json:"id"`
 	ProductID    int       `json:"product_id"`
+	VariantID    null.Int  `json:"variant_id"`
+	VariantSKU   string    `json:"variant_sku"`
 	ProductPrice float64   `json:"product_price"`
 	ProductName  string    `json:"product_name"`
 	Quantity     int       `json:"quantity"`
@@ -185,17 +213,48 @@ type OrderItem struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// OrderAddress represents the shipping or billing address of an order for response in order list
+type OrderAddress struct {
+	Name       string `json:"name"`
+	Phone      string `json:"phone"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
 // Order represents order for order list response
 type Order struct {
-	ID          int         `json:"id"`
-	OrderNumber string      `json:"order_number"`
-	OrderDate   time.Time   `json:"order_date"`
-	Status      string      `json:"status"`
-	Note        string      `json:"note"`
-	UserID      int         `json:"user_id"`
-	OrderItems  []OrderItem `json:"order_items"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID              int           `json:"id"`
+	OrderNumber     string        `json:"order_number"`
+	OrderDate       time.Time     `json:"order_date"`
+	Status          string        `json:"status"`
+	Note            string        `json:"note"`
+	UserID          int           `json:"user_id"`
+	OrderItems      []OrderItem   `json:"order_items"`
+	ShippingAddress *OrderAddress `json:"shipping_address"`
+	BillingAddress  *OrderAddress `json:"billing_address"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+// toOrderAddress converts the address of the service, it is nil if the order has no address
+func toOrderAddress(address *orderServ.OrderAddress) *OrderAddress {
+	if address == nil {
+		return nil
+	}
+	return &OrderAddress{
+		Name:       address.Name,
+		Phone:      address.Phone,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
 }
 
 // OrderResponse represents order for order list response
@@ -236,6 +295,8 @@ func (h Handler) GetOrders(w http.ResponseWriter, r *http.Request) {
 			orderItems[j] = OrderItem{
 				ID:           orderItem.ID,
 				ProductID:    orderItem.ProductID,
+				VariantID:    orderItem.VariantID,
+				VariantSKU:   orderItem.VariantSKU,
 				ProductPrice: orderItem.ProductPrice,
 				ProductName:  orderItem.ProductName,
 				Quantity:     orderItem.Quantity,
@@ -247,15 +308,17 @@ func (h Handler) GetOrders(w http.ResponseWriter, r *http.Request) {
 		}
 
 		result[i] = Order{
-			ID:          order.ID,
-			OrderNumber: order.OrderNumber,
-			OrderDate:   order.OrderDate,
-			Status:      order.Status,
-			Note:        order.Note,
-			UserID:      order.UserID,
-			OrderItems:  orderItems,
-			CreatedAt:   order.CreatedAt,
-			UpdatedAt:   order.UpdatedAt,
+			ID:              order.ID,
+			OrderNumber:     order.OrderNumber,
+			OrderDate:       order.OrderDate,
+			Status:          order.Status,
+			Note:            order.Note,
+			UserID:          order.UserID,
+			OrderItems:      orderItems,
+			ShippingAddress: toOrderAddress(order.ShippingAddress),
+			BillingAddress:  toOrderAddress(order.BillingAddress),
+			CreatedAt:       order.CreatedAt,
+			UpdatedAt:       order.UpdatedAt,
 		}
 	}
 
@@ -277,6 +277,64 @@ func TestHandler_CreateOrder(t *testing.T) {
 			expErr:       ErrProductNotFound,
 			isCallToServ: true,
 		},
+		"success_with_addresses": {
+			given: givenData{
+				reqBody: `{
+					"user_id": 2,
+					"items": [{"product_id": 1, "quantity": 10}],
+					"shipping_address_id": 5,
+					"billing_address_id": 6
+				}`,
+				mock: mockData{
+					input: order.OrderInput{
+						UserID:            2,
+						Items:             []order.OrderItemInput{{ProductID: 1, Quantity: 10}},
+						ShippingAddressID: 5,
+						BillingAddressID:  6,
+					},
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusCreated,
+				body:       "Created order successfully",
+			},
+			isCallToServ: true,
+		},
+		"invalid_address_id": {
+			given: givenData{
+				reqBody: `{
+					"user_id": 2,
+					"items": [{"product_id": 1, "quantity": 10}],
+					"shipping_address_id": -1
+				}`,
+			},
+			expResult: expectedData{
+				statusCode: http.StatusBadRequest,
+			},
+			expErr: ErrInvalidAddressID,
+		},
+		"address_not_exist": {
+			given: givenData{
+				reqBody: `{
+					"user_id": 2,
+					"items": [{"product_id": 1, "quantity": 10}],
+					"shipping_address_id": 7
+				}`,
+				mock: mockData{
+					input: order.OrderInput{
+						UserID:            2,
+						Items:             []order.OrderItemInput{{ProductID: 1, Quantity: 10}},
+						ShippingAddressID: 7,
+					},
+					err: order.ErrAddressNotExist,
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusBadRequest,
+			},
+			expErr:       ErrAddressNotExist,
+			isCallToServ: true,
+		},
 	}
 
 	for decs, tc := range tcs {
@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+type OrganizationRequest struct {
+	Name  string      `json:"name"`
+	Admin userRequest `json:"admin"`
+}
+
+func validateOrganizationReq(req OrganizationRequest) (userServ.OrganizationInput, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return userServ.OrganizationInput{}, ErrNameCannotBeBlank
+	}
+
+	// The first user of the organization is always an active ADMIN
+	req.Admin.Role = auth.RoleAdmin
+	req.Admin.IsActive = true
+	admin, err := validateUserInput(req.Admin)
+	if err != nil {
+		return userServ.OrganizationInput{}, err
+	}
+
+	return userServ.OrganizationInput{
+		Name:  name,
+		Admin: admin,
+	}, nil
+}
+
+// GetOrganizations handle request to get all organizations
+func (h Handler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.GetOrganizations(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// GetOrganization handle request to get an organization
+func (h Handler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	// 1. Get organization ID from url param
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id <= 0 {
+		handleUserError(w, ErrInvalidID)
+		return
+	}
+
+	// 2. Get organization
+	result, err := h.userServ.GetOrganization(r.Context(), id)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// GetCurrentOrganization handle request to get the organization of the current user
+func (h Handler) GetCurrentOrganization(w http.ResponseWriter, r *http.Request) {
+	user, _ := auth.FromContext(r.Context())
+	result, err := h.userServ.GetOrganization(r.Context(), user.OrganizationID)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// CreateOrganization handle request to create an organization
+func (h Handler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	// 1. Decode
+	var req OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// 2. Validate request
+	input, err := validateOrganizationReq(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Create organization
+	result, err := h.userServ.CreateOrganization(r.Context(), input)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, result)
+}
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestHandler_CreateOrganization(t *testing.T) {
+	createdAt := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	admin := userServ.InputUser{Name: "owner", Email: "owner@example.com", Password: "abcd", Phone: "0987654321", Role: auth.RoleAdmin, IsActive: true}
+	tcs := map[string]struct {
+		reqBody       string
+		mockInput     userServ.OrganizationInput
+		mockResult    userServ.Organization
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			reqBody:    `{"name":" Shop A ","admin":{"name":"owner","email":"owner@example.com","password":"abcd","phone":"0987654321","role":"GUEST"}}`,
+			mockInput:  userServ.OrganizationInput{Name: "Shop A", Admin: admin},
+			mockResult: userServ.Organization{ID: 2, Name: "Shop A", CreatedAt: createdAt, UpdatedAt: createdAt},
+			statusCode: http.StatusCreated,
+			body:       "{\"id\":2,\"name\":\"Shop A\",\"created_at\":\"2022-07-01T00:00:00Z\",\"updated_at\":\"2022-07-01T00:00:00Z\"}",
+		},
+		"name_can_not_be_blank": {
+			reqBody:    `{"name":" ","admin":{"name":"owner","email":"owner@example.com","password":"abcd","phone":"0987654321"}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrNameCannotBeBlank,
+		},
+		"invalid_admin_email": {
+			reqBody:    `{"name":"Shop A","admin":{"name":"owner","email":"owner","password":"abcd","phone":"0987654321"}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidEmail,
+		},
+		"organization_existed": {
+			reqBody:       `{"name":"Default","admin":{"name":"owner","email":"owner@example.com","password":"abcd","phone":"0987654321"}}`,
+			mockInput:     userServ.OrganizationInput{Name: "Default", Admin: admin},
+			mockResultErr: userServ.ErrOrganizationExisted,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrOrganizationExisted,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/organizations", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("CreateOrganization", r.Context(), tc.mockInput).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.CreateOrganization(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_GetCurrentOrganization(t *testing.T) {
+	// GIVEN
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/me/organization", nil)
+	r = r.WithContext(auth.NewContext(r.Context(), auth.User{ID: 1, OrganizationID: 2}))
+	w := httptest.NewRecorder()
+
+	serviceMock := new(userServ.Mock)
+	serviceMock.On("GetOrganization", r.Context(), 2).Return(userServ.Organization{ID: 2, Name: "Shop A"}, nil)
+
+	handler := NewHandler(serviceMock, nil, nil)
+
+	// WHEN
+	handler.GetCurrentOrganization(w, r)
+
+	// THEN
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "{\"id\":2,\"name\":\"Shop A\",\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}", w.Body.String())
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/volatiletech/null/v8"
 
 	productServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
@@ -79,8 +80,17 @@ func (h Handler) GetProduct(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	// Get the options and the variants of the product
+	variants, err := h.productServ.GetProductVariants(r.Context(), []int{parsedID})
+	if err != nil {
+		utils.WriteErrorResponse(w, ErrInternalServerError)
+		return
+	}
+	result := productResponse{Product: product}
+	result.Options, result.Variants = toOptionAndVariantResponses(variants[parsedID].Options, variants[parsedID].Variants)
+
 	// response data to client
-	utils.WriteJSONResponse(w, http.StatusOK, product)
+	utils.WriteJSONResponse(w, http.StatusOK, result)
 }
 
 func (h Handler) CreateProduct(w http.ResponseWriter, r *http.Request) {
@@ -166,15 +176,24 @@ func (h Handler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 }
 
 type productItemResponse struct {
-	ID          int               `json:"id"`
-	Title       string            `json:"title"`
-	Description string            `json:"description"`
-	Price       float64           `json:"price"`
-	Quantity    int               `json:"quantity"`
-	IsActive    bool              `json:"is_active"`
-	User        createdByResponse `json:"user"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ID          int                `json:"id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Price       float64            `json:"price"`
+	Quantity    int                `json:"quantity"`
+	IsActive    bool               `json:"is_active"`
+	User        createdByResponse  `json:"user"`
+	Options     []optionResponse   `json:"options"`
+	Variants    []variantResponse  `json:"variants"`
+	Highlight   *highlightResponse `json:"highlight,omitempty"` // only with search query
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// highlightResponse is the title and the description with the words of the search query in <mark> tags, the text is HTML escaped
+type highlightResponse struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
 }
 type createdByResponse struct {
 	ID    int    `json:"id"`
@@ -182,9 +201,33 @@ type createdByResponse struct {
 	Email string `json:"email"`
 	Phone string `json:"phone"`
 }
+type priceBucketResponse struct {
+	From         float64      `json:"from"`
+	To           null.Float64 `json:"to"` // null for the last bucket
+	ProductCount int64        `json:"product_count"`
+}
+type sellerCountResponse struct {
+	UserID       int    `json:"user_id"`
+	Name         string `json:"name"`
+	ProductCount int64  `json:"product_count"`
+}
+type isActiveCountResponse struct {
+	Active   int64 `json:"active"`
+	Inactive int64 `json:"inactive"`
+}
+
+// facetsResponse are the numbers of the filtered products by price bucket, seller, status and category
+type facetsResponse struct {
+	PriceBuckets []priceBucketResponse   `json:"price_buckets"`
+	Sellers      []sellerCountResponse   `json:"sellers"`
+	IsActive     isActiveCountResponse   `json:"is_active"`
+	Categories   []categoryCountResponse `json:"categories"`
+}
 type getProductsResponse struct {
-	Products   []productItemResponse `json:"products"`
-	Pagination pagination            `json:"pagination"`
+	Products       []productItemResponse   `json:"products"`
+	CategoryCounts []categoryCountResponse `json:"category_counts"` // same as facets.categories
+	Facets         facetsResponse          `json:"facets"`
+	Pagination     pagination              `json:"pagination"`
 }
 
 // GetProducts handle get products request
@@ -210,6 +253,15 @@ func (h Handler) GetProducts(w http.ResponseWriter, r *http.Request) {
 		handleProductError(w, err)
 		return
 	}
+
+	// 4. Count the filtered products of each price bucket, seller, status and category
+	facets, err := h.productServ.GetProductFacets(r.Context(), getProductsInput)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+	facetsResult := toFacetsResponse(facets)
+
 	result := make([]productItemResponse, len(products))
 	for i, p := range products {
 		result[i] = productItemResponse{
@@ -228,6 +280,10 @@ func (h Handler) GetProducts(w http.ResponseWriter, r *http.Request) {
 			CreatedAt: p.CreatedAt,
 			UpdatedAt: p.UpdatedAt,
 		}
+		result[i].Options, result[i].Variants = toOptionAndVariantResponses(p.Options, p.Variants)
+		if p.Highlight != nil {
+			result[i].Highlight = &highlightResponse{Title: p.Highlight.Title, Description: p.Highlight.Description}
+		}
 	}
 
 	if getProductsInput.Pagination.Page == 0 && getProductsInput.Pagination.Limit == 0 {
@@ -236,7 +292,9 @@ func (h Handler) GetProducts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	utils.WriteJSONResponse(w, http.StatusOK, getProductsResponse{
-		Products: result,
+		Products:       result,
+		CategoryCounts: facetsResult.Categories,
+		Facets:         facetsResult,
 		Pagination: pagination{
 			CurrentPage: getProductsInput.Pagination.Page,
 			Limit:       getProductsInput.Pagination.Limit,
@@ -245,6 +303,31 @@ func (h Handler) GetProducts(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// toFacetsResponse converts the facets of the service to the response
+func toFacetsResponse(facets productServ.ProductFacets) facetsResponse {
+	result := facetsResponse{
+		PriceBuckets: make([]priceBucketResponse, len(facets.PriceBuckets)),
+		Sellers:      make([]sellerCountResponse, len(facets.Sellers)),
+		IsActive:     isActiveCountResponse{Active: facets.Active, Inactive: facets.Inactive},
+		Categories:   make([]categoryCountResponse, len(facets.Categories)),
+	}
+	for i, b := range facets.PriceBuckets {
+		result.PriceBuckets[i] = priceBucketResponse{From: b.From, To: b.To, ProductCount: b.ProductCount}
+	}
+	for i, s := range facets.Sellers {
+		result.Sellers[i] = sellerCountResponse{UserID: s.UserID, Name: s.Name, ProductCount: s.ProductCount}
+	}
+	for i, c := range facets.Categories {
+		result.Categories[i] = categoryCountResponse{
+			CategoryID:   c.CategoryID,
+			Name:         c.Name,
+			Slug:         c.Slug,
+			ProductCount: c.ProductCount,
+		}
+	}
+	return result
+}
+
 const (
 	maxSizeUploadCSVFile = 1024 * 1024 // 1 MB
 )
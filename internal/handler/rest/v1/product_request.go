@@ -2,6 +2,7 @@ package v1
 
 import (
 	"strings"
+	"unicode/utf8"
 
 	"github.com/volatiletech/null/v8"
 
@@ -21,13 +22,16 @@ type priceRangeRequest struct {
 }
 
 type getProductsRequest struct {
-	ID         int               `json:"id"`
-	Title      string            `json:"title"`
-	PriceRange priceRangeRequest `json:"price_range"`
-	IsActive   null.Bool         `json:"is_active"`
-	UserID     int               `json:"user_id"`
-	OrderBy    orderRequest      `json:"order_by"`
-	Pagination paginationInput   `json:"pagination"`
+	ID           int               `json:"id"`
+	Title        string            `json:"title"`
+	Query        string            `json:"q"` // full-text search of the title and the description
+	PriceRange   priceRangeRequest `json:"price_range"`
+	IsActive     null.Bool         `json:"is_active"`
+	UserID       int               `json:"user_id"`
+	CategoryID   int               `json:"category_id"`
+	OrderBy      orderRequest      `json:"order_by"`
+	Pagination   paginationInput   `json:"pagination"`
+	PriceBuckets []float64         `json:"price_buckets"` // the ascending lower bounds of the price buckets of the facets
 }
 
 // validateGetProductsInput validate get products request
@@ -48,7 +52,28 @@ func validGetProductsInput(req getProductsRequest) (productServ.GetProductsInput
 		return productServ.GetProductsInput{}, ErrInvalidUserID
 	}
 
-	// 4. Validate order by if any
+	// 4. Validate category ID if any
+	if req.CategoryID < 0 {
+		return productServ.GetProductsInput{}, ErrInvalidCategoryID
+	}
+
+	// 5. Validate search query if any
+	query := strings.TrimSpace(req.Query)
+	if utf8.RuneCountInString(query) > productServ.MaxSearchQueryLength {
+		return productServ.GetProductsInput{}, ErrInvalidSearchQuery
+	}
+
+	// 6. Validate price buckets if any
+	if len(req.PriceBuckets) > productServ.MaxPriceBuckets {
+		return productServ.GetProductsInput{}, ErrInvalidPriceBuckets
+	}
+	for i, bound := range req.PriceBuckets {
+		if bound < 0 || (i > 0 && bound <= req.PriceBuckets[i-1]) {
+			return productServ.GetProductsInput{}, ErrInvalidPriceBuckets
+		}
+	}
+
+	// 7. Validate order by if any
 	orderByTitle := strings.TrimSpace(req.OrderBy.Title)
 	if orderByTitle != "" && orderByTitle != OrderTypeASC && orderByTitle != OrderTypeDESC {
 		return productServ.GetProductsInput{}, ErrInvalidOrderBy
@@ -69,12 +94,14 @@ func validGetProductsInput(req getProductsRequest) (productServ.GetProductsInput
 	return productServ.GetProductsInput{
 		ID:       req.ID,
 		Title:    strings.TrimSpace(req.Title),
+		Query:    query,
 		IsActive: req.IsActive,
 		PriceRange: productServ.PriceRange{
 			MinPrice: req.PriceRange.MinPrice,
 			MaxPrice: req.PriceRange.MaxPrice,
 		},
-		UserID: req.UserID,
+		UserID:     req.UserID,
+		CategoryID: req.CategoryID,
 		OrderBy: productServ.OrderInput{
 			CreatedAt: orderByCreatedAt,
 			Title:     orderByTitle,
@@ -85,5 +112,6 @@ func validGetProductsInput(req getProductsRequest) (productServ.GetProductsInput
 			Limit: req.Pagination.Limit,
 			Page:  req.Pagination.Page,
 		},
+		PriceBuckets: req.PriceBuckets,
 	}, nil
 }
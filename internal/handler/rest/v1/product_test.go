@@ -82,6 +82,7 @@ func TestProductHandler_GetProduct(t *testing.T) {
 
 			productServiceMock := new(productService.Mock)
 			productServiceMock.On("GetProduct", r.Context(), tc.input.productID).Return(tc.input.mockResultProduct, tc.input.mockResultError)
+			productServiceMock.On("GetProductVariants", r.Context(), []int{tc.input.productID}).Return(map[int]productService.ProductVariants{}, nil)
 			handler := NewHandler(nil, productServiceMock, nil)
 
 			// WHEN
@@ -587,12 +588,18 @@ func TestProductHandler_GetProducts(t *testing.T) {
 		mockResultProducts   []productService.ProductItem
 		mockResultTotalCount int64
 		mockResultError      error
+		mockResultFacets     productService.ProductFacets
 	}
 	type output struct {
 		result     getProductsResponse
 		statusCode int
 		err        error
 	}
+	noFacets := facetsResponse{
+		PriceBuckets: []priceBucketResponse{},
+		Sellers:      []sellerCountResponse{},
+		Categories:   []categoryCountResponse{},
+	}
 	tcs := map[string]struct {
 		input     input
 		expOutput output
@@ -604,6 +611,7 @@ func TestProductHandler_GetProducts(t *testing.T) {
 					"price_range":{"min_price":100, "max_price":3000},
 					"is_active":true,
 					"user_id":1,
+					"category_id":3,
 					"order_by":{
 						"title":"desc",
 						"created_at":"desc"
@@ -614,6 +622,7 @@ func TestProductHandler_GetProducts(t *testing.T) {
 					PriceRange: productService.PriceRange{MinPrice: 100, MaxPrice: 3000},
 					IsActive:   null.NewBool(true, true),
 					UserID:     1,
+					CategoryID: 3,
 					OrderBy: productService.OrderInput{
 						Title:     "desc",
 						CreatedAt: "desc",
@@ -650,6 +659,19 @@ func TestProductHandler_GetProducts(t *testing.T) {
 					},
 				},
 				mockResultTotalCount: 2,
+				mockResultFacets: productService.ProductFacets{
+					PriceBuckets: []productService.PriceBucket{
+						{From: 0, To: null.Float64From(100000), ProductCount: 2},
+						{From: 100000, ProductCount: 0},
+					},
+					Sellers: []productService.SellerCount{
+						{UserID: 1, Name: "admin", ProductCount: 2},
+					},
+					Active: 2,
+					Categories: []productService.CategoryCount{
+						{CategoryID: 3, Name: "Phones", Slug: "phones", ProductCount: 2},
+					},
+				},
 			},
 			expOutput: output{
 				result: getProductsResponse{
@@ -667,6 +689,8 @@ func TestProductHandler_GetProducts(t *testing.T) {
 								Email: "admin@example.com",
 								Phone: "0987654321",
 							},
+							Options:  []optionResponse{},
+							Variants: []variantResponse{},
 						},
 						{
 							ID:          2,
@@ -681,6 +705,24 @@ func TestProductHandler_GetProducts(t *testing.T) {
 								Email: "admin@example.com",
 								Phone: "0987654321",
 							},
+							Options:  []optionResponse{},
+							Variants: []variantResponse{},
+						},
+					},
+					CategoryCounts: []categoryCountResponse{
+						{CategoryID: 3, Name: "Phones", Slug: "phones", ProductCount: 2},
+					},
+					Facets: facetsResponse{
+						PriceBuckets: []priceBucketResponse{
+							{From: 0, To: null.Float64From(100000), ProductCount: 2},
+							{From: 100000, ProductCount: 0},
+						},
+						Sellers: []sellerCountResponse{
+							{UserID: 1, Name: "admin", ProductCount: 2},
+						},
+						IsActive: isActiveCountResponse{Active: 2},
+						Categories: []categoryCountResponse{
+							{CategoryID: 3, Name: "Phones", Slug: "phones", ProductCount: 2},
 						},
 					},
 					Pagination: pagination{
@@ -692,6 +734,122 @@ func TestProductHandler_GetProducts(t *testing.T) {
 				statusCode: http.StatusOK,
 			},
 		},
+		"search": {
+			input: input{
+				reqBody: `{"q": " red shirt "}`,
+				mockInput: productService.GetProductsInput{
+					Query: "red shirt",
+				},
+				mockResultProducts: []productService.ProductItem{
+					{
+						ID:          1,
+						Title:       "Red shirt",
+						Description: "A red cotton shirt",
+						Price:       100,
+						Quantity:    10,
+						IsActive:    true,
+						User:        productService.CreatedBy{ID: 1, Name: "admin"},
+						Highlight: &productService.Highlight{
+							Title:       "<mark>Red</mark> <mark>shirt</mark>",
+							Description: "A <mark>red</mark> cotton <mark>shirt</mark>",
+						},
+					},
+				},
+				mockResultTotalCount: 1,
+			},
+			expOutput: output{
+				result: getProductsResponse{
+					Products: []productItemResponse{
+						{
+							ID:          1,
+							Title:       "Red shirt",
+							Description: "A red cotton shirt",
+							Price:       100,
+							Quantity:    10,
+							IsActive:    true,
+							User:        createdByResponse{ID: 1, Name: "admin"},
+							Options:     []optionResponse{},
+							Variants:    []variantResponse{},
+							Highlight: &highlightResponse{
+								Title:       "<mark>Red</mark> <mark>shirt</mark>",
+								Description: "A <mark>red</mark> cotton <mark>shirt</mark>",
+							},
+						},
+					},
+					CategoryCounts: []categoryCountResponse{},
+					Facets:         noFacets,
+					Pagination: pagination{
+						CurrentPage: 1,
+						Limit:       20,
+						TotalCount:  1,
+					},
+				},
+				statusCode: http.StatusOK,
+			},
+		},
+		"invalid_search_query": {
+			input: input{
+				reqBody: `{"q": "` + strings.Repeat("a", 257) + `"}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidSearchQuery,
+			},
+		},
+		"price_buckets": {
+			input: input{
+				reqBody: `{"price_buckets": [0, 10, 50]}`,
+				mockInput: productService.GetProductsInput{
+					PriceBuckets: []float64{0, 10, 50},
+				},
+				mockResultProducts: []productService.ProductItem{},
+				mockResultFacets: productService.ProductFacets{
+					PriceBuckets: []productService.PriceBucket{
+						{From: 0, To: null.Float64From(10), ProductCount: 0},
+						{From: 10, To: null.Float64From(50), ProductCount: 0},
+						{From: 50, ProductCount: 0},
+					},
+				},
+			},
+			expOutput: output{
+				result: getProductsResponse{
+					Products:       []productItemResponse{},
+					CategoryCounts: []categoryCountResponse{},
+					Facets: facetsResponse{
+						PriceBuckets: []priceBucketResponse{
+							{From: 0, To: null.Float64From(10)},
+							{From: 10, To: null.Float64From(50)},
+							{From: 50},
+						},
+						Sellers:    []sellerCountResponse{},
+						Categories: []categoryCountResponse{},
+					},
+					Pagination: pagination{
+						CurrentPage: 1,
+						Limit:       20,
+					},
+				},
+				statusCode: http.StatusOK,
+			},
+		},
+		"invalid_price_buckets": {
+			input: input{
+				reqBody: `{"price_buckets": [0, 50, 10]}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidPriceBuckets,
+			},
+		},
+		"negative_price_bucket": {
+			input: input{
+				reqBody: `{"price_buckets": [-10, 50]}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidPriceBuckets,
+			},
+		},
 		"invalid_id": {
 			input: input{
 				reqBody: `{"id": -1}`,
@@ -725,6 +883,15 @@ func TestProductHandler_GetProducts(t *testing.T) {
 				err:        ErrInvalidUserID,
 			},
 		},
+		"invalid_category_id": {
+			input: input{
+				reqBody: `{"category_id": -1}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidCategoryID,
+			},
+		},
 		"invalid_order_by": {
 			input: input{
 				reqBody: `{
@@ -789,6 +956,8 @@ func TestProductHandler_GetProducts(t *testing.T) {
 								Email: "admin@example.com",
 								Phone: "0987654321",
 							},
+							Options:  []optionResponse{},
+							Variants: []variantResponse{},
 						},
 						{
 							ID:          2,
@@ -803,8 +972,12 @@ func TestProductHandler_GetProducts(t *testing.T) {
 								Email: "admin@example.com",
 								Phone: "0987654321",
 							},
+							Options:  []optionResponse{},
+							Variants: []variantResponse{},
 						},
 					},
+					CategoryCounts: []categoryCountResponse{},
+					Facets:         noFacets,
 					Pagination: pagination{
 						CurrentPage: 1,
 						Limit:       20,
@@ -871,6 +1044,8 @@ func TestProductHandler_GetProducts(t *testing.T) {
 								Email: "admin@example.com",
 								Phone: "0987654321",
 							},
+							Options:  []optionResponse{},
+							Variants: []variantResponse{},
 						},
 						{
 							ID:          2,
@@ -885,8 +1060,12 @@ func TestProductHandler_GetProducts(t *testing.T) {
 								Email: "admin@example.com",
 								Phone: "0987654321",
 							},
+							Options:  []optionResponse{},
+							Variants: []variantResponse{},
 						},
 					},
+					CategoryCounts: []categoryCountResponse{},
+					Facets:         noFacets,
 					Pagination: pagination{
 						CurrentPage: 2,
 						Limit:       2,
@@ -913,6 +1092,7 @@ func TestProductHandler_GetProducts(t *testing.T) {
 			// 2. Define mock and handler
 			serviceMock := new(productService.Mock)
 			serviceMock.On("GetProducts", r.Context(), tc.input.mockInput).Return(tc.input.mockResultProducts, tc.input.mockResultTotalCount, tc.input.mockResultError)
+			serviceMock.On("GetProductFacets", r.Context(), tc.input.mockInput).Return(tc.input.mockResultFacets, nil)
 			handler := NewHandler(nil, serviceMock, nil)
 
 			//WHEN
@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+type UpdateProfileRequest struct {
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+	Email string `json:"email"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+func validateUpdateProfileReq(req UpdateProfileRequest) (userServ.UpdateProfileInput, error) {
+	name, phone, email := strings.TrimSpace(req.Name), strings.TrimSpace(req.Phone), strings.TrimSpace(req.Email)
+	if name == "" {
+		return userServ.UpdateProfileInput{}, ErrNameCannotBeBlank
+	}
+	if phone == "" {
+		return userServ.UpdateProfileInput{}, ErrPhoneCannotBeBlank
+	}
+	if email == "" {
+		return userServ.UpdateProfileInput{}, ErrEmailCannotBeBlank
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return userServ.UpdateProfileInput{}, ErrInvalidEmail
+	}
+
+	return userServ.UpdateProfileInput{
+		Name:  name,
+		Phone: phone,
+		Email: email,
+	}, nil
+}
+
+// GetProfile handle request to get the profile of the current user
+func (h Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.GetProfile(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// UpdateProfile handle request to update the name, phone and email of the current user
+func (h Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	// 1. Decode
+	var req UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// 2. Validate request
+	input, err := validateUpdateProfileReq(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Update profile
+	result, err := h.userServ.UpdateProfile(r.Context(), input)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// ChangePassword handle request to change the password of the current user
+func (h Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	// 1. Decode
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// 2. Validate request
+	if req.CurrentPassword == "" || strings.TrimSpace(req.NewPassword) == "" {
+		handleUserError(w, ErrPasswordCannotBeBlank)
+		return
+	}
+
+	// 3. Change password
+	if err := h.userServ.ChangePassword(r.Context(), userServ.ChangePasswordInput{
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgChangePassword,
+	})
+}
@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/password"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+func TestHandler_GetProfile(t *testing.T) {
+	createdAt := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		mockResult    userServ.Profile
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			mockResult: userServ.Profile{ID: 1, Name: "Guest", Email: "guest@example.com", Phone: "0987654321", Role: "GUEST", CreatedAt: createdAt, UpdatedAt: createdAt},
+			statusCode: http.StatusOK,
+			body:       "{\"id\":1,\"name\":\"Guest\",\"email\":\"guest@example.com\",\"phone\":\"0987654321\",\"role\":\"GUEST\",\"email_verified_at\":null,\"created_at\":\"2022-07-01T00:00:00Z\",\"updated_at\":\"2022-07-01T00:00:00Z\"}",
+		},
+		"user_not_found": {
+			mockResultErr: userServ.ErrUserNotFound,
+			statusCode:    http.StatusNotFound,
+			err:           ErrUserNotFound,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("GetProfile", r.Context()).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.GetProfile(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateProfile(t *testing.T) {
+	createdAt := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		reqBody       string
+		mockInput     userServ.UpdateProfileInput
+		mockResult    userServ.Profile
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			reqBody:    `{"name":" Guest ","phone":"0987654321","email":"new@example.com"}`,
+			mockInput:  userServ.UpdateProfileInput{Name: "Guest", Phone: "0987654321", Email: "new@example.com"},
+			mockResult: userServ.Profile{ID: 1, Name: "Guest", Email: "new@example.com", Phone: "0987654321", Role: "GUEST", CreatedAt: createdAt, UpdatedAt: createdAt},
+			statusCode: http.StatusOK,
+			body:       "{\"id\":1,\"name\":\"Guest\",\"email\":\"new@example.com\",\"phone\":\"0987654321\",\"role\":\"GUEST\",\"email_verified_at\":null,\"created_at\":\"2022-07-01T00:00:00Z\",\"updated_at\":\"2022-07-01T00:00:00Z\"}",
+		},
+		"name_can_not_be_blank": {
+			reqBody:    `{"name":"","phone":"0987654321","email":"new@example.com"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrNameCannotBeBlank,
+		},
+		"invalid_email": {
+			reqBody:    `{"name":"Guest","phone":"0987654321","email":"new"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidEmail,
+		},
+		"email_existed": {
+			reqBody:       `{"name":"Guest","phone":"0987654321","email":"admin@example.com"}`,
+			mockInput:     userServ.UpdateProfileInput{Name: "Guest", Phone: "0987654321", Email: "admin@example.com"},
+			mockResultErr: userServ.ErrEmailExisted,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrEmailExisted,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/me", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("UpdateProfile", r.Context(), tc.mockInput).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.UpdateProfile(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ChangePassword(t *testing.T) {
+	tcs := map[string]struct {
+		reqBody       string
+		mockInput     userServ.ChangePasswordInput
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			reqBody:    `{"current_password":"current-password","new_password":"new-password"}`,
+			mockInput:  userServ.ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "new-password"},
+			statusCode: http.StatusOK,
+			body:       "{\"success\":true,\"msg\":\"Change password successfully\"}",
+		},
+		"password_can_not_be_blank": {
+			reqBody:    `{"current_password":"current-password","new_password":" "}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrPasswordCannotBeBlank,
+		},
+		"incorrect_password": {
+			reqBody:       `{"current_password":"wrong-password","new_password":"new-password"}`,
+			mockInput:     userServ.ChangePasswordInput{CurrentPassword: "wrong-password", NewPassword: "new-password"},
+			mockResultErr: userServ.ErrIncorrectPassword,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrIncorrectPassword,
+		},
+		"password_reused": {
+			reqBody:       `{"current_password":"current-password","new_password":"old-password"}`,
+			mockInput:     userServ.ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "old-password"},
+			mockResultErr: userServ.ErrPasswordReused,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrPasswordReused,
+		},
+		"weak_password": {
+			reqBody:       `{"current_password":"current-password","new_password":"qwerty123"}`,
+			mockInput:     userServ.ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "qwerty123"},
+			mockResultErr: fmt.Errorf("%w: %v", userServ.ErrWeakPassword, password.ErrTooCommon),
+			statusCode:    http.StatusBadRequest,
+			err:           utils.ErrorResponse{Status: http.StatusBadRequest, Code: "weak_password", Desc: "password does not satisfy the password policy: password is too common"},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/me/password", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("ChangePassword", r.Context(), tc.mockInput).Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.ChangePassword(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type UserRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+func validateRoleID(id string) (int, error) {
+	result, err := strconv.Atoi(id)
+	if err != nil || result < 0 {
+		return 0, ErrInvalidID
+	}
+	return result, nil
+}
+
+// uniqueNames trims the names and removes the duplicated ones, blank names are invalid
+func uniqueNames(names []string, errBlank error) ([]string, error) {
+	result := []string{}
+	existed := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, errBlank
+		}
+		if !existed[name] {
+			existed[name] = true
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
+func validateRoleReq(req RoleRequest) (userServ.RoleInput, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return userServ.RoleInput{}, ErrNameCannotBeBlank
+	}
+	permissions, err := uniqueNames(req.Permissions, ErrInvalidPermission)
+	if err != nil {
+		return userServ.RoleInput{}, err
+	}
+
+	return userServ.RoleInput{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	}, nil
+}
+
+// roleInputError converts ErrRoleNotFound to ErrInvalidRole when the role is given in the request body
+func roleInputError(err error) error {
+	if err == userServ.ErrRoleNotFound {
+		return ErrInvalidRole
+	}
+	return err
+}
+
+// GetRoles handle request to get all roles
+func (h Handler) GetRoles(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.GetRoles(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// GetRole handle request to get a role
+func (h Handler) GetRole(w http.ResponseWriter, r *http.Request) {
+	// 1. Get role ID from url param
+	id, err := validateRoleID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Get role using "id"
+	result, err := h.userServ.GetRole(r.Context(), id)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// CreateRole handle request to create a role
+func (h Handler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	// 1. Decode
+	var req RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// 2. Validate request
+	input, err := validateRoleReq(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Create role
+	result, err := h.userServ.CreateRole(r.Context(), input)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, result)
+}
+
+// UpdateRole handle request to update a role and replace its permissions
+func (h Handler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	// 1. Get role ID from url param
+	id, err := validateRoleID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request
+	var req RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+	input, err := validateRoleReq(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	input.ID = id
+
+	// 3. Update role
+	if err := h.userServ.UpdateRole(r.Context(), input); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUpdateRole,
+	})
+}
+
+// DeleteRole handle request to delete a role
+func (h Handler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	// 1. Get role ID from url param
+	id, err := validateRoleID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Delete role using "id"
+	if err := h.userServ.DeleteRole(r.Context(), id); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgDeleteRole,
+	})
+}
+
+// GetPermissions handle request to get all permissions
+func (h Handler) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.GetPermissions(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// GetUserRoles handle request to get the roles of a user
+func (h Handler) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	// 1. Get user ID from url param
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Get roles of the user
+	result, err := h.userServ.GetUserRoles(r.Context(), userID)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// UpdateUserRoles handle request to replace the additional roles of a user
+func (h Handler) UpdateUserRoles(w http.ResponseWriter, r *http.Request) {
+	// 1. Get user ID from url param
+	userID, err := validateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request
+	var req UserRolesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+	roles, err := uniqueNames(req.Roles, ErrInvalidRole)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Replace roles of the user
+	if err := h.userServ.SetUserRoles(r.Context(), userID, roles); err != nil {
+		handleUserError(w, roleInputError(err))
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUpdateUserRoles,
+	})
+}
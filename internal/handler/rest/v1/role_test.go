@@ -0,0 +1,288 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestHandler_CreateRole(t *testing.T) {
+	createdAt := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		reqBody       string
+		mockInput     userServ.RoleInput
+		mockResult    userServ.Role
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			reqBody:    `{"name":" WAREHOUSE ","description":"Warehouse scripts","permissions":["product:write","product:write:any","product:write"]}`,
+			mockInput:  userServ.RoleInput{Name: "WAREHOUSE", Description: "Warehouse scripts", Permissions: []string{auth.PermProductWrite, auth.PermProductWriteAny}},
+			mockResult: userServ.Role{ID: 3, Name: "WAREHOUSE", Description: "Warehouse scripts", Permissions: []string{auth.PermProductWrite, auth.PermProductWriteAny}, CreatedAt: createdAt, UpdatedAt: createdAt},
+			statusCode: http.StatusCreated,
+			body:       "{\"id\":3,\"name\":\"WAREHOUSE\",\"description\":\"Warehouse scripts\",\"permissions\":[\"product:write\",\"product:write:any\"],\"created_at\":\"2022-07-01T00:00:00Z\",\"updated_at\":\"2022-07-01T00:00:00Z\"}",
+		},
+		"name_can_not_be_blank": {
+			reqBody:    `{"name":" ","permissions":["product:write"]}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrNameCannotBeBlank,
+		},
+		"blank_permission": {
+			reqBody:    `{"name":"WAREHOUSE","permissions":[""]}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidPermission,
+		},
+		"unknown_permission": {
+			reqBody:       `{"name":"WAREHOUSE","permissions":["product:delete"]}`,
+			mockInput:     userServ.RoleInput{Name: "WAREHOUSE", Permissions: []string{"product:delete"}},
+			mockResultErr: userServ.ErrInvalidPermission,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrInvalidPermission,
+		},
+		"role_existed": {
+			reqBody:       `{"name":"ADMIN","permissions":[]}`,
+			mockInput:     userServ.RoleInput{Name: "ADMIN", Permissions: []string{}},
+			mockResultErr: userServ.ErrRoleExisted,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrRoleExisted,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/roles", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("CreateRole", r.Context(), tc.mockInput).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.CreateRole(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateRole(t *testing.T) {
+	tcs := map[string]struct {
+		roleID        string
+		reqBody       string
+		mockInput     userServ.RoleInput
+		mockResultErr error
+		statusCode    int
+		err           error
+	}{
+		"success": {
+			roleID:     "3",
+			reqBody:    `{"name":"STOCK","permissions":["product:write"]}`,
+			mockInput:  userServ.RoleInput{ID: 3, Name: "STOCK", Permissions: []string{auth.PermProductWrite}},
+			statusCode: http.StatusOK,
+		},
+		"invalid_id": {
+			roleID:     "abc",
+			reqBody:    `{"name":"STOCK","permissions":["product:write"]}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidID,
+		},
+		"rename_built_in_role": {
+			roleID:        "1",
+			reqBody:       `{"name":"ROOT","permissions":["user:read"]}`,
+			mockInput:     userServ.RoleInput{ID: 1, Name: "ROOT", Permissions: []string{auth.PermUserRead}},
+			mockResultErr: userServ.ErrBuiltInRole,
+			statusCode:    http.StatusConflict,
+			err:           ErrBuiltInRole,
+		},
+		"not_found": {
+			roleID:        "100",
+			reqBody:       `{"name":"STOCK","permissions":["product:write"]}`,
+			mockInput:     userServ.RoleInput{ID: 100, Name: "STOCK", Permissions: []string{auth.PermProductWrite}},
+			mockResultErr: userServ.ErrRoleNotFound,
+			statusCode:    http.StatusNotFound,
+			err:           ErrRoleNotFound,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/roles/"+tc.roleID, strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.roleID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("UpdateRole", r.Context(), tc.mockInput).Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.UpdateRole(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, "{\"success\":true,\"msg\":\""+MsgUpdateRole+"\"}", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_DeleteRole(t *testing.T) {
+	tcs := map[string]struct {
+		roleID        string
+		mockRoleID    int
+		mockResultErr error
+		statusCode    int
+		err           error
+	}{
+		"success": {
+			roleID:     "3",
+			mockRoleID: 3,
+			statusCode: http.StatusOK,
+		},
+		"invalid_id": {
+			roleID:     "-1",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidID,
+		},
+		"built_in_role": {
+			roleID:        "2",
+			mockRoleID:    2,
+			mockResultErr: userServ.ErrBuiltInRole,
+			statusCode:    http.StatusConflict,
+			err:           ErrBuiltInRole,
+		},
+		"primary_role_of_users": {
+			roleID:        "3",
+			mockRoleID:    3,
+			mockResultErr: userServ.ErrRoleInUse,
+			statusCode:    http.StatusConflict,
+			err:           ErrRoleInUse,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/roles/"+tc.roleID, nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.roleID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("DeleteRole", r.Context(), tc.mockRoleID).Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.DeleteRole(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, "{\"success\":true,\"msg\":\""+MsgDeleteRole+"\"}", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateUserRoles(t *testing.T) {
+	tcs := map[string]struct {
+		userID        string
+		reqBody       string
+		mockUserID    int
+		mockRoles     []string
+		mockResultErr error
+		statusCode    int
+		err           error
+	}{
+		"success": {
+			userID:     "10",
+			reqBody:    `{"roles":["WAREHOUSE"," REPORTER "]}`,
+			mockUserID: 10,
+			mockRoles:  []string{"WAREHOUSE", "REPORTER"},
+			statusCode: http.StatusOK,
+		},
+		"remove_all_roles": {
+			userID:     "10",
+			reqBody:    `{"roles":[]}`,
+			mockUserID: 10,
+			mockRoles:  []string{},
+			statusCode: http.StatusOK,
+		},
+		"unknown_role": {
+			userID:        "10",
+			reqBody:       `{"roles":["SUPERMAN"]}`,
+			mockUserID:    10,
+			mockRoles:     []string{"SUPERMAN"},
+			mockResultErr: userServ.ErrRoleNotFound,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrInvalidRole,
+		},
+		"user_not_found": {
+			userID:        "99",
+			reqBody:       `{"roles":["WAREHOUSE"]}`,
+			mockUserID:    99,
+			mockRoles:     []string{"WAREHOUSE"},
+			mockResultErr: userServ.ErrUserNotFound,
+			statusCode:    http.StatusNotFound,
+			err:           ErrUserNotFound,
+		},
+		"invalid_body": {
+			userID:     "10",
+			reqBody:    `{"roles":"WAREHOUSE"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidBodyRequest,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/users/"+tc.userID+"/roles", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.userID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("SetUserRoles", r.Context(), tc.mockUserID, tc.mockRoles).Return(tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.UpdateUserRoles(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, "{\"success\":true,\"msg\":\""+MsgUpdateUserRoles+"\"}", w.Body.String())
+			}
+		})
+	}
+}
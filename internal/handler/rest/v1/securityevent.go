@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+type securityEventFilter struct {
+	UserID int       `json:"user_id"`
+	Type   string    `json:"type"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+type securityEventsRequest struct {
+	Filter     securityEventFilter `json:"filter"`
+	Pagination paginationInput     `json:"pagination"`
+}
+
+type securityEventsResponse struct {
+	SecurityEvents []userServ.SecurityEvent `json:"security_events"`
+	Pagination     pagination               `json:"pagination"`
+}
+
+// WithClient puts the IP address and the user agent of the request into the request context, they are recorded with the security events
+func WithClient(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := auth.NewClientContext(r.Context(), auth.Client{
+			IPAddress: clientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// decodeSecurityEventsRequest decodes the optional request body of the security event lists
+func decodeSecurityEventsRequest(r *http.Request) (securityEventsRequest, error) {
+	var req securityEventsRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return securityEventsRequest{}, ErrInvalidBodyRequest
+		}
+	}
+	return req, nil
+}
+
+// validateSecurityEventsRequest validates the filter and the pagination of the security event lists
+func validateSecurityEventsRequest(req securityEventsRequest) (userServ.SecurityEventsInput, error) {
+	if req.Filter.UserID < 0 {
+		return userServ.SecurityEventsInput{}, ErrInvalidUserID
+	}
+	if req.Filter.Type != "" && !userServ.SecurityEventTypes[req.Filter.Type] {
+		return userServ.SecurityEventsInput{}, ErrInvalidSecurityEventType
+	}
+	if !req.Filter.From.IsZero() && !req.Filter.To.IsZero() && !req.Filter.From.Before(req.Filter.To) {
+		return userServ.SecurityEventsInput{}, ErrInvalidTimeRange
+	}
+
+	pageInput, err := validatePagination(req.Pagination)
+	if err != nil {
+		return userServ.SecurityEventsInput{}, err
+	}
+
+	return userServ.SecurityEventsInput{
+		UserID:     req.Filter.UserID,
+		Type:       req.Filter.Type,
+		From:       req.Filter.From,
+		To:         req.Filter.To,
+		Pagination: pageInput,
+	}, nil
+}
+
+// GetSecurityEvents handle request to search the security events of the users by user, type and time range
+func (h Handler) GetSecurityEvents(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeSecurityEventsRequest(r)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	input, err := validateSecurityEventsRequest(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	result, totalCount, err := h.userServ.GetSecurityEvents(r.Context(), input)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, securityEventsResponse{
+		SecurityEvents: result,
+		Pagination: pagination{
+			CurrentPage: input.Pagination.Page,
+			Limit:       input.Pagination.Limit,
+			TotalCount:  totalCount,
+		},
+	})
+}
+
+// GetCurrentUserSecurityEvents handle request of the current user to view their recent activity, only the pagination of the body is used
+func (h Handler) GetCurrentUserSecurityEvents(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeSecurityEventsRequest(r)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+	pageInput, err := validatePagination(req.Pagination)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	result, totalCount, err := h.userServ.GetCurrentUserSecurityEvents(r.Context(), pageInput)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, securityEventsResponse{
+		SecurityEvents: result,
+		Pagination: pagination{
+			CurrentPage: pageInput.Page,
+			Limit:       pageInput.Limit,
+			TotalCount:  totalCount,
+		},
+	})
+}
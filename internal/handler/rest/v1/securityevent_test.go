@@ -0,0 +1,179 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestHandler_GetSecurityEvents(t *testing.T) {
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		body       string
+		mockInput  userServ.SecurityEventsInput
+		statusCode int
+		expBody    string
+		err        error
+	}{
+		"success": {
+			body: `{
+				"filter": {
+					"user_id": 10,
+					"type": "LOGIN_FAILED",
+					"from": "2022-01-01T00:00:00Z",
+					"to": "2022-02-01T00:00:00Z"
+				},
+				"pagination": {"page": 2, "limit": 10}
+			}`,
+			mockInput: userServ.SecurityEventsInput{
+				UserID:     10,
+				Type:       "LOGIN_FAILED",
+				From:       from,
+				To:         to,
+				Pagination: userServ.Pagination{Page: 2, Limit: 10},
+			},
+			statusCode: http.StatusOK,
+			expBody:    `{"security_events":[{"id":1,"user_id":10,"type":"LOGIN_FAILED","email":"mai@example.com","ip_address":"192.0.2.1","user_agent":"curl/7.79.1","created_at":"2022-01-01T10:00:00Z"}],"pagination":{"current_page":2,"limit":10,"total_count":1}}`,
+		},
+		"success_empty_body": {
+			mockInput:  userServ.SecurityEventsInput{Pagination: userServ.Pagination{Page: 1, Limit: 20}},
+			statusCode: http.StatusOK,
+			expBody:    `{"security_events":[{"id":1,"user_id":10,"type":"LOGIN_FAILED","email":"mai@example.com","ip_address":"192.0.2.1","user_agent":"curl/7.79.1","created_at":"2022-01-01T10:00:00Z"}],"pagination":{"current_page":1,"limit":20,"total_count":1}}`,
+		},
+		"invalid_request_body": {
+			body:       `{{abc`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidBodyRequest,
+		},
+		"invalid_user_id": {
+			body:       `{"filter": {"user_id": -1}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidUserID,
+		},
+		"invalid_type": {
+			body:       `{"filter": {"type": "LOGOUT"}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidSecurityEventType,
+		},
+		"invalid_time_range": {
+			body:       `{"filter": {"from": "2022-02-01T00:00:00Z", "to": "2022-01-01T00:00:00Z"}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidTimeRange,
+		},
+		"invalid_pagination": {
+			body:       `{"pagination": {"page": -1}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidPaginationPage,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users/security-events", strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("GetSecurityEvents", r.Context(), tc.mockInput).Return([]userServ.SecurityEvent{{
+				ID: 1, UserID: 10, Type: "LOGIN_FAILED", Email: "mai@example.com",
+				IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1", CreatedAt: time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC),
+			}}, int64(1), nil)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.GetSecurityEvents(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				serviceMock.AssertNotCalled(t, "GetSecurityEvents", mock.Anything, mock.Anything)
+				return
+			}
+			require.Equal(t, tc.expBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetCurrentUserSecurityEvents(t *testing.T) {
+	tcs := map[string]struct {
+		body          string
+		mockInput     userServ.Pagination
+		mockResultErr error
+		statusCode    int
+		expBody       string
+		err           error
+	}{
+		"success": {
+			body:       `{"pagination": {"page": 1, "limit": 5}}`,
+			mockInput:  userServ.Pagination{Page: 1, Limit: 5},
+			statusCode: http.StatusOK,
+			expBody:    `{"security_events":[],"pagination":{"current_page":1,"limit":5,"total_count":0}}`,
+		},
+		"invalid_pagination": {
+			body:       `{"pagination": {"limit": -1}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidPaginationLimit,
+		},
+		"permission_denied": {
+			mockInput:     userServ.Pagination{Page: 1, Limit: 20},
+			mockResultErr: userServ.ErrPermissionDenied,
+			statusCode:    http.StatusForbidden,
+			err:           ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/me/security-events", strings.NewReader(tc.body))
+			r = r.WithContext(auth.NewContext(r.Context(), auth.User{ID: 10, Role: auth.RoleGuest}))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("GetCurrentUserSecurityEvents", r.Context(), tc.mockInput).Return([]userServ.SecurityEvent{}, int64(0), tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.GetCurrentUserSecurityEvents(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, tc.expBody, w.Body.String())
+		})
+	}
+}
+
+func TestWithClient(t *testing.T) {
+	// GIVEN
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("User-Agent", "curl/7.79.1")
+	w := httptest.NewRecorder()
+
+	var client auth.Client
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client = auth.ClientFromContext(r.Context())
+	})
+
+	// WHEN
+	WithClient(next).ServeHTTP(w, r)
+
+	// THEN
+	require.Equal(t, auth.Client{IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1"}, client)
+}
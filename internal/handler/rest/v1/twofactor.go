@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+// LoginTwoFactor handle request to complete the login by a two-factor code
+func (h Handler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	// Get request body
+	var req TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// Validate request
+	challengeToken := strings.TrimSpace(req.ChallengeToken)
+	if challengeToken == "" {
+		handleUserError(w, ErrTokenCannotBeBlank)
+		return
+	}
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		handleUserError(w, ErrCodeCannotBeBlank)
+		return
+	}
+
+	// Call login two-factor func of service
+	result, err := h.userServ.LoginTwoFactor(r.Context(), userServ.TwoFactorLoginInput{
+		ChallengeToken: challengeToken,
+		Code:           code,
+		IPAddress:      clientIP(r),
+	})
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// EnrollTwoFactor handle request to generate a TOTP secret for the current user
+func (h Handler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	result, err := h.userServ.EnrollTwoFactor(r.Context())
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code"`
+}
+
+type confirmTwoFactorResponse struct {
+	BackupCodes []string `json:"backup_codes"`
+}
+
+// ConfirmTwoFactor handle request to enable two-factor authentication by a code of the enrolled secret
+func (h Handler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	// Get request body
+	var req ConfirmTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// Validate request
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		handleUserError(w, ErrCodeCannotBeBlank)
+		return
+	}
+
+	// Call confirm two-factor func of service
+	backupCodes, err := h.userServ.ConfirmTwoFactor(r.Context(), code)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, confirmTwoFactorResponse{BackupCodes: backupCodes})
+}
@@ -0,0 +1,223 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+)
+
+func TestHandler_LoginTwoFactor(t *testing.T) {
+	type input struct {
+		reqBody       string
+		mockInput     userServ.TwoFactorLoginInput
+		mockResult    userServ.LoginResponse
+		mockResultErr error
+	}
+	type output struct {
+		body       userServ.LoginResponse
+		statusCode int
+		err        error
+	}
+	tcs := map[string]struct {
+		input     input
+		expOutput output
+	}{
+		"success": {
+			input: input{
+				reqBody: `{"challenge_token":"challenge","code":"123456"}`,
+				mockInput: userServ.TwoFactorLoginInput{
+					ChallengeToken: "challenge",
+					Code:           "123456",
+					IPAddress:      "192.0.2.1",
+				},
+				mockResult: userServ.LoginResponse{
+					AccessToken:  "access",
+					RefreshToken: "refresh",
+					Scope:        "ADMIN",
+					ExpiresIn:    1800,
+					TokenType:    "Bearer",
+				},
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body: userServ.LoginResponse{
+					AccessToken:  "access",
+					RefreshToken: "refresh",
+					Scope:        "ADMIN",
+					ExpiresIn:    1800,
+					TokenType:    "Bearer",
+				},
+			},
+		},
+		"token_can_not_be_blank": {
+			input: input{
+				reqBody: `{"challenge_token":"","code":"123456"}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrTokenCannotBeBlank,
+			},
+		},
+		"code_can_not_be_blank": {
+			input: input{
+				reqBody: `{"challenge_token":"challenge","code":" "}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrCodeCannotBeBlank,
+			},
+		},
+		"invalid_code": {
+			input: input{
+				reqBody: `{"challenge_token":"challenge","code":"123456"}`,
+				mockInput: userServ.TwoFactorLoginInput{
+					ChallengeToken: "challenge",
+					Code:           "123456",
+					IPAddress:      "192.0.2.1",
+				},
+				mockResultErr: userServ.ErrInvalidTwoFactorCode,
+			},
+			expOutput: output{
+				statusCode: http.StatusUnauthorized,
+				err:        ErrInvalidTwoFactorCode,
+			},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/login/2fa", strings.NewReader(tc.input.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("LoginTwoFactor", r.Context(), tc.input.mockInput).Return(tc.input.mockResult, tc.input.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.LoginTwoFactor(w, r)
+
+			// THEN
+			require.Equal(t, tc.expOutput.statusCode, w.Code)
+			if tc.expOutput.err != nil {
+				require.EqualError(t, tc.expOutput.err, w.Body.String())
+			} else {
+				var result userServ.LoginResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				require.Equal(t, tc.expOutput.body, result)
+			}
+		})
+	}
+}
+
+func TestHandler_EnrollTwoFactor(t *testing.T) {
+	tcs := map[string]struct {
+		mockResult    userServ.TwoFactorEnrollment
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			mockResult: userServ.TwoFactorEnrollment{Secret: "SECRET", OtpauthURI: "otpauth://totp/app:admin@example.com?secret=SECRET"},
+			statusCode: http.StatusOK,
+			body:       "{\"secret\":\"SECRET\",\"otpauth_uri\":\"otpauth://totp/app:admin@example.com?secret=SECRET\"}",
+		},
+		"already_enabled": {
+			mockResultErr: userServ.ErrTwoFactorEnabled,
+			statusCode:    http.StatusConflict,
+			err:           ErrTwoFactorEnabled,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/2fa/enroll", nil)
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("EnrollTwoFactor", r.Context()).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.EnrollTwoFactor(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ConfirmTwoFactor(t *testing.T) {
+	tcs := map[string]struct {
+		reqBody       string
+		mockInput     string
+		mockResult    []string
+		mockResultErr error
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			reqBody:    `{"code":"123456"}`,
+			mockInput:  "123456",
+			mockResult: []string{"abcdef123456", "123456abcdef"},
+			statusCode: http.StatusOK,
+			body:       "{\"backup_codes\":[\"abcdef123456\",\"123456abcdef\"]}",
+		},
+		"code_can_not_be_blank": {
+			reqBody:    `{"code":""}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrCodeCannotBeBlank,
+		},
+		"not_enrolled": {
+			reqBody:       `{"code":"123456"}`,
+			mockInput:     "123456",
+			mockResultErr: userServ.ErrTwoFactorNotEnrolled,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrTwoFactorNotEnrolled,
+		},
+		"invalid_code": {
+			reqBody:       `{"code":"123456"}`,
+			mockInput:     "123456",
+			mockResultErr: userServ.ErrInvalidTwoFactorCode,
+			statusCode:    http.StatusUnauthorized,
+			err:           ErrInvalidTwoFactorCode,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/2fa/confirm", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("ConfirmTwoFactor", r.Context(), tc.mockInput).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.ConfirmTwoFactor(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
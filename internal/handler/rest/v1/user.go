@@ -2,6 +2,7 @@ package v1
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/mail"
 	"strconv"
@@ -10,17 +11,13 @@ import (
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
 	"github.com/volatiletech/null/v8"
 
 	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
 )
 
-const (
-	UserRoleAdmin = "ADMIN"
-	UserRoleGuest = "GUEST"
-)
-
 type userRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
@@ -30,10 +27,6 @@ type userRequest struct {
 	IsActive bool   `json:"is_active" validate:"required"`
 }
 
-func isValidRole(role string) bool {
-	return role == UserRoleAdmin || role == UserRoleGuest
-}
-
 func validateUserID(id string) (int, error) {
 	userID, err := strconv.Atoi(id)
 	if err != nil || userID < 0 {
@@ -43,19 +36,25 @@ func validateUserID(id string) (int, error) {
 }
 
 func validateUserInput(req userRequest) (userServ.InputUser, error) {
+	return validateUser(req, true)
+}
+
+// validateUpdateUserInput validates the input of UpdateUser, the password is only replaced when one is given
+func validateUpdateUserInput(req userRequest) (userServ.InputUser, error) {
+	return validateUser(req, false)
+}
+
+func validateUser(req userRequest, passwordRequired bool) (userServ.InputUser, error) {
 	if _, err := mail.ParseAddress(req.Email); err != nil { // parsed without error means valid email
 		return userServ.InputUser{}, ErrInvalidEmail
 	}
-	if !isValidRole(req.Role) {
-		return userServ.InputUser{}, ErrInvalidRole
-	}
 	if strings.TrimSpace(req.Name) == "" {
 		return userServ.InputUser{}, ErrNameCannotBeBlank
 	}
 	if strings.TrimSpace(req.Email) == "" {
 		return userServ.InputUser{}, ErrEmailCannotBeBlank
 	}
-	if strings.TrimSpace(req.Password) == "" {
+	if strings.TrimSpace(req.Password) == "" && (passwordRequired || req.Password != "") {
 		return userServ.InputUser{}, ErrPasswordCannotBeBlank
 	}
 	if strings.TrimSpace(req.Phone) == "" {
@@ -94,7 +93,7 @@ func (h Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	// 3. Create User
 	result, err := h.userServ.CreateUser(r.Context(), userInput)
 	if err != nil {
-		handleUserError(w, err)
+		handleUserError(w, roleInputError(err))
 		return
 	}
 
@@ -140,9 +139,6 @@ func validateGetUserInput(req getUserRequest) (userServ.InputGetUser, error) {
 		}
 	}
 	filterRole := strings.TrimSpace(req.Role)
-	if filterRole != "" && filterRole != UserRoleAdmin && filterRole != UserRoleGuest {
-		return userServ.InputGetUser{}, ErrInvalidRole
-	}
 	filterName := strings.TrimSpace(req.Name)
 
 	sortName := strings.TrimSpace(req.Sort.Name)
@@ -180,6 +176,16 @@ func validateGetUserInput(req getUserRequest) (userServ.InputGetUser, error) {
 
 // GetUsers returns list of users with given input.
 func (h Handler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	h.getUsers(w, r, false)
+}
+
+// GetDeletedUsers returns list of deleted users with given input.
+func (h Handler) GetDeletedUsers(w http.ResponseWriter, r *http.Request) {
+	h.getUsers(w, r, true)
+}
+
+// getUsers returns list of the deleted or not deleted users with given input.
+func (h Handler) getUsers(w http.ResponseWriter, r *http.Request, deleted bool) {
 	// 1. Decode request
 	var req getUserRequest
 	if r.ContentLength > 0 {
@@ -195,6 +201,7 @@ func (h Handler) GetUsers(w http.ResponseWriter, r *http.Request) {
 		handleUserError(w, err)
 		return
 	}
+	getInput.Deleted = deleted
 
 	// 2. Get users
 	result, totalCount, err := h.userServ.GetUsers(r.Context(), getInput)
@@ -235,7 +242,7 @@ func (h Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	inputUser, err := validateUserInput(userReq)
+	inputUser, err := validateUpdateUserInput(userReq)
 	if err != nil {
 		handleUserError(w, err)
 		return
@@ -244,7 +251,7 @@ func (h Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	// 4. Call service to update user
 	if err = h.userServ.UpdateUser(r.Context(), inputUser); err != nil {
-		handleUserError(w, err)
+		handleUserError(w, roleInputError(err))
 		return
 	}
 
@@ -277,6 +284,22 @@ func (h Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 
 const (
 	MsgDeleteUserSuccess = "Delete user successfully"
+	MsgUnlockUser        = "Unlock user successfully"
+	MsgRestoreUser       = "Restore user successfully"
+	MsgLogoutSuccess     = "Logout successfully"
+	MsgForgotPassword    = "If the email is registered, a password reset link has been sent"
+	MsgResetPassword     = "Reset password successfully"
+	MsgChangePassword    = "Change password successfully"
+	MsgVerifyEmail       = "Verify email successfully"
+	MsgResendVerifyEmail = "If the email is registered and not verified, a verification link has been sent"
+	MsgRevokeAPIKey      = "Revoke API key successfully"
+	MsgUpdateRole        = "Update role successfully"
+	MsgDeleteRole        = "Delete role successfully"
+	MsgUpdateUserRoles   = "Update user roles successfully"
+	MsgEndImpersonation  = "End impersonation successfully"
+	MsgUpdateAddress     = "Update address successfully"
+	MsgDeleteAddress     = "Delete address successfully"
+	MsgEraseUser         = "Erase user successfully"
 )
 
 func (h Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
@@ -301,6 +324,52 @@ func (h Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RestoreUser restores a deleted user
+func (h Handler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	// 1. Get user ID from url param
+	id := chi.URLParam(r, "id")
+
+	// 2. Validate ID
+	userID, err := validateUserID(id)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Restore user using "id"
+	if err := h.userServ.RestoreUser(r.Context(), userID); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgRestoreUser,
+	})
+}
+
+// UnlockUser clears the failed logins which lock the user
+func (h Handler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	// 1. Get user ID from url param
+	id := chi.URLParam(r, "id")
+
+	// 2. Validate ID
+	userID, err := validateUserID(id)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Unlock user using "id"
+	if err := h.userServ.UnlockUser(r.Context(), userID); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUnlockUser,
+	})
+}
+
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -323,6 +392,15 @@ func validateLoginReq(loginReq LoginRequest) (userServ.LoginInput, error) {
 	}, nil
 }
 
+// clientIP returns the IP address of the client which sent the request
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // Login handle login request
 func (h Handler) Login(w http.ResponseWriter, r *http.Request) {
 	// Get request body
@@ -338,6 +416,7 @@ func (h Handler) Login(w http.ResponseWriter, r *http.Request) {
 		handleUserError(w, err)
 		return
 	}
+	loginInput.IPAddress = clientIP(r)
 
 	// Call login func of service
 	result, err := h.userServ.Login(r.Context(), loginInput)
@@ -348,3 +427,190 @@ func (h Handler) Login(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteJSONResponse(w, http.StatusOK, result)
 }
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshToken handle request to rotate the refresh token and get a new access token
+func (h Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	// Get request body
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	refreshToken := strings.TrimSpace(req.RefreshToken)
+	if refreshToken == "" {
+		handleUserError(w, ErrTokenCannotBeBlank)
+		return
+	}
+
+	// Call refresh token func of service
+	result, err := h.userServ.RefreshToken(r.Context(), refreshToken)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout handle request to revoke the current tokens
+func (h Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	// Get request body, the refresh token is optional
+	var req LogoutRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			handleUserError(w, ErrInvalidBodyRequest)
+			return
+		}
+	}
+
+	// Call logout func of service
+	if err := h.userServ.Logout(r.Context(), userServ.LogoutInput{
+		AccessToken:  jwtauth.TokenFromHeader(r),
+		RefreshToken: strings.TrimSpace(req.RefreshToken),
+	}); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgLogoutSuccess,
+	})
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ForgotPassword handle request to send a password reset link
+func (h Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	// Get request body
+	var req ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// Validate email
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		handleUserError(w, ErrEmailCannotBeBlank)
+		return
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		handleUserError(w, ErrInvalidEmail)
+		return
+	}
+
+	// Call forgot password func of service
+	if err := h.userServ.ForgotPassword(r.Context(), email); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgForgotPassword,
+	})
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ResetPassword handle request to set a new password by the password reset token
+func (h Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	// Get request body
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// Validate request
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		handleUserError(w, ErrTokenCannotBeBlank)
+		return
+	}
+	if strings.TrimSpace(req.Password) == "" {
+		handleUserError(w, ErrPasswordCannotBeBlank)
+		return
+	}
+
+	// Call reset password func of service
+	if err := h.userServ.ResetPassword(r.Context(), userServ.ResetPasswordInput{
+		Token:    token,
+		Password: req.Password,
+	}); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgResetPassword,
+	})
+}
+
+// VerifyEmail handle request from the link in the verification email
+func (h Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	// Get token from query param
+	token := strings.TrimSpace(r.URL.Query().Get("token"))
+	if token == "" {
+		handleUserError(w, ErrTokenCannotBeBlank)
+		return
+	}
+
+	// Call verify email func of service
+	if err := h.userServ.VerifyEmail(r.Context(), token); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgVerifyEmail,
+	})
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// ResendVerificationEmail handle request to send the verification email again
+func (h Handler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	// Get request body
+	var req ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+
+	// Validate email
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		handleUserError(w, ErrEmailCannotBeBlank)
+		return
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		handleUserError(w, ErrInvalidEmail)
+		return
+	}
+
+	// Call resend verification email func of service
+	if err := h.userServ.ResendVerificationEmail(r.Context(), email); err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgResendVerifyEmail,
+	})
+}
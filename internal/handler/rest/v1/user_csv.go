@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+// ImportUsersCSV handle request to create users from a csv file, the form value "invite" sends invite emails
+// instead of returning the temporary passwords
+func (h Handler) ImportUsersCSV(w http.ResponseWriter, r *http.Request) {
+	// 1. Check max size upload csv file (1MB)
+	r.Body = http.MaxBytesReader(w, r.Body, maxSizeUploadCSVFile)
+	if err := r.ParseMultipartForm(maxSizeUploadCSVFile); err != nil {
+		handleUserError(w, ErrFileSizeTooLarge)
+		return
+	}
+
+	// 2. Get csv file from request body
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		handleUserError(w, ErrInvalidBodyRequest)
+		return
+	}
+	defer file.Close()
+	if filepath.Ext(fileHeader.Filename) != ".csv" {
+		handleUserError(w, ErrInvalidFileType)
+		return
+	}
+	sendInvites := false
+	if invite := r.FormValue("invite"); invite != "" {
+		if sendInvites, err = strconv.ParseBool(invite); err != nil {
+			handleUserError(w, ErrInvalidBodyRequest)
+			return
+		}
+	}
+
+	// 3. Import users
+	result, err := h.userServ.ImportUsersCSV(r.Context(), file, sendInvites)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// ExportUsersCSV handle request to download the users which match the filter of get users as a csv file
+func (h Handler) ExportUsersCSV(w http.ResponseWriter, r *http.Request) {
+	// 1. Decode request
+	var req getUserRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			handleUserError(w, ErrInvalidBodyRequest)
+			return
+		}
+	}
+
+	// 2. Validate request
+	input, err := validateGetUserInput(req)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	// 3. Export users
+	result, err := h.userServ.ExportUsersCSV(r.Context(), input)
+	if err != nil {
+		handleUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=users_%s.csv", time.Now().Format("20060102")))
+	w.WriteHeader(http.StatusOK)
+	w.Write(result)
+}
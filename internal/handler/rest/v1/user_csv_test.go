@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	userServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/user"
+)
+
+func TestHandler_ImportUsersCSV(t *testing.T) {
+	tcs := map[string]struct {
+		fileName      string
+		invite        string
+		mockInvites   bool
+		mockResult    userServ.ImportUsersResult
+		mockResultErr error
+		isCallToServ  bool
+		statusCode    int
+		body          string
+		err           error
+	}{
+		"success": {
+			fileName:     "users.csv",
+			invite:       "true",
+			mockInvites:  true,
+			mockResult:   userServ.ImportUsersResult{Created: []userServ.ImportedUser{{Row: 2, ID: 10, Email: "mai@example.com"}}, Failed: []userServ.ImportUserError{}},
+			isCallToServ: true,
+			statusCode:   http.StatusOK,
+			body:         "{\"created\":[{\"row\":2,\"id\":10,\"email\":\"mai@example.com\"}],\"failed\":[]}",
+		},
+		"invalid_file_type": {
+			fileName:   "users.txt",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidFileType,
+		},
+		"invalid_invite": {
+			fileName:   "users.csv",
+			invite:     "maybe",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidBodyRequest,
+		},
+		"invalid_csv_header": {
+			fileName:      "users.csv",
+			mockResultErr: userServ.ErrInvalidCSVHeader,
+			isCallToServ:  true,
+			statusCode:    http.StatusBadRequest,
+			err:           ErrInvalidCSVHeader,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			reqBody := new(bytes.Buffer)
+			mw := multipart.NewWriter(reqBody)
+			formWriter, err := mw.CreateFormFile("file", tc.fileName)
+			require.NoError(t, err)
+			_, err = formWriter.Write([]byte("name,email\nMai,mai@example.com\n"))
+			require.NoError(t, err)
+			if tc.invite != "" {
+				require.NoError(t, mw.WriteField("invite", tc.invite))
+			}
+			mw.Close()
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/import-csv", reqBody)
+			r.Header.Set("Content-Type", mw.FormDataContentType())
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("ImportUsersCSV", r.Context(), mock.Anything, tc.mockInvites).Return(tc.mockResult, tc.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.ImportUsersCSV(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+			if !tc.isCallToServ {
+				serviceMock.AssertNotCalled(t, "ImportUsersCSV", r.Context(), mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandler_ExportUsersCSV(t *testing.T) {
+	tcs := map[string]struct {
+		reqBody      string
+		mockInput    userServ.InputGetUser
+		isCallToServ bool
+		statusCode   int
+		body         string
+		err          error
+	}{
+		"success": {
+			reqBody:      `{"role":"GUEST"}`,
+			mockInput:    userServ.InputGetUser{Role: "GUEST", Pagination: userServ.Pagination{Page: 1, Limit: 20}},
+			isCallToServ: true,
+			statusCode:   http.StatusOK,
+			body:         "id,name,email\n1,Mai,mai@example.com\n",
+		},
+		"invalid_email": {
+			reqBody:    `{"email":"not-an-email"}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidEmail,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users/export/csv", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("ExportUsersCSV", r.Context(), tc.mockInput).Return([]byte("id,name,email\n1,Mai,mai@example.com\n"), nil)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.ExportUsersCSV(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.body, w.Body.String())
+				require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+			}
+			if !tc.isCallToServ {
+				serviceMock.AssertNotCalled(t, "ExportUsersCSV", r.Context(), mock.Anything)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/volatiletech/null/v8"
@@ -138,16 +139,9 @@ func TestHandler_CreateUser(t *testing.T) {
 						Role:     "invalid",
 						IsActive: true,
 					},
-					result: model.User{
-						ID:       1,
-						Name:     "guest",
-						Email:    "guest@example.com",
-						Password: "123456",
-						Phone:    "123456",
-						Role:     "invalid",
-						IsActive: true,
-					},
+					err: userServ.ErrRoleNotFound,
 				},
+				isCallToServ: true,
 			},
 			expResult: expectedData{
 				statusCode: 400,
@@ -493,17 +487,30 @@ func TestHandler_GetUsers(t *testing.T) {
 			},
 			expErr: ErrInvalidEmail,
 		},
-		"error_invalid_role": {
+		"success_filter_by_custom_role": {
 			given: givenData{
 				reqBody: `{
-					"role": "SUPERMAN"
+					"role": "WAREHOUSE"
 				}`,
-				isCallToServ: false,
+				mock: mockData{
+					input: userServ.InputGetUser{
+						Role:       "WAREHOUSE",
+						Pagination: userServ.Pagination{Page: 1, Limit: 20},
+					},
+					output: []model.User{},
+				},
+				isCallToServ: true,
 			},
 			expResult: expectedData{
-				statusCode: http.StatusBadRequest,
+				statusCode: http.StatusOK,
+				data: usersResponse{
+					Users: []model.User{},
+					Pagination: pagination{
+						CurrentPage: 1,
+						Limit:       20,
+					},
+				},
 			},
-			expErr: ErrInvalidRole,
 		},
 		"error_invalid_sort_order_type": {
 			given: givenData{
@@ -632,6 +639,110 @@ func TestHandler_GetUsers(t *testing.T) {
 	}
 }
 
+func TestHandler_GetDeletedUsers(t *testing.T) {
+	type mockData struct {
+		input      userServ.InputGetUser
+		totalCount int64
+		output     []model.User
+		err        error
+	}
+
+	type givenData struct {
+		reqBody      string
+		mock         mockData
+		isCallToServ bool
+	}
+
+	type expectedData struct {
+		statusCode int
+		data       usersResponse
+	}
+
+	deletedAt := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		given     givenData
+		expResult expectedData
+		expErr    error
+	}{
+		"success": {
+			given: givenData{
+				reqBody: `{
+					"role": "GUEST"
+				}`,
+				mock: mockData{
+					input: userServ.InputGetUser{
+						Role:       "GUEST",
+						Deleted:    true,
+						Pagination: userServ.Pagination{Page: 1, Limit: 20},
+					},
+					totalCount: 1,
+					output: []model.User{
+						{ID: 1, Name: "test", Email: "test@exam.com", Role: "GUEST", DeletedAt: null.TimeFrom(deletedAt)},
+					},
+				},
+				isCallToServ: true,
+			},
+			expResult: expectedData{
+				statusCode: http.StatusOK,
+				data: usersResponse{
+					Users: []model.User{
+						{ID: 1, Name: "test", Email: "test@exam.com", Role: "GUEST", DeletedAt: null.TimeFrom(deletedAt)},
+					},
+					Pagination: pagination{
+						CurrentPage: 1,
+						Limit:       20,
+						TotalCount:  1,
+					},
+				},
+			},
+		},
+		"invalid_sort_type": {
+			given: givenData{
+				reqBody: `{
+					"sort": {
+						"name": "abc"
+					}
+				}`,
+			},
+			expResult: expectedData{
+				statusCode: http.StatusBadRequest,
+			},
+			expErr: ErrInvalidSortType,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			serviceMock := new(userServ.Mock)
+			if tc.given.isCallToServ {
+				serviceMock.On("GetUsers", context.Background(), tc.given.mock.input).Return(tc.given.mock.output, tc.given.mock.totalCount, tc.given.mock.err)
+			}
+			handler := NewHandler(serviceMock, nil, nil)
+
+			r := httptest.NewRequest("GET", "/api/v1/users/deleted", strings.NewReader(tc.given.reqBody))
+
+			w := httptest.NewRecorder()
+
+			// When
+			handler.GetDeletedUsers(w, r)
+
+			// Then
+			require.Equal(t, tc.expResult.statusCode, w.Code)
+			if tc.expErr != nil {
+				require.EqualError(t, tc.expErr, w.Body.String())
+			} else {
+				var actualResult usersResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &actualResult); err != nil {
+					t.Fatal(err)
+				}
+				require.Equal(t, tc.expResult.data, actualResult, "Should be equal expected result")
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUserHandler_UpdateUser(t *testing.T) {
 	type input struct {
 		userID     string
@@ -746,6 +857,16 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 					"role": "Nope",
 					"is_active": true
 				}`,
+				mockInput: userServ.InputUser{
+					ID:       2,
+					Name:     "guest",
+					Email:    "guest@example.com",
+					Password: "123456",
+					Phone:    "123456",
+					Role:     "Nope",
+					IsActive: true,
+				},
+				mockResult: userServ.ErrRoleNotFound,
 			},
 			expOutput: output{
 				expStatusCode: http.StatusBadRequest,
@@ -769,7 +890,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 				expErr:        ErrNameCannotBeBlank,
 			},
 		},
-		"missing_password_field": {
+		"success_without_password": {
 			input: input{
 				userID: "1",
 				reqBody: `{
@@ -780,6 +901,31 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 					"role": "GUEST",
 					"is_active": true
 				}`,
+				mockInput: userServ.InputUser{
+					ID:       1,
+					Name:     "sdfaf",
+					Email:    "guest@example.com",
+					Phone:    "123456",
+					Role:     "GUEST",
+					IsActive: true,
+				},
+			},
+			expOutput: output{
+				expStatusCode: http.StatusOK,
+				expResult:     `{"success":true,"msg":"Update user successfully"}`,
+			},
+		},
+		"blank_password_field": {
+			input: input{
+				userID: "1",
+				reqBody: `{
+					"name": "sdfaf",
+					"email": "guest@example.com",
+					"password": "   ",
+					"phone": "123456",
+					"role": "GUEST",
+					"is_active": true
+				}`,
 			},
 			expOutput: output{
 				expStatusCode: http.StatusBadRequest,
@@ -1030,109 +1176,299 @@ func TestHandler_DeleteUser(t *testing.T) {
 	}
 }
 
-func TestHandler_Login(t *testing.T) {
-	type input struct {
-		reqBody       string
-		mockInput     userServ.LoginInput
-		mockResult    userServ.LoginResponse
-		mockResultErr error
+func TestHandler_UnlockUser(t *testing.T) {
+	type mockData struct {
+		userID int
+		err    error
 	}
-	type output struct {
-		body       userServ.LoginResponse
+
+	type givenData struct {
+		userID string
+		mock   mockData
+	}
+
+	type expectedData struct {
 		statusCode int
-		err        error
+		result     string
 	}
+
 	tcs := map[string]struct {
-		input     input
-		expOutput output
+		given     givenData
+		expResult expectedData
+		expErr    error
 	}{
 		"success": {
-			input: input{
-				reqBody: `{
-				   "email":"example@example.com",
-				   "password":"123456789"
-				}`,
-				mockInput: userServ.LoginInput{
-					Email:    "example@example.com",
-					Password: "123456789",
-				},
-				mockResult: userServ.LoginResponse{
-					AccessToken: "fbhaffiuerfweifewiuffgefhjgfiuyfr",
-					Scope:       "GUEST",
-					ExpiresIn:   1800,
-					TokenType:   "Bearer",
+			given: givenData{
+				userID: "1",
+				mock: mockData{
+					userID: 1,
 				},
 			},
-			expOutput: output{
+			expResult: expectedData{
 				statusCode: http.StatusOK,
-				body: userServ.LoginResponse{
-					AccessToken: "fbhaffiuerfweifewiuffgefhjgfiuyfr",
-					Scope:       "GUEST",
-					ExpiresIn:   1800,
-					TokenType:   "Bearer",
-				},
-			},
-		},
-		"invalid_email": {
-			input: input{
-				reqBody: `{
-				   "email":"exampleexample.com",
-				   "password":"123456789"
-				}`,
-			},
-			expOutput: output{
-				statusCode: http.StatusBadRequest,
-				err:        ErrInvalidEmail,
-			},
-		},
-		"password_can_not_be_blank": {
-			input: input{
-				reqBody: `{
-				   "email":"example@example.com",
-				   "password":""
-				}`,
-			},
-			expOutput: output{
-				statusCode: http.StatusBadRequest,
-				err:        ErrPasswordCannotBeBlank,
+				result:     "{\"success\":true,\"msg\":\"Unlock user successfully\"}",
 			},
 		},
-		"invalid_request_body": {
-			input: input{
-				reqBody: `{
-				   "email":"example@example.com",
-				   "password":"123456",
-				}`,
+		"invalid_user_id": {
+			given: givenData{
+				userID: "abc",
 			},
-			expOutput: output{
+			expResult: expectedData{
 				statusCode: http.StatusBadRequest,
-				err:        ErrInvalidBodyRequest,
 			},
+			expErr: ErrInvalidUserID,
 		},
-		"password_is_incorrect": {
-			input: input{
-				reqBody: `{
-				   "email":"example@example.com",
-				   "password":"123456789"
-				}`,
-				mockInput: userServ.LoginInput{
-					Email:    "example@example.com",
-					Password: "123456789",
+		"user_not_found": {
+			given: givenData{
+				userID: "1",
+				mock: mockData{
+					userID: 1,
+					err:    userServ.ErrUserNotFound,
 				},
-				mockResultErr: userServ.ErrPasswordIncorrect,
 			},
-			expOutput: output{
-				statusCode: http.StatusUnauthorized,
-				err:        ErrPasswordIncorrect,
+			expResult: expectedData{
+				statusCode: http.StatusNotFound,
 			},
+			expErr: ErrUserNotFound,
 		},
 	}
+
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
-			// GIVEN
-			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/login", strings.NewReader(tc.input.reqBody))
+			// Given
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+tc.given.userID+"/unlock", nil)
 			w := httptest.NewRecorder()
-
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.given.userID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("UnlockUser", r.Context(), tc.given.mock.userID).Return(tc.given.mock.err)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// When
+			handler.UnlockUser(w, r)
+
+			// Then
+			require.Equal(t, tc.expResult.statusCode, w.Code)
+			if tc.expErr != nil {
+				require.EqualError(t, tc.expErr, w.Body.String())
+			} else {
+				require.Equal(t, tc.expResult.result, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_RestoreUser(t *testing.T) {
+	type mockData struct {
+		userID int
+		err    error
+	}
+
+	type givenData struct {
+		userID string
+		mock   mockData
+	}
+
+	type expectedData struct {
+		statusCode int
+		result     string
+	}
+
+	tcs := map[string]struct {
+		given     givenData
+		expResult expectedData
+		expErr    error
+	}{
+		"success": {
+			given: givenData{
+				userID: "1",
+				mock: mockData{
+					userID: 1,
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusOK,
+				result:     "{\"success\":true,\"msg\":\"Restore user successfully\"}",
+			},
+		},
+		"invalid_user_id": {
+			given: givenData{
+				userID: "abc",
+			},
+			expResult: expectedData{
+				statusCode: http.StatusBadRequest,
+			},
+			expErr: ErrInvalidUserID,
+		},
+		"user_not_found": {
+			given: givenData{
+				userID: "1",
+				mock: mockData{
+					userID: 1,
+					err:    userServ.ErrUserNotFound,
+				},
+			},
+			expResult: expectedData{
+				statusCode: http.StatusNotFound,
+			},
+			expErr: ErrUserNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+tc.given.userID+"/restore", nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.given.userID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("RestoreUser", r.Context(), tc.given.mock.userID).Return(tc.given.mock.err)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// When
+			handler.RestoreUser(w, r)
+
+			// Then
+			require.Equal(t, tc.expResult.statusCode, w.Code)
+			if tc.expErr != nil {
+				require.EqualError(t, tc.expErr, w.Body.String())
+			} else {
+				require.Equal(t, tc.expResult.result, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_Login(t *testing.T) {
+	type input struct {
+		reqBody       string
+		mockInput     userServ.LoginInput
+		mockResult    userServ.LoginResponse
+		mockResultErr error
+	}
+	type output struct {
+		body       userServ.LoginResponse
+		statusCode int
+		err        error
+	}
+	tcs := map[string]struct {
+		input     input
+		expOutput output
+	}{
+		"success": {
+			input: input{
+				reqBody: `{
+				   "email":"example@example.com",
+				   "password":"123456789"
+				}`,
+				mockInput: userServ.LoginInput{
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
+				},
+				mockResult: userServ.LoginResponse{
+					AccessToken: "fbhaffiuerfweifewiuffgefhjgfiuyfr",
+					Scope:       "GUEST",
+					ExpiresIn:   1800,
+					TokenType:   "Bearer",
+				},
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body: userServ.LoginResponse{
+					AccessToken: "fbhaffiuerfweifewiuffgefhjgfiuyfr",
+					Scope:       "GUEST",
+					ExpiresIn:   1800,
+					TokenType:   "Bearer",
+				},
+			},
+		},
+		"invalid_email": {
+			input: input{
+				reqBody: `{
+				   "email":"exampleexample.com",
+				   "password":"123456789"
+				}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidEmail,
+			},
+		},
+		"password_can_not_be_blank": {
+			input: input{
+				reqBody: `{
+				   "email":"example@example.com",
+				   "password":""
+				}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrPasswordCannotBeBlank,
+			},
+		},
+		"invalid_request_body": {
+			input: input{
+				reqBody: `{
+				   "email":"example@example.com",
+				   "password":"123456",
+				}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidBodyRequest,
+			},
+		},
+		"invalid_credentials": {
+			input: input{
+				reqBody: `{
+				   "email":"example@example.com",
+				   "password":"123456789"
+				}`,
+				mockInput: userServ.LoginInput{
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
+				},
+				mockResultErr: userServ.ErrInvalidCredentials,
+			},
+			expOutput: output{
+				statusCode: http.StatusUnauthorized,
+				err:        ErrInvalidCredentials,
+			},
+		},
+		"too_many_login_attempts": {
+			input: input{
+				reqBody: `{
+				   "email":"example@example.com",
+				   "password":"123456789"
+				}`,
+				mockInput: userServ.LoginInput{
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
+				},
+				mockResultErr: userServ.ErrTooManyLoginAttempts,
+			},
+			expOutput: output{
+				statusCode: http.StatusTooManyRequests,
+				err:        ErrTooManyLoginAttempts,
+			},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/login", strings.NewReader(tc.input.reqBody))
+			w := httptest.NewRecorder()
+
 			serviceMock := new(userServ.Mock)
 			serviceMock.On("Login", r.Context(), tc.input.mockInput).Return(tc.input.mockResult, tc.input.mockResultErr)
 
@@ -1159,3 +1495,501 @@ func TestHandler_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_RefreshToken(t *testing.T) {
+	type input struct {
+		reqBody       string
+		mockInput     string
+		mockResult    userServ.LoginResponse
+		mockResultErr error
+	}
+	type output struct {
+		body       userServ.LoginResponse
+		statusCode int
+		err        error
+	}
+	tcs := map[string]struct {
+		input     input
+		expOutput output
+	}{
+		"success": {
+			input: input{
+				reqBody:   `{"refresh_token":"refresh-token"}`,
+				mockInput: "refresh-token",
+				mockResult: userServ.LoginResponse{
+					AccessToken:  "new-access-token",
+					RefreshToken: "new-refresh-token",
+					Scope:        "GUEST",
+					ExpiresIn:    1800,
+					TokenType:    "Bearer",
+				},
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body: userServ.LoginResponse{
+					AccessToken:  "new-access-token",
+					RefreshToken: "new-refresh-token",
+					Scope:        "GUEST",
+					ExpiresIn:    1800,
+					TokenType:    "Bearer",
+				},
+			},
+		},
+		"refresh_token_can_not_be_blank": {
+			input: input{
+				reqBody: `{"refresh_token":" "}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrTokenCannotBeBlank,
+			},
+		},
+		"invalid_request_body": {
+			input: input{
+				reqBody: `{"refresh_token":"refresh-token",}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidBodyRequest,
+			},
+		},
+		"invalid_token": {
+			input: input{
+				reqBody:       `{"refresh_token":"refresh-token"}`,
+				mockInput:     "refresh-token",
+				mockResultErr: userServ.ErrInvalidToken,
+			},
+			expOutput: output{
+				statusCode: http.StatusUnauthorized,
+				err:        ErrInvalidToken,
+			},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/token/refresh", strings.NewReader(tc.input.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("RefreshToken", r.Context(), tc.input.mockInput).Return(tc.input.mockResult, tc.input.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.RefreshToken(w, r)
+
+			//THEN
+			require.Equal(t, tc.expOutput.statusCode, w.Code)
+			if tc.expOutput.err != nil {
+				require.EqualError(t, tc.expOutput.err, w.Body.String())
+			} else {
+				var result userServ.LoginResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+					t.Fatal(err)
+				}
+
+				require.Equal(t, tc.expOutput.body, result)
+			}
+		})
+	}
+}
+
+func TestHandler_Logout(t *testing.T) {
+	type input struct {
+		reqBody       string
+		mockInput     userServ.LogoutInput
+		mockResultErr error
+	}
+	type output struct {
+		body       string
+		statusCode int
+		err        error
+	}
+	tcs := map[string]struct {
+		input     input
+		expOutput output
+	}{
+		"success": {
+			input: input{
+				reqBody: `{"refresh_token":"refresh-token"}`,
+				mockInput: userServ.LogoutInput{
+					AccessToken:  "access-token",
+					RefreshToken: "refresh-token",
+				},
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body:       "{\"success\":true,\"msg\":\"Logout successfully\"}",
+			},
+		},
+		"success_without_body": {
+			input: input{
+				mockInput: userServ.LogoutInput{
+					AccessToken: "access-token",
+				},
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body:       "{\"success\":true,\"msg\":\"Logout successfully\"}",
+			},
+		},
+		"invalid_request_body": {
+			input: input{
+				reqBody: `{"refresh_token":"refresh-token",}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidBodyRequest,
+			},
+		},
+		"invalid_token": {
+			input: input{
+				reqBody: `{"refresh_token":"refresh-token"}`,
+				mockInput: userServ.LogoutInput{
+					AccessToken:  "access-token",
+					RefreshToken: "refresh-token",
+				},
+				mockResultErr: userServ.ErrInvalidToken,
+			},
+			expOutput: output{
+				statusCode: http.StatusUnauthorized,
+				err:        ErrInvalidToken,
+			},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/logout", strings.NewReader(tc.input.reqBody))
+			r.Header.Set("Authorization", "Bearer access-token")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("Logout", r.Context(), tc.input.mockInput).Return(tc.input.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.Logout(w, r)
+
+			//THEN
+			require.Equal(t, tc.expOutput.statusCode, w.Code)
+			if tc.expOutput.err != nil {
+				require.EqualError(t, tc.expOutput.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.expOutput.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ForgotPassword(t *testing.T) {
+	type input struct {
+		reqBody       string
+		mockInput     string
+		mockResultErr error
+	}
+	type output struct {
+		body       string
+		statusCode int
+		err        error
+	}
+	tcs := map[string]struct {
+		input     input
+		expOutput output
+	}{
+		"success": {
+			input: input{
+				reqBody:   `{"email":"example@example.com"}`,
+				mockInput: "example@example.com",
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body:       "{\"success\":true,\"msg\":\"If the email is registered, a password reset link has been sent\"}",
+			},
+		},
+		"email_can_not_be_blank": {
+			input: input{
+				reqBody: `{"email":""}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrEmailCannotBeBlank,
+			},
+		},
+		"invalid_email": {
+			input: input{
+				reqBody: `{"email":"exampleexample.com"}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidEmail,
+			},
+		},
+		"invalid_request_body": {
+			input: input{
+				reqBody: `{"email":"example@example.com",}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidBodyRequest,
+			},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/password/forgot", strings.NewReader(tc.input.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("ForgotPassword", r.Context(), tc.input.mockInput).Return(tc.input.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.ForgotPassword(w, r)
+
+			//THEN
+			require.Equal(t, tc.expOutput.statusCode, w.Code)
+			if tc.expOutput.err != nil {
+				require.EqualError(t, tc.expOutput.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.expOutput.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ResetPassword(t *testing.T) {
+	type input struct {
+		reqBody       string
+		mockInput     userServ.ResetPasswordInput
+		mockResultErr error
+	}
+	type output struct {
+		body       string
+		statusCode int
+		err        error
+	}
+	tcs := map[string]struct {
+		input     input
+		expOutput output
+	}{
+		"success": {
+			input: input{
+				reqBody: `{"token":"reset-token","password":"123456789"}`,
+				mockInput: userServ.ResetPasswordInput{
+					Token:    "reset-token",
+					Password: "123456789",
+				},
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body:       "{\"success\":true,\"msg\":\"Reset password successfully\"}",
+			},
+		},
+		"token_can_not_be_blank": {
+			input: input{
+				reqBody: `{"token":"","password":"123456789"}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrTokenCannotBeBlank,
+			},
+		},
+		"password_can_not_be_blank": {
+			input: input{
+				reqBody: `{"token":"reset-token","password":""}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrPasswordCannotBeBlank,
+			},
+		},
+		"invalid_token": {
+			input: input{
+				reqBody: `{"token":"reset-token","password":"123456789"}`,
+				mockInput: userServ.ResetPasswordInput{
+					Token:    "reset-token",
+					Password: "123456789",
+				},
+				mockResultErr: userServ.ErrInvalidToken,
+			},
+			expOutput: output{
+				statusCode: http.StatusUnauthorized,
+				err:        ErrInvalidToken,
+			},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/password/reset", strings.NewReader(tc.input.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("ResetPassword", r.Context(), tc.input.mockInput).Return(tc.input.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.ResetPassword(w, r)
+
+			//THEN
+			require.Equal(t, tc.expOutput.statusCode, w.Code)
+			if tc.expOutput.err != nil {
+				require.EqualError(t, tc.expOutput.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.expOutput.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	type input struct {
+		token         string
+		mockResultErr error
+	}
+	type output struct {
+		body       string
+		statusCode int
+		err        error
+	}
+	tcs := map[string]struct {
+		input     input
+		expOutput output
+	}{
+		"success": {
+			input: input{
+				token: "verification-token",
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body:       "{\"success\":true,\"msg\":\"Verify email successfully\"}",
+			},
+		},
+		"token_can_not_be_blank": {
+			input: input{},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrTokenCannotBeBlank,
+			},
+		},
+		"invalid_token": {
+			input: input{
+				token:         "verification-token",
+				mockResultErr: userServ.ErrInvalidToken,
+			},
+			expOutput: output{
+				statusCode: http.StatusUnauthorized,
+				err:        ErrInvalidToken,
+			},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users/email/verify?token="+tc.input.token, nil)
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("VerifyEmail", r.Context(), tc.input.token).Return(tc.input.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.VerifyEmail(w, r)
+
+			//THEN
+			require.Equal(t, tc.expOutput.statusCode, w.Code)
+			if tc.expOutput.err != nil {
+				require.EqualError(t, tc.expOutput.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.expOutput.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ResendVerificationEmail(t *testing.T) {
+	type input struct {
+		reqBody       string
+		mockInput     string
+		mockResultErr error
+	}
+	type output struct {
+		body       string
+		statusCode int
+		err        error
+	}
+	tcs := map[string]struct {
+		input     input
+		expOutput output
+	}{
+		"success": {
+			input: input{
+				reqBody:   `{"email":"example@example.com"}`,
+				mockInput: "example@example.com",
+			},
+			expOutput: output{
+				statusCode: http.StatusOK,
+				body:       "{\"success\":true,\"msg\":\"If the email is registered and not verified, a verification link has been sent\"}",
+			},
+		},
+		"email_can_not_be_blank": {
+			input: input{
+				reqBody: `{"email":""}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrEmailCannotBeBlank,
+			},
+		},
+		"invalid_email": {
+			input: input{
+				reqBody: `{"email":"exampleexample.com"}`,
+			},
+			expOutput: output{
+				statusCode: http.StatusBadRequest,
+				err:        ErrInvalidEmail,
+			},
+		},
+		"too_many_requests": {
+			input: input{
+				reqBody:       `{"email":"example@example.com"}`,
+				mockInput:     "example@example.com",
+				mockResultErr: userServ.ErrTooManyRequests,
+			},
+			expOutput: output{
+				statusCode: http.StatusTooManyRequests,
+				err:        ErrTooManyRequests,
+			},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/users/email/verify/resend", strings.NewReader(tc.input.reqBody))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(userServ.Mock)
+			serviceMock.On("ResendVerificationEmail", r.Context(), tc.input.mockInput).Return(tc.input.mockResultErr)
+
+			handler := NewHandler(serviceMock, nil, nil)
+
+			// WHEN
+			handler.ResendVerificationEmail(w, r)
+
+			//THEN
+			require.Equal(t, tc.expOutput.statusCode, w.Code)
+			if tc.expOutput.err != nil {
+				require.EqualError(t, tc.expOutput.err, w.Body.String())
+			} else {
+				require.Equal(t, tc.expOutput.body, w.Body.String())
+			}
+		})
+	}
+}
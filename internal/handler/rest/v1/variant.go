@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	productServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/utils"
+)
+
+const (
+	MsgUpdateProductOptions = "Update product options successfully"
+	MsgUpdateVariant        = "Update variant successfully"
+	MsgDeleteVariant        = "Delete variant successfully"
+)
+
+type productOptionsRequest struct {
+	Options []string `json:"options"` // the option names in their order, e.g. ["Size", "Colour"]
+}
+
+type variantRequest struct {
+	SKU      string            `json:"sku"`      // required
+	Price    null.Float64      `json:"price"`    // default null, the price of the product
+	Quantity int               `json:"quantity"` // default 0
+	Options  map[string]string `json:"options"`  // a value for each option of the product, e.g. {"Size": "M"}
+}
+
+type optionResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type variantResponse struct {
+	ID        int               `json:"id"`
+	SKU       string            `json:"sku"`
+	Price     null.Float64      `json:"price"`
+	Quantity  int               `json:"quantity"`
+	Options   map[string]string `json:"options"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// productResponse is the product with its options and variants
+type productResponse struct {
+	model.Product
+	Options  []optionResponse  `json:"options"`
+	Variants []variantResponse `json:"variants"`
+}
+
+// toVariantResponse converts the variant of the service to the response
+func toVariantResponse(variant productServ.Variant) variantResponse {
+	options := make(map[string]string, len(variant.Options))
+	for _, o := range variant.Options {
+		options[o.Name] = o.Value
+	}
+	return variantResponse{
+		ID:        variant.ID,
+		SKU:       variant.SKU,
+		Price:     variant.Price,
+		Quantity:  variant.Quantity,
+		Options:   options,
+		CreatedAt: variant.CreatedAt,
+		UpdatedAt: variant.UpdatedAt,
+	}
+}
+
+// toOptionAndVariantResponses converts the options and the variants of a product, they are empty lists if the product has none
+func toOptionAndVariantResponses(options []productServ.Option, variants []productServ.Variant) ([]optionResponse, []variantResponse) {
+	optionResult := make([]optionResponse, len(options))
+	for i, o := range options {
+		optionResult[i] = optionResponse{ID: o.ID, Name: o.Name, Position: o.Position}
+	}
+	variantResult := make([]variantResponse, len(variants))
+	for i, v := range variants {
+		variantResult[i] = toVariantResponse(v)
+	}
+	return optionResult, variantResult
+}
+
+func validateVariantID(id string) (int, error) {
+	result, err := strconv.Atoi(id)
+	if err != nil || result <= 0 {
+		return 0, ErrInvalidVariantID
+	}
+	return result, nil
+}
+
+// validateVariantReq validates the variant, the sku, the option names and the values are trimmed
+func validateVariantReq(req variantRequest) (productServ.VariantInput, error) {
+	sku := strings.TrimSpace(req.SKU)
+	if sku == "" {
+		return productServ.VariantInput{}, ErrSKUCannotBeBlank
+	}
+	if req.Price.Valid && req.Price.Float64 <= 0 {
+		return productServ.VariantInput{}, ErrInvalidPrice
+	}
+	if req.Quantity < 0 {
+		return productServ.VariantInput{}, ErrInvalidQuantity
+	}
+
+	options := make(map[string]string, len(req.Options))
+	for name, value := range req.Options {
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if name == "" {
+			return productServ.VariantInput{}, ErrInvalidOptionName
+		}
+		if value == "" {
+			return productServ.VariantInput{}, ErrInvalidOptionValue
+		}
+		options[name] = value
+	}
+
+	return productServ.VariantInput{
+		SKU:      sku,
+		Price:    req.Price,
+		Quantity: req.Quantity,
+		Options:  options,
+	}, nil
+}
+
+// UpdateProductOptions handle request to replace the options of a product
+func (h Handler) UpdateProductOptions(w http.ResponseWriter, r *http.Request) {
+	// 1. Get product ID from url param
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req productOptionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	names := make([]string, len(req.Options))
+	for i, name := range req.Options {
+		names[i] = strings.TrimSpace(name)
+		if names[i] == "" {
+			handleProductError(w, ErrInvalidOptionName)
+			return
+		}
+	}
+
+	// 3. Replace the options
+	if err := h.productServ.SetProductOptions(r.Context(), productID, names); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUpdateProductOptions,
+	})
+}
+
+// CreateVariant handle request to create a variant of a product
+func (h Handler) CreateVariant(w http.ResponseWriter, r *http.Request) {
+	// 1. Get product ID from url param
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req variantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	input, err := validateVariantReq(req)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 3. Create the variant
+	result, err := h.productServ.CreateVariant(r.Context(), productID, input)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, toVariantResponse(result))
+}
+
+// UpdateVariant handle request to update a variant of a product
+func (h Handler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
+	// 1. Get product ID and variant ID from url param
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+	id, err := validateVariantID(chi.URLParam(r, "variantID"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 2. Decode and validate request body
+	var req variantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleProductError(w, ErrInvalidBodyRequest)
+		return
+	}
+	input, err := validateVariantReq(req)
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	// 3. Update the variant
+	if err := h.productServ.UpdateVariant(r.Context(), productID, id, input); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgUpdateVariant,
+	})
+}
+
+// DeleteVariant handle request to delete a variant of a product
+func (h Handler) DeleteVariant(w http.ResponseWriter, r *http.Request) {
+	productID, err := validateProductID(chi.URLParam(r, "id"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+	id, err := validateVariantID(chi.URLParam(r, "variantID"))
+	if err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	if err := h.productServ.DeleteVariant(r.Context(), productID, id); err != nil {
+		handleProductError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, utils.SuccessResponse{
+		Success: true, Msg: MsgDeleteVariant,
+	})
+}
@@ -0,0 +1,224 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	productServ "github.com/vinhnv1/s3corp-golang-fresher/internal/service/product"
+)
+
+func TestHandler_UpdateProductOptions(t *testing.T) {
+	tcs := map[string]struct {
+		body       string
+		mockNames  []string
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			body:       `{"options": [" Size ", "Colour"]}`,
+			mockNames:  []string{"Size", "Colour"},
+			statusCode: http.StatusOK,
+		},
+		"blank_option_name": {
+			body:       `{"options": ["Size", " "]}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidOptionName,
+		},
+		"duplicate_option": {
+			body:       `{"options": ["Size", "size"]}`,
+			mockNames:  []string{"Size", "size"},
+			mockErr:    productServ.ErrDuplicateOption,
+			statusCode: http.StatusBadRequest,
+			err:        ErrDuplicateOption,
+		},
+		"product_has_variants": {
+			body:       `{"options": ["Size"]}`,
+			mockNames:  []string{"Size"},
+			mockErr:    productServ.ErrProductHasVariants,
+			statusCode: http.StatusConflict,
+			err:        ErrProductHasVariants,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withURLParam(httptest.NewRequest(http.MethodPut, "/api/v1/products/10/options", strings.NewReader(tc.body)), "id", "10")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("SetProductOptions", r.Context(), 10, tc.mockNames).Return(tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.UpdateProductOptions(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				if tc.mockNames == nil {
+					serviceMock.AssertNotCalled(t, "SetProductOptions", mock.Anything, mock.Anything, mock.Anything)
+				}
+				return
+			}
+			require.Equal(t, `{"success":true,"msg":"Update product options successfully"}`, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_CreateVariant(t *testing.T) {
+	createdAt := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		body       string
+		mockInput  productServ.VariantInput
+		mockErr    error
+		statusCode int
+		expBody    string
+		err        error
+	}{
+		"success": {
+			body:       `{"sku": " SHIRT-M ", "price": 120, "quantity": 4, "options": {"Size": " M "}}`,
+			mockInput:  productServ.VariantInput{SKU: "SHIRT-M", Price: null.Float64From(120), Quantity: 4, Options: map[string]string{"Size": "M"}},
+			statusCode: http.StatusCreated,
+			expBody:    `{"id":5,"sku":"SHIRT-M","price":120,"quantity":4,"options":{"Size":"M"},"created_at":"2022-01-01T10:00:00Z","updated_at":"2022-01-01T10:00:00Z"}`,
+		},
+		"invalid_request_body": {
+			body:       `{{abc`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidBodyRequest,
+		},
+		"blank_sku": {
+			body:       `{"sku": " ", "options": {"Size": "M"}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrSKUCannotBeBlank,
+		},
+		"invalid_price": {
+			body:       `{"sku": "SHIRT-M", "price": -1}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidPrice,
+		},
+		"invalid_quantity": {
+			body:       `{"sku": "SHIRT-M", "quantity": -1}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidQuantity,
+		},
+		"blank_option_value": {
+			body:       `{"sku": "SHIRT-M", "options": {"Size": ""}}`,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidOptionValue,
+		},
+		"sku_existed": {
+			body:       `{"sku": "SHIRT-M", "options": {"Size": "M"}}`,
+			mockInput:  productServ.VariantInput{SKU: "SHIRT-M", Options: map[string]string{"Size": "M"}},
+			mockErr:    productServ.ErrVariantSKUExisted,
+			statusCode: http.StatusBadRequest,
+			err:        ErrVariantSKUExisted,
+		},
+		"invalid_variant_options": {
+			body:       `{"sku": "SHIRT-M", "options": {"Colour": "Red"}}`,
+			mockInput:  productServ.VariantInput{SKU: "SHIRT-M", Options: map[string]string{"Colour": "Red"}},
+			mockErr:    productServ.ErrInvalidVariantOptions,
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidVariantOptions,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/products/10/variants", strings.NewReader(tc.body)), "id", "10")
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("CreateVariant", r.Context(), 10, tc.mockInput).Return(productServ.Variant{
+				ID: 5, SKU: tc.mockInput.SKU, Price: tc.mockInput.Price, Quantity: tc.mockInput.Quantity,
+				Options:   []productServ.VariantOption{{Name: "Size", Value: "M"}},
+				CreatedAt: createdAt, UpdatedAt: createdAt,
+			}, tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.CreateVariant(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, tc.expBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_UpdateVariant(t *testing.T) {
+	tcs := map[string]struct {
+		variantID  string
+		mockErr    error
+		statusCode int
+		err        error
+	}{
+		"success": {
+			variantID:  "5",
+			statusCode: http.StatusOK,
+		},
+		"invalid_variant_id": {
+			variantID:  "abc",
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidVariantID,
+		},
+		"variant_not_found": {
+			variantID:  "5",
+			mockErr:    productServ.ErrVariantNotFound,
+			statusCode: http.StatusNotFound,
+			err:        ErrVariantNotFound,
+		},
+		"variant_existed": {
+			variantID:  "5",
+			mockErr:    productServ.ErrVariantExisted,
+			statusCode: http.StatusBadRequest,
+			err:        ErrVariantExisted,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/products/10/variants/"+tc.variantID, strings.NewReader(`{"sku": "SHIRT-M", "quantity": 3, "options": {"Size": "M"}}`))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "10")
+			rctx.URLParams.Add("variantID", tc.variantID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			serviceMock := new(productServ.Mock)
+			serviceMock.On("UpdateVariant", r.Context(), 10, 5, productServ.VariantInput{SKU: "SHIRT-M", Quantity: 3, Options: map[string]string{"Size": "M"}}).Return(tc.mockErr)
+
+			handler := NewHandler(nil, serviceMock, nil)
+
+			// WHEN
+			handler.UpdateVariant(w, r)
+
+			// THEN
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.err != nil {
+				require.EqualError(t, tc.err, w.Body.String())
+				return
+			}
+			require.Equal(t, `{"success":true,"msg":"Update variant successfully"}`, w.Body.String())
+		})
+	}
+}
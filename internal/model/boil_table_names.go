@@ -4,13 +4,63 @@
 package model
 
 var TableNames = struct {
-	OrderItems string
-	Orders     string
-	Products   string
-	Users      string
+	Addresses             string
+	APIKeys               string
+	BackupCodes           string
+	Categories            string
+	DataRequests          string
+	Identities            string
+	Impersonations        string
+	LoginFailures         string
+	OrderAddresses        string
+	OrderItems            string
+	Orders                string
+	Organizations         string
+	PasswordHistories     string
+	PasswordResetTokens   string
+	Permissions           string
+	ProductCategories     string
+	ProductImages         string
+	ProductOptions        string
+	ProductVariantOptions string
+	ProductVariants       string
+	Products              string
+	RefreshTokens         string
+	RevokedAccessTokens   string
+	RolePermissions       string
+	Roles                 string
+	SecurityEvents        string
+	TotpSecrets           string
+	UserRoles             string
+	Users                 string
 }{
-	OrderItems: "order_items",
-	Orders:     "orders",
-	Products:   "products",
-	Users:      "users",
+	Addresses:             "addresses",
+	APIKeys:               "api_keys",
+	BackupCodes:           "backup_codes",
+	Categories:            "categories",
+	DataRequests:          "data_requests",
+	Identities:            "identities",
+	Impersonations:        "impersonations",
+	LoginFailures:         "login_failures",
+	OrderAddresses:        "order_addresses",
+	OrderItems:            "order_items",
+	Orders:                "orders",
+	Organizations:         "organizations",
+	PasswordHistories:     "password_histories",
+	PasswordResetTokens:   "password_reset_tokens",
+	Permissions:           "permissions",
+	ProductCategories:     "product_categories",
+	ProductImages:         "product_images",
+	ProductOptions:        "product_options",
+	ProductVariantOptions: "product_variant_options",
+	ProductVariants:       "product_variants",
+	Products:              "products",
+	RefreshTokens:         "refresh_tokens",
+	RevokedAccessTokens:   "revoked_access_tokens",
+	RolePermissions:       "role_permissions",
+	Roles:                 "roles",
+	SecurityEvents:        "security_events",
+	TotpSecrets:           "totp_secrets",
+	UserRoles:             "user_roles",
+	Users:                 "users",
 }
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -33,6 +34,8 @@ type OrderItem struct {
 	Note         string    `boil:"note" json:"note" toml:"note" yaml:"note"`
 	CreatedAt    time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt    time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	VariantID    null.Int  `boil:"variant_id" json:"variant_id,omitempty" toml:"variant_id" yaml:"variant_id,omitempty"`
+	VariantSku   string    `boil:"variant_sku" json:"variant_sku" toml:"variant_sku" yaml:"variant_sku"`
 
 	R *orderItemR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L orderItemL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -49,6 +52,8 @@ var OrderItemColumns = struct {
 	Note         string
 	CreatedAt    string
 	UpdatedAt    string
+	VariantID    string
+	VariantSku   string
 }{
 	ID:           "id",
 	OrderID:      "order_id",
@@ -60,6 +65,8 @@ var OrderItemColumns = struct {
 	Note:         "note",
 	CreatedAt:    "created_at",
 	UpdatedAt:    "updated_at",
+	VariantID:    "variant_id",
+	VariantSku:   "variant_sku",
 }
 
 var OrderItemTableColumns = struct {
@@ -73,6 +80,8 @@ var OrderItemTableColumns = struct {
 	Note         string
 	CreatedAt    string
 	UpdatedAt    string
+	VariantID    string
+	VariantSku   string
 }{
 	ID:           "order_items.id",
 	OrderID:      "order_items.order_id",
@@ -84,33 +93,12 @@ var OrderItemTableColumns = struct {
 	Note:         "order_items.note",
 	CreatedAt:    "order_items.created_at",
 	UpdatedAt:    "order_items.updated_at",
+	VariantID:    "order_items.variant_id",
+	VariantSku:   "order_items.variant_sku",
 }
 
 // Generated where
 
-type whereHelperint struct{ field string }
-
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelperfloat64 struct{ field string }
 
 func (w whereHelperfloat64) EQ(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.EQ, x) }
@@ -140,50 +128,6 @@ func (w whereHelperfloat64) NIN(slice []float64) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var OrderItemWhere = struct {
 	ID           whereHelperint
 	OrderID      whereHelperint
@@ -195,6 +139,8 @@ var OrderItemWhere = struct {
 	Note         whereHelperstring
 	CreatedAt    whereHelpertime_Time
 	UpdatedAt    whereHelpertime_Time
+	VariantID    whereHelpernull_Int
+	VariantSku   whereHelperstring
 }{
 	ID:           whereHelperint{field: "\"order_items\".\"id\""},
 	OrderID:      whereHelperint{field: "\"order_items\".\"order_id\""},
@@ -206,6 +152,8 @@ var OrderItemWhere = struct {
 	Note:         whereHelperstring{field: "\"order_items\".\"note\""},
 	CreatedAt:    whereHelpertime_Time{field: "\"order_items\".\"created_at\""},
 	UpdatedAt:    whereHelpertime_Time{field: "\"order_items\".\"updated_at\""},
+	VariantID:    whereHelpernull_Int{field: "\"order_items\".\"variant_id\""},
+	VariantSku:   whereHelperstring{field: "\"order_items\".\"variant_sku\""},
 }
 
 // OrderItemRels is where relationship names are stored.
@@ -246,9 +194,9 @@ func (r *orderItemR) GetProduct() *Product {
 type orderItemL struct{}
 
 var (
-	orderItemAllColumns            = []string{"id", "order_id", "product_id", "product_price", "product_name", "quantity", "discount", "note", "created_at", "updated_at"}
-	orderItemColumnsWithoutDefault = []string{"order_id", "product_id", "product_price", "product_name", "quantity"}
-	orderItemColumnsWithDefault    = []string{"id", "discount", "note", "created_at", "updated_at"}
+	orderItemAllColumns            = []string{"id", "order_id", "product_id", "product_price", "product_name", "quantity", "discount", "note", "created_at", "updated_at", "variant_id", "variant_sku"}
+	orderItemColumnsWithoutDefault = []string{"order_id", "product_id", "product_price", "product_name", "quantity", "variant_id"}
+	orderItemColumnsWithDefault    = []string{"id", "discount", "note", "created_at", "updated_at", "variant_sku"}
 	orderItemPrimaryKeyColumns     = []string{"id"}
 	orderItemGeneratedColumns      = []string{}
 )
@@ -23,94 +23,107 @@ import (
 
 // Order is an object representing the database table.
 type Order struct {
-	ID          int       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	OrderNumber string    `boil:"order_number" json:"order_number" toml:"order_number" yaml:"order_number"`
-	OrderDate   time.Time `boil:"order_date" json:"order_date" toml:"order_date" yaml:"order_date"`
-	Status      string    `boil:"status" json:"status" toml:"status" yaml:"status"`
-	Note        string    `boil:"note" json:"note" toml:"note" yaml:"note"`
-	UserID      int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID             int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	OrderNumber    string    `boil:"order_number" json:"order_number" toml:"order_number" yaml:"order_number"`
+	OrderDate      time.Time `boil:"order_date" json:"order_date" toml:"order_date" yaml:"order_date"`
+	Status         string    `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Note           string    `boil:"note" json:"note" toml:"note" yaml:"note"`
+	UserID         int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt      time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	OrganizationID int       `boil:"organization_id" json:"organization_id" toml:"organization_id" yaml:"organization_id"`
 
 	R *orderR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L orderL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var OrderColumns = struct {
-	ID          string
-	OrderNumber string
-	OrderDate   string
-	Status      string
-	Note        string
-	UserID      string
-	CreatedAt   string
-	UpdatedAt   string
+	ID             string
+	OrderNumber    string
+	OrderDate      string
+	Status         string
+	Note           string
+	UserID         string
+	CreatedAt      string
+	UpdatedAt      string
+	OrganizationID string
 }{
-	ID:          "id",
-	OrderNumber: "order_number",
-	OrderDate:   "order_date",
-	Status:      "status",
-	Note:        "note",
-	UserID:      "user_id",
-	CreatedAt:   "created_at",
-	UpdatedAt:   "updated_at",
+	ID:             "id",
+	OrderNumber:    "order_number",
+	OrderDate:      "order_date",
+	Status:         "status",
+	Note:           "note",
+	UserID:         "user_id",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	OrganizationID: "organization_id",
 }
 
 var OrderTableColumns = struct {
-	ID          string
-	OrderNumber string
-	OrderDate   string
-	Status      string
-	Note        string
-	UserID      string
-	CreatedAt   string
-	UpdatedAt   string
+	ID             string
+	OrderNumber    string
+	OrderDate      string
+	Status         string
+	Note           string
+	UserID         string
+	CreatedAt      string
+	UpdatedAt      string
+	OrganizationID string
 }{
-	ID:          "orders.id",
-	OrderNumber: "orders.order_number",
-	OrderDate:   "orders.order_date",
-	Status:      "orders.status",
-	Note:        "orders.note",
-	UserID:      "orders.user_id",
-	CreatedAt:   "orders.created_at",
-	UpdatedAt:   "orders.updated_at",
+	ID:             "orders.id",
+	OrderNumber:    "orders.order_number",
+	OrderDate:      "orders.order_date",
+	Status:         "orders.status",
+	Note:           "orders.note",
+	UserID:         "orders.user_id",
+	CreatedAt:      "orders.created_at",
+	UpdatedAt:      "orders.updated_at",
+	OrganizationID: "orders.organization_id",
 }
 
 // Generated where
 
 var OrderWhere = struct {
-	ID          whereHelperint
-	OrderNumber whereHelperstring
-	OrderDate   whereHelpertime_Time
-	Status      whereHelperstring
-	Note        whereHelperstring
-	UserID      whereHelperint
-	CreatedAt   whereHelpertime_Time
-	UpdatedAt   whereHelpertime_Time
+	ID             whereHelperint
+	OrderNumber    whereHelperstring
+	OrderDate      whereHelpertime_Time
+	Status         whereHelperstring
+	Note           whereHelperstring
+	UserID         whereHelperint
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	OrganizationID whereHelperint
 }{
-	ID:          whereHelperint{field: "\"orders\".\"id\""},
-	OrderNumber: whereHelperstring{field: "\"orders\".\"order_number\""},
-	OrderDate:   whereHelpertime_Time{field: "\"orders\".\"order_date\""},
-	Status:      whereHelperstring{field: "\"orders\".\"status\""},
-	Note:        whereHelperstring{field: "\"orders\".\"note\""},
-	UserID:      whereHelperint{field: "\"orders\".\"user_id\""},
-	CreatedAt:   whereHelpertime_Time{field: "\"orders\".\"created_at\""},
-	UpdatedAt:   whereHelpertime_Time{field: "\"orders\".\"updated_at\""},
+	ID:             whereHelperint{field: "\"orders\".\"id\""},
+	OrderNumber:    whereHelperstring{field: "\"orders\".\"order_number\""},
+	OrderDate:      whereHelpertime_Time{field: "\"orders\".\"order_date\""},
+	Status:         whereHelperstring{field: "\"orders\".\"status\""},
+	Note:           whereHelperstring{field: "\"orders\".\"note\""},
+	UserID:         whereHelperint{field: "\"orders\".\"user_id\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"orders\".\"created_at\""},
+	UpdatedAt:      whereHelpertime_Time{field: "\"orders\".\"updated_at\""},
+	OrganizationID: whereHelperint{field: "\"orders\".\"organization_id\""},
 }
 
 // OrderRels is where relationship names are stored.
 var OrderRels = struct {
-	User       string
-	OrderItems string
+	Organization   string
+	User           string
+	OrderAddresses string
+	OrderItems     string
 }{
-	User:       "User",
-	OrderItems: "OrderItems",
+	Organization:   "Organization",
+	User:           "User",
+	OrderAddresses: "OrderAddresses",
+	OrderItems:     "OrderItems",
 }
 
 // orderR is where relationships are stored.
 type orderR struct {
-	User       *User          `boil:"User" json:"User" toml:"User" yaml:"User"`
-	OrderItems OrderItemSlice `boil:"OrderItems" json:"OrderItems" toml:"OrderItems" yaml:"OrderItems"`
+	Organization   *Organization     `boil:"Organization" json:"Organization" toml:"Organization" yaml:"Organization"`
+	User           *User             `boil:"User" json:"User" toml:"User" yaml:"User"`
+	OrderAddresses OrderAddressSlice `boil:"OrderAddresses" json:"OrderAddresses" toml:"OrderAddresses" yaml:"OrderAddresses"`
+	OrderItems     OrderItemSlice    `boil:"OrderItems" json:"OrderItems" toml:"OrderItems" yaml:"OrderItems"`
 }
 
 // NewStruct creates a new relationship struct
@@ -118,6 +131,13 @@ func (*orderR) NewStruct() *orderR {
 	return &orderR{}
 }
 
+func (r *orderR) GetOrganization() *Organization {
+	if r == nil {
+		return nil
+	}
+	return r.Organization
+}
+
 func (r *orderR) GetUser() *User {
 	if r == nil {
 		return nil
@@ -125,6 +145,13 @@ func (r *orderR) GetUser() *User {
 	return r.User
 }
 
+func (r *orderR) GetOrderAddresses() OrderAddressSlice {
+	if r == nil {
+		return nil
+	}
+	return r.OrderAddresses
+}
+
 func (r *orderR) GetOrderItems() OrderItemSlice {
 	if r == nil {
 		return nil
@@ -136,9 +163,9 @@ func (r *orderR) GetOrderItems() OrderItemSlice {
 type orderL struct{}
 
 var (
-	orderAllColumns            = []string{"id", "order_number", "order_date", "status", "note", "user_id", "created_at", "updated_at"}
+	orderAllColumns            = []string{"id", "order_number", "order_date", "status", "note", "user_id", "created_at", "updated_at", "organization_id"}
 	orderColumnsWithoutDefault = []string{"order_number", "user_id"}
-	orderColumnsWithDefault    = []string{"id", "order_date", "status", "note", "created_at", "updated_at"}
+	orderColumnsWithDefault    = []string{"id", "order_date", "status", "note", "created_at", "updated_at", "organization_id"}
 	orderPrimaryKeyColumns     = []string{"id"}
 	orderGeneratedColumns      = []string{}
 )
@@ -234,6 +261,17 @@ func (q orderQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool
 	return count > 0, nil
 }
 
+// Organization pointed to by the foreign key.
+func (o *Order) Organization(mods ...qm.QueryMod) organizationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.OrganizationID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Organizations(queryMods...)
+}
+
 // User pointed to by the foreign key.
 func (o *Order) User(mods ...qm.QueryMod) userQuery {
 	queryMods := []qm.QueryMod{
@@ -245,6 +283,20 @@ func (o *Order) User(mods ...qm.QueryMod) userQuery {
 	return Users(queryMods...)
 }
 
+// OrderAddresses retrieves all the order_address's OrderAddresses with an executor.
+func (o *Order) OrderAddresses(mods ...qm.QueryMod) orderAddressQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"order_addresses\".\"order_id\"=?", o.ID),
+	)
+
+	return OrderAddresses(queryMods...)
+}
+
 // OrderItems retrieves all the order_item's OrderItems with an executor.
 func (o *Order) OrderItems(mods ...qm.QueryMod) orderItemQuery {
 	var queryMods []qm.QueryMod
@@ -259,6 +311,102 @@ func (o *Order) OrderItems(mods ...qm.QueryMod) orderItemQuery {
 	return OrderItems(queryMods...)
 }
 
+// LoadOrganization allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (orderL) LoadOrganization(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOrder interface{}, mods queries.Applicator) error {
+	var slice []*Order
+	var object *Order
+
+	if singular {
+		object = maybeOrder.(*Order)
+	} else {
+		slice = *maybeOrder.(*[]*Order)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &orderR{}
+		}
+		args = append(args, object.OrganizationID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &orderR{}
+			}
+
+			for _, a := range args {
+				if a == obj.OrganizationID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.OrganizationID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`organizations`),
+		qm.WhereIn(`organizations.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Organization")
+	}
+
+	var resultSlice []*Organization
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Organization")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for organizations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for organizations")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Organization = foreign
+		if foreign.R == nil {
+			foreign.R = &organizationR{}
+		}
+		foreign.R.Orders = append(foreign.R.Orders, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.OrganizationID == foreign.ID {
+				local.R.Organization = foreign
+				if foreign.R == nil {
+					foreign.R = &organizationR{}
+				}
+				foreign.R.Orders = append(foreign.R.Orders, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadUser allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (orderL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOrder interface{}, mods queries.Applicator) error {
@@ -355,6 +503,97 @@ func (orderL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular boo
 	return nil
 }
 
+// LoadOrderAddresses allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (orderL) LoadOrderAddresses(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOrder interface{}, mods queries.Applicator) error {
+	var slice []*Order
+	var object *Order
+
+	if singular {
+		object = maybeOrder.(*Order)
+	} else {
+		slice = *maybeOrder.(*[]*Order)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &orderR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &orderR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`order_addresses`),
+		qm.WhereIn(`order_addresses.order_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load order_addresses")
+	}
+
+	var resultSlice []*OrderAddress
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice order_addresses")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on order_addresses")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for order_addresses")
+	}
+
+	if singular {
+		object.R.OrderAddresses = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &orderAddressR{}
+			}
+			foreign.R.Order = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.OrderID {
+				local.R.OrderAddresses = append(local.R.OrderAddresses, foreign)
+				if foreign.R == nil {
+					foreign.R = &orderAddressR{}
+				}
+				foreign.R.Order = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadOrderItems allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (orderL) LoadOrderItems(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOrder interface{}, mods queries.Applicator) error {
@@ -446,6 +685,53 @@ func (orderL) LoadOrderItems(ctx context.Context, e boil.ContextExecutor, singul
 	return nil
 }
 
+// SetOrganization of the order to the related item.
+// Sets o.R.Organization to related.
+// Adds o to related.R.Orders.
+func (o *Order) SetOrganization(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Organization) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"orders\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"organization_id"}),
+		strmangle.WhereClause("\"", "\"", 2, orderPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.OrganizationID = related.ID
+	if o.R == nil {
+		o.R = &orderR{
+			Organization: related,
+		}
+	} else {
+		o.R.Organization = related
+	}
+
+	if related.R == nil {
+		related.R = &organizationR{
+			Orders: OrderSlice{o},
+		}
+	} else {
+		related.R.Orders = append(related.R.Orders, o)
+	}
+
+	return nil
+}
+
 // SetUser of the order to the related item.
 // Sets o.R.User to related.
 // Adds o to related.R.Orders.
@@ -493,6 +779,59 @@ func (o *Order) SetUser(ctx context.Context, exec boil.ContextExecutor, insert b
 	return nil
 }
 
+// AddOrderAddresses adds the given related objects to the existing relationships
+// of the order, optionally inserting them as new records.
+// Appends related to o.R.OrderAddresses.
+// Sets related.R.Order appropriately.
+func (o *Order) AddOrderAddresses(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OrderAddress) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.OrderID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"order_addresses\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"order_id"}),
+				strmangle.WhereClause("\"", "\"", 2, orderAddressPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.OrderID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &orderR{
+			OrderAddresses: related,
+		}
+	} else {
+		o.R.OrderAddresses = append(o.R.OrderAddresses, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &orderAddressR{
+				Order: o,
+			}
+		} else {
+			rel.R.Order = o
+		}
+	}
+	return nil
+}
+
 // AddOrderItems adds the given related objects to the existing relationships
 // of the order, optionally inserting them as new records.
 // Appends related to o.R.OrderItems.
@@ -23,62 +23,67 @@ import (
 
 // Product is an object representing the database table.
 type Product struct {
-	ID          int       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Title       string    `boil:"title" json:"title" toml:"title" yaml:"title"`
-	Description string    `boil:"description" json:"description" toml:"description" yaml:"description"`
-	Price       float64   `boil:"price" json:"price" toml:"price" yaml:"price"`
-	Quantity    int       `boil:"quantity" json:"quantity" toml:"quantity" yaml:"quantity"`
-	IsActive    bool      `boil:"is_active" json:"is_active" toml:"is_active" yaml:"is_active"`
-	UserID      int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID             int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Title          string    `boil:"title" json:"title" toml:"title" yaml:"title"`
+	Description    string    `boil:"description" json:"description" toml:"description" yaml:"description"`
+	Price          float64   `boil:"price" json:"price" toml:"price" yaml:"price"`
+	Quantity       int       `boil:"quantity" json:"quantity" toml:"quantity" yaml:"quantity"`
+	IsActive       bool      `boil:"is_active" json:"is_active" toml:"is_active" yaml:"is_active"`
+	UserID         int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt      time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	OrganizationID int       `boil:"organization_id" json:"organization_id" toml:"organization_id" yaml:"organization_id"`
 
 	R *productR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L productL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ProductColumns = struct {
-	ID          string
-	Title       string
-	Description string
-	Price       string
-	Quantity    string
-	IsActive    string
-	UserID      string
-	CreatedAt   string
-	UpdatedAt   string
+	ID             string
+	Title          string
+	Description    string
+	Price          string
+	Quantity       string
+	IsActive       string
+	UserID         string
+	CreatedAt      string
+	UpdatedAt      string
+	OrganizationID string
 }{
-	ID:          "id",
-	Title:       "title",
-	Description: "description",
-	Price:       "price",
-	Quantity:    "quantity",
-	IsActive:    "is_active",
-	UserID:      "user_id",
-	CreatedAt:   "created_at",
-	UpdatedAt:   "updated_at",
+	ID:             "id",
+	Title:          "title",
+	Description:    "description",
+	Price:          "price",
+	Quantity:       "quantity",
+	IsActive:       "is_active",
+	UserID:         "user_id",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	OrganizationID: "organization_id",
 }
 
 var ProductTableColumns = struct {
-	ID          string
-	Title       string
-	Description string
-	Price       string
-	Quantity    string
-	IsActive    string
-	UserID      string
-	CreatedAt   string
-	UpdatedAt   string
+	ID             string
+	Title          string
+	Description    string
+	Price          string
+	Quantity       string
+	IsActive       string
+	UserID         string
+	CreatedAt      string
+	UpdatedAt      string
+	OrganizationID string
 }{
-	ID:          "products.id",
-	Title:       "products.title",
-	Description: "products.description",
-	Price:       "products.price",
-	Quantity:    "products.quantity",
-	IsActive:    "products.is_active",
-	UserID:      "products.user_id",
-	CreatedAt:   "products.created_at",
-	UpdatedAt:   "products.updated_at",
+	ID:             "products.id",
+	Title:          "products.title",
+	Description:    "products.description",
+	Price:          "products.price",
+	Quantity:       "products.quantity",
+	IsActive:       "products.is_active",
+	UserID:         "products.user_id",
+	CreatedAt:      "products.created_at",
+	UpdatedAt:      "products.updated_at",
+	OrganizationID: "products.organization_id",
 }
 
 // Generated where
@@ -93,40 +98,45 @@ func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
 var ProductWhere = struct {
-	ID          whereHelperint
-	Title       whereHelperstring
-	Description whereHelperstring
-	Price       whereHelperfloat64
-	Quantity    whereHelperint
-	IsActive    whereHelperbool
-	UserID      whereHelperint
-	CreatedAt   whereHelpertime_Time
-	UpdatedAt   whereHelpertime_Time
+	ID             whereHelperint
+	Title          whereHelperstring
+	Description    whereHelperstring
+	Price          whereHelperfloat64
+	Quantity       whereHelperint
+	IsActive       whereHelperbool
+	UserID         whereHelperint
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	OrganizationID whereHelperint
 }{
-	ID:          whereHelperint{field: "\"products\".\"id\""},
-	Title:       whereHelperstring{field: "\"products\".\"title\""},
-	Description: whereHelperstring{field: "\"products\".\"description\""},
-	Price:       whereHelperfloat64{field: "\"products\".\"price\""},
-	Quantity:    whereHelperint{field: "\"products\".\"quantity\""},
-	IsActive:    whereHelperbool{field: "\"products\".\"is_active\""},
-	UserID:      whereHelperint{field: "\"products\".\"user_id\""},
-	CreatedAt:   whereHelpertime_Time{field: "\"products\".\"created_at\""},
-	UpdatedAt:   whereHelpertime_Time{field: "\"products\".\"updated_at\""},
+	ID:             whereHelperint{field: "\"products\".\"id\""},
+	Title:          whereHelperstring{field: "\"products\".\"title\""},
+	Description:    whereHelperstring{field: "\"products\".\"description\""},
+	Price:          whereHelperfloat64{field: "\"products\".\"price\""},
+	Quantity:       whereHelperint{field: "\"products\".\"quantity\""},
+	IsActive:       whereHelperbool{field: "\"products\".\"is_active\""},
+	UserID:         whereHelperint{field: "\"products\".\"user_id\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"products\".\"created_at\""},
+	UpdatedAt:      whereHelpertime_Time{field: "\"products\".\"updated_at\""},
+	OrganizationID: whereHelperint{field: "\"products\".\"organization_id\""},
 }
 
 // ProductRels is where relationship names are stored.
 var ProductRels = struct {
-	User       string
-	OrderItems string
+	Organization string
+	User         string
+	OrderItems   string
 }{
-	User:       "User",
-	OrderItems: "OrderItems",
+	Organization: "Organization",
+	User:         "User",
+	OrderItems:   "OrderItems",
 }
 
 // productR is where relationships are stored.
 type productR struct {
-	User       *User          `boil:"User" json:"User" toml:"User" yaml:"User"`
-	OrderItems OrderItemSlice `boil:"OrderItems" json:"OrderItems" toml:"OrderItems" yaml:"OrderItems"`
+	Organization *Organization  `boil:"Organization" json:"Organization" toml:"Organization" yaml:"Organization"`
+	User         *User          `boil:"User" json:"User" toml:"User" yaml:"User"`
+	OrderItems   OrderItemSlice `boil:"OrderItems" json:"OrderItems" toml:"OrderItems" yaml:"OrderItems"`
 }
 
 // NewStruct creates a new relationship struct
@@ -134,6 +144,13 @@ func (*productR) NewStruct() *productR {
 	return &productR{}
 }
 
+func (r *productR) GetOrganization() *Organization {
+	if r == nil {
+		return nil
+	}
+	return r.Organization
+}
+
 func (r *productR) GetUser() *User {
 	if r == nil {
 		return nil
@@ -152,9 +169,9 @@ func (r *productR) GetOrderItems() OrderItemSlice {
 type productL struct{}
 
 var (
-	productAllColumns            = []string{"id", "title", "description", "price", "quantity", "is_active", "user_id", "created_at", "updated_at"}
+	productAllColumns            = []string{"id", "title", "description", "price", "quantity", "is_active", "user_id", "created_at", "updated_at", "organization_id"}
 	productColumnsWithoutDefault = []string{"title", "price", "user_id"}
-	productColumnsWithDefault    = []string{"id", "description", "quantity", "is_active", "created_at", "updated_at"}
+	productColumnsWithDefault    = []string{"id", "description", "quantity", "is_active", "created_at", "updated_at", "organization_id"}
 	productPrimaryKeyColumns     = []string{"id"}
 	productGeneratedColumns      = []string{}
 )
@@ -250,6 +267,17 @@ func (q productQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bo
 	return count > 0, nil
 }
 
+// Organization pointed to by the foreign key.
+func (o *Product) Organization(mods ...qm.QueryMod) organizationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.OrganizationID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Organizations(queryMods...)
+}
+
 // User pointed to by the foreign key.
 func (o *Product) User(mods ...qm.QueryMod) userQuery {
 	queryMods := []qm.QueryMod{
@@ -275,6 +303,102 @@ func (o *Product) OrderItems(mods ...qm.QueryMod) orderItemQuery {
 	return OrderItems(queryMods...)
 }
 
+// LoadOrganization allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (productL) LoadOrganization(ctx context.Context, e boil.ContextExecutor, singular bool, maybeProduct interface{}, mods queries.Applicator) error {
+	var slice []*Product
+	var object *Product
+
+	if singular {
+		object = maybeProduct.(*Product)
+	} else {
+		slice = *maybeProduct.(*[]*Product)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &productR{}
+		}
+		args = append(args, object.OrganizationID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &productR{}
+			}
+
+			for _, a := range args {
+				if a == obj.OrganizationID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.OrganizationID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`organizations`),
+		qm.WhereIn(`organizations.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Organization")
+	}
+
+	var resultSlice []*Organization
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Organization")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for organizations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for organizations")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Organization = foreign
+		if foreign.R == nil {
+			foreign.R = &organizationR{}
+		}
+		foreign.R.Products = append(foreign.R.Products, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.OrganizationID == foreign.ID {
+				local.R.Organization = foreign
+				if foreign.R == nil {
+					foreign.R = &organizationR{}
+				}
+				foreign.R.Products = append(foreign.R.Products, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadUser allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (productL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeProduct interface{}, mods queries.Applicator) error {
@@ -462,6 +586,53 @@ func (productL) LoadOrderItems(ctx context.Context, e boil.ContextExecutor, sing
 	return nil
 }
 
+// SetOrganization of the product to the related item.
+// Sets o.R.Organization to related.
+// Adds o to related.R.Products.
+func (o *Product) SetOrganization(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Organization) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"products\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"organization_id"}),
+		strmangle.WhereClause("\"", "\"", 2, productPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.OrganizationID = related.ID
+	if o.R == nil {
+		o.R = &productR{
+			Organization: related,
+		}
+	} else {
+		o.R.Organization = related
+	}
+
+	if related.R == nil {
+		related.R = &organizationR{
+			Products: ProductSlice{o},
+		}
+	} else {
+		related.R.Products = append(related.R.Products, o)
+	}
+
+	return nil
+}
+
 // SetUser of the product to the related item.
 // Sets o.R.User to related.
 // Adds o to related.R.Products.
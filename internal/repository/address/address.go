@@ -0,0 +1,98 @@
+package address
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+// GetAddresses returns the addresses of the user, the oldest first
+func (r impl) GetAddresses(ctx context.Context, userID int) ([]model.Address, error) {
+	slice, err := model.Addresses(
+		model.AddressWhere.UserID.EQ(userID),
+		qm.OrderBy(model.AddressColumns.ID),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.Address, 0, len(slice))
+	for _, a := range slice {
+		result = append(result, *a)
+	}
+	return result, nil
+}
+
+// GetAddress returns the address with the given id, addresses of other users are not found
+func (r impl) GetAddress(ctx context.Context, userID int, id int) (model.Address, error) {
+	result, err := model.Addresses(
+		model.AddressWhere.ID.EQ(id),
+		model.AddressWhere.UserID.EQ(userID),
+	).One(ctx, r.db)
+	if err != nil {
+		return model.Address{}, err
+	}
+	return *result, nil
+}
+
+// CreateAddress creates a new address
+func (r impl) CreateAddress(ctx context.Context, tx *sql.Tx, address model.Address) (model.Address, error) {
+	if err := address.Insert(ctx, tx, boil.Whitelist("user_id", "name", "phone", "line1", "line2", "city", "state", "postal_code", "country", "is_default_shipping", "is_default_billing", "created_at", "updated_at")); err != nil {
+		return model.Address{}, err
+	}
+	return address, nil
+}
+
+// UpdateAddress updates all fields of the address except its user
+func (r impl) UpdateAddress(ctx context.Context, tx *sql.Tx, address model.Address) (int64, error) {
+	return model.Addresses(
+		model.AddressWhere.ID.EQ(address.ID),
+		model.AddressWhere.UserID.EQ(address.UserID),
+	).UpdateAll(ctx, tx, model.M{
+		model.AddressColumns.Name:              address.Name,
+		model.AddressColumns.Phone:             address.Phone,
+		model.AddressColumns.Line1:             address.Line1,
+		model.AddressColumns.Line2:             address.Line2,
+		model.AddressColumns.City:              address.City,
+		model.AddressColumns.State:             address.State,
+		model.AddressColumns.PostalCode:        address.PostalCode,
+		model.AddressColumns.Country:           address.Country,
+		model.AddressColumns.IsDefaultShipping: address.IsDefaultShipping,
+		model.AddressColumns.IsDefaultBilling:  address.IsDefaultBilling,
+		model.AddressColumns.UpdatedAt:         time.Now(),
+	})
+}
+
+// DeleteAddress deletes the address, the orders keep their own copy of it
+func (r impl) DeleteAddress(ctx context.Context, userID int, id int) (int64, error) {
+	return model.Addresses(
+		model.AddressWhere.ID.EQ(id),
+		model.AddressWhere.UserID.EQ(userID),
+	).DeleteAll(ctx, r.db)
+}
+
+// ClearDefaultAddresses unsets the default flags of all addresses of the user, it is called before another address becomes the default
+func (r impl) ClearDefaultAddresses(ctx context.Context, tx *sql.Tx, userID int, shipping bool, billing bool) error {
+	if shipping {
+		if _, err := model.Addresses(
+			model.AddressWhere.UserID.EQ(userID),
+			model.AddressWhere.IsDefaultShipping.EQ(true),
+		).UpdateAll(ctx, tx, model.M{model.AddressColumns.IsDefaultShipping: false}); err != nil {
+			return err
+		}
+	}
+	if billing {
+		if _, err := model.Addresses(
+			model.AddressWhere.UserID.EQ(userID),
+			model.AddressWhere.IsDefaultBilling.EQ(true),
+		).UpdateAll(ctx, tx, model.M{model.AddressColumns.IsDefaultBilling: false}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
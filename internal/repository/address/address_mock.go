@@ -0,0 +1,44 @@
+package address
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetAddresses(ctx context.Context, userID int) ([]model.Address, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.Address), args.Error(1)
+}
+
+func (m *Mock) GetAddress(ctx context.Context, userID int, id int) (model.Address, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(model.Address), args.Error(1)
+}
+
+func (m *Mock) CreateAddress(ctx context.Context, tx *sql.Tx, address model.Address) (model.Address, error) {
+	args := m.Called(ctx, tx, address)
+	return args.Get(0).(model.Address), args.Error(1)
+}
+
+func (m *Mock) UpdateAddress(ctx context.Context, tx *sql.Tx, address model.Address) (int64, error) {
+	args := m.Called(ctx, tx, address)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) DeleteAddress(ctx context.Context, userID int, id int) (int64, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) ClearDefaultAddresses(ctx context.Context, tx *sql.Tx, userID int, shipping bool, billing bool) error {
+	args := m.Called(ctx, tx, userID, shipping, billing)
+	return args.Error(0)
+}
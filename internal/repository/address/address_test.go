@@ -0,0 +1,274 @@
+package address
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+const cleanUpQuery = "DELETE FROM addresses; DELETE FROM users;"
+
+func TestAddressRepository_GetAddresses(t *testing.T) {
+	tcs := map[string]struct {
+		givenUserID int
+		expIDs      []int
+	}{
+		"success": {
+			givenUserID: 10,
+			expIDs:      []int{10, 11},
+		},
+		"no_addresses": {
+			givenUserID: 99,
+			expIDs:      []int{},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/addresses.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetAddresses(context.Background(), tc.givenUserID)
+
+			// Then
+			require.NoError(t, err)
+			ids := make([]int, 0, len(result))
+			for _, a := range result {
+				ids = append(ids, a.ID)
+			}
+			require.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
+
+func TestAddressRepository_GetAddress(t *testing.T) {
+	tcs := map[string]struct {
+		givenUserID int
+		givenID     int
+		expErr      error
+	}{
+		"success": {
+			givenUserID: 10,
+			givenID:     11,
+		},
+		"address_of_other_user": {
+			givenUserID: 10,
+			givenID:     12,
+			expErr:      sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/addresses.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetAddress(context.Background(), tc.givenUserID, tc.givenID)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.givenID, result.ID)
+			require.Equal(t, "2 Nguyen Hue", result.Line1)
+		})
+	}
+}
+
+func TestAddressRepository_CreateAddress(t *testing.T) {
+	tcs := map[string]struct {
+		given  model.Address
+		expErr bool
+	}{
+		"success": {
+			given: model.Address{UserID: 11, Name: "Lan", Phone: "0987654322", Line1: "4 Trang Tien", City: "Ha Noi", PostalCode: "10000", Country: "VN", IsDefaultBilling: true},
+		},
+		"second_default_shipping": {
+			given:  model.Address{UserID: 11, Name: "Lan", Phone: "0987654322", Line1: "4 Trang Tien", City: "Ha Noi", Country: "VN", IsDefaultShipping: true},
+			expErr: true,
+		},
+		"user_not_found": {
+			given:  model.Address{UserID: 99, Name: "Lan", Phone: "0987654322", Line1: "4 Trang Tien", City: "Ha Noi", Country: "VN"},
+			expErr: true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/addresses.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.CreateAddress(context.Background(), txTest, tc.given)
+
+			// Then
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, result.ID)
+			require.False(t, result.CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestAddressRepository_UpdateAddress(t *testing.T) {
+	tcs := map[string]struct {
+		given       model.Address
+		expAffected int64
+	}{
+		"success": {
+			given:       model.Address{ID: 11, UserID: 10, Name: "Mai", Phone: "0987654321", Line1: "5 Dong Khoi", City: "Ho Chi Minh City", Country: "VN"},
+			expAffected: 1,
+		},
+		"address_of_other_user": {
+			given:       model.Address{ID: 12, UserID: 10, Name: "Mai", Phone: "0987654321", Line1: "5 Dong Khoi", City: "Ho Chi Minh City", Country: "VN"},
+			expAffected: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/addresses.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest)
+
+			// When
+			affected, err := repo.UpdateAddress(context.Background(), txTest, tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expAffected, affected)
+		})
+	}
+}
+
+func TestAddressRepository_DeleteAddress(t *testing.T) {
+	tcs := map[string]struct {
+		givenUserID int
+		givenID     int
+		expAffected int64
+	}{
+		"success": {
+			givenUserID: 10,
+			givenID:     11,
+			expAffected: 1,
+		},
+		"address_of_other_user": {
+			givenUserID: 10,
+			givenID:     12,
+			expAffected: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/addresses.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			affected, err := repo.DeleteAddress(context.Background(), tc.givenUserID, tc.givenID)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expAffected, affected)
+		})
+	}
+}
+
+func TestAddressRepository_ClearDefaultAddresses(t *testing.T) {
+	tcs := map[string]struct {
+		givenShipping bool
+		givenBilling  bool
+		expShipping   bool
+		expBilling    bool
+	}{
+		"shipping_only": {
+			givenShipping: true,
+			expShipping:   false,
+			expBilling:    true,
+		},
+		"both": {
+			givenShipping: true,
+			givenBilling:  true,
+			expShipping:   false,
+			expBilling:    false,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/addresses.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest)
+
+			// When
+			err = repo.ClearDefaultAddresses(context.Background(), txTest, 10, tc.givenShipping, tc.givenBilling)
+
+			// Then
+			require.NoError(t, err)
+			result, err := model.FindAddress(context.Background(), txTest, 10)
+			require.NoError(t, err)
+			require.Equal(t, tc.expShipping, result.IsDefaultShipping)
+			require.Equal(t, tc.expBilling, result.IsDefaultBilling)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package address
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type IAddress interface {
+	// GetAddresses returns the addresses of the user
+	GetAddresses(ctx context.Context, userID int) ([]model.Address, error)
+
+	// GetAddress returns the address of the user with the given id
+	GetAddress(ctx context.Context, userID int, id int) (model.Address, error)
+
+	// CreateAddress creates a new address
+	CreateAddress(ctx context.Context, tx *sql.Tx, address model.Address) (model.Address, error)
+
+	// UpdateAddress updates the address
+	UpdateAddress(ctx context.Context, tx *sql.Tx, address model.Address) (int64, error)
+
+	// DeleteAddress deletes the address of the user with the given id
+	DeleteAddress(ctx context.Context, userID int, id int) (int64, error)
+
+	// ClearDefaultAddresses unsets the default shipping and/or billing address of the user
+	ClearDefaultAddresses(ctx context.Context, tx *sql.Tx, userID int, shipping bool, billing bool) error
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IAddress {
+	return impl{db: db}
+}
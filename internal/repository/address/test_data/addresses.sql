@@ -0,0 +1,8 @@
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
+(10, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true),
+(11, 'test2', 'test2@example.com', 'test', 'test', 'GUEST', true);
+
+INSERT INTO "addresses" ("id", "user_id", "name", "phone", "line1", "city", "postal_code", "country", "is_default_shipping", "is_default_billing") VALUES
+(10, 10, 'Mai', '0987654321', '1 Le Loi', 'Ho Chi Minh City', '70000', 'VN', true, true),
+(11, 10, 'Mai', '0987654321', '2 Nguyen Hue', 'Ho Chi Minh City', '70000', 'VN', false, false),
+(12, 11, 'Lan', '0987654322', '3 Hang Bai', 'Ha Noi', '10000', 'VN', true, false);
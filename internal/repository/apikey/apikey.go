@@ -0,0 +1,78 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+)
+
+// CreateAPIKey creates a new API key
+func (r impl) CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	if err := key.Insert(ctx, r.db, boil.Whitelist("user_id", "name", "prefix", "key_hash", "scope", "expires_at", "created_at", "updated_at")); err != nil {
+		return model.APIKey{}, err
+	}
+	return key, nil
+}
+
+// GetAPIKey returns the API key with the given id whose owner belongs to the organization of the request
+func (r impl) GetAPIKey(ctx context.Context, id int) (model.APIKey, error) {
+	result, err := model.APIKeys(
+		qm.InnerJoin(model.TableNames.Users+" on "+model.UserTableColumns.ID+" = "+model.APIKeyTableColumns.UserID),
+		model.APIKeyWhere.ID.EQ(id),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).One(ctx, r.db)
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	return *result, nil
+}
+
+// GetAPIKeyByHash returns the API key with the given hash
+func (r impl) GetAPIKeyByHash(ctx context.Context, keyHash string) (model.APIKey, error) {
+	result, err := model.APIKeys(model.APIKeyWhere.KeyHash.EQ(keyHash)).One(ctx, r.db)
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	return *result, nil
+}
+
+// GetAPIKeys returns the API keys of the user, the newest first
+func (r impl) GetAPIKeys(ctx context.Context, userID int) (model.APIKeySlice, error) {
+	return model.APIKeys(
+		model.APIKeyWhere.UserID.EQ(userID),
+		qm.OrderBy(model.APIKeyColumns.CreatedAt+" DESC"),
+	).All(ctx, r.db)
+}
+
+// RevokeAPIKey marks the API key as revoked, the affected rows is 0 if it does not exist or was already revoked
+func (r impl) RevokeAPIKey(ctx context.Context, id int) (int64, error) {
+	now := time.Now()
+	return model.APIKeys(
+		model.APIKeyWhere.ID.EQ(id),
+		model.APIKeyWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, r.db, model.M{
+		model.APIKeyColumns.RevokedAt: null.TimeFrom(now),
+		model.APIKeyColumns.UpdatedAt: now,
+	})
+}
+
+// UpdateLastUsedAt sets the last used time of the API key, the affected rows is 0 if it was updated within the interval.
+// It avoids writing the same row on every request of a script.
+func (r impl) UpdateLastUsedAt(ctx context.Context, id int, interval time.Duration) (int64, error) {
+	now := time.Now()
+	return model.APIKeys(
+		model.APIKeyWhere.ID.EQ(id),
+		qm.Expr(
+			model.APIKeyWhere.LastUsedAt.IsNull(),
+			qm.Or2(model.APIKeyWhere.LastUsedAt.LT(null.TimeFrom(now.Add(-interval)))),
+		),
+	).UpdateAll(ctx, r.db, model.M{
+		model.APIKeyColumns.LastUsedAt: null.TimeFrom(now),
+	})
+}
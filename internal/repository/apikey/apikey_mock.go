@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(model.APIKey), args.Error(1)
+}
+
+func (m *Mock) GetAPIKey(ctx context.Context, id int) (model.APIKey, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.APIKey), args.Error(1)
+}
+
+func (m *Mock) GetAPIKeyByHash(ctx context.Context, keyHash string) (model.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	return args.Get(0).(model.APIKey), args.Error(1)
+}
+
+func (m *Mock) GetAPIKeys(ctx context.Context, userID int) (model.APIKeySlice, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(model.APIKeySlice), args.Error(1)
+}
+
+func (m *Mock) RevokeAPIKey(ctx context.Context, id int) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) UpdateLastUsedAt(ctx context.Context, id int, interval time.Duration) (int64, error) {
+	args := m.Called(ctx, id, interval)
+	return args.Get(0).(int64), args.Error(1)
+}
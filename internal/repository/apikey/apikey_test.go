@@ -0,0 +1,261 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+const cleanUpQuery = "DELETE FROM api_keys; DELETE FROM users; DELETE FROM organizations WHERE id = 100;"
+
+func TestAPIKeyRepository_CreateAPIKey(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/api_keys.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest)
+	given := model.APIKey{
+		UserID:    11,
+		Name:      "warehouse",
+		Prefix:    "sk_dddddddd",
+		KeyHash:   "hash4",
+		Scope:     "write",
+		ExpiresAt: null.TimeFrom(time.Now().Add(time.Hour)),
+	}
+
+	// When
+	result, err := repo.CreateAPIKey(context.Background(), given)
+
+	// Then
+	require.NoError(t, err)
+	require.NotZero(t, result.ID)
+	found, err := repo.GetAPIKeyByHash(context.Background(), "hash4")
+	require.NoError(t, err)
+	require.Equal(t, result.ID, found.ID)
+	require.Equal(t, "write", found.Scope)
+	require.True(t, found.ExpiresAt.Valid)
+	require.False(t, found.LastUsedAt.Valid)
+}
+
+func TestAPIKeyRepository_GetAPIKeyByHash(t *testing.T) {
+	tcs := map[string]struct {
+		given  string
+		expID  int
+		expErr error
+	}{
+		"success": {
+			given: "hash2",
+			expID: 2,
+		},
+		"not_found": {
+			given:  "hash5",
+			expErr: sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/api_keys.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetAPIKeyByHash(context.Background(), tc.given)
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expID, result.ID)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRepository_GetAPIKey(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx context.Context
+		givenID  int
+		expErr   error
+	}{
+		"success": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  1,
+		},
+		"success_other_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), 100),
+			givenID:  4,
+		},
+		"error_key_of_other_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  4,
+			expErr:   sql.ErrNoRows,
+		},
+		"error_not_found": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  5,
+			expErr:   sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/api_keys.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetAPIKey(tc.givenCtx, tc.givenID)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.givenID, result.ID)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRepository_GetAPIKeys(t *testing.T) {
+	tcs := map[string]struct {
+		given  int
+		expIDs []int
+	}{
+		"newest_first": {
+			given:  10,
+			expIDs: []int{2, 1},
+		},
+		"no_keys": {
+			given: 13,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/api_keys.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetAPIKeys(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Len(t, result, len(tc.expIDs))
+			for i, key := range result {
+				require.Equal(t, tc.expIDs[i], key.ID)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRepository_RevokeAPIKey(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   1,
+			rowsAff: 1,
+		},
+		"already_revoked": {
+			given:   3,
+			rowsAff: 0,
+		},
+		"not_found": {
+			given:   5,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/api_keys.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.RevokeAPIKey(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
+
+func TestAPIKeyRepository_UpdateLastUsedAt(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"never_used": {
+			given:   1,
+			rowsAff: 1,
+		},
+		"used_recently": {
+			given:   2,
+			rowsAff: 0,
+		},
+		"used_before_interval": {
+			given:   3,
+			rowsAff: 1,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/api_keys.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.UpdateLastUsedAt(context.Background(), tc.given, time.Minute)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type IAPIKey interface {
+	// CreateAPIKey creates a new API key
+	CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error)
+
+	// GetAPIKey returns the API key with the given id
+	GetAPIKey(ctx context.Context, id int) (model.APIKey, error)
+
+	// GetAPIKeyByHash returns the API key with the given hash
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (model.APIKey, error)
+
+	// GetAPIKeys returns the API keys of the user, the newest first
+	GetAPIKeys(ctx context.Context, userID int) (model.APIKeySlice, error)
+
+	// RevokeAPIKey marks the API key as revoked if it is not revoked yet
+	RevokeAPIKey(ctx context.Context, id int) (int64, error)
+
+	// UpdateLastUsedAt sets the last used time of the API key if it was not updated within the interval
+	UpdateLastUsedAt(ctx context.Context, id int, interval time.Duration) (int64, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IAPIKey {
+	return impl{db: db}
+}
@@ -0,0 +1,15 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
+(10, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true),
+(11, 'test2', 'test2@example.com', 'test', 'test', 'GUEST', true);
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "organization_id") VALUES
+(12, 'test3', 'test3@example.com', 'test', 'test', 'GUEST', true, 100);
+
+INSERT INTO "api_keys" ("id", "user_id", "name", "prefix", "key_hash", "scope", "last_used_at", "revoked_at", "created_at") VALUES
+(1, 10, 'warehouse', 'sk_aaaaaaaa', 'hash1', 'write', NULL, NULL, NOW() - INTERVAL '1 day'),
+(2, 10, 'report', 'sk_bbbbbbbb', 'hash2', 'read', NOW(), NULL, NOW()),
+(3, 11, 'old', 'sk_cccccccc', 'hash3', 'read', NOW() - INTERVAL '1 hour', NOW(), NOW()),
+(4, 12, 'shop', 'sk_dddddddd', 'hash6', 'write', NULL, NULL, NOW());
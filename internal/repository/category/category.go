@@ -0,0 +1,165 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+)
+
+// toCategories converts the slice to the categories
+func toCategories(slice model.CategorySlice) []model.Category {
+	result := make([]model.Category, 0, len(slice))
+	for _, c := range slice {
+		result = append(result, *c)
+	}
+	return result
+}
+
+// GetCategories returns all categories of the organization, the siblings are ordered by their sort order and then their name
+func (r impl) GetCategories(ctx context.Context) ([]model.Category, error) {
+	slice, err := model.Categories(
+		tenant.Where(ctx, model.CategoryTableColumns.OrganizationID),
+		qm.OrderBy(model.CategoryColumns.SortOrder+", "+model.CategoryColumns.Name+", "+model.CategoryColumns.ID),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	return toCategories(slice), nil
+}
+
+// GetCategory returns the category with the given id, categories of other organizations are not found
+func (r impl) GetCategory(ctx context.Context, id int) (model.Category, error) {
+	result, err := model.Categories(
+		model.CategoryWhere.ID.EQ(id),
+		tenant.Where(ctx, model.CategoryTableColumns.OrganizationID),
+	).One(ctx, r.db)
+	if err != nil {
+		return model.Category{}, err
+	}
+	return *result, nil
+}
+
+// GetCategoriesByIDs returns the categories with the given ids, the unknown ids are skipped
+func (r impl) GetCategoriesByIDs(ctx context.Context, ids []int) ([]model.Category, error) {
+	slice, err := model.Categories(
+		model.CategoryWhere.ID.IN(ids),
+		tenant.Where(ctx, model.CategoryTableColumns.OrganizationID),
+		qm.OrderBy(model.CategoryColumns.ID),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	return toCategories(slice), nil
+}
+
+// ExistsCategoryBySlug checks whether a category other than exceptID has the slug in the organization
+func (r impl) ExistsCategoryBySlug(ctx context.Context, slug string, exceptID int) (bool, error) {
+	return model.Categories(
+		model.CategoryWhere.Slug.EQ(slug),
+		model.CategoryWhere.ID.NEQ(exceptID),
+		tenant.Where(ctx, model.CategoryTableColumns.OrganizationID),
+	).Exists(ctx, r.db)
+}
+
+// ExistsChildCategory checks whether the category has child categories
+func (r impl) ExistsChildCategory(ctx context.Context, id int) (bool, error) {
+	return model.Categories(
+		model.CategoryWhere.ParentID.EQ(null.IntFrom(id)),
+		tenant.Where(ctx, model.CategoryTableColumns.OrganizationID),
+	).Exists(ctx, r.db)
+}
+
+// CreateCategory creates a new category in the organization, its path is the path of the parent followed by its id
+func (r impl) CreateCategory(ctx context.Context, tx *sql.Tx, category model.Category) (model.Category, error) {
+	category.OrganizationID = tenant.ID(ctx)
+	if err := category.Insert(ctx, tx, boil.Whitelist("organization_id", "parent_id", "name", "slug", "sort_order", "created_at", "updated_at")); err != nil {
+		return model.Category{}, err
+	}
+
+	err := queries.Raw(`
+		UPDATE categories SET path = COALESCE((SELECT p.path FROM categories p WHERE p.id = categories.parent_id), '/') || categories.id || '/'
+		WHERE categories.id = $1
+		RETURNING path`, category.ID).QueryRowContext(ctx, tx).Scan(&category.Path)
+	if err != nil {
+		return model.Category{}, err
+	}
+	return category, nil
+}
+
+// UpdateCategory updates the category. If the parent is changed, the category and its descendants are moved under the new parent.
+func (r impl) UpdateCategory(ctx context.Context, tx *sql.Tx, category model.Category) (int64, error) {
+	affected, err := model.Categories(
+		model.CategoryWhere.ID.EQ(category.ID),
+		tenant.Where(ctx, model.CategoryTableColumns.OrganizationID),
+	).UpdateAll(ctx, tx, model.M{
+		model.CategoryColumns.ParentID:  category.ParentID,
+		model.CategoryColumns.Name:      category.Name,
+		model.CategoryColumns.Slug:      category.Slug,
+		model.CategoryColumns.SortOrder: category.SortOrder,
+		model.CategoryColumns.UpdatedAt: time.Now(),
+	})
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+
+	// Replace the old path prefix of the subtree by the new path of the category, nothing changes if the parent is the same
+	_, err = queries.Raw(`
+		UPDATE categories c SET path = n.path || SUBSTR(c.path, LENGTH(o.path) + 1)
+		FROM (SELECT path FROM categories WHERE id = $1) o,
+			(SELECT COALESCE((SELECT p.path FROM categories p WHERE p.id = x.parent_id), '/') || x.id || '/' AS path FROM categories x WHERE x.id = $1) n
+		WHERE c.path LIKE o.path || '%' AND o.path <> n.path`, category.ID).ExecContext(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// DeleteCategory deletes the category, the products assigned to it are kept
+func (r impl) DeleteCategory(ctx context.Context, id int) (int64, error) {
+	return model.Categories(
+		model.CategoryWhere.ID.EQ(id),
+		tenant.Where(ctx, model.CategoryTableColumns.OrganizationID),
+	).DeleteAll(ctx, r.db)
+}
+
+// GetProductCategories returns the categories assigned to the product, ordered by their path
+func (r impl) GetProductCategories(ctx context.Context, productID int) ([]model.Category, error) {
+	slice, err := model.Categories(
+		qm.InnerJoin("product_categories pc ON pc.category_id = categories.id"),
+		qm.Where("pc.product_id = ?", productID),
+		qm.OrderBy(model.CategoryTableColumns.Path),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	return toCategories(slice), nil
+}
+
+// SetProductCategories replaces the categories assigned to the product
+func (r impl) SetProductCategories(ctx context.Context, tx *sql.Tx, productID int, categoryIDs []int) error {
+	if _, err := queries.Raw("DELETE FROM product_categories WHERE product_id = $1", productID).ExecContext(ctx, tx); err != nil {
+		return err
+	}
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(categoryIDs))
+	args := []interface{}{productID}
+	for i, id := range categoryIDs {
+		values[i] = fmt.Sprintf("($1, $%d)", i+2)
+		args = append(args, id)
+	}
+	_, err := queries.Raw("INSERT INTO product_categories (product_id, category_id) VALUES "+strings.Join(values, ", "), args...).ExecContext(ctx, tx)
+	return err
+}
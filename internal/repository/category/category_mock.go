@@ -0,0 +1,64 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetCategories(ctx context.Context) ([]model.Category, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.Category), args.Error(1)
+}
+
+func (m *Mock) GetCategory(ctx context.Context, id int) (model.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.Category), args.Error(1)
+}
+
+func (m *Mock) GetCategoriesByIDs(ctx context.Context, ids []int) ([]model.Category, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]model.Category), args.Error(1)
+}
+
+func (m *Mock) ExistsCategoryBySlug(ctx context.Context, slug string, exceptID int) (bool, error) {
+	args := m.Called(ctx, slug, exceptID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Mock) ExistsChildCategory(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Mock) CreateCategory(ctx context.Context, tx *sql.Tx, category model.Category) (model.Category, error) {
+	args := m.Called(ctx, tx, category)
+	return args.Get(0).(model.Category), args.Error(1)
+}
+
+func (m *Mock) UpdateCategory(ctx context.Context, tx *sql.Tx, category model.Category) (int64, error) {
+	args := m.Called(ctx, tx, category)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) DeleteCategory(ctx context.Context, id int) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) GetProductCategories(ctx context.Context, productID int) ([]model.Category, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).([]model.Category), args.Error(1)
+}
+
+func (m *Mock) SetProductCategories(ctx context.Context, tx *sql.Tx, productID int, categoryIDs []int) error {
+	args := m.Called(ctx, tx, productID, categoryIDs)
+	return args.Error(0)
+}
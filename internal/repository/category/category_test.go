@@ -0,0 +1,266 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+const cleanUpQuery = "DELETE FROM product_categories; DELETE FROM categories; DELETE FROM products; DELETE FROM users; DELETE FROM organizations WHERE id <> 1;"
+
+// categoryPaths returns the path of each category by its id
+func categoryPaths(t *testing.T, dbTest *sql.DB) map[int]string {
+	rows, err := dbTest.Query("SELECT id, path FROM categories")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	result := map[int]string{}
+	for rows.Next() {
+		var id int
+		var path string
+		require.NoError(t, rows.Scan(&id, &path))
+		result[id] = path
+	}
+	return result
+}
+
+func TestCategoryRepository_GetCategories(t *testing.T) {
+	tcs := map[string]struct {
+		ctx    context.Context
+		expIDs []int
+	}{
+		"default_organization": {
+			ctx:    context.Background(),
+			expIDs: []int{13, 10, 12, 11},
+		},
+		"other_organization": {
+			ctx:    auth.NewTenantContext(context.Background(), 100),
+			expIDs: []int{14},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/categories.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetCategories(tc.ctx)
+
+			// Then
+			require.NoError(t, err)
+			ids := make([]int, 0, len(result))
+			for _, c := range result {
+				ids = append(ids, c.ID)
+			}
+			require.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
+
+func TestCategoryRepository_CreateCategory(t *testing.T) {
+	tcs := map[string]struct {
+		given     model.Category
+		expPrefix string
+		expErr    bool
+	}{
+		"root": {
+			given:     model.Category{Name: "Toys", Slug: "toys"},
+			expPrefix: "/",
+		},
+		"child": {
+			given:     model.Category{ParentID: null.IntFrom(11), Name: "Feature phones", Slug: "feature-phones"},
+			expPrefix: "/10/11/",
+		},
+		"slug_existed": {
+			given:  model.Category{Name: "Books", Slug: "books"},
+			expErr: true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/categories.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.CreateCategory(context.Background(), txTest, tc.given)
+
+			// Then
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, result.ID)
+			require.Equal(t, 1, result.OrganizationID)
+			require.Regexp(t, "^"+tc.expPrefix+"[0-9]+/$", result.Path)
+		})
+	}
+}
+
+func TestCategoryRepository_UpdateCategory(t *testing.T) {
+	tcs := map[string]struct {
+		ctx         context.Context
+		given       model.Category
+		expAffected int64
+		expPaths    map[int]string
+	}{
+		"rename": {
+			ctx:         context.Background(),
+			given:       model.Category{ID: 11, ParentID: null.IntFrom(10), Name: "Mobiles", Slug: "mobiles"},
+			expAffected: 1,
+			expPaths:    map[int]string{10: "/10/", 11: "/10/11/", 12: "/10/11/12/", 13: "/13/", 14: "/14/"},
+		},
+		"move_subtree": {
+			ctx:         context.Background(),
+			given:       model.Category{ID: 11, ParentID: null.IntFrom(13), Name: "Phones", Slug: "phones"},
+			expAffected: 1,
+			expPaths:    map[int]string{10: "/10/", 11: "/13/11/", 12: "/13/11/12/", 13: "/13/", 14: "/14/"},
+		},
+		"move_to_root": {
+			ctx:         context.Background(),
+			given:       model.Category{ID: 11, Name: "Phones", Slug: "phones"},
+			expAffected: 1,
+			expPaths:    map[int]string{10: "/10/", 11: "/11/", 12: "/11/12/", 13: "/13/", 14: "/14/"},
+		},
+		"category_of_other_organization": {
+			ctx:         auth.NewTenantContext(context.Background(), 100),
+			given:       model.Category{ID: 11, Name: "Phones", Slug: "phones"},
+			expAffected: 0,
+			expPaths:    map[int]string{10: "/10/", 11: "/10/11/", 12: "/10/11/12/", 13: "/13/", 14: "/14/"},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/categories.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+
+			repo := New(dbTest)
+
+			// When
+			affected, err := repo.UpdateCategory(tc.ctx, txTest, tc.given)
+			require.NoError(t, txTest.Commit())
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expAffected, affected)
+			require.Equal(t, tc.expPaths, categoryPaths(t, dbTest))
+		})
+	}
+}
+
+func TestCategoryRepository_ExistsChildCategory(t *testing.T) {
+	tcs := map[string]struct {
+		givenID int
+		exp     bool
+	}{
+		"has_children": {
+			givenID: 10,
+			exp:     true,
+		},
+		"leaf": {
+			givenID: 12,
+			exp:     false,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/categories.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.ExistsChildCategory(context.Background(), tc.givenID)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, result)
+		})
+	}
+}
+
+func TestCategoryRepository_SetProductCategories(t *testing.T) {
+	tcs := map[string]struct {
+		givenIDs []int
+		expIDs   []int
+	}{
+		"replace": {
+			givenIDs: []int{13, 11},
+			expIDs:   []int{11, 13},
+		},
+		"clear": {
+			givenIDs: []int{},
+			expIDs:   []int{},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/categories.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+
+			repo := New(dbTest)
+
+			// When
+			err = repo.SetProductCategories(context.Background(), txTest, 1, tc.givenIDs)
+			require.NoError(t, txTest.Commit())
+
+			// Then
+			require.NoError(t, err)
+			result, err := repo.GetProductCategories(context.Background(), 1)
+			require.NoError(t, err)
+			ids := make([]int, 0, len(result))
+			for _, c := range result {
+				ids = append(ids, c.ID)
+			}
+			require.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
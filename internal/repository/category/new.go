@@ -0,0 +1,48 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type ICategory interface {
+	// GetCategories returns all categories of the organization, the siblings are ordered by their sort order
+	GetCategories(ctx context.Context) ([]model.Category, error)
+
+	// GetCategory returns the category with the given id
+	GetCategory(ctx context.Context, id int) (model.Category, error)
+
+	// GetCategoriesByIDs returns the categories with the given ids
+	GetCategoriesByIDs(ctx context.Context, ids []int) ([]model.Category, error)
+
+	// ExistsCategoryBySlug checks whether a category other than exceptID has the slug
+	ExistsCategoryBySlug(ctx context.Context, slug string, exceptID int) (bool, error)
+
+	// ExistsChildCategory checks whether the category has child categories
+	ExistsChildCategory(ctx context.Context, id int) (bool, error)
+
+	// CreateCategory creates a new category and sets its path
+	CreateCategory(ctx context.Context, tx *sql.Tx, category model.Category) (model.Category, error)
+
+	// UpdateCategory updates the category and the paths of its subtree
+	UpdateCategory(ctx context.Context, tx *sql.Tx, category model.Category) (int64, error)
+
+	// DeleteCategory deletes the category with the given id
+	DeleteCategory(ctx context.Context, id int) (int64, error)
+
+	// GetProductCategories returns the categories assigned to the product
+	GetProductCategories(ctx context.Context, productID int) ([]model.Category, error)
+
+	// SetProductCategories replaces the categories assigned to the product
+	SetProductCategories(ctx context.Context, tx *sql.Tx, productID int, categoryIDs []int) error
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) ICategory {
+	return impl{db: db}
+}
@@ -0,0 +1,20 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO users ("id", "name", "email", "phone", password)
+VALUES (1, 'admin', 'admin@example.com', '0987654321', '123456789');
+
+INSERT INTO products ("id", "title", "price", "quantity", "user_id", "is_active")
+VALUES (1, 'AAA', 20000, 10, 1, true),
+       (2, 'BBB', 15000, 20, 1, true);
+
+INSERT INTO categories ("id", "organization_id", "parent_id", "name", "slug", "sort_order", "path") VALUES
+(10, 1, NULL, 'Electronics', 'electronics', 0, '/10/'),
+(11, 1, 10, 'Phones', 'phones', 1, '/10/11/'),
+(12, 1, 11, 'Smart phones', 'smart-phones', 0, '/10/11/12/'),
+(13, 1, NULL, 'Books', 'books', 0, '/13/'),
+(14, 100, NULL, 'Fashion', 'fashion', 0, '/14/');
+
+INSERT INTO product_categories ("product_id", "category_id") VALUES
+(1, 12),
+(2, 13);
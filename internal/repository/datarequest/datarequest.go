@@ -0,0 +1,38 @@
+package datarequest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+)
+
+// CreateDataRequest records a personal data request, it is created in the transaction of the export or the erasure
+func (r impl) CreateDataRequest(ctx context.Context, tx *sql.Tx, request model.DataRequest) (model.DataRequest, error) {
+	if err := request.Insert(ctx, tx, boil.Whitelist("actor_id", "subject_id", "type", "created_at")); err != nil {
+		return model.DataRequest{}, err
+	}
+	return request, nil
+}
+
+// GetDataRequests returns the personal data requests whose subject belongs to the organization of the request
+func (r impl) GetDataRequests(ctx context.Context) ([]model.DataRequest, error) {
+	slice, err := model.DataRequests(
+		qm.InnerJoin(model.TableNames.Users+" on "+model.UserTableColumns.ID+" = "+model.DataRequestTableColumns.SubjectID),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+		qm.OrderBy(model.DataRequestTableColumns.CreatedAt+" DESC, "+model.DataRequestTableColumns.ID+" DESC"),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.DataRequest, 0, len(slice))
+	for _, d := range slice {
+		result = append(result, *d)
+	}
+	return result, nil
+}
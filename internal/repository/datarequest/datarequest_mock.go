@@ -0,0 +1,24 @@
+package datarequest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) CreateDataRequest(ctx context.Context, tx *sql.Tx, request model.DataRequest) (model.DataRequest, error) {
+	args := m.Called(ctx, tx, request)
+	return args.Get(0).(model.DataRequest), args.Error(1)
+}
+
+func (m *Mock) GetDataRequests(ctx context.Context) ([]model.DataRequest, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.DataRequest), args.Error(1)
+}
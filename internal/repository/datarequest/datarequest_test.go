@@ -0,0 +1,105 @@
+package datarequest
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+const cleanUpQuery = "DELETE FROM data_requests; DELETE FROM users; DELETE FROM organizations WHERE id >= 100;"
+
+func TestDataRequestRepository_CreateDataRequest(t *testing.T) {
+	tcs := map[string]struct {
+		given  model.DataRequest
+		expErr bool
+	}{
+		"success": {
+			given: model.DataRequest{ActorID: 10, SubjectID: 11, Type: TypeErasure},
+		},
+		"subject_not_found": {
+			given:  model.DataRequest{ActorID: 10, SubjectID: 99, Type: TypeExport},
+			expErr: true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/data_requests.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.CreateDataRequest(context.Background(), txTest, tc.given)
+
+			// Then
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, result.ID)
+			require.False(t, result.CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestDataRequestRepository_GetDataRequests(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx context.Context
+		expIDs   []int
+	}{
+		"default_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			expIDs:   []int{2, 1},
+		},
+		"other_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), 100),
+			expIDs:   []int{3},
+		},
+		"unscoped": {
+			givenCtx: auth.NewUnscopedContext(context.Background()),
+			expIDs:   []int{3, 2, 1},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/data_requests.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetDataRequests(tc.givenCtx)
+
+			// Then
+			require.NoError(t, err)
+			ids := make([]int, 0, len(result))
+			for _, d := range result {
+				ids = append(ids, d.ID)
+			}
+			require.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
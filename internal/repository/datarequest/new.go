@@ -0,0 +1,31 @@
+package datarequest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+const (
+	// TypeExport is a request to export the personal data of a user
+	TypeExport = "EXPORT"
+	// TypeErasure is a request to erase the personal data of a user
+	TypeErasure = "ERASURE"
+)
+
+type IDataRequest interface {
+	// CreateDataRequest records a personal data request
+	CreateDataRequest(ctx context.Context, tx *sql.Tx, request model.DataRequest) (model.DataRequest, error)
+
+	// GetDataRequests returns the personal data requests of the organization, the latest first
+	GetDataRequests(ctx context.Context) ([]model.DataRequest, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IDataRequest {
+	return impl{db: db}
+}
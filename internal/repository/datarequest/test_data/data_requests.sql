@@ -0,0 +1,12 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "organization_id") VALUES
+(10, 'admin', 'admin@example.com', 'test', 'test', 'ADMIN', true, 1),
+(11, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true, 1),
+(12, 'test2', 'test2@example.com', 'test', 'test', 'GUEST', true, 100);
+
+INSERT INTO "data_requests" ("id", "actor_id", "subject_id", "type", "created_at") VALUES
+(1, 11, 11, 'EXPORT', '2022-01-01 10:00:00'),
+(2, 10, 11, 'ERASURE', NOW()),
+(3, 10, 12, 'EXPORT', NOW());
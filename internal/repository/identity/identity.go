@@ -0,0 +1,102 @@
+package identity
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+	userRepo "github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+)
+
+// GetIdentity returns the identity of the subject at the issuer
+func (r impl) GetIdentity(ctx context.Context, issuer string, subject string) (model.Identity, error) {
+	result, err := model.Identities(
+		model.IdentityWhere.Issuer.EQ(issuer),
+		model.IdentityWhere.Subject.EQ(subject),
+	).One(ctx, r.db)
+	if err != nil {
+		return model.Identity{}, err
+	}
+	if result.Email, err = r.keys.Decrypt(result.Email); err != nil {
+		return model.Identity{}, err
+	}
+	return *result, nil
+}
+
+// CreateIdentity links a new identity to its user, a subject can only be linked once per issuer. The email is stored encrypted.
+func (r impl) CreateIdentity(ctx context.Context, tx *sql.Tx, identity model.Identity) (model.Identity, error) {
+	email := identity.Email
+	var err error
+	if identity.Email, err = r.keys.Encrypt(email); err != nil {
+		return model.Identity{}, err
+	}
+	if err = identity.Insert(ctx, tx, boil.Whitelist("user_id", "issuer", "subject", "email", "created_at", "updated_at")); err != nil {
+		return model.Identity{}, err
+	}
+	identity.Email = email
+	return identity, nil
+}
+
+// CreateIdentityUser creates a user with an empty password, so the user can only login with the issuer.
+// The email is marked as verified because it was verified by the issuer, the user joins the organization of the request.
+func (r impl) CreateIdentityUser(ctx context.Context, tx *sql.Tx, user model.User) (model.User, error) {
+	user.Password = ""
+	user.EmailVerifiedAt = null.TimeFrom(time.Now())
+	user.OrganizationID = tenant.ID(ctx)
+	if err := userRepo.EncryptUser(r.keys, &user); err != nil {
+		return model.User{}, err
+	}
+	if err := user.Insert(ctx, tx, boil.Whitelist("name", "email", "email_index", "password", "phone", "role", "is_active", "email_verified_at", "organization_id", "created_at", "updated_at")); err != nil {
+		return model.User{}, err
+	}
+	if err := userRepo.DecryptUser(r.keys, &user); err != nil {
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+// ReencryptIdentities encrypts the plaintext emails of the batch of identities after "afterID" and re-encrypts the ones encrypted by retired keys with the active key.
+// An identity is skipped if its email was changed since the batch was read.
+func (r impl) ReencryptIdentities(ctx context.Context, afterID int, limit int) (userRepo.ReencryptResult, error) {
+	identities, err := model.Identities(
+		model.IdentityWhere.ID.GT(afterID),
+		qm.OrderBy(model.IdentityColumns.ID),
+		qm.Limit(limit),
+	).All(ctx, r.db)
+	if err != nil {
+		return userRepo.ReencryptResult{}, err
+	}
+
+	result := userRepo.ReencryptResult{LastID: afterID, Scanned: len(identities)}
+	for _, identity := range identities {
+		result.LastID = identity.ID
+		if !r.keys.NeedsReencrypt(identity.Email) {
+			continue
+		}
+
+		email, err := r.keys.Decrypt(identity.Email)
+		if err != nil {
+			return result, err
+		}
+		encrypted, err := r.keys.Encrypt(email)
+		if err != nil {
+			return result, err
+		}
+
+		affected, err := model.Identities(
+			model.IdentityWhere.ID.EQ(identity.ID),
+			model.IdentityWhere.Email.EQ(identity.Email),
+		).UpdateAll(ctx, r.db, model.M{model.IdentityColumns.Email: encrypted})
+		if err != nil {
+			return result, err
+		}
+		result.Reencrypted += affected
+	}
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package identity
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	userRepo "github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetIdentity(ctx context.Context, issuer string, subject string) (model.Identity, error) {
+	args := m.Called(ctx, issuer, subject)
+	return args.Get(0).(model.Identity), args.Error(1)
+}
+
+func (m *Mock) CreateIdentity(ctx context.Context, tx *sql.Tx, identity model.Identity) (model.Identity, error) {
+	args := m.Called(ctx, tx, identity)
+	return args.Get(0).(model.Identity), args.Error(1)
+}
+
+func (m *Mock) CreateIdentityUser(ctx context.Context, tx *sql.Tx, user model.User) (model.User, error) {
+	args := m.Called(ctx, tx, user)
+	return args.Get(0).(model.User), args.Error(1)
+}
+
+func (m *Mock) ReencryptIdentities(ctx context.Context, afterID int, limit int) (userRepo.ReencryptResult, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).(userRepo.ReencryptResult), args.Error(1)
+}
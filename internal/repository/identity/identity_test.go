@@ -0,0 +1,186 @@
+package identity
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption/encryptiontest"
+)
+
+const cleanUpQuery = "DELETE FROM identities; DELETE FROM users;"
+
+func TestIdentityRepository_GetIdentity(t *testing.T) {
+	tcs := map[string]struct {
+		givenIssuer  string
+		givenSubject string
+		expUserID    int
+		expEmail     string
+		expErr       error
+	}{
+		"success": {
+			givenIssuer:  "https://sso.example.com",
+			givenSubject: "sub-11",
+			expUserID:    11,
+			expEmail:     "test2@example.com",
+		},
+		"success_encrypted_email": {
+			givenIssuer:  "https://other.example.com",
+			givenSubject: "sub-12",
+			expUserID:    11,
+			expEmail:     "test2@example.com",
+		},
+		"other_issuer": {
+			givenIssuer:  "https://other.example.com",
+			givenSubject: "sub-11",
+			expErr:       sql.ErrNoRows,
+		},
+		"not_found": {
+			givenIssuer:  "https://sso.example.com",
+			givenSubject: "sub-10",
+			expErr:       sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/identities.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.GetIdentity(context.Background(), tc.givenIssuer, tc.givenSubject)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expUserID, result.UserID)
+				require.Equal(t, tc.expEmail, result.Email)
+			}
+		})
+	}
+}
+
+func TestIdentityRepository_CreateIdentity(t *testing.T) {
+	tcs := map[string]struct {
+		given     model.Identity
+		expDupErr bool
+	}{
+		"success": {
+			given: model.Identity{UserID: 10, Issuer: "https://sso.example.com", Subject: "sub-10", Email: "test1@example.com"},
+		},
+		"subject_already_linked": {
+			given:     model.Identity{UserID: 10, Issuer: "https://sso.example.com", Subject: "sub-11", Email: "test1@example.com"},
+			expDupErr: true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/identities.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+			tx, err := dbTest.Begin()
+			require.NoError(t, err)
+
+			// When
+			result, err := repo.CreateIdentity(context.Background(), tx, tc.given)
+
+			// Then
+			if tc.expDupErr {
+				require.Error(t, err)
+				require.NoError(t, tx.Rollback())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, tx.Commit())
+			require.NotZero(t, result.ID)
+			require.Equal(t, tc.given.Email, result.Email)
+			found, err := repo.GetIdentity(context.Background(), tc.given.Issuer, tc.given.Subject)
+			require.NoError(t, err)
+			require.Equal(t, tc.given.UserID, found.UserID)
+			require.Equal(t, tc.given.Email, found.Email)
+			stored, err := model.FindIdentity(context.Background(), dbTest, result.ID)
+			require.NoError(t, err)
+			require.True(t, encryption.IsEncrypted(stored.Email))
+		})
+	}
+}
+
+func TestIdentityRepository_CreateIdentityUser(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/identities.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest, encryptiontest.KeyRing())
+	tx, err := dbTest.Begin()
+	require.NoError(t, err)
+
+	// When
+	result, err := repo.CreateIdentityUser(context.Background(), tx, model.User{
+		Name:     "sso user",
+		Email:    "sso@example.com",
+		Password: "ignored",
+		Role:     "GUEST",
+		IsActive: true,
+	})
+	require.NoError(t, tx.Commit())
+
+	// Then
+	require.NoError(t, err)
+	require.NotZero(t, result.ID)
+	found, err := model.FindUser(context.Background(), dbTest, result.ID)
+	require.NoError(t, err)
+	require.Empty(t, found.Password)
+	require.True(t, found.EmailVerifiedAt.Valid)
+	require.True(t, encryption.IsEncrypted(found.Email))
+	require.Equal(t, "sso@example.com", result.Email)
+}
+
+func TestIdentityRepository_ReencryptIdentities(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/identities.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest, encryptiontest.KeyRing())
+
+	// When
+	result, err := repo.ReencryptIdentities(context.Background(), 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Scanned)
+	require.Equal(t, 20, result.LastID)
+	require.Equal(t, int64(1), result.Reencrypted)
+	stored, err := model.FindIdentity(context.Background(), dbTest, 1)
+	require.NoError(t, err)
+	require.True(t, encryption.IsEncrypted(stored.Email))
+	found, err := repo.GetIdentity(context.Background(), stored.Issuer, stored.Subject)
+	require.NoError(t, err)
+	require.Equal(t, "test2@example.com", found.Email)
+}
@@ -0,0 +1,34 @@
+package identity
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	userRepo "github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+)
+
+type IIdentity interface {
+	// GetIdentity returns the identity of the subject at the issuer
+	GetIdentity(ctx context.Context, issuer string, subject string) (model.Identity, error)
+
+	// CreateIdentity links a new identity to its user
+	CreateIdentity(ctx context.Context, tx *sql.Tx, identity model.Identity) (model.Identity, error)
+
+	// CreateIdentityUser creates a user without password for an identity, the email is verified by the issuer
+	CreateIdentityUser(ctx context.Context, tx *sql.Tx, user model.User) (model.User, error)
+
+	// ReencryptIdentities encrypts the emails of the batch of identities after the given id with the active key
+	ReencryptIdentities(ctx context.Context, afterID int, limit int) (userRepo.ReencryptResult, error)
+}
+
+type impl struct {
+	db   *sql.DB
+	keys *encryption.KeyRing
+}
+
+// New returns the identity repository, the emails of the identities and the users are encrypted with the keys
+func New(db *sql.DB, keys *encryption.KeyRing) IIdentity {
+	return impl{db: db, keys: keys}
+}
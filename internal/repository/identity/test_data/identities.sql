@@ -0,0 +1,8 @@
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
+(10, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true),
+(11, 'test2', 'test2@example.com', '', '', 'GUEST', true);
+
+-- The email of identity 1 is plaintext, written before the encryption was enabled, the email of identity 20 is encrypted with the keys of encryptiontest
+INSERT INTO "identities" ("id", "user_id", "issuer", "subject", "email") VALUES
+(1, 11, 'https://sso.example.com', 'sub-11', 'test2@example.com'),
+(20, 11, 'https://other.example.com', 'sub-12', 'enc:v1:default:soRPu6+R4i02FSw92zssddPaIkcC3wJRXNBBoM/YJ7r8I6Mo8YtXIJKHV8j5TxMndPftdReWIHMmlI1d:xZyJWbbtezpr+lJlmfb84hsHo6AwPtCWuaqaEk2FhFKDcNFJheHg9g7vLsp/');
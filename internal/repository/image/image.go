@@ -0,0 +1,102 @@
+package image
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+)
+
+// GetImages returns the images of the product in the organization, ordered by their position and then their id
+func (r impl) GetImages(ctx context.Context, productID int) ([]model.ProductImage, error) {
+	slice, err := model.ProductImages(
+		model.ProductImageWhere.ProductID.EQ(productID),
+		tenant.Where(ctx, model.ProductImageTableColumns.OrganizationID),
+		qm.OrderBy(model.ProductImageColumns.Position+", "+model.ProductImageColumns.ID),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ProductImage, 0, len(slice))
+	for _, i := range slice {
+		result = append(result, *i)
+	}
+	return result, nil
+}
+
+// GetImage returns the image of the product, images of other organizations are not found
+func (r impl) GetImage(ctx context.Context, productID, id int) (model.ProductImage, error) {
+	image, err := model.ProductImages(
+		model.ProductImageWhere.ID.EQ(id),
+		model.ProductImageWhere.ProductID.EQ(productID),
+		tenant.Where(ctx, model.ProductImageTableColumns.OrganizationID),
+	).One(ctx, r.db)
+	if err != nil {
+		return model.ProductImage{}, err
+	}
+	return *image, nil
+}
+
+// CreateImage creates a new image in the organization
+func (r impl) CreateImage(ctx context.Context, tx *sql.Tx, image model.ProductImage) (model.ProductImage, error) {
+	image.OrganizationID = tenant.ID(ctx)
+	if err := image.Insert(ctx, tx, boil.Infer()); err != nil {
+		return model.ProductImage{}, err
+	}
+	return image, nil
+}
+
+// SetPrimaryImage clears the primary image of the product first, so the unique index of the primary image is never violated
+func (r impl) SetPrimaryImage(ctx context.Context, tx *sql.Tx, productID, id int) (int64, error) {
+	if _, err := model.ProductImages(
+		model.ProductImageWhere.ProductID.EQ(productID),
+		model.ProductImageWhere.IsPrimary.EQ(true),
+		tenant.Where(ctx, model.ProductImageTableColumns.OrganizationID),
+	).UpdateAll(ctx, tx, model.M{
+		model.ProductImageColumns.IsPrimary: false,
+		model.ProductImageColumns.UpdatedAt: time.Now(),
+	}); err != nil {
+		return 0, err
+	}
+
+	return model.ProductImages(
+		model.ProductImageWhere.ID.EQ(id),
+		model.ProductImageWhere.ProductID.EQ(productID),
+		tenant.Where(ctx, model.ProductImageTableColumns.OrganizationID),
+	).UpdateAll(ctx, tx, model.M{
+		model.ProductImageColumns.IsPrimary: true,
+		model.ProductImageColumns.UpdatedAt: time.Now(),
+	})
+}
+
+// SetPositions sets the position of each image of the product to its index in ids
+func (r impl) SetPositions(ctx context.Context, tx *sql.Tx, productID int, ids []int) error {
+	for position, id := range ids {
+		if _, err := model.ProductImages(
+			model.ProductImageWhere.ID.EQ(id),
+			model.ProductImageWhere.ProductID.EQ(productID),
+			tenant.Where(ctx, model.ProductImageTableColumns.OrganizationID),
+		).UpdateAll(ctx, tx, model.M{
+			model.ProductImageColumns.Position:  position,
+			model.ProductImageColumns.UpdatedAt: time.Now(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteImage deletes the image of the product, its files are deleted from the storage by the service
+func (r impl) DeleteImage(ctx context.Context, tx *sql.Tx, productID, id int) (int64, error) {
+	return model.ProductImages(
+		model.ProductImageWhere.ID.EQ(id),
+		model.ProductImageWhere.ProductID.EQ(productID),
+		tenant.Where(ctx, model.ProductImageTableColumns.OrganizationID),
+	).DeleteAll(ctx, tx)
+}
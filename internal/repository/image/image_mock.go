@@ -0,0 +1,44 @@
+package image
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetImages(ctx context.Context, productID int) ([]model.ProductImage, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).([]model.ProductImage), args.Error(1)
+}
+
+func (m *Mock) GetImage(ctx context.Context, productID, id int) (model.ProductImage, error) {
+	args := m.Called(ctx, productID, id)
+	return args.Get(0).(model.ProductImage), args.Error(1)
+}
+
+func (m *Mock) CreateImage(ctx context.Context, tx *sql.Tx, image model.ProductImage) (model.ProductImage, error) {
+	args := m.Called(ctx, tx, image)
+	return args.Get(0).(model.ProductImage), args.Error(1)
+}
+
+func (m *Mock) SetPrimaryImage(ctx context.Context, tx *sql.Tx, productID, id int) (int64, error) {
+	args := m.Called(ctx, tx, productID, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) SetPositions(ctx context.Context, tx *sql.Tx, productID int, ids []int) error {
+	args := m.Called(ctx, tx, productID, ids)
+	return args.Error(0)
+}
+
+func (m *Mock) DeleteImage(ctx context.Context, tx *sql.Tx, productID, id int) (int64, error) {
+	args := m.Called(ctx, tx, productID, id)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,249 @@
+package image
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+const cleanUpQuery = "DELETE FROM product_images; DELETE FROM products; DELETE FROM users; DELETE FROM organizations WHERE id <> 1;"
+
+func TestImageRepository_GetImages(t *testing.T) {
+	tcs := map[string]struct {
+		ctx    context.Context
+		expIDs []int
+	}{
+		"default_organization": {
+			ctx:    context.Background(),
+			expIDs: []int{11, 10, 12},
+		},
+		"other_organization": {
+			ctx:    auth.NewTenantContext(context.Background(), 100),
+			expIDs: []int{13},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/images.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetImages(tc.ctx, 1)
+
+			// Then
+			require.NoError(t, err)
+			ids := make([]int, 0, len(result))
+			for _, i := range result {
+				ids = append(ids, i.ID)
+			}
+			require.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
+
+func TestImageRepository_GetImage(t *testing.T) {
+	tcs := map[string]struct {
+		productID int
+		id        int
+		expErr    error
+	}{
+		"success": {
+			productID: 1,
+			id:        10,
+		},
+		"other_product": {
+			productID: 2,
+			id:        10,
+			expErr:    sql.ErrNoRows,
+		},
+		"other_organization": {
+			productID: 1,
+			id:        13,
+			expErr:    sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/images.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetImage(context.Background(), tc.productID, tc.id)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "products/1/images/a", result.StorageKey)
+			require.True(t, result.IsPrimary)
+		})
+	}
+}
+
+func TestImageRepository_CreateImage(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/images.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+	require.NoError(t, err)
+	defer txTest.Rollback()
+
+	repo := New(dbTest)
+
+	// When
+	result, err := repo.CreateImage(auth.NewTenantContext(context.Background(), 100), txTest, model.ProductImage{
+		ProductID: 2, StorageKey: "products/2/images/e", ContentType: "image/png", Size: 100, Width: 10, Height: 10, Position: 0,
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.NotZero(t, result.ID)
+	require.Equal(t, 100, result.OrganizationID)
+	require.False(t, result.CreatedAt.IsZero())
+}
+
+func TestImageRepository_SetPrimaryImage(t *testing.T) {
+	tcs := map[string]struct {
+		id          int
+		expAffected int64
+		expPrimary  int
+	}{
+		"success": {
+			id:          12,
+			expAffected: 1,
+			expPrimary:  12,
+		},
+		"other_organization": {
+			id:          13,
+			expAffected: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/images.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest)
+
+			// When
+			affected, err := repo.SetPrimaryImage(context.Background(), txTest, 1, tc.id)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expAffected, affected)
+			primaries, err := model.ProductImages(model.ProductImageWhere.IsPrimary.EQ(true)).All(context.Background(), txTest)
+			require.NoError(t, err)
+			if tc.expPrimary == 0 {
+				require.Empty(t, primaries)
+				return
+			}
+			require.Len(t, primaries, 1)
+			require.Equal(t, tc.expPrimary, primaries[0].ID)
+		})
+	}
+}
+
+func TestImageRepository_SetPositions(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/images.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+	require.NoError(t, err)
+	defer txTest.Rollback()
+
+	repo := New(dbTest)
+
+	// When
+	err = repo.SetPositions(context.Background(), txTest, 1, []int{12, 10, 11})
+
+	// Then
+	require.NoError(t, err)
+	images, err := model.ProductImages(model.ProductImageWhere.ProductID.EQ(1), model.ProductImageWhere.OrganizationID.EQ(1)).All(context.Background(), txTest)
+	require.NoError(t, err)
+	positions := map[int]int{}
+	for _, i := range images {
+		positions[i.ID] = i.Position
+	}
+	require.Equal(t, map[int]int{12: 0, 10: 1, 11: 2}, positions)
+}
+
+func TestImageRepository_DeleteImage(t *testing.T) {
+	tcs := map[string]struct {
+		ctx         context.Context
+		expAffected int64
+	}{
+		"success": {
+			ctx:         context.Background(),
+			expAffected: 1,
+		},
+		"other_organization": {
+			ctx:         auth.NewTenantContext(context.Background(), 100),
+			expAffected: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/images.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest)
+
+			// When
+			affected, err := repo.DeleteImage(tc.ctx, txTest, 1, 10)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expAffected, affected)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package image
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type IImage interface {
+	// GetImages returns the images of the product, ordered by their position
+	GetImages(ctx context.Context, productID int) ([]model.ProductImage, error)
+
+	// GetImage returns the image of the product with the given id
+	GetImage(ctx context.Context, productID, id int) (model.ProductImage, error)
+
+	// CreateImage creates a new image of a product
+	CreateImage(ctx context.Context, tx *sql.Tx, image model.ProductImage) (model.ProductImage, error)
+
+	// SetPrimaryImage makes the image the only primary image of the product
+	SetPrimaryImage(ctx context.Context, tx *sql.Tx, productID, id int) (int64, error)
+
+	// SetPositions sets the position of each image of the product to its index in ids
+	SetPositions(ctx context.Context, tx *sql.Tx, productID int, ids []int) error
+
+	// DeleteImage deletes the image of the product
+	DeleteImage(ctx context.Context, tx *sql.Tx, productID, id int) (int64, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IImage {
+	return impl{db: db}
+}
@@ -0,0 +1,15 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO users ("id", "name", "email", "phone", password)
+VALUES (1, 'admin', 'admin@example.com', '0987654321', '123456789');
+
+INSERT INTO products ("id", "title", "price", "quantity", "user_id", "is_active")
+VALUES (1, 'Shirt', 100, 10, 1, true),
+       (2, 'Book', 50, 20, 1, true);
+
+INSERT INTO product_images ("id", "organization_id", "product_id", "storage_key", "content_type", "size", "width", "height", "position", "is_primary") VALUES
+(10, 1, 1, 'products/1/images/a', 'image/jpeg', 2048, 800, 600, 1, true),
+(11, 1, 1, 'products/1/images/b', 'image/png', 1024, 400, 400, 0, false),
+(12, 1, 1, 'products/1/images/c', 'image/gif', 512, 100, 100, 1, false),
+(13, 100, 1, 'products/1/images/d', 'image/png', 256, 50, 50, 0, false);
@@ -0,0 +1,49 @@
+package impersonation
+
+import (
+	"context"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+)
+
+// CreateImpersonation records the start of an impersonation session, the session starts at its created_at
+func (r impl) CreateImpersonation(ctx context.Context, impersonation model.Impersonation) (model.Impersonation, error) {
+	if err := impersonation.Insert(ctx, r.db, boil.Whitelist("actor_id", "subject_id", "token_id", "reason", "expires_at", "created_at")); err != nil {
+		return model.Impersonation{}, err
+	}
+	return impersonation, nil
+}
+
+// EndImpersonation records the end of the impersonation session, the affected rows is 0 if it was already ended
+func (r impl) EndImpersonation(ctx context.Context, tokenID string) (int64, error) {
+	return model.Impersonations(
+		model.ImpersonationWhere.TokenID.EQ(tokenID),
+		model.ImpersonationWhere.EndedAt.IsNull(),
+	).UpdateAll(ctx, r.db, model.M{
+		model.ImpersonationColumns.EndedAt: null.TimeFrom(time.Now()),
+	})
+}
+
+// GetImpersonations returns the impersonation sessions whose impersonated user belongs to the organization of the request
+func (r impl) GetImpersonations(ctx context.Context) ([]model.Impersonation, error) {
+	slice, err := model.Impersonations(
+		qm.InnerJoin(model.TableNames.Users+" on "+model.UserTableColumns.ID+" = "+model.ImpersonationTableColumns.SubjectID),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+		qm.OrderBy(model.ImpersonationTableColumns.CreatedAt+" DESC, "+model.ImpersonationTableColumns.ID+" DESC"),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.Impersonation, 0, len(slice))
+	for _, i := range slice {
+		result = append(result, *i)
+	}
+	return result, nil
+}
@@ -0,0 +1,28 @@
+package impersonation
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) CreateImpersonation(ctx context.Context, impersonation model.Impersonation) (model.Impersonation, error) {
+	args := m.Called(ctx, impersonation)
+	return args.Get(0).(model.Impersonation), args.Error(1)
+}
+
+func (m *Mock) EndImpersonation(ctx context.Context, tokenID string) (int64, error) {
+	args := m.Called(ctx, tokenID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) GetImpersonations(ctx context.Context) ([]model.Impersonation, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.Impersonation), args.Error(1)
+}
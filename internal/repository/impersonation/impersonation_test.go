@@ -0,0 +1,146 @@
+package impersonation
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+const cleanUpQuery = "DELETE FROM impersonations; DELETE FROM users; DELETE FROM organizations WHERE id >= 100;"
+
+func TestImpersonationRepository_CreateImpersonation(t *testing.T) {
+	tcs := map[string]struct {
+		given  model.Impersonation
+		expErr bool
+	}{
+		"success": {
+			given: model.Impersonation{ActorID: 10, SubjectID: 11, TokenID: "jti-4", Reason: "order looks wrong", ExpiresAt: time.Now().Add(15 * time.Minute)},
+		},
+		"token_id_existed": {
+			given:  model.Impersonation{ActorID: 10, SubjectID: 11, TokenID: "jti-1", ExpiresAt: time.Now().Add(15 * time.Minute)},
+			expErr: true,
+		},
+		"subject_not_found": {
+			given:  model.Impersonation{ActorID: 10, SubjectID: 99, TokenID: "jti-4", ExpiresAt: time.Now().Add(15 * time.Minute)},
+			expErr: true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/impersonations.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.CreateImpersonation(context.Background(), tc.given)
+
+			// Then
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, result.ID)
+			require.False(t, result.CreatedAt.IsZero())
+			require.False(t, result.EndedAt.Valid)
+		})
+	}
+}
+
+func TestImpersonationRepository_EndImpersonation(t *testing.T) {
+	tcs := map[string]struct {
+		given       string
+		expAffected int64
+	}{
+		"success": {
+			given:       "jti-2",
+			expAffected: 1,
+		},
+		"already_ended": {
+			given:       "jti-1",
+			expAffected: 0,
+		},
+		"not_found": {
+			given:       "jti-9",
+			expAffected: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/impersonations.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			affected, err := repo.EndImpersonation(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expAffected, affected)
+		})
+	}
+}
+
+func TestImpersonationRepository_GetImpersonations(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx context.Context
+		expIDs   []int
+	}{
+		"default_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			expIDs:   []int{2, 1},
+		},
+		"other_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), 100),
+			expIDs:   []int{3},
+		},
+		"unscoped": {
+			givenCtx: auth.NewUnscopedContext(context.Background()),
+			expIDs:   []int{3, 2, 1},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/impersonations.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetImpersonations(tc.givenCtx)
+
+			// Then
+			require.NoError(t, err)
+			ids := make([]int, 0, len(result))
+			for _, i := range result {
+				ids = append(ids, i.ID)
+			}
+			require.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
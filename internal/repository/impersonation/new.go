@@ -0,0 +1,27 @@
+package impersonation
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type IImpersonation interface {
+	// CreateImpersonation records the start of an impersonation session
+	CreateImpersonation(ctx context.Context, impersonation model.Impersonation) (model.Impersonation, error)
+
+	// EndImpersonation records the end of the impersonation session of the given token id if it is not ended yet
+	EndImpersonation(ctx context.Context, tokenID string) (int64, error)
+
+	// GetImpersonations returns the impersonation sessions of the organization, the latest first
+	GetImpersonations(ctx context.Context) ([]model.Impersonation, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IImpersonation {
+	return impl{db: db}
+}
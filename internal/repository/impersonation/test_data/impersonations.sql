@@ -0,0 +1,12 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "organization_id") VALUES
+(10, 'admin', 'admin@example.com', 'test', 'test', 'ADMIN', true, 1),
+(11, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true, 1),
+(12, 'test2', 'test2@example.com', 'test', 'test', 'GUEST', true, 100);
+
+INSERT INTO "impersonations" ("id", "actor_id", "subject_id", "token_id", "reason", "expires_at", "ended_at", "created_at") VALUES
+(1, 10, 11, 'jti-1', 'order looks wrong', '2022-01-01 10:15:00', '2022-01-01 10:05:00', '2022-01-01 10:00:00'),
+(2, 10, 11, 'jti-2', '', NOW() + INTERVAL '15 minutes', NULL, NOW()),
+(3, 10, 12, 'jti-3', '', NOW() + INTERVAL '15 minutes', NULL, NOW());
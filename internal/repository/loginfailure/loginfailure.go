@@ -0,0 +1,70 @@
+package loginfailure
+
+import (
+	"context"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+// storedKey returns the key as it is stored, the emails of ScopeAccount are stored as their blind index
+func (r impl) storedKey(scope, key string) string {
+	if scope == ScopeAccount {
+		return r.keys.BlindIndex(key)
+	}
+	return key
+}
+
+// GetLoginFailure returns the failed logins of the given scope and key
+func (r impl) GetLoginFailure(ctx context.Context, scope, key string) (model.LoginFailure, error) {
+	result, err := model.LoginFailures(
+		model.LoginFailureWhere.Scope.EQ(scope),
+		model.LoginFailureWhere.Key.EQ(r.storedKey(scope, key)),
+	).One(ctx, r.db)
+	if err != nil {
+		return model.LoginFailure{}, err
+	}
+	return *result, nil
+}
+
+// RecordLoginFailure increases the failed attempts in one statement, so concurrent failures are all counted
+func (r impl) RecordLoginFailure(ctx context.Context, scope, key string, window time.Duration) (model.LoginFailure, error) {
+	queryStr := `INSERT INTO login_failures (scope, key, failed_attempts, last_failed_at, created_at, updated_at)
+		VALUES ($1, $2, 1, NOW(), NOW(), NOW())
+		ON CONFLICT (scope, key) DO UPDATE SET
+			failed_attempts = CASE
+				WHEN login_failures.last_failed_at < NOW() - make_interval(secs => $3) THEN 1
+				ELSE login_failures.failed_attempts + 1
+			END,
+			last_failed_at = NOW(),
+			updated_at = NOW()
+		RETURNING *`
+
+	var result model.LoginFailure
+	if err := queries.Raw(queryStr, scope, r.storedKey(scope, key), window.Seconds()).Bind(ctx, r.db, &result); err != nil {
+		return model.LoginFailure{}, err
+	}
+	return result, nil
+}
+
+// LockLoginFailure rejects logins of the given scope and key until the given time
+func (r impl) LockLoginFailure(ctx context.Context, scope, key string, until time.Time) (int64, error) {
+	return model.LoginFailures(
+		model.LoginFailureWhere.Scope.EQ(scope),
+		model.LoginFailureWhere.Key.EQ(r.storedKey(scope, key)),
+	).UpdateAll(ctx, r.db, model.M{
+		model.LoginFailureColumns.LockedUntil: null.TimeFrom(until),
+		model.LoginFailureColumns.UpdatedAt:   time.Now(),
+	})
+}
+
+// DeleteLoginFailure clears the failed logins of the given scope and key
+func (r impl) DeleteLoginFailure(ctx context.Context, scope, key string) (int64, error) {
+	return model.LoginFailures(
+		model.LoginFailureWhere.Scope.EQ(scope),
+		model.LoginFailureWhere.Key.EQ(r.storedKey(scope, key)),
+	).DeleteAll(ctx, r.db)
+}
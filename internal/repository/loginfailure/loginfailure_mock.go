@@ -0,0 +1,34 @@
+package loginfailure
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetLoginFailure(ctx context.Context, scope, key string) (model.LoginFailure, error) {
+	args := m.Called(ctx, scope, key)
+	return args.Get(0).(model.LoginFailure), args.Error(1)
+}
+
+func (m *Mock) RecordLoginFailure(ctx context.Context, scope, key string, window time.Duration) (model.LoginFailure, error) {
+	args := m.Called(ctx, scope, key, window)
+	return args.Get(0).(model.LoginFailure), args.Error(1)
+}
+
+func (m *Mock) LockLoginFailure(ctx context.Context, scope, key string, until time.Time) (int64, error) {
+	args := m.Called(ctx, scope, key, until)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) DeleteLoginFailure(ctx context.Context, scope, key string) (int64, error) {
+	args := m.Called(ctx, scope, key)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,178 @@
+package loginfailure
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption/encryptiontest"
+)
+
+func TestLoginFailureRepository_GetLoginFailure(t *testing.T) {
+	type givenData struct {
+		scope string
+		key   string
+	}
+	tcs := map[string]struct {
+		given       givenData
+		expAttempts int
+		expLocked   bool
+		expErr      error
+	}{
+		"success": {
+			given:       givenData{scope: ScopeIP, key: "192.0.2.1"},
+			expAttempts: 20,
+			expLocked:   true,
+		},
+		"not_found_other_scope": {
+			given:  givenData{scope: ScopeAccount, key: "192.0.2.1"},
+			expErr: sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/login_failures.sql")
+			defer dbTest.Exec("DELETE FROM login_failures;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.GetLoginFailure(context.Background(), tc.given.scope, tc.given.key)
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expAttempts, result.FailedAttempts)
+				require.Equal(t, tc.expLocked, result.LockedUntil.Valid)
+			}
+		})
+	}
+}
+
+func TestLoginFailureRepository_RecordLoginFailure(t *testing.T) {
+	tcs := map[string]struct {
+		given       string
+		expAttempts int
+	}{
+		"first_failure": {
+			given:       "test3@example.com",
+			expAttempts: 1,
+		},
+		"failure_within_window": {
+			given:       "test1@example.com",
+			expAttempts: 4,
+		},
+		"failure_after_window": {
+			given:       "test2@example.com",
+			expAttempts: 1,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/login_failures.sql")
+			defer dbTest.Exec("DELETE FROM login_failures;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.RecordLoginFailure(context.Background(), ScopeAccount, tc.given, 15*time.Minute)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, ScopeAccount, result.Scope)
+			// The email is stored as its blind index
+			require.Equal(t, encryptiontest.KeyRing().BlindIndex(tc.given), result.Key)
+			require.Equal(t, tc.expAttempts, result.FailedAttempts)
+		})
+	}
+}
+
+func TestLoginFailureRepository_LockLoginFailure(t *testing.T) {
+	tcs := map[string]struct {
+		given   string
+		rowsAff int64
+	}{
+		"success": {
+			given:   "test1@example.com",
+			rowsAff: 1,
+		},
+		"not_found": {
+			given:   "test3@example.com",
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/login_failures.sql")
+			defer dbTest.Exec("DELETE FROM login_failures;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.LockLoginFailure(context.Background(), ScopeAccount, tc.given, time.Now().Add(time.Minute))
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
+
+func TestLoginFailureRepository_DeleteLoginFailure(t *testing.T) {
+	tcs := map[string]struct {
+		given   string
+		rowsAff int64
+	}{
+		"success": {
+			given:   "test1@example.com",
+			rowsAff: 1,
+		},
+		"not_found": {
+			given:   "test3@example.com",
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/login_failures.sql")
+			defer dbTest.Exec("DELETE FROM login_failures;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.DeleteLoginFailure(context.Background(), ScopeAccount, tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package loginfailure
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+)
+
+const (
+	// ScopeAccount tracks failed logins of an email
+	ScopeAccount = "ACCOUNT"
+	// ScopeIP tracks failed logins of an IP address
+	ScopeIP = "IP"
+)
+
+type ILoginFailure interface {
+	// GetLoginFailure returns the failed logins of the given scope and key
+	GetLoginFailure(ctx context.Context, scope, key string) (model.LoginFailure, error)
+
+	// RecordLoginFailure increases the failed attempts of the given scope and key,
+	// the counting restarts if the last failure is older than the given window
+	RecordLoginFailure(ctx context.Context, scope, key string, window time.Duration) (model.LoginFailure, error)
+
+	// LockLoginFailure rejects logins of the given scope and key until the given time
+	LockLoginFailure(ctx context.Context, scope, key string, until time.Time) (int64, error)
+
+	// DeleteLoginFailure clears the failed logins of the given scope and key
+	DeleteLoginFailure(ctx context.Context, scope, key string) (int64, error)
+}
+
+type impl struct {
+	db   *sql.DB
+	keys *encryption.KeyRing
+}
+
+// New returns the login failure repository, the emails of ScopeAccount are stored as their blind index by the keys
+func New(db *sql.DB, keys *encryption.KeyRing) ILoginFailure {
+	return impl{db: db, keys: keys}
+}
@@ -0,0 +1,5 @@
+-- The keys of ACCOUNT are the blind indexes of test1@example.com and test2@example.com by the keys of encryptiontest
+INSERT INTO "login_failures" ("id", "scope", "key", "failed_attempts", "last_failed_at", "locked_until") VALUES
+(1, 'ACCOUNT', 'a6c883803dc2d2c02aef8ef869b97064f8c4c389c27c8d22789fac287e43a469', 3, NOW(), NULL),
+(2, 'ACCOUNT', '4ab91f23b5685074126863054177de34c5a1149e18c5fc2deb26de5ce5f7472b', 5, NOW() - INTERVAL '1 hour', NOW() - INTERVAL '1 hour'),
+(3, 'IP', '192.0.2.1', 20, NOW(), NOW() + INTERVAL '15 minutes');
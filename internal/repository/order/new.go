@@ -14,6 +14,12 @@ type IOrder interface {
 	// CreateItem create new order item
 	CreateItem(ctx context.Context, tx *sql.Tx, item model.OrderItem) error
 
+	// CreateOrderAddress create the shipping or billing address snapshot of an order
+	CreateOrderAddress(ctx context.Context, tx *sql.Tx, address model.OrderAddress) error
+
+	// AnonymizeOrders clears the personal data of the orders of the user and keeps the orders themselves
+	AnonymizeOrders(ctx context.Context, tx *sql.Tx, userID int) error
+
 	// GetStatistics returns summary statistic of orders.
 	GetStatistics(ctx context.Context) ([]Statistics, error)
 
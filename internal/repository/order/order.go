@@ -5,21 +5,64 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
 )
 
 func (r impl) CreateOrder(ctx context.Context, tx *sql.Tx, order model.Order) (model.Order, error) {
-	if err := order.Insert(context.Background(), tx, boil.Infer()); err != nil {
+	order.OrganizationID = tenant.ID(ctx)
+	if err := order.Insert(ctx, tx, boil.Infer()); err != nil {
 		return model.Order{}, err
 	}
 	return order, nil
 }
 
 func (r impl) CreateItem(ctx context.Context, tx *sql.Tx, item model.OrderItem) error {
-	return item.Insert(context.Background(), tx, boil.Infer())
+	return item.Insert(ctx, tx, boil.Infer())
+}
+
+// Types of the addresses attached to an order
+const (
+	AddressTypeShipping = "SHIPPING"
+	AddressTypeBilling  = "BILLING"
+)
+
+func (r impl) CreateOrderAddress(ctx context.Context, tx *sql.Tx, address model.OrderAddress) error {
+	return address.Insert(ctx, tx, boil.Infer())
+}
+
+// AnonymizeOrders clears the notes and the recipients of the orders of the user.
+// The orders, their items and prices are kept for accounting, the city and the country of the addresses are kept for tax reports.
+func (r impl) AnonymizeOrders(ctx context.Context, tx *sql.Tx, userID int) error {
+	now := time.Now()
+	if _, err := model.Orders(model.OrderWhere.UserID.EQ(userID)).UpdateAll(ctx, tx, model.M{
+		model.OrderColumns.Note:      "",
+		model.OrderColumns.UpdatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	ordersOfUser := "(SELECT " + model.OrderColumns.ID + " FROM " + model.TableNames.Orders + " WHERE " + model.OrderColumns.UserID + " = ?)"
+	if _, err := model.OrderItems(qm.Where(model.OrderItemColumns.OrderID+" IN "+ordersOfUser, userID)).UpdateAll(ctx, tx, model.M{
+		model.OrderItemColumns.Note:      "",
+		model.OrderItemColumns.UpdatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	_, err := model.OrderAddresses(qm.Where(model.OrderAddressColumns.OrderID+" IN "+ordersOfUser, userID)).UpdateAll(ctx, tx, model.M{
+		model.OrderAddressColumns.Name:       "",
+		model.OrderAddressColumns.Phone:      "",
+		model.OrderAddressColumns.Line1:      "",
+		model.OrderAddressColumns.Line2:      "",
+		model.OrderAddressColumns.PostalCode: "",
+		model.OrderAddressColumns.UpdatedAt:  now,
+	})
+	return err
 }
 
 type Statistics struct {
@@ -31,6 +74,7 @@ func (r impl) GetStatistics(ctx context.Context) ([]Statistics, error) {
 	qms := []qm.QueryMod{
 		qm.Select("count(*) as count", model.OrderColumns.Status),
 		qm.From("orders"),
+		tenant.Where(ctx, model.OrderTableColumns.OrganizationID),
 		qm.GroupBy(model.OrderColumns.Status),
 	}
 
@@ -63,12 +107,13 @@ func (r impl) GetLatestOrder(ctx context.Context, limit int) ([]OrderInfo, error
 		),
 		qm.From("orders o"),
 		qm.InnerJoin("order_items oi on oi.order_id = o.id"),
+		tenant.Where(ctx, "o."+model.OrderColumns.OrganizationID),
 		qm.GroupBy("o.id, oi.discount"),
 		qm.Limit(limit),
 	}
 
 	var result []OrderInfo
-	if err := model.NewQuery(qms...).Bind(context.Background(), r.db, &result); err != nil {
+	if err := model.NewQuery(qms...).Bind(ctx, r.db, &result); err != nil {
 		return nil, err
 	}
 
@@ -106,6 +151,8 @@ type OrdersInput struct {
 type OrderItem struct {
 	ID           int
 	ProductID    int
+	VariantID    null.Int
+	VariantSKU   string
 	ProductPrice float64
 	ProductName  string
 	Quantity     int
@@ -115,23 +162,39 @@ type OrderItem struct {
 	UpdatedAt    time.Time
 }
 
+// OrderAddress represents the address snapshot of an order
+type OrderAddress struct {
+	Name       string
+	Phone      string
+	Line1      string
+	Line2      string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
 // Order represents order for order list which will be returned
 type Order struct {
-	ID          int
-	OrderNumber string
-	OrderDate   time.Time
-	Status      string
-	Note        string
-	UserID      int
-	OrderItems  []OrderItem
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              int
+	OrderNumber     string
+	OrderDate       time.Time
+	Status          string
+	Note            string
+	UserID          int
+	OrderItems      []OrderItem
+	ShippingAddress *OrderAddress
+	BillingAddress  *OrderAddress
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 // GetOrders returns order list from database
 func (r impl) GetOrders(ctx context.Context, input OrdersInput) ([]Order, int64, error) {
 	var qms = []qm.QueryMod{
 		qm.Load(model.OrderRels.OrderItems),
+		qm.Load(model.OrderRels.OrderAddresses),
+		tenant.Where(ctx, model.OrderTableColumns.OrganizationID),
 	}
 
 	// Add filter condition.
@@ -148,7 +211,7 @@ func (r impl) GetOrders(ctx context.Context, input OrdersInput) ([]Order, int64,
 		qms = append(qms, model.OrderWhere.Status.EQ(input.Filter.Status))
 	}
 
-	totalCount, err := model.Orders(qms...).Count(context.Background(), r.db)
+	totalCount, err := model.Orders(qms...).Count(ctx, r.db)
 	if err != nil {
 		return []Order{}, 0, err
 	}
@@ -173,7 +236,7 @@ func (r impl) GetOrders(ctx context.Context, input OrdersInput) ([]Order, int64,
 			qm.Limit(input.Pagination.Limit))
 	}
 
-	orders, err := model.Orders(qms...).All(context.Background(), r.db)
+	orders, err := model.Orders(qms...).All(ctx, r.db)
 	if err != nil {
 		return []Order{}, 0, nil
 	}
@@ -187,6 +250,8 @@ func (r impl) GetOrders(ctx context.Context, input OrdersInput) ([]Order, int64,
 			orderItems[j] = OrderItem{
 				ID:           orderItem.ID,
 				ProductID:    orderItem.ProductID,
+				VariantID:    orderItem.VariantID,
+				VariantSKU:   orderItem.VariantSku,
 				ProductPrice: orderItem.ProductPrice,
 				ProductName:  orderItem.ProductName,
 				Quantity:     orderItem.Quantity,
@@ -208,6 +273,24 @@ func (r impl) GetOrders(ctx context.Context, input OrdersInput) ([]Order, int64,
 			CreatedAt:   order.CreatedAt,
 			UpdatedAt:   order.UpdatedAt,
 		}
+		for _, address := range order.R.OrderAddresses {
+			orderAddress := &OrderAddress{
+				Name:       address.Name,
+				Phone:      address.Phone,
+				Line1:      address.Line1,
+				Line2:      address.Line2,
+				City:       address.City,
+				State:      address.State,
+				PostalCode: address.PostalCode,
+				Country:    address.Country,
+			}
+			switch address.Type {
+			case AddressTypeShipping:
+				result[i].ShippingAddress = orderAddress
+			case AddressTypeBilling:
+				result[i].BillingAddress = orderAddress
+			}
+		}
 	}
 	return result, totalCount, nil
 }
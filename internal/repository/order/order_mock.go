@@ -23,6 +23,16 @@ func (m *Mock) CreateItem(ctx context.Context, tx *sql.Tx, item model.OrderItem)
 	return args.Error(0)
 }
 
+func (m *Mock) CreateOrderAddress(ctx context.Context, tx *sql.Tx, address model.OrderAddress) error {
+	args := m.Called(ctx, tx, address)
+	return args.Error(0)
+}
+
+func (m *Mock) AnonymizeOrders(ctx context.Context, tx *sql.Tx, userID int) error {
+	args := m.Called(ctx, tx, userID)
+	return args.Error(0)
+}
+
 func (m *Mock) GetStatistics(ctx context.Context) ([]Statistics, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]Statistics), args.Error(1)
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
 )
 
@@ -29,11 +30,12 @@ func TestOrderRepository_CreateOrder(t *testing.T) {
 				Note:        "Order 1",
 			},
 			expResult: model.Order{
-				OrderNumber: "123456789",
-				OrderDate:   time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC),
-				Status:      "NEW",
-				UserID:      10,
-				Note:        "Order 1",
+				OrderNumber:    "123456789",
+				OrderDate:      time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC),
+				Status:         "NEW",
+				UserID:         10,
+				Note:           "Order 1",
+				OrganizationID: auth.DefaultOrganizationID,
 			},
 		},
 		"error": {
@@ -139,12 +141,145 @@ func TestOrderRepository_CreateItem(t *testing.T) {
 	}
 }
 
+func TestOrderRepository_CreateOrderAddress(t *testing.T) {
+	tcs := map[string]struct {
+		input  model.OrderAddress
+		expErr bool
+	}{
+		"success": {
+			input: model.OrderAddress{
+				OrderID:    10,
+				Type:       AddressTypeShipping,
+				Name:       "test1",
+				Phone:      "0987654321",
+				Line1:      "1 Le Loi",
+				City:       "Ho Chi Minh City",
+				PostalCode: "70000",
+				Country:    "VN",
+			},
+		},
+		"order_not_found": {
+			input: model.OrderAddress{
+				OrderID: 99,
+				Type:    AddressTypeBilling,
+				Name:    "test1",
+				Phone:   "0987654321",
+				Line1:   "1 Le Loi",
+				City:    "Ho Chi Minh City",
+				Country: "VN",
+			},
+			expErr: true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, err := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, err)
+			defer dbTest.Close()
+
+			orderRepo := New(dbTest)
+			db.LoadSqlTestFile(t, dbTest, "test_data/order_item.sql")
+			defer dbTest.Exec(`DELETE FROM order_addresses; DELETE FROM order_items;DELETE FROM products; DELETE FROM orders; DELETE FROM users;`)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			// When
+			err = orderRepo.CreateOrderAddress(context.Background(), txTest, tc.input)
+
+			// Then
+			if tc.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrderRepository_AnonymizeOrders(t *testing.T) {
+	tcs := map[string]struct {
+		givenUserID    int
+		expOrders      int64
+		expAddressName string
+	}{
+		"success": {
+			givenUserID:    10,
+			expOrders:      2,
+			expAddressName: "",
+		},
+		"other_user": {
+			givenUserID:    11,
+			expOrders:      3,
+			expAddressName: "test1",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, err := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, err)
+			defer dbTest.Close()
+
+			orderRepo := New(dbTest)
+			db.LoadSqlTestFile(t, dbTest, "test_data/get_orders.sql")
+			defer dbTest.Exec(`DELETE FROM order_addresses; DELETE FROM order_items;DELETE FROM products; DELETE FROM orders; DELETE FROM users;`)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			// When
+			err = orderRepo.AnonymizeOrders(context.Background(), txTest, tc.givenUserID)
+
+			// Then
+			require.NoError(t, err)
+			orders, err := model.Orders(model.OrderWhere.UserID.EQ(tc.givenUserID)).Count(context.Background(), txTest)
+			require.NoError(t, err)
+			require.Equal(t, tc.expOrders, orders)
+
+			// The addresses of order 1 belong to user 10, their city and country are kept
+			address, err := model.FindOrderAddress(context.Background(), txTest, 10)
+			require.NoError(t, err)
+			require.Equal(t, tc.expAddressName, address.Name)
+			require.Equal(t, "Ho Chi Minh City", address.City)
+			require.Equal(t, "VN", address.Country)
+		})
+	}
+}
+
 func TestOrderRepository_GetStatistics(t *testing.T) {
 	tcs := map[string]struct {
+		givenCtx  context.Context
 		expResult []Statistics
 		expErr    error
 	}{
-		"success": {
+		"success_unscoped": {
+			givenCtx: auth.NewUnscopedContext(context.Background()),
+			expResult: []Statistics{
+				{
+					Status: "FAILED",
+					Count:  1,
+				},
+				{
+					Status: "SUCCESS",
+					Count:  2,
+				},
+				{
+					Status: "PENDING",
+					Count:  1,
+				}, {
+					Status: "NEW",
+					Count:  3,
+				},
+			},
+		},
+		"success_default_organization": {
+			givenCtx: auth.NewContext(context.Background(), auth.User{ID: 10, OrganizationID: auth.DefaultOrganizationID}),
 			expResult: []Statistics{
 				{
 					Status: "FAILED",
@@ -163,6 +298,15 @@ func TestOrderRepository_GetStatistics(t *testing.T) {
 				},
 			},
 		},
+		"success_other_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), 100),
+			expResult: []Statistics{
+				{
+					Status: "NEW",
+					Count:  1,
+				},
+			},
+		},
 	}
 
 	for desc, tc := range tcs {
@@ -174,10 +318,11 @@ func TestOrderRepository_GetStatistics(t *testing.T) {
 
 			orderRepo := New(dbTest)
 			db.LoadSqlTestFile(t, dbTest, "test_data/order.sql")
-			defer dbTest.Exec("DELETE FROM orders; DELETE FROM users;")
+			db.LoadSqlTestFile(t, dbTest, "test_data/organization_orders.sql")
+			defer dbTest.Exec("DELETE FROM orders; DELETE FROM users; DELETE FROM organizations WHERE id = 100;")
 
 			// When
-			result, err := orderRepo.GetStatistics(context.Background())
+			result, err := orderRepo.GetStatistics(tc.givenCtx)
 
 			// Then
 			if tc.expErr != nil {
@@ -288,6 +433,22 @@ func TestOderRepository_GetOrders(t *testing.T) {
 								Discount:     0,
 							},
 						},
+						ShippingAddress: &OrderAddress{
+							Name:       "test1",
+							Phone:      "0987654321",
+							Line1:      "1 Le Loi",
+							City:       "Ho Chi Minh City",
+							PostalCode: "70000",
+							Country:    "VN",
+						},
+						BillingAddress: &OrderAddress{
+							Name:       "test1",
+							Phone:      "0987654321",
+							Line1:      "3 Hang Bai",
+							City:       "Ha Noi",
+							PostalCode: "10000",
+							Country:    "VN",
+						},
 					},
 					{
 						ID:          5,
@@ -328,7 +489,7 @@ func TestOderRepository_GetOrders(t *testing.T) {
 
 			orderRepo := New(dbTest)
 			db.LoadSqlTestFile(t, dbTest, "test_data/get_orders.sql")
-			defer dbTest.Exec(`DELETE FROM order_items;DELETE FROM products; DELETE FROM orders; DELETE FROM users;`)
+			defer dbTest.Exec(`DELETE FROM order_addresses; DELETE FROM order_items;DELETE FROM products; DELETE FROM orders; DELETE FROM users;`)
 
 			// When
 			result, totalCount, err := orderRepo.GetOrders(tc.input.ctx, tc.input.ordersInput)
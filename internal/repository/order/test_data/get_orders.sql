@@ -18,3 +18,7 @@ VALUES (10, 1, 10, 1000, 'Product 10', 20, 0, ''),
 (11, 1, 11, 1000, 'Product 11', 30, 0, ''),
 (12, 2, 11, 1000, 'Product 11', 40, 0, ''),
 (13, 2, 10, 1000, 'Product 10', 50, 0, '');
+
+INSERT INTO "order_addresses" ("id", "order_id", "type", "name", "phone", "line1", "city", "postal_code", "country")
+VALUES (10, 1, 'SHIPPING', 'test1', '0987654321', '1 Le Loi', 'Ho Chi Minh City', '70000', 'VN'),
+(11, 1, 'BILLING', 'test1', '0987654321', '3 Hang Bai', 'Ha Noi', '10000', 'VN');
@@ -0,0 +1,5 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO "orders" ("id", "order_number", "user_id", "status", "organization_id")
+VALUES (16, 'GGG', 10, 'NEW', 100);
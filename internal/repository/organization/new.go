@@ -0,0 +1,30 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type IOrganization interface {
+	// GetOrganizations returns all organizations
+	GetOrganizations(ctx context.Context) (model.OrganizationSlice, error)
+
+	// GetOrganization returns the organization with the given id
+	GetOrganization(ctx context.Context, id int) (model.Organization, error)
+
+	// ExistsOrganizationByName returns true if the organization exists
+	ExistsOrganizationByName(ctx context.Context, name string) (bool, error)
+
+	// CreateOrganization creates a new organization
+	CreateOrganization(ctx context.Context, tx *sql.Tx, organization model.Organization) (model.Organization, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IOrganization {
+	return impl{db: db}
+}
@@ -0,0 +1,38 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+// GetOrganizations returns all organizations ordered by name
+func (r impl) GetOrganizations(ctx context.Context) (model.OrganizationSlice, error) {
+	return model.Organizations(qm.OrderBy(model.OrganizationColumns.Name)).All(ctx, r.db)
+}
+
+// GetOrganization returns the organization with the given id
+func (r impl) GetOrganization(ctx context.Context, id int) (model.Organization, error) {
+	result, err := model.Organizations(model.OrganizationWhere.ID.EQ(id)).One(ctx, r.db)
+	if err != nil {
+		return model.Organization{}, err
+	}
+	return *result, nil
+}
+
+// ExistsOrganizationByName checks if an organization exists by name
+func (r impl) ExistsOrganizationByName(ctx context.Context, name string) (bool, error) {
+	return model.Organizations(model.OrganizationWhere.Name.EQ(name)).Exists(ctx, r.db)
+}
+
+// CreateOrganization creates a new organization
+func (r impl) CreateOrganization(ctx context.Context, tx *sql.Tx, organization model.Organization) (model.Organization, error) {
+	if err := organization.Insert(ctx, tx, boil.Whitelist("name", "created_at", "updated_at")); err != nil {
+		return model.Organization{}, err
+	}
+	return organization, nil
+}
@@ -0,0 +1,34 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetOrganizations(ctx context.Context) (model.OrganizationSlice, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(model.OrganizationSlice), args.Error(1)
+}
+
+func (m *Mock) GetOrganization(ctx context.Context, id int) (model.Organization, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.Organization), args.Error(1)
+}
+
+func (m *Mock) ExistsOrganizationByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Mock) CreateOrganization(ctx context.Context, tx *sql.Tx, organization model.Organization) (model.Organization, error) {
+	args := m.Called(ctx, tx, organization)
+	return args.Get(0).(model.Organization), args.Error(1)
+}
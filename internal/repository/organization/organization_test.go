@@ -0,0 +1,83 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+// The default organization is created by the migration and kept
+const cleanUpQuery = "DELETE FROM organizations WHERE id >= 100 OR name = 'Shop C';"
+
+func TestOrganizationRepository_GetOrganization(t *testing.T) {
+	tcs := map[string]struct {
+		given  int
+		exp    string
+		expErr error
+	}{
+		"success": {
+			given: 100,
+			exp:   "Shop A",
+		},
+		"not_found": {
+			given:  102,
+			expErr: sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/organizations.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetOrganization(context.Background(), tc.given)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, result.Name)
+		})
+	}
+}
+
+func TestOrganizationRepository_CreateOrganization(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/organizations.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest)
+
+	// When
+	tx, err := dbTest.Begin()
+	require.NoError(t, err)
+	result, err := repo.CreateOrganization(context.Background(), tx, model.Organization{Name: "Shop C"})
+
+	// Then
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	require.NotZero(t, result.ID)
+
+	existed, err := repo.ExistsOrganizationByName(context.Background(), "Shop C")
+	require.NoError(t, err)
+	require.True(t, existed)
+}
@@ -0,0 +1,3 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A'),
+(101, 'Shop B');
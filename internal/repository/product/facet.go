@@ -0,0 +1,126 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+const (
+	facetPrice    = "price"
+	facetUser     = "user"
+	facetIsActive = "is_active"
+	facetCategory = "category"
+)
+
+// Facets are the numbers of the filtered products by price bucket, seller, status and category
+type Facets struct {
+	PriceCounts []int64 // PriceCounts[i] is the number of products with a price from bounds[i] to bounds[i+1], the last bucket has no upper bound
+	Users       []UserCount
+	Active      int64
+	Inactive    int64
+	Categories  []CategoryCount
+}
+
+type UserCount struct {
+	UserID       int
+	Name         string
+	ProductCount int64
+}
+
+type CategoryCount struct {
+	CategoryID   int
+	Name         string
+	Slug         string
+	ProductCount int64
+}
+
+// facetRow is a row of the union of the aggregations, the columns which a facet does not use are empty
+type facetRow struct {
+	Facet        string `boil:"facet"`
+	Key          int    `boil:"key"`
+	Name         string `boil:"name"`
+	Slug         string `boil:"slug"`
+	Path         string `boil:"path"`
+	ProductCount int64  `boil:"product_count"`
+}
+
+// GetFacets returns the facets of the filtered products in one query, the filtered products are a CTE shared by the aggregations.
+// priceBounds are the ascending lower bounds of the price buckets, the products cheaper than the first bound are not counted.
+// A product of a category is also counted in the ancestors of the category, the categories and the sellers without products are skipped.
+func (r impl) GetFacets(ctx context.Context, filter Filter, priceBounds []float64) (Facets, error) {
+	// 1. Build the filtered products query, its placeholders are numbered before the placeholder of the price bounds
+	filtered, args := queries.BuildQuery(model.Products(append(filterMods(ctx, filter),
+		qm.Select("products.id", "products.price", "products.user_id", "products.is_active"))...).Query)
+	filtered = strings.TrimSuffix(filtered, ";")
+	args = append(args, pq.Array(priceBounds))
+
+	// 2. Aggregate each facet
+	var rows []facetRow
+	if err := queries.Raw(fmt.Sprintf(`
+		WITH filtered AS (%s)
+		SELECT '%s' AS facet, width_bucket(f.price, $%d::float8[]) AS key, '' AS name, '' AS slug, '' AS path, COUNT(*) AS product_count
+		FROM filtered f GROUP BY 2
+		UNION ALL
+		SELECT '%s', u.id, u.name, '', '', COUNT(*)
+		FROM filtered f JOIN users u ON u.id = f.user_id GROUP BY u.id
+		UNION ALL
+		SELECT '%s', f.is_active::int, '', '', '', COUNT(*)
+		FROM filtered f GROUP BY f.is_active
+		UNION ALL
+		SELECT '%s', c.id, c.name, c.slug, c.path, COUNT(DISTINCT f.id)
+		FROM filtered f
+		JOIN product_categories pc ON pc.product_id = f.id
+		JOIN categories d ON d.id = pc.category_id
+		JOIN categories c ON d.path LIKE c.path || '%%'
+		GROUP BY c.id`,
+		filtered, facetPrice, len(args), facetUser, facetIsActive, facetCategory,
+	), args...).Bind(ctx, r.db, &rows); err != nil {
+		return Facets{}, err
+	}
+
+	// 3. Split the rows by facet
+	result := Facets{PriceCounts: make([]int64, len(priceBounds)), Users: []UserCount{}, Categories: []CategoryCount{}}
+	paths := map[int]string{}
+	for _, row := range rows {
+		switch row.Facet {
+		case facetPrice:
+			// width_bucket returns 0 for the prices below the first bound
+			if row.Key > 0 {
+				result.PriceCounts[row.Key-1] = row.ProductCount
+			}
+		case facetUser:
+			result.Users = append(result.Users, UserCount{UserID: row.Key, Name: row.Name, ProductCount: row.ProductCount})
+		case facetIsActive:
+			if row.Key == 1 {
+				result.Active = row.ProductCount
+			} else {
+				result.Inactive = row.ProductCount
+			}
+		case facetCategory:
+			paths[row.Key] = row.Path
+			result.Categories = append(result.Categories, CategoryCount{
+				CategoryID: row.Key, Name: row.Name, Slug: row.Slug, ProductCount: row.ProductCount,
+			})
+		}
+	}
+
+	// 4. The sellers with the most products first, the categories in the order of the tree
+	sort.Slice(result.Users, func(i, j int) bool {
+		if result.Users[i].ProductCount != result.Users[j].ProductCount {
+			return result.Users[i].ProductCount > result.Users[j].ProductCount
+		}
+		return result.Users[i].UserID < result.Users[j].UserID
+	})
+	sort.Slice(result.Categories, func(i, j int) bool {
+		return paths[result.Categories[i].CategoryID] < paths[result.Categories[j].CategoryID]
+	})
+	return result, nil
+}
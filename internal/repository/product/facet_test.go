@@ -0,0 +1,82 @@
+package product
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption/encryptiontest"
+)
+
+func TestProductRepo_GetFacets(t *testing.T) {
+	tcs := map[string]struct {
+		filter      Filter
+		priceBounds []float64
+		expFacets   Facets
+	}{
+		"all_products": {
+			priceBounds: []float64{10, 50, 150},
+			expFacets: Facets{
+				// the product of price 5 is below the first bound
+				PriceCounts: []int64{2, 1, 1},
+				Users: []UserCount{
+					{UserID: 2, Name: "admin 2", ProductCount: 3},
+					{UserID: 1, Name: "admin", ProductCount: 2},
+				},
+				Active:   3,
+				Inactive: 2,
+				Categories: []CategoryCount{
+					{CategoryID: 10, Name: "Electronics", Slug: "electronics", ProductCount: 3},
+					{CategoryID: 11, Name: "Phones", Slug: "phones", ProductCount: 2},
+					{CategoryID: 13, Name: "Books", Slug: "books", ProductCount: 1},
+				},
+			},
+		},
+		"filtered_products": {
+			filter:      Filter{IsActive: null.NewBool(false, true)},
+			priceBounds: []float64{0, 50, 150},
+			expFacets: Facets{
+				PriceCounts: []int64{1, 0, 1},
+				Users: []UserCount{
+					{UserID: 1, Name: "admin", ProductCount: 1},
+					{UserID: 2, Name: "admin 2", ProductCount: 1},
+				},
+				Inactive: 2,
+				Categories: []CategoryCount{
+					{CategoryID: 13, Name: "Books", Slug: "books", ProductCount: 1},
+				},
+			},
+		},
+		"no_products": {
+			filter:      Filter{Query: "xylophone"},
+			priceBounds: []float64{0, 50},
+			expFacets: Facets{
+				PriceCounts: []int64{0, 0},
+				Users:       []UserCount{},
+				Categories:  []CategoryCount{},
+			},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			dbConn, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+			db.LoadSqlTestFile(t, dbConn, "test_data/get_facets.sql")
+			defer dbConn.Exec("DELETE FROM product_categories; DELETE FROM categories; DELETE FROM products; DELETE FROM users;")
+			productRepo := New(dbConn, encryptiontest.KeyRing())
+
+			// WHEN
+			result, err := productRepo.GetFacets(context.Background(), tc.filter, tc.priceBounds)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.expFacets, result)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
 )
 
 type IProduct interface {
@@ -29,14 +30,19 @@ type IProduct interface {
 	// GetProducts returns list of products (filtered by filter obj)
 	GetProducts(ctx context.Context, filter Filter) ([]ProductItem, int64, error)
 
+	// GetFacets returns the number of products of each price bucket, seller, status and category (filtered by filter obj)
+	GetFacets(ctx context.Context, filter Filter, priceBounds []float64) (Facets, error)
+
 	// GetStatistics returns summary statistic of products
 	GetStatistics(ctx context.Context) (SummaryStatistics, error)
 }
 
 type impl struct {
-	db *sql.DB
+	db   *sql.DB
+	keys *encryption.KeyRing
 }
 
-func New(db *sql.DB) IProduct {
-	return &impl{db: db}
+// New returns the product repository, the keys decrypt the contacts of the creators
+func New(db *sql.DB, keys *encryption.KeyRing) IProduct {
+	return &impl{db: db, keys: keys}
 }
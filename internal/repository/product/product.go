@@ -14,10 +14,12 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
 )
 
 func (r impl) GetProduct(ctx context.Context, id int) (model.Product, error) {
-	product, err := model.Products(qm.Where("id=?", id)).One(context.Background(), r.db)
+	product, err := model.Products(qm.Where("id=?", id), tenant.Where(ctx, model.ProductTableColumns.OrganizationID)).One(ctx, r.db)
 	if product == nil {
 		return model.Product{}, err
 	}
@@ -25,19 +27,32 @@ func (r impl) GetProduct(ctx context.Context, id int) (model.Product, error) {
 }
 
 func (r impl) ExistsProductByID(ctx context.Context, id int) (bool, error) {
-	return model.Products(model.ProductWhere.ID.EQ(id)).Exists(context.Background(), r.db)
+	return model.Products(model.ProductWhere.ID.EQ(id), tenant.Where(ctx, model.ProductTableColumns.OrganizationID)).Exists(ctx, r.db)
 }
 
 func (r impl) CreateProduct(ctx context.Context, newProduct model.Product) (model.Product, error) {
-	err := newProduct.Insert(context.Background(), r.db, boil.Whitelist("title", "description", "price", "quantity", "is_active", "user_id", "created_at", "updated_at"))
+	newProduct.OrganizationID = tenant.ID(ctx)
+	err := newProduct.Insert(ctx, r.db, boil.Whitelist("title", "description", "price", "quantity", "is_active", "user_id", "organization_id", "created_at", "updated_at"))
 	if err != nil {
 		return model.Product{}, err
 	}
 	return newProduct, nil
 }
 
+// UpdateProduct updates the product, the organization and creation time of the product are kept
 func (r impl) UpdateProduct(ctx context.Context, product model.Product) (int64, error) {
-	affected, err := product.Update(context.Background(), r.db, boil.Infer())
+	affected, err := model.Products(
+		model.ProductWhere.ID.EQ(product.ID),
+		tenant.Where(ctx, model.ProductTableColumns.OrganizationID),
+	).UpdateAll(ctx, r.db, model.M{
+		model.ProductColumns.Title:       product.Title,
+		model.ProductColumns.Description: product.Description,
+		model.ProductColumns.Price:       product.Price,
+		model.ProductColumns.Quantity:    product.Quantity,
+		model.ProductColumns.IsActive:    product.IsActive,
+		model.ProductColumns.UserID:      product.UserID,
+		model.ProductColumns.UpdatedAt:   time.Now(),
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -45,7 +60,7 @@ func (r impl) UpdateProduct(ctx context.Context, product model.Product) (int64,
 }
 
 func (r impl) DeleteProduct(ctx context.Context, id int) (int64, error) {
-	affected, err := model.Products(qm.Where("id=?", id)).DeleteAll(context.Background(), r.db)
+	affected, err := model.Products(qm.Where("id=?", id), tenant.Where(ctx, model.ProductTableColumns.OrganizationID)).DeleteAll(ctx, r.db)
 	if err != nil {
 		return 0, err
 	}
@@ -72,9 +87,11 @@ type Pagination struct {
 type Filter struct {
 	ID         int
 	Title      string
+	Query      string // the full-text search query of the title and the description
 	PriceRange PriceRange
 	IsActive   null.Bool
 	UserID     int
+	CategoryID int
 	OrderBy    OrderBy
 	Pagination Pagination
 }
@@ -87,6 +104,7 @@ type ProductItem struct {
 	Quantity    int
 	IsActive    bool
 	User        CreatedBy
+	Highlight   *Highlight // nil without search query
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -97,17 +115,19 @@ type CreatedBy struct {
 	Phone string
 }
 
-func (r impl) GetProducts(ctx context.Context, filter Filter) ([]ProductItem, int64, error) {
-	// 1. Init query mods slice.
-	var qms []qm.QueryMod
+// filterMods returns the query mods of the filter conditions, the products are limited to the organization
+func filterMods(ctx context.Context, filter Filter) []qm.QueryMod {
+	qms := []qm.QueryMod{tenant.Where(ctx, model.ProductTableColumns.OrganizationID)}
 
-	// 2. Add filter condition.
 	if filter.ID > 0 {
 		qms = append(qms, model.ProductWhere.ID.EQ(filter.ID))
 	}
 	if filter.Title != "" {
 		qms = append(qms, qm.Where("title LIKE ?", "%"+filter.Title+"%"))
 	}
+	if filter.Query != "" {
+		qms = append(qms, searchWhere(filter.Query))
+	}
 	if filter.UserID > 0 {
 		qms = append(qms, model.ProductWhere.UserID.EQ(filter.UserID))
 	}
@@ -118,14 +138,27 @@ func (r impl) GetProducts(ctx context.Context, filter Filter) ([]ProductItem, in
 	if filter.IsActive.Valid {
 		qms = append(qms, model.ProductWhere.IsActive.EQ(filter.IsActive.Bool))
 	}
+	if filter.CategoryID > 0 {
+		// The products of the category and of all its descendants
+		qms = append(qms, qm.Where(`products.id IN (
+			SELECT pc.product_id FROM product_categories pc
+			JOIN categories c ON c.id = pc.category_id
+			WHERE c.path LIKE (SELECT path FROM categories WHERE id = ?) || '%')`, filter.CategoryID))
+	}
+	return qms
+}
+
+func (r impl) GetProducts(ctx context.Context, filter Filter) ([]ProductItem, int64, error) {
+	// 1. Init query mods slice with the filter conditions.
+	qms := filterMods(ctx, filter)
 
-	// 3. Calculate total rows of filtered products list.
+	// 2. Calculate total rows of filtered products list.
 	totalCount, err := model.Products(qms...).Count(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	//4. Sorting
+	//3. Sorting
 	if filter.OrderBy != (OrderBy{}) {
 		if filter.OrderBy.Title != "" {
 			qms = append(qms, qm.OrderBy(model.ProductColumns.Title+" "+filter.OrderBy.Title))
@@ -139,14 +172,17 @@ func (r impl) GetProducts(ctx context.Context, filter Filter) ([]ProductItem, in
 		if filter.OrderBy.Quantity != "" {
 			qms = append(qms, qm.OrderBy(model.ProductColumns.Quantity+" "+filter.OrderBy.Quantity))
 		}
+	} else if filter.Query != "" {
+		// The most relevant products first
+		qms = append(qms, searchOrderBy(filter.Query), qm.OrderBy(model.ProductColumns.UpdatedAt+" desc"))
 	} else {
 		qms = append(qms, qm.OrderBy(model.ProductColumns.UpdatedAt+" desc"))
 	}
 
-	// 5. Load relationships
+	// 4. Load relationships
 	qms = append(qms, qm.Load(model.ProductRels.User))
 
-	// 6. Add pagination condition.
+	// 5. Add pagination condition.
 	if filter.Pagination != (Pagination{}) {
 		qms = append(
 			qms,
@@ -154,15 +190,31 @@ func (r impl) GetProducts(ctx context.Context, filter Filter) ([]ProductItem, in
 			qm.Limit(filter.Pagination.Limit))
 	}
 
-	// 7. Get the products with the queries
-	productSlice, err := model.Products(qms...).All(context.Background(), r.db)
+	// 6. Get the products with the queries
+	productSlice, err := model.Products(qms...).All(ctx, r.db)
 	if err != nil {
 		return []ProductItem{}, 0, err
 	}
 
+	// 7. Highlight the words of the search query
+	var highlights map[int]Highlight
+	if filter.Query != "" && len(productSlice) > 0 {
+		ids := make([]int, len(productSlice))
+		for i, p := range productSlice {
+			ids[i] = p.ID
+		}
+		if highlights, err = r.getHighlights(ctx, ids, filter.Query); err != nil {
+			return []ProductItem{}, 0, err
+		}
+	}
+
 	// 8. Map the productSlice to []productItem
 	var result = make([]ProductItem, len(productSlice))
 	for i, p := range productSlice {
+		// The email and the phone of the creator are stored encrypted
+		if err = user.DecryptUser(r.keys, p.R.User); err != nil {
+			return []ProductItem{}, 0, err
+		}
 		result[i] = ProductItem{
 			ID:          p.ID,
 			Title:       p.Title,
@@ -179,6 +231,9 @@ func (r impl) GetProducts(ctx context.Context, filter Filter) ([]ProductItem, in
 			CreatedAt: p.CreatedAt,
 			UpdatedAt: p.UpdatedAt,
 		}
+		if highlight, ok := highlights[p.ID]; ok {
+			result[i].Highlight = &highlight
+		}
 	}
 	return result, totalCount, nil
 }
@@ -186,20 +241,22 @@ func (r impl) GetProducts(ctx context.Context, filter Filter) ([]ProductItem, in
 func (r impl) InsertAll(ctx context.Context, tx *sql.Tx, products []model.Product) error {
 	// Init query string
 	queryStr := fmt.Sprintf(
-		"INSERT INTO %s (%s, %s, %s, %s, %s, %s) VALUES ",
+		"INSERT INTO %s (%s, %s, %s, %s, %s, %s, %s) VALUES ",
 		model.TableNames.Products,
 		model.ProductColumns.Title, model.ProductColumns.Description, model.ProductColumns.Price,
 		model.ProductColumns.Quantity, model.ProductColumns.IsActive, model.ProductColumns.UserID,
+		model.ProductColumns.OrganizationID,
 	)
 
 	var values []interface{}
-	totalField := 6
+	totalField := 7
 	idx := 0
+	organizationID := tenant.ID(ctx)
 
 	// Loop through the products and append the query string and values
 	for _, p := range products {
-		queryStr += fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d),", idx+1, idx+2, idx+3, idx+4, idx+5, idx+6)
-		values = append(values, p.Title, p.Description, p.Price, p.Quantity, p.IsActive, p.UserID)
+		queryStr += fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d),", idx+1, idx+2, idx+3, idx+4, idx+5, idx+6, idx+7)
+		values = append(values, p.Title, p.Description, p.Price, p.Quantity, p.IsActive, p.UserID, organizationID)
 		idx += totalField
 	}
 
@@ -220,14 +277,15 @@ type SummaryStatistics struct {
 }
 
 func (r impl) GetStatistics(ctx context.Context) (SummaryStatistics, error) {
-	// Get the total number of products
-	total, err := model.Products().Count(ctx, r.db)
+	// Get the total number of products of the organization
+	scope := tenant.Where(ctx, model.ProductTableColumns.OrganizationID)
+	total, err := model.Products(scope).Count(ctx, r.db)
 	if err != nil {
 		return SummaryStatistics{}, err
 	}
 
 	// Get the total number of inactive products
-	totalInactive, err := model.Products(model.ProductWhere.IsActive.EQ(false)).Count(ctx, r.db)
+	totalInactive, err := model.Products(model.ProductWhere.IsActive.EQ(false), scope).Count(ctx, r.db)
 	if err != nil {
 		return SummaryStatistics{}, err
 	}
@@ -64,6 +64,11 @@ func (p *Mock) InsertAll(ctx context.Context, tx *sql.Tx, products []model.Produ
 	return args.Error(0)
 }
 
+func (m *Mock) GetFacets(ctx context.Context, filter Filter, priceBounds []float64) (Facets, error) {
+	args := m.Called(ctx, filter, priceBounds)
+	return args.Get(0).(Facets), args.Error(1)
+}
+
 func (m *Mock) GetStatistics(ctx context.Context) (SummaryStatistics, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(SummaryStatistics), args.Error(1)
@@ -11,7 +11,9 @@ import (
 	"github.com/volatiletech/null/v8"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption/encryptiontest"
 )
 
 func TestProductRepo_GetProduct(t *testing.T) {
@@ -39,22 +41,33 @@ func TestProductRepo_GetProduct(t *testing.T) {
 			},
 			expOutput: output{
 				product: model.Product{
-					ID:          1,
-					Title:       "test",
-					Description: "",
-					Price:       20000,
-					Quantity:    10,
-					IsActive:    true,
-					UserID:      1,
+					ID:             1,
+					Title:          "test",
+					Description:    "",
+					Price:          20000,
+					Quantity:       10,
+					IsActive:       true,
+					UserID:         1,
+					OrganizationID: auth.DefaultOrganizationID,
 				},
 			},
 		},
+		"error_other_organization": {
+			input: input{
+				productID:     1,
+				givenDataPath: "test_data/get_product.sql",
+				ctx:           auth.NewTenantContext(context.Background(), 100),
+			},
+			expOutput: output{
+				err: sql.ErrNoRows,
+			},
+		},
 	}
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
 			//GIVEN
 			dbConn, err := db.DBConnect(os.Getenv("DB_URL"))
-			productRepo := New(dbConn)
+			productRepo := New(dbConn, encryptiontest.KeyRing())
 			require.NoError(t, err)
 			db.LoadSqlTestFile(t, dbConn, tc.input.givenDataPath) // execute sql file to add data for test
 			defer dbConn.Exec("delete from products; delete from users ;")
@@ -108,13 +121,14 @@ func TestProductRepo_CreateProduct(t *testing.T) {
 
 			expOutput: output{
 				product: model.Product{
-					ID:          1,
-					Title:       "test",
-					Description: "",
-					Price:       20000,
-					Quantity:    10,
-					IsActive:    true,
-					UserID:      1,
+					ID:             1,
+					Title:          "test",
+					Description:    "",
+					Price:          20000,
+					Quantity:       10,
+					IsActive:       true,
+					UserID:         1,
+					OrganizationID: auth.DefaultOrganizationID,
 				},
 			},
 		},
@@ -134,13 +148,14 @@ func TestProductRepo_CreateProduct(t *testing.T) {
 
 			expOutput: output{
 				product: model.Product{
-					ID:          1,
-					Title:       "test",
-					Description: "",
-					Price:       20000,
-					Quantity:    10,
-					IsActive:    false,
-					UserID:      1,
+					ID:             1,
+					Title:          "test",
+					Description:    "",
+					Price:          20000,
+					Quantity:       10,
+					IsActive:       false,
+					UserID:         1,
+					OrganizationID: auth.DefaultOrganizationID,
 				},
 			},
 		},
@@ -152,7 +167,7 @@ func TestProductRepo_CreateProduct(t *testing.T) {
 			require.NoError(t, dbErr)
 			db.LoadSqlTestFile(t, dbConn, tc.input.givenDataPath)
 			defer dbConn.Exec("delete from products; delete from users ;")
-			productRepo := New(dbConn)
+			productRepo := New(dbConn, encryptiontest.KeyRing())
 
 			//WHEN
 			result, err := productRepo.CreateProduct(tc.input.ctx, tc.input.newProduct)
@@ -214,7 +229,7 @@ func TestProductRepo_UpdateProduct(t *testing.T) {
 				IsActive:    true,
 				UserID:      100,
 			},
-			expErr: errors.New("model: unable to update products row: pq: insert or update on table \"products\" violates foreign key constraint \"products_user_id_fkey\""),
+			expErr: errors.New("model: unable to update all for products: pq: insert or update on table \"products\" violates foreign key constraint \"products_user_id_fkey\""),
 		},
 	}
 	for desc, tc := range tcs {
@@ -223,7 +238,7 @@ func TestProductRepo_UpdateProduct(t *testing.T) {
 			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
 			require.NoError(t, dbErr)
 
-			productRepo := New(dbTest)
+			productRepo := New(dbTest, encryptiontest.KeyRing())
 
 			db.LoadSqlTestFile(t, dbTest, "test_data/products.sql")
 			defer dbTest.Exec("delete from products; delete from users;")
@@ -290,7 +305,7 @@ func TestProductRepo_DeleteProduct(t *testing.T) {
 			db.LoadSqlTestFile(t, dbConn, tc.input.givenDataPath)
 			defer dbConn.Exec("delete from users ;")
 			defer dbConn.Exec("delete from products;")
-			productRepo := New(dbConn)
+			productRepo := New(dbConn, encryptiontest.KeyRing())
 
 			//WHEN
 			result, err := productRepo.DeleteProduct(tc.input.ctx, tc.input.productID)
@@ -443,6 +458,56 @@ func TestProductRepo_GetProducts(t *testing.T) {
 				totalCount: 4,
 			},
 		},
+		"search_ranked_with_highlight": {
+			input: input{
+				filter: Filter{
+					Query: "pen",
+				},
+				ctx:           context.Background(),
+				givenDataPath: "test_data/get_products.sql",
+			},
+			expOutput: output{
+				products: []ProductItem{
+					{
+						ID:          2,
+						Title:       `Thien Long ballpoint pen`,
+						Description: `Nice pen from Thien Long company`,
+						Price:       10000,
+						Quantity:    2,
+						IsActive:    true,
+						User: CreatedBy{
+							ID:    1,
+							Name:  "admin",
+							Email: "admin@example.com",
+							Phone: "0987654321",
+						},
+						Highlight: &Highlight{
+							Title:       `Thien Long ballpoint <mark>pen</mark>`,
+							Description: `Nice <mark>pen</mark> from Thien Long company`,
+						},
+					},
+					{
+						ID:          3,
+						Title:       `Thien long pencil`,
+						Description: `Nice pen from Thien Long company`,
+						Price:       5000,
+						Quantity:    3,
+						IsActive:    true,
+						User: CreatedBy{
+							ID:    2,
+							Name:  "admin 2",
+							Email: "admin2@example.com",
+							Phone: "0987654321",
+						},
+						Highlight: &Highlight{
+							Title:       `Thien long pencil`,
+							Description: `Nice <mark>pen</mark> from Thien Long company`,
+						},
+					},
+				},
+				totalCount: 2,
+			},
+		},
 		"empty_data_by_is_active": {
 			input: input{
 				filter: Filter{
@@ -525,7 +590,7 @@ func TestProductRepo_GetProducts(t *testing.T) {
 			db.LoadSqlTestFile(t, dbConn, tc.input.givenDataPath)
 			defer dbConn.Exec("delete from users ;")
 			defer dbConn.Exec("delete from products;")
-			productRepo := New(dbConn)
+			productRepo := New(dbConn, encryptiontest.KeyRing())
 
 			//WHEN
 			result, totalCount, err := productRepo.GetProducts(tc.input.ctx, tc.input.filter)
@@ -623,7 +688,7 @@ func TestProductRepo_InsertALl(t *testing.T) {
 			txTest, txErr := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
 			require.NoError(t, txErr)
 
-			productRepo := New(dbTest)
+			productRepo := New(dbTest, encryptiontest.KeyRing())
 
 			// When
 			err := productRepo.InsertAll(context.Background(), txTest, tc.given)
@@ -643,15 +708,31 @@ func TestProductRepo_InsertALl(t *testing.T) {
 
 func TestOrderRepository_GetStatistics(t *testing.T) {
 	tcs := map[string]struct {
+		givenCtx  context.Context
 		expResult SummaryStatistics
 		expErr    error
 	}{
-		"success": {
+		"success_unscoped": {
+			givenCtx: auth.NewUnscopedContext(context.Background()),
+			expResult: SummaryStatistics{
+				Total:         5,
+				TotalInactive: 2,
+			},
+		},
+		"success_default_organization": {
+			givenCtx: auth.NewContext(context.Background(), auth.User{ID: 1, OrganizationID: auth.DefaultOrganizationID}),
 			expResult: SummaryStatistics{
 				Total:         4,
 				TotalInactive: 1,
 			},
 		},
+		"success_other_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), 100),
+			expResult: SummaryStatistics{
+				Total:         1,
+				TotalInactive: 1,
+			},
+		},
 	}
 
 	for desc, tc := range tcs {
@@ -661,12 +742,13 @@ func TestOrderRepository_GetStatistics(t *testing.T) {
 			require.NoError(t, err)
 			defer dbTest.Close()
 
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 			db.LoadSqlTestFile(t, dbTest, "test_data/products.sql")
-			defer dbTest.Exec("DELETE FROM products;")
+			db.LoadSqlTestFile(t, dbTest, "test_data/organization_products.sql")
+			defer dbTest.Exec("DELETE FROM products; DELETE FROM organizations WHERE id = 100;")
 
 			// When
-			result, err := repo.GetStatistics(context.Background())
+			result, err := repo.GetStatistics(tc.givenCtx)
 
 			// Then
 			if tc.expErr != nil {
@@ -0,0 +1,69 @@
+package product
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// searchConfig is the text search configuration of products.search_vector, it must match the migration of the column
+const searchConfig = "english"
+
+const (
+	// highlightStart and highlightStop mark the matched words in the snippets, they are replaced by <mark> after the text is escaped
+	highlightStart = "\x01"
+	highlightStop  = "\x02"
+
+	titleHeadlineOptions       = "HighlightAll=true, StartSel=" + highlightStart + ", StopSel=" + highlightStop
+	descriptionHeadlineOptions = "MaxFragments=2, MaxWords=20, MinWords=8, FragmentDelimiter=\" … \", StartSel=" + highlightStart + ", StopSel=" + highlightStop
+)
+
+// Highlight is the title and the description of a product with the words matched by the search query in <mark> tags, the text is HTML escaped
+type Highlight struct {
+	Title       string
+	Description string
+}
+
+// searchWhere matches the products by the search query, the query uses the syntax of web search engines: quoted phrases, "or" and "-" to exclude a word
+func searchWhere(query string) qm.QueryMod {
+	return qm.Where("products.search_vector @@ websearch_to_tsquery(?::regconfig, ?)", searchConfig, query)
+}
+
+// searchOrderBy orders the products by the relevance of the search query, a match in the title weighs more than in the description
+func searchOrderBy(query string) qm.QueryMod {
+	return qm.OrderBy("ts_rank(products.search_vector, websearch_to_tsquery(?::regconfig, ?)) DESC", searchConfig, query)
+}
+
+// toHighlightHTML escapes the headline and replaces the markers of the matched words by <mark> tags
+func toHighlightHTML(headline string) string {
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(html.EscapeString(headline))
+}
+
+// getHighlights returns the highlights of the products for the search query, only the products of a page are highlighted because ts_headline is slow
+func (r impl) getHighlights(ctx context.Context, ids []int, query string) (map[int]Highlight, error) {
+	var rows []struct {
+		ID          int    `boil:"id"`
+		Title       string `boil:"title"`
+		Description string `boil:"description"`
+	}
+	if err := queries.Raw(`
+		SELECT p.id,
+			ts_headline($1::regconfig, p.title, q, $3) AS title,
+			ts_headline($1::regconfig, p.description, q, $4) AS description
+		FROM products p, websearch_to_tsquery($1::regconfig, $2) q
+		WHERE p.id = ANY($5)`,
+		searchConfig, query, titleHeadlineOptions, descriptionHeadlineOptions, pq.Array(ids),
+	).Bind(ctx, r.db, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]Highlight, len(rows))
+	for _, row := range rows {
+		result[row.ID] = Highlight{Title: toHighlightHTML(row.Title), Description: toHighlightHTML(row.Description)}
+	}
+	return result, nil
+}
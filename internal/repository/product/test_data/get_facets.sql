@@ -0,0 +1,21 @@
+INSERT INTO users (id, name, email, phone, password)
+    VALUES (1, 'admin', 'admin@example.com', '0987654321', '123456789'),
+    (2, 'admin 2', 'admin2@example.com', '0987654321', '123456789');
+
+INSERT INTO products (id, title, description, price, quantity, user_id, is_active) VALUES
+    (1, 'Phone A', 'Cheap phone', 5, 1, 1, true),
+    (2, 'Phone B', 'Good phone', 20, 2, 2, true),
+    (3, 'Book', 'Old book', 30, 3, 2, false),
+    (4, 'Laptop', 'Fast laptop', 100, 4, 2, true),
+    (5, 'Pen', 'Gold pen', 200, 5, 1, false);
+
+INSERT INTO categories (id, organization_id, parent_id, name, slug, sort_order, path) VALUES
+    (10, 1, NULL, 'Electronics', 'electronics', 0, '/10/'),
+    (11, 1, 10, 'Phones', 'phones', 0, '/10/11/'),
+    (13, 1, NULL, 'Books', 'books', 1, '/13/');
+
+INSERT INTO product_categories (product_id, category_id) VALUES
+    (1, 11),
+    (2, 11),
+    (4, 10),
+    (3, 13);
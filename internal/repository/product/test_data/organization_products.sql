@@ -0,0 +1,5 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO products ("id", "title", "price", "quantity", "user_id", "is_active", "organization_id")
+VALUES (5, 'EEE', 30000, 5, 1, false, 100);
@@ -4,9 +4,24 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/address"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/apikey"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/category"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/datarequest"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/identity"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/image"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/impersonation"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/order"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/organization"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/twofactor"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/variant"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
 )
 
 type IRepo interface {
@@ -17,27 +32,98 @@ type IRepo interface {
 	// Product returns product repository
 	Product() product.IProduct
 
+	// Category returns product category repository
+	Category() category.ICategory
+
+	// Variant returns product option and variant repository
+	Variant() variant.IVariant
+
+	// Image returns product image repository
+	Image() image.IImage
+
 	// Order returns order repository
 	Order() order.IOrder
 
+	// Token returns token repository
+	Token() token.IToken
+
+	// LoginFailure returns login failure repository
+	LoginFailure() loginfailure.ILoginFailure
+
+	// TwoFactor returns two-factor authentication repository
+	TwoFactor() twofactor.ITwoFactor
+
+	// APIKey returns API key repository
+	APIKey() apikey.IAPIKey
+
+	// Role returns role and permission repository
+	Role() role.IRole
+
+	// Identity returns OpenID Connect identity repository
+	Identity() identity.IIdentity
+
+	// Organization returns organization repository
+	Organization() organization.IOrganization
+
+	// Impersonation returns impersonation session repository
+	Impersonation() impersonation.IImpersonation
+
+	// Address returns user address repository
+	Address() address.IAddress
+
+	// DataRequest returns personal data request repository
+	DataRequest() datarequest.IDataRequest
+
+	// SecurityEvent returns security event repository
+	SecurityEvent() securityevent.ISecurityEvent
+
 	// Tx commits the given function in a transaction.
 	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
 }
 
-func New(db *sql.DB) IRepo {
+// New returns the repositories, the keys encrypt the personal data of the users
+func New(db *sql.DB, keys *encryption.KeyRing) IRepo {
 	return impl{
-		db:      db,
-		order:   order.New(db),
-		user:    user.New(db),
-		product: product.New(db),
+		db:            db,
+		order:         order.New(db),
+		user:          user.New(db, keys),
+		product:       product.New(db, keys),
+		category:      category.New(db),
+		variant:       variant.New(db),
+		image:         image.New(db),
+		token:         token.New(db),
+		loginFailure:  loginfailure.New(db, keys),
+		twoFactor:     twofactor.New(db),
+		apiKey:        apikey.New(db),
+		role:          role.New(db),
+		identity:      identity.New(db, keys),
+		organization:  organization.New(db),
+		impersonation: impersonation.New(db),
+		address:       address.New(db),
+		dataRequest:   datarequest.New(db),
+		securityEvent: securityevent.New(db, keys),
 	}
 }
 
 type impl struct {
-	db      *sql.DB
-	order   order.IOrder
-	user    user.IUser
-	product product.IProduct
+	db            *sql.DB
+	order         order.IOrder
+	user          user.IUser
+	product       product.IProduct
+	category      category.ICategory
+	variant       variant.IVariant
+	image         image.IImage
+	token         token.IToken
+	loginFailure  loginfailure.ILoginFailure
+	twoFactor     twofactor.ITwoFactor
+	apiKey        apikey.IAPIKey
+	role          role.IRole
+	identity      identity.IIdentity
+	organization  organization.IOrganization
+	impersonation impersonation.IImpersonation
+	address       address.IAddress
+	dataRequest   datarequest.IDataRequest
+	securityEvent securityevent.ISecurityEvent
 }
 
 func (i impl) User() user.IUser {
@@ -48,10 +134,66 @@ func (i impl) Product() product.IProduct {
 	return i.product
 }
 
+func (i impl) Category() category.ICategory {
+	return i.category
+}
+
+func (i impl) Variant() variant.IVariant {
+	return i.variant
+}
+
+func (i impl) Image() image.IImage {
+	return i.image
+}
+
 func (i impl) Order() order.IOrder {
 	return i.order
 }
 
+func (i impl) Token() token.IToken {
+	return i.token
+}
+
+func (i impl) LoginFailure() loginfailure.ILoginFailure {
+	return i.loginFailure
+}
+
+func (i impl) TwoFactor() twofactor.ITwoFactor {
+	return i.twoFactor
+}
+
+func (i impl) APIKey() apikey.IAPIKey {
+	return i.apiKey
+}
+
+func (i impl) Role() role.IRole {
+	return i.role
+}
+
+func (i impl) Identity() identity.IIdentity {
+	return i.identity
+}
+
+func (i impl) Organization() organization.IOrganization {
+	return i.organization
+}
+
+func (i impl) Impersonation() impersonation.IImpersonation {
+	return i.impersonation
+}
+
+func (i impl) Address() address.IAddress {
+	return i.address
+}
+
+func (i impl) DataRequest() datarequest.IDataRequest {
+	return i.dataRequest
+}
+
+func (i impl) SecurityEvent() securityevent.ISecurityEvent {
+	return i.securityEvent
+}
+
 func (i impl) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
 	tx, err := i.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault})
 	if err != nil {
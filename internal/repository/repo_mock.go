@@ -6,9 +6,23 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/address"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/apikey"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/category"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/datarequest"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/identity"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/image"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/impersonation"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/order"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/organization"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/twofactor"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/variant"
 )
 
 type Mock struct {
@@ -25,11 +39,81 @@ func (m *Mock) Product() product.IProduct {
 	return args.Get(0).(product.IProduct)
 }
 
+func (m *Mock) Category() category.ICategory {
+	args := m.Called()
+	return args.Get(0).(category.ICategory)
+}
+
+func (m *Mock) Variant() variant.IVariant {
+	args := m.Called()
+	return args.Get(0).(variant.IVariant)
+}
+
+func (m *Mock) Image() image.IImage {
+	args := m.Called()
+	return args.Get(0).(image.IImage)
+}
+
 func (m *Mock) Order() order.IOrder {
 	args := m.Called()
 	return args.Get(0).(order.IOrder)
 }
 
+func (m *Mock) Token() token.IToken {
+	args := m.Called()
+	return args.Get(0).(token.IToken)
+}
+
+func (m *Mock) LoginFailure() loginfailure.ILoginFailure {
+	args := m.Called()
+	return args.Get(0).(loginfailure.ILoginFailure)
+}
+
+func (m *Mock) TwoFactor() twofactor.ITwoFactor {
+	args := m.Called()
+	return args.Get(0).(twofactor.ITwoFactor)
+}
+
+func (m *Mock) APIKey() apikey.IAPIKey {
+	args := m.Called()
+	return args.Get(0).(apikey.IAPIKey)
+}
+
+func (m *Mock) Role() role.IRole {
+	args := m.Called()
+	return args.Get(0).(role.IRole)
+}
+
+func (m *Mock) Identity() identity.IIdentity {
+	args := m.Called()
+	return args.Get(0).(identity.IIdentity)
+}
+
+func (m *Mock) Organization() organization.IOrganization {
+	args := m.Called()
+	return args.Get(0).(organization.IOrganization)
+}
+
+func (m *Mock) Impersonation() impersonation.IImpersonation {
+	args := m.Called()
+	return args.Get(0).(impersonation.IImpersonation)
+}
+
+func (m *Mock) Address() address.IAddress {
+	args := m.Called()
+	return args.Get(0).(address.IAddress)
+}
+
+func (m *Mock) DataRequest() datarequest.IDataRequest {
+	args := m.Called()
+	return args.Get(0).(datarequest.IDataRequest)
+}
+
+func (m *Mock) SecurityEvent() securityevent.ISecurityEvent {
+	args := m.Called()
+	return args.Get(0).(securityevent.ISecurityEvent)
+}
+
 func (m *Mock) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
 	args := m.Called(ctx, fn)
 	return args.Error(0)
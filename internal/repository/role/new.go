@@ -0,0 +1,63 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type IRole interface {
+	// GetRoles returns all roles with their permissions
+	GetRoles(ctx context.Context) (model.RoleSlice, error)
+
+	// GetRole returns the role with the given id and its permissions
+	GetRole(ctx context.Context, id int) (model.Role, error)
+
+	// GetRolesByNames returns the roles with the given names
+	GetRolesByNames(ctx context.Context, names []string) (model.RoleSlice, error)
+
+	// ExistsRoleByName returns true if the role exists
+	ExistsRoleByName(ctx context.Context, name string) (bool, error)
+
+	// CreateRole creates a new role
+	CreateRole(ctx context.Context, tx *sql.Tx, role model.Role) (model.Role, error)
+
+	// UpdateRole updates the name and the description of the role
+	UpdateRole(ctx context.Context, tx *sql.Tx, role model.Role) (int64, error)
+
+	// DeleteRole deletes the role with the given id, the role is removed from the users who have it as an additional role
+	DeleteRole(ctx context.Context, id int) (int64, error)
+
+	// SetRolePermissions replaces the permissions of the role
+	SetRolePermissions(ctx context.Context, tx *sql.Tx, roleID int, permissions model.PermissionSlice) error
+
+	// RenameUsersRole changes the primary role of the users who have the old role
+	RenameUsersRole(ctx context.Context, tx *sql.Tx, oldName string, newName string) (int64, error)
+
+	// ExistsUserWithRole returns true if a user has the role as the primary role
+	ExistsUserWithRole(ctx context.Context, name string) (bool, error)
+
+	// GetPermissions returns all permissions
+	GetPermissions(ctx context.Context) (model.PermissionSlice, error)
+
+	// GetPermissionsByNames returns the permissions with the given names
+	GetPermissionsByNames(ctx context.Context, names []string) (model.PermissionSlice, error)
+
+	// GetUserRoles returns the additional roles of the user
+	GetUserRoles(ctx context.Context, userID int) (model.RoleSlice, error)
+
+	// SetUserRoles replaces the additional roles of the user
+	SetUserRoles(ctx context.Context, tx *sql.Tx, userID int, roles model.RoleSlice) error
+
+	// GetUserPermissions returns the names of the permissions of the primary role and the additional roles of the user
+	GetUserPermissions(ctx context.Context, userID int) ([]string, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IRole {
+	return impl{db: db}
+}
@@ -0,0 +1,152 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+)
+
+// GetRoles returns all roles of the organization with their permissions ordered by name
+func (r impl) GetRoles(ctx context.Context) (model.RoleSlice, error) {
+	return model.Roles(
+		tenant.Where(ctx, model.RoleTableColumns.OrganizationID),
+		qm.Load(model.RoleRels.Permissions, qm.OrderBy(model.PermissionColumns.Name)),
+		qm.OrderBy(model.RoleColumns.Name),
+	).All(ctx, r.db)
+}
+
+// GetRole returns the role of the organization with the given id and its permissions
+func (r impl) GetRole(ctx context.Context, id int) (model.Role, error) {
+	result, err := model.Roles(
+		model.RoleWhere.ID.EQ(id),
+		tenant.Where(ctx, model.RoleTableColumns.OrganizationID),
+		qm.Load(model.RoleRels.Permissions, qm.OrderBy(model.PermissionColumns.Name)),
+	).One(ctx, r.db)
+	if err != nil {
+		return model.Role{}, err
+	}
+	return *result, nil
+}
+
+// GetRolesByNames returns the roles of the organization with the given names, unknown names are ignored
+func (r impl) GetRolesByNames(ctx context.Context, names []string) (model.RoleSlice, error) {
+	return model.Roles(
+		model.RoleWhere.Name.IN(names),
+		tenant.Where(ctx, model.RoleTableColumns.OrganizationID),
+	).All(ctx, r.db)
+}
+
+// ExistsRoleByName checks if a role of the organization exists by name
+func (r impl) ExistsRoleByName(ctx context.Context, name string) (bool, error) {
+	return model.Roles(
+		model.RoleWhere.Name.EQ(name),
+		tenant.Where(ctx, model.RoleTableColumns.OrganizationID),
+	).Exists(ctx, r.db)
+}
+
+// CreateRole creates a new role in the organization
+func (r impl) CreateRole(ctx context.Context, tx *sql.Tx, role model.Role) (model.Role, error) {
+	role.OrganizationID = tenant.ID(ctx)
+	if err := role.Insert(ctx, tx, boil.Whitelist("organization_id", "name", "description", "created_at", "updated_at")); err != nil {
+		return model.Role{}, err
+	}
+	return role, nil
+}
+
+// UpdateRole updates the name and the description of the role
+func (r impl) UpdateRole(ctx context.Context, tx *sql.Tx, role model.Role) (int64, error) {
+	return model.Roles(
+		model.RoleWhere.ID.EQ(role.ID),
+		tenant.Where(ctx, model.RoleTableColumns.OrganizationID),
+	).UpdateAll(ctx, tx, model.M{
+		model.RoleColumns.Name:        role.Name,
+		model.RoleColumns.Description: role.Description,
+		model.RoleColumns.UpdatedAt:   time.Now(),
+	})
+}
+
+// DeleteRole deletes the role of the organization with the given id, its permissions and user assignments are deleted by cascade
+func (r impl) DeleteRole(ctx context.Context, id int) (int64, error) {
+	return model.Roles(
+		model.RoleWhere.ID.EQ(id),
+		tenant.Where(ctx, model.RoleTableColumns.OrganizationID),
+	).DeleteAll(ctx, r.db)
+}
+
+// SetRolePermissions replaces the permissions of the role
+func (r impl) SetRolePermissions(ctx context.Context, tx *sql.Tx, roleID int, permissions model.PermissionSlice) error {
+	role := model.Role{ID: roleID}
+	return role.SetPermissions(ctx, tx, false, permissions...)
+}
+
+// RenameUsersRole changes the primary role of the users of the organization who have the old role
+func (r impl) RenameUsersRole(ctx context.Context, tx *sql.Tx, oldName string, newName string) (int64, error) {
+	return model.Users(
+		model.UserWhere.Role.EQ(oldName),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).UpdateAll(ctx, tx, model.M{
+		model.UserColumns.Role: newName,
+	})
+}
+
+// ExistsUserWithRole checks if a user of the organization has the role as the primary role
+func (r impl) ExistsUserWithRole(ctx context.Context, name string) (bool, error) {
+	return model.Users(
+		model.UserWhere.Role.EQ(name),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).Exists(ctx, r.db)
+}
+
+// GetPermissions returns all permissions ordered by name
+func (r impl) GetPermissions(ctx context.Context) (model.PermissionSlice, error) {
+	return model.Permissions(qm.OrderBy(model.PermissionColumns.Name)).All(ctx, r.db)
+}
+
+// GetPermissionsByNames returns the permissions with the given names, unknown names are ignored
+func (r impl) GetPermissionsByNames(ctx context.Context, names []string) (model.PermissionSlice, error) {
+	return model.Permissions(model.PermissionWhere.Name.IN(names)).All(ctx, r.db)
+}
+
+// GetUserRoles returns the additional roles of the user ordered by name
+func (r impl) GetUserRoles(ctx context.Context, userID int) (model.RoleSlice, error) {
+	user := model.User{ID: userID}
+	return user.Roles(qm.OrderBy(model.RoleTableColumns.Name)).All(ctx, r.db)
+}
+
+// SetUserRoles replaces the additional roles of the user
+func (r impl) SetUserRoles(ctx context.Context, tx *sql.Tx, userID int, roles model.RoleSlice) error {
+	user := model.User{ID: userID}
+	return user.SetRoles(ctx, tx, false, roles...)
+}
+
+// GetUserPermissions returns the names of the permissions of the primary role and the additional roles of the user,
+// the primary role is the role with its name in the organization of the user
+func (r impl) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+	var rows []struct {
+		Name string `boil:"name"`
+	}
+	err := queries.Raw(`
+		SELECT DISTINCT p.name
+		FROM permissions p
+		JOIN role_permissions rp ON rp.permission_id = p.id
+		JOIN roles r ON r.id = rp.role_id
+		WHERE r.id IN (SELECT pr.id FROM roles pr JOIN users u ON u.organization_id = pr.organization_id AND u.role = pr.name WHERE u.id = $1)
+			OR r.id IN (SELECT ur.role_id FROM user_roles ur WHERE ur.user_id = $1)
+		ORDER BY p.name`, userID).Bind(ctx, r.db, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(rows))
+	for i, row := range rows {
+		result[i] = row.Name
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetRoles(ctx context.Context) (model.RoleSlice, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(model.RoleSlice), args.Error(1)
+}
+
+func (m *Mock) GetRole(ctx context.Context, id int) (model.Role, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.Role), args.Error(1)
+}
+
+func (m *Mock) GetRolesByNames(ctx context.Context, names []string) (model.RoleSlice, error) {
+	args := m.Called(ctx, names)
+	return args.Get(0).(model.RoleSlice), args.Error(1)
+}
+
+func (m *Mock) ExistsRoleByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Mock) CreateRole(ctx context.Context, tx *sql.Tx, role model.Role) (model.Role, error) {
+	args := m.Called(ctx, tx, role)
+	return args.Get(0).(model.Role), args.Error(1)
+}
+
+func (m *Mock) UpdateRole(ctx context.Context, tx *sql.Tx, role model.Role) (int64, error) {
+	args := m.Called(ctx, tx, role)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) DeleteRole(ctx context.Context, id int) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) SetRolePermissions(ctx context.Context, tx *sql.Tx, roleID int, permissions model.PermissionSlice) error {
+	args := m.Called(ctx, tx, roleID, permissions)
+	return args.Error(0)
+}
+
+func (m *Mock) RenameUsersRole(ctx context.Context, tx *sql.Tx, oldName string, newName string) (int64, error) {
+	args := m.Called(ctx, tx, oldName, newName)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) ExistsUserWithRole(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Mock) GetPermissions(ctx context.Context) (model.PermissionSlice, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(model.PermissionSlice), args.Error(1)
+}
+
+func (m *Mock) GetPermissionsByNames(ctx context.Context, names []string) (model.PermissionSlice, error) {
+	args := m.Called(ctx, names)
+	return args.Get(0).(model.PermissionSlice), args.Error(1)
+}
+
+func (m *Mock) GetUserRoles(ctx context.Context, userID int) (model.RoleSlice, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(model.RoleSlice), args.Error(1)
+}
+
+func (m *Mock) SetUserRoles(ctx context.Context, tx *sql.Tx, userID int, roles model.RoleSlice) error {
+	args := m.Called(ctx, tx, userID, roles)
+	return args.Error(0)
+}
+
+func (m *Mock) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,225 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+// The seeded ADMIN and GUEST roles and the permissions are created by the migration and kept
+const cleanUpQuery = "DELETE FROM user_roles; DELETE FROM users; DELETE FROM roles WHERE id >= 100; DELETE FROM organizations WHERE id = 100;"
+
+func TestRoleRepository_GetUserPermissions(t *testing.T) {
+	tcs := map[string]struct {
+		given int
+		exp   []string
+	}{
+		"primary_and_additional_roles": {
+			given: 10,
+			exp:   []string{"order:read", "order:read:any", "order:write", "product:write", "statistics:read"},
+		},
+		"custom_primary_role": {
+			given: 11,
+			exp:   []string{"product:write", "product:write:any"},
+		},
+		"primary_role_of_own_organization": {
+			given: 12,
+			exp:   []string{"order:read"},
+		},
+		"user_not_found": {
+			given: 13,
+			exp:   []string{},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/roles.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetUserPermissions(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, result)
+		})
+	}
+}
+
+func TestRoleRepository_GetRole(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/roles.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest)
+
+	// When
+	result, err := repo.GetRole(context.Background(), 100)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "WAREHOUSE", result.Name)
+	require.Len(t, result.R.Permissions, 2)
+	require.Equal(t, "product:write", result.R.Permissions[0].Name)
+	require.Equal(t, "product:write:any", result.R.Permissions[1].Name)
+
+	// The roles of other organizations are not found
+	_, err = repo.GetRole(auth.NewTenantContext(context.Background(), 100), 100)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRoleRepository_SetRolePermissions(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/roles.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest)
+	permissions, err := repo.GetPermissionsByNames(context.Background(), []string{"order:read"})
+	require.NoError(t, err)
+	tx, err := dbTest.Begin()
+	require.NoError(t, err)
+
+	// When
+	err = repo.SetRolePermissions(context.Background(), tx, 100, permissions)
+	require.NoError(t, tx.Commit())
+
+	// Then
+	require.NoError(t, err)
+	result, err := repo.GetUserPermissions(context.Background(), 11)
+	require.NoError(t, err)
+	require.Equal(t, []string{"order:read"}, result)
+}
+
+func TestRoleRepository_SetUserRoles(t *testing.T) {
+	tcs := map[string]struct {
+		given []string
+		exp   []string
+	}{
+		"replace_roles": {
+			given: []string{"WAREHOUSE"},
+			exp:   []string{"WAREHOUSE"},
+		},
+		"remove_roles": {
+			given: []string{},
+			exp:   []string{},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/roles.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+			roles, err := repo.GetRolesByNames(context.Background(), tc.given)
+			require.NoError(t, err)
+			tx, err := dbTest.Begin()
+			require.NoError(t, err)
+
+			// When
+			err = repo.SetUserRoles(context.Background(), tx, 10, roles)
+			require.NoError(t, tx.Commit())
+
+			// Then
+			require.NoError(t, err)
+			result, err := repo.GetUserRoles(context.Background(), 10)
+			require.NoError(t, err)
+			names := []string{}
+			for _, r := range result {
+				names = append(names, r.Name)
+			}
+			require.Equal(t, tc.exp, names)
+		})
+	}
+}
+
+func TestRoleRepository_RenameUsersRole(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/roles.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest)
+	tx, err := dbTest.Begin()
+	require.NoError(t, err)
+
+	// When
+	_, err = repo.UpdateRole(context.Background(), tx, model.Role{ID: 100, Name: "STOCK"})
+	require.NoError(t, err)
+	result, err := repo.RenameUsersRole(context.Background(), tx, "WAREHOUSE", "STOCK")
+	require.NoError(t, tx.Commit())
+
+	// Then, the user of the other organization keeps the role of the same name
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result)
+	existed, err := repo.ExistsUserWithRole(context.Background(), "STOCK")
+	require.NoError(t, err)
+	require.True(t, existed)
+}
+
+func TestRoleRepository_DeleteRole(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   101,
+			rowsAff: 1,
+		},
+		"not_found": {
+			given:   103,
+			rowsAff: 0,
+		},
+		"other_organization": {
+			given:   102,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/roles.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.DeleteRole(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
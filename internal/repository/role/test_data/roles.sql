@@ -0,0 +1,28 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO "roles" ("id", "name", "description") VALUES
+(100, 'WAREHOUSE', 'Warehouse scripts'),
+(101, 'REPORTER', 'Reports');
+
+INSERT INTO "roles" ("id", "organization_id", "name", "description") VALUES
+(102, 100, 'WAREHOUSE', 'Warehouse of Shop A');
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT 100, "id" FROM "permissions" WHERE "name" IN ('product:write', 'product:write:any');
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT 101, "id" FROM "permissions" WHERE "name" IN ('order:read:any', 'statistics:read');
+
+INSERT INTO "role_permissions" ("role_id", "permission_id")
+SELECT 102, "id" FROM "permissions" WHERE "name" IN ('order:read');
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
+(10, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true),
+(11, 'test2', 'test2@example.com', 'test', 'test', 'WAREHOUSE', true);
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "organization_id") VALUES
+(12, 'test3', 'test3@example.com', 'test', 'test', 'WAREHOUSE', true, 100);
+
+INSERT INTO "user_roles" ("user_id", "role_id") VALUES
+(10, 101);
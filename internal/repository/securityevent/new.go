@@ -0,0 +1,50 @@
+package securityevent
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+)
+
+const (
+	// TypeLoginSucceeded is a login which issued tokens
+	TypeLoginSucceeded = "LOGIN_SUCCEEDED"
+	// TypeLoginFailed is a login with an unknown email, an incorrect password or an incorrect two-factor code
+	TypeLoginFailed = "LOGIN_FAILED"
+	// TypePasswordChanged is a change or a reset of the password
+	TypePasswordChanged = "PASSWORD_CHANGED"
+	// TypeRoleChanged is a change of the roles of the user
+	TypeRoleChanged = "ROLE_CHANGED"
+	// TypeTokenRevoked is a logout or a revoked API key
+	TypeTokenRevoked = "TOKEN_REVOKED"
+)
+
+type ISecurityEvent interface {
+	// CreateSecurityEvent records a security event
+	CreateSecurityEvent(ctx context.Context, event model.SecurityEvent) (model.SecurityEvent, error)
+
+	// GetSecurityEvents returns the security events of the organization by filter, the latest first
+	GetSecurityEvents(ctx context.Context, filter Filter) ([]model.SecurityEvent, int64, error)
+
+	// GetUserSecurityEvents returns all security events of the user, the oldest first
+	GetUserSecurityEvents(ctx context.Context, userID int) ([]model.SecurityEvent, error)
+
+	// AnonymizeSecurityEvents clears the emails, the IP addresses and the user agents of the security events of the user
+	AnonymizeSecurityEvents(ctx context.Context, tx *sql.Tx, userID int) error
+
+	// ReencryptSecurityEvents encrypts the emails of the batch of security events after the given id with the active key
+	ReencryptSecurityEvents(ctx context.Context, afterID int, limit int) (user.ReencryptResult, error)
+}
+
+type impl struct {
+	db   *sql.DB
+	keys *encryption.KeyRing
+}
+
+// New returns the security event repository, the emails of the events are encrypted with the keys
+func New(db *sql.DB, keys *encryption.KeyRing) ISecurityEvent {
+	return impl{db: db, keys: keys}
+}
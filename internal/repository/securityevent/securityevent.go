@@ -0,0 +1,169 @@
+package securityevent
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+)
+
+type Pagination struct {
+	Page  int
+	Limit int
+}
+
+type Filter struct {
+	UserID int
+	Type   string
+	// From and To limit the time of the events, zero values are not limited
+	From       time.Time
+	To         time.Time
+	Pagination Pagination
+}
+
+// CreateSecurityEvent records a security event, it belongs to the organization of the request if no organization is given.
+// The email is stored encrypted.
+func (r impl) CreateSecurityEvent(ctx context.Context, event model.SecurityEvent) (model.SecurityEvent, error) {
+	if event.OrganizationID == 0 {
+		event.OrganizationID = tenant.ID(ctx)
+	}
+	email := event.Email
+	var err error
+	if event.Email, err = r.keys.Encrypt(email); err != nil {
+		return model.SecurityEvent{}, err
+	}
+	if err = event.Insert(ctx, r.db, boil.Whitelist("user_id", "organization_id", "type", "email", "ip_address", "user_agent", "created_at")); err != nil {
+		return model.SecurityEvent{}, err
+	}
+	event.Email = email
+	return event, nil
+}
+
+// GetSecurityEvents returns the security events of the organization of the request by filter, the latest first
+func (r impl) GetSecurityEvents(ctx context.Context, filter Filter) ([]model.SecurityEvent, int64, error) {
+	// 1. Add filter condition, the events are limited to the organization
+	qms := []qm.QueryMod{tenant.Where(ctx, model.SecurityEventTableColumns.OrganizationID)}
+	if filter.UserID > 0 {
+		qms = append(qms, model.SecurityEventWhere.UserID.EQ(null.IntFrom(filter.UserID)))
+	}
+	if filter.Type != "" {
+		qms = append(qms, model.SecurityEventWhere.Type.EQ(filter.Type))
+	}
+	if !filter.From.IsZero() {
+		qms = append(qms, model.SecurityEventWhere.CreatedAt.GTE(filter.From))
+	}
+	if !filter.To.IsZero() {
+		qms = append(qms, model.SecurityEventWhere.CreatedAt.LT(filter.To))
+	}
+
+	// 2. Calculate total rows of the filtered events
+	totalCount, err := model.SecurityEvents(qms...).Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 3. Add sort and pagination condition, default pagination is page 1, limit 20
+	pagination := filter.Pagination
+	if pagination == (Pagination{}) {
+		pagination = Pagination{Page: 1, Limit: 20}
+	}
+	qms = append(qms,
+		qm.OrderBy(model.SecurityEventTableColumns.CreatedAt+" DESC, "+model.SecurityEventTableColumns.ID+" DESC"),
+		qm.Offset(pagination.Limit*(pagination.Page-1)),
+		qm.Limit(pagination.Limit),
+	)
+
+	slice, err := model.SecurityEvents(qms...).All(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]model.SecurityEvent, 0, len(slice))
+	for _, e := range slice {
+		if e.Email, err = r.keys.Decrypt(e.Email); err != nil {
+			return nil, 0, err
+		}
+		result = append(result, *e)
+	}
+	return result, totalCount, nil
+}
+
+// GetUserSecurityEvents returns all security events of the user in the organization, the oldest first.
+// The failed logins of unknown emails are not tied to any user, so they are not included.
+func (r impl) GetUserSecurityEvents(ctx context.Context, userID int) ([]model.SecurityEvent, error) {
+	slice, err := model.SecurityEvents(
+		model.SecurityEventWhere.UserID.EQ(null.IntFrom(userID)),
+		tenant.Where(ctx, model.SecurityEventTableColumns.OrganizationID),
+		qm.OrderBy(model.SecurityEventTableColumns.CreatedAt+", "+model.SecurityEventTableColumns.ID),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.SecurityEvent, 0, len(slice))
+	for _, e := range slice {
+		if e.Email, err = r.keys.Decrypt(e.Email); err != nil {
+			return nil, err
+		}
+		result = append(result, *e)
+	}
+	return result, nil
+}
+
+// AnonymizeSecurityEvents clears the personal data of the security events of the user.
+// The types and the times of the events are kept, so the audit trail of the organization stays complete.
+func (r impl) AnonymizeSecurityEvents(ctx context.Context, tx *sql.Tx, userID int) error {
+	_, err := model.SecurityEvents(model.SecurityEventWhere.UserID.EQ(null.IntFrom(userID))).UpdateAll(ctx, tx, model.M{
+		model.SecurityEventColumns.Email:     "",
+		model.SecurityEventColumns.IPAddress: "",
+		model.SecurityEventColumns.UserAgent: "",
+	})
+	return err
+}
+
+// ReencryptSecurityEvents encrypts the plaintext emails of the batch of security events after "afterID" and re-encrypts the ones encrypted by retired keys with the active key.
+// It runs across the organizations.
+func (r impl) ReencryptSecurityEvents(ctx context.Context, afterID int, limit int) (user.ReencryptResult, error) {
+	events, err := model.SecurityEvents(
+		model.SecurityEventWhere.ID.GT(afterID),
+		qm.OrderBy(model.SecurityEventColumns.ID),
+		qm.Limit(limit),
+	).All(ctx, r.db)
+	if err != nil {
+		return user.ReencryptResult{}, err
+	}
+
+	result := user.ReencryptResult{LastID: afterID, Scanned: len(events)}
+	for _, event := range events {
+		result.LastID = event.ID
+		if !r.keys.NeedsReencrypt(event.Email) {
+			continue
+		}
+
+		email, err := r.keys.Decrypt(event.Email)
+		if err != nil {
+			return result, err
+		}
+		encrypted, err := r.keys.Encrypt(email)
+		if err != nil {
+			return result, err
+		}
+
+		affected, err := model.SecurityEvents(
+			model.SecurityEventWhere.ID.EQ(event.ID),
+			model.SecurityEventWhere.Email.EQ(event.Email),
+		).UpdateAll(ctx, r.db, model.M{model.SecurityEventColumns.Email: encrypted})
+		if err != nil {
+			return result, err
+		}
+		result.Reencrypted += affected
+	}
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package securityevent
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) CreateSecurityEvent(ctx context.Context, event model.SecurityEvent) (model.SecurityEvent, error) {
+	args := m.Called(ctx, event)
+	return args.Get(0).(model.SecurityEvent), args.Error(1)
+}
+
+func (m *Mock) GetSecurityEvents(ctx context.Context, filter Filter) ([]model.SecurityEvent, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]model.SecurityEvent), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *Mock) GetUserSecurityEvents(ctx context.Context, userID int) ([]model.SecurityEvent, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.SecurityEvent), args.Error(1)
+}
+
+func (m *Mock) AnonymizeSecurityEvents(ctx context.Context, tx *sql.Tx, userID int) error {
+	args := m.Called(ctx, tx, userID)
+	return args.Error(0)
+}
+
+func (m *Mock) ReencryptSecurityEvents(ctx context.Context, afterID int, limit int) (user.ReencryptResult, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).(user.ReencryptResult), args.Error(1)
+}
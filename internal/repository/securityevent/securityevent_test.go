@@ -0,0 +1,267 @@
+package securityevent
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption/encryptiontest"
+)
+
+const cleanUpQuery = "DELETE FROM security_events; DELETE FROM users; DELETE FROM organizations WHERE id >= 100;"
+
+func TestSecurityEventRepository_CreateSecurityEvent(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx          context.Context
+		given             model.SecurityEvent
+		expOrganizationID int
+	}{
+		"success": {
+			givenCtx:          context.Background(),
+			given:             model.SecurityEvent{UserID: null.IntFrom(12), OrganizationID: 100, Type: TypeLoginSucceeded, Email: "test2@example.com", IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1"},
+			expOrganizationID: 100,
+		},
+		"unknown_user": {
+			givenCtx:          context.Background(),
+			given:             model.SecurityEvent{Type: TypeLoginFailed, Email: "unknown@example.com", IPAddress: "192.0.2.1"},
+			expOrganizationID: auth.DefaultOrganizationID,
+		},
+		"organization_of_request": {
+			givenCtx:          auth.NewTenantContext(context.Background(), 100),
+			given:             model.SecurityEvent{UserID: null.IntFrom(12), Type: TypeRoleChanged, Email: "test2@example.com"},
+			expOrganizationID: 100,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/security_events.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.CreateSecurityEvent(tc.givenCtx, tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.NotZero(t, result.ID)
+			require.Equal(t, tc.expOrganizationID, result.OrganizationID)
+			require.False(t, result.CreatedAt.IsZero())
+			require.Equal(t, tc.given.Email, result.Email)
+			stored, err := model.FindSecurityEvent(context.Background(), dbTest, result.ID)
+			require.NoError(t, err)
+			require.True(t, encryption.IsEncrypted(stored.Email))
+		})
+	}
+}
+
+func TestSecurityEventRepository_GetSecurityEvents(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx      context.Context
+		givenFilter   Filter
+		expIDs        []int
+		expEmails     []string
+		expTotalCount int64
+	}{
+		"default_organization": {
+			givenCtx:      auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			expIDs:        []int{3, 2, 1},
+			expTotalCount: 3,
+		},
+		"other_organization": {
+			givenCtx:      auth.NewTenantContext(context.Background(), 100),
+			expIDs:        []int{4},
+			expEmails:     []string{"test2@example.com"},
+			expTotalCount: 1,
+		},
+		"by_user": {
+			givenCtx:      context.Background(),
+			givenFilter:   Filter{UserID: 11},
+			expIDs:        []int{3, 1},
+			expTotalCount: 2,
+		},
+		"by_type": {
+			givenCtx:      auth.NewUnscopedContext(context.Background()),
+			givenFilter:   Filter{Type: TypeLoginSucceeded},
+			expIDs:        []int{4, 1},
+			expTotalCount: 2,
+		},
+		"by_time_range": {
+			givenCtx: context.Background(),
+			givenFilter: Filter{
+				From: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2022, 1, 4, 0, 0, 0, 0, time.UTC),
+			},
+			expIDs:        []int{3, 2},
+			expTotalCount: 2,
+		},
+		"paginated": {
+			givenCtx:      auth.NewUnscopedContext(context.Background()),
+			givenFilter:   Filter{Pagination: Pagination{Page: 2, Limit: 3}},
+			expIDs:        []int{1},
+			expTotalCount: 4,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/security_events.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, totalCount, err := repo.GetSecurityEvents(tc.givenCtx, tc.givenFilter)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expTotalCount, totalCount)
+			ids := make([]int, 0, len(result))
+			for _, e := range result {
+				ids = append(ids, e.ID)
+			}
+			require.Equal(t, tc.expIDs, ids)
+			if tc.expEmails != nil {
+				emails := make([]string, 0, len(result))
+				for _, e := range result {
+					emails = append(emails, e.Email)
+				}
+				require.Equal(t, tc.expEmails, emails)
+			}
+		})
+	}
+}
+
+func TestSecurityEventRepository_GetUserSecurityEvents(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx    context.Context
+		givenUserID int
+		expIDs      []int
+		expEmails   []string
+	}{
+		"success": {
+			givenCtx:    context.Background(),
+			givenUserID: 11,
+			expIDs:      []int{1, 3},
+			expEmails:   []string{"test1@example.com", "test1@example.com"},
+		},
+		"encrypted_email": {
+			givenCtx:    auth.NewTenantContext(context.Background(), 100),
+			givenUserID: 12,
+			expIDs:      []int{4},
+			expEmails:   []string{"test2@example.com"},
+		},
+		"other_organization": {
+			givenCtx:    context.Background(),
+			givenUserID: 12,
+			expIDs:      []int{},
+			expEmails:   []string{},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/security_events.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.GetUserSecurityEvents(tc.givenCtx, tc.givenUserID)
+
+			// Then
+			require.NoError(t, err)
+			ids := make([]int, 0, len(result))
+			emails := make([]string, 0, len(result))
+			for _, e := range result {
+				ids = append(ids, e.ID)
+				emails = append(emails, e.Email)
+			}
+			require.Equal(t, tc.expIDs, ids)
+			require.Equal(t, tc.expEmails, emails)
+		})
+	}
+}
+
+func TestSecurityEventRepository_AnonymizeSecurityEvents(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/security_events.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest, encryptiontest.KeyRing())
+
+	// When
+	tx, err := dbTest.Begin()
+	require.NoError(t, err)
+	err = repo.AnonymizeSecurityEvents(context.Background(), tx, 11)
+	require.NoError(t, tx.Commit())
+
+	// Then
+	require.NoError(t, err)
+	events, err := model.SecurityEvents(qm.OrderBy(model.SecurityEventColumns.ID)).All(context.Background(), dbTest)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	for _, e := range events {
+		if e.UserID.Int == 11 {
+			// The events of the user are kept without its personal data
+			require.Empty(t, e.Email)
+			require.Empty(t, e.IPAddress)
+			require.Empty(t, e.UserAgent)
+			require.NotEmpty(t, e.Type)
+		} else {
+			require.NotEmpty(t, e.Email)
+			require.NotEmpty(t, e.IPAddress)
+		}
+	}
+}
+
+func TestSecurityEventRepository_ReencryptSecurityEvents(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/security_events.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest, encryptiontest.KeyRing())
+
+	// When
+	result, err := repo.ReencryptSecurityEvents(context.Background(), 1, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Scanned)
+	require.Equal(t, 4, result.LastID)
+	require.Equal(t, int64(2), result.Reencrypted)
+	for id, encrypted := range map[int]bool{1: false, 2: true, 3: true, 4: true} {
+		stored, err := model.FindSecurityEvent(context.Background(), dbTest, id)
+		require.NoError(t, err)
+		require.Equal(t, encrypted, encryption.IsEncrypted(stored.Email))
+	}
+}
@@ -0,0 +1,14 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "organization_id") VALUES
+(10, 'admin', 'admin@example.com', 'test', 'test', 'ADMIN', true, 1),
+(11, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true, 1),
+(12, 'test2', 'test2@example.com', 'test', 'test', 'GUEST', true, 100);
+
+-- The emails of the events 1-3 are plaintext, written before the encryption was enabled, the email of event 4 is encrypted with the keys of encryptiontest
+INSERT INTO "security_events" ("id", "user_id", "organization_id", "type", "email", "ip_address", "user_agent", "created_at") VALUES
+(1, 11, 1, 'LOGIN_SUCCEEDED', 'test1@example.com', '192.0.2.1', 'curl/7.79.1', '2022-01-01 10:00:00+00'),
+(2, NULL, 1, 'LOGIN_FAILED', 'unknown@example.com', '192.0.2.2', 'curl/7.79.1', '2022-01-02 10:00:00+00'),
+(3, 11, 1, 'PASSWORD_CHANGED', 'test1@example.com', '192.0.2.1', 'curl/7.79.1', '2022-01-03 10:00:00+00'),
+(4, 12, 100, 'LOGIN_SUCCEEDED', 'enc:v1:default:soRPu6+R4i02FSw92zssddPaIkcC3wJRXNBBoM/YJ7r8I6Mo8YtXIJKHV8j5TxMndPftdReWIHMmlI1d:xZyJWbbtezpr+lJlmfb84hsHo6AwPtCWuaqaEk2FhFKDcNFJheHg9g7vLsp/', '192.0.2.3', 'curl/7.79.1', '2022-01-04 10:00:00+00');
@@ -0,0 +1,30 @@
+// Package tenant limits the repository queries to the organization of the request
+package tenant
+
+import (
+	"context"
+
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+// Where returns a query mod which limits the query to the organization ctx is scoped to.
+// column is the qualified organization_id column of the table, e.g. model.ProductTableColumns.OrganizationID.
+// A ctx without any scope, e.g. of jobs and anonymous requests without an organization, is limited to auth.DefaultOrganizationID like ID.
+// Nothing is limited only if ctx was made by auth.NewUnscopedContext.
+func Where(ctx context.Context, column string) qm.QueryMod {
+	if auth.IsUnscoped(ctx) {
+		return qm.QueryModFunc(func(q *queries.Query) {})
+	}
+	return qm.Where(column+" = ?", ID(ctx))
+}
+
+// ID returns the organization which the rows created with ctx belong to
+func ID(ctx context.Context) int {
+	if organizationID, ok := auth.TenantFromContext(ctx); ok {
+		return organizationID
+	}
+	return auth.DefaultOrganizationID
+}
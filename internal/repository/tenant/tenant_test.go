@@ -0,0 +1,71 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestWhere(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx  context.Context
+		expResult string
+		expArgs   []interface{}
+	}{
+		"not scoped falls back to the default organization": {
+			givenCtx:  context.Background(),
+			expResult: `SELECT * FROM "products" WHERE (products.organization_id = $1);`,
+			expArgs:   []interface{}{auth.DefaultOrganizationID},
+		},
+		"unscoped": {
+			givenCtx:  auth.NewUnscopedContext(context.Background()),
+			expResult: `SELECT * FROM "products";`,
+		},
+		"scoped after unscoped": {
+			givenCtx:  auth.NewTenantContext(auth.NewUnscopedContext(context.Background()), 3),
+			expResult: `SELECT * FROM "products" WHERE (products.organization_id = $1);`,
+			expArgs:   []interface{}{3},
+		},
+		"scoped by user": {
+			givenCtx:  auth.NewContext(context.Background(), auth.User{ID: 1, OrganizationID: 2}),
+			expResult: `SELECT * FROM "products" WHERE (products.organization_id = $1);`,
+			expArgs:   []interface{}{2},
+		},
+		"scoped by request": {
+			givenCtx:  auth.NewTenantContext(context.Background(), 3),
+			expResult: `SELECT * FROM "products" WHERE (products.organization_id = $1);`,
+			expArgs:   []interface{}{3},
+		},
+		"latest scope wins": {
+			givenCtx:  auth.NewTenantContext(auth.NewContext(context.Background(), auth.User{ID: 1, OrganizationID: 2}), 3),
+			expResult: `SELECT * FROM "products" WHERE (products.organization_id = $1);`,
+			expArgs:   []interface{}{3},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			q := model.NewQuery(qm.From(`"products"`), Where(tc.givenCtx, "products.organization_id"))
+
+			// WHEN
+			result, args := queries.BuildQuery(q)
+
+			// THEN
+			require.Equal(t, tc.expResult, result)
+			require.Equal(t, tc.expArgs, args)
+		})
+	}
+}
+
+func TestID(t *testing.T) {
+	require.Equal(t, auth.DefaultOrganizationID, ID(context.Background()))
+	require.Equal(t, 2, ID(auth.NewContext(context.Background(), auth.User{ID: 1, OrganizationID: 2})))
+	require.Equal(t, auth.DefaultOrganizationID, ID(auth.NewUnscopedContext(context.Background())))
+}
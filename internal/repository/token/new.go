@@ -0,0 +1,50 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type IToken interface {
+	// CreateRefreshToken creates a new refresh token
+	CreateRefreshToken(ctx context.Context, token model.RefreshToken) (model.RefreshToken, error)
+
+	// GetRefreshTokenByHash returns the refresh token with the given hash
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (model.RefreshToken, error)
+
+	// RevokeRefreshToken revokes the refresh token with the given id if it is not revoked yet
+	RevokeRefreshToken(ctx context.Context, id int) (int64, error)
+
+	// RevokeTokenFamily revokes all refresh tokens of the given family
+	RevokeTokenFamily(ctx context.Context, familyID string) (int64, error)
+
+	// RevokeUserRefreshTokens revokes all refresh tokens of the given user
+	RevokeUserRefreshTokens(ctx context.Context, userID int) (int64, error)
+
+	// RevokeAccessToken adds the access token id to the denylist until it expires
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+
+	// IsAccessTokenRevoked returns true if the access token id is in the denylist
+	// or the token was issued before the sessions of its user were revoked
+	IsAccessTokenRevoked(ctx context.Context, jti string, userID int, issuedAt time.Time) (bool, error)
+
+	// CreatePasswordResetToken creates a new password reset token
+	CreatePasswordResetToken(ctx context.Context, token model.PasswordResetToken) (model.PasswordResetToken, error)
+
+	// GetPasswordResetTokenByHash returns the password reset token with the given hash
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (model.PasswordResetToken, error)
+
+	// UsePasswordResetToken marks the password reset token as used if it is not used yet
+	UsePasswordResetToken(ctx context.Context, id int) (int64, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IToken {
+	return impl{db: db}
+}
@@ -0,0 +1,16 @@
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "sessions_revoked_at") VALUES
+(10, 'test1', 'test1@example.com', 'test', 'test', 'ADMIN', true, NULL),
+(11, 'test2', 'test2@example.com', 'test', 'test', 'GUEST', true, NOW());
+
+INSERT INTO "refresh_tokens" ("id", "user_id", "token_hash", "family_id", "expires_at", "revoked_at") VALUES
+(1, 10, 'hash1', 'family1', NOW() + INTERVAL '1 day', NULL),
+(2, 10, 'hash2', 'family1', NOW() + INTERVAL '1 day', NOW()),
+(3, 10, 'hash3', 'family2', NOW() + INTERVAL '1 day', NULL);
+
+INSERT INTO "revoked_access_tokens" ("jti", "expires_at") VALUES
+('jti1', NOW() + INTERVAL '1 hour'),
+('jti2', NOW() - INTERVAL '1 hour');
+
+INSERT INTO "password_reset_tokens" ("id", "user_id", "token_hash", "expires_at", "used_at") VALUES
+(1, 10, 'reset1', NOW() + INTERVAL '30 minutes', NULL),
+(2, 10, 'reset2', NOW() + INTERVAL '30 minutes', NOW());
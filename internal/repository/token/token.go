@@ -0,0 +1,121 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+// CreateRefreshToken creates a new refresh token
+func (r impl) CreateRefreshToken(ctx context.Context, token model.RefreshToken) (model.RefreshToken, error) {
+	if err := token.Insert(ctx, r.db, boil.Whitelist("user_id", "token_hash", "family_id", "expires_at", "created_at", "updated_at")); err != nil {
+		return model.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+// GetRefreshTokenByHash returns the refresh token with the given hash
+func (r impl) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (model.RefreshToken, error) {
+	result, err := model.RefreshTokens(model.RefreshTokenWhere.TokenHash.EQ(tokenHash)).One(ctx, r.db)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	return *result, nil
+}
+
+// RevokeRefreshToken revokes the refresh token, the affected rows is 0 if it was already revoked
+func (r impl) RevokeRefreshToken(ctx context.Context, id int) (int64, error) {
+	now := time.Now()
+	return model.RefreshTokens(
+		model.RefreshTokenWhere.ID.EQ(id),
+		model.RefreshTokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, r.db, model.M{
+		model.RefreshTokenColumns.RevokedAt: null.TimeFrom(now),
+		model.RefreshTokenColumns.UpdatedAt: now,
+	})
+}
+
+// RevokeTokenFamily revokes all active refresh tokens of the family
+func (r impl) RevokeTokenFamily(ctx context.Context, familyID string) (int64, error) {
+	now := time.Now()
+	return model.RefreshTokens(
+		model.RefreshTokenWhere.FamilyID.EQ(familyID),
+		model.RefreshTokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, r.db, model.M{
+		model.RefreshTokenColumns.RevokedAt: null.TimeFrom(now),
+		model.RefreshTokenColumns.UpdatedAt: now,
+	})
+}
+
+// RevokeUserRefreshTokens revokes all active refresh tokens of the user
+func (r impl) RevokeUserRefreshTokens(ctx context.Context, userID int) (int64, error) {
+	now := time.Now()
+	return model.RefreshTokens(
+		model.RefreshTokenWhere.UserID.EQ(userID),
+		model.RefreshTokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, r.db, model.M{
+		model.RefreshTokenColumns.RevokedAt: null.TimeFrom(now),
+		model.RefreshTokenColumns.UpdatedAt: now,
+	})
+}
+
+// RevokeAccessToken adds the access token id to the denylist, revoking the same token twice is not an error
+func (r impl) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	token := model.RevokedAccessToken{
+		Jti:       jti,
+		ExpiresAt: expiresAt,
+	}
+	return token.Upsert(ctx, r.db, false, []string{model.RevokedAccessTokenColumns.Jti}, boil.None(), boil.Infer())
+}
+
+// IsAccessTokenRevoked returns true if the access token id is in the denylist and not expired yet,
+// or the sessions of the user were revoked after the token was issued
+func (r impl) IsAccessTokenRevoked(ctx context.Context, jti string, userID int, issuedAt time.Time) (bool, error) {
+	denied, err := model.RevokedAccessTokens(
+		model.RevokedAccessTokenWhere.Jti.EQ(jti),
+		qm.Where(model.RevokedAccessTokenColumns.ExpiresAt+" > NOW()"),
+	).Exists(ctx, r.db)
+	if err != nil || denied {
+		return denied, err
+	}
+
+	// issued_at of a token only has second precision
+	return model.Users(
+		model.UserWhere.ID.EQ(userID),
+		qm.Where("date_trunc('second', "+model.UserColumns.SessionsRevokedAt+") > ?", issuedAt),
+	).Exists(ctx, r.db)
+}
+
+// CreatePasswordResetToken creates a new password reset token
+func (r impl) CreatePasswordResetToken(ctx context.Context, token model.PasswordResetToken) (model.PasswordResetToken, error) {
+	if err := token.Insert(ctx, r.db, boil.Whitelist("user_id", "token_hash", "expires_at", "created_at", "updated_at")); err != nil {
+		return model.PasswordResetToken{}, err
+	}
+	return token, nil
+}
+
+// GetPasswordResetTokenByHash returns the password reset token with the given hash
+func (r impl) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (model.PasswordResetToken, error) {
+	result, err := model.PasswordResetTokens(model.PasswordResetTokenWhere.TokenHash.EQ(tokenHash)).One(ctx, r.db)
+	if err != nil {
+		return model.PasswordResetToken{}, err
+	}
+	return *result, nil
+}
+
+// UsePasswordResetToken marks the password reset token as used, the affected rows is 0 if it was already used
+func (r impl) UsePasswordResetToken(ctx context.Context, id int) (int64, error) {
+	now := time.Now()
+	return model.PasswordResetTokens(
+		model.PasswordResetTokenWhere.ID.EQ(id),
+		model.PasswordResetTokenWhere.UsedAt.IsNull(),
+	).UpdateAll(ctx, r.db, model.M{
+		model.PasswordResetTokenColumns.UsedAt:    null.TimeFrom(now),
+		model.PasswordResetTokenColumns.UpdatedAt: now,
+	})
+}
@@ -0,0 +1,64 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) CreateRefreshToken(ctx context.Context, token model.RefreshToken) (model.RefreshToken, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(model.RefreshToken), args.Error(1)
+}
+
+func (m *Mock) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (model.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(model.RefreshToken), args.Error(1)
+}
+
+func (m *Mock) RevokeRefreshToken(ctx context.Context, id int) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) RevokeTokenFamily(ctx context.Context, familyID string) (int64, error) {
+	args := m.Called(ctx, familyID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	args := m.Called(ctx, jti, expiresAt)
+	return args.Error(0)
+}
+
+func (m *Mock) RevokeUserRefreshTokens(ctx context.Context, userID int) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) IsAccessTokenRevoked(ctx context.Context, jti string, userID int, issuedAt time.Time) (bool, error) {
+	args := m.Called(ctx, jti, userID, issuedAt)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+func (m *Mock) CreatePasswordResetToken(ctx context.Context, token model.PasswordResetToken) (model.PasswordResetToken, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(model.PasswordResetToken), args.Error(1)
+}
+
+func (m *Mock) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (model.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(model.PasswordResetToken), args.Error(1)
+}
+
+func (m *Mock) UsePasswordResetToken(ctx context.Context, id int) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,405 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+func TestTokenRepository_CreateRefreshToken(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+	tcs := map[string]struct {
+		given     model.RefreshToken
+		expResult model.RefreshToken
+		expErr    error
+	}{
+		"success": {
+			given: model.RefreshToken{
+				UserID:    10,
+				TokenHash: "hash4",
+				FamilyID:  "family3",
+				ExpiresAt: expiresAt,
+			},
+			expResult: model.RefreshToken{
+				UserID:    10,
+				TokenHash: "hash4",
+				FamilyID:  "family3",
+				ExpiresAt: expiresAt,
+			},
+		},
+		"error_duplicate_hash": {
+			given: model.RefreshToken{
+				UserID:    10,
+				TokenHash: "hash1",
+				FamilyID:  "family3",
+				ExpiresAt: expiresAt,
+			},
+			expErr: errors.New("model: unable to insert into refresh_tokens: pq: duplicate key value violates unique constraint \"token_hash_on_refresh_tokens\""),
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.CreateRefreshToken(context.Background(), tc.given)
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				tc.expResult.ID = result.ID
+				tc.expResult.CreatedAt = result.CreatedAt
+				tc.expResult.UpdatedAt = result.UpdatedAt
+				require.Equal(t, tc.expResult, result)
+			}
+		})
+	}
+}
+
+func TestTokenRepository_GetRefreshTokenByHash(t *testing.T) {
+	tcs := map[string]struct {
+		given     string
+		expResult model.RefreshToken
+		expErr    error
+	}{
+		"success": {
+			given: "hash1",
+			expResult: model.RefreshToken{
+				ID:        1,
+				UserID:    10,
+				TokenHash: "hash1",
+				FamilyID:  "family1",
+			},
+		},
+		"error_not_found": {
+			given:  "hash5",
+			expErr: sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetRefreshTokenByHash(context.Background(), tc.given)
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				tc.expResult.ExpiresAt = result.ExpiresAt
+				tc.expResult.CreatedAt = result.CreatedAt
+				tc.expResult.UpdatedAt = result.UpdatedAt
+				require.Equal(t, tc.expResult, result)
+			}
+		})
+	}
+}
+
+func TestTokenRepository_RevokeRefreshToken(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   1,
+			rowsAff: 1,
+		},
+		"already_revoked": {
+			given:   2,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.RevokeRefreshToken(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
+
+func TestTokenRepository_RevokeTokenFamily(t *testing.T) {
+	tcs := map[string]struct {
+		given   string
+		rowsAff int64
+	}{
+		"success": {
+			given:   "family1",
+			rowsAff: 1,
+		},
+		"not_found": {
+			given:   "family5",
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.RevokeTokenFamily(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
+
+func TestTokenRepository_RevokeAccessToken(t *testing.T) {
+	tcs := map[string]struct {
+		given string
+	}{
+		"success": {
+			given: "jti3",
+		},
+		"already_revoked": {
+			given: "jti1",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			err := repo.RevokeAccessToken(context.Background(), tc.given, time.Now().Add(time.Hour))
+
+			// Then
+			require.NoError(t, err)
+			revoked, err := repo.IsAccessTokenRevoked(context.Background(), tc.given, 10, time.Now())
+			require.NoError(t, err)
+			require.True(t, revoked)
+		})
+	}
+}
+
+func TestTokenRepository_IsAccessTokenRevoked(t *testing.T) {
+	type givenData struct {
+		jti      string
+		userID   int
+		issuedAt time.Time
+	}
+	tcs := map[string]struct {
+		given     givenData
+		expResult bool
+	}{
+		"revoked": {
+			given:     givenData{jti: "jti1", userID: 10, issuedAt: time.Now()},
+			expResult: true,
+		},
+		"revoked_but_expired": {
+			given:     givenData{jti: "jti2", userID: 10, issuedAt: time.Now()},
+			expResult: false,
+		},
+		"not_revoked": {
+			given:     givenData{jti: "jti3", userID: 10, issuedAt: time.Now()},
+			expResult: false,
+		},
+		"issued_before_sessions_revoked": {
+			given:     givenData{jti: "jti3", userID: 11, issuedAt: time.Now().Add(-time.Hour)},
+			expResult: true,
+		},
+		"issued_after_sessions_revoked": {
+			given:     givenData{jti: "jti3", userID: 11, issuedAt: time.Now().Add(time.Hour)},
+			expResult: false,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.IsAccessTokenRevoked(context.Background(), tc.given.jti, tc.given.userID, tc.given.issuedAt)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expResult, result)
+		})
+	}
+}
+
+func TestTokenRepository_RevokeUserRefreshTokens(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   10,
+			rowsAff: 2,
+		},
+		"no_tokens": {
+			given:   11,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.RevokeUserRefreshTokens(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
+
+func TestTokenRepository_GetPasswordResetTokenByHash(t *testing.T) {
+	tcs := map[string]struct {
+		given     string
+		expResult model.PasswordResetToken
+		expErr    error
+	}{
+		"success": {
+			given: "reset1",
+			expResult: model.PasswordResetToken{
+				ID:        1,
+				UserID:    10,
+				TokenHash: "reset1",
+			},
+		},
+		"error_not_found": {
+			given:  "reset5",
+			expErr: sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetPasswordResetTokenByHash(context.Background(), tc.given)
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				tc.expResult.ExpiresAt = result.ExpiresAt
+				tc.expResult.CreatedAt = result.CreatedAt
+				tc.expResult.UpdatedAt = result.UpdatedAt
+				require.Equal(t, tc.expResult, result)
+			}
+		})
+	}
+}
+
+func TestTokenRepository_UsePasswordResetToken(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   1,
+			rowsAff: 1,
+		},
+		"already_used": {
+			given:   2,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/tokens.sql")
+			defer dbTest.Exec("DELETE FROM password_reset_tokens; DELETE FROM revoked_access_tokens; DELETE FROM refresh_tokens; DELETE FROM users;")
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.UsePasswordResetToken(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type ITwoFactor interface {
+	// GetTOTPSecret returns the TOTP secret of the user
+	GetTOTPSecret(ctx context.Context, userID int) (model.TotpSecret, error)
+
+	// SaveTOTPSecret saves a new unconfirmed TOTP secret of the user, it replaces the existing one
+	SaveTOTPSecret(ctx context.Context, userID int, secret string) error
+
+	// ConfirmTOTPSecret confirms the TOTP secret of the user if it is not confirmed yet, the step is marked as used
+	ConfirmTOTPSecret(ctx context.Context, tx *sql.Tx, userID int, step int64) (int64, error)
+
+	// UseTOTPStep marks the time step as used if it is after the last used step
+	UseTOTPStep(ctx context.Context, userID int, step int64) (int64, error)
+
+	// ReplaceBackupCodes deletes the backup codes of the user and creates new ones with the given hashes
+	ReplaceBackupCodes(ctx context.Context, tx *sql.Tx, userID int, codeHashes []string) error
+
+	// UseBackupCode marks the backup code of the user with the given hash as used if it is not used yet
+	UseBackupCode(ctx context.Context, userID int, codeHash string) (int64, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) ITwoFactor {
+	return impl{db: db}
+}
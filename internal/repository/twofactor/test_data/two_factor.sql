@@ -0,0 +1,12 @@
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
+(10, 'test1', 'test1@example.com', 'test', 'test', 'ADMIN', true),
+(11, 'test2', 'test2@example.com', 'test', 'test', 'ADMIN', true),
+(12, 'test3', 'test3@example.com', 'test', 'test', 'ADMIN', true);
+
+INSERT INTO "totp_secrets" ("id", "user_id", "secret", "confirmed_at", "last_used_step") VALUES
+(1, 10, 'SECRET1', NOW(), 100),
+(2, 11, 'SECRET2', NULL, NULL);
+
+INSERT INTO "backup_codes" ("id", "user_id", "code_hash", "used_at") VALUES
+(1, 10, 'hash1', NULL),
+(2, 10, 'hash2', NOW());
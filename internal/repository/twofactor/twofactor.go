@@ -0,0 +1,99 @@
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+// GetTOTPSecret returns the TOTP secret of the user
+func (r impl) GetTOTPSecret(ctx context.Context, userID int) (model.TotpSecret, error) {
+	result, err := model.TotpSecrets(model.TotpSecretWhere.UserID.EQ(userID)).One(ctx, r.db)
+	if err != nil {
+		return model.TotpSecret{}, err
+	}
+	return *result, nil
+}
+
+// SaveTOTPSecret saves a new unconfirmed TOTP secret of the user, it replaces the existing one
+func (r impl) SaveTOTPSecret(ctx context.Context, userID int, secret string) error {
+	totpSecret := model.TotpSecret{
+		UserID: userID,
+		Secret: secret,
+	}
+	return totpSecret.Upsert(ctx, r.db, true,
+		[]string{model.TotpSecretColumns.UserID},
+		boil.Whitelist(
+			model.TotpSecretColumns.Secret,
+			model.TotpSecretColumns.ConfirmedAt,
+			model.TotpSecretColumns.LastUsedStep,
+			model.TotpSecretColumns.UpdatedAt,
+		),
+		boil.Whitelist("user_id", "secret", "created_at", "updated_at"),
+	)
+}
+
+// ConfirmTOTPSecret confirms the TOTP secret, the affected rows is 0 if it was already confirmed
+func (r impl) ConfirmTOTPSecret(ctx context.Context, tx *sql.Tx, userID int, step int64) (int64, error) {
+	now := time.Now()
+	return model.TotpSecrets(
+		model.TotpSecretWhere.UserID.EQ(userID),
+		model.TotpSecretWhere.ConfirmedAt.IsNull(),
+	).UpdateAll(ctx, tx, model.M{
+		model.TotpSecretColumns.ConfirmedAt:  null.TimeFrom(now),
+		model.TotpSecretColumns.LastUsedStep: null.Int64From(step),
+		model.TotpSecretColumns.UpdatedAt:    now,
+	})
+}
+
+// UseTOTPStep marks the time step as used, the affected rows is 0 if the step or a later one was already used
+func (r impl) UseTOTPStep(ctx context.Context, userID int, step int64) (int64, error) {
+	return model.TotpSecrets(
+		model.TotpSecretWhere.UserID.EQ(userID),
+		model.TotpSecretWhere.ConfirmedAt.IsNotNull(),
+		qm.Expr(
+			model.TotpSecretWhere.LastUsedStep.IsNull(),
+			qm.Or2(model.TotpSecretWhere.LastUsedStep.LT(null.Int64From(step))),
+		),
+	).UpdateAll(ctx, r.db, model.M{
+		model.TotpSecretColumns.LastUsedStep: null.Int64From(step),
+		model.TotpSecretColumns.UpdatedAt:    time.Now(),
+	})
+}
+
+// ReplaceBackupCodes deletes the backup codes of the user and creates new ones with the given hashes
+func (r impl) ReplaceBackupCodes(ctx context.Context, tx *sql.Tx, userID int, codeHashes []string) error {
+	if _, err := model.BackupCodes(model.BackupCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		code := model.BackupCode{
+			UserID:   userID,
+			CodeHash: codeHash,
+		}
+		if err := code.Insert(ctx, tx, boil.Whitelist("user_id", "code_hash", "created_at", "updated_at")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseBackupCode marks the backup code as used, the affected rows is 0 if it does not exist or was already used
+func (r impl) UseBackupCode(ctx context.Context, userID int, codeHash string) (int64, error) {
+	now := time.Now()
+	return model.BackupCodes(
+		model.BackupCodeWhere.UserID.EQ(userID),
+		model.BackupCodeWhere.CodeHash.EQ(codeHash),
+		model.BackupCodeWhere.UsedAt.IsNull(),
+	).UpdateAll(ctx, r.db, model.M{
+		model.BackupCodeColumns.UsedAt:    null.TimeFrom(now),
+		model.BackupCodeColumns.UpdatedAt: now,
+	})
+}
@@ -0,0 +1,44 @@
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetTOTPSecret(ctx context.Context, userID int) (model.TotpSecret, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(model.TotpSecret), args.Error(1)
+}
+
+func (m *Mock) SaveTOTPSecret(ctx context.Context, userID int, secret string) error {
+	args := m.Called(ctx, userID, secret)
+	return args.Error(0)
+}
+
+func (m *Mock) ConfirmTOTPSecret(ctx context.Context, tx *sql.Tx, userID int, step int64) (int64, error) {
+	args := m.Called(ctx, tx, userID, step)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) UseTOTPStep(ctx context.Context, userID int, step int64) (int64, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) ReplaceBackupCodes(ctx context.Context, tx *sql.Tx, userID int, codeHashes []string) error {
+	args := m.Called(ctx, tx, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *Mock) UseBackupCode(ctx context.Context, userID int, codeHash string) (int64, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Get(0).(int64), args.Error(1)
+}
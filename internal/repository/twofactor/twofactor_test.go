@@ -0,0 +1,249 @@
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+const cleanUpQuery = "DELETE FROM backup_codes; DELETE FROM totp_secrets; DELETE FROM users;"
+
+func TestTwoFactorRepository_SaveTOTPSecret(t *testing.T) {
+	tcs := map[string]struct {
+		given int
+	}{
+		"new_secret": {
+			given: 12,
+		},
+		"replace_confirmed_secret": {
+			given: 10,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/two_factor.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			err := repo.SaveTOTPSecret(context.Background(), tc.given, "NEWSECRET")
+
+			// Then
+			require.NoError(t, err)
+			result, err := repo.GetTOTPSecret(context.Background(), tc.given)
+			require.NoError(t, err)
+			require.Equal(t, "NEWSECRET", result.Secret)
+			require.False(t, result.ConfirmedAt.Valid)
+			require.False(t, result.LastUsedStep.Valid)
+		})
+	}
+}
+
+func TestTwoFactorRepository_ConfirmTOTPSecret(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   11,
+			rowsAff: 1,
+		},
+		"already_confirmed": {
+			given:   10,
+			rowsAff: 0,
+		},
+		"not_found": {
+			given:   12,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/two_factor.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+			tx, err := dbTest.Begin()
+			require.NoError(t, err)
+
+			// When
+			result, err := repo.ConfirmTOTPSecret(context.Background(), tx, tc.given, 200)
+			require.NoError(t, tx.Commit())
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
+
+func TestTwoFactorRepository_UseTOTPStep(t *testing.T) {
+	type givenData struct {
+		userID int
+		step   int64
+	}
+	tcs := map[string]struct {
+		given   givenData
+		rowsAff int64
+	}{
+		"success": {
+			given:   givenData{userID: 10, step: 101},
+			rowsAff: 1,
+		},
+		"step_already_used": {
+			given:   givenData{userID: 10, step: 100},
+			rowsAff: 0,
+		},
+		"not_confirmed": {
+			given:   givenData{userID: 11, step: 101},
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/two_factor.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.UseTOTPStep(context.Background(), tc.given.userID, tc.given.step)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
+
+func TestTwoFactorRepository_ReplaceBackupCodes(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/two_factor.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest)
+	tx, err := dbTest.Begin()
+	require.NoError(t, err)
+
+	// When
+	err = repo.ReplaceBackupCodes(context.Background(), tx, 10, []string{"hash3", "hash4"})
+	require.NoError(t, tx.Commit())
+
+	// Then
+	require.NoError(t, err)
+	result, err := repo.UseBackupCode(context.Background(), 10, "hash1")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), result)
+	result, err = repo.UseBackupCode(context.Background(), 10, "hash3")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result)
+}
+
+func TestTwoFactorRepository_UseBackupCode(t *testing.T) {
+	type givenData struct {
+		userID   int
+		codeHash string
+	}
+	tcs := map[string]struct {
+		given   givenData
+		rowsAff int64
+	}{
+		"success": {
+			given:   givenData{userID: 10, codeHash: "hash1"},
+			rowsAff: 1,
+		},
+		"already_used": {
+			given:   givenData{userID: 10, codeHash: "hash2"},
+			rowsAff: 0,
+		},
+		"code_of_other_user": {
+			given:   givenData{userID: 11, codeHash: "hash1"},
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/two_factor.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.UseBackupCode(context.Background(), tc.given.userID, tc.given.codeHash)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+		})
+	}
+}
+
+func TestTwoFactorRepository_GetTOTPSecret(t *testing.T) {
+	tcs := map[string]struct {
+		given  int
+		expErr error
+	}{
+		"success": {
+			given: 10,
+		},
+		"not_found": {
+			given:  12,
+			expErr: sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/two_factor.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetTOTPSecret(context.Background(), tc.given)
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "SECRET1", result.Secret)
+				require.True(t, result.ConfirmedAt.Valid)
+			}
+		})
+	}
+}
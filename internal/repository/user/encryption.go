@@ -0,0 +1,116 @@
+package user
+
+import (
+	"context"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+)
+
+// EncryptUser encrypts the email and the phone of the user before it is written and sets the blind index of the email
+func EncryptUser(keys *encryption.KeyRing, user *model.User) error {
+	var err error
+	user.EmailIndex = null.StringFrom(keys.BlindIndex(user.Email))
+	if user.Email, err = keys.Encrypt(user.Email); err != nil {
+		return err
+	}
+	user.Phone, err = keys.Encrypt(user.Phone)
+	return err
+}
+
+// DecryptUser decrypts the email and the phone of the user read from the database.
+// The blind index is cleared, it is only used to find users by email.
+func DecryptUser(keys *encryption.KeyRing, user *model.User) error {
+	var err error
+	user.EmailIndex = null.String{}
+	if user.Email, err = keys.Decrypt(user.Email); err != nil {
+		return err
+	}
+	user.Phone, err = keys.Decrypt(user.Phone)
+	return err
+}
+
+// decryptUsers decrypts the users read from the database
+func decryptUsers(keys *encryption.KeyRing, users model.UserSlice) error {
+	for _, user := range users {
+		if err := DecryptUser(keys, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encryptedContact returns the encrypted email and phone columns to update, with the blind index of the email
+func encryptedContact(keys *encryption.KeyRing, email string, phone string) (model.M, error) {
+	user := model.User{Email: email, Phone: phone}
+	if err := EncryptUser(keys, &user); err != nil {
+		return nil, err
+	}
+	return model.M{
+		model.UserColumns.Email:      user.Email,
+		model.UserColumns.Phone:      user.Phone,
+		model.UserColumns.EmailIndex: user.EmailIndex,
+	}, nil
+}
+
+// emailWhere finds the users with the email by its blind index.
+// Rows written before the encryption was enabled have no index yet, they are found by the plaintext email until they are re-encrypted.
+func emailWhere(keys *encryption.KeyRing, email string) qm.QueryMod {
+	return qm.Expr(
+		model.UserWhere.EmailIndex.EQ(null.StringFrom(keys.BlindIndex(email))),
+		qm.Or2(qm.Expr(model.UserWhere.EmailIndex.IsNull(), model.UserWhere.Email.EQ(email))),
+	)
+}
+
+// ReencryptResult is the result of a batch of ReencryptUsers
+type ReencryptResult struct {
+	// LastID is the id of the last scanned user, the next batch starts after it
+	LastID      int
+	Scanned     int
+	Reencrypted int64
+}
+
+// ReencryptUsers encrypts the plaintext emails and phones of the batch of users after "afterID" and re-encrypts the ones encrypted by retired keys with the active key.
+// It runs across the organizations. A user is skipped if its email or phone was changed since the batch was read, the change was written with the active key.
+func (r impl) ReencryptUsers(ctx context.Context, afterID int, limit int) (ReencryptResult, error) {
+	users, err := model.Users(
+		model.UserWhere.ID.GT(afterID),
+		qm.OrderBy(model.UserColumns.ID),
+		qm.Limit(limit),
+	).All(ctx, r.db)
+	if err != nil {
+		return ReencryptResult{}, err
+	}
+
+	result := ReencryptResult{LastID: afterID, Scanned: len(users)}
+	for _, user := range users {
+		result.LastID = user.ID
+		if user.EmailIndex.Valid && !r.keys.NeedsReencrypt(user.Email) && !r.keys.NeedsReencrypt(user.Phone) {
+			continue
+		}
+
+		storedEmail, storedPhone := user.Email, user.Phone
+		if err = DecryptUser(r.keys, user); err != nil {
+			return result, err
+		}
+		var columns model.M
+		if columns, err = encryptedContact(r.keys, user.Email, user.Phone); err != nil {
+			return result, err
+		}
+
+		var affected int64
+		affected, err = model.Users(
+			model.UserWhere.ID.EQ(user.ID),
+			model.UserWhere.Email.EQ(storedEmail),
+			model.UserWhere.Phone.EQ(storedPhone),
+		).UpdateAll(ctx, r.db, columns)
+		if err != nil {
+			return result, err
+		}
+		result.Reencrypted += affected
+	}
+	return result, nil
+}
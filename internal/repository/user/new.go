@@ -3,13 +3,15 @@ package user
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
 )
 
 type IUser interface {
 	// CreateUser creates a new user
-	CreateUser(ctx context.Context, user model.User) (model.User, error)
+	CreateUser(ctx context.Context, tx *sql.Tx, user model.User) (model.User, error)
 
 	// ExistsUserByEmail returns true if the user exists
 	ExistsUserByEmail(ctx context.Context, email string) (bool, error)
@@ -20,28 +22,67 @@ type IUser interface {
 	// GetUsers returns all users by given Filter param
 	GetUsers(ctx context.Context, input Filter) (model.UserSlice, int64, error)
 
-	// UpdateUser updates the user
+	// UpdateUser updates the user except the password
 	UpdateUser(ctx context.Context, updateUser model.User) (int64, error)
 
+	// UpdatePassword updates the password of the user and revokes the user sessions
+	UpdatePassword(ctx context.Context, id int, password string) (int64, error)
+
+	// UpdatePasswordHash replaces the hash of the unchanged password of the user, the sessions are kept
+	UpdatePasswordHash(ctx context.Context, id int, oldHash string, newHash string) (int64, error)
+
+	// VerifyEmail marks the email of the user as verified
+	VerifyEmail(ctx context.Context, id int, email string) (int64, error)
+
+	// UpdateVerificationSentAt records a verification email is sent, it is limited to once per interval
+	UpdateVerificationSentAt(ctx context.Context, id int, interval time.Duration) (int64, error)
+
+	// UpdateProfile updates the name, phone and email of the user
+	UpdateProfile(ctx context.Context, user model.User) (int64, error)
+
+	// CreatePasswordHistory records a replaced password hash of the user
+	CreatePasswordHistory(ctx context.Context, history model.PasswordHistory) error
+
+	// GetPasswordHistories returns the latest "limit" replaced password hashes of the user
+	GetPasswordHistories(ctx context.Context, userID int, limit int) (model.PasswordHistorySlice, error)
+
 	// GetUser returns a user by input "id" param
 	GetUser(ctx context.Context, id int) (model.User, error)
 
-	// DeleteUser deletes the user with the given id
-	DeleteUser(ctx context.Context, id int) (int64, error)
+	// DeleteUser soft deletes the user with the given id and revokes its refresh tokens and API keys
+	DeleteUser(ctx context.Context, tx *sql.Tx, id int) (int64, error)
+
+	// RevokeUserSessions revokes the issued access tokens, the refresh tokens and the API keys of the user
+	RevokeUserSessions(ctx context.Context, tx *sql.Tx, id int) (int64, error)
+
+	// RestoreUser restores the soft deleted user with the given id
+	RestoreUser(ctx context.Context, id int) (int64, error)
+
+	// GetUserData returns the user with its addresses, orders, products, sign-in methods and personal data requests
+	GetUserData(ctx context.Context, id int) (model.User, error)
+
+	// EraseUser anonymizes the personal data of the user and deletes its credentials
+	EraseUser(ctx context.Context, tx *sql.Tx, id int) (int64, error)
 
 	// GetUserByEmail returns a user with the given email
 	GetUserByEmail(ctx context.Context, email string) (model.User, error)
 
 	// GetStatistics returns summary statistic of users
 	GetStatistics(ctx context.Context) (SummaryStatistics, error)
+
+	// ReencryptUsers encrypts the emails and phones of a batch of users with the active key
+	ReencryptUsers(ctx context.Context, afterID int, limit int) (ReencryptResult, error)
 }
 
 type impl struct {
-	db *sql.DB
+	db   *sql.DB
+	keys *encryption.KeyRing
 }
 
-func New(db *sql.DB) IUser {
+// New returns the user repository, the emails and the phones are encrypted with the keys
+func New(db *sql.DB, keys *encryption.KeyRing) IUser {
 	return impl{
-		db: db,
+		db:   db,
+		keys: keys,
 	}
 }
@@ -2,5 +2,18 @@ INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_act
 VALUES (2, 'test1', 'test77@example.com', 'test', 'test', 'ADMIN', true),
        (1, 'test1', 'test1@example.com', 'test', 'test', 'ADMIN', true);
 
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "deleted_at")
+VALUES (3, 'test3', 'test3@example.com', 'test', 'test', 'GUEST', true, NOW());
+
 INSERT INTO "products" (id, title, description, price, quantity, is_active, user_id)
 VALUES (1, 'test1', 'test1', 1, 1, true, 2);
+
+INSERT INTO "refresh_tokens" ("user_id", "token_hash", "family_id", "expires_at")
+VALUES (1, 'hash1', 'family1', NOW() + INTERVAL '1 day'),
+       (1, 'hash2', 'family2', NOW() + INTERVAL '1 day');
+
+INSERT INTO "api_keys" ("id", "user_id", "name", "prefix", "key_hash", "scope") VALUES
+(1, 1, 'script', 'sk_aaaaaaaa', 'hash1', 'write');
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "deleted_at", "erased_at")
+VALUES (4, 'Erased user', 'erased-4@erased.invalid', '', '', 'GUEST', false, NOW(), NOW());
@@ -0,0 +1,33 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "deleted_at", "erased_at", "organization_id") VALUES
+(10, 'test10', 'test10@example.com', 'test', '0987654321', 'GUEST', true, NULL, NULL, 1),
+(11, 'test11', 'test11@example.com', 'test', '0987654322', 'GUEST', true, NOW(), NULL, 1),
+(12, 'test12', 'test12@example.com', 'test', '0987654323', 'GUEST', true, NULL, NULL, 100),
+(13, 'Erased user', 'erased-13@erased.invalid', '', '', 'GUEST', false, NOW(), NOW(), 1);
+
+INSERT INTO "addresses" ("id", "user_id", "name", "phone", "line1", "city", "postal_code", "country", "is_default_shipping", "is_default_billing") VALUES
+(10, 10, 'test10', '0987654321', '1 Le Loi', 'Ho Chi Minh City', '70000', 'VN', true, true);
+
+INSERT INTO "products" ("id", "title", "description", "price", "quantity", "is_active", "user_id") VALUES
+(10, 'Product 10', 'Product 10', 1000, 10, true, 10);
+
+INSERT INTO "orders" ("id", "order_number", "user_id", "status", "note") VALUES
+(10, 'ORDER_NUMBER_10', 10, 'NEW', 'call before delivery');
+
+INSERT INTO "order_items" ("id", "order_id", "product_id", "product_price", "product_name", "quantity", "discount", "note") VALUES
+(10, 10, 10, 1000, 'Product 10', 1, 0, 'gift wrap'),
+(11, 10, 10, 1000, 'Product 10', 2, 0, '');
+
+INSERT INTO "identities" ("id", "user_id", "issuer", "subject", "email") VALUES
+(10, 10, 'https://sso.example.com', 'sub-10', 'test10@example.com');
+
+INSERT INTO "api_keys" ("id", "user_id", "name", "prefix", "key_hash", "scope") VALUES
+(10, 10, 'warehouse', 'sk_aaaaaaaa', 'hash10', 'write');
+
+INSERT INTO "login_failures" ("id", "scope", "key", "failed_attempts") VALUES
+(10, 'ACCOUNT', '5c0730d186f0de766a95358b861c409685ea3445dcdb039b938de31184d20845', 2); -- the blind index of test10@example.com with the keys of encryptiontest
+
+INSERT INTO "data_requests" ("id", "actor_id", "subject_id", "type") VALUES
+(10, 10, 10, 'EXPORT');
@@ -1,2 +1,5 @@
 INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
-(10, 'Mai', 'mai@example.com', 'test', 'test', 'ADMIN', true)
+(10, 'Mai', 'mai@example.com', 'test', 'test', 'ADMIN', true);
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "deleted_at") VALUES
+(11, 'Lan', 'lan@example.com', 'test', 'test', 'GUEST', true, NOW());
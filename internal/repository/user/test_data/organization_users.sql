@@ -0,0 +1,6 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "organization_id") VALUES
+(20, 'test6', 'test6@example.com', 'test', 'test', 'ADMIN', true, 100),
+(21, 'test7', 'test7@example.com', 'test', 'test', 'GUEST', false, 100);
@@ -0,0 +1,9 @@
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
+(10, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true),
+(11, 'test2', 'test2@example.com', 'test', 'test', 'GUEST', true);
+
+INSERT INTO "password_histories" ("id", "user_id", "password", "created_at") VALUES
+(1, 10, 'password1', NOW() - INTERVAL '3 day'),
+(2, 10, 'password2', NOW() - INTERVAL '2 day'),
+(3, 10, 'password3', NOW() - INTERVAL '1 day'),
+(4, 11, 'password4', NOW());
@@ -1,3 +1,9 @@
 INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
-(10, 'test1', 'test1@example.com', 'test', 'test', 'ADMIN', true),
-(11, 'test2', 'test2@example.com', 'test', 'test', 'ADMIN', true);
+(10, 'test1', 'test1@example.com', 'test', 'test', 'ADMIN', true);
+
+-- test2 is encrypted with the keys of encryptiontest
+INSERT INTO "users" ("id", "name", "email", "email_index", "password", "phone", "role", "is_active") VALUES
+(11, 'test2', 'enc:v1:default:VCsttSQlbCxSVKajmbfGeRtSRJzOw9LO2DSSHTVvSJE2PauJQBrrxyIK5O1j6Awfs81ybDb1r7EHfrHf:D/jhI1TDnUrFLHnyq0O1mJaS9frhocxnPOYrcaRN2dGLLN0FwRGVbbWpycJB', '4ab91f23b5685074126863054177de34c5a1149e18c5fc2deb26de5ce5f7472b', 'test', 'enc:v1:default:m0o659Bc8s/gOaB306c3QTPAgE1q0uFcuHGuXc5WY/TNJAIuWQMu4ErwiR7bmxhxk6qvT0Juwg0fqt2q:MS0icFUmpTno+87STy6WITx6OTpY83UM0EjFNgulQFI', 'ADMIN', true);
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "deleted_at") VALUES
+(13, 'test4', 'test4@example.com', 'test', 'test', 'GUEST', true, NOW());
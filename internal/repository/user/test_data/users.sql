@@ -1,6 +1,11 @@
 INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active") VALUES
 (10, 'test1', 'test1@example.com', 'test', 'test', 'ADMIN', true),
-(11, 'test2', 'test2@example.com', 'test', 'test', 'ADMIN', false),
 (12, 'test3', 'test3@example.com', 'test', 'test', 'GUEST', true),
 (13, 'test4', 'test4@example.com', 'test', 'test', 'GUEST', true);
 
+-- test2 is encrypted with the keys of encryptiontest, the other users are plaintext rows written before the encryption was enabled
+INSERT INTO "users" ("id", "name", "email", "email_index", "password", "phone", "role", "is_active") VALUES
+(11, 'test2', 'enc:v1:default:AV27M2e0CZnZWw8qW/bEdl1Bo2sE13TXYSP4vAekV08WdX+6wiWeR2OtZGyxsct1HnsX6go/hFgEY56o:cbjHRzyJBCXZRQzi6H6nCgXRng87LB/jb3F6fM1GgXh2L8lSy72XU6zUyMvB', '4ab91f23b5685074126863054177de34c5a1149e18c5fc2deb26de5ce5f7472b', 'test', 'enc:v1:default:hbjTPhEY6by85PldrscP5tyIoHCulwSgXeOW5eJG68ilmQcmfRVse8hT6WXdn6lzmewaxf/yDxSME0xg:sUAr/81chHe8bRXJZ7bRM3XEkk52/cnpMRNv/Qp+eH4', 'ADMIN', false);
+
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "deleted_at") VALUES
+(14, 'test5', 'test5@example.com', 'test', 'test', 'GUEST', true, NOW());
@@ -0,0 +1,4 @@
+INSERT INTO "users" ("id", "name", "email", "password", "phone", "role", "is_active", "verification_sent_at") VALUES
+(10, 'test1', 'test1@example.com', 'test', 'test', 'GUEST', true, NULL),
+(11, 'test2', 'test2@example.com', 'test', 'test', 'GUEST', true, NOW() - INTERVAL '1 hour'),
+(12, 'test3', 'test3@example.com', 'test', 'test', 'GUEST', true, NOW());
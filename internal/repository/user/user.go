@@ -2,30 +2,44 @@ package user
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
 )
 
-// CreateUser creates a new user.
-func (r impl) CreateUser(ctx context.Context, user model.User) (model.User, error) {
-	if err := user.Insert(ctx, r.db, boil.Whitelist("name", "email", "password", "phone", "role", "is_active", "created_at", "updated_at")); err != nil {
+// CreateUser creates a new user in the organization of the request, the email and the phone are stored encrypted.
+func (r impl) CreateUser(ctx context.Context, tx *sql.Tx, user model.User) (model.User, error) {
+	user.OrganizationID = tenant.ID(ctx)
+	if err := EncryptUser(r.keys, &user); err != nil {
+		return model.User{}, err
+	}
+	if err := user.Insert(ctx, tx, boil.Whitelist("name", "email", "email_index", "password", "phone", "role", "is_active", "organization_id", "created_at", "updated_at")); err != nil {
+		return model.User{}, err
+	}
+	if err := DecryptUser(r.keys, &user); err != nil {
 		return model.User{}, err
 	}
 	return user, nil
 }
 
-// ExistsUserByEmail checks if a user exists by email.
+// ExistsUserByEmail checks if a user exists by email, it is not limited to the organization because emails are unique across organizations.
 func (r impl) ExistsUserByEmail(ctx context.Context, email string) (bool, error) {
-	return model.Users(model.UserWhere.Email.EQ(email)).Exists(ctx, r.db)
+	return model.Users(emailWhere(r.keys, email)).Exists(ctx, r.db)
 }
 
+// ExistsUserByID checks if a user exists by id, deleted users do not exist.
 func (r impl) ExistsUserByID(ctx context.Context, id int) (bool, error) {
-	return model.Users(model.UserWhere.ID.EQ(id)).Exists(ctx, r.db)
+	return model.Users(model.UserWhere.ID.EQ(id), model.UserWhere.DeletedAt.IsNull(), tenant.Where(ctx, model.UserTableColumns.OrganizationID)).Exists(ctx, r.db)
 }
 
 type SortParams struct {
@@ -45,21 +59,27 @@ type Filter struct {
 	Name       string
 	IsActive   null.Bool
 	Role       string
+	Deleted    bool
 	Sort       SortParams
 	Pagination Pagination
 }
 
-// GetUsers returns a list of users by filter.
+// GetUsers returns a list of users by filter, deleted users are only returned if the filter is for deleted users.
 func (r impl) GetUsers(ctx context.Context, input Filter) (model.UserSlice, int64, error) {
-	// 1. Init query mods slice.
-	var qms []qm.QueryMod
+	// 1. Init query mods slice, the users are limited to the organization.
+	qms := []qm.QueryMod{tenant.Where(ctx, model.UserTableColumns.OrganizationID)}
+	if input.Deleted {
+		qms = append(qms, model.UserWhere.DeletedAt.IsNotNull())
+	} else {
+		qms = append(qms, model.UserWhere.DeletedAt.IsNull())
+	}
 
 	// 2. Add filter condition.
 	if input.ID > 0 {
 		qms = append(qms, model.UserWhere.ID.EQ(input.ID))
 	}
 	if input.Email != "" {
-		qms = append(qms, model.UserWhere.Email.EQ(input.Email))
+		qms = append(qms, emailWhere(r.keys, input.Email))
 	}
 	if input.Name != "" {
 		qms = append(qms, qm.Where("name LIKE ?", "%"+input.Name+"%"))
@@ -77,20 +97,19 @@ func (r impl) GetUsers(ctx context.Context, input Filter) (model.UserSlice, int6
 		return nil, 0, err
 	}
 
-	// 4. Add sort condition.
+	// 4. Add sort condition, the emails are encrypted so the users are not sorted by email.
 	if input.Sort != (SortParams{}) {
 		if input.Sort.Name != "" {
 			qms = append(qms, qm.OrderBy(fmt.Sprintf("%s %s", model.UserColumns.Name, input.Sort.Name)))
 		}
-		if input.Sort.Email != "" {
-			qms = append(qms, qm.OrderBy(fmt.Sprintf("%s %s", model.UserColumns.Email, input.Sort.Email)))
-		}
 		if input.Sort.CreatedAt != "" {
 			qms = append(qms, qm.OrderBy(fmt.Sprintf("%s %s", model.UserColumns.CreatedAt, input.Sort.CreatedAt)))
 		}
 	} else {
 		qms = append(qms, qm.OrderBy(fmt.Sprintf("%s %s", model.UserColumns.UpdatedAt, "desc")))
 	}
+	// The id breaks the ties, e.g. users created at the same time, so the pages neither overlap nor skip users
+	qms = append(qms, qm.OrderBy(model.UserColumns.ID))
 
 	// 5. Add pagination condition.
 	if input.Pagination != (Pagination{}) {
@@ -108,44 +127,306 @@ func (r impl) GetUsers(ctx context.Context, input Filter) (model.UserSlice, int6
 	if err != nil {
 		return nil, 0, err
 	}
+	if err = decryptUsers(r.keys, users); err != nil {
+		return nil, 0, err
+	}
 
 	return users, totalCount, nil
 }
 
-// UpdateUser updates the user profile and returns the updated user profile
+// UpdateUser updates the user profile, role and status but not the password, deleted users are not updated
 func (r impl) UpdateUser(ctx context.Context, updateUser model.User) (int64, error) {
-	// Update the user profile
-	result, err := updateUser.Update(context.Background(), r.db, boil.Infer())
+	columns, err := encryptedContact(r.keys, updateUser.Email, updateUser.Phone)
+	if err != nil {
+		return 0, err
+	}
+	columns[model.UserColumns.Name] = updateUser.Name
+	columns[model.UserColumns.Role] = updateUser.Role
+	columns[model.UserColumns.IsActive] = updateUser.IsActive
+	columns[model.UserColumns.UpdatedAt] = time.Now()
 
+	return model.Users(
+		model.UserWhere.ID.EQ(updateUser.ID),
+		model.UserWhere.DeletedAt.IsNull(),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).UpdateAll(ctx, r.db, columns)
+}
+
+// UpdatePassword updates the password of the user and revokes all sessions issued before
+func (r impl) UpdatePassword(ctx context.Context, id int, password string) (int64, error) {
+	now := time.Now()
+	return model.Users(model.UserWhere.ID.EQ(id), tenant.Where(ctx, model.UserTableColumns.OrganizationID)).UpdateAll(ctx, r.db, model.M{
+		model.UserColumns.Password:          password,
+		model.UserColumns.SessionsRevokedAt: null.TimeFrom(now),
+		model.UserColumns.UpdatedAt:         now,
+	})
+}
+
+// UpdatePasswordHash replaces the password hash with a new hash of the same password, e.g. with another bcrypt cost.
+// The affected rows is 0 if the password was changed since oldHash was read.
+func (r impl) UpdatePasswordHash(ctx context.Context, id int, oldHash string, newHash string) (int64, error) {
+	return model.Users(model.UserWhere.ID.EQ(id), model.UserWhere.Password.EQ(oldHash)).UpdateAll(ctx, r.db, model.M{
+		model.UserColumns.Password: newHash,
+	})
+}
+
+// VerifyEmail marks the email of the user as verified, the email must still be the current email of the user
+func (r impl) VerifyEmail(ctx context.Context, id int, email string) (int64, error) {
+	now := time.Now()
+	return model.Users(
+		model.UserWhere.ID.EQ(id),
+		emailWhere(r.keys, email),
+		model.UserWhere.DeletedAt.IsNull(),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).UpdateAll(ctx, r.db, model.M{
+		model.UserColumns.EmailVerifiedAt: null.TimeFrom(now),
+		model.UserColumns.UpdatedAt:       now,
+	})
+}
+
+// UpdateVerificationSentAt records a verification email is sent to the user,
+// nothing is updated if the last one was sent within the given interval
+func (r impl) UpdateVerificationSentAt(ctx context.Context, id int, interval time.Duration) (int64, error) {
+	now := time.Now()
+	return model.Users(
+		model.UserWhere.ID.EQ(id),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+		qm.Expr(
+			model.UserWhere.VerificationSentAt.IsNull(),
+			qm.Or2(model.UserWhere.VerificationSentAt.LT(null.TimeFrom(now.Add(-interval)))),
+		),
+	).UpdateAll(ctx, r.db, model.M{
+		model.UserColumns.VerificationSentAt: null.TimeFrom(now),
+	})
+}
+
+// UpdateProfile updates the name, phone and email of the user, EmailVerifiedAt is reset by the caller if the email is changed
+func (r impl) UpdateProfile(ctx context.Context, user model.User) (int64, error) {
+	columns, err := encryptedContact(r.keys, user.Email, user.Phone)
 	if err != nil {
 		return 0, err
 	}
-	return result, nil
+	columns[model.UserColumns.Name] = user.Name
+	columns[model.UserColumns.EmailVerifiedAt] = user.EmailVerifiedAt
+	columns[model.UserColumns.VerificationSentAt] = user.VerificationSentAt
+	columns[model.UserColumns.UpdatedAt] = time.Now()
+
+	return model.Users(
+		model.UserWhere.ID.EQ(user.ID),
+		model.UserWhere.DeletedAt.IsNull(),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).UpdateAll(ctx, r.db, columns)
 }
 
+// CreatePasswordHistory records a replaced password hash of the user
+func (r impl) CreatePasswordHistory(ctx context.Context, history model.PasswordHistory) error {
+	return history.Insert(ctx, r.db, boil.Infer())
+}
+
+// GetPasswordHistories returns the latest replaced password hashes of the user
+func (r impl) GetPasswordHistories(ctx context.Context, userID int, limit int) (model.PasswordHistorySlice, error) {
+	return model.PasswordHistories(
+		model.PasswordHistoryWhere.UserID.EQ(userID),
+		qm.OrderBy(model.PasswordHistoryColumns.CreatedAt+" DESC, "+model.PasswordHistoryColumns.ID+" DESC"),
+		qm.Limit(limit),
+	).All(ctx, r.db)
+}
+
+// GetUser returns the user with the given id, deleted users are not found
 func (r impl) GetUser(ctx context.Context, id int) (model.User, error) {
-	user, err := model.Users(model.UserWhere.ID.EQ(id)).One(ctx, r.db)
+	user, err := model.Users(model.UserWhere.ID.EQ(id), model.UserWhere.DeletedAt.IsNull(), tenant.Where(ctx, model.UserTableColumns.OrganizationID)).One(ctx, r.db)
 	if err != nil {
 		return model.User{}, err
 	}
+	if err = DecryptUser(r.keys, user); err != nil {
+		return model.User{}, err
+	}
 	return *user, nil
 }
 
-func (r impl) DeleteUser(ctx context.Context, id int) (int64, error) {
-	result, err := model.Users(model.UserWhere.ID.EQ(id)).DeleteAll(ctx, r.db)
+// DeleteUser soft deletes the user and revokes the user sessions including all refresh tokens, the products and orders of the user are kept
+func (r impl) DeleteUser(ctx context.Context, tx *sql.Tx, id int) (int64, error) {
+	now := time.Now()
+	affected, err := model.Users(
+		model.UserWhere.ID.EQ(id),
+		model.UserWhere.DeletedAt.IsNull(),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).UpdateAll(ctx, tx, model.M{
+		model.UserColumns.DeletedAt:         null.TimeFrom(now),
+		model.UserColumns.SessionsRevokedAt: null.TimeFrom(now),
+		model.UserColumns.UpdatedAt:         now,
+	})
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+
+	if err = revokeCredentials(ctx, tx, id, now); err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// RevokeUserSessions revokes the sessions issued before now and the refresh tokens and the API keys of the user
+func (r impl) RevokeUserSessions(ctx context.Context, tx *sql.Tx, id int) (int64, error) {
+	now := time.Now()
+	affected, err := model.Users(
+		model.UserWhere.ID.EQ(id),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).UpdateAll(ctx, tx, model.M{
+		model.UserColumns.SessionsRevokedAt: null.TimeFrom(now),
+		model.UserColumns.UpdatedAt:         now,
+	})
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+
+	if err = revokeCredentials(ctx, tx, id, now); err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// revokeCredentials revokes the active refresh tokens and API keys of the user
+func revokeCredentials(ctx context.Context, tx *sql.Tx, id int, now time.Time) error {
+	if _, err := model.RefreshTokens(
+		model.RefreshTokenWhere.UserID.EQ(id),
+		model.RefreshTokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, tx, model.M{
+		model.RefreshTokenColumns.RevokedAt: null.TimeFrom(now),
+		model.RefreshTokenColumns.UpdatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	_, err := model.APIKeys(
+		model.APIKeyWhere.UserID.EQ(id),
+		model.APIKeyWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, tx, model.M{
+		model.APIKeyColumns.RevokedAt: null.TimeFrom(now),
+		model.APIKeyColumns.UpdatedAt: now,
+	})
+	return err
+}
+
+// RestoreUser restores the soft deleted user
+func (r impl) RestoreUser(ctx context.Context, id int) (int64, error) {
+	return model.Users(
+		model.UserWhere.ID.EQ(id),
+		model.UserWhere.DeletedAt.IsNotNull(),
+		model.UserWhere.ErasedAt.IsNull(),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).UpdateAll(ctx, r.db, model.M{
+		model.UserColumns.DeletedAt: null.Time{},
+		model.UserColumns.UpdatedAt: time.Now(),
+	})
+}
+
+// GetUserData returns the user with everything tied to it, soft deleted and erased users are included
+func (r impl) GetUserData(ctx context.Context, id int) (model.User, error) {
+	user, err := model.Users(
+		model.UserWhere.ID.EQ(id),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+		qm.Load(model.UserRels.Addresses, qm.OrderBy(model.AddressColumns.ID)),
+		qm.Load(model.UserRels.Orders, qm.OrderBy(model.OrderColumns.ID)),
+		qm.Load(qm.Rels(model.UserRels.Orders, model.OrderRels.OrderItems), qm.OrderBy(model.OrderItemColumns.ID)),
+		qm.Load(qm.Rels(model.UserRels.Orders, model.OrderRels.OrderAddresses)),
+		qm.Load(model.UserRels.Products, qm.OrderBy(model.ProductColumns.ID)),
+		qm.Load(model.UserRels.Identities),
+		qm.Load(model.UserRels.APIKeys, qm.OrderBy(model.APIKeyColumns.ID)),
+		qm.Load(model.UserRels.SubjectImpersonations, qm.OrderBy(model.ImpersonationColumns.ID)),
+		qm.Load(model.UserRels.SubjectDataRequests, qm.OrderBy(model.DataRequestColumns.ID)),
+	).One(ctx, r.db)
+	if err != nil {
+		return model.User{}, err
+	}
+	if err = DecryptUser(r.keys, user); err != nil {
+		return model.User{}, err
+	}
+	for _, identity := range user.R.Identities {
+		if identity.Email, err = r.keys.Decrypt(identity.Email); err != nil {
+			return model.User{}, err
+		}
+	}
+	return *user, nil
+}
+
+// EraseUser anonymizes the personal fields of the user and deletes its addresses, sign-in methods and credentials.
+// The user row is kept as a soft deleted user, so its orders and products stay intact.
+// The affected rows is 0 if the user is not found or was already erased.
+func (r impl) EraseUser(ctx context.Context, tx *sql.Tx, id int) (int64, error) {
+	user, err := model.Users(
+		model.UserWhere.ID.EQ(id),
+		model.UserWhere.ErasedAt.IsNull(),
+		tenant.Where(ctx, model.UserTableColumns.OrganizationID),
+	).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	// The blind index of the email is the key of the login failures of the user
+	if err = DecryptUser(r.keys, user); err != nil {
+		return 0, err
+	}
+
+	// An erased user is a deleted user, the time it was deleted is kept if it was deleted before
+	now := time.Now()
+	deletedAt := user.DeletedAt
+	if !deletedAt.Valid {
+		deletedAt = null.TimeFrom(now)
+	}
+	columns, err := encryptedContact(r.keys, fmt.Sprintf("erased-%d@erased.invalid", user.ID), "")
+	if err != nil {
+		return 0, err
+	}
+	columns[model.UserColumns.Name] = "Erased user"
+	columns[model.UserColumns.Password] = ""
+	columns[model.UserColumns.IsActive] = false
+	columns[model.UserColumns.EmailVerifiedAt] = null.Time{}
+	columns[model.UserColumns.DeletedAt] = deletedAt
+	columns[model.UserColumns.SessionsRevokedAt] = null.TimeFrom(now)
+	columns[model.UserColumns.ErasedAt] = null.TimeFrom(now)
+	columns[model.UserColumns.UpdatedAt] = now
+
+	affected, err := model.Users(model.UserWhere.ID.EQ(user.ID)).UpdateAll(ctx, tx, columns)
 	if err != nil {
 		return 0, err
 	}
-	return result, nil
+
+	queries := []interface {
+		DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error)
+	}{
+		model.Addresses(model.AddressWhere.UserID.EQ(user.ID)),
+		model.Identities(model.IdentityWhere.UserID.EQ(user.ID)),
+		model.APIKeys(model.APIKeyWhere.UserID.EQ(user.ID)),
+		model.TotpSecrets(model.TotpSecretWhere.UserID.EQ(user.ID)),
+		model.BackupCodes(model.BackupCodeWhere.UserID.EQ(user.ID)),
+		model.RefreshTokens(model.RefreshTokenWhere.UserID.EQ(user.ID)),
+		model.PasswordResetTokens(model.PasswordResetTokenWhere.UserID.EQ(user.ID)),
+		model.PasswordHistories(model.PasswordHistoryWhere.UserID.EQ(user.ID)),
+		model.LoginFailures(
+			model.LoginFailureWhere.Scope.EQ(loginfailure.ScopeAccount),
+			model.LoginFailureWhere.Key.EQ(r.keys.BlindIndex(strings.ToLower(strings.TrimSpace(user.Email)))),
+		),
+	}
+	for _, q := range queries {
+		if _, err = q.DeleteAll(ctx, tx); err != nil {
+			return 0, err
+		}
+	}
+	return affected, nil
 }
 
-// GetUserByEmail returns the user with the given email
+// GetUserByEmail returns the user with the given email, deleted users are not found
 func (r impl) GetUserByEmail(ctx context.Context, email string) (model.User, error) {
 	// Get the user by email
-	result, err := model.Users(model.UserWhere.Email.EQ(email)).One(ctx, r.db)
+	result, err := model.Users(emailWhere(r.keys, email), model.UserWhere.DeletedAt.IsNull(), tenant.Where(ctx, model.UserTableColumns.OrganizationID)).One(ctx, r.db)
 	if err != nil {
 		return model.User{}, err
 	}
+	if err = DecryptUser(r.keys, result); err != nil {
+		return model.User{}, err
+	}
 	return *result, nil
 }
 
@@ -155,14 +436,15 @@ type SummaryStatistics struct {
 }
 
 func (r impl) GetStatistics(ctx context.Context) (SummaryStatistics, error) {
-	// Get the total number of users
-	total, err := model.Users().Count(ctx, r.db)
+	// Get the total number of users of the organization, deleted users are not counted
+	scope := tenant.Where(ctx, model.UserTableColumns.OrganizationID)
+	total, err := model.Users(model.UserWhere.DeletedAt.IsNull(), scope).Count(ctx, r.db)
 	if err != nil {
 		return SummaryStatistics{}, err
 	}
 
 	// Get the total number of inactive users
-	totalInactive, err := model.Users(model.UserWhere.IsActive.EQ(false)).Count(ctx, r.db)
+	totalInactive, err := model.Users(model.UserWhere.IsActive.EQ(false), model.UserWhere.DeletedAt.IsNull(), scope).Count(ctx, r.db)
 	if err != nil {
 		return SummaryStatistics{}, err
 	}
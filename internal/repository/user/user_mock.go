@@ -2,6 +2,8 @@ package user
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -12,8 +14,8 @@ type Mock struct {
 	mock.Mock
 }
 
-func (m *Mock) CreateUser(ctx context.Context, user model.User) (model.User, error) {
-	args := m.Called(ctx, user)
+func (m *Mock) CreateUser(ctx context.Context, tx *sql.Tx, user model.User) (model.User, error) {
+	args := m.Called(ctx, tx, user)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
@@ -37,16 +39,71 @@ func (m *Mock) UpdateUser(ctx context.Context, updateUser model.User) (int64, er
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *Mock) UpdatePassword(ctx context.Context, id int, password string) (int64, error) {
+	args := m.Called(ctx, id, password)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) UpdatePasswordHash(ctx context.Context, id int, oldHash string, newHash string) (int64, error) {
+	args := m.Called(ctx, id, oldHash, newHash)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) VerifyEmail(ctx context.Context, id int, email string) (int64, error) {
+	args := m.Called(ctx, id, email)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) UpdateVerificationSentAt(ctx context.Context, id int, interval time.Duration) (int64, error) {
+	args := m.Called(ctx, id, interval)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) UpdateProfile(ctx context.Context, user model.User) (int64, error) {
+	args := m.Called(ctx, user)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) CreatePasswordHistory(ctx context.Context, history model.PasswordHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+func (m *Mock) GetPasswordHistories(ctx context.Context, userID int, limit int) (model.PasswordHistorySlice, error) {
+	args := m.Called(ctx, userID, limit)
+	return args.Get(0).(model.PasswordHistorySlice), args.Error(1)
+}
+
 func (m *Mock) GetUser(ctx context.Context, id int) (model.User, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
-func (m *Mock) DeleteUser(ctx context.Context, id int) (int64, error) {
+func (m *Mock) DeleteUser(ctx context.Context, tx *sql.Tx, id int) (int64, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) RevokeUserSessions(ctx context.Context, tx *sql.Tx, id int) (int64, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) RestoreUser(ctx context.Context, id int) (int64, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *Mock) GetUserData(ctx context.Context, id int) (model.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.User), args.Error(1)
+}
+
+func (m *Mock) EraseUser(ctx context.Context, tx *sql.Tx, id int) (int64, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *Mock) GetUserByEmail(ctx context.Context, email string) (model.User, error) {
 	args := m.Called(ctx, email)
 	return args.Get(0).(model.User), args.Error(1)
@@ -56,3 +113,8 @@ func (m *Mock) GetStatistics(ctx context.Context) (SummaryStatistics, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(SummaryStatistics), args.Error(1)
 }
+
+func (m *Mock) ReencryptUsers(ctx context.Context, afterID int, limit int) (ReencryptResult, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).(ReencryptResult), args.Error(1)
+}
@@ -6,14 +6,20 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption/encryptiontest"
 )
 
+const eraseUserCleanUpQuery = "DELETE FROM data_requests; DELETE FROM login_failures; DELETE FROM api_keys; DELETE FROM identities; DELETE FROM addresses; DELETE FROM order_items; DELETE FROM orders; DELETE FROM products; DELETE FROM users; DELETE FROM organizations WHERE id >= 100;"
+
 func TestRepository_CreateUser(t *testing.T) {
 	tcs := map[string]struct {
 		given     model.User
@@ -31,13 +37,14 @@ func TestRepository_CreateUser(t *testing.T) {
 				IsActive: true,
 			},
 			expResult: model.User{
-				ID:       2,
-				Name:     "test02",
-				Email:    "test@example.com",
-				Password: "123456",
-				Phone:    "123456",
-				Role:     "GUEST",
-				IsActive: true,
+				ID:             2,
+				Name:           "test02",
+				Email:          "test@example.com",
+				Password:       "123456",
+				Phone:          "123456",
+				Role:           "GUEST",
+				IsActive:       true,
+				OrganizationID: auth.DefaultOrganizationID,
 			},
 		},
 		"error_duplicate_email": {
@@ -49,7 +56,7 @@ func TestRepository_CreateUser(t *testing.T) {
 				Role:     "GUEST",
 				IsActive: true,
 			},
-			expErr: errors.New("model: unable to insert into users: pq: duplicate key value violates unique constraint \"email_on_users\""),
+			expErr: errors.New("model: unable to insert into users: pq: duplicate key value violates unique constraint \"email_index_on_users\""),
 		},
 	}
 
@@ -62,10 +69,17 @@ func TestRepository_CreateUser(t *testing.T) {
 			db.LoadSqlTestFile(t, dbTest, "test_data/users.sql")
 			defer dbTest.Exec("DELETE FROM users;")
 
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 
 			// When
-			result, err := repo.CreateUser(context.Background(), tc.given)
+			tx, err := dbTest.Begin()
+			require.NoError(t, err)
+			result, err := repo.CreateUser(context.Background(), tx, tc.given)
+			if err != nil {
+				tx.Rollback()
+			} else {
+				require.NoError(t, tx.Commit())
+			}
 
 			// Then
 			if tc.expErr != nil {
@@ -78,6 +92,16 @@ func TestRepository_CreateUser(t *testing.T) {
 				tc.expResult.UpdatedAt = result.UpdatedAt
 
 				require.Equal(t, tc.expResult, result)
+
+				// The email and the phone are stored encrypted, the email is found by its blind index
+				found, err := model.FindUser(context.Background(), dbTest, result.ID)
+				require.NoError(t, err)
+				require.True(t, encryption.IsEncrypted(found.Email))
+				require.True(t, encryption.IsEncrypted(found.Phone))
+				require.True(t, found.EmailIndex.Valid)
+				exists, err := repo.ExistsUserByEmail(context.Background(), tc.given.Email)
+				require.NoError(t, err)
+				require.True(t, exists)
 			}
 		})
 	}
@@ -108,7 +132,7 @@ func TestRepository_ExistsUserByEmail(t *testing.T) {
 			db.LoadSqlTestFile(t, dbTest, "test_data/users.sql")
 			defer dbTest.Exec("DELETE FROM users;")
 
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 
 			// When
 			result, err := repo.ExistsUserByEmail(context.Background(), tc.given)
@@ -138,7 +162,7 @@ func TestUserRepository_GetUsers(t *testing.T) {
 			expResult: []model.User{
 				{
 					ID:   10,
-					Name: "test1", Email: "test1@example.com", Password: "test", Phone: "test", Role: "ADMIN", IsActive: true,
+					Name: "test1", Email: "test1@example.com", Password: "test", Phone: "test", Role: "ADMIN", IsActive: true, OrganizationID: auth.DefaultOrganizationID,
 				},
 			},
 			expTotalCount: 1,
@@ -150,23 +174,54 @@ func TestUserRepository_GetUsers(t *testing.T) {
 			expResult: []model.User{
 				{
 					ID:   10,
-					Name: "test1", Email: "test1@example.com", Password: "test", Phone: "test", Role: "ADMIN", IsActive: true,
+					Name: "test1", Email: "test1@example.com", Password: "test", Phone: "test", Role: "ADMIN", IsActive: true, OrganizationID: auth.DefaultOrganizationID,
 				},
 				{
 					ID:   11,
-					Name: "test2", Email: "test2@example.com", Password: "test", Phone: "test", Role: "ADMIN", IsActive: false,
+					Name: "test2", Email: "test2@example.com", Password: "test", Phone: "test", Role: "ADMIN", IsActive: false, OrganizationID: auth.DefaultOrganizationID,
+				},
+				{
+					ID:   12,
+					Name: "test3", Email: "test3@example.com", Password: "test", Phone: "test", Role: "GUEST", IsActive: true, OrganizationID: auth.DefaultOrganizationID,
+				},
+				{
+					ID:   13,
+					Name: "test4", Email: "test4@example.com", Password: "test", Phone: "test", Role: "GUEST", IsActive: true, OrganizationID: auth.DefaultOrganizationID,
 				},
+			},
+			expTotalCount: 4,
+		},
+		"success_paginated_with_same_created_at": {
+			// The users of the fixture are created at the same time
+			given: Filter{
+				Name:       "test",
+				Sort:       SortParams{CreatedAt: "asc"},
+				Pagination: Pagination{Page: 2, Limit: 2},
+			},
+			expResult: []model.User{
 				{
 					ID:   12,
-					Name: "test3", Email: "test3@example.com", Password: "test", Phone: "test", Role: "GUEST", IsActive: true,
+					Name: "test3", Email: "test3@example.com", Password: "test", Phone: "test", Role: "GUEST", IsActive: true, OrganizationID: auth.DefaultOrganizationID,
 				},
 				{
 					ID:   13,
-					Name: "test4", Email: "test4@example.com", Password: "test", Phone: "test", Role: "GUEST", IsActive: true,
+					Name: "test4", Email: "test4@example.com", Password: "test", Phone: "test", Role: "GUEST", IsActive: true, OrganizationID: auth.DefaultOrganizationID,
 				},
 			},
 			expTotalCount: 4,
 		},
+		"success_deleted": {
+			given: Filter{
+				Deleted: true,
+			},
+			expResult: []model.User{
+				{
+					ID:   14,
+					Name: "test5", Email: "test5@example.com", Password: "test", Phone: "test", Role: "GUEST", IsActive: true, OrganizationID: auth.DefaultOrganizationID,
+				},
+			},
+			expTotalCount: 1,
+		},
 	}
 
 	for desc, tc := range tcs {
@@ -174,7 +229,7 @@ func TestUserRepository_GetUsers(t *testing.T) {
 			// Given
 			dbTest, err := db.DBConnect(os.Getenv("DB_URL"))
 			require.NoError(t, err)
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 
 			db.LoadSqlTestFile(t, dbTest, "test_data/users.sql")
 			defer dbTest.Exec("DELETE FROM users;")
@@ -193,8 +248,10 @@ func TestUserRepository_GetUsers(t *testing.T) {
 					tc.expResult[i].ID = user.ID
 					tc.expResult[i].CreatedAt = user.CreatedAt
 					tc.expResult[i].UpdatedAt = user.UpdatedAt
+					tc.expResult[i].DeletedAt = user.DeletedAt
 
 					require.Equal(t, tc.expResult[i], *user)
+					require.Equal(t, tc.given.Deleted, user.DeletedAt.Valid)
 				}
 
 				require.Equal(t, tc.expTotalCount, totalCount)
@@ -223,7 +280,6 @@ func TestRepository_UpdateUser(t *testing.T) {
 					ID:       10,
 					Name:     "test2",
 					Email:    "test3@example.com",
-					Password: "test",
 					Phone:    "123456",
 					Role:     "GUEST",
 					IsActive: true,
@@ -240,7 +296,22 @@ func TestRepository_UpdateUser(t *testing.T) {
 					ID:       12,
 					Name:     "test2",
 					Email:    "test3@example.com",
-					Password: "test",
+					Phone:    "123456",
+					Role:     "GUEST",
+					IsActive: true,
+				},
+			},
+			expOutput: output{
+				expResult: 0,
+			},
+		},
+		"deleted": {
+			input: input{
+				ctx: context.Background(),
+				user: model.User{
+					ID:       13,
+					Name:     "test2",
+					Email:    "test4@example.com",
 					Phone:    "123456",
 					Role:     "GUEST",
 					IsActive: true,
@@ -257,7 +328,6 @@ func TestRepository_UpdateUser(t *testing.T) {
 					ID:       10,
 					Name:     "test2",
 					Email:    "test2@example.com",
-					Password: "test",
 					Phone:    "123456",
 					Role:     "GUEST",
 					IsActive: true,
@@ -265,7 +335,7 @@ func TestRepository_UpdateUser(t *testing.T) {
 			},
 			expOutput: output{
 				expResult: 0,
-				expErr:    errors.New("model: unable to update users row: pq: duplicate key value violates unique constraint \"email_on_users\""),
+				expErr:    errors.New("model: unable to update all for users: pq: duplicate key value violates unique constraint \"email_index_on_users\""),
 			},
 		},
 	}
@@ -279,7 +349,7 @@ func TestRepository_UpdateUser(t *testing.T) {
 			db.LoadSqlTestFile(t, dbTest, "test_data/update_user.sql")
 			defer dbTest.Exec("DELETE FROM users;")
 
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 
 			// When
 			result, err := repo.UpdateUser(context.Background(), tc.input.user)
@@ -290,7 +360,317 @@ func TestRepository_UpdateUser(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tc.expOutput.expResult, result)
+				if result > 0 {
+					// The password is only replaced by UpdatePassword
+					user, err := model.FindUser(context.Background(), dbTest, tc.input.user.ID)
+					require.NoError(t, err)
+					require.Equal(t, "test", user.Password)
+				}
+			}
+		})
+	}
+}
+
+func TestUserRepository_UpdatePassword(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   10,
+			rowsAff: 1,
+		},
+		"not_found": {
+			given:   15,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/users.sql")
+			defer dbTest.Exec("DELETE FROM users;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.UpdatePassword(context.Background(), tc.given, "new-password")
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+			if tc.rowsAff > 0 {
+				user, err := repo.GetUser(context.Background(), tc.given)
+				require.NoError(t, err)
+				require.Equal(t, "new-password", user.Password)
+				require.True(t, user.SessionsRevokedAt.Valid)
+			}
+		})
+	}
+}
+
+func TestUserRepository_UpdatePasswordHash(t *testing.T) {
+	tcs := map[string]struct {
+		givenID      int
+		givenOldHash string
+		rowsAff      int64
+	}{
+		"success": {
+			givenID:      10,
+			givenOldHash: "test",
+			rowsAff:      1,
+		},
+		"password_changed": {
+			givenID:      10,
+			givenOldHash: "other",
+			rowsAff:      0,
+		},
+		"not_found": {
+			givenID:      15,
+			givenOldHash: "test",
+			rowsAff:      0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/users.sql")
+			defer dbTest.Exec("DELETE FROM users;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.UpdatePasswordHash(context.Background(), tc.givenID, tc.givenOldHash, "new-hash")
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+			if tc.rowsAff > 0 {
+				user, err := repo.GetUser(context.Background(), tc.givenID)
+				require.NoError(t, err)
+				require.Equal(t, "new-hash", user.Password)
+				require.False(t, user.SessionsRevokedAt.Valid)
+			}
+		})
+	}
+}
+
+func TestUserRepository_UpdateProfile(t *testing.T) {
+	tcs := map[string]struct {
+		given   model.User
+		rowsAff int64
+	}{
+		"success": {
+			given:   model.User{ID: 10, Name: "new name", Phone: "0123456789", Email: "new@example.com"},
+			rowsAff: 1,
+		},
+		"deleted": {
+			given:   model.User{ID: 13, Name: "new name", Phone: "0123456789", Email: "test4@example.com"},
+			rowsAff: 0,
+		},
+		"not_found": {
+			given:   model.User{ID: 15, Name: "new name", Phone: "0123456789", Email: "test5@example.com"},
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/update_user.sql")
+			defer dbTest.Exec("DELETE FROM users;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.UpdateProfile(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+			if tc.rowsAff > 0 {
+				user, err := repo.GetUser(context.Background(), tc.given.ID)
+				require.NoError(t, err)
+				require.Equal(t, tc.given.Name, user.Name)
+				require.Equal(t, tc.given.Phone, user.Phone)
+				require.Equal(t, tc.given.Email, user.Email)
+				require.False(t, user.EmailVerifiedAt.Valid)
+				require.Equal(t, "test", user.Password)
+				require.Equal(t, "ADMIN", user.Role)
+			}
+		})
+	}
+}
+
+func TestUserRepository_GetPasswordHistories(t *testing.T) {
+	tcs := map[string]struct {
+		userID int
+		limit  int
+		exp    []string
+	}{
+		"success": {
+			userID: 10,
+			limit:  2,
+			exp:    []string{"password3", "password2"},
+		},
+		"less_than_limit": {
+			userID: 11,
+			limit:  2,
+			exp:    []string{"password4"},
+		},
+		"no_history": {
+			userID: 12,
+			limit:  2,
+			exp:    []string{},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/password_histories.sql")
+			defer dbTest.Exec("DELETE FROM password_histories; DELETE FROM users;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.GetPasswordHistories(context.Background(), tc.userID, tc.limit)
+
+			// Then
+			require.NoError(t, err)
+			passwords := make([]string, len(result))
+			for i, history := range result {
+				passwords[i] = history.Password
 			}
+			require.Equal(t, tc.exp, passwords)
+		})
+	}
+}
+
+func TestUserRepository_CreatePasswordHistory(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/password_histories.sql")
+	defer dbTest.Exec("DELETE FROM password_histories; DELETE FROM users;")
+
+	repo := New(dbTest, encryptiontest.KeyRing())
+
+	// When
+	err := repo.CreatePasswordHistory(context.Background(), model.PasswordHistory{UserID: 11, Password: "password5"})
+
+	// Then
+	require.NoError(t, err)
+	result, err := repo.GetPasswordHistories(context.Background(), 11, 5)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	require.Equal(t, "password5", result[0].Password)
+}
+
+func TestUserRepository_VerifyEmail(t *testing.T) {
+	type givenData struct {
+		id    int
+		email string
+	}
+	tcs := map[string]struct {
+		given   givenData
+		rowsAff int64
+	}{
+		"success": {
+			given:   givenData{id: 10, email: "test1@example.com"},
+			rowsAff: 1,
+		},
+		"email_changed": {
+			given:   givenData{id: 10, email: "old@example.com"},
+			rowsAff: 0,
+		},
+		"not_found": {
+			given:   givenData{id: 15, email: "test1@example.com"},
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/update_user.sql")
+			defer dbTest.Exec("DELETE FROM users;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.VerifyEmail(context.Background(), tc.given.id, tc.given.email)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+			if tc.rowsAff > 0 {
+				user, err := repo.GetUser(context.Background(), tc.given.id)
+				require.NoError(t, err)
+				require.True(t, user.EmailVerifiedAt.Valid)
+			}
+		})
+	}
+}
+
+func TestUserRepository_UpdateVerificationSentAt(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success_never_sent": {
+			given:   10,
+			rowsAff: 1,
+		},
+		"success_sent_before_interval": {
+			given:   11,
+			rowsAff: 1,
+		},
+		"sent_within_interval": {
+			given:   12,
+			rowsAff: 0,
+		},
+		"not_found": {
+			given:   15,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/verification.sql")
+			defer dbTest.Exec("DELETE FROM users;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.UpdateVerificationSentAt(context.Background(), tc.given, time.Minute)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
 		})
 	}
 }
@@ -304,19 +684,24 @@ func TestUserRepository_GetUser(t *testing.T) {
 		"success": {
 			given: 10,
 			expResult: model.User{
-				ID:       10,
-				Name:     "test1",
-				Email:    "test1@example.com",
-				Password: "test",
-				Phone:    "test",
-				Role:     "ADMIN",
-				IsActive: true,
+				ID:             10,
+				Name:           "test1",
+				Email:          "test1@example.com",
+				Password:       "test",
+				Phone:          "test",
+				Role:           "ADMIN",
+				IsActive:       true,
+				OrganizationID: auth.DefaultOrganizationID,
 			},
 		},
 		"error_not_found": {
 			given:  15,
 			expErr: sql.ErrNoRows,
 		},
+		"error_deleted": {
+			given:  14,
+			expErr: sql.ErrNoRows,
+		},
 	}
 
 	for desc, tc := range tcs {
@@ -328,7 +713,7 @@ func TestUserRepository_GetUser(t *testing.T) {
 			db.LoadSqlTestFile(t, dbTest, "test_data/users.sql")
 			defer dbTest.Exec("DELETE FROM users;")
 
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 
 			// When
 			result, err := repo.GetUser(context.Background(), tc.given)
@@ -358,9 +743,13 @@ func TestUserRepository_DeleteUser(t *testing.T) {
 			given:   1,
 			rowsAff: 1,
 		},
-		"error": {
-			given:  2,
-			expErr: errors.New("model: unable to delete all from users: pq: update or delete on table \"users\" violates foreign key constraint \"products_user_id_fkey\" on table \"products\""),
+		"success_user_with_products": {
+			given:   2,
+			rowsAff: 1,
+		},
+		"already_deleted": {
+			given:   3,
+			rowsAff: 0,
 		},
 	}
 
@@ -371,12 +760,15 @@ func TestUserRepository_DeleteUser(t *testing.T) {
 			require.NoError(t, dbErr)
 
 			db.LoadSqlTestFile(t, dbTest, "test_data/delete_user.sql")
-			defer dbTest.Exec("DELETE FROM products; DELETE FROM users;")
+			defer dbTest.Exec("DELETE FROM api_keys; DELETE FROM refresh_tokens; DELETE FROM products; DELETE FROM users;")
 
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 
 			// When
-			result, err := repo.DeleteUser(context.Background(), tc.given)
+			tx, err := dbTest.Begin()
+			require.NoError(t, err)
+			result, err := repo.DeleteUser(context.Background(), tx, tc.given)
+			require.NoError(t, tx.Commit())
 
 			// Then
 			if tc.expErr != nil {
@@ -386,6 +778,135 @@ func TestUserRepository_DeleteUser(t *testing.T) {
 				//must be success
 				require.NoError(t, err)
 				require.Equal(t, tc.rowsAff, result)
+
+				// The user is kept with its products
+				user, err := model.FindUser(context.Background(), dbTest, tc.given)
+				require.NoError(t, err)
+				require.True(t, user.DeletedAt.Valid)
+				require.True(t, user.SessionsRevokedAt.Valid)
+
+				// The refresh tokens of a deleted user are revoked
+				if tc.rowsAff > 0 {
+					active, err := model.RefreshTokens(
+						model.RefreshTokenWhere.UserID.EQ(tc.given),
+						model.RefreshTokenWhere.RevokedAt.IsNull(),
+					).Exists(context.Background(), dbTest)
+					require.NoError(t, err)
+					require.False(t, active)
+					activeKeys, err := model.APIKeys(
+						model.APIKeyWhere.UserID.EQ(tc.given),
+						model.APIKeyWhere.RevokedAt.IsNull(),
+					).Exists(context.Background(), dbTest)
+					require.NoError(t, err)
+					require.False(t, activeKeys)
+				}
+			}
+		})
+	}
+}
+
+func TestUserRepository_RevokeUserSessions(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   1,
+			rowsAff: 1,
+		},
+		"not_found": {
+			given:   5,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/delete_user.sql")
+			defer dbTest.Exec("DELETE FROM api_keys; DELETE FROM refresh_tokens; DELETE FROM products; DELETE FROM users;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			tx, err := dbTest.Begin()
+			require.NoError(t, err)
+			result, err := repo.RevokeUserSessions(context.Background(), tx, tc.given)
+			require.NoError(t, tx.Commit())
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+			if tc.rowsAff > 0 {
+				// The user is kept, its sessions, refresh tokens and API keys are revoked
+				user, err := model.FindUser(context.Background(), dbTest, tc.given)
+				require.NoError(t, err)
+				require.False(t, user.DeletedAt.Valid)
+				require.True(t, user.SessionsRevokedAt.Valid)
+				activeTokens, err := model.RefreshTokens(
+					model.RefreshTokenWhere.UserID.EQ(tc.given),
+					model.RefreshTokenWhere.RevokedAt.IsNull(),
+				).Exists(context.Background(), dbTest)
+				require.NoError(t, err)
+				require.False(t, activeTokens)
+				activeKeys, err := model.APIKeys(
+					model.APIKeyWhere.UserID.EQ(tc.given),
+					model.APIKeyWhere.RevokedAt.IsNull(),
+				).Exists(context.Background(), dbTest)
+				require.NoError(t, err)
+				require.False(t, activeKeys)
+			}
+		})
+	}
+}
+
+func TestUserRepository_RestoreUser(t *testing.T) {
+	tcs := map[string]struct {
+		given   int
+		rowsAff int64
+	}{
+		"success": {
+			given:   3,
+			rowsAff: 1,
+		},
+		"not_deleted": {
+			given:   1,
+			rowsAff: 0,
+		},
+		"erased": {
+			given:   4,
+			rowsAff: 0,
+		},
+		"not_found": {
+			given:   15,
+			rowsAff: 0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/delete_user.sql")
+			defer dbTest.Exec("DELETE FROM api_keys; DELETE FROM refresh_tokens; DELETE FROM products; DELETE FROM users;")
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.RestoreUser(context.Background(), tc.given)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+			if tc.rowsAff > 0 {
+				user, err := repo.GetUser(context.Background(), tc.given)
+				require.NoError(t, err)
+				require.False(t, user.DeletedAt.Valid)
 			}
 		})
 	}
@@ -411,13 +932,14 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 			},
 			expOutput: output{
 				user: model.User{
-					ID:       10,
-					Name:     "Mai",
-					Email:    "mai@example.com",
-					Password: "test",
-					Phone:    "test",
-					Role:     "ADMIN",
-					IsActive: true,
+					ID:             10,
+					Name:           "Mai",
+					Email:          "mai@example.com",
+					Password:       "test",
+					Phone:          "test",
+					Role:           "ADMIN",
+					IsActive:       true,
+					OrganizationID: auth.DefaultOrganizationID,
 				},
 			},
 		},
@@ -430,6 +952,15 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 				err: sql.ErrNoRows,
 			},
 		},
+		"deleted": {
+			input: input{
+				ctx:   context.Background(),
+				email: "lan@example.com",
+			},
+			expOutput: output{
+				err: sql.ErrNoRows,
+			},
+		},
 	}
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
@@ -440,7 +971,7 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 			db.LoadSqlTestFile(t, dbTest, "test_data/get_user_by_email.sql")
 			defer dbTest.Exec("DELETE FROM products;DELETE FROM users;")
 
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 
 			// When
 			result, err := repo.GetUserByEmail(tc.input.ctx, tc.input.email)
@@ -463,15 +994,38 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 
 func TestOrderRepository_GetStatistics(t *testing.T) {
 	tcs := map[string]struct {
+		givenCtx  context.Context
 		expResult SummaryStatistics
 		expErr    error
 	}{
-		"success": {
+		"success_unscoped": {
+			givenCtx: auth.NewUnscopedContext(context.Background()),
+			expResult: SummaryStatistics{
+				Total:         6,
+				TotalInactive: 2,
+			},
+		},
+		"success_default_organization": {
+			givenCtx: auth.NewContext(context.Background(), auth.User{ID: 10, OrganizationID: auth.DefaultOrganizationID}),
 			expResult: SummaryStatistics{
 				Total:         4,
 				TotalInactive: 1,
 			},
 		},
+		"success_not_scoped": {
+			givenCtx: context.Background(),
+			expResult: SummaryStatistics{
+				Total:         4,
+				TotalInactive: 1,
+			},
+		},
+		"success_other_organization": {
+			givenCtx: auth.NewContext(context.Background(), auth.User{ID: 20, OrganizationID: 100}),
+			expResult: SummaryStatistics{
+				Total:         2,
+				TotalInactive: 1,
+			},
+		},
 	}
 
 	for desc, tc := range tcs {
@@ -481,12 +1035,13 @@ func TestOrderRepository_GetStatistics(t *testing.T) {
 			require.NoError(t, err)
 			defer dbTest.Close()
 
-			repo := New(dbTest)
+			repo := New(dbTest, encryptiontest.KeyRing())
 			db.LoadSqlTestFile(t, dbTest, "test_data/users.sql")
-			defer dbTest.Exec("DELETE FROM users;")
+			db.LoadSqlTestFile(t, dbTest, "test_data/organization_users.sql")
+			defer dbTest.Exec("DELETE FROM users; DELETE FROM organizations WHERE id = 100;")
 
 			// When
-			result, err := repo.GetStatistics(context.Background())
+			result, err := repo.GetStatistics(tc.givenCtx)
 
 			// Then
 			if tc.expErr != nil {
@@ -498,3 +1053,196 @@ func TestOrderRepository_GetStatistics(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_GetUserData(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx       context.Context
+		givenID        int
+		expOrders      int
+		expItems       int
+		expAddresses   int
+		expIdentities  int
+		expAPIKeys     int
+		expDataRequest int
+		expErr         error
+	}{
+		"success": {
+			givenCtx:       auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:        10,
+			expOrders:      1,
+			expItems:       2,
+			expAddresses:   1,
+			expIdentities:  1,
+			expAPIKeys:     1,
+			expDataRequest: 1,
+		},
+		"deleted_user": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  11,
+		},
+		"other_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  12,
+			expErr:   sql.ErrNoRows,
+		},
+		"not_found": {
+			givenCtx: context.Background(),
+			givenID:  99,
+			expErr:   sql.ErrNoRows,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/erase_user.sql")
+			defer dbTest.Exec(eraseUserCleanUpQuery)
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.GetUserData(tc.givenCtx, tc.givenID)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.givenID, result.ID)
+			require.Len(t, result.R.Orders, tc.expOrders)
+			items := 0
+			for _, o := range result.R.Orders {
+				items += len(o.R.OrderItems)
+			}
+			require.Equal(t, tc.expItems, items)
+			require.Len(t, result.R.Addresses, tc.expAddresses)
+			require.Len(t, result.R.Identities, tc.expIdentities)
+			require.Len(t, result.R.APIKeys, tc.expAPIKeys)
+			require.Len(t, result.R.SubjectDataRequests, tc.expDataRequest)
+		})
+	}
+}
+
+func TestUserRepository_EraseUser(t *testing.T) {
+	tcs := map[string]struct {
+		givenCtx context.Context
+		givenID  int
+		rowsAff  int64
+	}{
+		"success": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  10,
+			rowsAff:  1,
+		},
+		"success_deleted_user": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  11,
+			rowsAff:  1,
+		},
+		"already_erased": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  13,
+			rowsAff:  0,
+		},
+		"other_organization": {
+			givenCtx: auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			givenID:  12,
+			rowsAff:  0,
+		},
+		"not_found": {
+			givenCtx: context.Background(),
+			givenID:  99,
+			rowsAff:  0,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/erase_user.sql")
+			defer dbTest.Exec(eraseUserCleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest, encryptiontest.KeyRing())
+
+			// When
+			result, err := repo.EraseUser(tc.givenCtx, txTest, tc.givenID)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.rowsAff, result)
+			if tc.rowsAff == 0 {
+				return
+			}
+
+			user, err := model.FindUser(context.Background(), txTest, tc.givenID)
+			require.NoError(t, err)
+			require.Equal(t, "Erased user", user.Name)
+			require.NotContains(t, user.Email, "example.com")
+			require.Empty(t, user.Phone)
+			require.Empty(t, user.Password)
+			require.True(t, user.ErasedAt.Valid)
+			require.True(t, user.DeletedAt.Valid)
+
+			// The credentials are deleted, the orders are kept
+			addresses, err := model.Addresses(model.AddressWhere.UserID.EQ(tc.givenID)).Count(context.Background(), txTest)
+			require.NoError(t, err)
+			require.Zero(t, addresses)
+			apiKeys, err := model.APIKeys(model.APIKeyWhere.UserID.EQ(tc.givenID)).Count(context.Background(), txTest)
+			require.NoError(t, err)
+			require.Zero(t, apiKeys)
+			failures, err := model.LoginFailures(model.LoginFailureWhere.Key.EQ(encryptiontest.KeyRing().BlindIndex("test10@example.com"))).Count(context.Background(), txTest)
+			require.NoError(t, err)
+			require.Zero(t, failures)
+			orders, err := model.Orders(model.OrderWhere.UserID.EQ(10)).Count(context.Background(), txTest)
+			require.NoError(t, err)
+			require.EqualValues(t, 1, orders)
+		})
+	}
+}
+
+func TestUserRepository_ReencryptUsers(t *testing.T) {
+	// Given
+	dbTest, err := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, err)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/users.sql")
+	defer dbTest.Exec("DELETE FROM users;")
+
+	repo := New(dbTest, encryptiontest.KeyRing())
+
+	// When
+	first, err := repo.ReencryptUsers(context.Background(), 0, 3)
+	require.NoError(t, err)
+	second, err := repo.ReencryptUsers(context.Background(), first.LastID, 3)
+	require.NoError(t, err)
+	again, err := repo.ReencryptUsers(context.Background(), 0, 10)
+	require.NoError(t, err)
+
+	// Then
+	// test2 was already encrypted with the active key
+	require.Equal(t, ReencryptResult{LastID: 12, Scanned: 3, Reencrypted: 2}, first)
+	require.Equal(t, ReencryptResult{LastID: 14, Scanned: 2, Reencrypted: 2}, second)
+	require.Equal(t, ReencryptResult{LastID: 14, Scanned: 5, Reencrypted: 0}, again)
+
+	users, err := model.Users().All(context.Background(), dbTest)
+	require.NoError(t, err)
+	for _, u := range users {
+		require.True(t, encryption.IsEncrypted(u.Email))
+		require.True(t, u.EmailIndex.Valid)
+	}
+	result, err := repo.GetUserByEmail(context.Background(), "test1@example.com")
+	require.NoError(t, err)
+	require.Equal(t, 10, result.ID)
+	require.Equal(t, "test", result.Phone)
+}
@@ -0,0 +1,48 @@
+package variant
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type IVariant interface {
+	// GetOptions returns the options of the products, ordered by their position
+	GetOptions(ctx context.Context, productIDs []int) ([]model.ProductOption, error)
+
+	// SetOptions replaces the options of the product, the position of each option is its index
+	SetOptions(ctx context.Context, tx *sql.Tx, productID int, names []string) error
+
+	// GetVariants returns the variants of the products with their option values
+	GetVariants(ctx context.Context, productIDs []int) ([]Variant, error)
+
+	// GetVariant returns the variant with the given id
+	GetVariant(ctx context.Context, id int) (Variant, error)
+
+	// ExistsVariantBySKU checks whether a variant other than exceptID has the sku
+	ExistsVariantBySKU(ctx context.Context, sku string, exceptID int) (bool, error)
+
+	// ExistsVariantByProductID checks whether the product has variants
+	ExistsVariantByProductID(ctx context.Context, productID int) (bool, error)
+
+	// CreateVariant creates a new variant with its option values
+	CreateVariant(ctx context.Context, tx *sql.Tx, variant Variant) (Variant, error)
+
+	// UpdateVariant updates the variant and replaces its option values
+	UpdateVariant(ctx context.Context, tx *sql.Tx, variant Variant) (int64, error)
+
+	// DeleteVariant deletes the variant of the product
+	DeleteVariant(ctx context.Context, productID, id int) (int64, error)
+
+	// DecreaseQuantity takes the quantity from the stock of the variant, nothing is updated if the stock is not enough
+	DecreaseQuantity(ctx context.Context, tx *sql.Tx, id, quantity int) (int64, error)
+}
+
+type impl struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) IVariant {
+	return impl{db: db}
+}
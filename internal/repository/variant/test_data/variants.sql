@@ -0,0 +1,26 @@
+INSERT INTO "organizations" ("id", "name") VALUES
+(100, 'Shop A');
+
+INSERT INTO users ("id", "name", "email", "phone", password)
+VALUES (1, 'admin', 'admin@example.com', '0987654321', '123456789');
+
+INSERT INTO products ("id", "title", "price", "quantity", "user_id", "is_active")
+VALUES (1, 'Shirt', 100, 10, 1, true),
+       (2, 'Book', 50, 20, 1, true);
+
+INSERT INTO product_options ("id", "product_id", "name", "position") VALUES
+(10, 1, 'Colour', 1),
+(11, 1, 'Size', 0);
+
+INSERT INTO product_variants ("id", "organization_id", "product_id", "sku", "price", "quantity") VALUES
+(20, 1, 1, 'SHIRT-M-RED', 120, 5),
+(21, 1, 1, 'SHIRT-L-RED', NULL, 0),
+(22, 100, 1, 'SHIRT-S-RED', NULL, 3);
+
+INSERT INTO product_variant_options ("variant_id", "option_id", "value") VALUES
+(20, 10, 'Red'),
+(20, 11, 'M'),
+(21, 10, 'Red'),
+(21, 11, 'L'),
+(22, 10, 'Red'),
+(22, 11, 'S');
@@ -0,0 +1,225 @@
+package variant
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/tenant"
+)
+
+// Variant is a variant of a product with the value of each option of the product
+type Variant struct {
+	model.ProductVariant
+	Values []OptionValue
+}
+
+// OptionValue is the value of an option for a variant, e.g. Size M
+type OptionValue struct {
+	VariantID int    `boil:"variant_id"`
+	OptionID  int    `boil:"option_id"`
+	Value     string `boil:"value"`
+}
+
+// toInterfaces converts the ids to the arguments of a WHERE IN query
+func toInterfaces(ids []int) []interface{} {
+	result := make([]interface{}, len(ids))
+	for i, id := range ids {
+		result[i] = id
+	}
+	return result
+}
+
+// GetOptions returns the options of the products, ordered by the product and then their position
+func (r impl) GetOptions(ctx context.Context, productIDs []int) ([]model.ProductOption, error) {
+	slice, err := model.ProductOptions(
+		model.ProductOptionWhere.ProductID.IN(productIDs),
+		qm.OrderBy(model.ProductOptionColumns.ProductID+", "+model.ProductOptionColumns.Position+", "+model.ProductOptionColumns.ID),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ProductOption, 0, len(slice))
+	for _, o := range slice {
+		result = append(result, *o)
+	}
+	return result, nil
+}
+
+// SetOptions replaces the options of the product
+func (r impl) SetOptions(ctx context.Context, tx *sql.Tx, productID int, names []string) error {
+	if _, err := model.ProductOptions(model.ProductOptionWhere.ProductID.EQ(productID)).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+	for i, name := range names {
+		option := model.ProductOption{ProductID: productID, Name: name, Position: i}
+		if err := option.Insert(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getValues returns the option values of the variants, ordered by the position of the options
+func (r impl) getValues(ctx context.Context, variantIDs []int) ([]OptionValue, error) {
+	var result []OptionValue
+	if len(variantIDs) == 0 {
+		return result, nil
+	}
+	err := model.NewQuery(
+		qm.Select("pvo.variant_id", "pvo.option_id", "pvo.value"),
+		qm.From("product_variant_options pvo"),
+		qm.InnerJoin("product_options po ON po.id = pvo.option_id"),
+		qm.WhereIn("pvo.variant_id IN ?", toInterfaces(variantIDs)...),
+		qm.OrderBy("po.position, po.id"),
+	).Bind(ctx, r.db, &result)
+	return result, err
+}
+
+// GetVariants returns the variants of the products in the organization, ordered by the product and then their id
+func (r impl) GetVariants(ctx context.Context, productIDs []int) ([]Variant, error) {
+	slice, err := model.ProductVariants(
+		model.ProductVariantWhere.ProductID.IN(productIDs),
+		tenant.Where(ctx, model.ProductVariantTableColumns.OrganizationID),
+		qm.OrderBy(model.ProductVariantColumns.ProductID+", "+model.ProductVariantColumns.ID),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(slice))
+	for i, v := range slice {
+		ids[i] = v.ID
+	}
+	values, err := r.getValues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	valuesByVariant := map[int][]OptionValue{}
+	for _, v := range values {
+		valuesByVariant[v.VariantID] = append(valuesByVariant[v.VariantID], v)
+	}
+
+	result := make([]Variant, len(slice))
+	for i, v := range slice {
+		result[i] = Variant{ProductVariant: *v, Values: valuesByVariant[v.ID]}
+	}
+	return result, nil
+}
+
+// GetVariant returns the variant with the given id, variants of other organizations are not found
+func (r impl) GetVariant(ctx context.Context, id int) (Variant, error) {
+	variant, err := model.ProductVariants(
+		model.ProductVariantWhere.ID.EQ(id),
+		tenant.Where(ctx, model.ProductVariantTableColumns.OrganizationID),
+	).One(ctx, r.db)
+	if err != nil {
+		return Variant{}, err
+	}
+
+	values, err := r.getValues(ctx, []int{id})
+	if err != nil {
+		return Variant{}, err
+	}
+	return Variant{ProductVariant: *variant, Values: values}, nil
+}
+
+// ExistsVariantBySKU checks whether a variant other than exceptID has the sku in the organization
+func (r impl) ExistsVariantBySKU(ctx context.Context, sku string, exceptID int) (bool, error) {
+	return model.ProductVariants(
+		model.ProductVariantWhere.Sku.EQ(sku),
+		model.ProductVariantWhere.ID.NEQ(exceptID),
+		tenant.Where(ctx, model.ProductVariantTableColumns.OrganizationID),
+	).Exists(ctx, r.db)
+}
+
+// ExistsVariantByProductID checks whether the product has variants
+func (r impl) ExistsVariantByProductID(ctx context.Context, productID int) (bool, error) {
+	return model.ProductVariants(model.ProductVariantWhere.ProductID.EQ(productID)).Exists(ctx, r.db)
+}
+
+// insertValues inserts the option values of the variant
+func insertValues(ctx context.Context, tx *sql.Tx, variantID int, values []OptionValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	rows := make([]string, len(values))
+	args := []interface{}{variantID}
+	for i, v := range values {
+		rows[i] = fmt.Sprintf("($1, $%d, $%d)", 2*i+2, 2*i+3)
+		args = append(args, v.OptionID, v.Value)
+	}
+	_, err := queries.Raw("INSERT INTO product_variant_options (variant_id, option_id, value) VALUES "+strings.Join(rows, ", "), args...).ExecContext(ctx, tx)
+	return err
+}
+
+// CreateVariant creates a new variant in the organization with its option values
+func (r impl) CreateVariant(ctx context.Context, tx *sql.Tx, variant Variant) (Variant, error) {
+	variant.OrganizationID = tenant.ID(ctx)
+	if err := variant.ProductVariant.Insert(ctx, tx, boil.Whitelist("organization_id", "product_id", "sku", "price", "quantity", "created_at", "updated_at")); err != nil {
+		return Variant{}, err
+	}
+
+	for i := range variant.Values {
+		variant.Values[i].VariantID = variant.ID
+	}
+	if err := insertValues(ctx, tx, variant.ID, variant.Values); err != nil {
+		return Variant{}, err
+	}
+	return variant, nil
+}
+
+// UpdateVariant updates the sku, the price and the quantity of the variant of the product and replaces its option values
+func (r impl) UpdateVariant(ctx context.Context, tx *sql.Tx, variant Variant) (int64, error) {
+	affected, err := model.ProductVariants(
+		model.ProductVariantWhere.ID.EQ(variant.ID),
+		model.ProductVariantWhere.ProductID.EQ(variant.ProductID),
+		tenant.Where(ctx, model.ProductVariantTableColumns.OrganizationID),
+	).UpdateAll(ctx, tx, model.M{
+		model.ProductVariantColumns.Sku:       variant.Sku,
+		model.ProductVariantColumns.Price:     variant.Price,
+		model.ProductVariantColumns.Quantity:  variant.Quantity,
+		model.ProductVariantColumns.UpdatedAt: time.Now(),
+	})
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+
+	if _, err := queries.Raw("DELETE FROM product_variant_options WHERE variant_id = $1", variant.ID).ExecContext(ctx, tx); err != nil {
+		return 0, err
+	}
+	if err := insertValues(ctx, tx, variant.ID, variant.Values); err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// DeleteVariant deletes the variant of the product, the order items of the variant are kept
+func (r impl) DeleteVariant(ctx context.Context, productID, id int) (int64, error) {
+	return model.ProductVariants(
+		model.ProductVariantWhere.ID.EQ(id),
+		model.ProductVariantWhere.ProductID.EQ(productID),
+		tenant.Where(ctx, model.ProductVariantTableColumns.OrganizationID),
+	).DeleteAll(ctx, r.db)
+}
+
+// DecreaseQuantity takes the quantity from the stock of the variant in one statement, so concurrent orders cannot oversell it
+func (r impl) DecreaseQuantity(ctx context.Context, tx *sql.Tx, id, quantity int) (int64, error) {
+	result, err := queries.Raw(
+		"UPDATE product_variants SET quantity = quantity - $1, updated_at = NOW() WHERE id = $2 AND quantity >= $1",
+		quantity, id,
+	).ExecContext(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
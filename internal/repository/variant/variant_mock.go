@@ -0,0 +1,64 @@
+package variant
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetOptions(ctx context.Context, productIDs []int) ([]model.ProductOption, error) {
+	args := m.Called(ctx, productIDs)
+	return args.Get(0).([]model.ProductOption), args.Error(1)
+}
+
+func (m *Mock) SetOptions(ctx context.Context, tx *sql.Tx, productID int, names []string) error {
+	args := m.Called(ctx, tx, productID, names)
+	return args.Error(0)
+}
+
+func (m *Mock) GetVariants(ctx context.Context, productIDs []int) ([]Variant, error) {
+	args := m.Called(ctx, productIDs)
+	return args.Get(0).([]Variant), args.Error(1)
+}
+
+func (m *Mock) GetVariant(ctx context.Context, id int) (Variant, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(Variant), args.Error(1)
+}
+
+func (m *Mock) ExistsVariantBySKU(ctx context.Context, sku string, exceptID int) (bool, error) {
+	args := m.Called(ctx, sku, exceptID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Mock) ExistsVariantByProductID(ctx context.Context, productID int) (bool, error) {
+	args := m.Called(ctx, productID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Mock) CreateVariant(ctx context.Context, tx *sql.Tx, variant Variant) (Variant, error) {
+	args := m.Called(ctx, tx, variant)
+	return args.Get(0).(Variant), args.Error(1)
+}
+
+func (m *Mock) UpdateVariant(ctx context.Context, tx *sql.Tx, variant Variant) (int64, error) {
+	args := m.Called(ctx, tx, variant)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) DeleteVariant(ctx context.Context, productID, id int) (int64, error) {
+	args := m.Called(ctx, productID, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Mock) DecreaseQuantity(ctx context.Context, tx *sql.Tx, id, quantity int) (int64, error) {
+	args := m.Called(ctx, tx, id, quantity)
+	return args.Get(0).(int64), args.Error(1)
+}
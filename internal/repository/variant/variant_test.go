@@ -0,0 +1,188 @@
+package variant
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/db"
+)
+
+const cleanUpQuery = "DELETE FROM product_variant_options; DELETE FROM product_variants; DELETE FROM product_options; DELETE FROM products; DELETE FROM users; DELETE FROM organizations WHERE id <> 1;"
+
+func TestVariantRepository_GetOptions(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/variants.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	repo := New(dbTest)
+
+	// When
+	result, err := repo.GetOptions(context.Background(), []int{1, 2})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	require.Equal(t, "Size", result[0].Name)
+	require.Equal(t, "Colour", result[1].Name)
+}
+
+func TestVariantRepository_GetVariants(t *testing.T) {
+	tcs := map[string]struct {
+		ctx    context.Context
+		expIDs []int
+	}{
+		"default_organization": {
+			ctx:    context.Background(),
+			expIDs: []int{20, 21},
+		},
+		"other_organization": {
+			ctx:    auth.NewTenantContext(context.Background(), 100),
+			expIDs: []int{22},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/variants.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.GetVariants(tc.ctx, []int{1})
+
+			// Then
+			require.NoError(t, err)
+			ids := make([]int, 0, len(result))
+			for _, v := range result {
+				ids = append(ids, v.ID)
+				// the values are ordered by the position of the options
+				require.Len(t, v.Values, 2)
+				require.Equal(t, 11, v.Values[0].OptionID)
+				require.Equal(t, 10, v.Values[1].OptionID)
+			}
+			require.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
+
+func TestVariantRepository_ExistsVariantBySKU(t *testing.T) {
+	tcs := map[string]struct {
+		sku      string
+		exceptID int
+		expExist bool
+	}{
+		"existed": {
+			sku:      "SHIRT-M-RED",
+			expExist: true,
+		},
+		"same_variant": {
+			sku:      "SHIRT-M-RED",
+			exceptID: 20,
+		},
+		"other_organization": {
+			sku: "SHIRT-S-RED",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/variants.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.ExistsVariantBySKU(context.Background(), tc.sku, tc.exceptID)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expExist, result)
+		})
+	}
+}
+
+func TestVariantRepository_CreateVariant(t *testing.T) {
+	// Given
+	dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+	require.NoError(t, dbErr)
+
+	db.LoadSqlTestFile(t, dbTest, "test_data/variants.sql")
+	defer dbTest.Exec(cleanUpQuery)
+
+	txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+	require.NoError(t, err)
+	defer txTest.Rollback()
+
+	repo := New(dbTest)
+
+	// When
+	result, err := repo.CreateVariant(context.Background(), txTest, Variant{
+		ProductVariant: model.ProductVariant{ProductID: 1, Sku: "SHIRT-XL-BLUE", Price: null.Float64From(140), Quantity: 2},
+		Values:         []OptionValue{{OptionID: 11, Value: "XL"}, {OptionID: 10, Value: "Blue"}},
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.NotZero(t, result.ID)
+	require.Equal(t, 1, result.OrganizationID)
+	require.Equal(t, result.ID, result.Values[0].VariantID)
+}
+
+func TestVariantRepository_DecreaseQuantity(t *testing.T) {
+	tcs := map[string]struct {
+		quantity    int
+		expAffected int64
+	}{
+		"in_stock": {
+			quantity:    5,
+			expAffected: 1,
+		},
+		"out_of_stock": {
+			quantity: 6,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			dbTest, dbErr := db.DBConnect(os.Getenv("DB_URL"))
+			require.NoError(t, dbErr)
+
+			db.LoadSqlTestFile(t, dbTest, "test_data/variants.sql")
+			defer dbTest.Exec(cleanUpQuery)
+
+			txTest, err := dbTest.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault})
+			require.NoError(t, err)
+			defer txTest.Rollback()
+
+			repo := New(dbTest)
+
+			// When
+			result, err := repo.DecreaseQuantity(context.Background(), txTest, 20, tc.quantity)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expAffected, result)
+		})
+	}
+}
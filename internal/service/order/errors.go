@@ -5,6 +5,12 @@ import (
 )
 
 var (
-	ErrUserNotExist    = errors.New("user does not exist")
-	ErrProductNotExist = errors.New("product does not exist")
+	ErrUserNotExist     = errors.New("user does not exist")
+	ErrProductNotExist  = errors.New("product does not exist")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrAddressNotExist  = errors.New("address does not exist")
+
+	ErrVariantRequired   = errors.New("variant is required for the product with variants")
+	ErrVariantNotExist   = errors.New("variant does not exist")
+	ErrVariantOutOfStock = errors.New("variant is out of stock")
 )
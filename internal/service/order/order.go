@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null/v8"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
 	orderRepo "github.com/vinhnv1/s3corp-golang-fresher/internal/repository/order"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
 )
 
 type OrderStatus string
@@ -24,8 +26,10 @@ const (
 
 type OrderItemInput struct {
 	ProductID    int
+	VariantID    int // required if the product has variants
 	ProductPrice float64
 	ProductName  string
+	VariantSKU   string
 	Quantity     int
 	Discount     float64
 	Note         string
@@ -35,9 +39,104 @@ type OrderInput struct {
 	Note   string
 	UserID int
 	Items  []OrderItemInput
+
+	// ShippingAddressID and BillingAddressID are addresses of the user, zero picks the default address of the user
+	ShippingAddressID int
+	BillingAddressID  int
+}
+
+// findAddress returns the address with the given id, or the default address picked by isDefault if id is zero
+func findAddress(addresses []model.Address, id int, isDefault func(model.Address) bool) (*model.Address, error) {
+	for i, address := range addresses {
+		if (id > 0 && address.ID == id) || (id == 0 && isDefault(address)) {
+			return &addresses[i], nil
+		}
+	}
+	if id > 0 {
+		return nil, ErrAddressNotExist
+	}
+	return nil, nil
+}
+
+// orderAddresses returns the shipping and billing address of the order, the billing address falls back to the shipping address.
+// Both are nil if the user has no address.
+func (serv impl) orderAddresses(ctx context.Context, input OrderInput) (*model.Address, *model.Address, error) {
+	addresses, err := serv.repo.Address().GetAddresses(ctx, input.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shipping, err := findAddress(addresses, input.ShippingAddressID, func(a model.Address) bool { return a.IsDefaultShipping })
+	if err != nil {
+		return nil, nil, err
+	}
+	billing, err := findAddress(addresses, input.BillingAddressID, func(a model.Address) bool { return a.IsDefaultBilling })
+	if err != nil {
+		return nil, nil, err
+	}
+	if billing == nil {
+		billing = shipping
+	}
+	return shipping, billing, nil
+}
+
+// toOrderAddress copies the address into an address of the order, later changes of the address book do not affect the order
+func toOrderAddress(orderID int, addressType string, address model.Address) model.OrderAddress {
+	return model.OrderAddress{
+		OrderID:    orderID,
+		Type:       addressType,
+		Name:       address.Name,
+		Phone:      address.Phone,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
+}
+
+// itemVariant sets the sku and the price of the variant of the item, the price of the variant overrides the price of the product.
+// The variant must be given if the product has variants.
+func (serv impl) itemVariant(ctx context.Context, item *OrderItemInput) error {
+	if item.VariantID == 0 {
+		hasVariants, err := serv.repo.Variant().ExistsVariantByProductID(ctx, item.ProductID)
+		if err != nil {
+			return err
+		}
+		if hasVariants {
+			return ErrVariantRequired
+		}
+		return nil
+	}
+
+	variant, err := serv.repo.Variant().GetVariant(ctx, item.VariantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrVariantNotExist
+	} else if err != nil {
+		return err
+	}
+	if variant.ProductID != item.ProductID {
+		return ErrVariantNotExist
+	}
+	if variant.Quantity < item.Quantity {
+		return ErrVariantOutOfStock
+	}
+
+	item.VariantSKU = variant.Sku
+	if variant.Price.Valid {
+		item.ProductPrice = variant.Price.Float64
+	}
+	return nil
 }
 
 func (serv impl) CreateOrder(ctx context.Context, input OrderInput) error {
+	// Only users with the order:write:any permission can create order for another user
+	caller, ok := auth.FromContext(ctx)
+	if !ok || !caller.CanAccess(auth.PermOrderWrite, input.UserID) {
+		return ErrPermissionDenied
+	}
+
 	// Check exists user by order user_id
 	existed, err := serv.repo.User().ExistsUserByID(ctx, input.UserID)
 	if err != nil {
@@ -47,7 +146,13 @@ func (serv impl) CreateOrder(ctx context.Context, input OrderInput) error {
 		return ErrUserNotExist
 	}
 
-	// Check exists product by order item product_id
+	// Get the shipping and billing address of the order
+	shipping, billing, err := serv.orderAddresses(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	// Check exists product by order item product_id, and its variant if the product has variants
 	for i, item := range input.Items {
 		product, err := serv.repo.Product().GetProduct(ctx, item.ProductID)
 		if errors.Is(err, sql.ErrNoRows) {
@@ -57,6 +162,10 @@ func (serv impl) CreateOrder(ctx context.Context, input OrderInput) error {
 		}
 		input.Items[i].ProductName = product.Title
 		input.Items[i].ProductPrice = product.Price
+
+		if err := serv.itemVariant(ctx, &input.Items[i]); err != nil {
+			return err
+		}
 	}
 
 	if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
@@ -78,9 +187,9 @@ func (serv impl) CreateOrder(ctx context.Context, input OrderInput) error {
 			return fmt.Errorf("error when create order: %v", err)
 		}
 
-		// Create order item
+		// Create order item, the stock of the variant is taken
 		for _, item := range input.Items {
-			if err := serv.repo.Order().CreateItem(ctx, tx, model.OrderItem{
+			orderItem := model.OrderItem{
 				OrderID:      order.ID,
 				ProductID:    item.ProductID,
 				ProductPrice: item.ProductPrice,
@@ -88,11 +197,35 @@ func (serv impl) CreateOrder(ctx context.Context, input OrderInput) error {
 				Quantity:     item.Quantity,
 				Discount:     item.Discount,
 				Note:         item.Note,
-			}); err != nil {
+				VariantSku:   item.VariantSKU,
+			}
+			if item.VariantID != 0 {
+				affected, err := serv.repo.Variant().DecreaseQuantity(ctx, tx, item.VariantID, item.Quantity)
+				if err != nil {
+					return fmt.Errorf("error when decrease variant quantity: %v", err)
+				}
+				if affected == 0 {
+					return ErrVariantOutOfStock
+				}
+				orderItem.VariantID = null.IntFrom(item.VariantID)
+			}
+			if err := serv.repo.Order().CreateItem(ctx, tx, orderItem); err != nil {
 				return fmt.Errorf("error when create order item: %v", err)
 			}
 		}
 
+		// Create the snapshot of the addresses
+		if shipping != nil {
+			if err := serv.repo.Order().CreateOrderAddress(ctx, tx, toOrderAddress(order.ID, orderRepo.AddressTypeShipping, *shipping)); err != nil {
+				return fmt.Errorf("error when create order address: %v", err)
+			}
+		}
+		if billing != nil {
+			if err := serv.repo.Order().CreateOrderAddress(ctx, tx, toOrderAddress(order.ID, orderRepo.AddressTypeBilling, *billing)); err != nil {
+				return fmt.Errorf("error when create order address: %v", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -132,6 +265,8 @@ type OrdersInput struct {
 type OrderItem struct {
 	ID           int
 	ProductID    int
+	VariantID    null.Int
+	VariantSKU   string
 	ProductPrice float64
 	ProductName  string
 	Quantity     int
@@ -141,21 +276,64 @@ type OrderItem struct {
 	UpdatedAt    time.Time
 }
 
+// OrderAddress represents the address of an order at the time it was placed
+type OrderAddress struct {
+	Name       string
+	Phone      string
+	Line1      string
+	Line2      string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
 // Order represents result item which will be returned
 type Order struct {
-	ID          int
-	OrderNumber string
-	OrderDate   time.Time
-	Status      string
-	Note        string
-	UserID      int
-	OrderItems  []OrderItem
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              int
+	OrderNumber     string
+	OrderDate       time.Time
+	Status          string
+	Note            string
+	UserID          int
+	OrderItems      []OrderItem
+	ShippingAddress *OrderAddress
+	BillingAddress  *OrderAddress
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// toAddress converts the address of the repository, it is nil if the order has no address
+func toAddress(address *orderRepo.OrderAddress) *OrderAddress {
+	if address == nil {
+		return nil
+	}
+	return &OrderAddress{
+		Name:       address.Name,
+		Phone:      address.Phone,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
 }
 
 // GetOrders returns list of orders which is filterd
 func (serv impl) GetOrders(ctx context.Context, input OrdersInput) ([]Order, int64, error) {
+	// Users without the order:read:any permission can only see their own orders
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return []Order{}, 0, ErrPermissionDenied
+	}
+	if !caller.HasPermission(auth.PermOrderReadAny) {
+		if !caller.HasPermission(auth.PermOrderRead) {
+			return []Order{}, 0, ErrPermissionDenied
+		}
+		input.Filter.UserID = caller.ID
+	}
+
 	orders, totalCount, err := serv.repo.Order().GetOrders(ctx, orderRepo.OrdersInput{
 		Filter: orderRepo.OrderFilter{
 			ID:          input.Filter.ID,
@@ -185,6 +363,8 @@ func (serv impl) GetOrders(ctx context.Context, input OrdersInput) ([]Order, int
 			orderItems[j] = OrderItem{
 				ID:           orderItem.ID,
 				ProductID:    orderItem.ProductID,
+				VariantID:    orderItem.VariantID,
+				VariantSKU:   orderItem.VariantSKU,
 				ProductPrice: orderItem.ProductPrice,
 				ProductName:  orderItem.ProductName,
 				Quantity:     orderItem.Quantity,
@@ -196,15 +376,17 @@ func (serv impl) GetOrders(ctx context.Context, input OrdersInput) ([]Order, int
 		}
 
 		result[i] = Order{
-			ID:          order.ID,
-			OrderNumber: order.OrderNumber,
-			OrderDate:   order.OrderDate,
-			Status:      order.Status,
-			Note:        order.Note,
-			UserID:      order.UserID,
-			OrderItems:  orderItems,
-			CreatedAt:   order.CreatedAt,
-			UpdatedAt:   order.UpdatedAt,
+			ID:              order.ID,
+			OrderNumber:     order.OrderNumber,
+			OrderDate:       order.OrderDate,
+			Status:          order.Status,
+			Note:            order.Note,
+			UserID:          order.UserID,
+			OrderItems:      orderItems,
+			ShippingAddress: toAddress(order.ShippingAddress),
+			BillingAddress:  toAddress(order.BillingAddress),
+			CreatedAt:       order.CreatedAt,
+			UpdatedAt:       order.UpdatedAt,
 		}
 	}
 
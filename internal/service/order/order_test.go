@@ -7,13 +7,23 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/address"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/order"
 	orderRepo "github.com/vinhnv1/s3corp-golang-fresher/internal/repository/order"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/variant"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+// Permissions of the seeded GUEST and ADMIN roles used by the tests
+var (
+	guestPermissions = []string{auth.PermOrderRead, auth.PermOrderWrite}
+	adminPermissions = []string{auth.PermOrderRead, auth.PermOrderReadAny, auth.PermOrderWrite, auth.PermOrderWriteAny}
 )
 
 func TestOrderService_CreateOrder(t *testing.T) {
@@ -24,8 +34,14 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		userErr    error
 		product    []model.Product
 		productErr []error
+		addresses  []model.Address
+		// variants of the products
+		hasVariants bool
+		variant     variant.Variant
+		variantErr  error
 	}
 	type givenData struct {
+		ctx   context.Context
 		input OrderInput
 		mock  mockData
 	}
@@ -35,6 +51,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 	}{
 		"success": {
 			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
 				input: OrderInput{
 					Note:   "New order",
 					UserID: 2,
@@ -77,6 +94,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		},
 		"error_user_is_not_exists": {
 			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
 				input: OrderInput{
 					Note:   "New order",
 					UserID: 2,
@@ -119,6 +137,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		},
 		"error_product_is_not_exists": {
 			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
 				input: OrderInput{
 					Note:   "New order",
 					UserID: 2,
@@ -159,28 +178,181 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			},
 			expErr: ErrProductNotExist,
 		},
+		"success_with_addresses": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				input: OrderInput{
+					Note:              "New order",
+					UserID:            2,
+					Items:             []OrderItemInput{{ProductID: 1, Quantity: 10}},
+					ShippingAddressID: 6,
+				},
+				mock: mockData{
+					txFn:       mock.AnythingOfType("func(*sql.Tx) error"),
+					userExist:  true,
+					product:    []model.Product{{ID: 1, Title: "product 1", Price: 100}},
+					productErr: []error{nil},
+					addresses: []model.Address{
+						{ID: 5, UserID: 2, Line1: "1 Le Loi", Country: "VN", IsDefaultShipping: true, IsDefaultBilling: true},
+						{ID: 6, UserID: 2, Line1: "2 Nguyen Hue", Country: "VN"},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"error_address_is_not_exists": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				input: OrderInput{
+					Note:             "New order",
+					UserID:           2,
+					Items:            []OrderItemInput{{ProductID: 1, Quantity: 10}},
+					BillingAddressID: 7,
+				},
+				mock: mockData{
+					txFn:       mock.AnythingOfType("func(*sql.Tx) error"),
+					userExist:  true,
+					product:    []model.Product{{ID: 1, Title: "product 1", Price: 100}},
+					productErr: []error{nil},
+					addresses: []model.Address{
+						{ID: 5, UserID: 2, Line1: "1 Le Loi", Country: "VN", IsDefaultShipping: true, IsDefaultBilling: true},
+					},
+				},
+			},
+			expErr: ErrAddressNotExist,
+		},
+		"error_permission_denied": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 3, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				input: OrderInput{
+					Note:   "New order",
+					UserID: 2,
+					Items: []OrderItemInput{
+						{
+							ProductID: 1,
+							Quantity:  10,
+							Discount:  0,
+							Note:      "item 1",
+						},
+					},
+				},
+				mock: mockData{
+					product:    []model.Product{{ID: 1}},
+					productErr: []error{nil},
+				},
+			},
+			expErr: ErrPermissionDenied,
+		},
+		"success_with_variant": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				input: OrderInput{
+					UserID: 2,
+					Items:  []OrderItemInput{{ProductID: 1, VariantID: 4, Quantity: 2}},
+				},
+				mock: mockData{
+					txFn:       mock.AnythingOfType("func(*sql.Tx) error"),
+					userExist:  true,
+					product:    []model.Product{{ID: 1, Title: "product 1", Price: 100}},
+					productErr: []error{nil},
+					variant:    variant.Variant{ProductVariant: model.ProductVariant{ID: 4, ProductID: 1, Sku: "P1-M", Price: null.Float64From(120), Quantity: 5}},
+				},
+			},
+			expErr: nil,
+		},
+		"error_variant_is_required": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				input: OrderInput{
+					UserID: 2,
+					Items:  []OrderItemInput{{ProductID: 1, Quantity: 2}},
+				},
+				mock: mockData{
+					userExist:   true,
+					product:     []model.Product{{ID: 1, Title: "product 1", Price: 100}},
+					productErr:  []error{nil},
+					hasVariants: true,
+				},
+			},
+			expErr: ErrVariantRequired,
+		},
+		"error_variant_of_another_product": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				input: OrderInput{
+					UserID: 2,
+					Items:  []OrderItemInput{{ProductID: 1, VariantID: 4, Quantity: 2}},
+				},
+				mock: mockData{
+					userExist:  true,
+					product:    []model.Product{{ID: 1, Title: "product 1", Price: 100}},
+					productErr: []error{nil},
+					variant:    variant.Variant{ProductVariant: model.ProductVariant{ID: 4, ProductID: 2, Sku: "P2-M", Quantity: 5}},
+				},
+			},
+			expErr: ErrVariantNotExist,
+		},
+		"error_variant_is_not_exists": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				input: OrderInput{
+					UserID: 2,
+					Items:  []OrderItemInput{{ProductID: 1, VariantID: 4, Quantity: 2}},
+				},
+				mock: mockData{
+					userExist:  true,
+					product:    []model.Product{{ID: 1, Title: "product 1", Price: 100}},
+					productErr: []error{nil},
+					variantErr: sql.ErrNoRows,
+				},
+			},
+			expErr: ErrVariantNotExist,
+		},
+		"error_variant_is_out_of_stock": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				input: OrderInput{
+					UserID: 2,
+					Items:  []OrderItemInput{{ProductID: 1, VariantID: 4, Quantity: 6}},
+				},
+				mock: mockData{
+					userExist:  true,
+					product:    []model.Product{{ID: 1, Title: "product 1", Price: 100}},
+					productErr: []error{nil},
+					variant:    variant.Variant{ProductVariant: model.ProductVariant{ID: 4, ProductID: 1, Sku: "P1-M", Quantity: 5}},
+				},
+			},
+			expErr: ErrVariantOutOfStock,
+		},
 	}
 
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
 			// Given
 			repoMock := new(repository.Mock)
-			repoMock.On("Tx", context.Background(), tc.given.mock.txFn).Return(tc.given.mock.txErr)
+			repoMock.On("Tx", tc.given.ctx, tc.given.mock.txFn).Return(tc.given.mock.txErr)
 			userRepo := new(user.Mock)
-			userRepo.On("ExistsUserByID", context.Background(), tc.given.input.UserID).Return(tc.given.mock.userExist, tc.given.mock.userErr)
+			userRepo.On("ExistsUserByID", tc.given.ctx, tc.given.input.UserID).Return(tc.given.mock.userExist, tc.given.mock.userErr)
 			repoMock.On("User").Return(userRepo)
+			addressRepo := new(address.Mock)
+			addressRepo.On("GetAddresses", tc.given.ctx, tc.given.input.UserID).Return(tc.given.mock.addresses, nil)
+			repoMock.On("Address").Return(addressRepo)
 			productRepo := new(product.Mock)
 			for i, item := range tc.given.input.Items {
-				productRepo.On("GetProduct", context.Background(), item.ProductID).Return(tc.given.mock.product[i], tc.given.mock.productErr[i])
+				productRepo.On("GetProduct", tc.given.ctx, item.ProductID).Return(tc.given.mock.product[i], tc.given.mock.productErr[i])
 			}
 			repoMock.On("Product").Return(productRepo)
+			variantRepo := new(variant.Mock)
+			variantRepo.On("ExistsVariantByProductID", tc.given.ctx, mock.Anything).Return(tc.given.mock.hasVariants, nil)
+			variantRepo.On("GetVariant", tc.given.ctx, mock.Anything).Return(tc.given.mock.variant, tc.given.mock.variantErr)
+			repoMock.On("Variant").Return(variantRepo)
 			orderRepoMock := new(order.Mock)
 			repoMock.On("Order").Return(orderRepoMock)
 
 			orderServ := New(repoMock)
 
 			// When
-			err := orderServ.CreateOrder(context.Background(), tc.given.input)
+			err := orderServ.CreateOrder(tc.given.ctx, tc.given.input)
 
 			// Then
 			if tc.expErr != nil {
@@ -192,6 +364,79 @@ func TestOrderService_CreateOrder(t *testing.T) {
 	}
 }
 
+func TestOrderService_orderAddresses(t *testing.T) {
+	addresses := []model.Address{
+		{ID: 5, UserID: 2, Line1: "1 Le Loi", IsDefaultShipping: true},
+		{ID: 6, UserID: 2, Line1: "2 Nguyen Hue", IsDefaultBilling: true},
+		{ID: 7, UserID: 2, Line1: "3 Hang Bai"},
+	}
+	tcs := map[string]struct {
+		givenAddresses []model.Address
+		givenInput     OrderInput
+		expShippingID  int
+		expBillingID   int
+		expErr         error
+	}{
+		"default_addresses": {
+			givenAddresses: addresses,
+			givenInput:     OrderInput{UserID: 2},
+			expShippingID:  5,
+			expBillingID:   6,
+		},
+		"selected_addresses": {
+			givenAddresses: addresses,
+			givenInput:     OrderInput{UserID: 2, ShippingAddressID: 7, BillingAddressID: 5},
+			expShippingID:  7,
+			expBillingID:   5,
+		},
+		"billing_falls_back_to_shipping": {
+			givenAddresses: []model.Address{{ID: 7, UserID: 2, Line1: "3 Hang Bai"}},
+			givenInput:     OrderInput{UserID: 2, ShippingAddressID: 7},
+			expShippingID:  7,
+			expBillingID:   7,
+		},
+		"no_addresses": {
+			givenAddresses: []model.Address{},
+			givenInput:     OrderInput{UserID: 2},
+		},
+		"address_of_other_user": {
+			givenAddresses: addresses,
+			givenInput:     OrderInput{UserID: 2, ShippingAddressID: 8},
+			expErr:         ErrAddressNotExist,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repoMock := new(repository.Mock)
+			addressRepo := new(address.Mock)
+			addressRepo.On("GetAddresses", ctx, tc.givenInput.UserID).Return(tc.givenAddresses, nil)
+			repoMock.On("Address").Return(addressRepo)
+
+			serv := impl{repo: repoMock}
+
+			// When
+			shipping, billing, err := serv.orderAddresses(ctx, tc.givenInput)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expShippingID == 0 {
+				require.Nil(t, shipping)
+				require.Nil(t, billing)
+				return
+			}
+			require.Equal(t, tc.expShippingID, shipping.ID)
+			require.Equal(t, tc.expBillingID, billing.ID)
+		})
+	}
+}
+
 func TestOrderService_GetOrders(t *testing.T) {
 	type mockData struct {
 		inputCTX         context.Context
@@ -216,7 +461,7 @@ func TestOrderService_GetOrders(t *testing.T) {
 	}{
 		"success": {
 			input: input{
-				ctx: context.Background(),
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: adminPermissions}),
 				ordersInput: OrdersInput{
 					Filter: OrderFilter{
 						ID:          1,
@@ -234,7 +479,7 @@ func TestOrderService_GetOrders(t *testing.T) {
 					},
 				},
 				mockData: mockData{
-					inputCTX: context.Background(),
+					inputCTX: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: adminPermissions}),
 					input: orderRepo.OrdersInput{
 						Filter: orderRepo.OrderFilter{
 							ID:          1,
@@ -326,6 +571,37 @@ func TestOrderService_GetOrders(t *testing.T) {
 				},
 			},
 		},
+		"success_guest_only_sees_own_orders": {
+			input: input{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 3, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				ordersInput: OrdersInput{
+					Filter: OrderFilter{
+						UserID: 2,
+					},
+				},
+				mockData: mockData{
+					inputCTX: auth.NewContext(context.Background(), auth.User{ID: 3, Role: auth.RoleGuest, Permissions: guestPermissions}),
+					input: orderRepo.OrdersInput{
+						Filter: orderRepo.OrderFilter{
+							UserID: 3,
+						},
+					},
+					mockResultOrders: []orderRepo.Order{},
+				},
+			},
+			expOutput: output{
+				orders: []Order{},
+			},
+		},
+		"error_anonymous": {
+			input: input{
+				ctx: context.Background(),
+			},
+			expOutput: output{
+				orders: []Order{},
+				err:    ErrPermissionDenied,
+			},
+		},
 	}
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
@@ -0,0 +1,268 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+)
+
+// Category is a node of the category tree, Children are only set by GetCategories
+type Category struct {
+	ID        int
+	ParentID  null.Int
+	Name      string
+	Slug      string
+	SortOrder int
+	Path      string
+	Children  []Category
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CategoryInput struct {
+	ParentID  int // 0 for a root category
+	Name      string
+	Slug      string
+	SortOrder int
+}
+
+type CategoryCount struct {
+	CategoryID   int
+	Name         string
+	Slug         string
+	ProductCount int64
+}
+
+// toCategory converts model.Category to Category
+func toCategory(category model.Category) Category {
+	return Category{
+		ID:        category.ID,
+		ParentID:  category.ParentID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		SortOrder: category.SortOrder,
+		Path:      category.Path,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+// buildCategoryTree returns the root categories with their descendants, the order of the siblings is kept
+func buildCategoryTree(categories []model.Category) []Category {
+	children := map[int][]model.Category{}
+	for _, c := range categories {
+		children[c.ParentID.Int] = append(children[c.ParentID.Int], c)
+	}
+
+	var build func(parentID int) []Category
+	build = func(parentID int) []Category {
+		result := make([]Category, 0, len(children[parentID]))
+		for _, c := range children[parentID] {
+			node := toCategory(c)
+			node.Children = build(c.ID)
+			result = append(result, node)
+		}
+		return result
+	}
+	return build(0)
+}
+
+// GetCategories returns the category tree of the organization
+func (serv impl) GetCategories(ctx context.Context) ([]Category, error) {
+	categories, err := serv.repo.Category().GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories), nil
+}
+
+// GetCategory returns the category with id
+func (serv impl) GetCategory(ctx context.Context, id int) (Category, error) {
+	category, err := serv.repo.Category().GetCategory(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Category{}, ErrCategoryNotFound
+	} else if err != nil {
+		return Category{}, err
+	}
+	return toCategory(category), nil
+}
+
+// checkCategory checks that the slug is not used by another category and returns the parent of the category
+func (serv impl) checkCategory(ctx context.Context, id int, input CategoryInput) (model.Category, error) {
+	existed, err := serv.repo.Category().ExistsCategoryBySlug(ctx, input.Slug, id)
+	if err != nil {
+		return model.Category{}, err
+	}
+	if existed {
+		return model.Category{}, ErrCategorySlugExisted
+	}
+
+	if input.ParentID == 0 {
+		return model.Category{}, nil
+	}
+	parent, err := serv.repo.Category().GetCategory(ctx, input.ParentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Category{}, ErrParentCategoryNotExist
+	}
+	return parent, err
+}
+
+// CreateCategory creates a new category under the parent of the input
+func (serv impl) CreateCategory(ctx context.Context, input CategoryInput) (Category, error) {
+	if _, err := serv.checkCategory(ctx, 0, input); err != nil {
+		return Category{}, err
+	}
+
+	var created model.Category
+	if err := serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		var err error
+		created, err = serv.repo.Category().CreateCategory(ctx, tx, model.Category{
+			ParentID:  toParentID(input.ParentID),
+			Name:      input.Name,
+			Slug:      input.Slug,
+			SortOrder: input.SortOrder,
+		})
+		return err
+	}); err != nil {
+		return Category{}, err
+	}
+	return toCategory(created), nil
+}
+
+// UpdateCategory updates the category, the category and its descendants are moved if the parent is changed.
+// A category cannot be moved under itself or one of its descendants.
+func (serv impl) UpdateCategory(ctx context.Context, id int, input CategoryInput) error {
+	// 1. Get the current category
+	current, err := serv.repo.Category().GetCategory(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCategoryNotFound
+	} else if err != nil {
+		return err
+	}
+
+	// 2. Check the slug and the new parent
+	parent, err := serv.checkCategory(ctx, id, input)
+	if err != nil {
+		return err
+	}
+	if input.ParentID != 0 && strings.HasPrefix(parent.Path, current.Path) {
+		return ErrInvalidParentCategory
+	}
+
+	// 3. Update the category and the paths of its subtree
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		affected, err := serv.repo.Category().UpdateCategory(ctx, tx, model.Category{
+			ID:        id,
+			ParentID:  toParentID(input.ParentID),
+			Name:      input.Name,
+			Slug:      input.Slug,
+			SortOrder: input.SortOrder,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrCategoryNotFound
+		}
+		return nil
+	})
+}
+
+// DeleteCategory deletes a category without child categories, its products are kept
+func (serv impl) DeleteCategory(ctx context.Context, id int) error {
+	hasChildren, err := serv.repo.Category().ExistsChildCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return ErrCategoryHasChildren
+	}
+
+	affected, err := serv.repo.Category().DeleteCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+// GetProductCategories returns the categories of the product
+func (serv impl) GetProductCategories(ctx context.Context, productID int) ([]Category, error) {
+	existed, err := serv.repo.Product().ExistsProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !existed {
+		return nil, ErrProductNotFound
+	}
+
+	categories, err := serv.repo.Category().GetProductCategories(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Category, 0, len(categories))
+	for _, c := range categories {
+		result = append(result, toCategory(c))
+	}
+	return result, nil
+}
+
+// SetProductCategories replaces the categories of the product, only the users who can update the product can change them
+func (serv impl) SetProductCategories(ctx context.Context, productID int, categoryIDs []int) error {
+	// 1. Get the product to check its owner
+	product, err := serv.repo.Product().GetProduct(ctx, productID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrProductNotFound
+	} else if err != nil {
+		return err
+	}
+	if !canManageProduct(ctx, product.UserID) {
+		return ErrPermissionDenied
+	}
+
+	// 2. All categories must exist in the organization
+	ids := uniqueIDs(categoryIDs)
+	if len(ids) > 0 {
+		categories, err := serv.repo.Category().GetCategoriesByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if len(categories) != len(ids) {
+			return ErrCategoryNotExist
+		}
+	}
+
+	// 3. Replace the categories
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		return serv.repo.Category().SetProductCategories(ctx, tx, productID, ids)
+	})
+}
+
+// toParentID returns the parent column of the category, root categories have no parent
+func toParentID(parentID int) null.Int {
+	if parentID == 0 {
+		return null.Int{}
+	}
+	return null.IntFrom(parentID)
+}
+
+// uniqueIDs returns the ids without duplicates, the order is kept
+func uniqueIDs(ids []int) []int {
+	seen := map[int]bool{}
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
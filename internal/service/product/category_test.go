@@ -0,0 +1,281 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/category"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+// runTx makes the repository mock run the function of Tx
+func runTx(t *testing.T, repoMock *repository.Mock, ctx context.Context) {
+	repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+		require.NoError(t, args.Get(1).(func(*sql.Tx) error)(nil))
+	})
+}
+
+func TestProductService_GetCategories(t *testing.T) {
+	// GIVEN
+	ctx := context.Background()
+	categoryRepoMock := new(category.Mock)
+	categoryRepoMock.On("GetCategories", ctx).Return([]model.Category{
+		{ID: 1, Name: "Electronics", Slug: "electronics", Path: "/1/"},
+		{ID: 3, ParentID: null.IntFrom(1), Name: "Laptops", Slug: "laptops", Path: "/1/3/"},
+		{ID: 2, ParentID: null.IntFrom(1), Name: "Phones", Slug: "phones", SortOrder: 1, Path: "/1/2/"},
+		{ID: 4, ParentID: null.IntFrom(2), Name: "Cases", Slug: "cases", Path: "/1/2/4/"},
+		{ID: 5, Name: "Books", Slug: "books", SortOrder: 1, Path: "/5/"},
+	}, nil)
+	repoMock := new(repository.Mock)
+	repoMock.On("Category").Return(categoryRepoMock)
+
+	productService := New(repoMock)
+
+	// WHEN
+	result, err := productService.GetCategories(ctx)
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, []Category{
+		{ID: 1, Name: "Electronics", Slug: "electronics", Path: "/1/", Children: []Category{
+			{ID: 3, ParentID: null.IntFrom(1), Name: "Laptops", Slug: "laptops", Path: "/1/3/", Children: []Category{}},
+			{ID: 2, ParentID: null.IntFrom(1), Name: "Phones", Slug: "phones", SortOrder: 1, Path: "/1/2/", Children: []Category{
+				{ID: 4, ParentID: null.IntFrom(2), Name: "Cases", Slug: "cases", Path: "/1/2/4/", Children: []Category{}},
+			}},
+		}},
+		{ID: 5, Name: "Books", Slug: "books", SortOrder: 1, Path: "/5/", Children: []Category{}},
+	}, result)
+}
+
+func TestProductService_CreateCategory(t *testing.T) {
+	tcs := map[string]struct {
+		input          CategoryInput
+		mockSlugExists bool
+		mockParentErr  error
+		expCategory    model.Category
+		expErr         error
+	}{
+		"success_root": {
+			input:       CategoryInput{Name: "Books", Slug: "books"},
+			expCategory: model.Category{Name: "Books", Slug: "books"},
+		},
+		"success_child": {
+			input:       CategoryInput{ParentID: 1, Name: "Phones", Slug: "phones", SortOrder: 2},
+			expCategory: model.Category{ParentID: null.IntFrom(1), Name: "Phones", Slug: "phones", SortOrder: 2},
+		},
+		"slug_existed": {
+			input:          CategoryInput{Name: "Books", Slug: "books"},
+			mockSlugExists: true,
+			expErr:         ErrCategorySlugExisted,
+		},
+		"parent_not_exist": {
+			input:         CategoryInput{ParentID: 9, Name: "Phones", Slug: "phones"},
+			mockParentErr: sql.ErrNoRows,
+			expErr:        ErrParentCategoryNotExist,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			categoryRepoMock := new(category.Mock)
+			categoryRepoMock.On("ExistsCategoryBySlug", ctx, tc.input.Slug, 0).Return(tc.mockSlugExists, nil)
+			categoryRepoMock.On("GetCategory", ctx, tc.input.ParentID).Return(model.Category{ID: tc.input.ParentID, Path: "/1/"}, tc.mockParentErr)
+			categoryRepoMock.On("CreateCategory", ctx, (*sql.Tx)(nil), tc.expCategory).Return(model.Category{ID: 2, Name: tc.input.Name, Path: "/1/2/"}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Category").Return(categoryRepoMock)
+			runTx(t, repoMock, ctx)
+
+			productService := New(repoMock)
+
+			// WHEN
+			result, err := productService.CreateCategory(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				categoryRepoMock.AssertNotCalled(t, "CreateCategory", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, Category{ID: 2, Name: tc.input.Name, Path: "/1/2/"}, result)
+			categoryRepoMock.AssertCalled(t, "CreateCategory", ctx, (*sql.Tx)(nil), tc.expCategory)
+		})
+	}
+}
+
+func TestProductService_UpdateCategory(t *testing.T) {
+	tcs := map[string]struct {
+		input      CategoryInput
+		mockParent model.Category
+		expErr     error
+	}{
+		"success_move": {
+			input:      CategoryInput{ParentID: 5, Name: "Phones", Slug: "phones"},
+			mockParent: model.Category{ID: 5, Path: "/5/"},
+		},
+		"success_root": {
+			input: CategoryInput{Name: "Phones", Slug: "phones"},
+		},
+		"parent_is_itself": {
+			input:      CategoryInput{ParentID: 2, Name: "Phones", Slug: "phones"},
+			mockParent: model.Category{ID: 2, Path: "/1/2/"},
+			expErr:     ErrInvalidParentCategory,
+		},
+		"parent_is_descendant": {
+			input:      CategoryInput{ParentID: 4, Name: "Phones", Slug: "phones"},
+			mockParent: model.Category{ID: 4, Path: "/1/2/4/"},
+			expErr:     ErrInvalidParentCategory,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			categoryRepoMock := new(category.Mock)
+			categoryRepoMock.On("GetCategory", ctx, 2).Return(model.Category{ID: 2, ParentID: null.IntFrom(1), Path: "/1/2/"}, nil)
+			categoryRepoMock.On("GetCategory", ctx, tc.input.ParentID).Return(tc.mockParent, nil)
+			categoryRepoMock.On("ExistsCategoryBySlug", ctx, "phones", 2).Return(false, nil)
+			categoryRepoMock.On("UpdateCategory", ctx, (*sql.Tx)(nil), model.Category{
+				ID: 2, ParentID: toParentID(tc.input.ParentID), Name: "Phones", Slug: "phones",
+			}).Return(int64(1), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Category").Return(categoryRepoMock)
+			runTx(t, repoMock, ctx)
+
+			productService := New(repoMock)
+
+			// WHEN
+			err := productService.UpdateCategory(ctx, 2, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				categoryRepoMock.AssertNotCalled(t, "UpdateCategory", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			categoryRepoMock.AssertNumberOfCalls(t, "UpdateCategory", 1)
+		})
+	}
+}
+
+func TestProductService_DeleteCategory(t *testing.T) {
+	tcs := map[string]struct {
+		mockHasChildren bool
+		mockAffected    int64
+		expErr          error
+	}{
+		"success": {
+			mockAffected: 1,
+		},
+		"has_children": {
+			mockHasChildren: true,
+			expErr:          ErrCategoryHasChildren,
+		},
+		"not_found": {
+			expErr: ErrCategoryNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			categoryRepoMock := new(category.Mock)
+			categoryRepoMock.On("ExistsChildCategory", ctx, 2).Return(tc.mockHasChildren, nil)
+			categoryRepoMock.On("DeleteCategory", ctx, 2).Return(tc.mockAffected, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Category").Return(categoryRepoMock)
+
+			productService := New(repoMock)
+
+			// WHEN
+			err := productService.DeleteCategory(ctx, 2)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProductService_SetProductCategories(t *testing.T) {
+	tcs := map[string]struct {
+		ctx            context.Context
+		categoryIDs    []int
+		mockProductErr error
+		mockCategories []model.Category
+		expIDs         []int
+		expErr         error
+	}{
+		"success": {
+			ctx:            auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			categoryIDs:    []int{2, 3, 2},
+			mockCategories: []model.Category{{ID: 2}, {ID: 3}},
+			expIDs:         []int{2, 3},
+		},
+		"success_clear": {
+			ctx:    auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			expIDs: []int{},
+		},
+		"product_not_found": {
+			ctx:            auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			mockProductErr: sql.ErrNoRows,
+			expErr:         ErrProductNotFound,
+		},
+		"permission_denied": {
+			ctx:         auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			categoryIDs: []int{2},
+			expErr:      ErrPermissionDenied,
+		},
+		"category_not_exist": {
+			ctx:            auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions}),
+			categoryIDs:    []int{2, 9},
+			mockCategories: []model.Category{{ID: 2}},
+			expErr:         ErrCategoryNotExist,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetProduct", tc.ctx, 10).Return(model.Product{ID: 10, UserID: 1}, tc.mockProductErr)
+			categoryRepoMock := new(category.Mock)
+			categoryRepoMock.On("GetCategoriesByIDs", tc.ctx, mock.Anything).Return(tc.mockCategories, nil)
+			categoryRepoMock.On("SetProductCategories", tc.ctx, (*sql.Tx)(nil), 10, tc.expIDs).Return(nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+			repoMock.On("Category").Return(categoryRepoMock)
+			runTx(t, repoMock, tc.ctx)
+
+			productService := New(repoMock)
+
+			// WHEN
+			err := productService.SetProductCategories(tc.ctx, 10, tc.categoryIDs)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				categoryRepoMock.AssertNotCalled(t, "SetProductCategories", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			categoryRepoMock.AssertCalled(t, "SetProductCategories", tc.ctx, (*sql.Tx)(nil), 10, tc.expIDs)
+		})
+	}
+}
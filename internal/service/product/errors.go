@@ -9,4 +9,26 @@ var (
 	ErrProductNotFound     = errors.New("product is not found")
 	ErrFileCannotBeCreated = errors.New("file cannot be created")
 	ErrFileCannotBeRead    = errors.New("file cannot be read")
+	ErrPermissionDenied    = errors.New("permission denied")
+
+	ErrCategoryNotFound       = errors.New("category is not found")
+	ErrCategoryNotExist       = errors.New("category does not exist")
+	ErrParentCategoryNotExist = errors.New("parent category does not exist")
+	ErrInvalidParentCategory  = errors.New("parent category cannot be the category or one of its descendants")
+	ErrCategorySlugExisted    = errors.New("category slug is already exists")
+	ErrCategoryHasChildren    = errors.New("category has child categories")
+
+	ErrProductHasVariants    = errors.New("options cannot be changed while the product has variants")
+	ErrDuplicateOption       = errors.New("option names must be unique")
+	ErrVariantNotFound       = errors.New("variant is not found")
+	ErrInvalidVariantOptions = errors.New("variant must have a value for each option of the product")
+	ErrVariantSKUExisted     = errors.New("variant sku is already exists")
+	ErrVariantExisted        = errors.New("variant with the same option values is already exists")
+
+	ErrImageNotFound        = errors.New("image is not found")
+	ErrUnsupportedImageType = errors.New("image must be a jpeg, png or gif image")
+	ErrInvalidImage         = errors.New("image cannot be decoded")
+	ErrImageTooLarge        = errors.New("image is too large")
+	ErrInvalidImageSize     = errors.New("image size is invalid")
+	ErrInvalidImageOrder    = errors.New("image order must contain every image of the product once")
 )
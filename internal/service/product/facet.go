@@ -0,0 +1,75 @@
+package product
+
+import (
+	"context"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// MaxPriceBuckets is the maximum number of the price buckets of the facets
+const MaxPriceBuckets = 20
+
+// DefaultPriceBuckets are the lower bounds of the price buckets when GetProductsInput has none
+var DefaultPriceBuckets = []float64{0, 100000, 500000, 1000000, 5000000, 10000000}
+
+// PriceBucket is the number of products with a price from From to To, the last bucket has no upper bound
+type PriceBucket struct {
+	From         float64
+	To           null.Float64
+	ProductCount int64
+}
+
+type SellerCount struct {
+	UserID       int
+	Name         string
+	ProductCount int64
+}
+
+// ProductFacets are the numbers of the products filtered like GetProducts by price bucket, seller, status and category
+type ProductFacets struct {
+	PriceBuckets []PriceBucket
+	Sellers      []SellerCount
+	Active       int64
+	Inactive     int64
+	Categories   []CategoryCount
+}
+
+// GetProductFacets returns the facets of the products filtered like GetProducts, the price buckets start at input.PriceBuckets
+// or DefaultPriceBuckets. A product of a category is also counted in the ancestors of the category.
+func (serv impl) GetProductFacets(ctx context.Context, input GetProductsInput) (ProductFacets, error) {
+	bounds := input.PriceBuckets
+	if len(bounds) == 0 {
+		bounds = DefaultPriceBuckets
+	}
+
+	facets, err := serv.repo.Product().GetFacets(ctx, toFilter(input), bounds)
+	if err != nil {
+		return ProductFacets{}, err
+	}
+
+	result := ProductFacets{
+		PriceBuckets: make([]PriceBucket, len(bounds)),
+		Sellers:      make([]SellerCount, len(facets.Users)),
+		Active:       facets.Active,
+		Inactive:     facets.Inactive,
+		Categories:   make([]CategoryCount, len(facets.Categories)),
+	}
+	for i, from := range bounds {
+		result.PriceBuckets[i] = PriceBucket{From: from, ProductCount: facets.PriceCounts[i]}
+		if i+1 < len(bounds) {
+			result.PriceBuckets[i].To = null.Float64From(bounds[i+1])
+		}
+	}
+	for i, u := range facets.Users {
+		result.Sellers[i] = SellerCount{UserID: u.UserID, Name: u.Name, ProductCount: u.ProductCount}
+	}
+	for i, c := range facets.Categories {
+		result.Categories[i] = CategoryCount{
+			CategoryID:   c.CategoryID,
+			Name:         c.Name,
+			Slug:         c.Slug,
+			ProductCount: c.ProductCount,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
+)
+
+func TestProductService_GetProductFacets(t *testing.T) {
+	tcs := map[string]struct {
+		input      GetProductsInput
+		expFilter  product.Filter
+		expBounds  []float64
+		mockFacets product.Facets
+		mockErr    error
+		expResult  ProductFacets
+		expErr     error
+	}{
+		"success": {
+			input:     GetProductsInput{Title: "phone", CategoryID: 1, PriceBuckets: []float64{0, 10, 50}},
+			expFilter: product.Filter{Title: "phone", CategoryID: 1},
+			expBounds: []float64{0, 10, 50},
+			mockFacets: product.Facets{
+				PriceCounts: []int64{1, 0, 2},
+				Users: []product.UserCount{
+					{UserID: 2, Name: "admin 2", ProductCount: 2},
+					{UserID: 1, Name: "admin", ProductCount: 1},
+				},
+				Active:   2,
+				Inactive: 1,
+				Categories: []product.CategoryCount{
+					{CategoryID: 1, Name: "Electronics", Slug: "electronics", ProductCount: 3},
+					{CategoryID: 2, Name: "Phones", Slug: "phones", ProductCount: 2},
+				},
+			},
+			expResult: ProductFacets{
+				PriceBuckets: []PriceBucket{
+					{From: 0, To: null.Float64From(10), ProductCount: 1},
+					{From: 10, To: null.Float64From(50), ProductCount: 0},
+					{From: 50, ProductCount: 2},
+				},
+				Sellers: []SellerCount{
+					{UserID: 2, Name: "admin 2", ProductCount: 2},
+					{UserID: 1, Name: "admin", ProductCount: 1},
+				},
+				Active:   2,
+				Inactive: 1,
+				Categories: []CategoryCount{
+					{CategoryID: 1, Name: "Electronics", Slug: "electronics", ProductCount: 3},
+					{CategoryID: 2, Name: "Phones", Slug: "phones", ProductCount: 2},
+				},
+			},
+		},
+		"default_price_buckets": {
+			input:      GetProductsInput{Query: "book"},
+			expFilter:  product.Filter{Query: "book"},
+			expBounds:  DefaultPriceBuckets,
+			mockFacets: product.Facets{PriceCounts: make([]int64, len(DefaultPriceBuckets)), Users: []product.UserCount{}, Categories: []product.CategoryCount{}},
+			expResult: ProductFacets{
+				PriceBuckets: []PriceBucket{
+					{From: 0, To: null.Float64From(100000)},
+					{From: 100000, To: null.Float64From(500000)},
+					{From: 500000, To: null.Float64From(1000000)},
+					{From: 1000000, To: null.Float64From(5000000)},
+					{From: 5000000, To: null.Float64From(10000000)},
+					{From: 10000000},
+				},
+				Sellers:    []SellerCount{},
+				Categories: []CategoryCount{},
+			},
+		},
+		"error": {
+			input:     GetProductsInput{},
+			expFilter: product.Filter{},
+			expBounds: DefaultPriceBuckets,
+			mockErr:   errors.New("test"),
+			expErr:    errors.New("test"),
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetFacets", ctx, tc.expFilter, tc.expBounds).Return(tc.mockFacets, tc.mockErr)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+
+			productService := New(repoMock)
+
+			// WHEN
+			result, err := productService.GetProductFacets(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expResult, result)
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/storage"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/thumbnail"
+)
+
+const (
+	// MaxImageSize is the maximum size of an uploaded image in bytes
+	MaxImageSize = 5 << 20
+	// MaxImagePixels is the maximum width * height of an uploaded image, larger images are rejected before they are decoded
+	MaxImagePixels = 25_000_000
+	// OriginalImageSize is the size name of the uploaded image
+	OriginalImageSize = "original"
+)
+
+// ThumbnailSize is a size of the generated thumbnails, the image is scaled down to fit in a square of Side pixels
+type ThumbnailSize struct {
+	Name string
+	Side int
+}
+
+// ThumbnailSizes are the thumbnails generated for each uploaded image
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "small", Side: 160},
+	{Name: "medium", Side: 480},
+	{Name: "large", Side: 1024},
+}
+
+// imageExtensions are the extensions of the stored files by content type
+var imageExtensions = map[string]string{
+	thumbnail.JPEG: ".jpg",
+	thumbnail.PNG:  ".png",
+	thumbnail.GIF:  ".gif",
+}
+
+// Image is an image of a product
+type Image struct {
+	ID          int
+	ProductID   int
+	ContentType string
+	Size        int // bytes of the original image
+	Width       int
+	Height      int
+	Position    int
+	IsPrimary   bool
+	Sizes       []string // the original and the thumbnail sizes which can be downloaded
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ImageInput struct {
+	Data      []byte
+	IsPrimary bool
+}
+
+// imageSizes returns the size names of every image
+func imageSizes() []string {
+	sizes := []string{OriginalImageSize}
+	for _, s := range ThumbnailSizes {
+		sizes = append(sizes, s.Name)
+	}
+	return sizes
+}
+
+func toImage(image model.ProductImage) Image {
+	return Image{
+		ID:          image.ID,
+		ProductID:   image.ProductID,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		Width:       image.Width,
+		Height:      image.Height,
+		Position:    image.Position,
+		IsPrimary:   image.IsPrimary,
+		Sizes:       imageSizes(),
+		CreatedAt:   image.CreatedAt,
+		UpdatedAt:   image.UpdatedAt,
+	}
+}
+
+// imageObjectType returns the content type of the file of the size of the image, the original keeps the type of the upload
+func imageObjectType(image model.ProductImage, size string) string {
+	if size == OriginalImageSize {
+		return image.ContentType
+	}
+	return thumbnail.Type(image.ContentType)
+}
+
+// imageObjectKey returns the key of the file of the size of the image
+func imageObjectKey(image model.ProductImage, size string) string {
+	return image.StorageKey + "/" + size + imageExtensions[imageObjectType(image, size)]
+}
+
+// deleteImageObjects deletes the files of the image, errors are only logged because the rows are already deleted
+func deleteImageObjects(ctx context.Context, store storage.Storage, image model.ProductImage) {
+	for _, size := range imageSizes() {
+		if err := store.Delete(ctx, imageObjectKey(image, size)); err != nil {
+			log.Printf("Error when delete file of image %d: %v\n", image.ID, err)
+		}
+	}
+}
+
+// GetProductImages returns the images of the product ordered by their position
+func (serv impl) GetProductImages(ctx context.Context, productID int) ([]Image, error) {
+	existed, err := serv.repo.Product().ExistsProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !existed {
+		return nil, ErrProductNotFound
+	}
+
+	images, err := serv.repo.Image().GetImages(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Image, len(images))
+	for i, image := range images {
+		result[i] = toImage(image)
+	}
+	return result, nil
+}
+
+// GetProductImageFile returns the file of the size of the image, its body must be closed by the caller
+func (serv impl) GetProductImageFile(ctx context.Context, productID, id int, size string) (storage.Object, error) {
+	// 1. Check the size name
+	valid := false
+	for _, s := range imageSizes() {
+		valid = valid || s == size
+	}
+	if !valid {
+		return storage.Object{}, ErrInvalidImageSize
+	}
+
+	// 2. Get the image
+	image, err := serv.repo.Image().GetImage(ctx, productID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Object{}, ErrImageNotFound
+	} else if err != nil {
+		return storage.Object{}, err
+	}
+
+	// 3. Get its file
+	store, err := storage.FromEnv()
+	if err != nil {
+		return storage.Object{}, err
+	}
+	object, err := store.Get(ctx, imageObjectKey(image, size))
+	if errors.Is(err, storage.ErrNotFound) {
+		return storage.Object{}, ErrImageNotFound
+	}
+	return object, err
+}
+
+// UploadProductImage stores the image with its thumbnails and adds it after the other images of the product.
+// The first image of a product is always its primary image.
+func (serv impl) UploadProductImage(ctx context.Context, productID int, input ImageInput) (Image, error) {
+	// 1. Check the product and the permission
+	if _, err := serv.getManagedProduct(ctx, productID); err != nil {
+		return Image{}, err
+	}
+
+	// 2. Sniff the type and decode the image
+	if len(input.Data) > MaxImageSize {
+		return Image{}, ErrImageTooLarge
+	}
+	contentType, err := thumbnail.DetectType(input.Data)
+	if err != nil {
+		return Image{}, ErrUnsupportedImageType
+	}
+	decoded, err := thumbnail.Decode(input.Data, MaxImagePixels)
+	if errors.Is(err, thumbnail.ErrTooManyPixels) {
+		return Image{}, ErrImageTooLarge
+	} else if err != nil {
+		return Image{}, ErrInvalidImage
+	}
+
+	// 3. Generate the thumbnails
+	id, err := uuid.NewV4()
+	if err != nil {
+		return Image{}, err
+	}
+	image := model.ProductImage{
+		ProductID:   productID,
+		StorageKey:  fmt.Sprintf("products/%d/images/%s", productID, id.String()),
+		ContentType: contentType,
+		Size:        len(input.Data),
+		Width:       decoded.Bounds().Dx(),
+		Height:      decoded.Bounds().Dy(),
+	}
+	files := map[string][]byte{OriginalImageSize: input.Data}
+	for _, s := range ThumbnailSizes {
+		data, err := thumbnail.Encode(thumbnail.Fit(decoded, s.Side), imageObjectType(image, s.Name))
+		if err != nil {
+			return Image{}, err
+		}
+		files[s.Name] = data
+	}
+
+	// 4. Store the files, the stored files are deleted if one fails
+	store, err := storage.FromEnv()
+	if err != nil {
+		return Image{}, err
+	}
+	for _, size := range imageSizes() {
+		if err := store.Put(ctx, imageObjectKey(image, size), files[size], imageObjectType(image, size)); err != nil {
+			deleteImageObjects(ctx, store, image)
+			return Image{}, err
+		}
+	}
+
+	// 5. Create the image after the last image of the product
+	images, err := serv.repo.Image().GetImages(ctx, productID)
+	if err != nil {
+		deleteImageObjects(ctx, store, image)
+		return Image{}, err
+	}
+	if len(images) > 0 {
+		image.Position = images[len(images)-1].Position + 1
+	}
+	isPrimary := input.IsPrimary || len(images) == 0
+	var created model.ProductImage
+	if err := serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		var err error
+		created, err = serv.repo.Image().CreateImage(ctx, tx, image)
+		if err != nil {
+			return err
+		}
+		if isPrimary {
+			if _, err := serv.repo.Image().SetPrimaryImage(ctx, tx, productID, created.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		deleteImageObjects(ctx, store, image)
+		return Image{}, err
+	}
+	created.IsPrimary = isPrimary
+	return toImage(created), nil
+}
+
+// SetProductImagePositions orders the images of the product, ids must contain every image of the product once
+func (serv impl) SetProductImagePositions(ctx context.Context, productID int, ids []int) error {
+	// 1. Check the product and the permission
+	if _, err := serv.getManagedProduct(ctx, productID); err != nil {
+		return err
+	}
+
+	// 2. The ids must be exactly the images of the product
+	images, err := serv.repo.Image().GetImages(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if len(ids) != len(images) {
+		return ErrInvalidImageOrder
+	}
+	remaining := map[int]bool{}
+	for _, image := range images {
+		remaining[image.ID] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return ErrInvalidImageOrder
+		}
+		delete(remaining, id)
+	}
+
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		return serv.repo.Image().SetPositions(ctx, tx, productID, ids)
+	})
+}
+
+// SetPrimaryProductImage makes the image the primary image of the product
+func (serv impl) SetPrimaryProductImage(ctx context.Context, productID, id int) error {
+	if _, err := serv.getManagedProduct(ctx, productID); err != nil {
+		return err
+	}
+
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		affected, err := serv.repo.Image().SetPrimaryImage(ctx, tx, productID, id)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrImageNotFound
+		}
+		return nil
+	})
+}
+
+// DeleteProductImage deletes the image and its files, the next image becomes primary if the primary image is deleted
+func (serv impl) DeleteProductImage(ctx context.Context, productID, id int) error {
+	// 1. Check the product and the permission
+	if _, err := serv.getManagedProduct(ctx, productID); err != nil {
+		return err
+	}
+
+	// 2. Find the image and the image which replaces it as primary
+	images, err := serv.repo.Image().GetImages(ctx, productID)
+	if err != nil {
+		return err
+	}
+	var deleted *model.ProductImage
+	nextPrimary := 0
+	for i := range images {
+		if images[i].ID == id {
+			deleted = &images[i]
+		} else if nextPrimary == 0 {
+			nextPrimary = images[i].ID
+		}
+	}
+	if deleted == nil {
+		return ErrImageNotFound
+	}
+
+	// 3. Delete the image
+	if err := serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		affected, err := serv.repo.Image().DeleteImage(ctx, tx, productID, id)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrImageNotFound
+		}
+		if deleted.IsPrimary && nextPrimary != 0 {
+			if _, err := serv.repo.Image().SetPrimaryImage(ctx, tx, productID, nextPrimary); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 4. Delete its files
+	store, err := storage.FromEnv()
+	if err != nil {
+		log.Printf("Error when delete files of image %d: %v\n", id, err)
+		return nil
+	}
+	deleteImageObjects(ctx, store, *deleted)
+	return nil
+}
@@ -0,0 +1,453 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	goimage "image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/image"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/storage"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/storage/storagetest"
+)
+
+// testImage returns an encoded image of the size, format is "png" or "jpeg"
+func testImage(t *testing.T, format string, width, height int) []byte {
+	img := goimage.NewRGBA(goimage.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	} else {
+		require.NoError(t, png.Encode(&buf, img))
+	}
+	return buf.Bytes()
+}
+
+// hugePNG returns a png of 1x1 pixel whose header claims the size, it cannot be decoded but its config can
+func hugePNG(t *testing.T, width, height int) []byte {
+	data := testImage(t, "png", 1, 1)
+	// signature (8) + length (4) + "IHDR" (4) + width (4) + height (4) + ... + crc (4)
+	binary.BigEndian.PutUint32(data[16:20], uint32(width))
+	binary.BigEndian.PutUint32(data[20:24], uint32(height))
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+// useLocalStorage stores the image files in a temporary directory
+func useLocalStorage(t *testing.T) storage.Storage {
+	dir := t.TempDir()
+	t.Setenv("STORAGE_DRIVER", storage.DriverLocal)
+	t.Setenv("STORAGE_LOCAL_DIR", dir)
+	return storage.NewLocal(dir)
+}
+
+// useS3Storage stores the image files in a local S3-compatible service
+func useS3Storage(t *testing.T) *storagetest.S3 {
+	s3 := storagetest.NewS3("images", "minio", "minio-secret")
+	t.Cleanup(s3.Close)
+	config := s3.Config()
+	t.Setenv("STORAGE_DRIVER", storage.DriverS3)
+	t.Setenv("S3_ENDPOINT", config.Endpoint)
+	t.Setenv("S3_BUCKET", config.Bucket)
+	t.Setenv("S3_ACCESS_KEY_ID", config.AccessKeyID)
+	t.Setenv("S3_SECRET_ACCESS_KEY", config.SecretAccessKey)
+	return s3
+}
+
+func TestProductService_UploadProductImage(t *testing.T) {
+	ownerCtx := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions})
+	createdAt := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		ctx            context.Context
+		input          ImageInput
+		mockImages     []model.ProductImage
+		mockCreateErr  error
+		expContentType string
+		expPosition    int
+		expPrimary     bool
+		expErr         error
+	}{
+		"first_image_is_primary": {
+			ctx:            ownerCtx,
+			input:          ImageInput{Data: testImage(t, "png", 1200, 600)},
+			mockImages:     []model.ProductImage{},
+			expContentType: "image/png",
+			expPrimary:     true,
+		},
+		"after_other_images": {
+			ctx:            ownerCtx,
+			input:          ImageInput{Data: testImage(t, "jpeg", 1200, 600)},
+			mockImages:     []model.ProductImage{{ID: 1, Position: 0, IsPrimary: true}, {ID: 2, Position: 2}},
+			expContentType: "image/jpeg",
+			expPosition:    3,
+		},
+		"set_primary": {
+			ctx:            ownerCtx,
+			input:          ImageInput{Data: testImage(t, "png", 1200, 600), IsPrimary: true},
+			mockImages:     []model.ProductImage{{ID: 1, Position: 0, IsPrimary: true}},
+			expContentType: "image/png",
+			expPosition:    1,
+			expPrimary:     true,
+		},
+		"permission_denied": {
+			ctx:    auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			input:  ImageInput{Data: testImage(t, "png", 10, 10)},
+			expErr: ErrPermissionDenied,
+		},
+		"unsupported_image_type": {
+			ctx:    ownerCtx,
+			input:  ImageInput{Data: []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>")},
+			expErr: ErrUnsupportedImageType,
+		},
+		"invalid_image": {
+			ctx:    ownerCtx,
+			input:  ImageInput{Data: testImage(t, "png", 10, 10)[:40]},
+			expErr: ErrInvalidImage,
+		},
+		"too_many_pixels": {
+			ctx:    ownerCtx,
+			input:  ImageInput{Data: hugePNG(t, 10000, 10000)},
+			expErr: ErrImageTooLarge,
+		},
+		"too_large": {
+			ctx:    ownerCtx,
+			input:  ImageInput{Data: make([]byte, MaxImageSize+1)},
+			expErr: ErrImageTooLarge,
+		},
+		"create_image_failed": {
+			ctx:           ownerCtx,
+			input:         ImageInput{Data: testImage(t, "png", 10, 10)},
+			mockImages:    []model.ProductImage{},
+			mockCreateErr: errors.New("insert failed"),
+			expErr:        errors.New("insert failed"),
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			s3 := useS3Storage(t)
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetProduct", tc.ctx, 10).Return(model.Product{ID: 10, UserID: 1}, nil)
+			imageRepoMock := new(image.Mock)
+			imageRepoMock.On("GetImages", tc.ctx, 10).Return(tc.mockImages, nil)
+			imageRepoMock.On("CreateImage", tc.ctx, (*sql.Tx)(nil), mock.AnythingOfType("model.ProductImage")).Return(model.ProductImage{
+				ID: 7, ProductID: 10, ContentType: tc.expContentType, Size: len(tc.input.Data), Width: 1200, Height: 600,
+				Position: tc.expPosition, CreatedAt: createdAt, UpdatedAt: createdAt,
+			}, tc.mockCreateErr)
+			imageRepoMock.On("SetPrimaryImage", tc.ctx, (*sql.Tx)(nil), 10, 7).Return(int64(1), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+			repoMock.On("Image").Return(imageRepoMock)
+			repoMock.On("Tx", tc.ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(tc.mockCreateErr).Run(func(args mock.Arguments) {
+				args.Get(1).(func(*sql.Tx) error)(nil)
+			})
+
+			productService := New(repoMock)
+
+			// WHEN
+			result, err := productService.UploadProductImage(tc.ctx, 10, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				require.Empty(t, s3.Keys())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, Image{
+				ID: 7, ProductID: 10, ContentType: tc.expContentType, Size: len(tc.input.Data), Width: 1200, Height: 600,
+				Position: tc.expPosition, IsPrimary: tc.expPrimary, Sizes: []string{"original", "small", "medium", "large"},
+				CreatedAt: createdAt, UpdatedAt: createdAt,
+			}, result)
+
+			// The image is created with the sniffed type, its size and its position
+			created := imageRepoMock.Calls[1].Arguments.Get(2).(model.ProductImage)
+			require.Equal(t, tc.expContentType, created.ContentType)
+			require.Equal(t, 1200, created.Width)
+			require.Equal(t, 600, created.Height)
+			require.Equal(t, tc.expPosition, created.Position)
+			require.False(t, created.IsPrimary)
+			if tc.expPrimary {
+				imageRepoMock.AssertCalled(t, "SetPrimaryImage", tc.ctx, (*sql.Tx)(nil), 10, 7)
+			} else {
+				imageRepoMock.AssertNotCalled(t, "SetPrimaryImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			// The original and the thumbnails are stored under the key of the image
+			ext, thumbnailType := ".png", "image/png"
+			if tc.expContentType == "image/jpeg" {
+				ext, thumbnailType = ".jpg", "image/jpeg"
+			}
+			original, ok := s3.Object(created.StorageKey + "/original" + ext)
+			require.True(t, ok)
+			require.Equal(t, tc.input.Data, original.Data)
+			require.Equal(t, tc.expContentType, original.ContentType)
+			for size, expBounds := range map[string]goimage.Rectangle{
+				"small":  goimage.Rect(0, 0, 160, 80),
+				"medium": goimage.Rect(0, 0, 480, 240),
+				"large":  goimage.Rect(0, 0, 1024, 512),
+			} {
+				object, ok := s3.Object(created.StorageKey + "/" + size + ext)
+				require.True(t, ok, size)
+				require.Equal(t, thumbnailType, object.ContentType)
+				thumbnail, _, err := goimage.Decode(bytes.NewReader(object.Data))
+				require.NoError(t, err)
+				require.Equal(t, expBounds, thumbnail.Bounds(), size)
+			}
+			require.Len(t, s3.Keys(), 4)
+		})
+	}
+}
+
+func TestProductService_GetProductImageFile(t *testing.T) {
+	tcs := map[string]struct {
+		size         string
+		mockImageErr error
+		expErr       error
+	}{
+		"original": {
+			size: "original",
+		},
+		"thumbnail": {
+			size: "small",
+		},
+		"invalid_size": {
+			size:   "huge",
+			expErr: ErrInvalidImageSize,
+		},
+		"image_not_found": {
+			size:         "small",
+			mockImageErr: sql.ErrNoRows,
+			expErr:       ErrImageNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			store := useLocalStorage(t)
+			require.NoError(t, store.Put(ctx, "products/10/images/abc/original.gif", []byte("GIF89a original"), "image/gif"))
+			require.NoError(t, store.Put(ctx, "products/10/images/abc/small.png", []byte("small"), "image/png"))
+			imageRepoMock := new(image.Mock)
+			imageRepoMock.On("GetImage", ctx, 10, 7).Return(model.ProductImage{ID: 7, ProductID: 10, StorageKey: "products/10/images/abc", ContentType: "image/gif"}, tc.mockImageErr)
+			repoMock := new(repository.Mock)
+			repoMock.On("Image").Return(imageRepoMock)
+
+			productService := New(repoMock)
+
+			// WHEN
+			result, err := productService.GetProductImageFile(ctx, 10, 7, tc.size)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+			if tc.size == "original" {
+				require.Equal(t, "GIF89a original", string(data))
+				require.Equal(t, "image/gif", result.ContentType)
+			} else {
+				require.Equal(t, "small", string(data))
+				require.Equal(t, "image/png", result.ContentType)
+			}
+		})
+	}
+}
+
+func TestProductService_SetProductImagePositions(t *testing.T) {
+	tcs := map[string]struct {
+		ids    []int
+		expErr error
+	}{
+		"success": {
+			ids: []int{3, 1, 2},
+		},
+		"missing_image": {
+			ids:    []int{3, 1},
+			expErr: ErrInvalidImageOrder,
+		},
+		"duplicate_image": {
+			ids:    []int{3, 1, 1},
+			expErr: ErrInvalidImageOrder,
+		},
+		"other_image": {
+			ids:    []int{3, 1, 4},
+			expErr: ErrInvalidImageOrder,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions})
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetProduct", ctx, 10).Return(model.Product{ID: 10, UserID: 1}, nil)
+			imageRepoMock := new(image.Mock)
+			imageRepoMock.On("GetImages", ctx, 10).Return([]model.ProductImage{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+			imageRepoMock.On("SetPositions", ctx, (*sql.Tx)(nil), 10, tc.ids).Return(nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+			repoMock.On("Image").Return(imageRepoMock)
+			runTx(t, repoMock, ctx)
+
+			productService := New(repoMock)
+
+			// WHEN
+			err := productService.SetProductImagePositions(ctx, 10, tc.ids)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				imageRepoMock.AssertNotCalled(t, "SetPositions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			imageRepoMock.AssertCalled(t, "SetPositions", ctx, (*sql.Tx)(nil), 10, tc.ids)
+		})
+	}
+}
+
+func TestProductService_SetPrimaryProductImage(t *testing.T) {
+	tcs := map[string]struct {
+		mockAffected int64
+		expErr       error
+	}{
+		"success": {
+			mockAffected: 1,
+		},
+		"image_not_found": {
+			expErr: ErrImageNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions})
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetProduct", ctx, 10).Return(model.Product{ID: 10, UserID: 1}, nil)
+			imageRepoMock := new(image.Mock)
+			imageRepoMock.On("SetPrimaryImage", ctx, (*sql.Tx)(nil), 10, 7).Return(tc.mockAffected, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+			repoMock.On("Image").Return(imageRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(tc.expErr)
+
+			productService := New(repoMock)
+
+			// WHEN
+			err := productService.SetPrimaryProductImage(ctx, 10, 7)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProductService_DeleteProductImage(t *testing.T) {
+	tcs := map[string]struct {
+		id             int
+		expNextPrimary int
+		expErr         error
+	}{
+		"primary_image": {
+			id:             1,
+			expNextPrimary: 2,
+		},
+		"other_image": {
+			id: 2,
+		},
+		"image_not_found": {
+			id:     9,
+			expErr: ErrImageNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions})
+			s3 := useS3Storage(t)
+			store, err := storage.FromEnv()
+			require.NoError(t, err)
+			images := []model.ProductImage{
+				{ID: 1, ProductID: 10, StorageKey: "products/10/images/a", ContentType: "image/jpeg", IsPrimary: true},
+				{ID: 2, ProductID: 10, StorageKey: "products/10/images/b", ContentType: "image/gif", Position: 1},
+			}
+			for _, i := range images {
+				for _, size := range imageSizes() {
+					require.NoError(t, store.Put(ctx, imageObjectKey(i, size), []byte("data"), imageObjectType(i, size)))
+				}
+			}
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetProduct", ctx, 10).Return(model.Product{ID: 10, UserID: 1}, nil)
+			imageRepoMock := new(image.Mock)
+			imageRepoMock.On("GetImages", ctx, 10).Return(images, nil)
+			imageRepoMock.On("DeleteImage", ctx, (*sql.Tx)(nil), 10, tc.id).Return(int64(1), nil)
+			imageRepoMock.On("SetPrimaryImage", ctx, (*sql.Tx)(nil), 10, tc.expNextPrimary).Return(int64(1), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+			repoMock.On("Image").Return(imageRepoMock)
+			runTx(t, repoMock, ctx)
+
+			productService := New(repoMock)
+
+			// WHEN
+			err = productService.DeleteProductImage(ctx, 10, tc.id)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				require.Len(t, s3.Keys(), 8)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expNextPrimary != 0 {
+				imageRepoMock.AssertCalled(t, "SetPrimaryImage", ctx, (*sql.Tx)(nil), 10, tc.expNextPrimary)
+			} else {
+				imageRepoMock.AssertNotCalled(t, "SetPrimaryImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			// Only the files of the deleted image are deleted
+			remaining := images[0]
+			if tc.id == remaining.ID {
+				remaining = images[1]
+			}
+			keys := make([]string, 0)
+			for _, size := range imageSizes() {
+				keys = append(keys, imageObjectKey(remaining, size))
+			}
+			require.ElementsMatch(t, keys, s3.Keys())
+		})
+	}
+}
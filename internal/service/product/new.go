@@ -6,6 +6,7 @@ import (
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/storage"
 )
 
 type IProduct interface {
@@ -32,6 +33,63 @@ type IProduct interface {
 
 	// GetProducts returns list of products
 	GetProducts(ctx context.Context, input GetProductsInput) ([]ProductItem, int64, error)
+
+	// GetProductFacets returns the number of products of each price bucket, seller, status and category, filtered like GetProducts
+	GetProductFacets(ctx context.Context, input GetProductsInput) (ProductFacets, error)
+
+	// GetCategories returns the category tree
+	GetCategories(ctx context.Context) ([]Category, error)
+
+	// GetCategory returns the category with id
+	GetCategory(ctx context.Context, id int) (Category, error)
+
+	// CreateCategory creates a new category from category input
+	CreateCategory(ctx context.Context, input CategoryInput) (Category, error)
+
+	// UpdateCategory updates the category with id and category input, the category is moved if its parent is changed
+	UpdateCategory(ctx context.Context, id int, input CategoryInput) error
+
+	// DeleteCategory deletes the category with id
+	DeleteCategory(ctx context.Context, id int) error
+
+	// GetProductCategories returns the categories of the product
+	GetProductCategories(ctx context.Context, productID int) ([]Category, error)
+
+	// SetProductCategories replaces the categories of the product
+	SetProductCategories(ctx context.Context, productID int, categoryIDs []int) error
+
+	// GetProductVariants returns the options and the variants of the products
+	GetProductVariants(ctx context.Context, productIDs []int) (map[int]ProductVariants, error)
+
+	// SetProductOptions replaces the options of the product
+	SetProductOptions(ctx context.Context, productID int, names []string) error
+
+	// CreateVariant creates a new variant of the product from variant input
+	CreateVariant(ctx context.Context, productID int, input VariantInput) (Variant, error)
+
+	// UpdateVariant updates the variant of the product with variant input
+	UpdateVariant(ctx context.Context, productID, id int, input VariantInput) error
+
+	// DeleteVariant deletes the variant of the product
+	DeleteVariant(ctx context.Context, productID, id int) error
+
+	// GetProductImages returns the images of the product
+	GetProductImages(ctx context.Context, productID int) ([]Image, error)
+
+	// GetProductImageFile returns the file of the image in the size, the original or a thumbnail
+	GetProductImageFile(ctx context.Context, productID, id int, size string) (storage.Object, error)
+
+	// UploadProductImage stores a new image of the product with its thumbnails
+	UploadProductImage(ctx context.Context, productID int, input ImageInput) (Image, error)
+
+	// SetProductImagePositions orders the images of the product by ids
+	SetProductImagePositions(ctx context.Context, productID int, ids []int) error
+
+	// SetPrimaryProductImage makes the image the primary image of the product
+	SetPrimaryProductImage(ctx context.Context, productID, id int) error
+
+	// DeleteProductImage deletes the image of the product and its files
+	DeleteProductImage(ctx context.Context, productID, id int) error
 }
 
 type impl struct {
@@ -17,7 +17,9 @@ import (
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
 	productRepo "github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/storage"
 )
 
 type ProductInput struct {
@@ -40,9 +42,23 @@ func (serv impl) GetProduct(ctx context.Context, id int) (model.Product, error)
 
 }
 
+// canManageProduct returns true if the user in ctx can write products of the owner
+func canManageProduct(ctx context.Context, ownerID int) bool {
+	user, ok := auth.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	return user.CanAccess(auth.PermProductWrite, ownerID)
+}
+
 // CreateProduct create new product from product input
 func (serv impl) CreateProduct(ctx context.Context, newProduct ProductInput) (model.Product, error) {
-	// 1. Check exists user by product user_id
+	// 1. Only users with the product:write:any permission can create product for another user
+	if !canManageProduct(ctx, newProduct.UserID) {
+		return model.Product{}, ErrPermissionDenied
+	}
+
+	// 2. Check exists user by product user_id
 	existed, err := serv.repo.User().ExistsUserByID(ctx, newProduct.UserID)
 	if err != nil {
 		return model.Product{}, err
@@ -68,17 +84,21 @@ func (serv impl) CreateProduct(ctx context.Context, newProduct ProductInput) (mo
 
 //UpdateProduct updates a product with the specified product
 func (serv impl) UpdateProduct(ctx context.Context, id int, product ProductInput) error {
-	// 1. Check exists user by product user_id
-	existed, err := serv.repo.Product().ExistsProductByID(ctx, id)
+	// 1. Get current product to check its owner
+	current, err := serv.repo.Product().GetProduct(ctx, id)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrProductNotFound
+		}
 		return err
 	}
 
-	if !existed {
-		return ErrUserNotExist
+	// 2. Only ADMIN or the owner can update the product, the owner cannot hand it over to another user
+	if !canManageProduct(ctx, current.UserID) || !canManageProduct(ctx, product.UserID) {
+		return ErrPermissionDenied
 	}
 
-	// 2. Call repo func to update product, get result and error
+	// 3. Call repo func to update product, get result and error
 	// result: number of rows are updated and any error
 	result, err := serv.repo.Product().UpdateProduct(ctx, model.Product{
 		ID:          id,
@@ -93,7 +113,7 @@ func (serv impl) UpdateProduct(ctx context.Context, id int, product ProductInput
 		return err
 	}
 
-	// 2. If number of rows are updated equal zero, return not found error
+	// 4. If number of rows are updated equal zero, return not found error
 	if result == 0 {
 		return ErrProductNotFound
 	}
@@ -103,17 +123,49 @@ func (serv impl) UpdateProduct(ctx context.Context, id int, product ProductInput
 
 //DeleteProduct delete product by id
 func (serv impl) DeleteProduct(ctx context.Context, id int) error {
-	// 1. Call repo func to delete product, get result and error
+	// 1. Get current product to check its owner
+	current, err := serv.repo.Product().GetProduct(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrProductNotFound
+		}
+		return err
+	}
+
+	// 2. Only ADMIN or the owner can delete the product
+	if !canManageProduct(ctx, current.UserID) {
+		return ErrPermissionDenied
+	}
+
+	// 3. Get the images, their rows are deleted with the product but their files are not
+	images, err := serv.repo.Image().GetImages(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// 4. Call repo func to delete product, get result and error
 	// affected rows: number of rows are deleted and any error
 	affectedRows, err := serv.repo.Product().DeleteProduct(ctx, id)
 	if err != nil {
 		return err
 	}
-	// 2. If number of rows are deleted equal zero, return not found error
+	// 5. If number of rows are deleted equal zero, return not found error
 	if affectedRows == 0 {
 		return ErrProductNotFound
 	}
-	// 3. Return nil if everything is successful
+
+	// 6. Delete the files of the images
+	if len(images) > 0 {
+		store, err := storage.FromEnv()
+		if err != nil {
+			log.Printf("Error when delete image files of product %d: %v\n", id, err)
+			return nil
+		}
+		for _, image := range images {
+			deleteImageObjects(ctx, store, image)
+		}
+	}
+	// 7. Return nil if everything is successful
 	return nil
 }
 
@@ -133,14 +185,20 @@ type Pagination struct {
 	Page, Limit int
 }
 
+// MaxSearchQueryLength is the maximum number of characters of the full-text search query
+const MaxSearchQueryLength = 256
+
 type GetProductsInput struct {
-	ID         int
-	Title      string
-	PriceRange PriceRange
-	IsActive   null.Bool
-	UserID     int
-	OrderBy    OrderInput
-	Pagination Pagination
+	ID           int
+	Title        string
+	Query        string // full-text search of the title and the description, the products are ordered by relevance unless OrderBy is set
+	PriceRange   PriceRange
+	IsActive     null.Bool
+	UserID       int
+	CategoryID   int
+	OrderBy      OrderInput
+	Pagination   Pagination
+	PriceBuckets []float64 // the ascending lower bounds of the price buckets of the facets, DefaultPriceBuckets if empty
 }
 
 // toFilter returns a filter which be converted by getProductsInput
@@ -149,12 +207,14 @@ func toFilter(input GetProductsInput) productRepo.Filter {
 	return productRepo.Filter{
 		ID:    input.ID,
 		Title: input.Title,
+		Query: input.Query,
 		PriceRange: productRepo.PriceRange{
 			MinPrice: input.PriceRange.MinPrice,
 			MaxPrice: input.PriceRange.MaxPrice,
 		},
-		IsActive: input.IsActive,
-		UserID:   input.UserID,
+		IsActive:   input.IsActive,
+		UserID:     input.UserID,
+		CategoryID: input.CategoryID,
 		OrderBy: productRepo.OrderBy{
 			Title:     input.OrderBy.Title,
 			Price:     input.OrderBy.Price,
@@ -176,6 +236,9 @@ type ProductItem struct {
 	Quantity    int
 	IsActive    bool
 	User        CreatedBy
+	Options     []Option
+	Variants    []Variant
+	Highlight   *Highlight // nil without search query
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -186,6 +249,12 @@ type CreatedBy struct {
 	Phone string
 }
 
+// Highlight is the title and the description with the words of the search query in <mark> tags, the text is HTML escaped
+type Highlight struct {
+	Title       string
+	Description string
+}
+
 //GetProducts returns a list of products
 func (serv impl) GetProducts(ctx context.Context, input GetProductsInput) ([]ProductItem, int64, error) {
 	// Set pagination is default value if it is empty
@@ -201,6 +270,17 @@ func (serv impl) GetProducts(ctx context.Context, input GetProductsInput) ([]Pro
 	if err != nil {
 		return []ProductItem{}, 0, err
 	}
+
+	// 3. Get the options and the variants of the products
+	ids := make([]int, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	variants, err := serv.GetProductVariants(ctx, ids)
+	if err != nil {
+		return []ProductItem{}, 0, err
+	}
+
 	var result = make([]ProductItem, len(products))
 	for i, p := range products {
 		result[i] = ProductItem{
@@ -216,9 +296,14 @@ func (serv impl) GetProducts(ctx context.Context, input GetProductsInput) ([]Pro
 				Email: p.User.Email,
 				Phone: p.User.Phone,
 			},
+			Options:   variants[p.ID].Options,
+			Variants:  variants[p.ID].Variants,
 			CreatedAt: p.CreatedAt,
 			UpdatedAt: p.UpdatedAt,
 		}
+		if p.Highlight != nil {
+			result[i].Highlight = &Highlight{Title: p.Highlight.Title, Description: p.Highlight.Description}
+		}
 	}
 	return result, totalCount, err
 }
@@ -319,6 +404,11 @@ func (serv impl) ImportProductCSV(ctx context.Context, fileName string, csvFile
 			continue
 		}
 
+		if !canManageProduct(ctx, userId) {
+			log.Printf("Skipping row (%d) because permission denied for user_id: %d\n", rowIndex, userId)
+			continue
+		}
+
 		productList = append(productList, model.Product{
 			Title:       rowMap["title"],
 			Description: rowMap["description"],
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/storage"
 )
 
 type Mock struct {
@@ -67,3 +68,98 @@ func (p *Mock) GetStatistics(ctx context.Context) (SummaryStatistics, error) {
 	args := p.Called(ctx)
 	return args.Get(0).(SummaryStatistics), args.Error(1)
 }
+
+func (p *Mock) GetProductFacets(ctx context.Context, input GetProductsInput) (ProductFacets, error) {
+	args := p.Called(ctx, input)
+	return args.Get(0).(ProductFacets), args.Error(1)
+}
+
+func (p *Mock) GetCategories(ctx context.Context) ([]Category, error) {
+	args := p.Called(ctx)
+	return args.Get(0).([]Category), args.Error(1)
+}
+
+func (p *Mock) GetCategory(ctx context.Context, id int) (Category, error) {
+	args := p.Called(ctx, id)
+	return args.Get(0).(Category), args.Error(1)
+}
+
+func (p *Mock) CreateCategory(ctx context.Context, input CategoryInput) (Category, error) {
+	args := p.Called(ctx, input)
+	return args.Get(0).(Category), args.Error(1)
+}
+
+func (p *Mock) UpdateCategory(ctx context.Context, id int, input CategoryInput) error {
+	args := p.Called(ctx, id, input)
+	return args.Error(0)
+}
+
+func (p *Mock) DeleteCategory(ctx context.Context, id int) error {
+	args := p.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (p *Mock) GetProductCategories(ctx context.Context, productID int) ([]Category, error) {
+	args := p.Called(ctx, productID)
+	return args.Get(0).([]Category), args.Error(1)
+}
+
+func (p *Mock) SetProductCategories(ctx context.Context, productID int, categoryIDs []int) error {
+	args := p.Called(ctx, productID, categoryIDs)
+	return args.Error(0)
+}
+
+func (p *Mock) GetProductVariants(ctx context.Context, productIDs []int) (map[int]ProductVariants, error) {
+	args := p.Called(ctx, productIDs)
+	return args.Get(0).(map[int]ProductVariants), args.Error(1)
+}
+
+func (p *Mock) SetProductOptions(ctx context.Context, productID int, names []string) error {
+	args := p.Called(ctx, productID, names)
+	return args.Error(0)
+}
+
+func (p *Mock) CreateVariant(ctx context.Context, productID int, input VariantInput) (Variant, error) {
+	args := p.Called(ctx, productID, input)
+	return args.Get(0).(Variant), args.Error(1)
+}
+
+func (p *Mock) UpdateVariant(ctx context.Context, productID, id int, input VariantInput) error {
+	args := p.Called(ctx, productID, id, input)
+	return args.Error(0)
+}
+
+func (p *Mock) DeleteVariant(ctx context.Context, productID, id int) error {
+	args := p.Called(ctx, productID, id)
+	return args.Error(0)
+}
+
+func (p *Mock) GetProductImages(ctx context.Context, productID int) ([]Image, error) {
+	args := p.Called(ctx, productID)
+	return args.Get(0).([]Image), args.Error(1)
+}
+
+func (p *Mock) GetProductImageFile(ctx context.Context, productID, id int, size string) (storage.Object, error) {
+	args := p.Called(ctx, productID, id, size)
+	return args.Get(0).(storage.Object), args.Error(1)
+}
+
+func (p *Mock) UploadProductImage(ctx context.Context, productID int, input ImageInput) (Image, error) {
+	args := p.Called(ctx, productID, input)
+	return args.Get(0).(Image), args.Error(1)
+}
+
+func (p *Mock) SetProductImagePositions(ctx context.Context, productID int, ids []int) error {
+	args := p.Called(ctx, productID, ids)
+	return args.Error(0)
+}
+
+func (p *Mock) SetPrimaryProductImage(ctx context.Context, productID, id int) error {
+	args := p.Called(ctx, productID, id)
+	return args.Error(0)
+}
+
+func (p *Mock) DeleteProductImage(ctx context.Context, productID, id int) error {
+	args := p.Called(ctx, productID, id)
+	return args.Error(0)
+}
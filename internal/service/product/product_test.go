@@ -2,6 +2,7 @@ package product
 
 import (
 	"context"
+	"database/sql"
 	"io"
 	"os"
 	"strings"
@@ -13,8 +14,17 @@ import (
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/image"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/variant"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+// Permissions of the seeded GUEST and ADMIN roles used by the tests
+var (
+	guestPermissions = []string{auth.PermProductWrite}
+	adminPermissions = []string{auth.PermProductWrite, auth.PermProductWriteAny}
 )
 
 func TestProductService_GetProduct(t *testing.T) {
@@ -129,9 +139,9 @@ func TestProductService_CreateProduct(t *testing.T) {
 					IsActive:    true,
 					UserID:      1,
 				},
-				ctx: context.Background(),
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
 				mockCreateProduct: mockCreateProduct{
-					ctx: context.Background(),
+					ctx: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
 					product: model.Product{
 						Title:       "test",
 						Description: "",
@@ -151,7 +161,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 					},
 				},
 				mockExistUser: mockExistUser{
-					ctx:    context.Background(),
+					ctx:    auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
 					userID: 1,
 					result: true,
 				},
@@ -178,9 +188,9 @@ func TestProductService_CreateProduct(t *testing.T) {
 					IsActive:    true,
 					UserID:      100,
 				},
-				ctx: context.Background(),
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions}),
 				mockExistUser: mockExistUser{
-					ctx:    context.Background(),
+					ctx:    auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions}),
 					userID: 100,
 					result: false,
 				},
@@ -189,6 +199,21 @@ func TestProductService_CreateProduct(t *testing.T) {
 				err: ErrUserNotExist,
 			},
 		},
+		"permission_denied": {
+			input: input{
+				product: ProductInput{
+					Title:    "test",
+					Price:    20000,
+					Quantity: 10,
+					IsActive: true,
+					UserID:   3,
+				},
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			},
+			expOutput: output{
+				err: ErrPermissionDenied,
+			},
+		},
 	}
 
 	for desc, tc := range tcs {
@@ -222,12 +247,15 @@ func TestProductService_CreateProduct(t *testing.T) {
 
 func TestProductService_UpdateProduct(t *testing.T) {
 	type mockData struct {
+		current     model.Product
+		currentErr  error
 		input       model.Product
 		affectedRow int64
 		err         error
 	}
 
 	type givenData struct {
+		ctx   context.Context
 		id    int
 		input ProductInput
 		mock  mockData
@@ -239,7 +267,8 @@ func TestProductService_UpdateProduct(t *testing.T) {
 	}{
 		"success": {
 			given: givenData{
-				id: 1,
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				id:  1,
 				input: ProductInput{
 					Title:       "test",
 					Description: "",
@@ -249,6 +278,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 					UserID:      1,
 				},
 				mock: mockData{
+					current: model.Product{ID: 1, UserID: 1},
 					input: model.Product{
 						ID:          1,
 						Title:       "test",
@@ -265,7 +295,8 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		},
 		"error": {
 			given: givenData{
-				id: 1,
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions}),
+				id:  1,
 				input: ProductInput{
 					Title:       "test",
 					Description: "",
@@ -275,6 +306,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 					UserID:      1,
 				},
 				mock: mockData{
+					current: model.Product{ID: 1, UserID: 1},
 					input: model.Product{
 						ID:          1,
 						Title:       "test",
@@ -289,6 +321,54 @@ func TestProductService_UpdateProduct(t *testing.T) {
 			},
 			expErr: ErrProductNotFound,
 		},
+		"product_not_found": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions}),
+				id:  2,
+				input: ProductInput{
+					Title:    "test",
+					Price:    20000,
+					Quantity: 10,
+					UserID:   1,
+				},
+				mock: mockData{
+					currentErr: sql.ErrNoRows,
+				},
+			},
+			expErr: ErrProductNotFound,
+		},
+		"permission_denied_not_owner": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				id:  3,
+				input: ProductInput{
+					Title:    "test",
+					Price:    20000,
+					Quantity: 10,
+					UserID:   1,
+				},
+				mock: mockData{
+					current: model.Product{ID: 3, UserID: 5},
+				},
+			},
+			expErr: ErrPermissionDenied,
+		},
+		"permission_denied_change_owner": {
+			given: givenData{
+				ctx: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				id:  1,
+				input: ProductInput{
+					Title:    "test",
+					Price:    20000,
+					Quantity: 10,
+					UserID:   5,
+				},
+				mock: mockData{
+					current: model.Product{ID: 1, UserID: 1},
+				},
+			},
+			expErr: ErrPermissionDenied,
+		},
 	}
 
 	for desc, tc := range tcs {
@@ -296,14 +376,14 @@ func TestProductService_UpdateProduct(t *testing.T) {
 			// Given
 			repoMock := new(repository.Mock)
 			productMock := new(product.Mock)
-			productMock.On("UpdateProduct", context.Background(), tc.given.mock.input).Return(tc.given.mock.affectedRow, tc.given.mock.err)
-			productMock.On("ExistsProductByID", context.Background(), tc.given.id).Return(true, nil)
+			productMock.On("GetProduct", tc.given.ctx, tc.given.id).Return(tc.given.mock.current, tc.given.mock.currentErr)
+			productMock.On("UpdateProduct", tc.given.ctx, tc.given.mock.input).Return(tc.given.mock.affectedRow, tc.given.mock.err)
 			repoMock.On("Product").Return(productMock)
 
 			productService := New(repoMock)
 
 			// When
-			err := productService.UpdateProduct(context.Background(), tc.given.id, tc.given.input)
+			err := productService.UpdateProduct(tc.given.ctx, tc.given.id, tc.given.input)
 
 			// Then
 			if tc.expErr != nil {
@@ -322,47 +402,63 @@ func TestProductService_DeleteProduct(t *testing.T) {
 		productID          int
 		mockInputID        int
 		mockInputCTX       context.Context
+		mockCurrent        model.Product
+		mockCurrentError   error
 		mockOutputError    error
 		mockOutputAffected int
 	}
-	type output struct {
-		product model.Product
-		err     error
-	}
 	tcs := map[string]struct {
 		input    input
 		expError error // output
 	}{
 		"success": {
 			input: input{
-				ctx:       context.Background(),
+				ctx:       auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
 				productID: 1,
 
 				mockInputID:        1,
-				mockInputCTX:       context.Background(),
+				mockInputCTX:       auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				mockCurrent:        model.Product{ID: 1, UserID: 1},
 				mockOutputAffected: 1,
 			},
 			expError: nil,
 		},
 		"not_found": {
 			input: input{
-				ctx:       context.Background(),
+				ctx:       auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions}),
 				productID: 2,
 
-				mockInputID:        2,
-				mockInputCTX:       context.Background(),
-				mockOutputAffected: 0,
+				mockInputID:      2,
+				mockInputCTX:     auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions}),
+				mockCurrentError: sql.ErrNoRows,
 			},
 			expError: ErrProductNotFound,
 		},
+		"permission_denied": {
+			input: input{
+				ctx:       auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				productID: 3,
+
+				mockInputID:  3,
+				mockInputCTX: auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+				mockCurrent:  model.Product{ID: 3, UserID: 5},
+			},
+			expError: ErrPermissionDenied,
+		},
 	}
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
 			// GIVEN
 			productMock := new(product.Mock)
-			productMock.On("DeleteProduct", tc.input.mockInputCTX, tc.input.productID).Return(tc.input.mockOutputAffected, tc.input.mockOutputError)
+			productMock.On("GetProduct", tc.input.mockInputCTX, tc.input.mockInputID).Return(tc.input.mockCurrent, tc.input.mockCurrentError)
+			imageMock := new(image.Mock)
+			if tc.expError == nil {
+				productMock.On("DeleteProduct", tc.input.mockInputCTX, tc.input.mockInputID).Return(tc.input.mockOutputAffected, tc.input.mockOutputError)
+				imageMock.On("GetImages", tc.input.mockInputCTX, tc.input.mockInputID).Return([]model.ProductImage{}, nil)
+			}
 			repoMock := new(repository.Mock)
 			repoMock.On("Product").Return(productMock)
+			repoMock.On("Image").Return(imageMock)
 
 			productService := New(repoMock)
 
@@ -505,6 +601,70 @@ func TestProductService_GetProducts(t *testing.T) {
 				totalCount: 2,
 			},
 		},
+		"search": {
+			input: input{
+				ctx: context.Background(),
+				getProductsInput: GetProductsInput{
+					Query: "macbook pro",
+					Pagination: Pagination{
+						Limit: 20,
+						Page:  1,
+					},
+				},
+				mockInputCTX: context.Background(),
+				mockInputFilter: product.Filter{
+					Query: "macbook pro",
+					Pagination: product.Pagination{
+						Limit: 20,
+						Page:  1,
+					},
+				},
+				mockResultProducts: []product.ProductItem{
+					{
+						ID:          3,
+						Title:       "Macbook Pro",
+						Description: "Apple laptop",
+						Price:       2500000,
+						Quantity:    150,
+						IsActive:    true,
+						User: product.CreatedBy{
+							ID:    2,
+							Name:  "admin 2",
+							Email: "admin2@example.com",
+							Phone: "0987654321",
+						},
+						Highlight: &product.Highlight{
+							Title:       "<mark>Macbook</mark> <mark>Pro</mark>",
+							Description: "Apple laptop",
+						},
+					},
+				},
+				mockResultTotalCount: 1,
+			},
+			expOutput: output{
+				result: []ProductItem{
+					{
+						ID:          3,
+						Title:       "Macbook Pro",
+						Description: "Apple laptop",
+						Price:       2500000,
+						Quantity:    150,
+						IsActive:    true,
+						User: CreatedBy{
+							ID:    2,
+							Name:  "admin 2",
+							Email: "admin2@example.com",
+							Phone: "0987654321",
+						},
+						Highlight: &Highlight{
+							Title:       "<mark>Macbook</mark> <mark>Pro</mark>",
+							Description: "Apple laptop",
+						},
+					},
+				},
+				totalCount: 1,
+			},
+		},
 		"empty_result": {
 			input: input{
 				ctx: context.Background(),
@@ -630,8 +790,12 @@ func TestProductService_GetProducts(t *testing.T) {
 			// GIVEN
 			productMock := new(product.Mock)
 			productMock.On("GetProducts", tc.input.mockInputCTX, tc.input.mockInputFilter).Return(tc.input.mockResultProducts, tc.input.mockResultTotalCount, tc.input.mockResultError)
+			variantMock := new(variant.Mock)
+			variantMock.On("GetOptions", tc.input.mockInputCTX, mock.Anything).Return([]model.ProductOption{}, nil)
+			variantMock.On("GetVariants", tc.input.mockInputCTX, mock.Anything).Return([]variant.Variant{}, nil)
 			repoMock := new(repository.Mock)
 			repoMock.On("Product").Return(productMock)
+			repoMock.On("Variant").Return(variantMock)
 
 			productService := New(repoMock)
 
@@ -701,7 +865,7 @@ func TestProductService_ImportProductCSV(t *testing.T) {
 			csvReader := strings.NewReader(tc.given.csvData)
 
 			// When
-			err := productServ.ImportProductCSV(context.Background(), "product.csv", csvReader)
+			err := productServ.ImportProductCSV(auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: adminPermissions}), "product.csv", csvReader)
 
 			// Then
 			if tc.expErr != nil {
@@ -0,0 +1,285 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	variantRepo "github.com/vinhnv1/s3corp-golang-fresher/internal/repository/variant"
+)
+
+// Option is an option of the variants of a product, e.g. Size or Colour
+type Option struct {
+	ID       int
+	Name     string
+	Position int
+}
+
+// VariantOption is the value of an option for a variant, e.g. Size M
+type VariantOption struct {
+	Name  string
+	Value string
+}
+
+// Variant is a variant of a product with its own sku, price and stock
+type Variant struct {
+	ID        int
+	SKU       string
+	Price     null.Float64 // NULL for the price of the product
+	Quantity  int
+	Options   []VariantOption // ordered by the position of the options
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ProductVariants are the options and the variants of a product
+type ProductVariants struct {
+	Options  []Option
+	Variants []Variant
+}
+
+type VariantInput struct {
+	SKU      string
+	Price    null.Float64
+	Quantity int
+	Options  map[string]string // option name -> value
+}
+
+// GetProductVariants returns the options and the variants of each product, products without options are not in the map
+func (serv impl) GetProductVariants(ctx context.Context, productIDs []int) (map[int]ProductVariants, error) {
+	result := map[int]ProductVariants{}
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	options, err := serv.repo.Variant().GetOptions(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := serv.repo.Variant().GetVariants(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	optionNames := map[int]string{}
+	for _, o := range options {
+		optionNames[o.ID] = o.Name
+		pv := result[o.ProductID]
+		pv.Options = append(pv.Options, Option{ID: o.ID, Name: o.Name, Position: o.Position})
+		result[o.ProductID] = pv
+	}
+	for _, v := range variants {
+		pv := result[v.ProductID]
+		pv.Variants = append(pv.Variants, toVariant(v, optionNames))
+		result[v.ProductID] = pv
+	}
+	return result, nil
+}
+
+// toVariant converts the variant of the repository, optionNames are the names of the options by their id
+func toVariant(variant variantRepo.Variant, optionNames map[int]string) Variant {
+	options := make([]VariantOption, len(variant.Values))
+	for i, v := range variant.Values {
+		options[i] = VariantOption{Name: optionNames[v.OptionID], Value: v.Value}
+	}
+	return Variant{
+		ID:        variant.ID,
+		SKU:       variant.Sku,
+		Price:     variant.Price,
+		Quantity:  variant.Quantity,
+		Options:   options,
+		CreatedAt: variant.CreatedAt,
+		UpdatedAt: variant.UpdatedAt,
+	}
+}
+
+// getManagedProduct returns the product if the user in ctx can update it
+func (serv impl) getManagedProduct(ctx context.Context, productID int) (model.Product, error) {
+	product, err := serv.repo.Product().GetProduct(ctx, productID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Product{}, ErrProductNotFound
+	} else if err != nil {
+		return model.Product{}, err
+	}
+	if !canManageProduct(ctx, product.UserID) {
+		return model.Product{}, ErrPermissionDenied
+	}
+	return product, nil
+}
+
+// SetProductOptions replaces the options of the product, the options cannot be changed while the product has variants
+func (serv impl) SetProductOptions(ctx context.Context, productID int, names []string) error {
+	// 1. Check the product and the permission
+	if _, err := serv.getManagedProduct(ctx, productID); err != nil {
+		return err
+	}
+
+	// 2. The option names are case-insensitive unique
+	seen := map[string]bool{}
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if seen[key] {
+			return ErrDuplicateOption
+		}
+		seen[key] = true
+	}
+
+	// 3. The values of the existing variants would not match the new options
+	hasVariants, err := serv.repo.Variant().ExistsVariantByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if hasVariants {
+		return ErrProductHasVariants
+	}
+
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		return serv.repo.Variant().SetOptions(ctx, tx, productID, names)
+	})
+}
+
+// combinationKey identifies the option values of a variant, the values must be ordered by option
+func combinationKey(values []variantRepo.OptionValue) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v.OptionID) + "=" + v.Value
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "\x00")
+}
+
+// checkVariant checks the sku and the option values of the variant, it returns the values ordered by option and the names of the options.
+// Every option of the product must have a value and no other variant of the product can have the same values.
+func (serv impl) checkVariant(ctx context.Context, productID, id int, input VariantInput) ([]variantRepo.OptionValue, map[int]string, error) {
+	// 1. The sku is unique in the organization
+	existed, err := serv.repo.Variant().ExistsVariantBySKU(ctx, input.SKU, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existed {
+		return nil, nil, ErrVariantSKUExisted
+	}
+
+	// 2. Map the values to the options of the product
+	options, err := serv.repo.Variant().GetOptions(ctx, []int{productID})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(input.Options) != len(options) {
+		return nil, nil, ErrInvalidVariantOptions
+	}
+	values := make([]variantRepo.OptionValue, 0, len(options))
+	optionNames := map[int]string{}
+	for _, o := range options {
+		value, ok := input.Options[o.Name]
+		if !ok {
+			return nil, nil, ErrInvalidVariantOptions
+		}
+		values = append(values, variantRepo.OptionValue{OptionID: o.ID, Value: value})
+		optionNames[o.ID] = o.Name
+	}
+
+	// 3. The values are unique among the variants of the product
+	variants, err := serv.repo.Variant().GetVariants(ctx, []int{productID})
+	if err != nil {
+		return nil, nil, err
+	}
+	key := combinationKey(values)
+	for _, v := range variants {
+		if v.ID != id && combinationKey(v.Values) == key {
+			return nil, nil, ErrVariantExisted
+		}
+	}
+	return values, optionNames, nil
+}
+
+// CreateVariant creates a new variant of the product
+func (serv impl) CreateVariant(ctx context.Context, productID int, input VariantInput) (Variant, error) {
+	// 1. Check the product and the permission
+	if _, err := serv.getManagedProduct(ctx, productID); err != nil {
+		return Variant{}, err
+	}
+
+	// 2. Check the variant
+	values, optionNames, err := serv.checkVariant(ctx, productID, 0, input)
+	if err != nil {
+		return Variant{}, err
+	}
+
+	// 3. Create the variant with its option values
+	var created variantRepo.Variant
+	if err := serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		var err error
+		created, err = serv.repo.Variant().CreateVariant(ctx, tx, variantRepo.Variant{
+			ProductVariant: model.ProductVariant{
+				ProductID: productID,
+				Sku:       input.SKU,
+				Price:     input.Price,
+				Quantity:  input.Quantity,
+			},
+			Values: values,
+		})
+		return err
+	}); err != nil {
+		return Variant{}, err
+	}
+	return toVariant(created, optionNames), nil
+}
+
+// UpdateVariant updates the sku, the price, the stock and the option values of the variant of the product
+func (serv impl) UpdateVariant(ctx context.Context, productID, id int, input VariantInput) error {
+	// 1. Check the product and the permission
+	if _, err := serv.getManagedProduct(ctx, productID); err != nil {
+		return err
+	}
+
+	// 2. Check the variant
+	values, _, err := serv.checkVariant(ctx, productID, id, input)
+	if err != nil {
+		return err
+	}
+
+	// 3. Update the variant and its option values
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		affected, err := serv.repo.Variant().UpdateVariant(ctx, tx, variantRepo.Variant{
+			ProductVariant: model.ProductVariant{
+				ID:        id,
+				ProductID: productID,
+				Sku:       input.SKU,
+				Price:     input.Price,
+				Quantity:  input.Quantity,
+			},
+			Values: values,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrVariantNotFound
+		}
+		return nil
+	})
+}
+
+// DeleteVariant deletes the variant of the product, the orders of the variant keep its sku
+func (serv impl) DeleteVariant(ctx context.Context, productID, id int) error {
+	if _, err := serv.getManagedProduct(ctx, productID); err != nil {
+		return err
+	}
+
+	affected, err := serv.repo.Variant().DeleteVariant(ctx, productID, id)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrVariantNotFound
+	}
+	return nil
+}
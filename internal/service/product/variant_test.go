@@ -0,0 +1,242 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/variant"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestProductService_GetProductVariants(t *testing.T) {
+	// GIVEN
+	ctx := context.Background()
+	createdAt := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	variantRepoMock := new(variant.Mock)
+	variantRepoMock.On("GetOptions", ctx, []int{1, 2}).Return([]model.ProductOption{
+		{ID: 3, ProductID: 1, Name: "Size", Position: 1},
+		{ID: 4, ProductID: 1, Name: "Colour", Position: 2},
+	}, nil)
+	variantRepoMock.On("GetVariants", ctx, []int{1, 2}).Return([]variant.Variant{
+		{
+			ProductVariant: model.ProductVariant{ID: 5, ProductID: 1, Sku: "SHIRT-M-RED", Price: null.Float64From(120), Quantity: 4, CreatedAt: createdAt, UpdatedAt: createdAt},
+			Values:         []variant.OptionValue{{VariantID: 5, OptionID: 3, Value: "M"}, {VariantID: 5, OptionID: 4, Value: "Red"}},
+		},
+	}, nil)
+	repoMock := new(repository.Mock)
+	repoMock.On("Variant").Return(variantRepoMock)
+
+	productService := New(repoMock)
+
+	// WHEN
+	result, err := productService.GetProductVariants(ctx, []int{1, 2})
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, map[int]ProductVariants{
+		1: {
+			Options: []Option{{ID: 3, Name: "Size", Position: 1}, {ID: 4, Name: "Colour", Position: 2}},
+			Variants: []Variant{{
+				ID: 5, SKU: "SHIRT-M-RED", Price: null.Float64From(120), Quantity: 4,
+				Options:   []VariantOption{{Name: "Size", Value: "M"}, {Name: "Colour", Value: "Red"}},
+				CreatedAt: createdAt, UpdatedAt: createdAt,
+			}},
+		},
+	}, result)
+}
+
+func TestProductService_SetProductOptions(t *testing.T) {
+	tcs := map[string]struct {
+		ctx             context.Context
+		names           []string
+		mockProductErr  error
+		mockHasVariants bool
+		expErr          error
+	}{
+		"success": {
+			ctx:   auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			names: []string{"Size", "Colour"},
+		},
+		"product_not_found": {
+			ctx:            auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			names:          []string{"Size"},
+			mockProductErr: sql.ErrNoRows,
+			expErr:         ErrProductNotFound,
+		},
+		"permission_denied": {
+			ctx:    auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			names:  []string{"Size"},
+			expErr: ErrPermissionDenied,
+		},
+		"duplicate_option": {
+			ctx:    auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions}),
+			names:  []string{"Size", "size"},
+			expErr: ErrDuplicateOption,
+		},
+		"product_has_variants": {
+			ctx:             auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: adminPermissions}),
+			names:           []string{"Size"},
+			mockHasVariants: true,
+			expErr:          ErrProductHasVariants,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetProduct", tc.ctx, 10).Return(model.Product{ID: 10, UserID: 1}, tc.mockProductErr)
+			variantRepoMock := new(variant.Mock)
+			variantRepoMock.On("ExistsVariantByProductID", tc.ctx, 10).Return(tc.mockHasVariants, nil)
+			variantRepoMock.On("SetOptions", tc.ctx, (*sql.Tx)(nil), 10, tc.names).Return(nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+			repoMock.On("Variant").Return(variantRepoMock)
+			runTx(t, repoMock, tc.ctx)
+
+			productService := New(repoMock)
+
+			// WHEN
+			err := productService.SetProductOptions(tc.ctx, 10, tc.names)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				variantRepoMock.AssertNotCalled(t, "SetOptions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			variantRepoMock.AssertCalled(t, "SetOptions", tc.ctx, (*sql.Tx)(nil), 10, tc.names)
+		})
+	}
+}
+
+func TestProductService_CreateVariant(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions})
+	tcs := map[string]struct {
+		input         VariantInput
+		mockSKUExists bool
+		expValues     []variant.OptionValue
+		expErr        error
+	}{
+		"success": {
+			input:     VariantInput{SKU: "SHIRT-L-RED", Price: null.Float64From(130), Quantity: 2, Options: map[string]string{"Size": "L", "Colour": "Red"}},
+			expValues: []variant.OptionValue{{OptionID: 3, Value: "L"}, {OptionID: 4, Value: "Red"}},
+		},
+		"sku_existed": {
+			input:         VariantInput{SKU: "SHIRT-M-RED", Options: map[string]string{"Size": "L", "Colour": "Red"}},
+			mockSKUExists: true,
+			expErr:        ErrVariantSKUExisted,
+		},
+		"missing_option": {
+			input:  VariantInput{SKU: "SHIRT-L", Options: map[string]string{"Size": "L"}},
+			expErr: ErrInvalidVariantOptions,
+		},
+		"unknown_option": {
+			input:  VariantInput{SKU: "SHIRT-L", Options: map[string]string{"Size": "L", "Material": "Cotton"}},
+			expErr: ErrInvalidVariantOptions,
+		},
+		"variant_existed": {
+			input:  VariantInput{SKU: "SHIRT-M-RED-2", Options: map[string]string{"Colour": "Red", "Size": "M"}},
+			expErr: ErrVariantExisted,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetProduct", ctx, 10).Return(model.Product{ID: 10, UserID: 1}, nil)
+			variantRepoMock := new(variant.Mock)
+			variantRepoMock.On("ExistsVariantBySKU", ctx, tc.input.SKU, 0).Return(tc.mockSKUExists, nil)
+			variantRepoMock.On("GetOptions", ctx, []int{10}).Return([]model.ProductOption{
+				{ID: 3, ProductID: 10, Name: "Size", Position: 1},
+				{ID: 4, ProductID: 10, Name: "Colour", Position: 2},
+			}, nil)
+			variantRepoMock.On("GetVariants", ctx, []int{10}).Return([]variant.Variant{
+				{
+					ProductVariant: model.ProductVariant{ID: 5, ProductID: 10, Sku: "SHIRT-M-RED"},
+					Values:         []variant.OptionValue{{VariantID: 5, OptionID: 3, Value: "M"}, {VariantID: 5, OptionID: 4, Value: "Red"}},
+				},
+			}, nil)
+			variantRepoMock.On("CreateVariant", ctx, (*sql.Tx)(nil), mock.Anything).Return(variant.Variant{
+				ProductVariant: model.ProductVariant{ID: 6, ProductID: 10, Sku: tc.input.SKU, Price: tc.input.Price, Quantity: tc.input.Quantity},
+				Values:         tc.expValues,
+			}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+			repoMock.On("Variant").Return(variantRepoMock)
+			runTx(t, repoMock, ctx)
+
+			productService := New(repoMock)
+
+			// WHEN
+			result, err := productService.CreateVariant(ctx, 10, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				variantRepoMock.AssertNotCalled(t, "CreateVariant", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			variantRepoMock.AssertCalled(t, "CreateVariant", ctx, (*sql.Tx)(nil), variant.Variant{
+				ProductVariant: model.ProductVariant{ProductID: 10, Sku: tc.input.SKU, Price: tc.input.Price, Quantity: tc.input.Quantity},
+				Values:         tc.expValues,
+			})
+			require.Equal(t, Variant{
+				ID: 6, SKU: "SHIRT-L-RED", Price: null.Float64From(130), Quantity: 2,
+				Options: []VariantOption{{Name: "Size", Value: "L"}, {Name: "Colour", Value: "Red"}},
+			}, result)
+		})
+	}
+}
+
+func TestProductService_DeleteVariant(t *testing.T) {
+	tcs := map[string]struct {
+		mockAffected int64
+		expErr       error
+	}{
+		"success": {
+			mockAffected: 1,
+		},
+		"variant_not_found": {
+			expErr: ErrVariantNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Permissions: guestPermissions})
+			productRepoMock := new(product.Mock)
+			productRepoMock.On("GetProduct", ctx, 10).Return(model.Product{ID: 10, UserID: 1}, nil)
+			variantRepoMock := new(variant.Mock)
+			variantRepoMock.On("DeleteVariant", ctx, 10, 5).Return(tc.mockAffected, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Product").Return(productRepoMock)
+			repoMock.On("Variant").Return(variantRepoMock)
+
+			productService := New(repoMock)
+
+			// WHEN
+			err := productService.DeleteVariant(ctx, 10, 5)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
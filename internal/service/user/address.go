@@ -0,0 +1,223 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+// Address is an address in the address book of a user
+type Address struct {
+	ID                int       `json:"id"`
+	UserID            int       `json:"user_id"`
+	Name              string    `json:"name"`
+	Phone             string    `json:"phone"`
+	Line1             string    `json:"line1"`
+	Line2             string    `json:"line2"`
+	City              string    `json:"city"`
+	State             string    `json:"state"`
+	PostalCode        string    `json:"postal_code"`
+	Country           string    `json:"country"`
+	IsDefaultShipping bool      `json:"is_default_shipping"`
+	IsDefaultBilling  bool      `json:"is_default_billing"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type AddressInput struct {
+	ID                int
+	UserID            int
+	Name              string
+	Phone             string
+	Line1             string
+	Line2             string
+	City              string
+	State             string
+	PostalCode        string
+	Country           string
+	IsDefaultShipping bool
+	IsDefaultBilling  bool
+}
+
+// toAddress converts model.Address to Address
+func toAddress(address model.Address) Address {
+	return Address{
+		ID:                address.ID,
+		UserID:            address.UserID,
+		Name:              address.Name,
+		Phone:             address.Phone,
+		Line1:             address.Line1,
+		Line2:             address.Line2,
+		City:              address.City,
+		State:             address.State,
+		PostalCode:        address.PostalCode,
+		Country:           address.Country,
+		IsDefaultShipping: address.IsDefaultShipping,
+		IsDefaultBilling:  address.IsDefaultBilling,
+		CreatedAt:         address.CreatedAt,
+		UpdatedAt:         address.UpdatedAt,
+	}
+}
+
+// checkAddressBook checks that the caller can access the address book of the user and that the user exists.
+// Users manage their own addresses, other users need the given user permission.
+func (serv impl) checkAddressBook(ctx context.Context, userID int, permission string) error {
+	caller, ok := auth.FromContext(ctx)
+	if !ok || (caller.ID != userID && !caller.HasPermission(permission)) {
+		return ErrPermissionDenied
+	}
+
+	existed, err := serv.repo.User().ExistsUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !existed {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// GetAddresses returns the addresses of the user
+func (serv impl) GetAddresses(ctx context.Context, userID int) ([]Address, error) {
+	if err := serv.checkAddressBook(ctx, userID, auth.PermUserRead); err != nil {
+		return nil, err
+	}
+
+	addresses, err := serv.repo.Address().GetAddresses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Address, 0, len(addresses))
+	for _, a := range addresses {
+		result = append(result, toAddress(a))
+	}
+	return result, nil
+}
+
+// GetAddress returns an address of the user
+func (serv impl) GetAddress(ctx context.Context, userID int, id int) (Address, error) {
+	if err := serv.checkAddressBook(ctx, userID, auth.PermUserRead); err != nil {
+		return Address{}, err
+	}
+
+	address, err := serv.repo.Address().GetAddress(ctx, userID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Address{}, ErrAddressNotFound
+	} else if err != nil {
+		return Address{}, err
+	}
+	return toAddress(address), nil
+}
+
+// CreateAddress adds an address to the address book of the user.
+// The first address of the user becomes the default shipping and billing address.
+func (serv impl) CreateAddress(ctx context.Context, input AddressInput) (Address, error) {
+	// 1. Check the address book
+	if err := serv.checkAddressBook(ctx, input.UserID, auth.PermUserWrite); err != nil {
+		return Address{}, err
+	}
+
+	// 2. The first address is the default of both
+	addresses, err := serv.repo.Address().GetAddresses(ctx, input.UserID)
+	if err != nil {
+		return Address{}, err
+	}
+	if len(addresses) == 0 {
+		input.IsDefaultShipping = true
+		input.IsDefaultBilling = true
+	}
+
+	// 3. Replace the current defaults and create the address
+	var created model.Address
+	if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		if err := serv.repo.Address().ClearDefaultAddresses(ctx, tx, input.UserID, input.IsDefaultShipping, input.IsDefaultBilling); err != nil {
+			return err
+		}
+
+		created, err = serv.repo.Address().CreateAddress(ctx, tx, model.Address{
+			UserID:            input.UserID,
+			Name:              input.Name,
+			Phone:             input.Phone,
+			Line1:             input.Line1,
+			Line2:             input.Line2,
+			City:              input.City,
+			State:             input.State,
+			PostalCode:        input.PostalCode,
+			Country:           input.Country,
+			IsDefaultShipping: input.IsDefaultShipping,
+			IsDefaultBilling:  input.IsDefaultBilling,
+		})
+		return err
+	}); err != nil {
+		return Address{}, err
+	}
+
+	return toAddress(created), nil
+}
+
+// UpdateAddress updates an address of the user, a default flag is kept if it is not set again
+// so the user always has a default address once they have one.
+func (serv impl) UpdateAddress(ctx context.Context, input AddressInput) error {
+	// 1. Get the address
+	if err := serv.checkAddressBook(ctx, input.UserID, auth.PermUserWrite); err != nil {
+		return err
+	}
+
+	address, err := serv.repo.Address().GetAddress(ctx, input.UserID, input.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAddressNotFound
+	} else if err != nil {
+		return err
+	}
+
+	// 2. A default cannot be unset, another address has to become the default
+	setShipping := input.IsDefaultShipping && !address.IsDefaultShipping
+	setBilling := input.IsDefaultBilling && !address.IsDefaultBilling
+	address.Name = input.Name
+	address.Phone = input.Phone
+	address.Line1 = input.Line1
+	address.Line2 = input.Line2
+	address.City = input.City
+	address.State = input.State
+	address.PostalCode = input.PostalCode
+	address.Country = input.Country
+	address.IsDefaultShipping = address.IsDefaultShipping || input.IsDefaultShipping
+	address.IsDefaultBilling = address.IsDefaultBilling || input.IsDefaultBilling
+
+	// 3. Replace the current defaults and update the address
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		if err := serv.repo.Address().ClearDefaultAddresses(ctx, tx, input.UserID, setShipping, setBilling); err != nil {
+			return err
+		}
+
+		affected, err := serv.repo.Address().UpdateAddress(ctx, tx, address)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrAddressNotFound
+		}
+		return nil
+	})
+}
+
+// DeleteAddress deletes an address of the user, the orders keep the address they were placed with
+func (serv impl) DeleteAddress(ctx context.Context, userID int, id int) error {
+	if err := serv.checkAddressBook(ctx, userID, auth.PermUserWrite); err != nil {
+		return err
+	}
+
+	affected, err := serv.repo.Address().DeleteAddress(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/address"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestUserService_GetAddresses(t *testing.T) {
+	tcs := map[string]struct {
+		caller     auth.User
+		givenUser  int
+		userExists bool
+		expLen     int
+		expErr     error
+	}{
+		"success_own_addresses": {
+			caller:     auth.User{ID: 2, Role: auth.RoleGuest},
+			givenUser:  2,
+			userExists: true,
+			expLen:     2,
+		},
+		"success_with_permission": {
+			caller:     auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserRead}},
+			givenUser:  2,
+			userExists: true,
+			expLen:     2,
+		},
+		"error_addresses_of_other_user": {
+			caller:    auth.User{ID: 3, Role: auth.RoleGuest},
+			givenUser: 2,
+			expErr:    ErrPermissionDenied,
+		},
+		"error_user_not_found": {
+			caller:     auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserRead}},
+			givenUser:  2,
+			userExists: false,
+			expErr:     ErrUserNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), tc.caller)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("ExistsUserByID", ctx, tc.givenUser).Return(tc.userExists, nil)
+			addressRepoMock := new(address.Mock)
+			addressRepoMock.On("GetAddresses", ctx, tc.givenUser).Return([]model.Address{{ID: 1, UserID: tc.givenUser}, {ID: 2, UserID: tc.givenUser}}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Address").Return(addressRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.GetAddresses(ctx, tc.givenUser)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				addressRepoMock.AssertNotCalled(t, "GetAddresses", ctx, tc.givenUser)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, result, tc.expLen)
+			}
+		})
+	}
+}
+
+func TestUserService_GetAddress(t *testing.T) {
+	tcs := map[string]struct {
+		addressErr error
+		expErr     error
+	}{
+		"success": {},
+		"error_not_found": {
+			addressErr: sql.ErrNoRows,
+			expErr:     ErrAddressNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest})
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("ExistsUserByID", ctx, 2).Return(true, nil)
+			addressRepoMock := new(address.Mock)
+			addressRepoMock.On("GetAddress", ctx, 2, 5).Return(model.Address{ID: 5, UserID: 2, Country: "VN"}, tc.addressErr)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Address").Return(addressRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.GetAddress(ctx, 2, 5)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, 5, result.ID)
+				require.Equal(t, "VN", result.Country)
+			}
+		})
+	}
+}
+
+func TestUserService_CreateAddress(t *testing.T) {
+	tcs := map[string]struct {
+		existing     []model.Address
+		input        AddressInput
+		expShipping  bool
+		expBilling   bool
+		expClearShip bool
+		expClearBill bool
+	}{
+		"first_address_becomes_default": {
+			existing:     []model.Address{},
+			input:        AddressInput{UserID: 2, Name: "Mai", Line1: "1 Le Loi", Country: "VN"},
+			expShipping:  true,
+			expBilling:   true,
+			expClearShip: true,
+			expClearBill: true,
+		},
+		"new_default_shipping": {
+			existing:     []model.Address{{ID: 1, UserID: 2, IsDefaultShipping: true, IsDefaultBilling: true}},
+			input:        AddressInput{UserID: 2, Name: "Mai", Line1: "2 Nguyen Hue", Country: "VN", IsDefaultShipping: true},
+			expShipping:  true,
+			expClearShip: true,
+		},
+		"not_default": {
+			existing: []model.Address{{ID: 1, UserID: 2, IsDefaultShipping: true, IsDefaultBilling: true}},
+			input:    AddressInput{UserID: 2, Name: "Mai", Line1: "2 Nguyen Hue", Country: "VN"},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest})
+			var saved model.Address
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("ExistsUserByID", ctx, 2).Return(true, nil)
+			addressRepoMock := new(address.Mock)
+			addressRepoMock.On("GetAddresses", ctx, 2).Return(tc.existing, nil)
+			addressRepoMock.On("ClearDefaultAddresses", ctx, (*sql.Tx)(nil), 2, tc.expClearShip, tc.expClearBill).Return(nil)
+			addressRepoMock.On("CreateAddress", ctx, (*sql.Tx)(nil), mock.AnythingOfType("model.Address")).Return(model.Address{ID: 3}, nil).Run(func(args mock.Arguments) {
+				saved = args.Get(2).(model.Address)
+			})
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Address").Return(addressRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+				require.NoError(t, args.Get(1).(func(*sql.Tx) error)(nil))
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.CreateAddress(ctx, tc.input)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, 3, result.ID)
+			require.Equal(t, tc.expShipping, saved.IsDefaultShipping)
+			require.Equal(t, tc.expBilling, saved.IsDefaultBilling)
+			require.Equal(t, tc.input.Line1, saved.Line1)
+			addressRepoMock.AssertCalled(t, "ClearDefaultAddresses", ctx, (*sql.Tx)(nil), 2, tc.expClearShip, tc.expClearBill)
+		})
+	}
+}
+
+func TestUserService_UpdateAddress(t *testing.T) {
+	tcs := map[string]struct {
+		current      model.Address
+		currentErr   error
+		input        AddressInput
+		expShipping  bool
+		expBilling   bool
+		expClearShip bool
+		expErr       error
+	}{
+		"success_set_default_shipping": {
+			current:      model.Address{ID: 5, UserID: 2},
+			input:        AddressInput{ID: 5, UserID: 2, Line1: "2 Nguyen Hue", Country: "VN", IsDefaultShipping: true},
+			expShipping:  true,
+			expClearShip: true,
+		},
+		"success_default_is_kept": {
+			current:    model.Address{ID: 5, UserID: 2, IsDefaultBilling: true},
+			input:      AddressInput{ID: 5, UserID: 2, Line1: "2 Nguyen Hue", Country: "VN"},
+			expBilling: true,
+		},
+		"error_not_found": {
+			currentErr: sql.ErrNoRows,
+			input:      AddressInput{ID: 5, UserID: 2},
+			expErr:     ErrAddressNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), auth.User{ID: 2, Role: auth.RoleGuest})
+			var saved model.Address
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("ExistsUserByID", ctx, 2).Return(true, nil)
+			addressRepoMock := new(address.Mock)
+			addressRepoMock.On("GetAddress", ctx, 2, 5).Return(tc.current, tc.currentErr)
+			addressRepoMock.On("ClearDefaultAddresses", ctx, (*sql.Tx)(nil), 2, tc.expClearShip, false).Return(nil)
+			addressRepoMock.On("UpdateAddress", ctx, (*sql.Tx)(nil), mock.AnythingOfType("model.Address")).Return(int64(1), nil).Run(func(args mock.Arguments) {
+				saved = args.Get(2).(model.Address)
+			})
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Address").Return(addressRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+				require.NoError(t, args.Get(1).(func(*sql.Tx) error)(nil))
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.UpdateAddress(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				addressRepoMock.AssertNotCalled(t, "UpdateAddress", ctx, (*sql.Tx)(nil), mock.Anything)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.input.Line1, saved.Line1)
+				require.Equal(t, tc.expShipping, saved.IsDefaultShipping)
+				require.Equal(t, tc.expBilling, saved.IsDefaultBilling)
+			}
+		})
+	}
+}
+
+func TestUserService_DeleteAddress(t *testing.T) {
+	tcs := map[string]struct {
+		caller   auth.User
+		affected int64
+		expErr   error
+	}{
+		"success": {
+			caller:   auth.User{ID: 2, Role: auth.RoleGuest},
+			affected: 1,
+		},
+		"error_not_found": {
+			caller:   auth.User{ID: 2, Role: auth.RoleGuest},
+			affected: 0,
+			expErr:   ErrAddressNotFound,
+		},
+		"error_read_permission_only": {
+			caller: auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserRead}},
+			expErr: ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), tc.caller)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("ExistsUserByID", ctx, 2).Return(true, nil)
+			addressRepoMock := new(address.Mock)
+			addressRepoMock.On("DeleteAddress", ctx, 2, 5).Return(tc.affected, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Address").Return(addressRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.DeleteAddress(ctx, 2, 5)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
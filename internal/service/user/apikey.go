@@ -0,0 +1,199 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+const (
+	apiKeyPrefix           = "sk_"
+	apiKeyPrefixLength     = len(apiKeyPrefix) + 8 // the visible part of the key to recognize it in the list
+	apiKeyLastUsedInterval = time.Minute
+)
+
+type CreateAPIKeyInput struct {
+	Name      string
+	Scope     string
+	ExpiresAt null.Time
+}
+
+// APIKey is an API key without its hash, Key is only returned when the key is created
+type APIKey struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	Prefix     string    `json:"prefix"`
+	Scope      string    `json:"scope"`
+	Key        string    `json:"key,omitempty"`
+	LastUsedAt null.Time `json:"last_used_at"`
+	ExpiresAt  null.Time `json:"expires_at"`
+	RevokedAt  null.Time `json:"revoked_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// toAPIKey converts model.APIKey to APIKey
+func toAPIKey(key model.APIKey) APIKey {
+	return APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scope:      key.Scope,
+		LastUsedAt: key.LastUsedAt,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// apiKeyOwner returns the current user, API keys can only be managed by users signed in with their own access token
+func apiKeyOwner(ctx context.Context) (auth.User, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok || caller.IsAPIKey() {
+		return auth.User{}, ErrPermissionDenied
+	}
+	if caller.IsImpersonated() {
+		return auth.User{}, ErrImpersonationNotAllowed
+	}
+	return caller, nil
+}
+
+// CreateAPIKey creates an API key of the current user. Only the hash of the key is stored, so the key is only returned once.
+func (serv impl) CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (APIKey, error) {
+	// 1. Get the current user
+	caller, err := apiKeyOwner(ctx)
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	// 2. Generate the key
+	token, err := generateToken()
+	if err != nil {
+		return APIKey{}, ErrTokeCannotBeGenerated
+	}
+	key := apiKeyPrefix + token
+
+	// 3. Save the hash of the key
+	result, err := serv.repo.APIKey().CreateAPIKey(ctx, model.APIKey{
+		UserID:    caller.ID,
+		Name:      input.Name,
+		Prefix:    key[:apiKeyPrefixLength],
+		KeyHash:   hashToken(key),
+		Scope:     input.Scope,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	created := toAPIKey(result)
+	created.Key = key
+	return created, nil
+}
+
+// GetAPIKeys returns the API keys of the current user including the revoked and expired ones
+func (serv impl) GetAPIKeys(ctx context.Context) ([]APIKey, error) {
+	// 1. Get the current user
+	caller, err := apiKeyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Get the keys
+	keys, err := serv.repo.APIKey().GetAPIKeys(ctx, caller.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]APIKey, len(keys))
+	for i, key := range keys {
+		result[i] = toAPIKey(*key)
+	}
+	return result, nil
+}
+
+// RevokeAPIKey revokes an API key of the current user, users with the api_key:write:any permission can revoke API keys of other users
+func (serv impl) RevokeAPIKey(ctx context.Context, id int) error {
+	// 1. Get the current user
+	caller, err := apiKeyOwner(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 2. Keys of other users are not found for users without the permission
+	key, err := serv.repo.APIKey().GetAPIKey(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAPIKeyNotFound
+	} else if err != nil {
+		return err
+	}
+	if key.UserID != caller.ID && !caller.HasPermission(auth.PermAPIKeyWriteAny) {
+		return ErrAPIKeyNotFound
+	}
+
+	// 3. Revoke the key, revoking a revoked key is a no-op
+	if _, err = serv.repo.APIKey().RevokeAPIKey(ctx, id); err != nil {
+		return err
+	}
+
+	serv.recordSecurityEvent(ctx, model.User{ID: key.UserID}, securityevent.TypeTokenRevoked)
+	return nil
+}
+
+// VerifyAPIKey verifies the API key and returns its owner with the scope of the key
+func (serv impl) VerifyAPIKey(ctx context.Context, key string) (auth.User, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return auth.User{}, ErrInvalidAPIKey
+	}
+
+	// 1. Get the key by its hash
+	apiKey, err := serv.repo.APIKey().GetAPIKeyByHash(ctx, hashToken(key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return auth.User{}, ErrInvalidAPIKey
+	} else if err != nil {
+		return auth.User{}, err
+	}
+
+	// 2. Reject revoked and expired keys
+	if apiKey.RevokedAt.Valid || (apiKey.ExpiresAt.Valid && !apiKey.ExpiresAt.Time.After(time.Now())) {
+		return auth.User{}, ErrInvalidAPIKey
+	}
+
+	// 3. Get the owner, the roles are read every time so a role change applies to the existing keys
+	owner, err := serv.repo.User().GetUser(lookupContext(ctx), apiKey.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return auth.User{}, ErrInvalidAPIKey
+	} else if err != nil {
+		return auth.User{}, err
+	}
+	if !owner.IsActive {
+		return auth.User{}, ErrUserInactive
+	}
+
+	// 4. Load the permissions of the owner
+	permissions, err := serv.repo.Role().GetUserPermissions(ctx, owner.ID)
+	if err != nil {
+		return auth.User{}, err
+	}
+
+	// 5. Track the usage of the key
+	if _, err = serv.repo.APIKey().UpdateLastUsedAt(ctx, apiKey.ID, apiKeyLastUsedInterval); err != nil {
+		return auth.User{}, err
+	}
+
+	return auth.User{
+		ID:             owner.ID,
+		Email:          owner.Email,
+		Role:           owner.Role,
+		OrganizationID: owner.OrganizationID,
+		Permissions:    permissions,
+		Scope:          apiKey.Scope,
+	}, nil
+}
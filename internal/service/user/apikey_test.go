@@ -0,0 +1,239 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/apikey"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestUserService_CreateAPIKey(t *testing.T) {
+	tcs := map[string]struct {
+		ctx    context.Context
+		input  CreateAPIKeyInput
+		expErr error
+	}{
+		"success": {
+			ctx:   auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest}),
+			input: CreateAPIKeyInput{Name: "warehouse", Scope: auth.ScopeWrite, ExpiresAt: null.TimeFrom(time.Now().Add(time.Hour))},
+		},
+		"error_anonymous": {
+			ctx:    context.Background(),
+			input:  CreateAPIKeyInput{Name: "warehouse", Scope: auth.ScopeWrite},
+			expErr: ErrPermissionDenied,
+		},
+		"error_authenticated_by_api_key": {
+			ctx:    auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Scope: auth.ScopeWrite}),
+			input:  CreateAPIKeyInput{Name: "warehouse", Scope: auth.ScopeWrite},
+			expErr: ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			var saved model.APIKey
+			apiKeyRepoMock := new(apikey.Mock)
+			apiKeyRepoMock.On("CreateAPIKey", tc.ctx, mock.AnythingOfType("model.APIKey")).Return(model.APIKey{ID: 1}, nil).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(model.APIKey)
+			})
+			repoMock := new(repository.Mock)
+			repoMock.On("APIKey").Return(apiKeyRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.CreateAPIKey(tc.ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				apiKeyRepoMock.AssertNotCalled(t, "CreateAPIKey", tc.ctx, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, 1, result.ID)
+				require.True(t, strings.HasPrefix(result.Key, "sk_"))
+				require.Equal(t, 1, saved.UserID)
+				require.Equal(t, tc.input.Scope, saved.Scope)
+				require.Equal(t, tc.input.ExpiresAt, saved.ExpiresAt)
+				require.Equal(t, result.Key[:11], saved.Prefix)
+				require.Equal(t, hashToken(result.Key), saved.KeyHash)
+			}
+		})
+	}
+}
+
+func TestUserService_RevokeAPIKey(t *testing.T) {
+	type mockData struct {
+		key    model.APIKey
+		keyErr error
+	}
+	tcs := map[string]struct {
+		caller     auth.User
+		mock       mockData
+		expRevoked bool
+		expErr     error
+	}{
+		"success": {
+			caller:     auth.User{ID: 1, Role: auth.RoleGuest},
+			mock:       mockData{key: model.APIKey{ID: 1, UserID: 1}},
+			expRevoked: true,
+		},
+		"success_revoke_key_of_other_user_with_permission": {
+			caller:     auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: []string{auth.PermAPIKeyWriteAny}},
+			mock:       mockData{key: model.APIKey{ID: 1, UserID: 1}},
+			expRevoked: true,
+		},
+		"error_key_of_other_user": {
+			caller: auth.User{ID: 3, Role: auth.RoleGuest},
+			mock:   mockData{key: model.APIKey{ID: 1, UserID: 1}},
+			expErr: ErrAPIKeyNotFound,
+		},
+		"error_not_found": {
+			caller: auth.User{ID: 1, Role: auth.RoleGuest},
+			mock:   mockData{keyErr: sql.ErrNoRows},
+			expErr: ErrAPIKeyNotFound,
+		},
+		"error_key_of_other_organization_with_permission": {
+			caller: auth.User{ID: 2, Role: auth.RoleAdmin, OrganizationID: 2, Permissions: []string{auth.PermAPIKeyWriteAny}},
+			mock:   mockData{keyErr: sql.ErrNoRows}, // the repository only finds the keys of the users of the organization
+			expErr: ErrAPIKeyNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), tc.caller)
+			apiKeyRepoMock := new(apikey.Mock)
+			apiKeyRepoMock.On("GetAPIKey", ctx, 1).Return(tc.mock.key, tc.mock.keyErr)
+			apiKeyRepoMock.On("RevokeAPIKey", ctx, 1).Return(int64(1), nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", ctx, mock.Anything).Return(model.SecurityEvent{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("APIKey").Return(apiKeyRepoMock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.RevokeAPIKey(ctx, 1)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expRevoked {
+				apiKeyRepoMock.AssertCalled(t, "RevokeAPIKey", ctx, 1)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", ctx, model.SecurityEvent{
+					UserID: null.IntFrom(1), Type: securityevent.TypeTokenRevoked,
+				})
+			} else {
+				apiKeyRepoMock.AssertNotCalled(t, "RevokeAPIKey", ctx, 1)
+				securityEventRepoMock.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUserService_VerifyAPIKey(t *testing.T) {
+	type mockData struct {
+		key    model.APIKey
+		keyErr error
+	}
+	tcs := map[string]struct {
+		key     string
+		mock    mockData
+		expUser auth.User
+		expErr  error
+	}{
+		"success": {
+			key: "sk_key1",
+			mock: mockData{
+				key: model.APIKey{ID: 1, UserID: 1, Scope: auth.ScopeRead, ExpiresAt: null.TimeFrom(time.Now().Add(time.Hour))},
+			},
+			expUser: auth.User{ID: 1, Email: "guest@example.com", Role: auth.RoleGuest, Permissions: []string{auth.PermProductWrite}, Scope: auth.ScopeRead},
+		},
+		"error_invalid_format": {
+			key:    "key2",
+			expErr: ErrInvalidAPIKey,
+		},
+		"error_not_found": {
+			key: "sk_key3",
+			mock: mockData{
+				keyErr: sql.ErrNoRows,
+			},
+			expErr: ErrInvalidAPIKey,
+		},
+		"error_revoked": {
+			key: "sk_key4",
+			mock: mockData{
+				key: model.APIKey{ID: 4, UserID: 1, Scope: auth.ScopeWrite, RevokedAt: null.TimeFrom(time.Now())},
+			},
+			expErr: ErrInvalidAPIKey,
+		},
+		"error_expired": {
+			key: "sk_key5",
+			mock: mockData{
+				key: model.APIKey{ID: 5, UserID: 1, Scope: auth.ScopeWrite, ExpiresAt: null.TimeFrom(time.Now().Add(-time.Minute))},
+			},
+			expErr: ErrInvalidAPIKey,
+		},
+		"error_inactive_owner": {
+			key: "sk_key6",
+			mock: mockData{
+				key: model.APIKey{ID: 6, UserID: 2, Scope: auth.ScopeWrite},
+			},
+			expErr: ErrUserInactive,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			apiKeyRepoMock := new(apikey.Mock)
+			apiKeyRepoMock.On("GetAPIKeyByHash", ctx, hashToken(tc.key)).Return(tc.mock.key, tc.mock.keyErr)
+			apiKeyRepoMock.On("UpdateLastUsedAt", ctx, tc.mock.key.ID, apiKeyLastUsedInterval).Return(int64(1), nil)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", auth.NewUnscopedContext(ctx), 1).Return(model.User{ID: 1, Email: "guest@example.com", Role: auth.RoleGuest, IsActive: true}, nil)
+			userRepoMock.On("GetUser", auth.NewUnscopedContext(ctx), 2).Return(model.User{ID: 2, Email: "inactive@example.com", Role: auth.RoleGuest}, nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("GetUserPermissions", ctx, 1).Return([]string{auth.PermProductWrite}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("APIKey").Return(apiKeyRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.VerifyAPIKey(ctx, tc.key)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				apiKeyRepoMock.AssertNotCalled(t, "UpdateLastUsedAt", ctx, tc.mock.key.ID, apiKeyLastUsedInterval)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expUser, result)
+				apiKeyRepoMock.AssertCalled(t, "UpdateLastUsedAt", ctx, tc.mock.key.ID, apiKeyLastUsedInterval)
+			}
+		})
+	}
+}
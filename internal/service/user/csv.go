@@ -0,0 +1,309 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	netmail "net/mail"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/bcrypt"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+)
+
+// userColumns are the columns of the user CSV files, the password hash is never exported
+var userColumns = []string{"id", "name", "email", "phone", "role", "is_active", "email_verified_at", "created_at", "updated_at"}
+
+const (
+	// maxImportUserRows limits the rows of an import, a larger file has to be split
+	maxImportUserRows = 1000
+
+	// exportUserPageSize is the number of users read from the database at once by the export
+	exportUserPageSize = 500
+
+	// temporaryPasswordLength is the length of the generated passwords of the imported users
+	temporaryPasswordLength = 16
+
+	// temporaryPasswordCost is the bcrypt cost of the generated passwords, they are random so a low cost keeps a large import fast.
+	// The hash is upgraded to the current cost at the first login, or replaced when an invited user sets a password.
+	temporaryPasswordCost = bcrypt.MinCost
+
+	// inviteExpireTime is longer than passwordResetTokenExpireTime, the invited users may not read the email at once
+	inviteExpireTime = 7 * 24 * time.Hour
+)
+
+func isValidUserFieldName(fieldName string) bool {
+	for _, column := range userColumns {
+		if strings.EqualFold(strings.TrimSpace(fieldName), column) {
+			return true
+		}
+	}
+	return false
+}
+
+func csvUserHeaders(fields []string) map[string]int {
+	headers := make(map[string]int)
+	for pos, field := range fields {
+		headers[strings.ToLower(strings.TrimSpace(field))] = pos // use lowercase field name as key
+	}
+	return headers
+}
+
+// ImportedUser is a user created by the import
+type ImportedUser struct {
+	Row   int    `json:"row"`
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+	// TemporaryPassword is only returned once, it is empty if an invite email is sent instead
+	TemporaryPassword string `json:"temporary_password,omitempty"`
+}
+
+// ImportUserError is a row which is not imported
+type ImportUserError struct {
+	Row   int    `json:"row"`
+	Email string `json:"email"`
+	Error string `json:"error"`
+}
+
+// ImportUsersResult is the report of an import, the row numbers count the header as row 1
+type ImportUsersResult struct {
+	Created []ImportedUser    `json:"created"`
+	Failed  []ImportUserError `json:"failed"`
+}
+
+// ImportUsersCSV creates a user for each row of the CSV file. The rows are imported one by one, invalid rows and rows with
+// an email which is already registered or used by an earlier row are reported and skipped.
+// Each user gets a generated password: if sendInvites is true the user is invited to set a password by email,
+// otherwise the temporary password is returned in the result.
+func (serv impl) ImportUsersCSV(ctx context.Context, csvFile io.Reader, sendInvites bool) (ImportUsersResult, error) {
+	// 1. Only users who can manage users can import users, roles other than GUEST are only given by users who can manage roles
+	if caller, ok := auth.FromContext(ctx); !ok || !caller.HasPermission(auth.PermUserWrite) {
+		return ImportUsersResult{}, ErrPermissionDenied
+	}
+
+	// 2. Read and validate the header row, name and email are required
+	reader := csv.NewReader(csvFile)
+	reader.FieldsPerRecord = -1
+	firstRow, err := reader.Read()
+	if err != nil {
+		return ImportUsersResult{}, ErrInvalidCSVHeader
+	}
+	for _, field := range firstRow {
+		if !isValidUserFieldName(field) {
+			return ImportUsersResult{}, ErrInvalidCSVHeader
+		}
+	}
+	headers := csvUserHeaders(firstRow)
+	if _, ok := headers["name"]; !ok {
+		return ImportUsersResult{}, ErrInvalidCSVHeader
+	}
+	if _, ok := headers["email"]; !ok {
+		return ImportUsersResult{}, ErrInvalidCSVHeader
+	}
+
+	// 3. Read all rows before creating users, so a file which is too large creates nothing
+	records, err := reader.ReadAll()
+	if err != nil {
+		return ImportUsersResult{}, ErrInvalidCSVFile
+	}
+	if len(records) > maxImportUserRows {
+		return ImportUsersResult{}, ErrTooManyCSVRows
+	}
+
+	result := ImportUsersResult{Created: []ImportedUser{}, Failed: []ImportUserError{}}
+	seenEmails := map[string]bool{}
+	validRoles := map[string]bool{}
+	for i, record := range records {
+		row := i + 2 // skip header row
+		if len(record) != len(firstRow) {
+			result.Failed = append(result.Failed, ImportUserError{Row: row, Error: "wrong number of fields"})
+			continue
+		}
+
+		// Received row values
+		rowMap := map[string]string{}
+		for fieldName, pos := range headers {
+			rowMap[fieldName] = strings.TrimSpace(record[pos])
+		}
+		failed := func(reason string) {
+			result.Failed = append(result.Failed, ImportUserError{Row: row, Email: rowMap["email"], Error: reason})
+		}
+
+		// 4. Validate the row
+		input, reason := serv.validateImportRow(ctx, rowMap, validRoles)
+		if reason != "" {
+			failed(reason)
+			continue
+		}
+		if seenEmails[strings.ToLower(input.Email)] {
+			failed("email is duplicated in the file")
+			continue
+		}
+		seenEmails[strings.ToLower(input.Email)] = true
+
+		// 5. Create the user with a generated password
+		token, err := generateToken()
+		if err != nil {
+			return result, ErrTokeCannotBeGenerated
+		}
+		input.Password = token[:temporaryPasswordLength]
+		created, err := serv.insertUser(ctx, input, temporaryPasswordCost)
+		if err == ErrEmailExisted {
+			failed(err.Error())
+			continue
+		} else if err != nil {
+			log.Printf("Error when import user at row %d: %v\n", row, err)
+			failed("user cannot be created")
+			continue
+		}
+
+		// 6. Invite the user or return the password. The invite verifies the email when it is redeemed,
+		// so only the users with a temporary password get the verification email
+		imported := ImportedUser{Row: row, ID: created.ID, Email: created.Email}
+		if sendInvites {
+			if err := serv.sendInviteEmail(ctx, created); err != nil {
+				log.Printf("Error when send invite email to user %d: %v\n", created.ID, err)
+			}
+		} else {
+			imported.TemporaryPassword = input.Password
+			if err := serv.sendVerificationEmail(ctx, created); err != nil {
+				log.Printf("Error when send verification email to user %d: %v\n", created.ID, err)
+			}
+		}
+		result.Created = append(result.Created, imported)
+	}
+
+	return result, nil
+}
+
+// validateImportRow converts a row to InputUser, the reason is not empty if the row is invalid
+func (serv impl) validateImportRow(ctx context.Context, rowMap map[string]string, validRoles map[string]bool) (InputUser, string) {
+	input := InputUser{
+		Name:     rowMap["name"],
+		Email:    rowMap["email"],
+		Phone:    rowMap["phone"],
+		Role:     rowMap["role"],
+		IsActive: true,
+	}
+	if input.Name == "" {
+		return InputUser{}, "name cannot be blank"
+	}
+	if address, err := netmail.ParseAddress(input.Email); err != nil || address.Address != input.Email {
+		return InputUser{}, "email is invalid"
+	}
+	if rowMap["is_active"] != "" {
+		isActive, err := strconv.ParseBool(rowMap["is_active"])
+		if err != nil {
+			return InputUser{}, "is_active is invalid"
+		}
+		input.IsActive = isActive
+	}
+
+	// The role is checked once per name
+	if input.Role == "" {
+		input.Role = auth.RoleGuest
+	}
+	if input.Role != auth.RoleGuest && !canManageRoles(ctx) {
+		return InputUser{}, "role cannot be given without role:write"
+	}
+	if _, checked := validRoles[input.Role]; !checked {
+		existed, err := serv.repo.Role().ExistsRoleByName(ctx, input.Role)
+		if err != nil {
+			log.Printf("Error when check role %s: %v\n", input.Role, err)
+		}
+		validRoles[input.Role] = existed
+	}
+	if !validRoles[input.Role] {
+		return InputUser{}, "role is not found"
+	}
+
+	return input, ""
+}
+
+// sendInviteEmail sends a link to set the password of an imported user, the link is a password reset token
+func (serv impl) sendInviteEmail(ctx context.Context, user model.User) error {
+	// 1. Generate invite token and store its hash
+	inviteToken, err := generateToken()
+	if err != nil {
+		return ErrTokeCannotBeGenerated
+	}
+	if _, err = serv.repo.Token().CreatePasswordResetToken(ctx, model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(inviteToken),
+		ExpiresAt: time.Now().Add(inviteExpireTime),
+	}); err != nil {
+		return fmt.Errorf("error when create invite token: %v", err)
+	}
+
+	// 2. Send the invite link
+	inviteURL := os.Getenv("APP_URL") + "/reset-password?token=" + inviteToken
+	if err = sendEmail(mail.EmailInput{
+		To:      []string{user.Email},
+		Subject: "You are invited",
+		Message: fmt.Sprintf("An account has been created for you. Click <a href=\"%s\">here</a> to set your password. The link expires in %d days.", inviteURL, int(inviteExpireTime.Hours()/24)),
+	}); err != nil {
+		return fmt.Errorf("failed sending email: %v", err)
+	}
+
+	return nil
+}
+
+// ExportUsersCSV returns a CSV file of all users which match the filter of input, the pagination of input is ignored
+func (serv impl) ExportUsersCSV(ctx context.Context, input InputGetUser) ([]byte, error) {
+	// 1. The users are read page by page in a stable order, the repository breaks the ties of the sort by id
+	if input.Sort == (SortArgs{}) {
+		input.Sort.CreatedAt = "asc"
+	}
+	input.Pagination = Pagination{Page: 1, Limit: exportUserPageSize}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(userColumns); err != nil {
+		return nil, err
+	}
+
+	// 2. Write the users without the password hash
+	for {
+		users, totalCount, err := serv.repo.User().GetUsers(ctx, toFilter(input))
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			emailVerifiedAt := ""
+			if u.EmailVerifiedAt.Valid {
+				emailVerifiedAt = u.EmailVerifiedAt.Time.Format(time.RFC3339)
+			}
+			if err := writer.Write([]string{
+				strconv.Itoa(u.ID),
+				u.Name,
+				u.Email,
+				u.Phone,
+				u.Role,
+				strconv.FormatBool(u.IsActive),
+				emailVerifiedAt,
+				u.CreatedAt.Format(time.RFC3339),
+				u.UpdatedAt.Format(time.RFC3339),
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if len(users) == 0 || int64(input.Pagination.Page*input.Pagination.Limit) >= totalCount {
+			break
+		}
+		input.Pagination.Page++
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
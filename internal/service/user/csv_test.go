@@ -0,0 +1,222 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+)
+
+func TestUserService_ImportUsersCSV(t *testing.T) {
+	tcs := map[string]struct {
+		caller       auth.User
+		csv          string
+		sendInvites  bool
+		expCreated   []string
+		expFailed    map[int]string
+		expPasswords bool
+		expEmails    int
+		expErr       error
+	}{
+		"success_with_temporary_passwords": {
+			caller:       auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite}},
+			csv:          "name,email,phone,role,is_active\nMai,mai@example.com,0987654321,GUEST,true\nLan,lan@example.com,0987654322,,false\n",
+			expCreated:   []string{"mai@example.com", "lan@example.com"},
+			expFailed:    map[int]string{},
+			expPasswords: true,
+			expEmails:    2,
+		},
+		"success_with_invites": {
+			caller:      auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite}},
+			csv:         "Name,Email\nMai,mai@example.com\n",
+			sendInvites: true,
+			expCreated:  []string{"mai@example.com"},
+			expFailed:   map[int]string{},
+			expEmails:   1,
+		},
+		"invalid_rows_are_skipped": {
+			caller: auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite, auth.PermRoleWrite}},
+			csv: "name,email,role,is_active\n" +
+				"Mai,mai@example.com,GUEST,true\n" +
+				",blank@example.com,GUEST,true\n" +
+				"Bad,not-an-email,GUEST,true\n" +
+				"Dup,MAI@example.com,GUEST,true\n" +
+				"Old,existed@example.com,GUEST,true\n" +
+				"Role,role@example.com,WAREHOUSE,true\n" +
+				"Active,active@example.com,GUEST,maybe\n" +
+				"Short,short@example.com\n",
+			expCreated: []string{"mai@example.com"},
+			expFailed: map[int]string{
+				3: "name cannot be blank",
+				4: "email is invalid",
+				5: "email is duplicated in the file",
+				6: "email existed",
+				7: "role is not found",
+				8: "is_active is invalid",
+				9: "wrong number of fields",
+			},
+			expPasswords: true,
+			expEmails:    1,
+		},
+		"roles_need_role_write": {
+			caller:     auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite}},
+			csv:        "name,email,role\nMai,mai@example.com,GUEST\nBoss,boss@example.com,ADMIN\n",
+			expCreated: []string{"mai@example.com"},
+			expFailed: map[int]string{
+				3: "role cannot be given without role:write",
+			},
+			expPasswords: true,
+			expEmails:    1,
+		},
+		"error_unknown_column": {
+			caller: auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite}},
+			csv:    "name,email,password\nMai,mai@example.com,secret\n",
+			expErr: ErrInvalidCSVHeader,
+		},
+		"error_missing_email_column": {
+			caller: auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite}},
+			csv:    "name,phone\nMai,0987654321\n",
+			expErr: ErrInvalidCSVHeader,
+		},
+		"error_too_many_rows": {
+			caller: auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite}},
+			csv:    "name,email\n" + strings.Repeat("Mai,mai@example.com\n", maxImportUserRows+1),
+			expErr: ErrTooManyCSVRows,
+		},
+		"error_permission_denied": {
+			caller: auth.User{ID: 1, Role: auth.RoleGuest},
+			csv:    "name,email\nMai,mai@example.com\n",
+			expErr: ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("ACCESS_TOKEN_KEY", "secret")
+			ctx := auth.NewContext(context.Background(), tc.caller)
+			var sent []mail.EmailInput
+			sendEmail = func(input mail.EmailInput) error {
+				sent = append(sent, input)
+				return nil
+			}
+			defer func() { sendEmail = mail.SendEmail }()
+
+			var saved []model.User
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("ExistsUserByEmail", ctx, "existed@example.com").Return(true, nil)
+			userRepoMock.On("ExistsUserByEmail", ctx, mock.AnythingOfType("string")).Return(false, nil)
+			userRepoMock.On("CreateUser", ctx, mock.Anything, mock.AnythingOfType("model.User")).Return(model.User{}, nil).Run(func(args mock.Arguments) {
+				saved = append(saved, args.Get(2).(model.User))
+			})
+			userRepoMock.On("UpdateVerificationSentAt", ctx, mock.AnythingOfType("int"), verificationResendInterval).Return(int64(1), nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("ExistsRoleByName", ctx, "WAREHOUSE").Return(false, nil)
+			roleRepoMock.On("ExistsRoleByName", ctx, auth.RoleGuest).Return(true, nil)
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("CreatePasswordResetToken", ctx, mock.AnythingOfType("model.PasswordResetToken")).Return(model.PasswordResetToken{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("Token").Return(tokenRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(func(*sql.Tx) error)(nil)
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.ImportUsersCSV(ctx, strings.NewReader(tc.csv), tc.sendInvites)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				userRepoMock.AssertNotCalled(t, "CreateUser", ctx, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Created, len(tc.expCreated))
+			for i, email := range tc.expCreated {
+				require.Equal(t, email, saved[i].Email)
+				require.NotEmpty(t, saved[i].Password)
+				// The generated passwords are hashed with a low cost, it is upgraded at the first login
+				cost, err := bcrypt.Cost([]byte(saved[i].Password))
+				require.NoError(t, err)
+				require.Equal(t, temporaryPasswordCost, cost)
+				if tc.expPasswords {
+					require.Len(t, result.Created[i].TemporaryPassword, temporaryPasswordLength)
+					require.NotContains(t, saved[i].Password, result.Created[i].TemporaryPassword)
+				} else {
+					require.Empty(t, result.Created[i].TemporaryPassword)
+				}
+			}
+			failed := map[int]string{}
+			for _, f := range result.Failed {
+				failed[f.Row] = f.Error
+			}
+			require.Equal(t, tc.expFailed, failed)
+			require.Len(t, sent, tc.expEmails)
+		})
+	}
+}
+
+func TestUserService_ExportUsersCSV(t *testing.T) {
+	createdAt := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		input     InputGetUser
+		expFilter user.Filter
+		users     model.UserSlice
+		expCSV    string
+	}{
+		"success": {
+			input: InputGetUser{Role: auth.RoleGuest, Pagination: Pagination{Page: 3, Limit: 10}},
+			expFilter: user.Filter{
+				Role:       auth.RoleGuest,
+				Sort:       user.SortParams{CreatedAt: "asc"},
+				Pagination: user.Pagination{Page: 1, Limit: exportUserPageSize},
+			},
+			users: model.UserSlice{
+				{ID: 1, Name: "Mai, Nguyen", Email: "mai@example.com", Password: "hash", Phone: "0987654321", Role: "GUEST", IsActive: true, EmailVerifiedAt: null.TimeFrom(createdAt), CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: 2, Name: "Lan", Email: "lan@example.com", Password: "hash", Role: "GUEST", CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+			expCSV: "id,name,email,phone,role,is_active,email_verified_at,created_at,updated_at\n" +
+				"1,\"Mai, Nguyen\",mai@example.com,0987654321,GUEST,true,2022-08-01T00:00:00Z,2022-08-01T00:00:00Z,2022-08-01T00:00:00Z\n" +
+				"2,Lan,lan@example.com,,GUEST,false,,2022-08-01T00:00:00Z,2022-08-01T00:00:00Z\n",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUsers", ctx, tc.expFilter).Return(tc.users, int64(len(tc.users)), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.ExportUsersCSV(ctx, tc.input)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.expCSV, string(result))
+			require.NotContains(t, string(result), "hash")
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package user
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/datarequest"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+// DataRequest is a logged export or erasure of the personal data of a user
+type DataRequest struct {
+	ID        int       `json:"id"`
+	ActorID   int       `json:"actor_id"`
+	SubjectID int       `json:"subject_id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserData is the machine-readable archive of everything tied to a user
+type UserData struct {
+	ExportedAt     time.Time          `json:"exported_at"`
+	Profile        Profile            `json:"profile"`
+	Addresses      []Address          `json:"addresses"`
+	Orders         []UserDataOrder    `json:"orders"`
+	Products       []UserDataProduct  `json:"products"`
+	Identities     []UserDataIdentity `json:"identities"`
+	APIKeys        []APIKey           `json:"api_keys"`
+	Impersonations []Impersonation    `json:"impersonations"`
+	DataRequests   []DataRequest      `json:"data_requests"`
+	SecurityEvents []SecurityEvent    `json:"security_events"`
+}
+
+type UserDataOrder struct {
+	ID          int                    `json:"id"`
+	OrderNumber string                 `json:"order_number"`
+	OrderDate   time.Time              `json:"order_date"`
+	Status      string                 `json:"status"`
+	Note        string                 `json:"note"`
+	Items       []UserDataOrderItem    `json:"items"`
+	Addresses   []UserDataOrderAddress `json:"addresses"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+type UserDataOrderItem struct {
+	ProductID    int     `json:"product_id"`
+	ProductName  string  `json:"product_name"`
+	ProductPrice float64 `json:"product_price"`
+	Quantity     int     `json:"quantity"`
+	Discount     float64 `json:"discount"`
+	Note         string  `json:"note"`
+}
+
+type UserDataOrderAddress struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Phone      string `json:"phone"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+type UserDataProduct struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	Price     float64   `json:"price"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserDataIdentity is an OpenID Connect identity linked to the user
+type UserDataIdentity struct {
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// toDataRequest converts model.DataRequest to DataRequest
+func toDataRequest(request model.DataRequest) DataRequest {
+	return DataRequest{
+		ID:        request.ID,
+		ActorID:   request.ActorID,
+		SubjectID: request.SubjectID,
+		Type:      request.Type,
+		CreatedAt: request.CreatedAt,
+	}
+}
+
+// toUserData converts the user loaded with its relationships to UserData, secrets such as password and key hashes are left out
+func toUserData(user model.User) UserData {
+	data := UserData{
+		ExportedAt:     time.Now(),
+		Profile:        toProfile(user),
+		Addresses:      []Address{},
+		Orders:         []UserDataOrder{},
+		Products:       []UserDataProduct{},
+		Identities:     []UserDataIdentity{},
+		APIKeys:        []APIKey{},
+		Impersonations: []Impersonation{},
+		DataRequests:   []DataRequest{},
+		SecurityEvents: []SecurityEvent{},
+	}
+	if user.R == nil {
+		return data
+	}
+
+	for _, a := range user.R.Addresses {
+		data.Addresses = append(data.Addresses, toAddress(*a))
+	}
+	for _, o := range user.R.Orders {
+		order := UserDataOrder{
+			ID:          o.ID,
+			OrderNumber: o.OrderNumber,
+			OrderDate:   o.OrderDate,
+			Status:      o.Status,
+			Note:        o.Note,
+			Items:       []UserDataOrderItem{},
+			Addresses:   []UserDataOrderAddress{},
+			CreatedAt:   o.CreatedAt,
+		}
+		if o.R != nil {
+			for _, i := range o.R.OrderItems {
+				order.Items = append(order.Items, UserDataOrderItem{
+					ProductID:    i.ProductID,
+					ProductName:  i.ProductName,
+					ProductPrice: i.ProductPrice,
+					Quantity:     i.Quantity,
+					Discount:     i.Discount,
+					Note:         i.Note,
+				})
+			}
+			for _, a := range o.R.OrderAddresses {
+				order.Addresses = append(order.Addresses, UserDataOrderAddress{
+					Type:       a.Type,
+					Name:       a.Name,
+					Phone:      a.Phone,
+					Line1:      a.Line1,
+					Line2:      a.Line2,
+					City:       a.City,
+					State:      a.State,
+					PostalCode: a.PostalCode,
+					Country:    a.Country,
+				})
+			}
+		}
+		data.Orders = append(data.Orders, order)
+	}
+	for _, p := range user.R.Products {
+		data.Products = append(data.Products, UserDataProduct{ID: p.ID, Title: p.Title, Price: p.Price, CreatedAt: p.CreatedAt})
+	}
+	for _, i := range user.R.Identities {
+		data.Identities = append(data.Identities, UserDataIdentity{Issuer: i.Issuer, Subject: i.Subject, Email: i.Email, CreatedAt: i.CreatedAt})
+	}
+	for _, k := range user.R.APIKeys {
+		data.APIKeys = append(data.APIKeys, toAPIKey(*k))
+	}
+	for _, i := range user.R.SubjectImpersonations {
+		data.Impersonations = append(data.Impersonations, toImpersonation(*i))
+	}
+	for _, r := range user.R.SubjectDataRequests {
+		data.DataRequests = append(data.DataRequests, toDataRequest(*r))
+	}
+	return data
+}
+
+// WriteZip writes the archive as a zip file with one JSON file per section
+func (data UserData) WriteZip(w io.Writer) error {
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{"profile.json", data.Profile},
+		{"addresses.json", data.Addresses},
+		{"orders.json", data.Orders},
+		{"products.json", data.Products},
+		{"identities.json", data.Identities},
+		{"api_keys.json", data.APIKeys},
+		{"impersonations.json", data.Impersonations},
+		{"data_requests.json", data.DataRequests},
+		{"security_events.json", data.SecurityEvents},
+	}
+
+	zipWriter := zip.NewWriter(w)
+	for _, f := range files {
+		fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: data.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(fileWriter)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(f.content); err != nil {
+			return err
+		}
+	}
+	return zipWriter.Close()
+}
+
+// createDataRequest logs a personal data request of the current user
+func (serv impl) createDataRequest(ctx context.Context, tx *sql.Tx, actorID int, subjectID int, requestType string) (model.DataRequest, error) {
+	request, err := serv.repo.DataRequest().CreateDataRequest(ctx, tx, model.DataRequest{
+		ActorID:   actorID,
+		SubjectID: subjectID,
+		Type:      requestType,
+	})
+	if err != nil {
+		return model.DataRequest{}, err
+	}
+	log.Printf("Personal data request: %s, actor %d, subject %d\n", requestType, actorID, subjectID)
+	return request, nil
+}
+
+// ExportUserData returns everything tied to the user and logs the export.
+// Users can export their own data, users with the "user:read" permission can export the data of the users of the organization.
+func (serv impl) ExportUserData(ctx context.Context, userID int) (UserData, error) {
+	// 1. Check the current user, the data of the user cannot be exported while an admin impersonates the user
+	caller, ok := auth.FromContext(ctx)
+	if !ok || (caller.ID != userID && !caller.HasPermission(auth.PermUserRead)) {
+		return UserData{}, ErrPermissionDenied
+	}
+	if err := denyImpersonation(ctx); err != nil {
+		return UserData{}, err
+	}
+
+	// 2. Get the user with everything tied to it, deleted users can still request their data
+	user, err := serv.repo.User().GetUserData(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return UserData{}, ErrUserNotFound
+	} else if err != nil {
+		return UserData{}, err
+	}
+	events, err := serv.repo.SecurityEvent().GetUserSecurityEvents(ctx, userID)
+	if err != nil {
+		return UserData{}, err
+	}
+
+	// 3. Log the export, it is part of the archive
+	var request model.DataRequest
+	if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		request, err = serv.createDataRequest(ctx, tx, caller.ID, userID, datarequest.TypeExport)
+		return err
+	}); err != nil {
+		return UserData{}, err
+	}
+
+	data := toUserData(user)
+	data.DataRequests = append(data.DataRequests, toDataRequest(request))
+	for _, e := range events {
+		data.SecurityEvents = append(data.SecurityEvents, toSecurityEvent(e))
+	}
+	return data, nil
+}
+
+// EraseUser anonymizes the personal data of the user and logs the erasure.
+// The user is kept as a deleted user, its orders and revenue are kept for accounting.
+func (serv impl) EraseUser(ctx context.Context, userID int) error {
+	// 1. Check the current user, users cannot erase themselves
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return ErrPermissionDenied
+	}
+	if err := denyImpersonation(ctx); err != nil {
+		return err
+	}
+	if caller.ID == userID {
+		return ErrUserCannotBeErased
+	}
+
+	// 2. Erase the user, anonymize its orders and security events and log the erasure at once
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		affected, err := serv.repo.User().EraseUser(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		// The user does not exist or was already erased
+		if affected < 1 {
+			return ErrUserNotFound
+		}
+
+		if err = serv.repo.Order().AnonymizeOrders(ctx, tx, userID); err != nil {
+			return err
+		}
+		if err = serv.repo.SecurityEvent().AnonymizeSecurityEvents(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		_, err = serv.createDataRequest(ctx, tx, caller.ID, userID, datarequest.TypeErasure)
+		return err
+	})
+}
+
+// GetDataRequests returns the personal data requests of the organization, the latest first
+func (serv impl) GetDataRequests(ctx context.Context) ([]DataRequest, error) {
+	requests, err := serv.repo.DataRequest().GetDataRequests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]DataRequest, len(requests))
+	for i, request := range requests {
+		result[i] = toDataRequest(request)
+	}
+	return result, nil
+}
@@ -0,0 +1,224 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/datarequest"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/order"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestUserService_ExportUserData(t *testing.T) {
+	userData := model.User{ID: 10, Name: "Mai", Email: "mai@example.com", Password: "hash"}
+	userData.R = userData.R.NewStruct()
+	orderData := &model.Order{ID: 1, OrderNumber: "ORDER_1", Note: "call before delivery"}
+	orderData.R = orderData.R.NewStruct()
+	orderData.R.OrderItems = model.OrderItemSlice{{ProductID: 2, ProductName: "Product 2", Quantity: 3}}
+	orderData.R.OrderAddresses = model.OrderAddressSlice{{Type: order.AddressTypeShipping, Name: "Mai", City: "Ha Noi", Country: "VN"}}
+	userData.R.Orders = model.OrderSlice{orderData}
+	userData.R.Addresses = model.AddressSlice{{ID: 5, UserID: 10, City: "Ha Noi"}}
+	userData.R.APIKeys = model.APIKeySlice{{ID: 7, Name: "report", KeyHash: "hash"}}
+	events := []model.SecurityEvent{{ID: 4, UserID: null.IntFrom(10), Type: securityevent.TypeLoginSucceeded, Email: "mai@example.com", IPAddress: "192.0.2.1"}}
+
+	tcs := map[string]struct {
+		ctx        context.Context
+		givenID    int
+		mockErr    error
+		expErr     error
+		expOrders  int
+		expRequest int
+	}{
+		"success_self": {
+			ctx:        auth.NewContext(context.Background(), auth.User{ID: 10, Role: auth.RoleGuest}),
+			givenID:    10,
+			expOrders:  1,
+			expRequest: 1,
+		},
+		"success_admin": {
+			ctx:        auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserRead}}),
+			givenID:    10,
+			expOrders:  1,
+			expRequest: 1,
+		},
+		"error_other_user": {
+			ctx:     auth.NewContext(context.Background(), auth.User{ID: 11, Role: auth.RoleGuest}),
+			givenID: 10,
+			expErr:  ErrPermissionDenied,
+		},
+		"error_impersonated": {
+			ctx:     auth.NewContext(context.Background(), auth.User{ID: 10, Role: auth.RoleGuest, ActorID: 1}),
+			givenID: 10,
+			expErr:  ErrImpersonationNotAllowed,
+		},
+		"error_user_not_found": {
+			ctx:     auth.NewContext(context.Background(), auth.User{ID: 10, Role: auth.RoleGuest}),
+			givenID: 10,
+			mockErr: sql.ErrNoRows,
+			expErr:  ErrUserNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			caller, _ := auth.FromContext(tc.ctx)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUserData", tc.ctx, tc.givenID).Return(userData, tc.mockErr)
+			dataRequestRepoMock := new(datarequest.Mock)
+			dataRequestRepoMock.On("CreateDataRequest", tc.ctx, (*sql.Tx)(nil), model.DataRequest{
+				ActorID: caller.ID, SubjectID: tc.givenID, Type: datarequest.TypeExport,
+			}).Return(model.DataRequest{ID: 3, ActorID: caller.ID, SubjectID: tc.givenID, Type: datarequest.TypeExport}, nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("GetUserSecurityEvents", tc.ctx, tc.givenID).Return(events, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("DataRequest").Return(dataRequestRepoMock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+			repoMock.On("Tx", tc.ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+				require.NoError(t, args.Get(1).(func(*sql.Tx) error)(nil))
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.ExportUserData(tc.ctx, tc.givenID)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				dataRequestRepoMock.AssertNotCalled(t, "CreateDataRequest", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "mai@example.com", result.Profile.Email)
+			require.Len(t, result.Orders, tc.expOrders)
+			require.Equal(t, "call before delivery", result.Orders[0].Note)
+			require.Len(t, result.Orders[0].Items, 1)
+			require.Len(t, result.Orders[0].Addresses, 1)
+			require.Len(t, result.Addresses, 1)
+			require.Len(t, result.APIKeys, 1)
+			require.Empty(t, result.APIKeys[0].Key)
+			require.Len(t, result.DataRequests, tc.expRequest)
+			require.Equal(t, []SecurityEvent{toSecurityEvent(events[0])}, result.SecurityEvents)
+		})
+	}
+}
+
+func TestUserData_WriteZip(t *testing.T) {
+	// GIVEN
+	data := toUserData(model.User{ID: 10, Name: "Mai", Email: "mai@example.com", Password: "hash"})
+
+	// WHEN
+	var buf bytes.Buffer
+	err := data.WriteZip(&buf)
+
+	// THEN
+	require.NoError(t, err)
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	names := make([]string, 0, len(reader.File))
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{"profile.json", "addresses.json", "orders.json", "products.json", "identities.json", "api_keys.json", "impersonations.json", "data_requests.json", "security_events.json"}, names)
+	require.NotContains(t, buf.String(), "hash")
+}
+
+func TestUserService_EraseUser(t *testing.T) {
+	admin := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite}})
+	tcs := map[string]struct {
+		ctx         context.Context
+		givenID     int
+		mockRowsAff int64
+		mockErr     error
+		expErr      error
+	}{
+		"success": {
+			ctx:         admin,
+			givenID:     10,
+			mockRowsAff: 1,
+		},
+		"error_user_not_found_or_erased": {
+			ctx:         admin,
+			givenID:     10,
+			mockRowsAff: 0,
+			expErr:      ErrUserNotFound,
+		},
+		"error_self": {
+			ctx:     admin,
+			givenID: 1,
+			expErr:  ErrUserCannotBeErased,
+		},
+		"error_impersonated": {
+			ctx:     auth.NewContext(context.Background(), auth.User{ID: 11, Role: auth.RoleGuest, ActorID: 1}),
+			givenID: 10,
+			expErr:  ErrImpersonationNotAllowed,
+		},
+		"error_repo": {
+			ctx:     admin,
+			givenID: 10,
+			mockErr: errors.New("database error"),
+			expErr:  errors.New("database error"),
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("EraseUser", tc.ctx, (*sql.Tx)(nil), tc.givenID).Return(tc.mockRowsAff, tc.mockErr)
+			orderRepoMock := new(order.Mock)
+			orderRepoMock.On("AnonymizeOrders", tc.ctx, (*sql.Tx)(nil), tc.givenID).Return(nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("AnonymizeSecurityEvents", tc.ctx, (*sql.Tx)(nil), tc.givenID).Return(nil)
+			dataRequestRepoMock := new(datarequest.Mock)
+			dataRequestRepoMock.On("CreateDataRequest", tc.ctx, (*sql.Tx)(nil), model.DataRequest{
+				ActorID: 1, SubjectID: tc.givenID, Type: datarequest.TypeErasure,
+			}).Return(model.DataRequest{ID: 3}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Order").Return(orderRepoMock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+			repoMock.On("DataRequest").Return(dataRequestRepoMock)
+			repoMock.On("Tx", tc.ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(tc.expErr).Run(func(args mock.Arguments) {
+				err := args.Get(1).(func(*sql.Tx) error)(nil)
+				if tc.expErr != nil {
+					require.EqualError(t, err, tc.expErr.Error())
+				} else {
+					require.NoError(t, err)
+				}
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.EraseUser(tc.ctx, tc.givenID)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				orderRepoMock.AssertNotCalled(t, "AnonymizeOrders", mock.Anything, mock.Anything, mock.Anything)
+				securityEventRepoMock.AssertNotCalled(t, "AnonymizeSecurityEvents", mock.Anything, mock.Anything, mock.Anything)
+				dataRequestRepoMock.AssertNotCalled(t, "CreateDataRequest", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			orderRepoMock.AssertExpectations(t)
+			securityEventRepoMock.AssertExpectations(t)
+			dataRequestRepoMock.AssertExpectations(t)
+		})
+	}
+}
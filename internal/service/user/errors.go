@@ -5,11 +5,40 @@ import (
 )
 
 var (
-	ErrEmailExisted           = errors.New("email existed")
-	ErrUserIDExisted          = errors.New("user id existed")
-	ErrUserNotFound           = errors.New("user is not found")
-	ErrPasswordCannotBeHashed = errors.New("password cannot be hashed")
-	ErrPasswordIncorrect      = errors.New("password is incorrect")
-	ErrTokeCannotBeGenerated  = errors.New("token cannot be generated")
-	ErrEmailNotExist          = errors.New("email does not exist")
+	ErrEmailExisted             = errors.New("email existed")
+	ErrUserIDExisted            = errors.New("user id existed")
+	ErrUserNotFound             = errors.New("user is not found")
+	ErrPasswordCannotBeHashed   = errors.New("password cannot be hashed")
+	ErrTokeCannotBeGenerated    = errors.New("token cannot be generated")
+	ErrInvalidCredentials       = errors.New("email or password is incorrect")
+	ErrTooManyLoginAttempts     = errors.New("too many failed login attempts")
+	ErrTwoFactorEnabled         = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled     = errors.New("two-factor authentication is not enrolled")
+	ErrInvalidTwoFactorCode     = errors.New("two-factor code is invalid")
+	ErrInvalidToken             = errors.New("token is invalid")
+	ErrPermissionDenied         = errors.New("permission denied")
+	ErrEmailNotVerified         = errors.New("email is not verified")
+	ErrUserInactive             = errors.New("user is inactive")
+	ErrTooManyRequests          = errors.New("too many requests")
+	ErrInvalidAPIKey            = errors.New("API key is invalid")
+	ErrAPIKeyNotFound           = errors.New("API key is not found")
+	ErrRoleNotFound             = errors.New("role is not found")
+	ErrRoleExisted              = errors.New("role existed")
+	ErrRoleInUse                = errors.New("role is the primary role of users")
+	ErrBuiltInRole              = errors.New("built-in role cannot be renamed or deleted")
+	ErrInvalidPermission        = errors.New("permission is invalid")
+	ErrIncorrectPassword        = errors.New("current password is incorrect")
+	ErrPasswordReused           = errors.New("password was used recently")
+	ErrOIDCNotConfigured        = errors.New("OpenID Connect login is not configured")
+	ErrInvalidOIDCLogin         = errors.New("OpenID Connect login is invalid")
+	ErrOrganizationNotFound     = errors.New("organization is not found")
+	ErrOrganizationExisted      = errors.New("organization existed")
+	ErrImpersonationNotAllowed  = errors.New("action is not allowed while impersonating")
+	ErrUserCannotBeImpersonated = errors.New("user cannot be impersonated")
+	ErrAddressNotFound          = errors.New("address is not found")
+	ErrInvalidCSVHeader         = errors.New("csv header is invalid")
+	ErrInvalidCSVFile           = errors.New("csv file is invalid")
+	ErrTooManyCSVRows           = errors.New("csv file has too many rows")
+	ErrUserCannotBeErased       = errors.New("user cannot be erased")
+	ErrWeakPassword             = errors.New("password does not satisfy the password policy")
 )
@@ -0,0 +1,170 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+)
+
+// impersonationExpireTime is shorter than tokenExpireTime, impersonation tokens cannot be refreshed
+const impersonationExpireTime = 15 * time.Minute
+
+// adminPermissions are the permissions which make a user an admin, whichever of the roles of the user grants them.
+// Admins cannot be impersonated, the impersonation would act with their permissions.
+var adminPermissions = []string{auth.PermUserWrite, auth.PermRoleWrite, auth.PermImpersonationWrite}
+
+// Impersonation is a session in which an admin acts as another user
+type Impersonation struct {
+	ID        int       `json:"id"`
+	ActorID   int       `json:"actor_id"`
+	SubjectID int       `json:"subject_id"`
+	Reason    string    `json:"reason"`
+	StartedAt time.Time `json:"started_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// EndedAt is null if the session was not ended before its token expired
+	EndedAt null.Time `json:"ended_at"`
+}
+
+type ImpersonationInput struct {
+	SubjectID int
+	Reason    string
+}
+
+// toImpersonation converts model.Impersonation to Impersonation
+func toImpersonation(impersonation model.Impersonation) Impersonation {
+	return Impersonation{
+		ID:        impersonation.ID,
+		ActorID:   impersonation.ActorID,
+		SubjectID: impersonation.SubjectID,
+		Reason:    impersonation.Reason,
+		StartedAt: impersonation.CreatedAt,
+		ExpiresAt: impersonation.ExpiresAt,
+		EndedAt:   impersonation.EndedAt,
+	}
+}
+
+// denyImpersonation rejects sensitive actions such as changing the password of the user while an admin impersonates the user
+func denyImpersonation(ctx context.Context) error {
+	if caller, ok := auth.FromContext(ctx); ok && caller.IsImpersonated() {
+		return ErrImpersonationNotAllowed
+	}
+	return nil
+}
+
+// StartImpersonation issues a short-lived access token of the subject for the current admin and records the start of the session.
+// The token carries the admin as actor, so the requests made with it can be told apart from the requests of the subject.
+func (serv impl) StartImpersonation(ctx context.Context, input ImpersonationInput) (LoginResponse, error) {
+	// 1. Only admins signed in with an access token can impersonate, an impersonation cannot be nested
+	actor, ok := auth.FromContext(ctx)
+	if !ok || actor.IsAPIKey() {
+		return LoginResponse{}, ErrPermissionDenied
+	}
+	if err := denyImpersonation(ctx); err != nil {
+		return LoginResponse{}, err
+	}
+	if actor.ID == input.SubjectID {
+		return LoginResponse{}, ErrUserCannotBeImpersonated
+	}
+
+	// 2. Get the subject in the organization of the admin, other admins cannot be impersonated
+	subject, err := serv.repo.User().GetUser(ctx, input.SubjectID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return LoginResponse{}, ErrUserNotFound
+	} else if err != nil {
+		return LoginResponse{}, err
+	}
+	permissions, err := serv.repo.Role().GetUserPermissions(ctx, subject.ID)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+	for _, p := range adminPermissions {
+		if (auth.User{Permissions: permissions}).HasPermission(p) {
+			return LoginResponse{}, ErrUserCannotBeImpersonated
+		}
+	}
+
+	// 3. Generate the access token of the subject
+	keys, err := jwt.KeySetFromEnv()
+	if err != nil {
+		return LoginResponse{}, ErrTokeCannotBeGenerated
+	}
+	token, accessToken, err := keys.Sign(jwt.JWTInput{
+		ID:             subject.ID,
+		Email:          subject.Email,
+		Role:           subject.Role,
+		OrganizationID: subject.OrganizationID,
+		ActorID:        actor.ID,
+		ExpiresIn:      impersonationExpireTime,
+	})
+	if err != nil {
+		return LoginResponse{}, ErrTokeCannotBeGenerated
+	}
+
+	// 4. Record the start of the session
+	if _, err = serv.repo.Impersonation().CreateImpersonation(ctx, model.Impersonation{
+		ActorID:   actor.ID,
+		SubjectID: subject.ID,
+		TokenID:   token.JwtID(),
+		Reason:    input.Reason,
+		ExpiresAt: token.Expiration(),
+	}); err != nil {
+		return LoginResponse{}, err
+	}
+	log.Printf("Impersonation started: actor %d, subject %d, token %s\n", actor.ID, subject.ID, token.JwtID())
+
+	return LoginResponse{
+		AccessToken: accessToken,
+		Scope:       subject.Role,
+		ExpiresIn:   impersonationExpireTime,
+		TokenType:   "Bearer",
+	}, nil
+}
+
+// EndImpersonation revokes the impersonation token and records the end of its session
+func (serv impl) EndImpersonation(ctx context.Context, accessToken string) error {
+	// 1. Only impersonation tokens have a session
+	claims, err := parseToken(accessToken)
+	if err != nil || claims.ActorID == 0 {
+		return ErrInvalidToken
+	}
+
+	// 2. Denylist the token and record the end of the session
+	if err = serv.repo.Token().RevokeAccessToken(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+		return err
+	}
+	return serv.endImpersonation(ctx, claims)
+}
+
+// endImpersonation records the end of the session of the impersonation token, ending a session twice is not an error
+func (serv impl) endImpersonation(ctx context.Context, claims jwt.JWTClaims) error {
+	affected, err := serv.repo.Impersonation().EndImpersonation(ctx, claims.TokenID)
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		log.Printf("Impersonation ended: actor %d, subject %d, token %s\n", claims.ActorID, claims.ID, claims.TokenID)
+	}
+	return nil
+}
+
+// GetImpersonations returns the impersonation sessions of the organization, the latest first
+func (serv impl) GetImpersonations(ctx context.Context) ([]Impersonation, error) {
+	impersonations, err := serv.repo.Impersonation().GetImpersonations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Impersonation, len(impersonations))
+	for i, impersonation := range impersonations {
+		result[i] = toImpersonation(impersonation)
+	}
+	return result, nil
+}
@@ -0,0 +1,184 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/impersonation"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+)
+
+func TestUserService_StartImpersonation(t *testing.T) {
+	admin := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleAdmin, OrganizationID: 2})
+	tcs := map[string]struct {
+		ctx        context.Context
+		subjectID  int
+		subject    model.User
+		subjectErr error
+		// permissions are the permissions of all roles of the subject
+		permissions []string
+		expErr      error
+	}{
+		"success": {
+			ctx:         admin,
+			subjectID:   10,
+			subject:     model.User{ID: 10, Email: "guest@example.com", Role: auth.RoleGuest, OrganizationID: 2},
+			permissions: []string{auth.PermOrderRead, auth.PermOrderWrite, auth.PermProductWrite},
+		},
+		"error_subject_not_found": {
+			ctx:        admin,
+			subjectID:  10,
+			subjectErr: sql.ErrNoRows,
+			expErr:     ErrUserNotFound,
+		},
+		"error_subject_is_admin": {
+			ctx:         admin,
+			subjectID:   10,
+			subject:     model.User{ID: 10, Email: "admin2@example.com", Role: auth.RoleAdmin, OrganizationID: 2},
+			permissions: []string{auth.PermRoleWrite, auth.PermUserRead, auth.PermUserWrite},
+			expErr:      ErrUserCannotBeImpersonated,
+		},
+		"error_subject_is_admin_by_additional_role": {
+			ctx:         admin,
+			subjectID:   10,
+			subject:     model.User{ID: 10, Email: "support@example.com", Role: auth.RoleGuest, OrganizationID: 2},
+			permissions: []string{auth.PermOrderRead, auth.PermUserRead, auth.PermUserWrite},
+			expErr:      ErrUserCannotBeImpersonated,
+		},
+		"error_self": {
+			ctx:       admin,
+			subjectID: 1,
+			expErr:    ErrUserCannotBeImpersonated,
+		},
+		"error_already_impersonating": {
+			ctx:       auth.NewContext(context.Background(), auth.User{ID: 11, Role: auth.RoleGuest, ActorID: 1}),
+			subjectID: 10,
+			expErr:    ErrImpersonationNotAllowed,
+		},
+		"error_api_key": {
+			ctx:       auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleAdmin, Scope: auth.ScopeWrite}),
+			subjectID: 10,
+			expErr:    ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("ACCESS_TOKEN_KEY", "secret")
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", tc.ctx, tc.subjectID).Return(tc.subject, tc.subjectErr)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("GetUserPermissions", tc.ctx, tc.subject.ID).Return(tc.permissions, nil)
+			impersonationRepoMock := new(impersonation.Mock)
+			impersonationRepoMock.On("CreateImpersonation", tc.ctx, mock.AnythingOfType("model.Impersonation")).Return(model.Impersonation{ID: 1}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("Impersonation").Return(impersonationRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.StartImpersonation(tc.ctx, ImpersonationInput{SubjectID: tc.subjectID, Reason: "order looks wrong"})
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				impersonationRepoMock.AssertNotCalled(t, "CreateImpersonation", tc.ctx, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, impersonationExpireTime, result.ExpiresIn)
+			require.Empty(t, result.RefreshToken)
+
+			// The token is issued for the subject and carries the admin as actor
+			claims, err := parseToken(result.AccessToken)
+			require.NoError(t, err)
+			require.Equal(t, tc.subject.ID, claims.ID)
+			require.Equal(t, tc.subject.OrganizationID, claims.OrganizationID)
+			require.Equal(t, 1, claims.ActorID)
+			impersonationRepoMock.AssertCalled(t, "CreateImpersonation", tc.ctx, mock.MatchedBy(func(i model.Impersonation) bool {
+				return i.ActorID == 1 && i.SubjectID == tc.subject.ID && i.TokenID == claims.TokenID && i.Reason == "order looks wrong"
+			}))
+		})
+	}
+}
+
+func TestUserService_EndImpersonation(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_KEY", "secret")
+	impersonationJWT, impersonationToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        10,
+		Email:     "guest@example.com",
+		Role:      "GUEST",
+		ActorID:   1,
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+	})
+	require.NoError(t, err)
+	_, accessToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        10,
+		Email:     "guest@example.com",
+		Role:      "GUEST",
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+	})
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		token  string
+		expErr error
+	}{
+		"success": {
+			token: impersonationToken,
+		},
+		"error_not_impersonation_token": {
+			token:  accessToken,
+			expErr: ErrInvalidToken,
+		},
+		"error_malformed": {
+			token:  "abcd",
+			expErr: ErrInvalidToken,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("RevokeAccessToken", ctx, impersonationJWT.JwtID(), mock.AnythingOfType("time.Time")).Return(nil)
+			impersonationRepoMock := new(impersonation.Mock)
+			impersonationRepoMock.On("EndImpersonation", ctx, impersonationJWT.JwtID()).Return(int64(1), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Token").Return(tokenRepoMock)
+			repoMock.On("Impersonation").Return(impersonationRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.EndImpersonation(ctx, tc.token)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				impersonationRepoMock.AssertNotCalled(t, "EndImpersonation", ctx, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			tokenRepoMock.AssertCalled(t, "RevokeAccessToken", ctx, impersonationJWT.JwtID(), mock.AnythingOfType("time.Time"))
+			impersonationRepoMock.AssertCalled(t, "EndImpersonation", ctx, impersonationJWT.JwtID())
+		})
+	}
+}
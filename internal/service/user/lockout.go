@@ -0,0 +1,99 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
+)
+
+// lockoutPolicy decides how long logins are rejected after failed attempts
+type lockoutPolicy struct {
+	freeAttempts int           // failures allowed before the delays start
+	maxAttempts  int           // failures which lock logins for the whole lockout duration
+	lockout      time.Duration // also the window failures are counted in
+}
+
+var (
+	accountLockoutPolicy = lockoutPolicy{freeAttempts: 3, maxAttempts: 10, lockout: 15 * time.Minute}
+	ipLockoutPolicy      = lockoutPolicy{freeAttempts: 20, maxAttempts: 100, lockout: 15 * time.Minute}
+)
+
+// delay returns how long logins are rejected after the given failed attempts, it doubles after every failure
+func (p lockoutPolicy) delay(failedAttempts int) time.Duration {
+	if failedAttempts <= p.freeAttempts {
+		return 0
+	}
+	shift := failedAttempts - p.freeAttempts - 1
+	if failedAttempts >= p.maxAttempts || shift >= 20 {
+		return p.lockout
+	}
+	if d := time.Second << shift; d < p.lockout {
+		return d
+	}
+	return p.lockout
+}
+
+// loginFailureKeys returns the keys of the failed logins tracked per scope, IP address is not tracked if unknown
+func loginFailureKeys(email, ipAddress string) map[string]string {
+	keys := map[string]string{loginfailure.ScopeAccount: strings.ToLower(strings.TrimSpace(email))}
+	if ipAddress != "" {
+		keys[loginfailure.ScopeIP] = ipAddress
+	}
+	return keys
+}
+
+// checkLoginLocked returns ErrTooManyLoginAttempts if logins of the email or the IP address are locked
+func (serv impl) checkLoginLocked(ctx context.Context, email, ipAddress string) error {
+	now := time.Now()
+	for scope, key := range loginFailureKeys(email, ipAddress) {
+		failure, err := serv.repo.LoginFailure().GetLoginFailure(ctx, scope, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if failure.LockedUntil.Valid && failure.LockedUntil.Time.After(now) {
+			return ErrTooManyLoginAttempts
+		}
+	}
+	return nil
+}
+
+// loginFailed records the failed login of the email and the IP address, locks them by their policies
+// and returns ErrInvalidCredentials
+func (serv impl) loginFailed(ctx context.Context, email, ipAddress string) error {
+	policies := map[string]lockoutPolicy{
+		loginfailure.ScopeAccount: accountLockoutPolicy,
+		loginfailure.ScopeIP:      ipLockoutPolicy,
+	}
+	for scope, key := range loginFailureKeys(email, ipAddress) {
+		policy := policies[scope]
+		failure, err := serv.repo.LoginFailure().RecordLoginFailure(ctx, scope, key, policy.lockout)
+		if err != nil {
+			return err
+		}
+		if delay := policy.delay(failure.FailedAttempts); delay > 0 {
+			if _, err = serv.repo.LoginFailure().LockLoginFailure(ctx, scope, key, time.Now().Add(delay)); err != nil {
+				return err
+			}
+		}
+	}
+	return ErrInvalidCredentials
+}
+
+// UnlockUser clears the failed logins of the user, so the user can login again immediately
+func (serv impl) UnlockUser(ctx context.Context, id int) error {
+	user, err := serv.repo.User().GetUser(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	} else if err != nil {
+		return err
+	}
+
+	_, err = serv.repo.LoginFailure().DeleteLoginFailure(ctx, loginfailure.ScopeAccount, strings.ToLower(strings.TrimSpace(user.Email)))
+	return err
+}
@@ -0,0 +1,102 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+)
+
+func TestLockoutPolicy_Delay(t *testing.T) {
+	policy := lockoutPolicy{freeAttempts: 3, maxAttempts: 10, lockout: 15 * time.Minute}
+	tcs := map[string]struct {
+		failedAttempts int
+		expDelay       time.Duration
+	}{
+		"free_attempt": {
+			failedAttempts: 3,
+			expDelay:       0,
+		},
+		"first_delay": {
+			failedAttempts: 4,
+			expDelay:       time.Second,
+		},
+		"progressive_delay": {
+			failedAttempts: 7,
+			expDelay:       8 * time.Second,
+		},
+		"max_attempts": {
+			failedAttempts: 10,
+			expDelay:       15 * time.Minute,
+		},
+		"over_max_attempts": {
+			failedAttempts: 1000,
+			expDelay:       15 * time.Minute,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			require.Equal(t, tc.expDelay, policy.delay(tc.failedAttempts))
+		})
+	}
+}
+
+func TestUserService_UnlockUser(t *testing.T) {
+	tcs := map[string]struct {
+		id         int
+		user       model.User
+		userErr    error
+		expDeleted bool
+		expErr     error
+	}{
+		"success": {
+			id:         1,
+			user:       model.User{ID: 1, Email: "Guest@example.com"},
+			expDeleted: true,
+		},
+		"error_user_not_found": {
+			id:      2,
+			userErr: sql.ErrNoRows,
+			expErr:  ErrUserNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", ctx, tc.id).Return(tc.user, tc.userErr)
+			loginFailureRepoMock := new(loginfailure.Mock)
+			loginFailureRepoMock.On("DeleteLoginFailure", ctx, loginfailure.ScopeAccount, "guest@example.com").Return(int64(1), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("LoginFailure").Return(loginFailureRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.UnlockUser(ctx, tc.id)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expDeleted {
+				loginFailureRepoMock.AssertCalled(t, "DeleteLoginFailure", ctx, loginfailure.ScopeAccount, "guest@example.com")
+			} else {
+				loginFailureRepoMock.AssertNotCalled(t, "DeleteLoginFailure", ctx, loginfailure.ScopeAccount, "guest@example.com")
+			}
+		})
+	}
+}
@@ -2,9 +2,15 @@ package user
 
 import (
 	"context"
+	"io"
+
+	"github.com/lestrrat-go/jwx/jwk"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/password"
 )
 
 type IService interface {
@@ -14,18 +20,162 @@ type IService interface {
 	// GetUsers returns all users by given InputGetUser param.
 	GetUsers(ctx context.Context, input InputGetUser) ([]model.User, int64, error)
 
-	// UpdateUser updates a user by given InputUser param.
+	// UpdateUser updates a user by given InputUser param, the password is only replaced when one is given.
 	UpdateUser(ctx context.Context, input InputUser) error
 
+	// ImportUsersCSV creates the users of the CSV file and returns a report of the created and the skipped rows.
+	ImportUsersCSV(ctx context.Context, csvFile io.Reader, sendInvites bool) (ImportUsersResult, error)
+
+	// ExportUsersCSV returns a CSV file of the users by given InputGetUser param.
+	ExportUsersCSV(ctx context.Context, input InputGetUser) ([]byte, error)
+
 	// GetUser returns a user by given "id" param.
 	GetUser(ctx context.Context, id int) (model.User, error)
 
 	// DeleteUser delete a user by given "id" param.
 	DeleteUser(ctx context.Context, id int) error
 
+	// RestoreUser restores a deleted user by given "id" param.
+	RestoreUser(ctx context.Context, id int) error
+
+	// GetProfile returns the profile of the current user
+	GetProfile(ctx context.Context) (Profile, error)
+
+	// UpdateProfile updates the profile of the current user, a changed email has to be verified again
+	UpdateProfile(ctx context.Context, input UpdateProfileInput) (Profile, error)
+
+	// ChangePassword sets a new password of the current user by the current password
+	ChangePassword(ctx context.Context, input ChangePasswordInput) error
+
 	// Login authenticate login data
 	Login(ctx context.Context, input LoginInput) (LoginResponse, error)
 
+	// StartOIDCLogin starts an OpenID Connect login and returns the URL of the issuer
+	StartOIDCLogin(ctx context.Context) (OIDCLoginRequest, error)
+
+	// OIDCCallback completes an OpenID Connect login by the authorization code of the issuer
+	OIDCCallback(ctx context.Context, input OIDCCallbackInput) (LoginResponse, error)
+
+	// LoginTwoFactor completes the login of a user with two-factor authentication
+	LoginTwoFactor(ctx context.Context, input TwoFactorLoginInput) (LoginResponse, error)
+
+	// EnrollTwoFactor generates a new TOTP secret for the current user
+	EnrollTwoFactor(ctx context.Context) (TwoFactorEnrollment, error)
+
+	// ConfirmTwoFactor enables two-factor authentication of the current user and returns the backup codes
+	ConfirmTwoFactor(ctx context.Context, code string) ([]string, error)
+
+	// UnlockUser clears the failed logins which lock the user
+	UnlockUser(ctx context.Context, id int) error
+
+	// RefreshToken rotates the refresh token and returns new tokens
+	RefreshToken(ctx context.Context, refreshToken string) (LoginResponse, error)
+
+	// Logout revokes the refresh token and the access token
+	Logout(ctx context.Context, input LogoutInput) error
+
+	// ForgotPassword sends a password reset link to the email
+	ForgotPassword(ctx context.Context, email string) error
+
+	// ResetPassword sets a new password by the password reset token
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error
+
+	// VerifyEmail marks the email of the user as verified by the verification token
+	VerifyEmail(ctx context.Context, token string) error
+
+	// ResendVerificationEmail sends the email verification link again
+	ResendVerificationEmail(ctx context.Context, email string) error
+
+	// VerifyAccessToken verifies the given access token and returns the authenticated user
+	VerifyAccessToken(ctx context.Context, accessToken string) (auth.User, error)
+
+	// GetJWKS returns the public keys which verify the signed tokens
+	GetJWKS(ctx context.Context) (jwk.Set, error)
+
+	// CreateAPIKey creates an API key of the current user, the key is only returned once
+	CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (APIKey, error)
+
+	// GetAPIKeys returns the API keys of the current user
+	GetAPIKeys(ctx context.Context) ([]APIKey, error)
+
+	// RevokeAPIKey revokes an API key of the current user
+	RevokeAPIKey(ctx context.Context, id int) error
+
+	// VerifyAPIKey verifies the given API key and returns the authenticated user limited by the key scope
+	VerifyAPIKey(ctx context.Context, key string) (auth.User, error)
+
+	// GetRoles returns all roles with their permissions
+	GetRoles(ctx context.Context) ([]Role, error)
+
+	// GetRole returns a role by given "id" param
+	GetRole(ctx context.Context, id int) (Role, error)
+
+	// CreateRole creates a new role with the given permissions
+	CreateRole(ctx context.Context, input RoleInput) (Role, error)
+
+	// UpdateRole updates a role and replaces its permissions
+	UpdateRole(ctx context.Context, input RoleInput) error
+
+	// DeleteRole deletes a role by given "id" param
+	DeleteRole(ctx context.Context, id int) error
+
+	// GetPermissions returns all permissions
+	GetPermissions(ctx context.Context) ([]model.Permission, error)
+
+	// GetUserRoles returns the primary role and the additional roles of a user
+	GetUserRoles(ctx context.Context, userID int) (UserRoles, error)
+
+	// SetUserRoles replaces the additional roles of a user
+	SetUserRoles(ctx context.Context, userID int, roleNames []string) error
+
+	// GetOrganizations returns all organizations
+	GetOrganizations(ctx context.Context) ([]Organization, error)
+
+	// GetOrganization returns an organization by given "id" param
+	GetOrganization(ctx context.Context, id int) (Organization, error)
+
+	// CreateOrganization creates a new organization with its first ADMIN
+	CreateOrganization(ctx context.Context, input OrganizationInput) (Organization, error)
+
+	// StartImpersonation issues a short-lived access token of another user for the current admin
+	StartImpersonation(ctx context.Context, input ImpersonationInput) (LoginResponse, error)
+
+	// EndImpersonation revokes the impersonation token and records the end of its session
+	EndImpersonation(ctx context.Context, accessToken string) error
+
+	// GetImpersonations returns the impersonation sessions
+	GetImpersonations(ctx context.Context) ([]Impersonation, error)
+
+	// GetAddresses returns the addresses of a user
+	GetAddresses(ctx context.Context, userID int) ([]Address, error)
+
+	// GetAddress returns an address of a user
+	GetAddress(ctx context.Context, userID int, id int) (Address, error)
+
+	// CreateAddress adds an address to the address book of a user
+	CreateAddress(ctx context.Context, input AddressInput) (Address, error)
+
+	// UpdateAddress updates an address of a user
+	UpdateAddress(ctx context.Context, input AddressInput) error
+
+	// DeleteAddress deletes an address of a user
+	DeleteAddress(ctx context.Context, userID int, id int) error
+
+	// ExportUserData returns everything tied to a user and logs the export
+	ExportUserData(ctx context.Context, userID int) (UserData, error)
+
+	// EraseUser anonymizes the personal data of a user and logs the erasure
+	EraseUser(ctx context.Context, userID int) error
+
+	// GetDataRequests returns the logged personal data exports and erasures
+	GetDataRequests(ctx context.Context) ([]DataRequest, error)
+
+	// GetSecurityEvents returns the logins and sensitive operations of the users
+	GetSecurityEvents(ctx context.Context, input SecurityEventsInput) ([]SecurityEvent, int64, error)
+
+	// GetCurrentUserSecurityEvents returns the recent logins and sensitive operations of the current user
+	GetCurrentUserSecurityEvents(ctx context.Context, pagination Pagination) ([]SecurityEvent, int64, error)
+
 	// GetStatistics returns statistic of users
 	GetStatistics(ctx context.Context, orderLimit int) (SummaryStatistics, error)
 }
@@ -34,6 +184,12 @@ type impl struct {
 	repo repository.IRepo
 }
 
+// sendEmail sends emails of the service, it is replaced in tests
+var sendEmail = mail.SendEmail
+
+// passwordPolicy returns the policy new passwords must satisfy, it can be replaced by another password.Policy
+var passwordPolicy = password.PolicyFromEnv
+
 func New(repo repository.IRepo) IService {
 	return impl{repo: repo}
 }
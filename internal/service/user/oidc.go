@@ -0,0 +1,185 @@
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/oidc"
+)
+
+// oidcProvider returns the configured OpenID Connect issuer, it is replaced in tests
+var oidcProvider = oidc.ProviderFromEnv
+
+// OIDCLoginRequest is a started OpenID Connect login.
+// State, Nonce and CodeVerifier are kept by the client until the issuer redirects back, they are never sent to the issuer in clear.
+type OIDCLoginRequest struct {
+	AuthURL      string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+type OIDCCallbackInput struct {
+	Code  string
+	State string
+	// Request is the login request which was started by StartOIDCLogin
+	Request OIDCLoginRequest
+}
+
+// StartOIDCLogin starts the authorization code flow with PKCE and returns the URL of the issuer which signs in the user
+func (serv impl) StartOIDCLogin(ctx context.Context) (OIDCLoginRequest, error) {
+	provider, err := oidcProvider(ctx)
+	if err != nil {
+		log.Printf("Error when get OpenID Connect provider: %v\n", err)
+		return OIDCLoginRequest{}, ErrOIDCNotConfigured
+	}
+
+	// Generate the state against CSRF, the nonce against replayed ID tokens and the PKCE code verifier
+	request := OIDCLoginRequest{}
+	if request.State, err = generateToken(); err != nil {
+		return OIDCLoginRequest{}, ErrTokeCannotBeGenerated
+	}
+	if request.Nonce, err = generateToken(); err != nil {
+		return OIDCLoginRequest{}, ErrTokeCannotBeGenerated
+	}
+	if request.CodeVerifier, err = oidc.NewCodeVerifier(); err != nil {
+		return OIDCLoginRequest{}, ErrTokeCannotBeGenerated
+	}
+
+	request.AuthURL = provider.AuthCodeURL(request.State, request.Nonce, oidc.CodeChallengeS256(request.CodeVerifier))
+	return request, nil
+}
+
+// OIDCCallback completes the login with the authorization code which the issuer redirected back with.
+// The user of the identity is created with the default role on the first login, then the tokens of the user are issued like Login.
+func (serv impl) OIDCCallback(ctx context.Context, input OIDCCallbackInput) (LoginResponse, error) {
+	// 1. The state must be the one of the login request
+	if input.Code == "" || input.Request.State == "" || subtle.ConstantTimeCompare([]byte(input.State), []byte(input.Request.State)) != 1 {
+		return LoginResponse{}, ErrInvalidOIDCLogin
+	}
+
+	provider, err := oidcProvider(ctx)
+	if err != nil {
+		log.Printf("Error when get OpenID Connect provider: %v\n", err)
+		return LoginResponse{}, ErrOIDCNotConfigured
+	}
+
+	// 2. Exchange the code for the ID token and verify it
+	tokens, err := provider.Exchange(ctx, input.Code, input.Request.CodeVerifier)
+	if err != nil {
+		log.Printf("Error when exchange OpenID Connect code: %v\n", err)
+		return LoginResponse{}, ErrInvalidOIDCLogin
+	}
+	claims, err := provider.VerifyIDToken(ctx, tokens.IDToken, input.Request.Nonce)
+	if err != nil {
+		log.Printf("Error when verify OpenID Connect ID token: %v\n", err)
+		return LoginResponse{}, ErrInvalidOIDCLogin
+	}
+
+	// 3. Get or create the user of the identity
+	user, err := serv.getOIDCUser(lookupContext(ctx), provider, claims)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+	if !user.IsActive {
+		return LoginResponse{}, ErrUserInactive
+	}
+
+	return serv.completeLogin(auth.NewTenantContext(ctx, user.OrganizationID), user)
+}
+
+// getOIDCUser returns the user linked to the identity.
+// An identity which is not linked yet is linked to the user with the same verified email, or to a new user without password.
+func (serv impl) getOIDCUser(ctx context.Context, provider *oidc.Provider, claims oidc.Claims) (model.User, error) {
+	// 1. Get the user of a linked identity
+	identity, err := serv.repo.Identity().GetIdentity(ctx, provider.Issuer(), claims.Subject)
+	if err == nil {
+		user, err := serv.repo.User().GetUser(ctx, identity.UserID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.User{}, ErrUserNotFound // the user was deleted
+		}
+		return user, err
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return model.User{}, err
+	}
+
+	// 2. Only emails verified by the issuer can be linked
+	if claims.Email == "" || !claims.EmailVerified {
+		return model.User{}, ErrEmailNotVerified
+	}
+	newIdentity := model.Identity{
+		Issuer:  provider.Issuer(),
+		Subject: claims.Subject,
+		Email:   claims.Email,
+	}
+
+	// 3. Link the identity to the user with the same email, the email must be verified by the user as well,
+	// otherwise anyone who registered the email first could take over the account
+	existing, err := serv.repo.User().GetUserByEmail(ctx, claims.Email)
+	if err == nil {
+		if !existing.EmailVerifiedAt.Valid {
+			return model.User{}, ErrEmailExisted
+		}
+		newIdentity.UserID = existing.ID
+		if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+			_, err := serv.repo.Identity().CreateIdentity(ctx, tx, newIdentity)
+			return err
+		}); err != nil {
+			return model.User{}, err
+		}
+		return existing, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return model.User{}, err
+	}
+
+	// The email of a deleted user cannot be used again
+	existed, err := serv.repo.User().ExistsUserByEmail(ctx, claims.Email)
+	if err != nil {
+		return model.User{}, err
+	}
+	if existed {
+		return model.User{}, ErrEmailExisted
+	}
+
+	// 4. Create the user with the default role and link the identity, the user belongs to the organization of the request
+	// or to the default organization
+	if auth.IsUnscoped(ctx) {
+		ctx = auth.NewTenantContext(ctx, auth.DefaultOrganizationID)
+	}
+	role := provider.DefaultRole()
+	if role == "" {
+		role = auth.RoleGuest
+	}
+	if err = serv.checkRoleExists(ctx, role); err != nil {
+		return model.User{}, err
+	}
+	name := claims.Name
+	if name == "" {
+		name = claims.Email
+	}
+
+	var user model.User
+	if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		user, err = serv.repo.Identity().CreateIdentityUser(ctx, tx, model.User{
+			Name:     name,
+			Email:    claims.Email,
+			Role:     role,
+			IsActive: true,
+		})
+		if err != nil {
+			return err
+		}
+		newIdentity.UserID = user.ID
+		_, err = serv.repo.Identity().CreateIdentity(ctx, tx, newIdentity)
+		return err
+	}); err != nil {
+		return model.User{}, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,289 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/identity"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/twofactor"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/oidc"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/oidc/oidctest"
+)
+
+// setupOIDCIssuer starts a local issuer and configures the service to use it
+func setupOIDCIssuer(t *testing.T) *oidctest.Issuer {
+	issuer := oidctest.NewIssuer("client", "client-secret")
+	t.Cleanup(issuer.Close)
+
+	t.Setenv("ACCESS_TOKEN_KEY", "secret")
+	t.Setenv("APP_URL", "http://localhost:5000")
+	t.Setenv("OIDC_ISSUER", issuer.URL)
+	t.Setenv("OIDC_CLIENT_ID", issuer.ClientID)
+	t.Setenv("OIDC_CLIENT_SECRET", issuer.ClientSecret)
+	return issuer
+}
+
+func TestUserService_StartOIDCLogin(t *testing.T) {
+	// GIVEN
+	issuer := setupOIDCIssuer(t)
+	userServ := New(new(repository.Mock))
+
+	// WHEN
+	result, err := userServ.StartOIDCLogin(context.Background())
+
+	// THEN
+	require.NoError(t, err)
+	require.NotEmpty(t, result.State)
+	require.NotEmpty(t, result.Nonce)
+	require.NotEmpty(t, result.CodeVerifier)
+	authURL, err := url.Parse(result.AuthURL)
+	require.NoError(t, err)
+	require.Equal(t, issuer.URL+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	query := authURL.Query()
+	require.Equal(t, "client", query.Get("client_id"))
+	require.Equal(t, "http://localhost:5000/api/v1/users/oidc/callback", query.Get("redirect_uri"))
+	require.Equal(t, result.State, query.Get("state"))
+	require.Equal(t, result.Nonce, query.Get("nonce"))
+	require.Equal(t, oidc.CodeChallengeS256(result.CodeVerifier), query.Get("code_challenge"))
+	require.Empty(t, query.Get("code_verifier"))
+}
+
+func TestUserService_StartOIDCLogin_NotConfigured(t *testing.T) {
+	// GIVEN
+	t.Setenv("OIDC_ISSUER", "")
+	t.Setenv("OIDC_CLIENT_ID", "")
+	userServ := New(new(repository.Mock))
+
+	// WHEN
+	_, err := userServ.StartOIDCLogin(context.Background())
+
+	// THEN
+	require.ErrorIs(t, err, ErrOIDCNotConfigured)
+}
+
+func TestUserService_OIDCCallback(t *testing.T) {
+	type mockData struct {
+		identity       model.Identity
+		identityErr    error
+		userByEmail    model.User
+		userByEmailErr error
+		emailExisted   bool
+		user           model.User
+		userErr        error
+		twoFactor      bool
+	}
+	tcs := map[string]struct {
+		claims     oidc.Claims
+		wrongState bool
+		wrongNonce bool
+		mock       mockData
+		expCreated bool
+		expLinked  bool
+		expResult  LoginResponse
+		expTwoFA   bool
+		expErr     error
+	}{
+		"success_linked_identity": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "sso@example.com", EmailVerified: true},
+			mock: mockData{
+				identity: model.Identity{ID: 1, UserID: 1},
+				user:     model.User{ID: 1, Email: "sso@example.com", Role: "GUEST", IsActive: true},
+			},
+			expResult: LoginResponse{Scope: "GUEST", ExpiresIn: tokenExpireTime, TokenType: "Bearer"},
+		},
+		"success_link_user_with_verified_email": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "admin@example.com", EmailVerified: true},
+			mock: mockData{
+				identityErr: sql.ErrNoRows,
+				userByEmail: model.User{ID: 2, Email: "admin@example.com", Role: "ADMIN", IsActive: true, EmailVerifiedAt: null.TimeFrom(time.Now())},
+			},
+			expLinked: true,
+			expResult: LoginResponse{Scope: "ADMIN", ExpiresIn: tokenExpireTime, TokenType: "Bearer"},
+		},
+		"success_create_user": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "new@example.com", EmailVerified: true, Name: "New User"},
+			mock: mockData{
+				identityErr:    sql.ErrNoRows,
+				userByEmailErr: sql.ErrNoRows,
+			},
+			expCreated: true,
+			expResult:  LoginResponse{Scope: "GUEST", ExpiresIn: tokenExpireTime, TokenType: "Bearer"},
+		},
+		"success_two_factor_required": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "admin@example.com", EmailVerified: true},
+			mock: mockData{
+				identity:  model.Identity{ID: 1, UserID: 2},
+				user:      model.User{ID: 2, Email: "admin@example.com", Role: "ADMIN", IsActive: true},
+				twoFactor: true,
+			},
+			expTwoFA:  true,
+			expResult: LoginResponse{TwoFactorRequired: true, ExpiresIn: twoFactorChallengeExpire},
+		},
+		"error_state_mismatch": {
+			claims:     oidc.Claims{Subject: "sub-1", Email: "sso@example.com", EmailVerified: true},
+			wrongState: true,
+			expErr:     ErrInvalidOIDCLogin,
+		},
+		"error_nonce_mismatch": {
+			claims:     oidc.Claims{Subject: "sub-1", Email: "sso@example.com", EmailVerified: true},
+			wrongNonce: true,
+			expErr:     ErrInvalidOIDCLogin,
+		},
+		"error_deleted_user": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "sso@example.com", EmailVerified: true},
+			mock: mockData{
+				identity: model.Identity{ID: 1, UserID: 1},
+				userErr:  sql.ErrNoRows,
+			},
+			expErr: ErrUserNotFound,
+		},
+		"error_inactive_user": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "sso@example.com", EmailVerified: true},
+			mock: mockData{
+				identity: model.Identity{ID: 1, UserID: 1},
+				user:     model.User{ID: 1, Email: "sso@example.com", Role: "GUEST"},
+			},
+			expErr: ErrUserInactive,
+		},
+		"error_email_not_verified_by_issuer": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "sso@example.com"},
+			mock: mockData{
+				identityErr: sql.ErrNoRows,
+			},
+			expErr: ErrEmailNotVerified,
+		},
+		"error_email_of_unverified_user": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "guest@example.com", EmailVerified: true},
+			mock: mockData{
+				identityErr: sql.ErrNoRows,
+				userByEmail: model.User{ID: 3, Email: "guest@example.com", Role: "GUEST"},
+			},
+			expErr: ErrEmailExisted,
+		},
+		"error_email_of_deleted_user": {
+			claims: oidc.Claims{Subject: "sub-1", Email: "deleted@example.com", EmailVerified: true},
+			mock: mockData{
+				identityErr:    sql.ErrNoRows,
+				userByEmailErr: sql.ErrNoRows,
+				emailExisted:   true,
+			},
+			expErr: ErrEmailExisted,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			issuer := setupOIDCIssuer(t)
+			ctx := context.Background()
+
+			identityRepoMock := new(identity.Mock)
+			identityRepoMock.On("GetIdentity", mock.Anything, issuer.URL, tc.claims.Subject).Return(tc.mock.identity, tc.mock.identityErr)
+			identityRepoMock.On("CreateIdentity", mock.Anything, mock.Anything, mock.AnythingOfType("model.Identity")).Return(model.Identity{}, nil)
+			identityRepoMock.On("CreateIdentityUser", mock.Anything, mock.Anything, mock.AnythingOfType("model.User")).Return(model.User{ID: 4, Email: tc.claims.Email, Role: "GUEST", IsActive: true}, nil)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", mock.Anything, tc.mock.identity.UserID).Return(tc.mock.user, tc.mock.userErr)
+			userRepoMock.On("GetUserByEmail", mock.Anything, tc.claims.Email).Return(tc.mock.userByEmail, tc.mock.userByEmailErr)
+			userRepoMock.On("ExistsUserByEmail", mock.Anything, tc.claims.Email).Return(tc.mock.emailExisted, nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("ExistsRoleByName", mock.Anything, "GUEST").Return(true, nil)
+			twoFactorRepoMock := new(twofactor.Mock)
+			if tc.mock.twoFactor {
+				twoFactorRepoMock.On("GetTOTPSecret", mock.Anything, mock.AnythingOfType("int")).Return(model.TotpSecret{ConfirmedAt: null.TimeFrom(time.Now())}, nil)
+			} else {
+				twoFactorRepoMock.On("GetTOTPSecret", mock.Anything, mock.AnythingOfType("int")).Return(model.TotpSecret{}, sql.ErrNoRows)
+			}
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("model.RefreshToken")).Return(model.RefreshToken{}, nil)
+			loginFailureRepoMock := new(loginfailure.Mock)
+			loginFailureRepoMock.On("DeleteLoginFailure", mock.Anything, loginfailure.ScopeAccount, mock.AnythingOfType("string")).Return(int64(1), nil)
+
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", mock.Anything, mock.Anything).Return(model.SecurityEvent{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+			repoMock.On("Identity").Return(identityRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("TwoFactor").Return(twoFactorRepoMock)
+			repoMock.On("Token").Return(tokenRepoMock)
+			repoMock.On("LoginFailure").Return(loginFailureRepoMock)
+			repoMock.On("Tx", mock.Anything, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+				require.NoError(t, args.Get(1).(func(*sql.Tx) error)(nil))
+			})
+
+			userServ := New(repoMock)
+			request, err := userServ.StartOIDCLogin(ctx)
+			require.NoError(t, err)
+			code, state, err := issuer.Authorize(request.AuthURL, tc.claims)
+			require.NoError(t, err)
+			if tc.wrongState {
+				state = "other-state"
+			}
+			if tc.wrongNonce {
+				request.Nonce = "other-nonce"
+			}
+
+			// WHEN
+			result, err := userServ.OIDCCallback(ctx, OIDCCallbackInput{Code: code, State: state, Request: request})
+
+			// THEN
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				tokenRepoMock.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.AnythingOfType("model.RefreshToken"))
+				return
+			}
+			require.NoError(t, err)
+			if tc.expTwoFA {
+				require.NotEmpty(t, result.ChallengeToken)
+				tc.expResult.ChallengeToken = result.ChallengeToken
+			} else {
+				require.NotEmpty(t, result.AccessToken)
+				require.NotEmpty(t, result.RefreshToken)
+				tc.expResult.AccessToken = result.AccessToken
+				tc.expResult.RefreshToken = result.RefreshToken
+			}
+			require.Equal(t, tc.expResult, result)
+
+			if tc.expCreated {
+				identityRepoMock.AssertCalled(t, "CreateIdentityUser", auth.NewTenantContext(auth.NewUnscopedContext(ctx), auth.DefaultOrganizationID), mock.Anything, model.User{
+					Name:     tc.claims.Name,
+					Email:    tc.claims.Email,
+					Role:     "GUEST",
+					IsActive: true,
+				})
+			} else {
+				identityRepoMock.AssertNotCalled(t, "CreateIdentityUser", mock.Anything, mock.Anything, mock.AnythingOfType("model.User"))
+			}
+			if tc.expCreated || tc.expLinked {
+				userID := tc.mock.userByEmail.ID
+				if tc.expCreated {
+					userID = 4
+				}
+				identityRepoMock.AssertCalled(t, "CreateIdentity", mock.Anything, mock.Anything, model.Identity{
+					UserID:  userID,
+					Issuer:  issuer.URL,
+					Subject: tc.claims.Subject,
+					Email:   tc.claims.Email,
+				})
+			} else {
+				identityRepoMock.AssertNotCalled(t, "CreateIdentity", mock.Anything, mock.Anything, mock.AnythingOfType("model.Identity"))
+			}
+		})
+	}
+}
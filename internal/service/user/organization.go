@@ -0,0 +1,111 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/bcrypt"
+)
+
+type Organization struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrganizationInput struct {
+	Name string
+	// Admin is the first user of the organization, the role is always ADMIN
+	Admin InputUser
+}
+
+// toOrganization converts model.Organization to Organization
+func toOrganization(organization model.Organization) Organization {
+	return Organization{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}
+}
+
+// GetOrganizations returns all organizations
+func (serv impl) GetOrganizations(ctx context.Context) ([]Organization, error) {
+	organizations, err := serv.repo.Organization().GetOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Organization, len(organizations))
+	for i, organization := range organizations {
+		result[i] = toOrganization(*organization)
+	}
+	return result, nil
+}
+
+// GetOrganization returns the organization with the given id
+func (serv impl) GetOrganization(ctx context.Context, id int) (Organization, error) {
+	organization, err := serv.repo.Organization().GetOrganization(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Organization{}, ErrOrganizationNotFound
+	} else if err != nil {
+		return Organization{}, err
+	}
+	return toOrganization(organization), nil
+}
+
+// CreateOrganization creates a new organization with its ADMIN and GUEST roles and its first ADMIN, the other users are managed by this ADMIN
+func (serv impl) CreateOrganization(ctx context.Context, input OrganizationInput) (Organization, error) {
+	// 1. Check exist organization with this name
+	existed, err := serv.repo.Organization().ExistsOrganizationByName(ctx, input.Name)
+	if err != nil {
+		return Organization{}, err
+	}
+	if existed {
+		return Organization{}, ErrOrganizationExisted
+	}
+
+	// 2. Check the ADMIN before creating anything, so the organization is not created without its ADMIN
+	if err = validatePassword(input.Admin.Password); err != nil {
+		return Organization{}, err
+	}
+	input.Admin.Role = auth.RoleAdmin
+	input.Admin.IsActive = true
+	admin, err := serv.newUser(ctx, input.Admin, bcrypt.Cost())
+	if err != nil {
+		return Organization{}, err
+	}
+
+	// 3. Create the organization, its roles and its ADMIN at once
+	var created model.Organization
+	var organizationCtx context.Context
+	if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		created, err = serv.repo.Organization().CreateOrganization(ctx, tx, model.Organization{Name: input.Name})
+		if err != nil {
+			return err
+		}
+
+		// The roles and the ADMIN belong to the new organization, its ADMIN does not get the platform permissions
+		organizationCtx = auth.NewTenantContext(ctx, created.ID)
+		if err = serv.createBuiltInRoles(organizationCtx, tx); err != nil {
+			return err
+		}
+		admin, err = serv.repo.User().CreateUser(organizationCtx, tx, admin)
+		return err
+	}); err != nil {
+		return Organization{}, err
+	}
+
+	// 4. Send the verification email, the ADMIN can request it again if sending fails
+	if err = serv.sendVerificationEmail(organizationCtx, admin); err != nil {
+		log.Printf("Error when send verification email to user %d: %v\n", admin.ID, err)
+	}
+
+	return toOrganization(created), nil
+}
@@ -0,0 +1,176 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/organization"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/password"
+)
+
+func TestUserService_CreateOrganization(t *testing.T) {
+	admin := InputUser{Name: "owner", Email: "owner@example.com", Password: "Secret-Passw0rd", Phone: "0987654321"}
+	tcs := map[string]struct {
+		input         OrganizationInput
+		existed       bool
+		emailExisted  bool
+		createUserErr error
+		expResult     Organization
+		expErr        error
+	}{
+		"success": {
+			input:     OrganizationInput{Name: "Shop A", Admin: admin},
+			expResult: Organization{ID: 2, Name: "Shop A"},
+		},
+		"error_organization_existed": {
+			input:   OrganizationInput{Name: "Default", Admin: admin},
+			existed: true,
+			expErr:  ErrOrganizationExisted,
+		},
+		"error_email_existed": {
+			input:        OrganizationInput{Name: "Shop A", Admin: admin},
+			emailExisted: true,
+			expErr:       ErrEmailExisted,
+		},
+		"error_admin_not_created": {
+			// The organization and its roles are rolled back with the ADMIN
+			input:         OrganizationInput{Name: "Shop A", Admin: admin},
+			createUserErr: errors.New("connection reset"),
+			expErr:        errors.New("connection reset"),
+		},
+		"error_weak_password": {
+			input:  OrganizationInput{Name: "Shop A", Admin: InputUser{Name: "owner", Email: "owner@example.com", Password: "abcd", Phone: "0987654321"}},
+			expErr: fmt.Errorf("%w: %v, it needs at least 8 characters", ErrWeakPassword, password.ErrTooShort),
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("ACCESS_TOKEN_KEY", "secret")
+			ctx := context.Background()
+			var sent []mail.EmailInput
+			sendEmail = func(input mail.EmailInput) error {
+				sent = append(sent, input)
+				return nil
+			}
+			defer func() { sendEmail = mail.SendEmail }()
+
+			// The ADMIN is created in the new organization
+			inNewOrganization := mock.MatchedBy(func(ctx context.Context) bool {
+				organizationID, ok := auth.TenantFromContext(ctx)
+				return ok && organizationID == 2
+			})
+			organizationRepoMock := new(organization.Mock)
+			organizationRepoMock.On("ExistsOrganizationByName", ctx, tc.input.Name).Return(tc.existed, nil)
+			organizationRepoMock.On("CreateOrganization", ctx, mock.Anything, model.Organization{Name: tc.input.Name}).
+				Return(model.Organization{ID: 2, Name: tc.input.Name}, nil)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("ExistsUserByEmail", mock.Anything, admin.Email).Return(tc.emailExisted, nil)
+			userRepoMock.On("CreateUser", inNewOrganization, mock.Anything, mock.MatchedBy(func(u model.User) bool {
+				return u.Email == admin.Email && u.Role == auth.RoleAdmin && u.IsActive
+			})).Return(model.User{ID: 20, Email: admin.Email, Role: auth.RoleAdmin, OrganizationID: 2}, tc.createUserErr)
+			userRepoMock.On("UpdateVerificationSentAt", inNewOrganization, 20, verificationResendInterval).Return(int64(1), nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("GetPermissions", inNewOrganization).Return(model.PermissionSlice{
+				{ID: 1, Name: auth.PermUserWrite},
+				{ID: 2, Name: auth.PermProductWrite},
+				{ID: 3, Name: auth.PermOrganizationWrite},
+			}, nil)
+			for id, name := range map[int]string{10: auth.RoleAdmin, 11: auth.RoleGuest} {
+				name := name
+				roleRepoMock.On("CreateRole", inNewOrganization, mock.Anything, mock.MatchedBy(func(r model.Role) bool { return r.Name == name })).
+					Return(model.Role{ID: id, Name: name}, nil)
+			}
+			roleRepoMock.On("SetRolePermissions", inNewOrganization, mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("model.PermissionSlice")).Return(nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Organization").Return(organizationRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(tc.createUserErr).Run(func(args mock.Arguments) {
+				require.Equal(t, tc.createUserErr, args.Get(1).(func(*sql.Tx) error)(nil))
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.CreateOrganization(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				require.Empty(t, sent)
+				if tc.createUserErr == nil {
+					organizationRepoMock.AssertNotCalled(t, "CreateOrganization", ctx, mock.Anything, mock.Anything)
+				}
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, result)
+				require.Len(t, sent, 1)
+				userRepoMock.AssertCalled(t, "CreateUser", inNewOrganization, mock.Anything, mock.Anything)
+				// The ADMIN of the new organization does not get the platform permissions
+				roleRepoMock.AssertCalled(t, "SetRolePermissions", inNewOrganization, mock.Anything, 10, model.PermissionSlice{
+					{ID: 1, Name: auth.PermUserWrite},
+					{ID: 2, Name: auth.PermProductWrite},
+				})
+				roleRepoMock.AssertCalled(t, "SetRolePermissions", inNewOrganization, mock.Anything, 11, model.PermissionSlice{
+					{ID: 2, Name: auth.PermProductWrite},
+				})
+			}
+		})
+	}
+}
+
+func TestUserService_GetOrganization(t *testing.T) {
+	tcs := map[string]struct {
+		mockResult model.Organization
+		mockErr    error
+		expResult  Organization
+		expErr     error
+	}{
+		"success": {
+			mockResult: model.Organization{ID: 1, Name: "Default"},
+			expResult:  Organization{ID: 1, Name: "Default"},
+		},
+		"error_organization_not_found": {
+			mockErr: sql.ErrNoRows,
+			expErr:  ErrOrganizationNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			organizationRepoMock := new(organization.Mock)
+			organizationRepoMock.On("GetOrganization", ctx, 1).Return(tc.mockResult, tc.mockErr)
+			repoMock := new(repository.Mock)
+			repoMock.On("Organization").Return(organizationRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.GetOrganization(ctx, 1)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/bcrypt"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+)
+
+const (
+	passwordResetTokenExpireTime = 30 * time.Minute
+)
+
+// validatePassword returns ErrWeakPassword with the broken rule if the new password does not satisfy the password policy
+func validatePassword(password string) error {
+	if err := passwordPolicy().Validate(password); err != nil {
+		return fmt.Errorf("%w: %v", ErrWeakPassword, err)
+	}
+	return nil
+}
+
+// rehashPassword upgrades the password hash of the user to the current bcrypt cost after a successful login.
+// The login does not fail if the hash cannot be upgraded, it is tried again on the next login.
+func (serv impl) rehashPassword(ctx context.Context, user model.User, password string) {
+	if !bcrypt.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPass, err := bcrypt.HashPassword(password)
+	if err != nil {
+		log.Printf("Error when rehash password of user %d: %v\n", user.ID, err)
+		return
+	}
+	// The hash is only replaced if the password was not changed in the meantime
+	if _, err = serv.repo.User().UpdatePasswordHash(ctx, user.ID, user.Password, hashedPass); err != nil {
+		log.Printf("Error when rehash password of user %d: %v\n", user.ID, err)
+	}
+}
+
+// ForgotPassword sends a password reset link to the email.
+// It does not return an error for unknown emails, so the caller cannot find out which emails are registered.
+func (serv impl) ForgotPassword(ctx context.Context, email string) error {
+	// 1. Get user with email
+	user, err := serv.repo.User().GetUserByEmail(lookupContext(ctx), email)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("Skipping password reset because email does not exist")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	ctx = auth.NewTenantContext(ctx, user.OrganizationID)
+
+	// 2. Generate reset token and store its hash
+	resetToken, err := generateToken()
+	if err != nil {
+		return ErrTokeCannotBeGenerated
+	}
+	if _, err = serv.repo.Token().CreatePasswordResetToken(ctx, model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(resetToken),
+		ExpiresAt: time.Now().Add(passwordResetTokenExpireTime),
+	}); err != nil {
+		return fmt.Errorf("error when create password reset token: %v", err)
+	}
+
+	// 3. Send the reset link
+	resetURL := os.Getenv("APP_URL") + "/reset-password?token=" + resetToken
+	if err = sendEmail(mail.EmailInput{
+		To:      []string{user.Email},
+		Subject: "Reset your password",
+		Message: fmt.Sprintf("Click <a href=\"%s\">here</a> to reset your password. The link expires in %d minutes.", resetURL, int(passwordResetTokenExpireTime.Minutes())),
+	}); err != nil {
+		return fmt.Errorf("failed sending email: %v", err)
+	}
+
+	return nil
+}
+
+type ResetPasswordInput struct {
+	Token    string
+	Password string
+}
+
+// ResetPassword sets a new password by the password reset token and revokes all sessions of the user, the last passwords cannot be reused
+func (serv impl) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	// 1. Get the reset token by its hash, it must be neither used nor expired
+	resetToken, err := serv.repo.Token().GetPasswordResetTokenByHash(ctx, hashToken(input.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidToken
+	} else if err != nil {
+		return err
+	}
+	if resetToken.UsedAt.Valid || resetToken.ExpiresAt.Before(time.Now()) {
+		return ErrInvalidToken
+	}
+
+	// 2. Get the user, the last passwords cannot be reused
+	user, err := serv.repo.User().GetUser(lookupContext(ctx), resetToken.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidToken
+	} else if err != nil {
+		return err
+	}
+	ctx = auth.NewTenantContext(ctx, user.OrganizationID)
+	if err = validatePassword(input.Password); err != nil {
+		return err
+	}
+	if err = serv.checkPasswordReused(ctx, user, input.Password); err != nil {
+		return err
+	}
+
+	// 3. Hash new password by bcrypt
+	hashedPass, err := bcrypt.HashPassword(input.Password)
+	if err != nil {
+		return ErrPasswordCannotBeHashed
+	}
+
+	// 4. Mark the token as used, no affected rows means it was used concurrently
+	affected, err := serv.repo.Token().UsePasswordResetToken(ctx, resetToken.ID)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrInvalidToken
+	}
+
+	// 5. Replace the password and revoke all sessions of the user
+	if err = serv.replacePassword(ctx, user, hashedPass); err != nil {
+		return err
+	}
+
+	// 6. The token was sent to the email of the user, e.g. by the invite of an imported user, so the email is verified
+	if !user.EmailVerifiedAt.Valid {
+		if _, err = serv.repo.User().VerifyEmail(ctx, user.ID, user.Email); err != nil {
+			return err
+		}
+	}
+	return nil
+}
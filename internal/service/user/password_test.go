@@ -0,0 +1,250 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/password"
+)
+
+func TestUserService_ForgotPassword(t *testing.T) {
+	type mockData struct {
+		user    model.User
+		userErr error
+		mailErr error
+	}
+	tcs := map[string]struct {
+		email   string
+		mock    mockData
+		expSent bool
+		expErr  error
+	}{
+		"success": {
+			email: "guest@example.com",
+			mock: mockData{
+				user: model.User{ID: 1, Email: "guest@example.com"},
+			},
+			expSent: true,
+		},
+		"email_is_not_registered": {
+			email: "unknown@example.com",
+			mock: mockData{
+				userErr: sql.ErrNoRows,
+			},
+		},
+		"error_send_mail": {
+			email: "guest@example.com",
+			mock: mockData{
+				user:    model.User{ID: 1, Email: "guest@example.com"},
+				mailErr: errors.New("cannot send email"),
+			},
+			expSent: true,
+			expErr:  errors.New("failed sending email: cannot send email"),
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("APP_URL", "http://localhost:5000")
+			ctx := context.Background()
+			var sent []mail.EmailInput
+			sendEmail = func(input mail.EmailInput) error {
+				sent = append(sent, input)
+				return tc.mock.mailErr
+			}
+			defer func() { sendEmail = mail.SendEmail }()
+
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUserByEmail", auth.NewUnscopedContext(ctx), tc.email).Return(tc.mock.user, tc.mock.userErr)
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("CreatePasswordResetToken", auth.NewTenantContext(ctx, tc.mock.user.OrganizationID), mock.MatchedBy(func(rt model.PasswordResetToken) bool {
+				return rt.UserID == tc.mock.user.ID && rt.TokenHash != "" && rt.ExpiresAt.After(time.Now())
+			})).Return(model.PasswordResetToken{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Token").Return(tokenRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.ForgotPassword(ctx, tc.email)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expSent {
+				require.Len(t, sent, 1)
+				require.Equal(t, []string{tc.email}, sent[0].To)
+				require.True(t, strings.Contains(sent[0].Message, "http://localhost:5000/reset-password?token="))
+			} else {
+				require.Empty(t, sent)
+				tokenRepoMock.AssertNotCalled(t, "CreatePasswordResetToken", ctx, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUserService_ResetPassword(t *testing.T) {
+	type mockData struct {
+		resetToken    model.PasswordResetToken
+		resetTokenErr error
+		histories     model.PasswordHistorySlice
+		useAffected   int64
+		unverified    bool
+	}
+	tcs := map[string]struct {
+		input       ResetPasswordInput
+		mock        mockData
+		expUpdated  bool
+		expVerified bool
+		expErr      error
+	}{
+		"success": {
+			input: ResetPasswordInput{Token: "token1", Password: "new-password"},
+			mock: mockData{
+				resetToken:  model.PasswordResetToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)},
+				histories:   model.PasswordHistorySlice{{UserID: 1, Password: oldPasswordHash}},
+				useAffected: 1,
+			},
+			expUpdated: true,
+		},
+		"success_invite_verifies_email": {
+			input: ResetPasswordInput{Token: "token9", Password: "new-password"},
+			mock: mockData{
+				resetToken:  model.PasswordResetToken{ID: 9, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)},
+				useAffected: 1,
+				unverified:  true,
+			},
+			expUpdated:  true,
+			expVerified: true,
+		},
+		"error_not_found": {
+			input: ResetPasswordInput{Token: "token2", Password: "new-password"},
+			mock: mockData{
+				resetTokenErr: sql.ErrNoRows,
+			},
+			expErr: ErrInvalidToken,
+		},
+		"error_expired": {
+			input: ResetPasswordInput{Token: "token3", Password: "new-password"},
+			mock: mockData{
+				resetToken: model.PasswordResetToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)},
+			},
+			expErr: ErrInvalidToken,
+		},
+		"error_already_used": {
+			input: ResetPasswordInput{Token: "token4", Password: "new-password"},
+			mock: mockData{
+				resetToken: model.PasswordResetToken{ID: 4, UserID: 1, ExpiresAt: time.Now().Add(time.Minute), UsedAt: null.TimeFrom(time.Now())},
+			},
+			expErr: ErrInvalidToken,
+		},
+		"error_used_concurrently": {
+			input: ResetPasswordInput{Token: "token5", Password: "new-password"},
+			mock: mockData{
+				resetToken:  model.PasswordResetToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)},
+				useAffected: 0,
+			},
+			expErr: ErrInvalidToken,
+		},
+		"error_reused_current_password": {
+			input: ResetPasswordInput{Token: "token6", Password: "current-password"},
+			mock: mockData{
+				resetToken: model.PasswordResetToken{ID: 6, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)},
+			},
+			expErr: ErrPasswordReused,
+		},
+		"error_reused_old_password": {
+			input: ResetPasswordInput{Token: "token7", Password: "old-password"},
+			mock: mockData{
+				resetToken: model.PasswordResetToken{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)},
+				histories:  model.PasswordHistorySlice{{UserID: 1, Password: oldPasswordHash}},
+			},
+			expErr: ErrPasswordReused,
+		},
+		"error_weak_password": {
+			input: ResetPasswordInput{Token: "token8", Password: "newpassword"},
+			mock: mockData{
+				resetToken: model.PasswordResetToken{ID: 8, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)},
+			},
+			expErr: fmt.Errorf("%w: %v, it needs 2 of them", ErrWeakPassword, password.ErrTooFewCharacterClasses),
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			userCtx := auth.NewTenantContext(ctx, 2)
+			currentUser := model.User{ID: 1, Email: "test@example.com", Password: currentPasswordHash, OrganizationID: 2}
+			if !tc.mock.unverified {
+				currentUser.EmailVerifiedAt = null.TimeFrom(time.Now())
+			}
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("GetPasswordResetTokenByHash", ctx, hashToken(tc.input.Token)).Return(tc.mock.resetToken, tc.mock.resetTokenErr)
+			tokenRepoMock.On("UsePasswordResetToken", userCtx, tc.mock.resetToken.ID).Return(tc.mock.useAffected, nil)
+			tokenRepoMock.On("RevokeUserRefreshTokens", userCtx, tc.mock.resetToken.UserID).Return(int64(1), nil)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", auth.NewUnscopedContext(ctx), tc.mock.resetToken.UserID).Return(currentUser, nil)
+			userRepoMock.On("GetPasswordHistories", userCtx, tc.mock.resetToken.UserID, passwordHistorySize-1).Return(tc.mock.histories, nil)
+			userRepoMock.On("UpdatePassword", userCtx, tc.mock.resetToken.UserID, mock.AnythingOfType("string")).Return(int64(1), nil)
+			userRepoMock.On("CreatePasswordHistory", userCtx, model.PasswordHistory{UserID: 1, Password: currentPasswordHash}).Return(nil)
+			userRepoMock.On("VerifyEmail", userCtx, 1, "test@example.com").Return(int64(1), nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", userCtx, mock.Anything).Return(model.SecurityEvent{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+			repoMock.On("Token").Return(tokenRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.ResetPassword(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expUpdated {
+				userRepoMock.AssertCalled(t, "UpdatePassword", userCtx, tc.mock.resetToken.UserID, mock.AnythingOfType("string"))
+				userRepoMock.AssertCalled(t, "CreatePasswordHistory", userCtx, model.PasswordHistory{UserID: 1, Password: currentPasswordHash})
+				tokenRepoMock.AssertCalled(t, "RevokeUserRefreshTokens", userCtx, tc.mock.resetToken.UserID)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", userCtx, model.SecurityEvent{
+					UserID: null.IntFrom(1), OrganizationID: 2, Type: securityevent.TypePasswordChanged, Email: "test@example.com",
+				})
+			} else {
+				userRepoMock.AssertNotCalled(t, "UpdatePassword", userCtx, tc.mock.resetToken.UserID, mock.AnythingOfType("string"))
+				securityEventRepoMock.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything)
+			}
+			if tc.expVerified {
+				// The reset token of an invite was sent to the email of the user
+				userRepoMock.AssertCalled(t, "VerifyEmail", userCtx, 1, "test@example.com")
+			} else {
+				userRepoMock.AssertNotCalled(t, "VerifyEmail", userCtx, 1, "test@example.com")
+			}
+		})
+	}
+}
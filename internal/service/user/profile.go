@@ -0,0 +1,215 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/bcrypt"
+)
+
+// passwordHistorySize is the number of last passwords which cannot be reused, the current password included
+const passwordHistorySize = 5
+
+// Profile is the user data which the user can see, the password hash is never returned
+type Profile struct {
+	ID              int       `json:"id"`
+	Name            string    `json:"name"`
+	Email           string    `json:"email"`
+	Phone           string    `json:"phone"`
+	Role            string    `json:"role"`
+	EmailVerifiedAt null.Time `json:"email_verified_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// toProfile converts model.User to Profile
+func toProfile(user model.User) Profile {
+	return Profile{
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Phone:           user.Phone,
+		Role:            user.Role,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+}
+
+// currentUser returns the signed in user
+func (serv impl) currentUser(ctx context.Context) (model.User, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return model.User{}, ErrPermissionDenied
+	}
+
+	user, err := serv.repo.User().GetUser(ctx, caller.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.User{}, ErrUserNotFound
+	} else if err != nil {
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+// GetProfile returns the profile of the current user
+func (serv impl) GetProfile(ctx context.Context) (Profile, error) {
+	user, err := serv.currentUser(ctx)
+	if err != nil {
+		return Profile{}, err
+	}
+	return toProfile(user), nil
+}
+
+type UpdateProfileInput struct {
+	Name  string
+	Phone string
+	Email string
+}
+
+// UpdateProfile updates the name, phone and email of the current user.
+// A changed email has to be verified again, the user cannot login until then.
+func (serv impl) UpdateProfile(ctx context.Context, input UpdateProfileInput) (Profile, error) {
+	// 1. Get the current user, API keys cannot change the profile of their owner, neither can an admin who impersonates the user
+	if caller, ok := auth.FromContext(ctx); ok && caller.IsAPIKey() {
+		return Profile{}, ErrPermissionDenied
+	}
+	if err := denyImpersonation(ctx); err != nil {
+		return Profile{}, err
+	}
+	user, err := serv.currentUser(ctx)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	// 2. A new email must not be registered by another user and is not verified yet
+	emailChanged := input.Email != user.Email
+	if emailChanged {
+		existed, err := serv.repo.User().ExistsUserByEmail(ctx, input.Email)
+		if err != nil {
+			return Profile{}, err
+		}
+		if existed {
+			return Profile{}, ErrEmailExisted
+		}
+		user.EmailVerifiedAt = null.Time{}
+		user.VerificationSentAt = null.Time{}
+	}
+
+	// 3. Update the profile
+	user.Name, user.Phone, user.Email = input.Name, input.Phone, input.Email
+	affected, err := serv.repo.User().UpdateProfile(ctx, user)
+	if err != nil {
+		return Profile{}, err
+	}
+	if affected < 1 {
+		return Profile{}, ErrUserNotFound
+	}
+
+	// 4. Send the verification email to the new email, the user can request it again if sending fails
+	if emailChanged {
+		if err = serv.sendVerificationEmail(ctx, user); err != nil {
+			log.Printf("Error when send verification email to user %d: %v\n", user.ID, err)
+		}
+	}
+
+	return toProfile(user), nil
+}
+
+type ChangePasswordInput struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
+// ChangePassword sets a new password of the current user after checking the current password, all sessions of the user are signed out
+func (serv impl) ChangePassword(ctx context.Context, input ChangePasswordInput) error {
+	// 1. API keys cannot change the password of their owner, neither can an admin who impersonates the user
+	if caller, ok := auth.FromContext(ctx); ok && caller.IsAPIKey() {
+		return ErrPermissionDenied
+	}
+	if err := denyImpersonation(ctx); err != nil {
+		return err
+	}
+
+	// 2. Get the current user
+	user, err := serv.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 3. Verify the current password
+	if !bcrypt.CheckPasswordHash(input.CurrentPassword, user.Password) {
+		return ErrIncorrectPassword
+	}
+
+	// 4. The new password must satisfy the password policy, the last passwords cannot be reused
+	if err = validatePassword(input.NewPassword); err != nil {
+		return err
+	}
+	if err = serv.checkPasswordReused(ctx, user, input.NewPassword); err != nil {
+		return err
+	}
+
+	// 5. Hash new password by bcrypt
+	hashedPass, err := bcrypt.HashPassword(input.NewPassword)
+	if err != nil {
+		return ErrPasswordCannotBeHashed
+	}
+
+	// 6. Replace the password
+	return serv.replacePassword(ctx, user, hashedPass)
+}
+
+// checkPasswordReused returns ErrPasswordReused if the password is the current password or one of the last replaced passwords
+func (serv impl) checkPasswordReused(ctx context.Context, user model.User, password string) error {
+	if bcrypt.CheckPasswordHash(password, user.Password) {
+		return ErrPasswordReused
+	}
+
+	histories, err := serv.repo.User().GetPasswordHistories(ctx, user.ID, passwordHistorySize-1)
+	if err != nil {
+		return err
+	}
+	for _, history := range histories {
+		if bcrypt.CheckPasswordHash(password, history.Password) {
+			return ErrPasswordReused
+		}
+	}
+	return nil
+}
+
+// replacePassword updates the password hash of the user, keeps the replaced hash in the password history and revokes all sessions of the user
+func (serv impl) replacePassword(ctx context.Context, user model.User, hashedPass string) error {
+	// 1. Update password, access tokens issued before are rejected from now
+	affected, err := serv.repo.User().UpdatePassword(ctx, user.ID, hashedPass)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrUserNotFound
+	}
+
+	// 2. Keep the replaced password
+	if err = serv.repo.User().CreatePasswordHistory(ctx, model.PasswordHistory{
+		UserID:   user.ID,
+		Password: user.Password,
+	}); err != nil {
+		return err
+	}
+
+	// 3. Revoke all refresh tokens of the user
+	if _, err = serv.repo.Token().RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+		return err
+	}
+
+	serv.recordSecurityEvent(ctx, user, securityevent.TypePasswordChanged)
+	return nil
+}
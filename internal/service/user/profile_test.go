@@ -0,0 +1,277 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/password"
+)
+
+// bcrypt hashes of "current-password" and "old-password" with the minimum cost to keep the tests fast
+const (
+	currentPasswordHash = "$2a$04$S1tZw.7ifxdCGmf7VHMp..GkjiHI5kxHoydkuQ4VvqqshtS/b/NGS"
+	oldPasswordHash     = "$2a$04$8n0d6U.M/QmTd5e58Q.4XOHsh.5axLC5ZE1eYUGW8o09meYFPDKX6"
+)
+
+func TestUserService_GetProfile(t *testing.T) {
+	verifiedAt := time.Now()
+	tcs := map[string]struct {
+		ctx        context.Context
+		mockResult model.User
+		mockErr    error
+		expResult  Profile
+		expErr     error
+	}{
+		"success": {
+			ctx:        auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest}),
+			mockResult: model.User{ID: 1, Name: "Guest", Email: "guest@example.com", Password: currentPasswordHash, Phone: "0987654321", Role: auth.RoleGuest, EmailVerifiedAt: null.TimeFrom(verifiedAt)},
+			expResult:  Profile{ID: 1, Name: "Guest", Email: "guest@example.com", Phone: "0987654321", Role: auth.RoleGuest, EmailVerifiedAt: null.TimeFrom(verifiedAt)},
+		},
+		"error_not_signed_in": {
+			ctx:    context.Background(),
+			expErr: ErrPermissionDenied,
+		},
+		"error_user_deleted": {
+			ctx:     auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest}),
+			mockErr: sql.ErrNoRows,
+			expErr:  ErrUserNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", tc.ctx, 1).Return(tc.mockResult, tc.mockErr)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.GetProfile(tc.ctx)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, result)
+			}
+		})
+	}
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	signedIn := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest})
+	verifiedAt := null.TimeFrom(time.Now())
+	currentUser := model.User{ID: 1, Name: "Guest", Email: "guest@example.com", Password: currentPasswordHash, Phone: "0987654321", Role: auth.RoleGuest, EmailVerifiedAt: verifiedAt}
+
+	type mockData struct {
+		emailExisted bool
+		updated      model.User
+		affected     int64
+	}
+	tcs := map[string]struct {
+		ctx       context.Context // the signed in user if nil
+		input     UpdateProfileInput
+		mock      mockData
+		expResult Profile
+		expSent   bool
+		expErr    error
+	}{
+		"success_same_email": {
+			input: UpdateProfileInput{Name: "New Guest", Phone: "0123456789", Email: "guest@example.com"},
+			mock: mockData{
+				updated:  model.User{ID: 1, Name: "New Guest", Email: "guest@example.com", Password: currentPasswordHash, Phone: "0123456789", Role: auth.RoleGuest, EmailVerifiedAt: verifiedAt},
+				affected: 1,
+			},
+			expResult: Profile{ID: 1, Name: "New Guest", Email: "guest@example.com", Phone: "0123456789", Role: auth.RoleGuest, EmailVerifiedAt: verifiedAt},
+		},
+		"success_new_email": {
+			input: UpdateProfileInput{Name: "Guest", Phone: "0987654321", Email: "new@example.com"},
+			mock: mockData{
+				updated:  model.User{ID: 1, Name: "Guest", Email: "new@example.com", Password: currentPasswordHash, Phone: "0987654321", Role: auth.RoleGuest},
+				affected: 1,
+			},
+			expResult: Profile{ID: 1, Name: "Guest", Email: "new@example.com", Phone: "0987654321", Role: auth.RoleGuest},
+			expSent:   true,
+		},
+		"error_email_existed": {
+			input: UpdateProfileInput{Name: "Guest", Phone: "0987654321", Email: "admin@example.com"},
+			mock: mockData{
+				emailExisted: true,
+			},
+			expErr: ErrEmailExisted,
+		},
+		"error_api_key": {
+			ctx:    auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Scope: auth.ScopeWrite}),
+			input:  UpdateProfileInput{Name: "Guest", Phone: "0987654321", Email: "attacker@example.com"},
+			mock:   mockData{affected: 1},
+			expErr: ErrPermissionDenied,
+		},
+		"error_deleted_concurrently": {
+			input: UpdateProfileInput{Name: "New Guest", Phone: "0987654321", Email: "guest@example.com"},
+			mock: mockData{
+				updated: model.User{ID: 1, Name: "New Guest", Email: "guest@example.com", Password: currentPasswordHash, Phone: "0987654321", Role: auth.RoleGuest, EmailVerifiedAt: verifiedAt},
+			},
+			expErr: ErrUserNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = signedIn
+			}
+			var sent []mail.EmailInput
+			sendEmail = func(input mail.EmailInput) error {
+				sent = append(sent, input)
+				return nil
+			}
+			defer func() { sendEmail = mail.SendEmail }()
+
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", ctx, 1).Return(currentUser, nil)
+			userRepoMock.On("ExistsUserByEmail", ctx, tc.input.Email).Return(tc.mock.emailExisted, nil)
+			userRepoMock.On("UpdateProfile", ctx, tc.mock.updated).Return(tc.mock.affected, nil)
+			userRepoMock.On("UpdateVerificationSentAt", ctx, 1, verificationResendInterval).Return(int64(1), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.UpdateProfile(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				userRepoMock.AssertNotCalled(t, "UpdateVerificationSentAt", ctx, 1, verificationResendInterval)
+				if tc.mock.updated.ID == 0 {
+					userRepoMock.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything)
+				}
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, result)
+			}
+			if tc.expSent {
+				require.Len(t, sent, 1)
+				require.Equal(t, []string{tc.input.Email}, sent[0].To)
+			} else {
+				require.Empty(t, sent)
+			}
+		})
+	}
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	signedIn := auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest})
+	tcs := map[string]struct {
+		ctx        context.Context
+		input      ChangePasswordInput
+		histories  model.PasswordHistorySlice
+		expUpdated bool
+		expErr     error
+	}{
+		"success": {
+			ctx:        signedIn,
+			input:      ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "new-password"},
+			histories:  model.PasswordHistorySlice{{UserID: 1, Password: oldPasswordHash}},
+			expUpdated: true,
+		},
+		"error_incorrect_current_password": {
+			ctx:    signedIn,
+			input:  ChangePasswordInput{CurrentPassword: "wrong-password", NewPassword: "new-password"},
+			expErr: ErrIncorrectPassword,
+		},
+		"error_reused_current_password": {
+			ctx:    signedIn,
+			input:  ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "current-password"},
+			expErr: ErrPasswordReused,
+		},
+		"error_reused_old_password": {
+			ctx:       signedIn,
+			input:     ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "old-password"},
+			histories: model.PasswordHistorySlice{{UserID: 1, Password: oldPasswordHash}},
+			expErr:    ErrPasswordReused,
+		},
+		"error_weak_password": {
+			ctx:    signedIn,
+			input:  ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "qwerty123"},
+			expErr: fmt.Errorf("%w: %v", ErrWeakPassword, password.ErrTooCommon),
+		},
+		"error_api_key": {
+			ctx:    auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, Scope: auth.ScopeWrite}),
+			input:  ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "new-password"},
+			expErr: ErrPermissionDenied,
+		},
+		"error_not_signed_in": {
+			ctx:    context.Background(),
+			input:  ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "new-password"},
+			expErr: ErrPermissionDenied,
+		},
+		"error_impersonated": {
+			ctx:    auth.NewContext(context.Background(), auth.User{ID: 1, Role: auth.RoleGuest, ActorID: 2}),
+			input:  ChangePasswordInput{CurrentPassword: "current-password", NewPassword: "new-password"},
+			expErr: ErrImpersonationNotAllowed,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", tc.ctx, 1).Return(model.User{ID: 1, Password: currentPasswordHash}, nil)
+			userRepoMock.On("GetPasswordHistories", tc.ctx, 1, passwordHistorySize-1).Return(tc.histories, nil)
+			userRepoMock.On("UpdatePassword", tc.ctx, 1, mock.AnythingOfType("string")).Return(int64(1), nil)
+			userRepoMock.On("CreatePasswordHistory", tc.ctx, model.PasswordHistory{UserID: 1, Password: currentPasswordHash}).Return(nil)
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("RevokeUserRefreshTokens", tc.ctx, 1).Return(int64(1), nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", tc.ctx, mock.Anything).Return(model.SecurityEvent{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Token").Return(tokenRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.ChangePassword(tc.ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expUpdated {
+				userRepoMock.AssertCalled(t, "CreatePasswordHistory", tc.ctx, model.PasswordHistory{UserID: 1, Password: currentPasswordHash})
+				tokenRepoMock.AssertCalled(t, "RevokeUserRefreshTokens", tc.ctx, 1)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", tc.ctx, model.SecurityEvent{
+					UserID: null.IntFrom(1), Type: securityevent.TypePasswordChanged,
+				})
+			} else {
+				userRepoMock.AssertNotCalled(t, "UpdatePassword", tc.ctx, 1, mock.AnythingOfType("string"))
+				securityEventRepoMock.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+type Role struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type RoleInput struct {
+	ID          int
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// toRole converts model.Role with its loaded permissions to Role
+func toRole(role model.Role) Role {
+	permissions := []string{}
+	if role.R != nil {
+		for _, p := range role.R.Permissions {
+			permissions = append(permissions, p.Name)
+		}
+	}
+	return Role{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// isBuiltInRole returns true for the roles the application relies on, they cannot be renamed or deleted
+func isBuiltInRole(name string) bool {
+	return name == auth.RoleAdmin || name == auth.RoleGuest
+}
+
+// guestPermissions are the permissions of the GUEST role of a new organization, its ADMIN role has all permissions
+// except the platform permissions
+var guestPermissions = []string{auth.PermProductWrite, auth.PermOrderRead, auth.PermOrderWrite}
+
+// isPlatformOrganization returns true if ctx is scoped to the default organization, only its roles can have the platform permissions
+func isPlatformOrganization(ctx context.Context) bool {
+	organizationID, ok := auth.TenantFromContext(ctx)
+	return !ok || organizationID == auth.DefaultOrganizationID
+}
+
+// checkRoleExists returns ErrRoleNotFound if there is no role with the name
+func (serv impl) checkRoleExists(ctx context.Context, name string) error {
+	existed, err := serv.repo.Role().ExistsRoleByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !existed {
+		return ErrRoleNotFound
+	}
+	return nil
+}
+
+// canManageRoles returns true if the caller can manage roles. Only they can give a role other than GUEST
+// or change the role of a user, user:write alone would let a user manager make anyone an admin.
+func canManageRoles(ctx context.Context) bool {
+	caller, ok := auth.FromContext(ctx)
+	return ok && caller.HasPermission(auth.PermRoleWrite)
+}
+
+// GetRoles returns all roles with their permissions
+func (serv impl) GetRoles(ctx context.Context) ([]Role, error) {
+	roles, err := serv.repo.Role().GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Role, len(roles))
+	for i, role := range roles {
+		result[i] = toRole(*role)
+	}
+	return result, nil
+}
+
+// GetRole returns the role with the given id
+func (serv impl) GetRole(ctx context.Context, id int) (Role, error) {
+	role, err := serv.repo.Role().GetRole(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Role{}, ErrRoleNotFound
+	} else if err != nil {
+		return Role{}, err
+	}
+	return toRole(role), nil
+}
+
+// getPermissionsByNames returns the permissions with the given names, all of them must exist
+func (serv impl) getPermissionsByNames(ctx context.Context, names []string) (model.PermissionSlice, error) {
+	permissions, err := serv.repo.Role().GetPermissionsByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	existed := map[string]bool{}
+	for _, p := range permissions {
+		existed[p.Name] = true
+	}
+	for _, name := range names {
+		if !existed[name] || (auth.IsPlatformPermission(name) && !isPlatformOrganization(ctx)) {
+			return nil, ErrInvalidPermission
+		}
+	}
+	return permissions, nil
+}
+
+// createBuiltInRoles creates the ADMIN and GUEST roles in the new organization ctx is scoped to, in the transaction of the organization
+func (serv impl) createBuiltInRoles(ctx context.Context, tx *sql.Tx) error {
+	// 1. Get the permissions of the roles
+	permissions, err := serv.repo.Role().GetPermissions(ctx)
+	if err != nil {
+		return err
+	}
+	var adminPermissions, guestRolePermissions model.PermissionSlice
+	for _, p := range permissions {
+		if !auth.IsPlatformPermission(p.Name) {
+			adminPermissions = append(adminPermissions, p)
+		}
+		for _, name := range guestPermissions {
+			if p.Name == name {
+				guestRolePermissions = append(guestRolePermissions, p)
+			}
+		}
+	}
+
+	// 2. Create the roles with their permissions
+	roles := []struct {
+		role        model.Role
+		permissions model.PermissionSlice
+	}{
+		{role: model.Role{Name: auth.RoleAdmin, Description: "Administrator"}, permissions: adminPermissions},
+		{role: model.Role{Name: auth.RoleGuest, Description: "Customer and seller"}, permissions: guestRolePermissions},
+	}
+	for _, r := range roles {
+		created, err := serv.repo.Role().CreateRole(ctx, tx, r.role)
+		if err != nil {
+			return err
+		}
+		if err = serv.repo.Role().SetRolePermissions(ctx, tx, created.ID, r.permissions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateRole creates a new role with the given permissions
+func (serv impl) CreateRole(ctx context.Context, input RoleInput) (Role, error) {
+	// 1. Check exist role with this name
+	existed, err := serv.repo.Role().ExistsRoleByName(ctx, input.Name)
+	if err != nil {
+		return Role{}, err
+	}
+	if existed {
+		return Role{}, ErrRoleExisted
+	}
+
+	// 2. Get the permissions
+	permissions, err := serv.getPermissionsByNames(ctx, input.Permissions)
+	if err != nil {
+		return Role{}, err
+	}
+
+	// 3. Create the role with its permissions
+	var created model.Role
+	if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		created, err = serv.repo.Role().CreateRole(ctx, tx, model.Role{
+			Name:        input.Name,
+			Description: input.Description,
+		})
+		if err != nil {
+			return err
+		}
+		return serv.repo.Role().SetRolePermissions(ctx, tx, created.ID, permissions)
+	}); err != nil {
+		return Role{}, err
+	}
+
+	result := toRole(created)
+	result.Permissions = input.Permissions
+	return result, nil
+}
+
+// UpdateRole updates the role and replaces its permissions, renaming a role renames the primary role of its users
+func (serv impl) UpdateRole(ctx context.Context, input RoleInput) error {
+	// 1. Get the current role
+	current, err := serv.repo.Role().GetRole(ctx, input.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrRoleNotFound
+	} else if err != nil {
+		return err
+	}
+
+	// 2. Check the new name
+	renamed := current.Name != input.Name
+	if renamed {
+		if isBuiltInRole(current.Name) {
+			return ErrBuiltInRole
+		}
+		existed, err := serv.repo.Role().ExistsRoleByName(ctx, input.Name)
+		if err != nil {
+			return err
+		}
+		if existed {
+			return ErrRoleExisted
+		}
+	}
+
+	// 3. Get the permissions
+	permissions, err := serv.getPermissionsByNames(ctx, input.Permissions)
+	if err != nil {
+		return err
+	}
+
+	// 4. Update the role, its permissions and its users
+	return serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		if _, err := serv.repo.Role().UpdateRole(ctx, tx, model.Role{
+			ID:          input.ID,
+			Name:        input.Name,
+			Description: input.Description,
+		}); err != nil {
+			return err
+		}
+		if err := serv.repo.Role().SetRolePermissions(ctx, tx, input.ID, permissions); err != nil {
+			return err
+		}
+		if renamed {
+			if _, err := serv.repo.Role().RenameUsersRole(ctx, tx, current.Name, input.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteRole deletes the role, it cannot be deleted while it is the primary role of a user
+func (serv impl) DeleteRole(ctx context.Context, id int) error {
+	// 1. Get the role
+	current, err := serv.repo.Role().GetRole(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrRoleNotFound
+	} else if err != nil {
+		return err
+	}
+	if isBuiltInRole(current.Name) {
+		return ErrBuiltInRole
+	}
+
+	// 2. Check the users of the role
+	inUse, err := serv.repo.Role().ExistsUserWithRole(ctx, current.Name)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrRoleInUse
+	}
+
+	// 3. Delete the role, it is removed from the additional roles of users
+	result, err := serv.repo.Role().DeleteRole(ctx, id)
+	if err != nil {
+		return err
+	}
+	if result < 1 {
+		return ErrRoleNotFound
+	}
+	return nil
+}
+
+// GetPermissions returns all permissions which can be granted to the roles of the organization,
+// the platform permissions are only returned to the default organization
+func (serv impl) GetPermissions(ctx context.Context) ([]model.Permission, error) {
+	permissionSlice, err := serv.repo.Role().GetPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]model.Permission, 0, len(permissionSlice))
+	for i := 0; i < len(permissionSlice); i++ {
+		if auth.IsPlatformPermission(permissionSlice[i].Name) && !isPlatformOrganization(ctx) {
+			continue
+		}
+		permissions = append(permissions, *permissionSlice[i])
+	}
+	return permissions, nil
+}
+
+// UserRoles are the roles of a user, Role is the primary role and Roles are the additional roles
+type UserRoles struct {
+	Role  string   `json:"role"`
+	Roles []string `json:"roles"`
+}
+
+// GetUserRoles returns the roles of the user
+func (serv impl) GetUserRoles(ctx context.Context, userID int) (UserRoles, error) {
+	// 1. Get the user
+	user, err := serv.repo.User().GetUser(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return UserRoles{}, ErrUserNotFound
+	} else if err != nil {
+		return UserRoles{}, err
+	}
+
+	// 2. Get the additional roles
+	roles, err := serv.repo.Role().GetUserRoles(ctx, userID)
+	if err != nil {
+		return UserRoles{}, err
+	}
+
+	result := UserRoles{Role: user.Role, Roles: make([]string, len(roles))}
+	for i, role := range roles {
+		result.Roles[i] = role.Name
+	}
+	return result, nil
+}
+
+// SetUserRoles replaces the additional roles of the user, only users who can manage roles can replace them
+func (serv impl) SetUserRoles(ctx context.Context, userID int, roleNames []string) error {
+	if !canManageRoles(ctx) {
+		return ErrPermissionDenied
+	}
+
+	// 1. Check exist user
+	existed, err := serv.repo.User().ExistsUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !existed {
+		return ErrUserNotFound
+	}
+
+	// 2. Get the roles, all of them must exist
+	roles, err := serv.repo.Role().GetRolesByNames(ctx, roleNames)
+	if err != nil {
+		return err
+	}
+	if len(roles) != len(roleNames) {
+		return ErrRoleNotFound
+	}
+
+	// 3. Replace the roles
+	if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		return serv.repo.Role().SetUserRoles(ctx, tx, userID, roles)
+	}); err != nil {
+		return err
+	}
+
+	serv.recordSecurityEvent(ctx, model.User{ID: userID}, securityevent.TypeRoleChanged)
+	return nil
+}
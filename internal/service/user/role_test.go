@@ -0,0 +1,353 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestUserService_CreateRole(t *testing.T) {
+	type mockData struct {
+		existed     bool
+		permissions model.PermissionSlice
+	}
+	tcs := map[string]struct {
+		ctx       context.Context
+		input     RoleInput
+		mock      mockData
+		expResult Role
+		expErr    error
+	}{
+		"success": {
+			input: RoleInput{Name: "WAREHOUSE", Description: "Warehouse scripts", Permissions: []string{auth.PermProductWrite, auth.PermProductWriteAny}},
+			mock: mockData{
+				permissions: model.PermissionSlice{{ID: 5, Name: auth.PermProductWrite}, {ID: 6, Name: auth.PermProductWriteAny}},
+			},
+			expResult: Role{ID: 3, Name: "WAREHOUSE", Description: "Warehouse scripts", Permissions: []string{auth.PermProductWrite, auth.PermProductWriteAny}},
+		},
+		"error_role_existed": {
+			input: RoleInput{Name: "ADMIN", Permissions: []string{auth.PermProductWrite}},
+			mock: mockData{
+				existed: true,
+			},
+			expErr: ErrRoleExisted,
+		},
+		"error_invalid_permission": {
+			input: RoleInput{Name: "WAREHOUSE", Permissions: []string{auth.PermProductWrite, "product:delete"}},
+			mock: mockData{
+				permissions: model.PermissionSlice{{ID: 5, Name: auth.PermProductWrite}},
+			},
+			expErr: ErrInvalidPermission,
+		},
+		"success_platform_permission_in_default_organization": {
+			ctx:   auth.NewTenantContext(context.Background(), auth.DefaultOrganizationID),
+			input: RoleInput{Name: "OPERATOR", Permissions: []string{auth.PermOrganizationRead}},
+			mock: mockData{
+				permissions: model.PermissionSlice{{ID: 13, Name: auth.PermOrganizationRead}},
+			},
+			expResult: Role{ID: 3, Name: "OPERATOR", Permissions: []string{auth.PermOrganizationRead}},
+		},
+		"error_platform_permission_in_other_organization": {
+			ctx:   auth.NewTenantContext(context.Background(), 2),
+			input: RoleInput{Name: "OPERATOR", Permissions: []string{auth.PermOrganizationRead}},
+			mock: mockData{
+				permissions: model.PermissionSlice{{ID: 13, Name: auth.PermOrganizationRead}},
+			},
+			expErr: ErrInvalidPermission,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			if tc.ctx != nil {
+				ctx = tc.ctx
+			}
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("ExistsRoleByName", ctx, tc.input.Name).Return(tc.mock.existed, nil)
+			roleRepoMock.On("GetPermissionsByNames", ctx, tc.input.Permissions).Return(tc.mock.permissions, nil)
+			roleRepoMock.On("CreateRole", ctx, (*sql.Tx)(nil), model.Role{Name: tc.input.Name, Description: tc.input.Description}).
+				Return(model.Role{ID: 3, Name: tc.input.Name, Description: tc.input.Description}, nil)
+			roleRepoMock.On("SetRolePermissions", ctx, (*sql.Tx)(nil), 3, tc.mock.permissions).Return(nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(func(*sql.Tx) error)(nil)
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.CreateRole(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				roleRepoMock.AssertNotCalled(t, "CreateRole", ctx, (*sql.Tx)(nil), mock.Anything)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, result)
+				roleRepoMock.AssertCalled(t, "SetRolePermissions", ctx, (*sql.Tx)(nil), 3, tc.mock.permissions)
+			}
+		})
+	}
+}
+
+func TestUserService_UpdateRole(t *testing.T) {
+	type mockData struct {
+		current    model.Role
+		currentErr error
+		existed    bool
+	}
+	tcs := map[string]struct {
+		input      RoleInput
+		mock       mockData
+		expRenamed bool
+		expErr     error
+	}{
+		"success_update_permissions": {
+			input: RoleInput{ID: 1, Name: "ADMIN", Permissions: []string{auth.PermUserRead}},
+			mock: mockData{
+				current: model.Role{ID: 1, Name: "ADMIN"},
+			},
+		},
+		"success_rename": {
+			input: RoleInput{ID: 3, Name: "STOCK", Permissions: []string{auth.PermUserRead}},
+			mock: mockData{
+				current: model.Role{ID: 3, Name: "WAREHOUSE"},
+			},
+			expRenamed: true,
+		},
+		"error_rename_built_in_role": {
+			input: RoleInput{ID: 1, Name: "ROOT", Permissions: []string{auth.PermUserRead}},
+			mock: mockData{
+				current: model.Role{ID: 1, Name: "ADMIN"},
+			},
+			expErr: ErrBuiltInRole,
+		},
+		"error_rename_to_existing_role": {
+			input: RoleInput{ID: 3, Name: "GUEST", Permissions: []string{auth.PermUserRead}},
+			mock: mockData{
+				current: model.Role{ID: 3, Name: "WAREHOUSE"},
+				existed: true,
+			},
+			expErr: ErrRoleExisted,
+		},
+		"error_not_found": {
+			input: RoleInput{ID: 4, Name: "REPORTER", Permissions: []string{auth.PermUserRead}},
+			mock: mockData{
+				currentErr: sql.ErrNoRows,
+			},
+			expErr: ErrRoleNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			permissions := model.PermissionSlice{{ID: 1, Name: auth.PermUserRead}}
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("GetRole", ctx, tc.input.ID).Return(tc.mock.current, tc.mock.currentErr)
+			roleRepoMock.On("ExistsRoleByName", ctx, tc.input.Name).Return(tc.mock.existed, nil)
+			roleRepoMock.On("GetPermissionsByNames", ctx, tc.input.Permissions).Return(permissions, nil)
+			roleRepoMock.On("UpdateRole", ctx, (*sql.Tx)(nil), mock.AnythingOfType("model.Role")).Return(int64(1), nil)
+			roleRepoMock.On("SetRolePermissions", ctx, (*sql.Tx)(nil), tc.input.ID, permissions).Return(nil)
+			roleRepoMock.On("RenameUsersRole", ctx, (*sql.Tx)(nil), tc.mock.current.Name, tc.input.Name).Return(int64(1), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(func(*sql.Tx) error)(nil)
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.UpdateRole(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				roleRepoMock.AssertNotCalled(t, "UpdateRole", ctx, (*sql.Tx)(nil), mock.Anything)
+			} else {
+				require.NoError(t, err)
+				roleRepoMock.AssertCalled(t, "SetRolePermissions", ctx, (*sql.Tx)(nil), tc.input.ID, permissions)
+			}
+			if tc.expRenamed {
+				roleRepoMock.AssertCalled(t, "RenameUsersRole", ctx, (*sql.Tx)(nil), tc.mock.current.Name, tc.input.Name)
+			} else {
+				roleRepoMock.AssertNotCalled(t, "RenameUsersRole", ctx, (*sql.Tx)(nil), mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUserService_DeleteRole(t *testing.T) {
+	type mockData struct {
+		current    model.Role
+		currentErr error
+		inUse      bool
+	}
+	tcs := map[string]struct {
+		id         int
+		mock       mockData
+		expDeleted bool
+		expErr     error
+	}{
+		"success": {
+			id:         3,
+			mock:       mockData{current: model.Role{ID: 3, Name: "WAREHOUSE", CreatedAt: time.Now()}},
+			expDeleted: true,
+		},
+		"error_built_in_role": {
+			id:     2,
+			mock:   mockData{current: model.Role{ID: 2, Name: "GUEST"}},
+			expErr: ErrBuiltInRole,
+		},
+		"error_primary_role_of_users": {
+			id:     3,
+			mock:   mockData{current: model.Role{ID: 3, Name: "WAREHOUSE"}, inUse: true},
+			expErr: ErrRoleInUse,
+		},
+		"error_not_found": {
+			id:     4,
+			mock:   mockData{currentErr: sql.ErrNoRows},
+			expErr: ErrRoleNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("GetRole", ctx, tc.id).Return(tc.mock.current, tc.mock.currentErr)
+			roleRepoMock.On("ExistsUserWithRole", ctx, tc.mock.current.Name).Return(tc.mock.inUse, nil)
+			roleRepoMock.On("DeleteRole", ctx, tc.id).Return(int64(1), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Role").Return(roleRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.DeleteRole(ctx, tc.id)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expDeleted {
+				roleRepoMock.AssertCalled(t, "DeleteRole", ctx, tc.id)
+			} else {
+				roleRepoMock.AssertNotCalled(t, "DeleteRole", ctx, tc.id)
+			}
+		})
+	}
+}
+
+func TestUserService_SetUserRoles(t *testing.T) {
+	type mockData struct {
+		userExist bool
+		roles     model.RoleSlice
+	}
+	tcs := map[string]struct {
+		caller    auth.User
+		roleNames []string
+		mock      mockData
+		expErr    error
+	}{
+		"success": {
+			caller:    auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite, auth.PermRoleWrite}},
+			roleNames: []string{"WAREHOUSE"},
+			mock: mockData{
+				userExist: true,
+				roles:     model.RoleSlice{{ID: 3, Name: "WAREHOUSE"}},
+			},
+		},
+		"success_remove_all_roles": {
+			caller:    auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite, auth.PermRoleWrite}},
+			roleNames: []string{},
+			mock: mockData{
+				userExist: true,
+				roles:     model.RoleSlice{},
+			},
+		},
+		"error_role_not_found": {
+			caller:    auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite, auth.PermRoleWrite}},
+			roleNames: []string{"WAREHOUSE", "REPORTER"},
+			mock: mockData{
+				userExist: true,
+				roles:     model.RoleSlice{{ID: 3, Name: "WAREHOUSE"}},
+			},
+			expErr: ErrRoleNotFound,
+		},
+		"error_permission_denied": {
+			caller:    auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite}},
+			roleNames: []string{"ADMIN"},
+			mock: mockData{
+				userExist: true,
+				roles:     model.RoleSlice{{ID: 1, Name: "ADMIN"}},
+			},
+			expErr: ErrPermissionDenied,
+		},
+		"error_user_not_found": {
+			caller:    auth.User{ID: 2, Role: auth.RoleAdmin, Permissions: []string{auth.PermUserWrite, auth.PermRoleWrite}},
+			roleNames: []string{"WAREHOUSE"},
+			expErr:    ErrUserNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), tc.caller)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("ExistsUserByID", ctx, 1).Return(tc.mock.userExist, nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("GetRolesByNames", ctx, tc.roleNames).Return(tc.mock.roles, nil)
+			roleRepoMock.On("SetUserRoles", ctx, (*sql.Tx)(nil), 1, tc.mock.roles).Return(nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", ctx, mock.Anything).Return(model.SecurityEvent{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(func(*sql.Tx) error)(nil)
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.SetUserRoles(ctx, 1, tc.roleNames)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				roleRepoMock.AssertNotCalled(t, "SetUserRoles", ctx, (*sql.Tx)(nil), 1, mock.Anything)
+				securityEventRepoMock.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				roleRepoMock.AssertCalled(t, "SetUserRoles", ctx, (*sql.Tx)(nil), 1, tc.mock.roles)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", ctx, model.SecurityEvent{
+					UserID: null.IntFrom(1), Type: securityevent.TypeRoleChanged,
+				})
+			}
+		})
+	}
+}
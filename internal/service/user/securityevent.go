@@ -0,0 +1,107 @@
+package user
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+// SecurityEvent is a login or a sensitive operation of a user
+type SecurityEvent struct {
+	ID int `json:"id"`
+	// UserID is zero for failed logins of unknown emails
+	UserID    int       `json:"user_id,omitempty"`
+	Type      string    `json:"type"`
+	Email     string    `json:"email"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SecurityEventTypes are the types of the security events
+var SecurityEventTypes = map[string]bool{
+	securityevent.TypeLoginSucceeded:  true,
+	securityevent.TypeLoginFailed:     true,
+	securityevent.TypePasswordChanged: true,
+	securityevent.TypeRoleChanged:     true,
+	securityevent.TypeTokenRevoked:    true,
+}
+
+type SecurityEventsInput struct {
+	UserID     int
+	Type       string
+	From       time.Time
+	To         time.Time
+	Pagination Pagination
+}
+
+// toSecurityEvent converts model.SecurityEvent to SecurityEvent
+func toSecurityEvent(event model.SecurityEvent) SecurityEvent {
+	return SecurityEvent{
+		ID:        event.ID,
+		UserID:    event.UserID.Int,
+		Type:      event.Type,
+		Email:     event.Email,
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+// recordSecurityEvent records the event of the user with the client of the request, the user has only an email for unknown emails.
+// The operation does not fail if the event cannot be recorded, the error is logged.
+func (serv impl) recordSecurityEvent(ctx context.Context, user model.User, eventType string) {
+	client := auth.ClientFromContext(ctx)
+	event := model.SecurityEvent{
+		OrganizationID: user.OrganizationID,
+		Type:           eventType,
+		Email:          user.Email,
+		IPAddress:      client.IPAddress,
+		UserAgent:      client.UserAgent,
+	}
+	if user.ID > 0 {
+		event.UserID = null.IntFrom(user.ID)
+	}
+
+	if _, err := serv.repo.SecurityEvent().CreateSecurityEvent(ctx, event); err != nil {
+		log.Printf("Error when record security event %s of user %d: %v\n", eventType, user.ID, err)
+	}
+}
+
+// GetSecurityEvents returns the security events of the organization by input with their total count, the latest first
+func (serv impl) GetSecurityEvents(ctx context.Context, input SecurityEventsInput) ([]SecurityEvent, int64, error) {
+	events, totalCount, err := serv.repo.SecurityEvent().GetSecurityEvents(ctx, securityevent.Filter{
+		UserID: input.UserID,
+		Type:   input.Type,
+		From:   input.From,
+		To:     input.To,
+		Pagination: securityevent.Pagination{
+			Page:  input.Pagination.Page,
+			Limit: input.Pagination.Limit,
+		},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]SecurityEvent, len(events))
+	for i, event := range events {
+		result[i] = toSecurityEvent(event)
+	}
+	return result, totalCount, nil
+}
+
+// GetCurrentUserSecurityEvents returns the recent security events of the current user, the latest first
+func (serv impl) GetCurrentUserSecurityEvents(ctx context.Context, pagination Pagination) ([]SecurityEvent, int64, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, 0, ErrPermissionDenied
+	}
+	return serv.GetSecurityEvents(ctx, SecurityEventsInput{UserID: caller.ID, Pagination: pagination})
+}
@@ -0,0 +1,171 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+)
+
+func TestUserService_recordSecurityEvent(t *testing.T) {
+	ctx := auth.NewClientContext(context.Background(), auth.Client{IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1"})
+	tcs := map[string]struct {
+		user     model.User
+		mockErr  error
+		expEvent model.SecurityEvent
+	}{
+		"success": {
+			user: model.User{ID: 10, OrganizationID: 1, Email: "mai@example.com"},
+			expEvent: model.SecurityEvent{
+				UserID: null.IntFrom(10), OrganizationID: 1, Type: securityevent.TypeLoginFailed,
+				Email: "mai@example.com", IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1",
+			},
+		},
+		"success_unknown_email": {
+			user: model.User{Email: "unknown@example.com"},
+			expEvent: model.SecurityEvent{
+				Type: securityevent.TypeLoginFailed, Email: "unknown@example.com", IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1",
+			},
+		},
+		"error_repo_is_ignored": {
+			user:    model.User{ID: 10, OrganizationID: 1, Email: "mai@example.com"},
+			mockErr: errors.New("database error"),
+			expEvent: model.SecurityEvent{
+				UserID: null.IntFrom(10), OrganizationID: 1, Type: securityevent.TypeLoginFailed,
+				Email: "mai@example.com", IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1",
+			},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", ctx, tc.expEvent).Return(model.SecurityEvent{}, tc.mockErr)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+
+			userServ := impl{repo: repoMock}
+
+			// WHEN
+			userServ.recordSecurityEvent(ctx, tc.user, securityevent.TypeLoginFailed)
+
+			// THEN
+			securityEventRepoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_GetSecurityEvents(t *testing.T) {
+	createdAt := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	tcs := map[string]struct {
+		input     SecurityEventsInput
+		mockErr   error
+		expFilter securityevent.Filter
+		expErr    error
+	}{
+		"success": {
+			input: SecurityEventsInput{
+				UserID:     10,
+				Type:       securityevent.TypeLoginFailed,
+				From:       createdAt,
+				Pagination: Pagination{Page: 2, Limit: 10},
+			},
+			expFilter: securityevent.Filter{
+				UserID:     10,
+				Type:       securityevent.TypeLoginFailed,
+				From:       createdAt,
+				Pagination: securityevent.Pagination{Page: 2, Limit: 10},
+			},
+		},
+		"error_repo": {
+			input:     SecurityEventsInput{Pagination: Pagination{Page: 1, Limit: 20}},
+			mockErr:   errors.New("database error"),
+			expFilter: securityevent.Filter{Pagination: securityevent.Pagination{Page: 1, Limit: 20}},
+			expErr:    errors.New("database error"),
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("GetSecurityEvents", ctx, tc.expFilter).Return([]model.SecurityEvent{{
+				ID: 1, UserID: null.IntFrom(10), OrganizationID: 1, Type: securityevent.TypeLoginFailed,
+				Email: "mai@example.com", IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1", CreatedAt: createdAt,
+			}}, int64(1), tc.mockErr)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, totalCount, err := userServ.GetSecurityEvents(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(1), totalCount)
+			require.Equal(t, []SecurityEvent{{
+				ID: 1, UserID: 10, Type: securityevent.TypeLoginFailed,
+				Email: "mai@example.com", IPAddress: "192.0.2.1", UserAgent: "curl/7.79.1", CreatedAt: createdAt,
+			}}, result)
+		})
+	}
+}
+
+func TestUserService_GetCurrentUserSecurityEvents(t *testing.T) {
+	tcs := map[string]struct {
+		ctx    context.Context
+		expErr error
+	}{
+		"success": {
+			ctx: auth.NewContext(context.Background(), auth.User{ID: 10, Role: auth.RoleGuest}),
+		},
+		"error_no_current_user": {
+			ctx:    context.Background(),
+			expErr: ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("GetSecurityEvents", tc.ctx, securityevent.Filter{
+				UserID:     10,
+				Pagination: securityevent.Pagination{Page: 1, Limit: 20},
+			}).Return([]model.SecurityEvent{}, int64(0), nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, _, err := userServ.GetCurrentUserSecurityEvents(tc.ctx, Pagination{Page: 1, Limit: 20})
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				securityEventRepoMock.AssertNotCalled(t, "GetSecurityEvents", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Empty(t, result)
+			securityEventRepoMock.AssertExpectations(t)
+		})
+	}
+}
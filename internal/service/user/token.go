@@ -0,0 +1,214 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/lestrrat-go/jwx/jwk"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+)
+
+// generateToken returns a random URL-safe token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hash of the token which is stored in the database instead of the token itself
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// signToken signs the token with the active key of the JWT key set
+func signToken(input jwt.JWTInput) (string, error) {
+	keys, err := jwt.KeySetFromEnv()
+	if err != nil {
+		return "", err
+	}
+	_, token, err := keys.Sign(input)
+	return token, err
+}
+
+// parseToken verifies the token with the JWT key set and returns its claims
+func parseToken(token string) (jwt.JWTClaims, error) {
+	keys, err := jwt.KeySetFromEnv()
+	if err != nil {
+		return jwt.JWTClaims{}, err
+	}
+	return keys.Parse(token)
+}
+
+// GetJWKS returns the public keys of the JWT key set, it is empty if tokens are signed with the HS256 secret key
+func (serv impl) GetJWKS(ctx context.Context) (jwk.Set, error) {
+	keys, err := jwt.KeySetFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return keys.PublicKeys()
+}
+
+// issueTokens generates a new access token and refresh token for the user.
+// The refresh token belongs to the given family, a new family is started if familyID is empty.
+func (serv impl) issueTokens(ctx context.Context, user model.User, familyID string) (LoginResponse, error) {
+	// 1. Generate access_token
+	accessToken, err := signToken(jwt.JWTInput{
+		ID:             user.ID,
+		Email:          user.Email,
+		Role:           user.Role,
+		OrganizationID: user.OrganizationID,
+		ExpiresIn:      tokenExpireTime,
+	})
+	if err != nil {
+		return LoginResponse{}, ErrTokeCannotBeGenerated
+	}
+
+	// 2. Generate refresh_token
+	refreshToken, err := generateToken()
+	if err != nil {
+		return LoginResponse{}, ErrTokeCannotBeGenerated
+	}
+	if familyID == "" {
+		family, err := uuid.NewV4()
+		if err != nil {
+			return LoginResponse{}, ErrTokeCannotBeGenerated
+		}
+		familyID = family.String()
+	}
+
+	// 3. Store the hash of refresh_token
+	if _, err = serv.repo.Token().CreateRefreshToken(ctx, model.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(refreshTokenExpireTime),
+	}); err != nil {
+		return LoginResponse{}, fmt.Errorf("error when create refresh token: %v", err)
+	}
+
+	return LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Scope:        user.Role,
+		ExpiresIn:    tokenExpireTime,
+		TokenType:    "Bearer",
+	}, nil
+}
+
+// RefreshToken rotates the refresh token and returns a new pair of tokens.
+// Using a refresh token which was already rotated or revoked revokes the whole token family.
+func (serv impl) RefreshToken(ctx context.Context, refreshToken string) (LoginResponse, error) {
+	// 1. Get refresh token by its hash
+	current, err := serv.repo.Token().GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return LoginResponse{}, ErrInvalidToken
+	} else if err != nil {
+		return LoginResponse{}, err
+	}
+
+	// 2. Reuse detection: a revoked token is presented, revoke all tokens of its family
+	if current.RevokedAt.Valid {
+		if _, err = serv.repo.Token().RevokeTokenFamily(ctx, current.FamilyID); err != nil {
+			return LoginResponse{}, err
+		}
+		return LoginResponse{}, ErrInvalidToken
+	}
+
+	if current.ExpiresAt.Before(time.Now()) {
+		return LoginResponse{}, ErrInvalidToken
+	}
+
+	// 3. Revoke the current token, no affected rows means it was rotated concurrently
+	affected, err := serv.repo.Token().RevokeRefreshToken(ctx, current.ID)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+	if affected == 0 {
+		if _, err = serv.repo.Token().RevokeTokenFamily(ctx, current.FamilyID); err != nil {
+			return LoginResponse{}, err
+		}
+		return LoginResponse{}, ErrInvalidToken
+	}
+
+	// 4. Issue new tokens in the same family with the latest user data
+	user, err := serv.repo.User().GetUser(lookupContext(ctx), current.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return LoginResponse{}, ErrInvalidToken
+	} else if err != nil {
+		return LoginResponse{}, err
+	}
+
+	// 5. The sessions of the user were revoked after the token was issued, e.g. the user was deleted
+	if user.SessionsRevokedAt.Valid && current.CreatedAt.Before(user.SessionsRevokedAt.Time) {
+		if _, err = serv.repo.Token().RevokeTokenFamily(ctx, current.FamilyID); err != nil {
+			return LoginResponse{}, err
+		}
+		return LoginResponse{}, ErrInvalidToken
+	}
+
+	// 6. A deactivated user cannot refresh the tokens, the current token is already revoked
+	if !user.IsActive {
+		return LoginResponse{}, ErrUserInactive
+	}
+
+	return serv.issueTokens(auth.NewTenantContext(ctx, user.OrganizationID), user, current.FamilyID)
+}
+
+type LogoutInput struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// Logout revokes the refresh token and denylists the access token until it expires
+func (serv impl) Logout(ctx context.Context, input LogoutInput) error {
+	// 1. Denylist the access token
+	claims, err := parseToken(input.AccessToken)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if err = serv.repo.Token().RevokeAccessToken(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+		return err
+	}
+
+	// Signing out of an impersonation token ends its session, it has no refresh token
+	if claims.ActorID > 0 {
+		return serv.endImpersonation(ctx, claims)
+	}
+	serv.recordSecurityEvent(ctx, model.User{ID: claims.ID, Email: claims.Email, OrganizationID: claims.OrganizationID}, securityevent.TypeTokenRevoked)
+
+	if input.RefreshToken == "" {
+		return nil
+	}
+
+	// 2. Revoke the refresh token, it must belong to the same user
+	current, err := serv.repo.Token().GetRefreshTokenByHash(ctx, hashToken(input.RefreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidToken
+	} else if err != nil {
+		return err
+	}
+	if current.UserID != claims.ID {
+		return ErrInvalidToken
+	}
+
+	if _, err = serv.repo.Token().RevokeRefreshToken(ctx, current.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,484 @@
+package user
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+)
+
+func TestUserService_RefreshToken(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_KEY", "secret")
+
+	type mockData struct {
+		current        model.RefreshToken
+		currentErr     error
+		revokeAffected int64
+		user           model.User
+	}
+	type givenData struct {
+		refreshToken string
+		mock         mockData
+	}
+	tcs := map[string]struct {
+		given            givenData
+		expFamilyRevoked bool
+		expRotated       bool
+		expErr           error
+	}{
+		"success": {
+			given: givenData{
+				refreshToken: "token1",
+				mock: mockData{
+					current: model.RefreshToken{
+						ID:        1,
+						UserID:    1,
+						TokenHash: hashToken("token1"),
+						FamilyID:  "family1",
+						ExpiresAt: time.Now().Add(time.Hour),
+					},
+					revokeAffected: 1,
+					user:           model.User{ID: 1, Email: "guest@example.com", Role: "GUEST", IsActive: true},
+				},
+			},
+			expRotated: true,
+		},
+		"error_not_found": {
+			given: givenData{
+				refreshToken: "token2",
+				mock: mockData{
+					currentErr: sql.ErrNoRows,
+				},
+			},
+			expErr: ErrInvalidToken,
+		},
+		"error_expired": {
+			given: givenData{
+				refreshToken: "token3",
+				mock: mockData{
+					current: model.RefreshToken{
+						ID:        3,
+						UserID:    1,
+						FamilyID:  "family1",
+						ExpiresAt: time.Now().Add(-time.Hour),
+					},
+				},
+			},
+			expErr: ErrInvalidToken,
+		},
+		"error_reused_token_revokes_family": {
+			given: givenData{
+				refreshToken: "token4",
+				mock: mockData{
+					current: model.RefreshToken{
+						ID:        4,
+						UserID:    1,
+						FamilyID:  "family1",
+						ExpiresAt: time.Now().Add(time.Hour),
+						RevokedAt: null.TimeFrom(time.Now()),
+					},
+				},
+			},
+			expFamilyRevoked: true,
+			expErr:           ErrInvalidToken,
+		},
+		"error_rotated_concurrently_revokes_family": {
+			given: givenData{
+				refreshToken: "token5",
+				mock: mockData{
+					current: model.RefreshToken{
+						ID:        5,
+						UserID:    1,
+						FamilyID:  "family1",
+						ExpiresAt: time.Now().Add(time.Hour),
+					},
+					revokeAffected: 0,
+				},
+			},
+			expFamilyRevoked: true,
+			expErr:           ErrInvalidToken,
+		},
+		"error_issued_before_sessions_revoked": {
+			given: givenData{
+				refreshToken: "token6",
+				mock: mockData{
+					current: model.RefreshToken{
+						ID:        6,
+						UserID:    1,
+						FamilyID:  "family1",
+						ExpiresAt: time.Now().Add(time.Hour),
+						CreatedAt: time.Now().Add(-time.Hour),
+					},
+					revokeAffected: 1,
+					user: model.User{
+						ID:                1,
+						Email:             "guest@example.com",
+						Role:              "GUEST",
+						SessionsRevokedAt: null.TimeFrom(time.Now()),
+					},
+				},
+			},
+			expFamilyRevoked: true,
+			expErr:           ErrInvalidToken,
+		},
+		"success_issued_after_sessions_revoked": {
+			given: givenData{
+				refreshToken: "token7",
+				mock: mockData{
+					current: model.RefreshToken{
+						ID:        7,
+						UserID:    1,
+						TokenHash: hashToken("token7"),
+						FamilyID:  "family1",
+						ExpiresAt: time.Now().Add(time.Hour),
+						CreatedAt: time.Now(),
+					},
+					revokeAffected: 1,
+					user: model.User{
+						ID:                1,
+						Email:             "guest@example.com",
+						Role:              "GUEST",
+						IsActive:          true,
+						SessionsRevokedAt: null.TimeFrom(time.Now().Add(-time.Hour)),
+					},
+				},
+			},
+			expRotated: true,
+		},
+		"error_inactive_user": {
+			given: givenData{
+				refreshToken: "token8",
+				mock: mockData{
+					current: model.RefreshToken{
+						ID:        8,
+						UserID:    1,
+						FamilyID:  "family1",
+						ExpiresAt: time.Now().Add(time.Hour),
+						CreatedAt: time.Now(),
+					},
+					revokeAffected: 1,
+					user:           model.User{ID: 1, Email: "guest@example.com", Role: "GUEST"},
+				},
+			},
+			expErr: ErrUserInactive,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			tokenRepoMock := new(token.Mock)
+			userCtx := auth.NewTenantContext(ctx, tc.given.mock.user.OrganizationID)
+			tokenRepoMock.On("GetRefreshTokenByHash", ctx, hashToken(tc.given.refreshToken)).Return(tc.given.mock.current, tc.given.mock.currentErr)
+			tokenRepoMock.On("RevokeRefreshToken", ctx, tc.given.mock.current.ID).Return(tc.given.mock.revokeAffected, nil)
+			tokenRepoMock.On("RevokeTokenFamily", ctx, tc.given.mock.current.FamilyID).Return(int64(1), nil)
+			tokenRepoMock.On("CreateRefreshToken", userCtx, mock.MatchedBy(func(rt model.RefreshToken) bool {
+				return rt.FamilyID == tc.given.mock.current.FamilyID && rt.UserID == tc.given.mock.current.UserID
+			})).Return(model.RefreshToken{}, nil)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", auth.NewUnscopedContext(ctx), tc.given.mock.current.UserID).Return(tc.given.mock.user, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Token").Return(tokenRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.RefreshToken(ctx, tc.given.refreshToken)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.NotEmpty(t, result.AccessToken)
+				require.NotEmpty(t, result.RefreshToken)
+				require.NotEqual(t, tc.given.refreshToken, result.RefreshToken)
+				require.Equal(t, tc.given.mock.user.Role, result.Scope)
+			}
+			if tc.expFamilyRevoked {
+				tokenRepoMock.AssertCalled(t, "RevokeTokenFamily", ctx, tc.given.mock.current.FamilyID)
+			} else {
+				tokenRepoMock.AssertNotCalled(t, "RevokeTokenFamily", ctx, tc.given.mock.current.FamilyID)
+			}
+			if tc.expRotated {
+				tokenRepoMock.AssertCalled(t, "CreateRefreshToken", userCtx, mock.AnythingOfType("model.RefreshToken"))
+			} else {
+				tokenRepoMock.AssertNotCalled(t, "CreateRefreshToken", userCtx, mock.AnythingOfType("model.RefreshToken"))
+			}
+		})
+	}
+}
+
+func TestUserService_Logout(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_KEY", "secret")
+	accessJWT, accessToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "guest@example.com",
+		Role:      "GUEST",
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+	})
+	require.NoError(t, err)
+
+	type givenData struct {
+		input      LogoutInput
+		current    model.RefreshToken
+		currentErr error
+	}
+	tcs := map[string]struct {
+		given            givenData
+		expRefreshRevoke bool
+		expErr           error
+	}{
+		"success": {
+			given: givenData{
+				input:   LogoutInput{AccessToken: accessToken, RefreshToken: "token1"},
+				current: model.RefreshToken{ID: 1, UserID: 1},
+			},
+			expRefreshRevoke: true,
+		},
+		"success_without_refresh_token": {
+			given: givenData{
+				input: LogoutInput{AccessToken: accessToken},
+			},
+		},
+		"error_invalid_access_token": {
+			given: givenData{
+				input: LogoutInput{AccessToken: "abcd", RefreshToken: "token1"},
+			},
+			expErr: ErrInvalidToken,
+		},
+		"error_refresh_token_of_other_user": {
+			given: givenData{
+				input:   LogoutInput{AccessToken: accessToken, RefreshToken: "token2"},
+				current: model.RefreshToken{ID: 2, UserID: 2},
+			},
+			expErr: ErrInvalidToken,
+		},
+		"error_refresh_token_not_found": {
+			given: givenData{
+				input:      LogoutInput{AccessToken: accessToken, RefreshToken: "token3"},
+				currentErr: sql.ErrNoRows,
+			},
+			expErr: ErrInvalidToken,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("RevokeAccessToken", ctx, accessJWT.JwtID(), mock.AnythingOfType("time.Time")).Return(nil)
+			tokenRepoMock.On("GetRefreshTokenByHash", ctx, hashToken(tc.given.input.RefreshToken)).Return(tc.given.current, tc.given.currentErr)
+			tokenRepoMock.On("RevokeRefreshToken", ctx, tc.given.current.ID).Return(int64(1), nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", ctx, mock.Anything).Return(model.SecurityEvent{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+			repoMock.On("Token").Return(tokenRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.Logout(ctx, tc.given.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				tokenRepoMock.AssertCalled(t, "RevokeAccessToken", ctx, accessJWT.JwtID(), mock.AnythingOfType("time.Time"))
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", ctx, mock.MatchedBy(func(event model.SecurityEvent) bool {
+					return event.Type == securityevent.TypeTokenRevoked
+				}))
+			}
+			if tc.expRefreshRevoke {
+				tokenRepoMock.AssertCalled(t, "RevokeRefreshToken", ctx, tc.given.current.ID)
+			} else {
+				tokenRepoMock.AssertNotCalled(t, "RevokeRefreshToken", ctx, tc.given.current.ID)
+			}
+		})
+	}
+}
+
+// writePEMKey writes the private key to the PEM file of the kid, expiresAt is written to the Expires-At header if it is not zero
+func writePEMKey(t *testing.T, dir, kid string, privateKey interface{}, expiresAt time.Time) jwt.Key {
+	b, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: b}
+	if !expiresAt.IsZero() {
+		block.Headers = map[string]string{jwt.ExpiresAtHeader: expiresAt.Format(time.RFC3339)}
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(block), 0600))
+
+	key, err := jwt.NewKey(kid, privateKey, time.Time{})
+	require.NoError(t, err)
+	return key
+}
+
+// signWithKey signs an access token of the admin with the key
+func signWithKey(t *testing.T, key jwt.Key) string {
+	keys, err := jwt.NewKeySet(key.ID, key)
+	require.NoError(t, err)
+	_, token, err := keys.Sign(jwt.JWTInput{ID: 1, Email: "admin@example.com", Role: "ADMIN", ExpiresIn: time.Minute})
+	require.NoError(t, err)
+	return token
+}
+
+func TestUserService_VerifyAccessToken_KeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, expiredKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, unknownKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	retired := writePEMKey(t, dir, "2022-06", rsaKey, time.Now().Add(time.Hour))
+	active := writePEMKey(t, dir, "2022-07", edKey, time.Time{})
+	expired := writePEMKey(t, dir, "2022-05", expiredKey, time.Now().Add(-time.Hour))
+	unknown, err := jwt.NewKey("2022-08", unknownKey, time.Time{})
+	require.NoError(t, err)
+	_, hmacToken, err := jwt.GenerateJWTToken(jwt.JWTInput{ID: 1, SecretKey: "secret", ExpiresIn: time.Minute})
+	require.NoError(t, err)
+
+	t.Setenv("ACCESS_TOKEN_KEY", "secret")
+	t.Setenv("JWT_KEYS_DIR", dir)
+	t.Setenv("JWT_ACTIVE_KEY_ID", active.ID)
+
+	tcs := map[string]struct {
+		token  string
+		expErr error
+	}{
+		"success_active_key": {
+			token: signWithKey(t, active),
+		},
+		"success_retired_key": {
+			token: signWithKey(t, retired),
+		},
+		"error_expired_key": {
+			token:  signWithKey(t, expired),
+			expErr: ErrInvalidToken,
+		},
+		"error_unknown_key": {
+			token:  signWithKey(t, unknown),
+			expErr: ErrInvalidToken,
+		},
+		"error_hmac_token": {
+			token:  hmacToken,
+			expErr: ErrInvalidToken,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("IsAccessTokenRevoked", ctx, mock.AnythingOfType("string"), 1, mock.AnythingOfType("time.Time")).Return(false, nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("GetUserPermissions", ctx, 1).Return([]string{auth.PermUserRead}, nil)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", auth.NewTenantContext(ctx, auth.DefaultOrganizationID), 1).Return(model.User{ID: 1, IsActive: true}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Token").Return(tokenRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.VerifyAccessToken(ctx, tc.token)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, auth.User{ID: 1, Email: "admin@example.com", Role: "ADMIN", OrganizationID: auth.DefaultOrganizationID, Permissions: []string{auth.PermUserRead}}, result)
+			}
+		})
+	}
+}
+
+func TestUserService_GetJWKS(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, expiredKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	writePEMKey(t, dir, "2022-06", rsaKey, time.Now().Add(time.Hour))
+	writePEMKey(t, dir, "2022-07", edKey, time.Time{})
+	writePEMKey(t, dir, "2022-05", expiredKey, time.Now().Add(-time.Hour))
+
+	tcs := map[string]struct {
+		keysDir string
+		expKeys []map[string]interface{}
+	}{
+		"success": {
+			keysDir: dir,
+			expKeys: []map[string]interface{}{
+				{"kid": "2022-06", "kty": "RSA", "alg": "RS256", "use": "sig"},
+				{"kid": "2022-07", "kty": "OKP", "alg": "EdDSA", "use": "sig"},
+			},
+		},
+		"hmac_secret_key_is_not_published": {
+			expKeys: []map[string]interface{}{},
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("ACCESS_TOKEN_KEY", "secret")
+			t.Setenv("JWT_KEYS_DIR", tc.keysDir)
+			t.Setenv("JWT_ACTIVE_KEY_ID", "2022-07")
+			userServ := New(new(repository.Mock))
+
+			// WHEN
+			result, err := userServ.GetJWKS(context.Background())
+
+			// THEN
+			require.NoError(t, err)
+			b, err := json.Marshal(result)
+			require.NoError(t, err)
+			var jwks struct {
+				Keys []map[string]interface{} `json:"keys"`
+			}
+			require.NoError(t, json.Unmarshal(b, &jwks))
+			require.Len(t, jwks.Keys, len(tc.expKeys))
+			for i, expKey := range tc.expKeys {
+				for k, v := range expKey {
+					require.Equal(t, v, jwks.Keys[i][k])
+				}
+				require.NotContains(t, jwks.Keys[i], "d") // private parts are never published
+			}
+		})
+	}
+}
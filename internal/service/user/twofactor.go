@@ -0,0 +1,225 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/totp"
+)
+
+const (
+	totpIssuer                = "s3corp-golang-fresher"
+	twoFactorChallengePurpose = "two_factor_challenge"
+	twoFactorChallengeExpire  = 5 * time.Minute
+	backupCodeCount           = 10
+)
+
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// EnrollTwoFactor generates a new TOTP secret for the current user, it must be confirmed by a code to enable two-factor authentication
+func (serv impl) EnrollTwoFactor(ctx context.Context) (TwoFactorEnrollment, error) {
+	// 1. Get the current user
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return TwoFactorEnrollment{}, ErrPermissionDenied
+	}
+	if caller.IsImpersonated() {
+		return TwoFactorEnrollment{}, ErrImpersonationNotAllowed
+	}
+
+	// 2. The secret cannot be replaced once two-factor authentication is enabled
+	current, err := serv.repo.TwoFactor().GetTOTPSecret(ctx, caller.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return TwoFactorEnrollment{}, err
+	}
+	if err == nil && current.ConfirmedAt.Valid {
+		return TwoFactorEnrollment{}, ErrTwoFactorEnabled
+	}
+
+	// 3. Generate and save a new secret
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return TwoFactorEnrollment{}, ErrTokeCannotBeGenerated
+	}
+	if err = serv.repo.TwoFactor().SaveTOTPSecret(ctx, caller.ID, secret); err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	return TwoFactorEnrollment{
+		Secret:     secret,
+		OtpauthURI: totp.URI(totpIssuer, caller.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication of the current user by a code of the enrolled secret
+// and returns the backup codes, they are only shown once.
+func (serv impl) ConfirmTwoFactor(ctx context.Context, code string) ([]string, error) {
+	// 1. Get the current user
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, ErrPermissionDenied
+	}
+	if caller.IsImpersonated() {
+		return nil, ErrImpersonationNotAllowed
+	}
+
+	// 2. Get the enrolled secret
+	secret, err := serv.repo.TwoFactor().GetTOTPSecret(ctx, caller.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTwoFactorNotEnrolled
+	} else if err != nil {
+		return nil, err
+	}
+	if secret.ConfirmedAt.Valid {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	// 3. Validate the code
+	step, ok := totp.Validate(secret.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	// 4. Generate backup codes
+	codes := make([]string, backupCodeCount)
+	codeHashes := make([]string, backupCodeCount)
+	for i := range codes {
+		if codes[i], err = generateBackupCode(); err != nil {
+			return nil, ErrTokeCannotBeGenerated
+		}
+		codeHashes[i] = hashToken(codes[i])
+	}
+
+	// 5. Confirm the secret and store the hashes of backup codes
+	if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		affected, err := serv.repo.TwoFactor().ConfirmTOTPSecret(ctx, tx, caller.ID, step)
+		if err != nil {
+			return err
+		}
+		if affected < 1 {
+			return ErrTwoFactorEnabled
+		}
+		return serv.repo.TwoFactor().ReplaceBackupCodes(ctx, tx, caller.ID, codeHashes)
+	}); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// generateBackupCode returns a random backup code
+func generateBackupCode() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// normalizeBackupCode removes separators and spaces the user may type in a backup code
+func normalizeBackupCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// twoFactorChallenge returns the response of a password verified login which needs a code to complete
+func (serv impl) twoFactorChallenge(user model.User) (LoginResponse, error) {
+	challengeToken, err := signToken(jwt.JWTInput{
+		ID:        user.ID,
+		Email:     user.Email,
+		ExpiresIn: twoFactorChallengeExpire,
+		Purpose:   twoFactorChallengePurpose,
+	})
+	if err != nil {
+		return LoginResponse{}, ErrTokeCannotBeGenerated
+	}
+
+	return LoginResponse{
+		ChallengeToken:    challengeToken,
+		TwoFactorRequired: true,
+		ExpiresIn:         twoFactorChallengeExpire,
+	}, nil
+}
+
+type TwoFactorLoginInput struct {
+	ChallengeToken string
+	Code           string
+	IPAddress      string
+}
+
+// LoginTwoFactor exchanges the challenge token of Login and a TOTP code or a backup code for the access token.
+// Incorrect codes are counted as failed logins.
+func (serv impl) LoginTwoFactor(ctx context.Context, input TwoFactorLoginInput) (LoginResponse, error) {
+	// 1. Verify the challenge token
+	claims, err := parseToken(input.ChallengeToken)
+	if err != nil || claims.Purpose != twoFactorChallengePurpose {
+		return LoginResponse{}, ErrInvalidToken
+	}
+
+	// 2. Reject login if the email or the IP address is locked
+	if err = serv.checkLoginLocked(ctx, claims.Email, input.IPAddress); err != nil {
+		return LoginResponse{}, err
+	}
+
+	// 3. Get the user and the confirmed secret
+	user, err := serv.repo.User().GetUser(lookupContext(ctx), claims.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return LoginResponse{}, ErrInvalidToken
+	} else if err != nil {
+		return LoginResponse{}, err
+	}
+	ctx = auth.NewTenantContext(ctx, user.OrganizationID)
+	secret, err := serv.repo.TwoFactor().GetTOTPSecret(ctx, user.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return LoginResponse{}, ErrInvalidToken
+	} else if err != nil {
+		return LoginResponse{}, err
+	}
+	if !secret.ConfirmedAt.Valid {
+		return LoginResponse{}, ErrInvalidToken
+	}
+
+	// 4. Verify the code, a TOTP code and a backup code can only be used once
+	verified, err := serv.verifyTwoFactorCode(ctx, secret, input.Code)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+	if !verified {
+		serv.recordSecurityEvent(ctx, user, securityevent.TypeLoginFailed)
+		if err = serv.loginFailed(ctx, claims.Email, input.IPAddress); errors.Is(err, ErrInvalidCredentials) {
+			return LoginResponse{}, ErrInvalidTwoFactorCode
+		}
+		return LoginResponse{}, err
+	}
+
+	// 5. Clear failed logins of the email and generate tokens
+	return serv.loginSucceeded(ctx, user)
+}
+
+// verifyTwoFactorCode returns true if the code is an unused TOTP code or an unused backup code of the user
+func (serv impl) verifyTwoFactorCode(ctx context.Context, secret model.TotpSecret, code string) (bool, error) {
+	if step, ok := totp.Validate(secret.Secret, strings.TrimSpace(code), time.Now()); ok {
+		affected, err := serv.repo.TwoFactor().UseTOTPStep(ctx, secret.UserID, step)
+		if err != nil {
+			return false, err
+		}
+		return affected > 0, nil
+	}
+
+	affected, err := serv.repo.TwoFactor().UseBackupCode(ctx, secret.UserID, hashToken(normalizeBackupCode(code)))
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
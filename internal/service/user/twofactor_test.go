@@ -0,0 +1,345 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/twofactor"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/totp"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestUserService_EnrollTwoFactor(t *testing.T) {
+	tcs := map[string]struct {
+		ctx       context.Context
+		current   model.TotpSecret
+		curErr    error
+		expSaved  bool
+		expErr    error
+		expSecret bool
+	}{
+		"success": {
+			ctx:       auth.NewContext(context.Background(), auth.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin}),
+			curErr:    sql.ErrNoRows,
+			expSaved:  true,
+			expSecret: true,
+		},
+		"success_replace_unconfirmed_secret": {
+			ctx:       auth.NewContext(context.Background(), auth.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin}),
+			current:   model.TotpSecret{UserID: 1, Secret: testTOTPSecret},
+			expSaved:  true,
+			expSecret: true,
+		},
+		"error_already_enabled": {
+			ctx:     auth.NewContext(context.Background(), auth.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin}),
+			current: model.TotpSecret{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(time.Now())},
+			expErr:  ErrTwoFactorEnabled,
+		},
+		"error_anonymous": {
+			ctx:    context.Background(),
+			expErr: ErrPermissionDenied,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			twoFactorRepoMock := new(twofactor.Mock)
+			twoFactorRepoMock.On("GetTOTPSecret", tc.ctx, 1).Return(tc.current, tc.curErr)
+			twoFactorRepoMock.On("SaveTOTPSecret", tc.ctx, 1, mock.AnythingOfType("string")).Return(nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("TwoFactor").Return(twoFactorRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.EnrollTwoFactor(tc.ctx)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expSecret {
+				require.NotEmpty(t, result.Secret)
+				require.True(t, strings.HasPrefix(result.OtpauthURI, "otpauth://totp/s3corp-golang-fresher:admin@example.com?"))
+				require.True(t, strings.Contains(result.OtpauthURI, "secret="+result.Secret))
+			}
+			if tc.expSaved {
+				twoFactorRepoMock.AssertCalled(t, "SaveTOTPSecret", tc.ctx, 1, result.Secret)
+			} else {
+				twoFactorRepoMock.AssertNotCalled(t, "SaveTOTPSecret", tc.ctx, 1, mock.AnythingOfType("string"))
+			}
+		})
+	}
+}
+
+func TestUserService_ConfirmTwoFactor(t *testing.T) {
+	validCode, err := totp.GenerateCode(testTOTPSecret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	type mockData struct {
+		current model.TotpSecret
+		curErr  error
+		txErr   error
+	}
+	tcs := map[string]struct {
+		code     string
+		mock     mockData
+		expCodes bool
+		expErr   error
+	}{
+		"success": {
+			code: validCode,
+			mock: mockData{
+				current: model.TotpSecret{UserID: 1, Secret: testTOTPSecret},
+			},
+			expCodes: true,
+		},
+		"error_not_enrolled": {
+			code: validCode,
+			mock: mockData{
+				curErr: sql.ErrNoRows,
+			},
+			expErr: ErrTwoFactorNotEnrolled,
+		},
+		"error_already_enabled": {
+			code: validCode,
+			mock: mockData{
+				current: model.TotpSecret{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(time.Now())},
+			},
+			expErr: ErrTwoFactorEnabled,
+		},
+		"error_invalid_code": {
+			code: "000000x",
+			mock: mockData{
+				current: model.TotpSecret{UserID: 1, Secret: testTOTPSecret},
+			},
+			expErr: ErrInvalidTwoFactorCode,
+		},
+		"error_confirmed_concurrently": {
+			code: validCode,
+			mock: mockData{
+				current: model.TotpSecret{UserID: 1, Secret: testTOTPSecret},
+				txErr:   ErrTwoFactorEnabled,
+			},
+			expErr: ErrTwoFactorEnabled,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := auth.NewContext(context.Background(), auth.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin})
+			twoFactorRepoMock := new(twofactor.Mock)
+			twoFactorRepoMock.On("GetTOTPSecret", ctx, 1).Return(tc.mock.current, tc.mock.curErr)
+			twoFactorRepoMock.On("ConfirmTOTPSecret", ctx, (*sql.Tx)(nil), 1, mock.AnythingOfType("int64")).Return(int64(1), nil)
+			twoFactorRepoMock.On("ReplaceBackupCodes", ctx, (*sql.Tx)(nil), 1, mock.AnythingOfType("[]string")).Return(nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("TwoFactor").Return(twoFactorRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(tc.mock.txErr).Run(func(args mock.Arguments) {
+				if tc.mock.txErr == nil {
+					require.NoError(t, args.Get(1).(func(*sql.Tx) error)(nil))
+				}
+			})
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.ConfirmTwoFactor(ctx, tc.code)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expCodes {
+				require.Len(t, result, backupCodeCount)
+				hashes := make([]string, len(result))
+				for i, code := range result {
+					hashes[i] = hashToken(code)
+				}
+				twoFactorRepoMock.AssertCalled(t, "ReplaceBackupCodes", ctx, (*sql.Tx)(nil), 1, hashes)
+			} else {
+				require.Empty(t, result)
+			}
+		})
+	}
+}
+
+func TestUserService_LoginTwoFactor(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_KEY", "secret")
+	_, challengeToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "admin@example.com",
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+		Purpose:   twoFactorChallengePurpose,
+	})
+	require.NoError(t, err)
+	_, accessToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "admin@example.com",
+		Role:      auth.RoleAdmin,
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+	})
+	require.NoError(t, err)
+	validCode, err := totp.GenerateCode(testTOTPSecret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	type mockData struct {
+		secret          model.TotpSecret
+		stepAffected    int64
+		backupAffected  int64
+		failedAttempts  int
+		lockedScope     string
+		expFailRecorded bool
+	}
+	tcs := map[string]struct {
+		input    TwoFactorLoginInput
+		mock     mockData
+		expLogin bool
+		expErr   error
+	}{
+		"success_totp_code": {
+			input: TwoFactorLoginInput{ChallengeToken: challengeToken, Code: validCode, IPAddress: "192.0.2.1"},
+			mock: mockData{
+				secret:       model.TotpSecret{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(time.Now())},
+				stepAffected: 1,
+			},
+			expLogin: true,
+		},
+		"success_backup_code": {
+			input: TwoFactorLoginInput{ChallengeToken: challengeToken, Code: "ABCD-EF12-3456", IPAddress: "192.0.2.1"},
+			mock: mockData{
+				secret:         model.TotpSecret{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(time.Now())},
+				backupAffected: 1,
+			},
+			expLogin: true,
+		},
+		"error_totp_code_reused": {
+			input: TwoFactorLoginInput{ChallengeToken: challengeToken, Code: validCode, IPAddress: "192.0.2.1"},
+			mock: mockData{
+				secret:          model.TotpSecret{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(time.Now())},
+				stepAffected:    0,
+				failedAttempts:  1,
+				expFailRecorded: true,
+			},
+			expErr: ErrInvalidTwoFactorCode,
+		},
+		"error_invalid_code": {
+			input: TwoFactorLoginInput{ChallengeToken: challengeToken, Code: "abcdef123456", IPAddress: "192.0.2.1"},
+			mock: mockData{
+				secret:          model.TotpSecret{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(time.Now())},
+				failedAttempts:  1,
+				expFailRecorded: true,
+			},
+			expErr: ErrInvalidTwoFactorCode,
+		},
+		"error_locked": {
+			input: TwoFactorLoginInput{ChallengeToken: challengeToken, Code: validCode, IPAddress: "192.0.2.1"},
+			mock: mockData{
+				secret:      model.TotpSecret{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(time.Now())},
+				lockedScope: loginfailure.ScopeAccount,
+			},
+			expErr: ErrTooManyLoginAttempts,
+		},
+		"error_access_token_as_challenge": {
+			input:  TwoFactorLoginInput{ChallengeToken: accessToken, Code: validCode, IPAddress: "192.0.2.1"},
+			expErr: ErrInvalidToken,
+		},
+		"error_two_factor_not_enabled": {
+			input: TwoFactorLoginInput{ChallengeToken: challengeToken, Code: validCode, IPAddress: "192.0.2.1"},
+			mock: mockData{
+				secret: model.TotpSecret{UserID: 1, Secret: testTOTPSecret},
+			},
+			expErr: ErrInvalidToken,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			userRepoMock := new(user.Mock)
+			userCtx := auth.NewTenantContext(ctx, 2)
+			userRepoMock.On("GetUser", auth.NewUnscopedContext(ctx), 1).Return(model.User{ID: 1, Email: "admin@example.com", Role: auth.RoleAdmin, OrganizationID: 2}, nil)
+			twoFactorRepoMock := new(twofactor.Mock)
+			twoFactorRepoMock.On("GetTOTPSecret", userCtx, 1).Return(tc.mock.secret, nil)
+			twoFactorRepoMock.On("UseTOTPStep", userCtx, 1, mock.AnythingOfType("int64")).Return(tc.mock.stepAffected, nil)
+			twoFactorRepoMock.On("UseBackupCode", userCtx, 1, mock.AnythingOfType("string")).Return(tc.mock.backupAffected, nil)
+			loginFailureRepoMock := new(loginfailure.Mock)
+			for _, scope := range []string{loginfailure.ScopeAccount, loginfailure.ScopeIP} {
+				failure, failureErr := model.LoginFailure{}, error(sql.ErrNoRows)
+				if scope == tc.mock.lockedScope {
+					failure, failureErr = model.LoginFailure{LockedUntil: null.TimeFrom(time.Now().Add(time.Minute))}, nil
+				}
+				loginFailureRepoMock.On("GetLoginFailure", ctx, scope, mock.AnythingOfType("string")).Return(failure, failureErr)
+				loginFailureRepoMock.On("RecordLoginFailure", userCtx, scope, mock.AnythingOfType("string"), mock.AnythingOfType("time.Duration")).Return(model.LoginFailure{FailedAttempts: tc.mock.failedAttempts}, nil)
+			}
+			loginFailureRepoMock.On("DeleteLoginFailure", userCtx, loginfailure.ScopeAccount, "admin@example.com").Return(int64(1), nil)
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("CreateRefreshToken", userCtx, mock.AnythingOfType("model.RefreshToken")).Return(model.RefreshToken{}, nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", userCtx, mock.Anything).Return(model.SecurityEvent{}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("TwoFactor").Return(twoFactorRepoMock)
+			repoMock.On("LoginFailure").Return(loginFailureRepoMock)
+			repoMock.On("Token").Return(tokenRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.LoginTwoFactor(ctx, tc.input)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expLogin {
+				require.NotEmpty(t, result.AccessToken)
+				require.NotEmpty(t, result.RefreshToken)
+				require.Equal(t, auth.RoleAdmin, result.Scope)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", userCtx, model.SecurityEvent{
+					UserID: null.IntFrom(1), OrganizationID: 2, Type: securityevent.TypeLoginSucceeded, Email: "admin@example.com",
+				})
+			} else {
+				require.Empty(t, result.AccessToken)
+			}
+			if tc.mock.backupAffected > 0 {
+				twoFactorRepoMock.AssertCalled(t, "UseBackupCode", userCtx, 1, hashToken("abcdef123456"))
+			}
+			if tc.mock.expFailRecorded {
+				loginFailureRepoMock.AssertCalled(t, "RecordLoginFailure", userCtx, loginfailure.ScopeAccount, "admin@example.com", accountLockoutPolicy.lockout)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", userCtx, model.SecurityEvent{
+					UserID: null.IntFrom(1), OrganizationID: 2, Type: securityevent.TypeLoginFailed, Email: "admin@example.com",
+				})
+			} else {
+				loginFailureRepoMock.AssertNotCalled(t, "RecordLoginFailure", userCtx, loginfailure.ScopeAccount, "admin@example.com", accountLockoutPolicy.lockout)
+			}
+		})
+	}
+}
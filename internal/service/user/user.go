@@ -4,15 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"os"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/volatiletech/null/v8"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
 	"github.com/vinhnv1/s3corp-golang-fresher/pkg/bcrypt"
-	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
 )
 
 // InputUser is the input for v1
@@ -28,6 +31,58 @@ type InputUser struct {
 
 // CreateUser creates a new user by InputUser param.
 func (serv impl) CreateUser(ctx context.Context, input InputUser) (model.User, error) {
+	// 1. Anyone can register as an active GUEST, other roles and the status can only be given by users who can manage users
+	if caller, ok := auth.FromContext(ctx); !ok || !caller.HasPermission(auth.PermUserWrite) {
+		if input.Role != auth.RoleGuest {
+			return model.User{}, ErrPermissionDenied
+		}
+		input.IsActive = true
+	}
+	if input.Role != auth.RoleGuest && !canManageRoles(ctx) {
+		return model.User{}, ErrPermissionDenied
+	}
+	if err := serv.checkRoleExists(ctx, input.Role); err != nil {
+		return model.User{}, err
+	}
+	if err := validatePassword(input.Password); err != nil {
+		return model.User{}, err
+	}
+
+	return serv.createUser(ctx, input)
+}
+
+// createUser creates the user in the organization of ctx and sends the verification email
+func (serv impl) createUser(ctx context.Context, input InputUser) (model.User, error) {
+	result, err := serv.insertUser(ctx, input, bcrypt.Cost())
+	if err != nil {
+		return model.User{}, err
+	}
+
+	// Send the verification email, the user can request it again if sending fails
+	if err = serv.sendVerificationEmail(ctx, result); err != nil {
+		log.Printf("Error when send verification email to user %d: %v\n", result.ID, err)
+	}
+
+	return result, nil
+}
+
+// insertUser creates the user in the organization of ctx with the password hashed with the given cost, the email is not verified yet
+func (serv impl) insertUser(ctx context.Context, input InputUser, cost int) (model.User, error) {
+	user, err := serv.newUser(ctx, input, cost)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	var result model.User
+	err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		result, err = serv.repo.User().CreateUser(ctx, tx, user)
+		return err
+	})
+	return result, err
+}
+
+// newUser returns the user to create with the password hashed with the given cost, the email must not be used yet
+func (serv impl) newUser(ctx context.Context, input InputUser, cost int) (model.User, error) {
 	// 1. Check exist user with this email
 	existed, err := serv.repo.User().ExistsUserByEmail(ctx, input.Email)
 	if err != nil {
@@ -39,25 +94,19 @@ func (serv impl) CreateUser(ctx context.Context, input InputUser) (model.User, e
 	}
 
 	// 2. Hash user password by bcrypt
-	hashedPass, err := bcrypt.HashPassword(input.Password)
+	hashedPass, err := bcrypt.HashPasswordWithCost(input.Password, cost)
 	if err != nil {
 		return model.User{}, ErrPasswordCannotBeHashed
 	}
 
-	// 3. Create user
-	result, err := serv.repo.User().CreateUser(ctx, model.User{
+	return model.User{
 		Name:     input.Name,
 		Email:    input.Email,
 		Password: hashedPass,
 		Phone:    input.Phone,
 		Role:     input.Role,
 		IsActive: input.IsActive,
-	})
-	if err != nil {
-		return model.User{}, err
-	}
-
-	return result, nil
+	}, nil
 }
 
 type SortArgs struct {
@@ -76,6 +125,7 @@ type InputGetUser struct {
 	Name       string
 	IsActive   null.Bool
 	Role       string
+	Deleted    bool
 	Sort       SortArgs
 	Pagination Pagination
 }
@@ -98,6 +148,7 @@ func toFilter(input InputGetUser) user.Filter {
 		Name:       input.Name,
 		IsActive:   input.IsActive,
 		Role:       input.Role,
+		Deleted:    input.Deleted,
 		Sort:       sortParams,
 		Pagination: pagination,
 	}
@@ -123,22 +174,56 @@ func (serv impl) GetUsers(ctx context.Context, input InputGetUser) ([]model.User
 	return users, totalCount, nil
 }
 
+// UpdateUser updates the user, the password is only replaced when one is given and all sessions of the user are signed out then.
+// The sessions, the refresh tokens and the API keys of a deactivated user are revoked.
 func (serv impl) UpdateUser(ctx context.Context, input InputUser) error {
-	// TODO: check if email is existed
+	// 1. Get the user
+	user, err := serv.repo.User().GetUser(ctx, input.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	} else if err != nil {
+		return err
+	}
 
-	// Hash new password
-	hashedNewPass, err := bcrypt.HashPassword(input.Password)
-	if err != nil {
-		return ErrPasswordCannotBeHashed
+	// 2. A new email must not be registered by another user
+	if input.Email != user.Email {
+		existed, err := serv.repo.User().ExistsUserByEmail(ctx, input.Email)
+		if err != nil {
+			return err
+		}
+		if existed {
+			return ErrEmailExisted
+		}
+	}
+
+	// 3. The primary role must be an existing role, only users who can manage roles can change it
+	if input.Role != user.Role && !canManageRoles(ctx) {
+		return ErrPermissionDenied
+	}
+	if err = serv.checkRoleExists(ctx, input.Role); err != nil {
+		return err
 	}
 
-	//Call the repository method
+	// 4. A new password must satisfy the password policy, the last passwords cannot be reused
+	var hashedPass string
+	if input.Password != "" {
+		if err = validatePassword(input.Password); err != nil {
+			return err
+		}
+		if err = serv.checkPasswordReused(ctx, user, input.Password); err != nil {
+			return err
+		}
+		if hashedPass, err = bcrypt.HashPassword(input.Password); err != nil {
+			return ErrPasswordCannotBeHashed
+		}
+	}
+
+	// 5. Update the user
 	result, err := serv.repo.User().UpdateUser(ctx,
 		model.User{
 			ID:       input.ID,
 			Name:     input.Name,
 			Email:    input.Email,
-			Password: hashedNewPass,
 			Phone:    input.Phone,
 			Role:     input.Role,
 			IsActive: input.IsActive,
@@ -151,6 +236,27 @@ func (serv impl) UpdateUser(ctx context.Context, input InputUser) error {
 	if result < 1 {
 		return ErrUserNotFound
 	}
+
+	user.Email = input.Email
+	if input.Role != user.Role {
+		serv.recordSecurityEvent(ctx, user, securityevent.TypeRoleChanged)
+	}
+
+	// 6. A deactivated user is signed out everywhere, its refresh tokens and API keys are revoked
+	if user.IsActive && !input.IsActive {
+		if err = serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+			_, err := serv.repo.User().RevokeUserSessions(ctx, tx, user.ID)
+			return err
+		}); err != nil {
+			return err
+		}
+		serv.recordSecurityEvent(ctx, user, securityevent.TypeTokenRevoked)
+	}
+
+	// 7. Replace the password
+	if hashedPass != "" {
+		return serv.replacePassword(ctx, user, hashedPass)
+	}
 	return nil
 }
 
@@ -166,14 +272,36 @@ func (serv impl) GetUser(ctx context.Context, id int) (model.User, error) {
 	return result, nil
 }
 
+// DeleteUser soft deletes the user, the user cannot login or refresh the tokens anymore but the products and orders of the user are kept
 func (serv impl) DeleteUser(ctx context.Context, id int) error {
-	//Call the repository method
-	result, err := serv.repo.User().DeleteUser(ctx, id)
+	//Call the repository method, the user and its refresh tokens are revoked at once
+	if err := serv.repo.Tx(ctx, func(tx *sql.Tx) error {
+		result, err := serv.repo.User().DeleteUser(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// Return not_found error if the affectedRows =1
+		if result < 1 {
+			return ErrUserNotFound
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	serv.recordSecurityEvent(ctx, model.User{ID: id}, securityevent.TypeTokenRevoked)
+	return nil
+}
+
+// RestoreUser restores the soft deleted user, the user has to login again
+func (serv impl) RestoreUser(ctx context.Context, id int) error {
+	result, err := serv.repo.User().RestoreUser(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	// Return not_found error if the affectedRows =1
+	// The user does not exist or is not deleted
 	if result < 1 {
 		return ErrUserNotFound
 	}
@@ -181,56 +309,148 @@ func (serv impl) DeleteUser(ctx context.Context, id int) error {
 }
 
 type LoginInput struct {
-	Email    string
-	Password string
+	Email     string
+	Password  string
+	IPAddress string
 }
 
 type LoginResponse struct {
-	AccessToken string        `json:"access_token"`
-	Scope       string        `json:"scope"`
-	ExpiresIn   time.Duration `json:"expires_in"`
-	TokenType   string        `json:"token_type"`
+	AccessToken       string        `json:"access_token,omitempty"`
+	RefreshToken      string        `json:"refresh_token,omitempty"`
+	Scope             string        `json:"scope,omitempty"`
+	ExpiresIn         time.Duration `json:"expires_in"`
+	TokenType         string        `json:"token_type,omitempty"`
+	ChallengeToken    string        `json:"challenge_token,omitempty"`
+	TwoFactorRequired bool          `json:"two_factor_required,omitempty"`
 }
 
 const (
-	tokenExpireTime = 30 * time.Minute
+	tokenExpireTime        = 30 * time.Minute
+	refreshTokenExpireTime = 7 * 24 * time.Hour
 )
 
-// Login authenticate user data
+// lookupContext returns ctx for finding the user of an anonymous request by a globally unique email, token or key.
+// The lookup is limited to the organization the request selected, it is not limited otherwise.
+// The ctx has to be scoped to the organization of the user once the user is found.
+func lookupContext(ctx context.Context) context.Context {
+	if _, ok := auth.TenantFromContext(ctx); ok {
+		return ctx
+	}
+	return auth.NewUnscopedContext(ctx)
+}
+
+// Login authenticate user data.
+// Unknown emails and incorrect passwords return the same error, failed attempts lock the email and the IP address.
 func (serv impl) Login(ctx context.Context, input LoginInput) (LoginResponse, error) {
+	// Reject login if the email or the IP address is locked
+	if err := serv.checkLoginLocked(ctx, input.Email, input.IPAddress); err != nil {
+		return LoginResponse{}, err
+	}
+
 	// Get user with email
-	user, err := serv.repo.User().GetUserByEmail(ctx, input.Email)
+	user, err := serv.repo.User().GetUserByEmail(lookupContext(ctx), input.Email)
 	if errors.Is(err, sql.ErrNoRows) {
-		return LoginResponse{}, ErrEmailNotExist
+		// Hashing takes as long as comparing with a hash of the current cost, so unknown emails cannot be told apart by timing
+		bcrypt.HashPassword(input.Password)
+		serv.recordSecurityEvent(ctx, model.User{Email: input.Email}, securityevent.TypeLoginFailed)
+		return LoginResponse{}, serv.loginFailed(ctx, input.Email, input.IPAddress)
 	} else if err != nil {
 		return LoginResponse{}, err
 	}
+	ctx = auth.NewTenantContext(ctx, user.OrganizationID)
 
 	// Verify password
 	if !bcrypt.CheckPasswordHash(input.Password, user.Password) {
-		return LoginResponse{}, ErrPasswordIncorrect
+		serv.recordSecurityEvent(ctx, user, securityevent.TypeLoginFailed)
+		return LoginResponse{}, serv.loginFailed(ctx, input.Email, input.IPAddress)
+	}
+	serv.rehashPassword(ctx, user, input.Password)
+
+	// Only active users with verified emails can login
+	if !user.IsActive {
+		return LoginResponse{}, ErrUserInactive
+	}
+	if !user.EmailVerifiedAt.Valid {
+		return LoginResponse{}, ErrEmailNotVerified
+	}
+
+	return serv.completeLogin(ctx, user)
+}
+
+// completeLogin returns the tokens of the authenticated user,
+// users with two-factor authentication get a challenge token instead and need a code to complete the login
+func (serv impl) completeLogin(ctx context.Context, user model.User) (LoginResponse, error) {
+	secret, err := serv.repo.TwoFactor().GetTOTPSecret(ctx, user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return LoginResponse{}, err
+	}
+	if err == nil && secret.ConfirmedAt.Valid {
+		return serv.twoFactorChallenge(user)
+	}
+
+	return serv.loginSucceeded(ctx, user)
+}
+
+// loginSucceeded clears failed logins of the user email, generates access_token and refresh_token of a new token family and records the login.
+// Failures of the IP address expire by themselves.
+func (serv impl) loginSucceeded(ctx context.Context, user model.User) (LoginResponse, error) {
+	if _, err := serv.repo.LoginFailure().DeleteLoginFailure(ctx, loginfailure.ScopeAccount, strings.ToLower(strings.TrimSpace(user.Email))); err != nil {
+		return LoginResponse{}, err
+	}
+
+	result, err := serv.issueTokens(ctx, user, "")
+	if err != nil {
+		return LoginResponse{}, err
+	}
+	serv.recordSecurityEvent(ctx, user, securityevent.TypeLoginSucceeded)
+	return result, nil
+}
+
+// VerifyAccessToken verifies the access token and returns the user carried by its claims
+func (serv impl) VerifyAccessToken(ctx context.Context, accessToken string) (auth.User, error) {
+	claims, err := parseToken(accessToken)
+	if err != nil || claims.Purpose != "" { // tokens with a purpose such as email verification are not access tokens
+		return auth.User{}, ErrInvalidToken
+	}
+
+	// Reject the token if it was revoked by logout or password reset
+	revoked, err := serv.repo.Token().IsAccessTokenRevoked(ctx, claims.TokenID, claims.ID, claims.IssuedAt)
+	if err != nil {
+		return auth.User{}, err
+	}
+	if revoked {
+		return auth.User{}, ErrInvalidToken
 	}
 
-	// Generate access_token
-	secretKey := os.Getenv("ACCESS_TOKEN_KEY")
+	// Tokens issued before organizations were introduced belong to the default organization
+	organizationID := claims.OrganizationID
+	if organizationID == 0 {
+		organizationID = auth.DefaultOrganizationID
+	}
 
-	jwtInput := jwt.JWTInput{
-		ID:        user.ID,
-		Email:     user.Email,
-		Role:      user.Role,
-		SecretKey: secretKey,
-		ExpiresIn: tokenExpireTime,
+	// Reject the token if the user was deactivated after it was issued
+	user, err := serv.repo.User().GetUser(auth.NewTenantContext(ctx, organizationID), claims.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return auth.User{}, ErrInvalidToken
+	} else if err != nil {
+		return auth.User{}, err
+	}
+	if !user.IsActive {
+		return auth.User{}, ErrUserInactive
 	}
 
-	_, accessToken, err := jwt.GenerateJWTToken(jwtInput)
+	// Load the permissions of the user, the roles may be changed after the token was issued
+	permissions, err := serv.repo.Role().GetUserPermissions(ctx, claims.ID)
 	if err != nil {
-		return LoginResponse{}, ErrTokeCannotBeGenerated
+		return auth.User{}, err
 	}
 
-	return LoginResponse{
-		AccessToken: accessToken,
-		Scope:       user.Role,
-		ExpiresIn:   tokenExpireTime,
-		TokenType:   "Bearer",
+	return auth.User{
+		ID:             claims.ID,
+		Email:          claims.Email,
+		Role:           claims.Role,
+		OrganizationID: organizationID,
+		Permissions:    permissions,
+		ActorID:        claims.ActorID,
 	}, nil
 }
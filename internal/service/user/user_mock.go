@@ -2,10 +2,14 @@ package user
 
 import (
 	"context"
+	"io"
+
+	"github.com/lestrrat-go/jwx/jwk"
 
 	"github.com/stretchr/testify/mock"
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
 )
 
 type Mock struct {
@@ -36,12 +40,252 @@ func (m *Mock) DeleteUser(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *Mock) RestoreUser(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *Mock) GetProfile(ctx context.Context) (Profile, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(Profile), args.Error(1)
+}
+
+func (m *Mock) UpdateProfile(ctx context.Context, input UpdateProfileInput) (Profile, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(Profile), args.Error(1)
+}
+
+func (m *Mock) ChangePassword(ctx context.Context, input ChangePasswordInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
 func (m *Mock) Login(ctx context.Context, input LoginInput) (LoginResponse, error) {
 	args := m.Called(ctx, input)
 	return args.Get(0).(LoginResponse), args.Error(1)
 }
 
+func (m *Mock) StartOIDCLogin(ctx context.Context) (OIDCLoginRequest, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(OIDCLoginRequest), args.Error(1)
+}
+
+func (m *Mock) OIDCCallback(ctx context.Context, input OIDCCallbackInput) (LoginResponse, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(LoginResponse), args.Error(1)
+}
+
+func (m *Mock) LoginTwoFactor(ctx context.Context, input TwoFactorLoginInput) (LoginResponse, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(LoginResponse), args.Error(1)
+}
+
+func (m *Mock) EnrollTwoFactor(ctx context.Context) (TwoFactorEnrollment, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(TwoFactorEnrollment), args.Error(1)
+}
+
+func (m *Mock) ConfirmTwoFactor(ctx context.Context, code string) ([]string, error) {
+	args := m.Called(ctx, code)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *Mock) UnlockUser(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *Mock) RefreshToken(ctx context.Context, refreshToken string) (LoginResponse, error) {
+	args := m.Called(ctx, refreshToken)
+	return args.Get(0).(LoginResponse), args.Error(1)
+}
+
+func (m *Mock) Logout(ctx context.Context, input LogoutInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *Mock) ForgotPassword(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *Mock) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *Mock) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *Mock) ResendVerificationEmail(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *Mock) VerifyAccessToken(ctx context.Context, accessToken string) (auth.User, error) {
+	args := m.Called(ctx, accessToken)
+	return args.Get(0).(auth.User), args.Error(1)
+}
+
+func (m *Mock) GetJWKS(ctx context.Context) (jwk.Set, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(jwk.Set), args.Error(1)
+}
+
+func (m *Mock) CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (APIKey, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(APIKey), args.Error(1)
+}
+
+func (m *Mock) GetAPIKeys(ctx context.Context) ([]APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]APIKey), args.Error(1)
+}
+
+func (m *Mock) RevokeAPIKey(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *Mock) VerifyAPIKey(ctx context.Context, key string) (auth.User, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(auth.User), args.Error(1)
+}
+
 func (m *Mock) GetStatistics(ctx context.Context, orderLimit int) (SummaryStatistics, error) {
 	args := m.Called(ctx, orderLimit)
 	return args.Get(0).(SummaryStatistics), args.Error(1)
 }
+
+func (m *Mock) GetRoles(ctx context.Context) ([]Role, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]Role), args.Error(1)
+}
+
+func (m *Mock) GetRole(ctx context.Context, id int) (Role, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(Role), args.Error(1)
+}
+
+func (m *Mock) CreateRole(ctx context.Context, input RoleInput) (Role, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(Role), args.Error(1)
+}
+
+func (m *Mock) UpdateRole(ctx context.Context, input RoleInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *Mock) DeleteRole(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *Mock) GetPermissions(ctx context.Context) ([]model.Permission, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.Permission), args.Error(1)
+}
+
+func (m *Mock) GetUserRoles(ctx context.Context, userID int) (UserRoles, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(UserRoles), args.Error(1)
+}
+
+func (m *Mock) SetUserRoles(ctx context.Context, userID int, roleNames []string) error {
+	args := m.Called(ctx, userID, roleNames)
+	return args.Error(0)
+}
+
+func (m *Mock) GetOrganizations(ctx context.Context) ([]Organization, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]Organization), args.Error(1)
+}
+
+func (m *Mock) GetOrganization(ctx context.Context, id int) (Organization, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(Organization), args.Error(1)
+}
+
+func (m *Mock) CreateOrganization(ctx context.Context, input OrganizationInput) (Organization, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(Organization), args.Error(1)
+}
+
+func (m *Mock) StartImpersonation(ctx context.Context, input ImpersonationInput) (LoginResponse, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(LoginResponse), args.Error(1)
+}
+
+func (m *Mock) EndImpersonation(ctx context.Context, accessToken string) error {
+	args := m.Called(ctx, accessToken)
+	return args.Error(0)
+}
+
+func (m *Mock) GetImpersonations(ctx context.Context) ([]Impersonation, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]Impersonation), args.Error(1)
+}
+
+func (m *Mock) ImportUsersCSV(ctx context.Context, csvFile io.Reader, sendInvites bool) (ImportUsersResult, error) {
+	args := m.Called(ctx, csvFile, sendInvites)
+	return args.Get(0).(ImportUsersResult), args.Error(1)
+}
+
+func (m *Mock) ExportUsersCSV(ctx context.Context, input InputGetUser) ([]byte, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *Mock) GetAddresses(ctx context.Context, userID int) ([]Address, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]Address), args.Error(1)
+}
+
+func (m *Mock) GetAddress(ctx context.Context, userID int, id int) (Address, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(Address), args.Error(1)
+}
+
+func (m *Mock) CreateAddress(ctx context.Context, input AddressInput) (Address, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(Address), args.Error(1)
+}
+
+func (m *Mock) UpdateAddress(ctx context.Context, input AddressInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *Mock) DeleteAddress(ctx context.Context, userID int, id int) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *Mock) ExportUserData(ctx context.Context, userID int) (UserData, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(UserData), args.Error(1)
+}
+
+func (m *Mock) EraseUser(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *Mock) GetDataRequests(ctx context.Context) ([]DataRequest, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]DataRequest), args.Error(1)
+}
+
+func (m *Mock) GetSecurityEvents(ctx context.Context, input SecurityEventsInput) ([]SecurityEvent, int64, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).([]SecurityEvent), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *Mock) GetCurrentUserSecurityEvents(ctx context.Context, pagination Pagination) ([]SecurityEvent, int64, error) {
+	args := m.Called(ctx, pagination)
+	return args.Get(0).([]SecurityEvent), args.Get(1).(int64), args.Error(2)
+}
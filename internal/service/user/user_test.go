@@ -14,9 +14,18 @@ import (
 
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/loginfailure"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/order"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/product"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/role"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/securityevent"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/token"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/twofactor"
 	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/password"
 )
 
 func TestUserService_CreateUser(t *testing.T) {
@@ -33,13 +42,16 @@ func TestUserService_CreateUser(t *testing.T) {
 	}
 
 	type givenData struct {
+		caller     *auth.User
 		input      InputUser
+		roleExist  bool
 		createUser createUserData
 		existUser  existUserData
 	}
 
 	type expectedData struct {
-		data model.User
+		data     model.User
+		isActive bool
 	}
 
 	tcs := map[string]struct {
@@ -52,11 +64,12 @@ func TestUserService_CreateUser(t *testing.T) {
 				input: InputUser{
 					Name:     "guest",
 					Email:    "guest@example.com",
-					Password: "abcd",
+					Password: "Secret-Passw0rd",
 					Phone:    "0987654321",
 					Role:     "GUEST",
 					IsActive: true,
 				},
+				roleExist: true,
 				createUser: createUserData{
 					input: mock.AnythingOfType("User"),
 					result: model.User{
@@ -84,6 +97,55 @@ func TestUserService_CreateUser(t *testing.T) {
 					Role:     "GUEST",
 					IsActive: true,
 				},
+				isActive: true,
+			},
+		},
+		"success_self_registration_ignores_is_active": {
+			given: givenData{
+				input: InputUser{
+					Name:     "guest",
+					Email:    "guest@example.com",
+					Password: "Secret-Passw0rd",
+					Phone:    "0987654321",
+					Role:     "GUEST",
+					IsActive: false,
+				},
+				roleExist: true,
+				createUser: createUserData{
+					input:  mock.AnythingOfType("User"),
+					result: model.User{ID: 1, Name: "guest", Email: "guest@example.com", Role: "GUEST", IsActive: true},
+				},
+				existUser: existUserData{
+					input: "guest@example.com",
+				},
+			},
+			expResult: expectedData{
+				data:     model.User{ID: 1, Name: "guest", Email: "guest@example.com", Role: "GUEST", IsActive: true},
+				isActive: true,
+			},
+		},
+		"success_user_manager_creates_inactive_user": {
+			given: givenData{
+				caller: &auth.User{ID: 2, Role: "ADMIN", Permissions: []string{auth.PermUserWrite}},
+				input: InputUser{
+					Name:     "guest",
+					Email:    "guest@example.com",
+					Password: "Secret-Passw0rd",
+					Phone:    "0987654321",
+					Role:     "GUEST",
+					IsActive: false,
+				},
+				roleExist: true,
+				createUser: createUserData{
+					input:  mock.AnythingOfType("User"),
+					result: model.User{ID: 1, Name: "guest", Email: "guest@example.com", Role: "GUEST"},
+				},
+				existUser: existUserData{
+					input: "guest@example.com",
+				},
+			},
+			expResult: expectedData{
+				data: model.User{ID: 1, Name: "guest", Email: "guest@example.com", Role: "GUEST"},
 			},
 		},
 		"error_email_duplicate": {
@@ -91,11 +153,12 @@ func TestUserService_CreateUser(t *testing.T) {
 				input: InputUser{
 					Name:     "guest",
 					Email:    "guest@example.com",
-					Password: "abcd",
+					Password: "Secret-Passw0rd",
 					Phone:    "0987654321",
 					Role:     "GUEST",
 					IsActive: true,
 				},
+				roleExist: true,
 				createUser: createUserData{
 					input: mock.AnythingOfType("User"),
 					result: model.User{
@@ -115,23 +178,122 @@ func TestUserService_CreateUser(t *testing.T) {
 			},
 			expErr: ErrEmailExisted,
 		},
+		"error_anonymous_cannot_create_admin": {
+			given: givenData{
+				input: InputUser{
+					Name:     "admin",
+					Email:    "admin@example.com",
+					Password: "Secret-Passw0rd",
+					Phone:    "0987654321",
+					Role:     "ADMIN",
+					IsActive: true,
+				},
+				createUser: createUserData{
+					input: mock.AnythingOfType("User"),
+				},
+				existUser: existUserData{
+					input: "admin@example.com",
+				},
+			},
+			expErr: ErrPermissionDenied,
+		},
+		"error_user_manager_cannot_give_admin": {
+			given: givenData{
+				caller: &auth.User{ID: 2, Role: "ADMIN", Permissions: []string{auth.PermUserWrite}},
+				input: InputUser{
+					Name:     "admin",
+					Email:    "admin@example.com",
+					Password: "Secret-Passw0rd",
+					Phone:    "0987654321",
+					Role:     "ADMIN",
+					IsActive: true,
+				},
+				roleExist: true,
+				createUser: createUserData{
+					input: mock.AnythingOfType("User"),
+				},
+				existUser: existUserData{
+					input: "admin@example.com",
+				},
+			},
+			expErr: ErrPermissionDenied,
+		},
+		"error_role_not_found": {
+			given: givenData{
+				caller: &auth.User{ID: 2, Role: "ADMIN", Permissions: []string{auth.PermUserWrite, auth.PermRoleWrite}},
+				input: InputUser{
+					Name:     "warehouse",
+					Email:    "warehouse@example.com",
+					Password: "Secret-Passw0rd",
+					Phone:    "0987654321",
+					Role:     "WAREHOUSE",
+					IsActive: true,
+				},
+				createUser: createUserData{
+					input: mock.AnythingOfType("User"),
+				},
+				existUser: existUserData{
+					input: "warehouse@example.com",
+				},
+			},
+			expErr: ErrRoleNotFound,
+		},
+		"error_weak_password": {
+			given: givenData{
+				input: InputUser{
+					Name:     "guest",
+					Email:    "guest@example.com",
+					Password: "abcd",
+					Phone:    "0987654321",
+					Role:     "GUEST",
+					IsActive: true,
+				},
+				roleExist: true,
+				createUser: createUserData{
+					input: mock.AnythingOfType("User"),
+				},
+				existUser: existUserData{
+					input: "guest@example.com",
+				},
+			},
+			expErr: fmt.Errorf("%w: %v, it needs at least 8 characters", ErrWeakPassword, password.ErrTooShort),
+		},
 	}
 
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
 			// Given
+			t.Setenv("ACCESS_TOKEN_KEY", "secret")
+			ctx := context.Background()
+			if tc.given.caller != nil {
+				ctx = auth.NewContext(ctx, *tc.given.caller)
+			}
+			var sent []mail.EmailInput
+			sendEmail = func(input mail.EmailInput) error {
+				sent = append(sent, input)
+				return nil
+			}
+			defer func() { sendEmail = mail.SendEmail }()
+
 			userRepoMock := new(user.Mock)
 
-			userRepoMock.On("CreateUser", context.Background(), tc.given.createUser.input).Return(tc.given.createUser.result, tc.given.createUser.err)
-			userRepoMock.On("ExistsUserByEmail", context.Background(), tc.given.existUser.input).Return(tc.given.existUser.result, tc.given.existUser.err)
+			userRepoMock.On("CreateUser", ctx, mock.Anything, tc.given.createUser.input).Return(tc.given.createUser.result, tc.given.createUser.err)
+			userRepoMock.On("ExistsUserByEmail", ctx, tc.given.existUser.input).Return(tc.given.existUser.result, tc.given.existUser.err)
+			userRepoMock.On("UpdateVerificationSentAt", ctx, tc.given.createUser.result.ID, verificationResendInterval).Return(int64(1), nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("ExistsRoleByName", ctx, tc.given.input.Role).Return(tc.given.roleExist, nil)
 
 			repoMock := new(repository.Mock)
 			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("Tx", ctx, mock.AnythingOfType("func(*sql.Tx) error")).Return(tc.given.createUser.err).Run(func(args mock.Arguments) {
+				args.Get(1).(func(*sql.Tx) error)(nil)
+			})
 
 			service := New(repoMock)
 
 			// When
-			result, err := service.CreateUser(context.Background(), tc.given.input)
+			result, err := service.CreateUser(ctx, tc.given.input)
 
 			// Then
 			if tc.expErr != nil {
@@ -139,6 +301,11 @@ func TestUserService_CreateUser(t *testing.T) {
 			} else {
 				require.NoError(t, tc.expErr, "Should not be error")
 				require.Equal(t, tc.expResult.data, result)
+				require.Len(t, sent, 1)
+				require.Equal(t, []string{tc.expResult.data.Email}, sent[0].To)
+				userRepoMock.AssertCalled(t, "CreateUser", ctx, mock.Anything, mock.MatchedBy(func(u model.User) bool {
+					return u.IsActive == tc.expResult.isActive
+				}))
 			}
 		})
 	}
@@ -320,78 +487,180 @@ func TestUserService_GetUsers(t *testing.T) {
 }
 
 func TestUserService_UpdateUser(t *testing.T) {
-	type input struct {
-		updateUser         InputUser
-		ctx                context.Context
-		mockInputUser      mock.AnythingOfTypeArgument
-		mockInputCTX       context.Context
-		mockResultAffected int64
-		mockResultError    error
+	currentUser := model.User{ID: 1, Name: "TEST", Email: "test@example.com", Password: currentPasswordHash, Role: "GUEST", IsActive: true}
+	admin := auth.User{ID: 2, Role: "ADMIN", Permissions: []string{auth.PermUserWrite, auth.PermRoleWrite}}
+	type mockData struct {
+		userErr      error
+		emailExisted bool
+		roleExist    bool
+		histories    model.PasswordHistorySlice
+		affected     int64
 	}
 	tcs := map[string]struct {
-		input  input
-		expErr error
+		caller             *auth.User
+		input              InputUser
+		mock               mockData
+		expUpdated         bool
+		expPasswordChanged bool
+		expRoleChanged     bool
+		expRevoked         bool
+		expErr             error
 	}{
-		"success": {
-			input: input{
-				updateUser: InputUser{
-					ID:       1,
-					Name:     "TEST",
-					Email:    "test@example",
-					Password: "test",
-					Phone:    "123456",
-					Role:     "ADMIN",
-					IsActive: true,
-				},
-				ctx: context.Background(),
-
-				mockInputUser:      mock.AnythingOfType("User"),
-				mockInputCTX:       context.Background(),
-				mockResultAffected: 1,
-			},
-			expErr: nil,
+		"success_without_password": {
+			input:          InputUser{ID: 1, Name: "TEST2", Email: "test@example.com", Phone: "123456", Role: "ADMIN", IsActive: true},
+			mock:           mockData{roleExist: true, affected: 1},
+			expUpdated:     true,
+			expRoleChanged: true,
+		},
+		"success_with_password": {
+			input:              InputUser{ID: 1, Name: "TEST", Email: "test@example.com", Password: "Secret-Passw0rd", Phone: "123456", Role: "ADMIN", IsActive: true},
+			mock:               mockData{roleExist: true, histories: model.PasswordHistorySlice{{UserID: 1, Password: oldPasswordHash}}, affected: 1},
+			expUpdated:         true,
+			expPasswordChanged: true,
+			expRoleChanged:     true,
+		},
+		"success_new_email": {
+			input:      InputUser{ID: 1, Name: "TEST", Email: "new@example.com", Phone: "123456", Role: "GUEST", IsActive: true},
+			mock:       mockData{roleExist: true, affected: 1},
+			expUpdated: true,
+		},
+		"success_deactivated": {
+			input:      InputUser{ID: 1, Name: "TEST", Email: "test@example.com", Phone: "123456", Role: "GUEST", IsActive: false},
+			mock:       mockData{roleExist: true, affected: 1},
+			expUpdated: true,
+			expRevoked: true,
+		},
+		"error_role_change_without_role_write": {
+			caller: &auth.User{ID: 2, Role: "ADMIN", Permissions: []string{auth.PermUserWrite}},
+			input:  InputUser{ID: 1, Name: "TEST", Email: "test@example.com", Phone: "123456", Role: "ADMIN", IsActive: true},
+			mock:   mockData{roleExist: true, affected: 1},
+			expErr: ErrPermissionDenied,
+		},
+		"success_user_manager_keeps_role": {
+			caller:     &auth.User{ID: 2, Role: "ADMIN", Permissions: []string{auth.PermUserWrite}},
+			input:      InputUser{ID: 1, Name: "TEST2", Email: "test@example.com", Phone: "123456", Role: "GUEST", IsActive: true},
+			mock:       mockData{roleExist: true, affected: 1},
+			expUpdated: true,
 		},
 		"not_found": {
-			input: input{
-				updateUser: InputUser{
-					ID:       2,
-					Name:     "TEST",
-					Email:    "test@example",
-					Password: "test",
-					Phone:    "123456",
-					Role:     "ADMIN",
-					IsActive: true,
-				},
-				ctx:                context.Background(),
-				mockInputUser:      mock.AnythingOfType("User"),
-				mockInputCTX:       context.Background(),
-				mockResultAffected: 0,
-			},
+			input:  InputUser{ID: 1, Name: "TEST", Email: "test@example.com", Phone: "123456", Role: "ADMIN", IsActive: true},
+			mock:   mockData{userErr: sql.ErrNoRows},
+			expErr: ErrUserNotFound,
+		},
+		"deleted_concurrently": {
+			input:  InputUser{ID: 1, Name: "TEST", Email: "test@example.com", Password: "Secret-Passw0rd", Phone: "123456", Role: "ADMIN", IsActive: true},
+			mock:   mockData{roleExist: true},
 			expErr: ErrUserNotFound,
 		},
+		"email_existed": {
+			input:  InputUser{ID: 1, Name: "TEST", Email: "other@example.com", Phone: "123456", Role: "GUEST", IsActive: true},
+			mock:   mockData{emailExisted: true, roleExist: true, affected: 1},
+			expErr: ErrEmailExisted,
+		},
+		"role_not_found": {
+			input:  InputUser{ID: 1, Name: "TEST", Email: "test@example.com", Phone: "123456", Role: "WAREHOUSE", IsActive: true},
+			expErr: ErrRoleNotFound,
+		},
+		"weak_password": {
+			input:  InputUser{ID: 1, Name: "TEST", Email: "test@example.com", Password: "password1", Phone: "123456", Role: "ADMIN", IsActive: true},
+			mock:   mockData{roleExist: true, affected: 1},
+			expErr: fmt.Errorf("%w: %v", ErrWeakPassword, password.ErrTooCommon),
+		},
+		"reused_password": {
+			input:  InputUser{ID: 1, Name: "TEST", Email: "test@example.com", Password: "old-password", Phone: "123456", Role: "ADMIN", IsActive: true},
+			mock:   mockData{roleExist: true, histories: model.PasswordHistorySlice{{UserID: 1, Password: oldPasswordHash}}, affected: 1},
+			expErr: ErrPasswordReused,
+		},
 	}
 
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
 			// Given
+			caller := admin
+			if tc.caller != nil {
+				caller = *tc.caller
+			}
+			ctx := auth.NewContext(context.Background(), caller)
+			updated := model.User{
+				ID:       tc.input.ID,
+				Name:     tc.input.Name,
+				Email:    tc.input.Email,
+				Phone:    tc.input.Phone,
+				Role:     tc.input.Role,
+				IsActive: tc.input.IsActive,
+			}
 			userRepoMock := new(user.Mock)
-			userRepoMock.On("UpdateUser", tc.input.mockInputCTX, tc.input.mockInputUser).Return(tc.input.mockResultAffected, tc.input.mockResultError)
+			userRepoMock.On("GetUser", ctx, tc.input.ID).Return(currentUser, tc.mock.userErr)
+			userRepoMock.On("ExistsUserByEmail", ctx, tc.input.Email).Return(tc.mock.emailExisted, nil)
+			userRepoMock.On("GetPasswordHistories", ctx, 1, passwordHistorySize-1).Return(tc.mock.histories, nil)
+			userRepoMock.On("UpdateUser", ctx, updated).Return(tc.mock.affected, nil)
+			userRepoMock.On("UpdatePassword", ctx, 1, mock.AnythingOfType("string")).Return(int64(1), nil)
+			userRepoMock.On("CreatePasswordHistory", ctx, model.PasswordHistory{UserID: 1, Password: currentPasswordHash}).Return(nil)
+			userRepoMock.On("RevokeUserSessions", ctx, (*sql.Tx)(nil), 1).Return(int64(1), nil)
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("RevokeUserRefreshTokens", ctx, 1).Return(int64(1), nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("ExistsRoleByName", ctx, tc.input.Role).Return(tc.mock.roleExist, nil)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", ctx, mock.Anything).Return(model.SecurityEvent{}, nil)
 			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
 			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("Token").Return(tokenRepoMock)
+			repoMock.On("Tx", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(func(*sql.Tx) error)(nil)
+			})
 
 			service := New(repoMock)
 
 			// When
-			err := service.UpdateUser(tc.input.ctx, tc.input.updateUser)
+			err := service.UpdateUser(ctx, tc.input)
 
 			// Then
 			if tc.expErr != nil {
-				//must be error
 				require.EqualError(t, err, tc.expErr.Error())
 			} else {
-				//must be success
 				require.NoError(t, err)
 			}
+			if tc.expUpdated {
+				userRepoMock.AssertCalled(t, "UpdateUser", ctx, updated)
+			} else if tc.mock.affected > 0 {
+				userRepoMock.AssertNotCalled(t, "UpdateUser", ctx, updated)
+			}
+			if tc.input.Email == currentUser.Email {
+				userRepoMock.AssertNotCalled(t, "ExistsUserByEmail", ctx, tc.input.Email)
+			}
+			if tc.expPasswordChanged {
+				// The password is replaced like ChangePassword, the sessions of the user are signed out
+				userRepoMock.AssertCalled(t, "UpdatePassword", ctx, 1, mock.AnythingOfType("string"))
+				userRepoMock.AssertCalled(t, "CreatePasswordHistory", ctx, model.PasswordHistory{UserID: 1, Password: currentPasswordHash})
+				tokenRepoMock.AssertCalled(t, "RevokeUserRefreshTokens", ctx, 1)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", ctx, model.SecurityEvent{
+					UserID: null.IntFrom(tc.input.ID), Type: securityevent.TypePasswordChanged, Email: tc.input.Email,
+				})
+			} else {
+				userRepoMock.AssertNotCalled(t, "UpdatePassword", ctx, 1, mock.AnythingOfType("string"))
+				tokenRepoMock.AssertNotCalled(t, "RevokeUserRefreshTokens", ctx, 1)
+				securityEventRepoMock.AssertNotCalled(t, "CreateSecurityEvent", ctx, model.SecurityEvent{
+					UserID: null.IntFrom(tc.input.ID), Type: securityevent.TypePasswordChanged, Email: tc.input.Email,
+				})
+			}
+			roleChanged := model.SecurityEvent{UserID: null.IntFrom(tc.input.ID), Type: securityevent.TypeRoleChanged, Email: tc.input.Email}
+			if tc.expRoleChanged {
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", ctx, roleChanged)
+			} else {
+				securityEventRepoMock.AssertNotCalled(t, "CreateSecurityEvent", ctx, roleChanged)
+			}
+			tokenRevoked := model.SecurityEvent{UserID: null.IntFrom(tc.input.ID), Type: securityevent.TypeTokenRevoked, Email: tc.input.Email}
+			if tc.expRevoked {
+				// A deactivated user is signed out and cannot use its API keys anymore
+				userRepoMock.AssertCalled(t, "RevokeUserSessions", ctx, (*sql.Tx)(nil), 1)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", ctx, tokenRevoked)
+			} else {
+				userRepoMock.AssertNotCalled(t, "RevokeUserSessions", ctx, (*sql.Tx)(nil), 1)
+				securityEventRepoMock.AssertNotCalled(t, "CreateSecurityEvent", ctx, tokenRevoked)
+			}
 		})
 	}
 }
@@ -520,8 +789,19 @@ func TestUserService_DeleteUser(t *testing.T) {
 			// Given
 			repoMock := new(repository.Mock)
 			userRepoMock := new(user.Mock)
-			userRepoMock.On("DeleteUser", context.Background(), tc.given.mock.userID).Return(tc.given.mock.rowsAff, tc.given.mock.err)
+			userRepoMock.On("DeleteUser", context.Background(), (*sql.Tx)(nil), tc.given.mock.userID).Return(tc.given.mock.rowsAff, tc.given.mock.err)
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", context.Background(), mock.Anything).Return(model.SecurityEvent{}, nil)
 			repoMock.On("User").Return(userRepoMock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock).Maybe()
+			repoMock.On("Tx", context.Background(), mock.AnythingOfType("func(*sql.Tx) error")).Return(tc.expErr).Run(func(args mock.Arguments) {
+				err := args.Get(1).(func(*sql.Tx) error)(nil)
+				if tc.expErr != nil {
+					require.EqualError(t, err, tc.expErr.Error())
+				} else {
+					require.NoError(t, err)
+				}
+			})
 
 			userServ := New(repoMock)
 
@@ -532,9 +812,13 @@ func TestUserService_DeleteUser(t *testing.T) {
 			if tc.expErr != nil {
 				//must be error
 				require.EqualError(t, tc.expErr, err.Error())
+				securityEventRepoMock.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything)
 			} else {
 				//must be success
 				require.NoError(t, err)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", context.Background(), model.SecurityEvent{
+					UserID: null.IntFrom(tc.given.userID), Type: securityevent.TypeTokenRevoked,
+				})
 			}
 			userRepoMock.AssertExpectations(t)
 			repoMock.AssertExpectations(t)
@@ -542,18 +826,66 @@ func TestUserService_DeleteUser(t *testing.T) {
 	}
 }
 
+func TestUserService_RestoreUser(t *testing.T) {
+	tcs := map[string]struct {
+		userID  int
+		rowsAff int64
+		expErr  error
+	}{
+		"success": {
+			userID:  1,
+			rowsAff: 1,
+		},
+		"error_not_deleted_or_not_found": {
+			userID:  2,
+			rowsAff: 0,
+			expErr:  ErrUserNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("RestoreUser", ctx, tc.userID).Return(tc.rowsAff, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// When
+			err := userServ.RestoreUser(ctx, tc.userID)
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			userRepoMock.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUserService_Login(t *testing.T) {
 	type input struct {
-		ctx             context.Context
-		loginInput      LoginInput
-		mockInputCTX    context.Context
-		mockInputEmail  string
-		mockResultUser  model.User
-		mockResultError error
+		ctx                context.Context
+		loginInput         LoginInput
+		mockInputCTX       context.Context
+		mockInputEmail     string
+		mockResultUser     model.User
+		mockResultError    error
+		mockLockedScope    string
+		mockFailedAttempts int
+		mockTwoFactor      bool
 	}
 	type output struct {
-		result LoginResponse
-		err    error
+		result    LoginResponse
+		err       error
+		expFailed bool
+		expLocked bool
+		expRehash bool
 	}
 	tcs := map[string]struct {
 		input     input
@@ -563,8 +895,83 @@ func TestUserService_Login(t *testing.T) {
 			input: input{
 				ctx: context.Background(),
 				loginInput: LoginInput{
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
+				},
+				mockInputCTX:   context.Background(),
+				mockInputEmail: "example@example.com",
+				mockResultUser: model.User{
+					ID:              1,
+					Name:            "Guest",
+					Email:           "example@example.com",
+					Password:        "$2a$14$R9cbWpV2ZjDxjvtWSiZ12OxKxJgpVePfeP8MpumxWr0yq614nKPeK",
+					Phone:           "0987654321",
+					Role:            "GUEST",
+					IsActive:        true,
+					EmailVerifiedAt: null.TimeFrom(time.Now()),
+				},
+			},
+			expOutput: output{
+				result: LoginResponse{
+					Scope:     "GUEST",
+					ExpiresIn: tokenExpireTime,
+					TokenType: "Bearer",
+				},
+				expRehash: true,
+			},
+		},
+		"email_is_not_registered": {
+			input: input{
+				ctx: context.Background(),
+				loginInput: LoginInput{
+					Email:     "example2@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
+				},
+				mockInputCTX:       context.Background(),
+				mockInputEmail:     "example2@example.com",
+				mockResultError:    sql.ErrNoRows,
+				mockFailedAttempts: 1,
+			},
+			expOutput: output{
+				err:       ErrInvalidCredentials,
+				expFailed: true,
+			},
+		},
+		"password_is_incorrect": {
+			input: input{
+				ctx: context.Background(),
+				loginInput: LoginInput{
+					Email:     "example@example.com",
+					Password:  "12345678910",
+					IPAddress: "192.0.2.1",
+				},
+				mockInputCTX:   context.Background(),
+				mockInputEmail: "example@example.com",
+				mockResultUser: model.User{
+					ID:       1,
+					Name:     "Guest",
 					Email:    "example@example.com",
-					Password: "123456789",
+					Password: "$2a$14$R9cbWpV2ZjDxjvtWSiZ12OxKxJgpVePfeP8MpumxWr0yq614nKPeK",
+					Phone:    "0987654321",
+					Role:     "GUEST",
+					IsActive: true,
+				},
+				mockFailedAttempts: 1,
+			},
+			expOutput: output{
+				err:       ErrInvalidCredentials,
+				expFailed: true,
+			},
+		},
+		"password_is_incorrect_too_many_times": {
+			input: input{
+				ctx: context.Background(),
+				loginInput: LoginInput{
+					Email:     "example@example.com",
+					Password:  "12345678910",
+					IPAddress: "192.0.2.1",
 				},
 				mockInputCTX:   context.Background(),
 				mockInputEmail: "example@example.com",
@@ -577,36 +984,83 @@ func TestUserService_Login(t *testing.T) {
 					Role:     "GUEST",
 					IsActive: true,
 				},
+				mockFailedAttempts: accountLockoutPolicy.maxAttempts,
 			},
 			expOutput: output{
-				result: LoginResponse{
-					Scope:     "GUEST",
-					ExpiresIn: tokenExpireTime,
-					TokenType: "Bearer",
+				err:       ErrInvalidCredentials,
+				expFailed: true,
+				expLocked: true,
+			},
+		},
+		"account_is_locked": {
+			input: input{
+				ctx: context.Background(),
+				loginInput: LoginInput{
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
 				},
+				mockInputCTX:    context.Background(),
+				mockInputEmail:  "example@example.com",
+				mockLockedScope: loginfailure.ScopeAccount,
+			},
+			expOutput: output{
+				err: ErrTooManyLoginAttempts,
 			},
 		},
-		"email_is_not_registered": {
+		"ip_address_is_locked": {
 			input: input{
 				ctx: context.Background(),
 				loginInput: LoginInput{
-					Email:    "example2@example.com",
-					Password: "123456789",
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
 				},
 				mockInputCTX:    context.Background(),
-				mockInputEmail:  "example2@example.com",
-				mockResultError: sql.ErrNoRows,
+				mockInputEmail:  "example@example.com",
+				mockLockedScope: loginfailure.ScopeIP,
 			},
 			expOutput: output{
-				err: ErrEmailNotExist,
+				err: ErrTooManyLoginAttempts,
 			},
 		},
-		"password_is_incorrect": {
+		"two_factor_required": {
 			input: input{
 				ctx: context.Background(),
 				loginInput: LoginInput{
-					Email:    "example@example.com",
-					Password: "12345678910",
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
+				},
+				mockInputCTX:   context.Background(),
+				mockInputEmail: "example@example.com",
+				mockResultUser: model.User{
+					ID:              1,
+					Name:            "Admin",
+					Email:           "example@example.com",
+					Password:        "$2a$14$R9cbWpV2ZjDxjvtWSiZ12OxKxJgpVePfeP8MpumxWr0yq614nKPeK",
+					Phone:           "0987654321",
+					Role:            "ADMIN",
+					IsActive:        true,
+					EmailVerifiedAt: null.TimeFrom(time.Now()),
+				},
+				mockTwoFactor: true,
+			},
+			expOutput: output{
+				result: LoginResponse{
+					TwoFactorRequired: true,
+					ExpiresIn:         twoFactorChallengeExpire,
+				},
+				expRehash: true,
+			},
+		},
+		"email_is_not_verified": {
+			input: input{
+				ctx: context.Background(),
+				loginInput: LoginInput{
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
 				},
 				mockInputCTX:   context.Background(),
 				mockInputEmail: "example@example.com",
@@ -621,17 +1075,70 @@ func TestUserService_Login(t *testing.T) {
 				},
 			},
 			expOutput: output{
-				err: ErrPasswordIncorrect,
+				err:       ErrEmailNotVerified,
+				expRehash: true,
+			},
+		},
+		"user_is_inactive": {
+			input: input{
+				ctx: context.Background(),
+				loginInput: LoginInput{
+					Email:     "example@example.com",
+					Password:  "123456789",
+					IPAddress: "192.0.2.1",
+				},
+				mockInputCTX:   context.Background(),
+				mockInputEmail: "example@example.com",
+				mockResultUser: model.User{
+					ID:              1,
+					Name:            "Guest",
+					Email:           "example@example.com",
+					Password:        "$2a$14$R9cbWpV2ZjDxjvtWSiZ12OxKxJgpVePfeP8MpumxWr0yq614nKPeK",
+					Phone:           "0987654321",
+					Role:            "GUEST",
+					IsActive:        false,
+					EmailVerifiedAt: null.TimeFrom(time.Now()),
+				},
+			},
+			expOutput: output{
+				err:       ErrUserInactive,
+				expRehash: true,
 			},
 		},
 	}
 	for desc, tc := range tcs {
 		t.Run(desc, func(t *testing.T) {
 			// GIVEN
+			securityEventRepoMock := new(securityevent.Mock)
+			securityEventRepoMock.On("CreateSecurityEvent", mock.Anything, mock.Anything).Return(model.SecurityEvent{}, nil)
 			repoMock := new(repository.Mock)
+			repoMock.On("SecurityEvent").Return(securityEventRepoMock)
 			userRepoMock := new(user.Mock)
-			userRepoMock.On("GetUserByEmail", tc.input.mockInputCTX, tc.input.mockInputEmail).Return(tc.input.mockResultUser, tc.input.mockResultError)
+			userRepoMock.On("GetUserByEmail", auth.NewUnscopedContext(tc.input.mockInputCTX), tc.input.mockInputEmail).Return(tc.input.mockResultUser, tc.input.mockResultError)
+			userRepoMock.On("UpdatePasswordHash", mock.Anything, tc.input.mockResultUser.ID, tc.input.mockResultUser.Password, mock.AnythingOfType("string")).Return(int64(1), nil)
 			repoMock.On("User").Return(userRepoMock)
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("model.RefreshToken")).Return(model.RefreshToken{}, nil)
+			repoMock.On("Token").Return(tokenRepoMock)
+			loginFailureRepoMock := new(loginfailure.Mock)
+			for _, scope := range []string{loginfailure.ScopeAccount, loginfailure.ScopeIP} {
+				failure, failureErr := model.LoginFailure{}, error(sql.ErrNoRows)
+				if scope == tc.input.mockLockedScope {
+					failure, failureErr = model.LoginFailure{LockedUntil: null.TimeFrom(time.Now().Add(time.Minute))}, nil
+				}
+				loginFailureRepoMock.On("GetLoginFailure", mock.Anything, scope, mock.AnythingOfType("string")).Return(failure, failureErr)
+				loginFailureRepoMock.On("RecordLoginFailure", mock.Anything, scope, mock.AnythingOfType("string"), mock.AnythingOfType("time.Duration")).Return(model.LoginFailure{FailedAttempts: tc.input.mockFailedAttempts}, nil)
+			}
+			loginFailureRepoMock.On("LockLoginFailure", mock.Anything, loginfailure.ScopeAccount, tc.input.mockInputEmail, mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+			loginFailureRepoMock.On("DeleteLoginFailure", mock.Anything, loginfailure.ScopeAccount, tc.input.mockInputEmail).Return(int64(1), nil)
+			repoMock.On("LoginFailure").Return(loginFailureRepoMock)
+			twoFactorRepoMock := new(twofactor.Mock)
+			if tc.input.mockTwoFactor {
+				twoFactorRepoMock.On("GetTOTPSecret", mock.Anything, tc.input.mockResultUser.ID).Return(model.TotpSecret{UserID: tc.input.mockResultUser.ID, ConfirmedAt: null.TimeFrom(time.Now())}, nil)
+			} else {
+				twoFactorRepoMock.On("GetTOTPSecret", mock.Anything, tc.input.mockResultUser.ID).Return(model.TotpSecret{}, sql.ErrNoRows)
+			}
+			repoMock.On("TwoFactor").Return(twoFactorRepoMock)
 
 			userServ := New(repoMock)
 
@@ -641,9 +1148,171 @@ func TestUserService_Login(t *testing.T) {
 			// THEN
 			if err != nil {
 				require.EqualError(t, err, tc.expOutput.err.Error())
+				twoFactorRepoMock.AssertNotCalled(t, "GetTOTPSecret", mock.Anything, tc.input.mockResultUser.ID)
+				tokenRepoMock.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.AnythingOfType("model.RefreshToken"))
+			} else if tc.input.mockTwoFactor {
+				tc.expOutput.result.ChallengeToken = result.ChallengeToken
+				require.NotEmpty(t, result.ChallengeToken)
+				require.Equal(t, tc.expOutput.result, result)
+				loginFailureRepoMock.AssertNotCalled(t, "DeleteLoginFailure", mock.Anything, loginfailure.ScopeAccount, tc.input.mockInputEmail)
 			} else {
 				tc.expOutput.result.AccessToken = result.AccessToken
+				tc.expOutput.result.RefreshToken = result.RefreshToken
+				require.NotEmpty(t, result.RefreshToken)
 				require.Equal(t, tc.expOutput.result, result)
+				loginFailureRepoMock.AssertCalled(t, "DeleteLoginFailure", mock.Anything, loginfailure.ScopeAccount, tc.input.mockInputEmail)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", mock.Anything, model.SecurityEvent{
+					UserID: null.IntFrom(tc.input.mockResultUser.ID), Type: securityevent.TypeLoginSucceeded, Email: tc.input.mockInputEmail,
+				})
+			}
+			if tc.expOutput.expFailed {
+				loginFailureRepoMock.AssertCalled(t, "RecordLoginFailure", mock.Anything, loginfailure.ScopeAccount, tc.input.mockInputEmail, accountLockoutPolicy.lockout)
+				securityEventRepoMock.AssertCalled(t, "CreateSecurityEvent", mock.Anything, mock.MatchedBy(func(event model.SecurityEvent) bool {
+					return event.Type == securityevent.TypeLoginFailed && event.Email == tc.input.mockInputEmail
+				}))
+				loginFailureRepoMock.AssertCalled(t, "RecordLoginFailure", mock.Anything, loginfailure.ScopeIP, tc.input.loginInput.IPAddress, ipLockoutPolicy.lockout)
+			} else {
+				loginFailureRepoMock.AssertNotCalled(t, "RecordLoginFailure", mock.Anything, loginfailure.ScopeAccount, tc.input.mockInputEmail, accountLockoutPolicy.lockout)
+			}
+			if tc.expOutput.expLocked {
+				loginFailureRepoMock.AssertCalled(t, "LockLoginFailure", mock.Anything, loginfailure.ScopeAccount, tc.input.mockInputEmail, mock.AnythingOfType("time.Time"))
+			} else {
+				loginFailureRepoMock.AssertNotCalled(t, "LockLoginFailure", mock.Anything, loginfailure.ScopeAccount, tc.input.mockInputEmail, mock.AnythingOfType("time.Time"))
+			}
+			// The stored hashes have cost 14, they are upgraded to the current cost after the password is checked
+			if tc.expOutput.expRehash {
+				userRepoMock.AssertCalled(t, "UpdatePasswordHash", mock.Anything, tc.input.mockResultUser.ID, tc.input.mockResultUser.Password, mock.AnythingOfType("string"))
+			} else {
+				userRepoMock.AssertNotCalled(t, "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUserService_VerifyAccessToken(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_KEY", "secret")
+	_, validToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "admin@example.com",
+		Role:      "ADMIN",
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+	})
+	require.NoError(t, err)
+	_, organizationToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:             1,
+		Email:          "admin@example.com",
+		Role:           "ADMIN",
+		OrganizationID: 2,
+		SecretKey:      "secret",
+		ExpiresIn:      time.Minute,
+	})
+	require.NoError(t, err)
+	_, impersonationToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:             1,
+		Email:          "guest@example.com",
+		Role:           "GUEST",
+		OrganizationID: 2,
+		ActorID:        3,
+		SecretKey:      "secret",
+		ExpiresIn:      time.Minute,
+	})
+	require.NoError(t, err)
+	_, otherKeyToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "admin@example.com",
+		Role:      "ADMIN",
+		SecretKey: "other",
+		ExpiresIn: time.Minute,
+	})
+	require.NoError(t, err)
+	_, expiredToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "admin@example.com",
+		Role:      "ADMIN",
+		SecretKey: "secret",
+		ExpiresIn: -time.Minute,
+	})
+	require.NoError(t, err)
+	_, verificationToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "admin@example.com",
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+		Purpose:   emailVerificationPurpose,
+	})
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		token     string
+		revoked   bool
+		inactive  bool
+		expResult auth.User
+		expErr    error
+	}{
+		"success": {
+			token:     organizationToken,
+			expResult: auth.User{ID: 1, Email: "admin@example.com", Role: "ADMIN", OrganizationID: 2, Permissions: []string{auth.PermUserRead, auth.PermUserWrite}},
+		},
+		"success_token_without_organization": {
+			token:     validToken,
+			expResult: auth.User{ID: 1, Email: "admin@example.com", Role: "ADMIN", OrganizationID: auth.DefaultOrganizationID, Permissions: []string{auth.PermUserRead, auth.PermUserWrite}},
+		},
+		"success_impersonation_token": {
+			token:     impersonationToken,
+			expResult: auth.User{ID: 1, Email: "guest@example.com", Role: "GUEST", OrganizationID: 2, Permissions: []string{auth.PermUserRead, auth.PermUserWrite}, ActorID: 3},
+		},
+		"error_revoked": {
+			token:   validToken,
+			revoked: true,
+			expErr:  ErrInvalidToken,
+		},
+		"error_inactive_user": {
+			token:    validToken,
+			inactive: true,
+			expErr:   ErrUserInactive,
+		},
+		"error_signed_by_other_key": {
+			token:  otherKeyToken,
+			expErr: ErrInvalidToken,
+		},
+		"error_expired": {
+			token:  expiredToken,
+			expErr: ErrInvalidToken,
+		},
+		"error_malformed": {
+			token:  "abcd",
+			expErr: ErrInvalidToken,
+		},
+		"error_email_verification_token": {
+			token:  verificationToken,
+			expErr: ErrInvalidToken,
+		},
+	}
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			tokenRepoMock := new(token.Mock)
+			tokenRepoMock.On("IsAccessTokenRevoked", context.Background(), mock.AnythingOfType("string"), 1, mock.AnythingOfType("time.Time")).Return(tc.revoked, nil)
+			roleRepoMock := new(role.Mock)
+			roleRepoMock.On("GetUserPermissions", context.Background(), 1).Return([]string{auth.PermUserRead, auth.PermUserWrite}, nil)
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUser", mock.Anything, 1).Return(model.User{ID: 1, IsActive: !tc.inactive}, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("Token").Return(tokenRepoMock)
+			repoMock.On("Role").Return(roleRepoMock)
+			repoMock.On("User").Return(userRepoMock)
+			userServ := New(repoMock)
+
+			// WHEN
+			result, err := userServ.VerifyAccessToken(context.Background(), tc.token)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, result)
 			}
 		})
 	}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+)
+
+const (
+	emailVerificationPurpose    = "email_verification"
+	emailVerificationExpireTime = 24 * time.Hour
+	verificationResendInterval  = time.Minute
+)
+
+// sendVerificationEmail sends a link with a signed token to verify the email of the user.
+// Only one email is sent per verificationResendInterval.
+func (serv impl) sendVerificationEmail(ctx context.Context, user model.User) error {
+	// 1. Record the sending time, nothing is recorded if an email was sent recently
+	affected, err := serv.repo.User().UpdateVerificationSentAt(ctx, user.ID, verificationResendInterval)
+	if err != nil {
+		return err
+	}
+	if affected < 1 {
+		return ErrTooManyRequests
+	}
+
+	// 2. Generate the verification token signed for the current email
+	verificationToken, err := signToken(jwt.JWTInput{
+		ID:        user.ID,
+		Email:     user.Email,
+		ExpiresIn: emailVerificationExpireTime,
+		Purpose:   emailVerificationPurpose,
+	})
+	if err != nil {
+		return ErrTokeCannotBeGenerated
+	}
+
+	// 3. Send the verification link
+	verifyURL := os.Getenv("APP_URL") + "/api/v1/users/email/verify?token=" + verificationToken
+	if err = sendEmail(mail.EmailInput{
+		To:      []string{user.Email},
+		Subject: "Verify your email",
+		Message: fmt.Sprintf("Click <a href=\"%s\">here</a> to verify your email. The link expires in %d hours.", verifyURL, int(emailVerificationExpireTime.Hours())),
+	}); err != nil {
+		return fmt.Errorf("failed sending email: %v", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the email of the user as verified by the verification token
+func (serv impl) VerifyEmail(ctx context.Context, token string) error {
+	// 1. Verify the signature, expiry and purpose of the token
+	claims, err := parseToken(token)
+	if err != nil || claims.Purpose != emailVerificationPurpose {
+		return ErrInvalidToken
+	}
+
+	// 2. Mark the email as verified, the token is invalid if the email of the user was changed
+	affected, err := serv.repo.User().VerifyEmail(lookupContext(ctx), claims.ID, claims.Email)
+	if err != nil {
+		return err
+	}
+	if affected < 1 {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// ResendVerificationEmail sends the verification link again to an unverified email.
+// It does not return an error for unknown or verified emails, so the caller cannot find out which emails are registered.
+func (serv impl) ResendVerificationEmail(ctx context.Context, email string) error {
+	// 1. Get user with email
+	user, err := serv.repo.User().GetUserByEmail(lookupContext(ctx), email)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("Skipping verification email because email does not exist")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	ctx = auth.NewTenantContext(ctx, user.OrganizationID)
+	if user.EmailVerifiedAt.Valid {
+		log.Printf("Skipping verification email because email of user %d is already verified\n", user.ID)
+		return nil
+	}
+
+	// 2. Send the verification link
+	return serv.sendVerificationEmail(ctx, user)
+}
@@ -0,0 +1,189 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/model"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository"
+	"github.com/vinhnv1/s3corp-golang-fresher/internal/repository/user"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/auth"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/jwt"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/mail"
+)
+
+func TestUserService_VerifyEmail(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_KEY", "secret")
+	_, validToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "guest@example.com",
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+		Purpose:   emailVerificationPurpose,
+	})
+	require.NoError(t, err)
+	_, expiredToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "guest@example.com",
+		SecretKey: "secret",
+		ExpiresIn: -time.Minute,
+		Purpose:   emailVerificationPurpose,
+	})
+	require.NoError(t, err)
+	_, accessToken, err := jwt.GenerateJWTToken(jwt.JWTInput{
+		ID:        1,
+		Email:     "guest@example.com",
+		Role:      "GUEST",
+		SecretKey: "secret",
+		ExpiresIn: time.Minute,
+	})
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		token       string
+		affected    int64
+		expVerified bool
+		expErr      error
+	}{
+		"success": {
+			token:       validToken,
+			affected:    1,
+			expVerified: true,
+		},
+		"error_email_changed": {
+			token:       validToken,
+			affected:    0,
+			expVerified: true,
+			expErr:      ErrInvalidToken,
+		},
+		"error_expired": {
+			token:  expiredToken,
+			expErr: ErrInvalidToken,
+		},
+		"error_access_token": {
+			token:  accessToken,
+			expErr: ErrInvalidToken,
+		},
+		"error_malformed": {
+			token:  "abcd",
+			expErr: ErrInvalidToken,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ctx := context.Background()
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("VerifyEmail", auth.NewUnscopedContext(ctx), 1, "guest@example.com").Return(tc.affected, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.VerifyEmail(ctx, tc.token)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expVerified {
+				userRepoMock.AssertCalled(t, "VerifyEmail", auth.NewUnscopedContext(ctx), 1, "guest@example.com")
+			} else {
+				userRepoMock.AssertNotCalled(t, "VerifyEmail", auth.NewUnscopedContext(ctx), 1, "guest@example.com")
+			}
+		})
+	}
+}
+
+func TestUserService_ResendVerificationEmail(t *testing.T) {
+	type mockData struct {
+		user         model.User
+		userErr      error
+		sentAffected int64
+	}
+	tcs := map[string]struct {
+		email   string
+		mock    mockData
+		expSent bool
+		expErr  error
+	}{
+		"success": {
+			email: "guest@example.com",
+			mock: mockData{
+				user:         model.User{ID: 1, Email: "guest@example.com"},
+				sentAffected: 1,
+			},
+			expSent: true,
+		},
+		"email_is_not_registered": {
+			email: "unknown@example.com",
+			mock: mockData{
+				userErr: sql.ErrNoRows,
+			},
+		},
+		"email_is_already_verified": {
+			email: "guest@example.com",
+			mock: mockData{
+				user: model.User{ID: 1, Email: "guest@example.com", EmailVerifiedAt: null.TimeFrom(time.Now())},
+			},
+		},
+		"error_sent_recently": {
+			email: "guest@example.com",
+			mock: mockData{
+				user:         model.User{ID: 1, Email: "guest@example.com"},
+				sentAffected: 0,
+			},
+			expErr: ErrTooManyRequests,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("ACCESS_TOKEN_KEY", "secret")
+			t.Setenv("APP_URL", "http://localhost:5000")
+			ctx := context.Background()
+			var sent []mail.EmailInput
+			sendEmail = func(input mail.EmailInput) error {
+				sent = append(sent, input)
+				return nil
+			}
+			defer func() { sendEmail = mail.SendEmail }()
+
+			userRepoMock := new(user.Mock)
+			userRepoMock.On("GetUserByEmail", auth.NewUnscopedContext(ctx), tc.email).Return(tc.mock.user, tc.mock.userErr)
+			userRepoMock.On("UpdateVerificationSentAt", auth.NewTenantContext(ctx, tc.mock.user.OrganizationID), tc.mock.user.ID, verificationResendInterval).Return(tc.mock.sentAffected, nil)
+			repoMock := new(repository.Mock)
+			repoMock.On("User").Return(userRepoMock)
+
+			userServ := New(repoMock)
+
+			// WHEN
+			err := userServ.ResendVerificationEmail(ctx, tc.email)
+
+			// THEN
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expSent {
+				require.Len(t, sent, 1)
+				require.Equal(t, []string{tc.email}, sent[0].To)
+				require.True(t, strings.Contains(sent[0].Message, "http://localhost:5000/api/v1/users/email/verify?token="))
+			} else {
+				require.Empty(t, sent)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package address
+
+import (
+	"regexp"
+	"strings"
+)
+
+// countries are the ISO 3166-1 alpha-2 country codes
+var countries = map[string]bool{}
+
+func init() {
+	for _, code := range strings.Fields(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ
+		BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+		CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ
+		DE DJ DK DM DO DZ
+		EC EE EG EH ER ES ET
+		FI FJ FK FM FO FR
+		GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY
+		HK HM HN HR HT HU
+		ID IE IL IM IN IO IQ IR IS IT
+		JE JM JO JP
+		KE KG KH KI KM KN KP KR KW KY KZ
+		LA LB LC LI LK LR LS LT LU LV LY
+		MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ
+		NA NC NE NF NG NI NL NO NP NR NU NZ
+		OM
+		PA PE PF PG PH PK PL PM PN PR PS PT PW PY
+		QA
+		RE RO RS RU RW
+		SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ
+		TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ
+		UA UG UM US UY UZ
+		VA VC VE VG VI VN VU
+		WF WS
+		YE YT
+		ZA ZM ZW`) {
+		countries[code] = true
+	}
+}
+
+// postalCodePatterns are the formats of the postal codes of the main countries
+var postalCodePatterns = map[string]*regexp.Regexp{
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"CA": regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+	"CN": regexp.MustCompile(`^\d{6}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+	"IN": regexp.MustCompile(`^\d{6}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"KR": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`),
+	"SG": regexp.MustCompile(`^\d{6}$`),
+	"TH": regexp.MustCompile(`^\d{5}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"VN": regexp.MustCompile(`^\d{5}$`),
+}
+
+// genericPostalCode is the format of the postal codes of the other countries
+var genericPostalCode = regexp.MustCompile(`^[A-Z\d][A-Z\d -]{1,8}[A-Z\d]$`)
+
+// noPostalCode are the countries which do not use postal codes
+var noPostalCode = map[string]bool{
+	"AE": true, "AG": true, "AO": true, "AW": true, "BF": true, "BI": true, "BJ": true, "BS": true,
+	"BW": true, "BZ": true, "CD": true, "CF": true, "CG": true, "CI": true, "CK": true, "CM": true,
+	"DJ": true, "DM": true, "ER": true, "FJ": true, "GA": true, "GD": true, "GH": true, "GM": true,
+	"GQ": true, "GY": true, "HK": true, "IE": true, "KI": true, "KM": true, "KN": true, "KP": true,
+	"LC": true, "ML": true, "MO": true, "MR": true, "MW": true, "NR": true, "NU": true, "QA": true,
+	"RW": true, "SB": true, "SC": true, "SL": true, "SR": true, "ST": true, "SY": true, "TD": true,
+	"TF": true, "TG": true, "TK": true, "TL": true, "TO": true, "TV": true, "UG": true, "VU": true,
+	"YE": true, "ZW": true,
+}
+
+// IsValidCountry returns true if the code is an uppercase ISO 3166-1 alpha-2 country code
+func IsValidCountry(code string) bool {
+	return countries[code]
+}
+
+// IsValidPostalCode returns true if the postal code has the format of the country.
+// The postal code is optional for the countries which do not use postal codes.
+func IsValidPostalCode(country string, postalCode string) bool {
+	if postalCode == "" {
+		return noPostalCode[country]
+	}
+	if pattern, ok := postalCodePatterns[country]; ok {
+		return pattern.MatchString(postalCode)
+	}
+	return genericPostalCode.MatchString(postalCode)
+}
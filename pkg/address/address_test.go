@@ -0,0 +1,83 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidCountry(t *testing.T) {
+	tcs := map[string]struct {
+		given string
+		exp   bool
+	}{
+		"vn":        {given: "VN", exp: true},
+		"us":        {given: "US", exp: true},
+		"zw":        {given: "ZW", exp: true},
+		"lowercase": {given: "vn"},
+		"unknown":   {given: "XX"},
+		"alpha_3":   {given: "VNM"},
+		"blank":     {given: ""},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result := IsValidCountry(tc.given)
+
+			// THEN
+			require.Equal(t, tc.exp, result)
+		})
+	}
+}
+
+func TestIsValidPostalCode(t *testing.T) {
+	tcs := map[string]struct {
+		givenCountry    string
+		givenPostalCode string
+		exp             bool
+	}{
+		"vn":                  {givenCountry: "VN", givenPostalCode: "70000", exp: true},
+		"vn_six_digits":       {givenCountry: "VN", givenPostalCode: "700000"},
+		"vn_four_digits":      {givenCountry: "VN", givenPostalCode: "7000"},
+		"vn_letters":          {givenCountry: "VN", givenPostalCode: "7000A"},
+		"us":                  {givenCountry: "US", givenPostalCode: "94105", exp: true},
+		"us_zip_plus_4":       {givenCountry: "US", givenPostalCode: "94105-1234", exp: true},
+		"us_short_plus_4":     {givenCountry: "US", givenPostalCode: "94105-123"},
+		"au":                  {givenCountry: "AU", givenPostalCode: "2000", exp: true},
+		"ca":                  {givenCountry: "CA", givenPostalCode: "K1A 0B1", exp: true},
+		"ca_without_space":    {givenCountry: "CA", givenPostalCode: "K1A0B1", exp: true},
+		"ca_lowercase":        {givenCountry: "CA", givenPostalCode: "k1a 0b1"},
+		"cn":                  {givenCountry: "CN", givenPostalCode: "100000", exp: true},
+		"de":                  {givenCountry: "DE", givenPostalCode: "10115", exp: true},
+		"fr":                  {givenCountry: "FR", givenPostalCode: "75001", exp: true},
+		"gb":                  {givenCountry: "GB", givenPostalCode: "SW1A 1AA", exp: true},
+		"gb_short":            {givenCountry: "GB", givenPostalCode: "M1 1AE", exp: true},
+		"gb_invalid":          {givenCountry: "GB", givenPostalCode: "12345"},
+		"in":                  {givenCountry: "IN", givenPostalCode: "110001", exp: true},
+		"jp":                  {givenCountry: "JP", givenPostalCode: "100-0001", exp: true},
+		"jp_without_hyphen":   {givenCountry: "JP", givenPostalCode: "1000001", exp: true},
+		"kr":                  {givenCountry: "KR", givenPostalCode: "03187", exp: true},
+		"nl":                  {givenCountry: "NL", givenPostalCode: "1012 AB", exp: true},
+		"nl_digits_only":      {givenCountry: "NL", givenPostalCode: "1012"},
+		"sg":                  {givenCountry: "SG", givenPostalCode: "018956", exp: true},
+		"th":                  {givenCountry: "TH", givenPostalCode: "10200", exp: true},
+		"generic":             {givenCountry: "BR", givenPostalCode: "01310-100", exp: true},
+		"generic_too_short":   {givenCountry: "BR", givenPostalCode: "1"},
+		"generic_too_long":    {givenCountry: "BR", givenPostalCode: "12345678901"},
+		"generic_symbols":     {givenCountry: "BR", givenPostalCode: "01310#100"},
+		"blank":               {givenCountry: "VN", givenPostalCode: ""},
+		"blank_no_postal":     {givenCountry: "HK", givenPostalCode: "", exp: true},
+		"given_for_no_postal": {givenCountry: "HK", givenPostalCode: "999077", exp: true},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result := IsValidPostalCode(tc.givenCountry, tc.givenPostalCode)
+
+			// THEN
+			require.Equal(t, tc.exp, result)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+)
+
+const (
+	RoleAdmin = "ADMIN"
+	RoleGuest = "GUEST"
+)
+
+// DefaultOrganizationID is the organization which data belongs to when no organization is given
+const DefaultOrganizationID = 1
+
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// Permissions are granted to roles, the ones ending with ":any" allow the action on resources of other users
+const (
+	PermUserRead             = "user:read"
+	PermUserWrite            = "user:write"
+	PermRoleRead             = "role:read"
+	PermRoleWrite            = "role:write"
+	PermProductWrite         = "product:write"
+	PermProductWriteAny      = "product:write:any"
+	PermOrderRead            = "order:read"
+	PermOrderReadAny         = "order:read:any"
+	PermOrderWrite           = "order:write"
+	PermOrderWriteAny        = "order:write:any"
+	PermStatisticsRead       = "statistics:read"
+	PermAPIKeyWriteAny       = "api_key:write:any"
+	PermOrganizationRead     = "organization:read"
+	PermOrganizationWrite    = "organization:write"
+	PermCategoryWrite        = "category:write"
+	PermTwoFactorWrite       = "two_factor:write"
+	PermImpersonationRead    = "impersonation:read"
+	PermImpersonationWrite   = "impersonation:write"
+	PermDataRequestRead      = "data_request:read"
+	PermSecurityEventReadAny = "security_event:read:any"
+)
+
+// PlatformPermissions manage the organizations, they are only granted to the roles of DefaultOrganizationID which operates the platform
+var PlatformPermissions = []string{PermOrganizationRead, PermOrganizationWrite}
+
+// IsPlatformPermission returns true if the permission is one of PlatformPermissions
+func IsPlatformPermission(permission string) bool {
+	for _, p := range PlatformPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// User represents the authenticated caller of a request
+type User struct {
+	ID    int
+	Email string
+	Role  string
+
+	// OrganizationID is the organization the user is a member of, the data of the request is limited to it
+	OrganizationID int
+
+	// Permissions are the permissions of all roles of the user
+	Permissions []string
+
+	// Scope limits the operations of a request authenticated by an API key, it is empty for access tokens
+	Scope string
+
+	// ActorID is the admin who impersonates the user, it is zero if the user is not impersonated
+	ActorID int
+}
+
+// HasPermission returns true if one of the roles of the user grants the permission
+func (u User) HasPermission(permission string) bool {
+	for _, p := range u.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccess returns true if the user has the permission for resources of the owner.
+// The owner needs the permission itself, other users need the permission with the ":any" suffix.
+func (u User) CanAccess(permission string, ownerID int) bool {
+	if u.ID == ownerID && u.HasPermission(permission) {
+		return true
+	}
+	return u.HasPermission(permission + ":any")
+}
+
+// IsAPIKey returns true if the user is authenticated by an API key
+func (u User) IsAPIKey() bool {
+	return u.Scope != ""
+}
+
+// IsImpersonated returns true if the user is impersonated by an admin
+func (u User) IsImpersonated() bool {
+	return u.ActorID > 0
+}
+
+// CanWrite returns true if the user is allowed to perform write operations
+func (u User) CanWrite() bool {
+	return u.Scope != ScopeRead
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx which carries the given user, ctx is scoped to the organization of the user
+func NewContext(ctx context.Context, user User) context.Context {
+	if user.OrganizationID > 0 {
+		ctx = NewTenantContext(ctx, user.OrganizationID)
+	}
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// FromContext returns the user stored in ctx, ok is false if the request is anonymous
+func FromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(contextKey{}).(User)
+	return user, ok
+}
+
+type tenantContextKey struct{}
+
+// NewTenantContext returns a copy of ctx which is scoped to the given organization, the latest scope of ctx wins
+func NewTenantContext(ctx context.Context, organizationID int) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, organizationID)
+}
+
+// unscopedTenant is the scope of a ctx which is explicitly not limited to an organization
+type unscopedTenant struct{}
+
+// NewUnscopedContext returns a copy of ctx which is not limited to any organization, e.g. to find the user of a globally unique email
+// before the organization of the user is known. A ctx without any scope is limited to DefaultOrganizationID, so queries across
+// organizations have to opt in by this function. The latest scope of ctx wins.
+func NewUnscopedContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, unscopedTenant{})
+}
+
+// TenantFromContext returns the organization which ctx is scoped to, ok is false if ctx is not scoped to any organization
+func TenantFromContext(ctx context.Context) (int, bool) {
+	organizationID, ok := ctx.Value(tenantContextKey{}).(int)
+	return organizationID, ok
+}
+
+// IsUnscoped returns true if ctx was explicitly made not to be limited to any organization by NewUnscopedContext
+func IsUnscoped(ctx context.Context) bool {
+	_, ok := ctx.Value(tenantContextKey{}).(unscopedTenant)
+	return ok
+}
+
+// Client is the client which sent the request, it is recorded with the security events of the user
+type Client struct {
+	IPAddress string
+	UserAgent string
+}
+
+type clientContextKey struct{}
+
+// NewClientContext returns a copy of ctx which carries the given client
+func NewClientContext(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+// ClientFromContext returns the client stored in ctx, it is empty if ctx does not carry a client, e.g. for jobs
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientContextKey{}).(Client)
+	return client
+}
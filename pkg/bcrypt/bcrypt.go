@@ -1,12 +1,36 @@
 package bcrypt
 
 import (
+	"os"
+	"strconv"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// DefaultCost is the cost of new hashes if BCRYPT_COST is not set
+const DefaultCost = 12
+
+// MinCost is the lowest cost, it is only enough for long random passwords such as generated temporary passwords
+const MinCost = bcrypt.MinCost
+
+// Cost returns the cost of new hashes from BCRYPT_COST, DefaultCost is used if it is not set or invalid
+func Cost() int {
+	cost, err := strconv.Atoi(os.Getenv("BCRYPT_COST"))
+	if err != nil || cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return DefaultCost
+	}
+	return cost
+}
+
 // HashPassword hash password using the provided password and the provided algorithm
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+	return HashPasswordWithCost(password, Cost())
+}
+
+// HashPasswordWithCost hashes the password with the given cost instead of the current cost, NeedsRehash is true for the hash
+// if the cost is not the current cost
+func HashPasswordWithCost(password string, cost int) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), cost)
 	return string(bytes), err
 }
 
@@ -15,3 +39,9 @@ func CheckPasswordHash(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// NeedsRehash returns true if the hash was generated with another cost than the current cost
+func NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost != Cost()
+}
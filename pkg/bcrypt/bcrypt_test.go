@@ -0,0 +1,100 @@
+package bcrypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCost(t *testing.T) {
+	tcs := map[string]struct {
+		given string
+		exp   int
+	}{
+		"not_set":      {given: "", exp: DefaultCost},
+		"low":          {given: "4", exp: 4},
+		"high":         {given: "14", exp: 14},
+		"max":          {given: "31", exp: 31},
+		"below_min":    {given: "3", exp: DefaultCost},
+		"above_max":    {given: "32", exp: DefaultCost},
+		"not_a_number": {given: "ten", exp: DefaultCost},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("BCRYPT_COST", tc.given)
+
+			// WHEN
+			result := Cost()
+
+			// THEN
+			require.Equal(t, tc.exp, result)
+		})
+	}
+}
+
+func TestHashPassword(t *testing.T) {
+	// GIVEN
+	t.Setenv("BCRYPT_COST", "5")
+
+	// WHEN
+	hash, err := HashPassword("Secret-Passw0rd")
+
+	// THEN
+	require.NoError(t, err)
+	require.True(t, CheckPasswordHash("Secret-Passw0rd", hash))
+	require.False(t, CheckPasswordHash("secret-passw0rd", hash))
+	require.False(t, NeedsRehash(hash))
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hashWithCost := func(cost int) string {
+		hash, err := HashPasswordWithCost("Secret-Passw0rd", cost)
+		require.NoError(t, err)
+		return hash
+	}
+
+	tcs := map[string]struct {
+		givenCost string
+		givenHash string
+		exp       bool
+	}{
+		"current_cost": {
+			givenCost: "5",
+			givenHash: hashWithCost(5),
+		},
+		"lower_cost": {
+			givenCost: "5",
+			givenHash: hashWithCost(MinCost),
+			exp:       true,
+		},
+		"higher_cost": {
+			givenCost: "4",
+			givenHash: hashWithCost(5),
+			exp:       true,
+		},
+		"invalid_hash": {
+			givenCost: "5",
+			givenHash: "not a hash",
+		},
+		"empty_hash": {
+			// The users of an identity provider have no password
+			givenCost: "5",
+			givenHash: "",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("BCRYPT_COST", tc.givenCost)
+
+			// WHEN
+			result := NeedsRehash(tc.givenHash)
+
+			// THEN
+			require.Equal(t, tc.exp, result)
+		})
+	}
+}
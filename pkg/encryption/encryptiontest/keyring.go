@@ -0,0 +1,29 @@
+// Package encryptiontest provides a key ring with fixed keys to test the encrypted columns, the encrypted test data is written with these keys
+package encryptiontest
+
+import (
+	"encoding/base64"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/encryption"
+)
+
+// The base64 encoded keys of the key ring like ENCRYPTION_KEY and BLIND_INDEX_KEY, they are only used by the tests
+const (
+	Key           = "JYPk9YYp5HlLF2Ok785xW9Sn8iKPruxJXD1uqwohPNQ="
+	BlindIndexKey = "SVyVAfOzJRmCEYdrjWjzdrnF9lvoDyx4Vsvm93XmJA8="
+)
+
+// KeyRing returns the key ring of Key with the id encryption.EnvKeyID and BlindIndexKey
+func KeyRing() *encryption.KeyRing {
+	secret, _ := base64.StdEncoding.DecodeString(Key)
+	indexKey, _ := base64.StdEncoding.DecodeString(BlindIndexKey)
+	key, err := encryption.NewKey(encryption.EnvKeyID, secret)
+	if err != nil {
+		panic(err)
+	}
+	keys, err := encryption.NewKeyRing(encryption.EnvKeyID, indexKey, key)
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
@@ -0,0 +1,243 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// KeySize is the size of the key encryption keys, the data keys and the blind index key, in bytes
+	KeySize = 32
+
+	// prefix marks an encrypted value, values without it are plaintext written before the encryption was enabled
+	prefix = "enc:v1:"
+
+	// BlindIndexKeyFile is the file of the blind index key in the key directory
+	BlindIndexKeyFile = "blind_index.secret"
+
+	// EnvKeyID is the id of the key given by ENCRYPTION_KEY
+	EnvKeyID = "default"
+)
+
+// Key is a key encryption key which wraps the data keys of the values, identified by its id
+type Key struct {
+	ID     string
+	secret []byte
+}
+
+// NewKey returns a key encryption key with the given 32 bytes secret
+func NewKey(id string, secret []byte) (Key, error) {
+	if id == "" || strings.Contains(id, ":") {
+		return Key{}, fmt.Errorf("invalid key id %q", id)
+	}
+	if len(secret) != KeySize {
+		return Key{}, fmt.Errorf("key %q must be %d bytes", id, KeySize)
+	}
+	return Key{ID: id, secret: secret}, nil
+}
+
+// KeyRing holds the active key which encrypts new values and the retired keys which still decrypt values encrypted before the rotation.
+// The blind index key never rotates, the indexes of all values are computed with it.
+type KeyRing struct {
+	activeID string
+	keys     map[string]Key
+	indexKey []byte
+}
+
+// NewKeyRing returns a key ring which encrypts values with the key "activeID"
+func NewKeyRing(activeID string, indexKey []byte, keys ...Key) (*KeyRing, error) {
+	if len(indexKey) != KeySize {
+		return nil, fmt.Errorf("blind index key must be %d bytes", KeySize)
+	}
+
+	kr := &KeyRing{activeID: activeID, keys: map[string]Key{}, indexKey: indexKey}
+	for _, key := range keys {
+		if _, ok := kr.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicated key id %q", key.ID)
+		}
+		kr.keys[key.ID] = key
+	}
+	if _, ok := kr.keys[activeID]; !ok {
+		return nil, fmt.Errorf("active key %q is not found", activeID)
+	}
+	return kr, nil
+}
+
+// LoadKeyRing loads the keys from the files of the directory, the id of a key is its file name without the ".key" extension.
+// A file contains a base64 encoded 32 bytes key, e.g. generated by "openssl rand -base64 32", the blind index key is the file BlindIndexKeyFile.
+func LoadKeyRing(dir, activeID string) (*KeyRing, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.key"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Key, 0, len(files))
+	for _, file := range files {
+		secret, err := loadSecret(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load key %s: %v", file, err)
+		}
+		key, err := NewKey(strings.TrimSuffix(filepath.Base(file), ".key"), secret)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	indexKey, err := loadSecret(filepath.Join(dir, BlindIndexKeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("cannot load blind index key: %v", err)
+	}
+	return NewKeyRing(activeID, indexKey, keys...)
+}
+
+// loadSecret reads a base64 encoded secret from the file
+func loadSecret(file string) ([]byte, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+}
+
+// KeyRingFromEnv returns the key ring configured by ENCRYPTION_KEYS_DIR and ENCRYPTION_ACTIVE_KEY_ID, it is loaded once at startup and shared by the repositories.
+// The base64 encoded ENCRYPTION_KEY and BLIND_INDEX_KEY are used if ENCRYPTION_KEYS_DIR is not set, the key has the id EnvKeyID.
+func KeyRingFromEnv() (*KeyRing, error) {
+	dir := os.Getenv("ENCRYPTION_KEYS_DIR")
+	if dir != "" {
+		return LoadKeyRing(dir, os.Getenv("ENCRYPTION_ACTIVE_KEY_ID"))
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(os.Getenv("ENCRYPTION_KEY"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ENCRYPTION_KEY: %v", err)
+	}
+	indexKey, err := base64.StdEncoding.DecodeString(os.Getenv("BLIND_INDEX_KEY"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BLIND_INDEX_KEY: %v", err)
+	}
+	key, err := NewKey(EnvKeyID, secret)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyRing(EnvKeyID, indexKey, key)
+}
+
+// Encrypt encrypts the value with a new data key, the data key is wrapped by the active key and stored with the value.
+// The format is "enc:v1:<key id>:<wrapped data key>:<encrypted value>", empty values are not encrypted.
+func (kr *KeyRing) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	wrappedKey, err := seal(kr.keys[kr.activeID].secret, dataKey)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + kr.activeID + ":" + base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" + base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt returns the plaintext of the encrypted value, plaintext values are returned as they are
+func (kr *KeyRing) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	key, ok := kr.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("unknown key id %q", parts[0])
+	}
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed data key: %v", err)
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed ciphertext: %v", err)
+	}
+
+	dataKey, err := open(key.secret, wrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("cannot unwrap data key: %v", err)
+	}
+	plaintext, err := open(dataKey, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("cannot decrypt value: %v", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencrypt returns true if the value is plaintext or was encrypted by another key than the active key
+func (kr *KeyRing) NeedsReencrypt(value string) bool {
+	if value == "" {
+		return false
+	}
+	return !strings.HasPrefix(value, prefix+kr.activeID+":")
+}
+
+// BlindIndex returns the HMAC-SHA256 of the value in hex, it finds exact matches of the value without decrypting the stored values
+func (kr *KeyRing) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, kr.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted returns true if the value was encrypted by a key ring
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// seal encrypts the data with AES-256-GCM, the random nonce is prepended to the result
+func seal(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+// open decrypts the data sealed by seal
+func open(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("data is too short")
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
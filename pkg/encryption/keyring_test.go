@@ -0,0 +1,417 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testSecret returns a 32 bytes secret filled with b
+func testSecret(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+// testKeyRing returns a key ring with the keys "old" and "new" which encrypts with activeID
+func testKeyRing(t *testing.T, activeID string) *KeyRing {
+	oldKey, err := NewKey("old", testSecret(1))
+	require.NoError(t, err)
+	newKey, err := NewKey("new", testSecret(2))
+	require.NoError(t, err)
+	kr, err := NewKeyRing(activeID, testSecret(3), oldKey, newKey)
+	require.NoError(t, err)
+	return kr
+}
+
+func TestNewKey(t *testing.T) {
+	tcs := map[string]struct {
+		givenID     string
+		givenSecret []byte
+		expErr      string
+	}{
+		"success": {
+			givenID:     "2022-07",
+			givenSecret: testSecret(1),
+		},
+		"empty_id": {
+			givenSecret: testSecret(1),
+			expErr:      `invalid key id ""`,
+		},
+		"id_with_separator": {
+			givenID:     "2022:07",
+			givenSecret: testSecret(1),
+			expErr:      `invalid key id "2022:07"`,
+		},
+		"short_secret": {
+			givenID:     "2022-07",
+			givenSecret: []byte("short"),
+			expErr:      `key "2022-07" must be 32 bytes`,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result, err := NewKey(tc.givenID, tc.givenSecret)
+
+			// THEN
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.givenID, result.ID)
+		})
+	}
+}
+
+func TestNewKeyRing(t *testing.T) {
+	key, err := NewKey("old", testSecret(1))
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		givenActiveID string
+		givenIndexKey []byte
+		givenKeys     []Key
+		expErr        string
+	}{
+		"success": {
+			givenActiveID: "old",
+			givenIndexKey: testSecret(3),
+			givenKeys:     []Key{key},
+		},
+		"short_index_key": {
+			givenActiveID: "old",
+			givenIndexKey: []byte("short"),
+			givenKeys:     []Key{key},
+			expErr:        "blind index key must be 32 bytes",
+		},
+		"duplicated_key": {
+			givenActiveID: "old",
+			givenIndexKey: testSecret(3),
+			givenKeys:     []Key{key, key},
+			expErr:        `duplicated key id "old"`,
+		},
+		"active_key_not_found": {
+			givenActiveID: "new",
+			givenIndexKey: testSecret(3),
+			givenKeys:     []Key{key},
+			expErr:        `active key "new" is not found`,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result, err := NewKeyRing(tc.givenActiveID, tc.givenIndexKey, tc.givenKeys...)
+
+			// THEN
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, result)
+		})
+	}
+}
+
+func TestKeyRing_EncryptDecrypt(t *testing.T) {
+	tcs := map[string]struct {
+		given string
+	}{
+		"email": {
+			given: "test@example.com",
+		},
+		"unicode": {
+			given: "Nguyễn Văn A",
+		},
+		"empty": {
+			given: "",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			kr := testKeyRing(t, "new")
+
+			// WHEN
+			encrypted, err := kr.Encrypt(tc.given)
+			require.NoError(t, err)
+			result, err := kr.Decrypt(encrypted)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.given, result)
+			if tc.given == "" {
+				require.Empty(t, encrypted)
+				return
+			}
+			require.True(t, IsEncrypted(encrypted))
+			require.True(t, strings.HasPrefix(encrypted, "enc:v1:new:"))
+			require.NotContains(t, encrypted, tc.given)
+
+			// Each value has its own data key and nonce
+			again, err := kr.Encrypt(tc.given)
+			require.NoError(t, err)
+			require.NotEqual(t, encrypted, again)
+		})
+	}
+}
+
+func TestKeyRing_Decrypt(t *testing.T) {
+	kr := testKeyRing(t, "new")
+	encrypted, err := kr.Encrypt("test@example.com")
+	require.NoError(t, err)
+	parts := strings.Split(encrypted, ":")
+	otherKey, err := NewKey("new", testSecret(9))
+	require.NoError(t, err)
+	other, err := NewKeyRing("new", testSecret(3), otherKey)
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		givenKeyRing *KeyRing
+		given        string
+		exp          string
+		expErr       string
+	}{
+		"success": {
+			givenKeyRing: kr,
+			given:        encrypted,
+			exp:          "test@example.com",
+		},
+		"plaintext": {
+			givenKeyRing: kr,
+			given:        "test@example.com",
+			exp:          "test@example.com",
+		},
+		"unknown_key": {
+			givenKeyRing: kr,
+			given:        strings.Join([]string{parts[0], parts[1], "retired", parts[3], parts[4]}, ":"),
+			expErr:       `unknown key id "retired"`,
+		},
+		"malformed": {
+			givenKeyRing: kr,
+			given:        "enc:v1:new:abc",
+			expErr:       "malformed encrypted value",
+		},
+		"malformed_data_key": {
+			givenKeyRing: kr,
+			given:        strings.Join([]string{parts[0], parts[1], parts[2], "!!!", parts[4]}, ":"),
+			expErr:       "malformed data key: illegal base64 data at input byte 0",
+		},
+		"tampered_ciphertext": {
+			givenKeyRing: kr,
+			given:        strings.Join([]string{parts[0], parts[1], parts[2], parts[3], base64.RawStdEncoding.EncodeToString([]byte("tampered ciphertext of the value"))}, ":"),
+			expErr:       "cannot decrypt value: cipher: message authentication failed",
+		},
+		"other_secret": {
+			givenKeyRing: other,
+			given:        encrypted,
+			expErr:       "cannot unwrap data key: cipher: message authentication failed",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result, err := tc.givenKeyRing.Decrypt(tc.given)
+
+			// THEN
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, result)
+		})
+	}
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	// GIVEN
+	before := testKeyRing(t, "old")
+	after := testKeyRing(t, "new")
+	encrypted, err := before.Encrypt("test@example.com")
+	require.NoError(t, err)
+
+	// WHEN
+	result, err := after.Decrypt(encrypted)
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, "test@example.com", result)
+	require.True(t, after.NeedsReencrypt(encrypted))
+	reencrypted, err := after.Encrypt(result)
+	require.NoError(t, err)
+	require.False(t, after.NeedsReencrypt(reencrypted))
+}
+
+func TestKeyRing_NeedsReencrypt(t *testing.T) {
+	kr := testKeyRing(t, "new")
+	active, err := kr.Encrypt("test@example.com")
+	require.NoError(t, err)
+	retired, err := testKeyRing(t, "old").Encrypt("test@example.com")
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		given string
+		exp   bool
+	}{
+		"active_key": {
+			given: active,
+			exp:   false,
+		},
+		"retired_key": {
+			given: retired,
+			exp:   true,
+		},
+		"plaintext": {
+			given: "test@example.com",
+			exp:   true,
+		},
+		"empty": {
+			given: "",
+			exp:   false,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			require.Equal(t, tc.exp, kr.NeedsReencrypt(tc.given))
+		})
+	}
+}
+
+func TestKeyRing_BlindIndex(t *testing.T) {
+	// GIVEN
+	kr := testKeyRing(t, "new")
+	otherKey, err := NewKey("new", testSecret(2))
+	require.NoError(t, err)
+	other, err := NewKeyRing("new", testSecret(4), otherKey)
+	require.NoError(t, err)
+
+	// WHEN
+	result := kr.BlindIndex("test@example.com")
+
+	// THEN
+	require.Len(t, result, 64)
+	require.Equal(t, result, kr.BlindIndex("test@example.com"))
+	require.Equal(t, result, testKeyRing(t, "old").BlindIndex("test@example.com"))
+	require.NotEqual(t, result, kr.BlindIndex("test2@example.com"))
+	require.NotEqual(t, result, other.BlindIndex("test@example.com"))
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	writeSecret := func(t *testing.T, dir, name string, secret []byte) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(base64.StdEncoding.EncodeToString(secret)+"\n"), 0600))
+	}
+
+	tcs := map[string]struct {
+		givenFiles    map[string][]byte
+		givenActiveID string
+		expErr        bool
+	}{
+		"success": {
+			givenFiles:    map[string][]byte{"old.key": testSecret(1), "new.key": testSecret(2), BlindIndexKeyFile: testSecret(3)},
+			givenActiveID: "new",
+		},
+		"active_key_not_found": {
+			givenFiles:    map[string][]byte{"old.key": testSecret(1), BlindIndexKeyFile: testSecret(3)},
+			givenActiveID: "new",
+			expErr:        true,
+		},
+		"blind_index_key_not_found": {
+			givenFiles:    map[string][]byte{"new.key": testSecret(2)},
+			givenActiveID: "new",
+			expErr:        true,
+		},
+		"short_key": {
+			givenFiles:    map[string][]byte{"new.key": []byte("short"), BlindIndexKeyFile: testSecret(3)},
+			givenActiveID: "new",
+			expErr:        true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			dir := t.TempDir()
+			for name, secret := range tc.givenFiles {
+				writeSecret(t, dir, name, secret)
+			}
+
+			// WHEN
+			result, err := LoadKeyRing(dir, tc.givenActiveID)
+
+			// THEN
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// The loaded keys decrypt the values of the same keys
+			encrypted, err := testKeyRing(t, "old").Encrypt("test@example.com")
+			require.NoError(t, err)
+			decrypted, err := result.Decrypt(encrypted)
+			require.NoError(t, err)
+			require.Equal(t, "test@example.com", decrypted)
+			require.Equal(t, testKeyRing(t, "old").BlindIndex("test@example.com"), result.BlindIndex("test@example.com"))
+		})
+	}
+}
+
+func TestKeyRingFromEnv(t *testing.T) {
+	tcs := map[string]struct {
+		givenKey      string
+		givenIndexKey string
+		expErr        string
+	}{
+		"success": {
+			givenKey:      base64.StdEncoding.EncodeToString(testSecret(1)),
+			givenIndexKey: base64.StdEncoding.EncodeToString(testSecret(3)),
+		},
+		"invalid_key": {
+			givenKey:      "not base64!",
+			givenIndexKey: base64.StdEncoding.EncodeToString(testSecret(3)),
+			expErr:        "invalid ENCRYPTION_KEY: illegal base64 data at input byte 3",
+		},
+		"missing_key": {
+			givenIndexKey: base64.StdEncoding.EncodeToString(testSecret(3)),
+			expErr:        `key "default" must be 32 bytes`,
+		},
+		"invalid_index_key": {
+			givenKey:      base64.StdEncoding.EncodeToString(testSecret(1)),
+			givenIndexKey: "not base64!",
+			expErr:        "invalid BLIND_INDEX_KEY: illegal base64 data at input byte 3",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("ENCRYPTION_KEYS_DIR", "")
+			t.Setenv("ENCRYPTION_KEY", tc.givenKey)
+			t.Setenv("BLIND_INDEX_KEY", tc.givenIndexKey)
+
+			// WHEN
+			result, err := KeyRingFromEnv()
+
+			// THEN
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			encrypted, err := result.Encrypt("test@example.com")
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(encrypted, "enc:v1:"+EnvKeyID+":"))
+		})
+	}
+}
@@ -4,38 +4,115 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/go-chi/jwtauth/v5"
+	"github.com/gofrs/uuid"
 	"github.com/lestrrat-go/jwx/jwt"
 )
 
 // JWTInput represents a JWT input to generates a JWT token
 type JWTInput struct {
-	ID        int
-	Email     string
-	Role      string
+	ID    int
+	Email string
+	Role  string
+	// OrganizationID is the organization of the user, the requests of the token are limited to it
+	OrganizationID int
+	// ActorID is the admin who impersonates the user, it is zero for tokens which are not impersonation tokens
+	ActorID   int
 	SecretKey string
 	ExpiresIn time.Duration
+	// Purpose marks a token which is not an access token, e.g. email verification
+	Purpose string
 }
 
-// GenerateJWTToken generates a token with the given data and secret key
+// GenerateJWTToken generates a HS256 token with the given data and secret key
 func GenerateJWTToken(input JWTInput) (jwt.Token, string, error) {
-	if input.SecretKey == "" {
-		return nil, "", fmt.Errorf("secret key cannot be empty")
+	ks, err := NewHMACKeySet(input.SecretKey)
+	if err != nil {
+		return nil, "", err
 	}
-	if input.ID < 0 {
-		return nil, "", fmt.Errorf("invalid id")
-	}
-	if input.ID < 0 {
-		return nil, "", fmt.Errorf("invalid email")
+	return ks.Sign(input)
+}
+
+// newToken returns an unsigned token with the claims of the input
+func newToken(input JWTInput) (jwt.Token, error) {
+	jti, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate token id: %v", err)
 	}
 
+	now := time.Now().UTC()
 	claim := map[string]interface{}{
-		"id":    input.ID,
-		"email": input.Email,
-		"role":  input.Role,
+		jwt.JwtIDKey:      jti.String(),
+		jwt.IssuedAtKey:   now,
+		jwt.ExpirationKey: now.Add(input.ExpiresIn),
+		"id":              input.ID,
+		"email":           input.Email,
+		"role":            input.Role,
+	}
+	if input.OrganizationID > 0 {
+		claim["org"] = input.OrganizationID
+	}
+	if input.ActorID > 0 {
+		claim["actor_id"] = input.ActorID
+	}
+	if input.Purpose != "" {
+		claim["purpose"] = input.Purpose
+	}
+
+	token := jwt.New()
+	for k, v := range claim {
+		if err := token.Set(k, v); err != nil {
+			return nil, err
+		}
+	}
+	return token, nil
+}
+
+// JWTClaims represents the claims carried by a JWT token
+type JWTClaims struct {
+	TokenID   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	ID        int
+	Email     string
+	Role      string
+	// OrganizationID is zero for tokens without an organization
+	OrganizationID int
+	// ActorID is zero for tokens which are not impersonation tokens
+	ActorID int
+	Purpose string
+}
+
+// ParseJWTToken verifies the signature and expiry of the HS256 token with the given secret key and returns its claims
+func ParseJWTToken(tokenString, secretKey string) (JWTClaims, error) {
+	ks, err := NewHMACKeySet(secretKey)
+	if err != nil {
+		return JWTClaims{}, err
+	}
+	return ks.Parse(tokenString)
+}
+
+// toClaims returns the claims of a verified token
+func toClaims(token jwt.Token) (JWTClaims, error) {
+	claims := token.PrivateClaims()
+	id, ok := claims["id"].(float64) // JSON numbers are decoded as float64
+	if !ok {
+		return JWTClaims{}, fmt.Errorf("invalid id")
 	}
-	jwtauth.SetExpiryIn(claim, input.ExpiresIn)
-	tokenAuth := jwtauth.New("HS256", []byte(input.SecretKey), nil)
+	email, _ := claims["email"].(string)
+	role, _ := claims["role"].(string)
+	purpose, _ := claims["purpose"].(string)
+	organizationID, _ := claims["org"].(float64)
+	actorID, _ := claims["actor_id"].(float64)
 
-	return tokenAuth.Encode(claim)
+	return JWTClaims{
+		TokenID:        token.JwtID(),
+		IssuedAt:       token.IssuedAt(),
+		ExpiresAt:      token.Expiration(),
+		ID:             int(id),
+		Email:          email,
+		Role:           role,
+		OrganizationID: int(organizationID),
+		ActorID:        int(actorID),
+		Purpose:        purpose,
+	}, nil
 }
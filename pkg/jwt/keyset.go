@@ -0,0 +1,275 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+// ExpiresAtHeader is the optional PEM header which sets when a retired key stops verifying tokens, in RFC 3339 format
+const ExpiresAtHeader = "Expires-At"
+
+// Key is a key to sign or verify tokens, identified by its kid
+type Key struct {
+	ID        string
+	Algorithm jwa.SignatureAlgorithm
+	// ExpiresAt is the time the key stops verifying tokens, zero means it never expires
+	ExpiresAt time.Time
+
+	signKey   interface{} // nil if only the public key is known
+	verifyKey interface{}
+}
+
+// NewKey returns a key for the given RSA or Ed25519 private or public key, or for a HMAC secret
+func NewKey(id string, rawKey interface{}, expiresAt time.Time) (Key, error) {
+	key := Key{ID: id, ExpiresAt: expiresAt}
+	switch k := rawKey.(type) {
+	case *rsa.PrivateKey:
+		key.Algorithm, key.signKey, key.verifyKey = jwa.RS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Algorithm, key.verifyKey = jwa.RS256, k
+	case ed25519.PrivateKey:
+		key.Algorithm, key.signKey, key.verifyKey = jwa.EdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Algorithm, key.verifyKey = jwa.EdDSA, k
+	case []byte:
+		if len(k) == 0 {
+			return Key{}, fmt.Errorf("secret key cannot be empty")
+		}
+		key.Algorithm, key.signKey, key.verifyKey = jwa.HS256, k, k
+	default:
+		return Key{}, fmt.Errorf("unsupported key type %T", rawKey)
+	}
+	return key, nil
+}
+
+// isExpired returns true if the key cannot verify tokens anymore
+func (k Key) isExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// KeySet holds the active key which signs new tokens and the retired keys which still verify tokens signed before the rotation
+type KeySet struct {
+	activeID string
+	keys     map[string]Key
+}
+
+// NewKeySet returns a key set which signs tokens with the key "activeID"
+func NewKeySet(activeID string, keys ...Key) (*KeySet, error) {
+	ks := &KeySet{activeID: activeID, keys: map[string]Key{}}
+	for _, key := range keys {
+		if _, ok := ks.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicated key id %q", key.ID)
+		}
+		ks.keys[key.ID] = key
+	}
+
+	active, ok := ks.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q is not found", activeID)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("active key %q must be a private key", activeID)
+	}
+	if active.isExpired(time.Now()) {
+		return nil, fmt.Errorf("active key %q is expired", activeID)
+	}
+	return ks, nil
+}
+
+// NewHMACKeySet returns a key set which signs and verifies tokens with the HS256 secret key, the tokens have no kid
+func NewHMACKeySet(secretKey string) (*KeySet, error) {
+	key, err := NewKey("", []byte(secretKey), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySet("", key)
+}
+
+// LoadKeySet loads the keys from the PEM files of the directory, the kid of a key is its file name without the ".pem" extension.
+// A file contains a PKCS #8 or PKCS #1 private key, or a PKIX public key for retired keys whose private key was removed.
+func LoadKeySet(dir, activeID string) (*KeySet, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Key, 0, len(files))
+	for _, file := range files {
+		key, err := loadPEMKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load key %s: %v", file, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewKeySet(activeID, keys...)
+}
+
+// loadPEMKey loads the key of a PEM file
+func loadPEMKey(file string) (Key, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Key{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, fmt.Errorf("no PEM block is found")
+	}
+
+	var rawKey interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		rawKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		rawKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		rawKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	var expiresAt time.Time
+	if v, ok := block.Headers[ExpiresAtHeader]; ok {
+		if expiresAt, err = time.Parse(time.RFC3339, v); err != nil {
+			return Key{}, fmt.Errorf("invalid %s header: %v", ExpiresAtHeader, err)
+		}
+	}
+
+	return NewKey(strings.TrimSuffix(filepath.Base(file), ".pem"), rawKey, expiresAt)
+}
+
+var (
+	keySetsMu sync.Mutex
+	keySets   = map[string]*KeySet{}
+)
+
+// KeySetFromEnv returns the key set configured by JWT_KEYS_DIR and JWT_ACTIVE_KEY_ID, the files of a directory are only loaded once.
+// The HS256 ACCESS_TOKEN_KEY is used if JWT_KEYS_DIR is not set.
+func KeySetFromEnv() (*KeySet, error) {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		return NewHMACKeySet(os.Getenv("ACCESS_TOKEN_KEY"))
+	}
+	activeID := os.Getenv("JWT_ACTIVE_KEY_ID")
+
+	keySetsMu.Lock()
+	defer keySetsMu.Unlock()
+	cacheKey := dir + "#" + activeID
+	if ks, ok := keySets[cacheKey]; ok {
+		return ks, nil
+	}
+	ks, err := LoadKeySet(dir, activeID)
+	if err != nil {
+		return nil, err
+	}
+	keySets[cacheKey] = ks
+	return ks, nil
+}
+
+// Sign generates a token with the given data signed by the active key
+func (ks *KeySet) Sign(input JWTInput) (jwt.Token, string, error) {
+	if input.ID < 0 {
+		return nil, "", fmt.Errorf("invalid id")
+	}
+
+	token, err := newToken(input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	active := ks.keys[ks.activeID]
+	var signKey interface{} = active.signKey
+	if active.ID != "" { // the kid is carried by the jwk.Key into the token header
+		jwkKey, err := jwk.New(active.signKey)
+		if err != nil {
+			return nil, "", err
+		}
+		if err = jwkKey.Set(jwk.KeyIDKey, active.ID); err != nil {
+			return nil, "", err
+		}
+		signKey = jwkKey
+	}
+
+	signed, err := jwt.Sign(token, active.Algorithm, signKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return token, string(signed), nil
+}
+
+// Parse verifies the signature with the key of the token kid and the expiry of the token, then returns its claims.
+// Tokens signed by retired keys are accepted until the keys expire.
+func (ks *KeySet) Parse(tokenString string) (JWTClaims, error) {
+	msg, err := jws.ParseString(tokenString)
+	if err != nil {
+		return JWTClaims{}, err
+	}
+	if len(msg.Signatures()) != 1 {
+		return JWTClaims{}, fmt.Errorf("token must have one signature")
+	}
+
+	kid := msg.Signatures()[0].ProtectedHeaders().KeyID()
+	key, ok := ks.keys[kid]
+	if !ok {
+		return JWTClaims{}, fmt.Errorf("unknown key id %q", kid)
+	}
+	if key.isExpired(time.Now()) {
+		return JWTClaims{}, fmt.Errorf("key %q is expired", kid)
+	}
+
+	token, err := jwt.ParseString(tokenString, jwt.WithVerify(key.Algorithm, key.verifyKey), jwt.WithValidate(true))
+	if err != nil {
+		return JWTClaims{}, err
+	}
+	return toClaims(token)
+}
+
+// PublicKeys returns the JSON Web Key Set of the asymmetric keys which are not expired, HMAC keys are never published
+func (ks *KeySet) PublicKeys() (jwk.Set, error) {
+	ids := make([]string, 0, len(ks.keys))
+	for id := range ks.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := jwk.NewSet()
+	now := time.Now()
+	for _, id := range ids {
+		key := ks.keys[id]
+		if key.Algorithm == jwa.HS256 || key.isExpired(now) {
+			continue
+		}
+
+		jwkKey, err := jwk.New(key.verifyKey)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range map[string]interface{}{
+			jwk.KeyIDKey:     key.ID,
+			jwk.AlgorithmKey: key.Algorithm,
+			jwk.KeyUsageKey:  jwk.ForSignature,
+		} {
+			if err = jwkKey.Set(k, v); err != nil {
+				return nil, err
+			}
+		}
+		set.Add(jwkKey)
+	}
+	return set, nil
+}
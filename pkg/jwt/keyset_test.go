@@ -0,0 +1,422 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/stretchr/testify/require"
+)
+
+// testInput is the input of the tokens signed by the tests
+var testInput = JWTInput{
+	ID:             10,
+	Email:          "test@example.com",
+	Role:           "GUEST",
+	OrganizationID: 100,
+	ActorID:        1,
+	ExpiresIn:      time.Minute,
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newKey(t *testing.T, id string, rawKey interface{}, expiresAt time.Time) Key {
+	key, err := NewKey(id, rawKey, expiresAt)
+	require.NoError(t, err)
+	return key
+}
+
+func newKeySet(t *testing.T, activeID string, keys ...Key) *KeySet {
+	ks, err := NewKeySet(activeID, keys...)
+	require.NoError(t, err)
+	return ks
+}
+
+func TestNewKey(t *testing.T) {
+	rsaKey := newRSAKey(t)
+	edKey := newEd25519Key(t)
+
+	tcs := map[string]struct {
+		givenKey     interface{}
+		expAlgorithm jwa.SignatureAlgorithm
+		expCanSign   bool
+		expErr       string
+	}{
+		"rsa_private_key": {
+			givenKey:     rsaKey,
+			expAlgorithm: jwa.RS256,
+			expCanSign:   true,
+		},
+		"rsa_public_key": {
+			givenKey:     &rsaKey.PublicKey,
+			expAlgorithm: jwa.RS256,
+		},
+		"ed25519_private_key": {
+			givenKey:     edKey,
+			expAlgorithm: jwa.EdDSA,
+			expCanSign:   true,
+		},
+		"ed25519_public_key": {
+			givenKey:     edKey.Public(),
+			expAlgorithm: jwa.EdDSA,
+		},
+		"secret": {
+			givenKey:     []byte("secret"),
+			expAlgorithm: jwa.HS256,
+			expCanSign:   true,
+		},
+		"empty_secret": {
+			givenKey: []byte{},
+			expErr:   "secret key cannot be empty",
+		},
+		"unsupported": {
+			givenKey: "secret",
+			expErr:   "unsupported key type string",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result, err := NewKey("2022-07", tc.givenKey, time.Time{})
+
+			// THEN
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "2022-07", result.ID)
+			require.Equal(t, tc.expAlgorithm, result.Algorithm)
+			require.Equal(t, tc.expCanSign, result.signKey != nil)
+		})
+	}
+}
+
+func TestNewKeySet(t *testing.T) {
+	edKey := newEd25519Key(t)
+	private := newKey(t, "new", edKey, time.Time{})
+	public := newKey(t, "old", edKey.Public(), time.Time{})
+	expired := newKey(t, "expired", newEd25519Key(t), time.Now().Add(-time.Minute))
+
+	tcs := map[string]struct {
+		givenActiveID string
+		givenKeys     []Key
+		expErr        string
+	}{
+		"success": {
+			givenActiveID: "new",
+			givenKeys:     []Key{private, public},
+		},
+		"duplicated_key": {
+			givenActiveID: "new",
+			givenKeys:     []Key{private, private},
+			expErr:        `duplicated key id "new"`,
+		},
+		"active_key_not_found": {
+			givenActiveID: "other",
+			givenKeys:     []Key{private},
+			expErr:        `active key "other" is not found`,
+		},
+		"active_key_is_public": {
+			givenActiveID: "old",
+			givenKeys:     []Key{private, public},
+			expErr:        `active key "old" must be a private key`,
+		},
+		"active_key_is_expired": {
+			givenActiveID: "expired",
+			givenKeys:     []Key{private, expired},
+			expErr:        `active key "expired" is expired`,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result, err := NewKeySet(tc.givenActiveID, tc.givenKeys...)
+
+			// THEN
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, result)
+		})
+	}
+}
+
+func TestKeySet_SignParse(t *testing.T) {
+	tcs := map[string]struct {
+		givenKey     interface{}
+		expAlgorithm jwa.SignatureAlgorithm
+	}{
+		"rs256": {
+			givenKey:     newRSAKey(t),
+			expAlgorithm: jwa.RS256,
+		},
+		"eddsa": {
+			givenKey:     newEd25519Key(t),
+			expAlgorithm: jwa.EdDSA,
+		},
+		"hs256": {
+			givenKey:     []byte("secret"),
+			expAlgorithm: jwa.HS256,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			ks := newKeySet(t, "2022-07", newKey(t, "2022-07", tc.givenKey, time.Time{}))
+
+			// WHEN
+			token, signed, err := ks.Sign(testInput)
+			require.NoError(t, err)
+			result, err := ks.Parse(signed)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, token.JwtID(), result.TokenID)
+			require.Equal(t, testInput.ID, result.ID)
+			require.Equal(t, testInput.Email, result.Email)
+			require.Equal(t, testInput.Role, result.Role)
+			require.Equal(t, testInput.OrganizationID, result.OrganizationID)
+			require.Equal(t, testInput.ActorID, result.ActorID)
+			require.WithinDuration(t, time.Now().Add(testInput.ExpiresIn), result.ExpiresAt, 5*time.Second)
+
+			// The header carries the kid which selects the key
+			msg, err := jws.ParseString(signed)
+			require.NoError(t, err)
+			headers := msg.Signatures()[0].ProtectedHeaders()
+			require.Equal(t, "2022-07", headers.KeyID())
+			require.Equal(t, tc.expAlgorithm, headers.Algorithm())
+		})
+	}
+}
+
+func TestKeySet_Parse(t *testing.T) {
+	oldKey := newEd25519Key(t)
+	newEdKey := newEd25519Key(t)
+	before := newKeySet(t, "old", newKey(t, "old", oldKey, time.Time{}))
+	after := newKeySet(t, "new",
+		newKey(t, "new", newEdKey, time.Time{}),
+		newKey(t, "old", oldKey.Public(), time.Now().Add(time.Hour)),
+	)
+	retired := newKeySet(t, "new",
+		newKey(t, "new", newEdKey, time.Time{}),
+		newKey(t, "old", oldKey.Public(), time.Now().Add(-time.Minute)),
+	)
+	impostor := newKeySet(t, "old", newKey(t, "old", newEd25519Key(t), time.Time{}))
+
+	sign := func(ks *KeySet, input JWTInput) string {
+		_, signed, err := ks.Sign(input)
+		require.NoError(t, err)
+		return signed
+	}
+	expiredInput := testInput
+	expiredInput.ExpiresIn = -time.Minute
+	signedByNew := sign(after, testInput)
+	parts := strings.Split(signedByNew, ".")
+	tampered := parts[0] + "." + parts[1] + "." + strings.Repeat("A", len(parts[2]))
+
+	tcs := map[string]struct {
+		givenKeySet *KeySet
+		givenToken  string
+		expErr      string
+	}{
+		"active_key": {
+			givenKeySet: after,
+			givenToken:  signedByNew,
+		},
+		"retired_key_before_expiry": {
+			givenKeySet: after,
+			givenToken:  sign(before, testInput),
+		},
+		"retired_key_after_expiry": {
+			givenKeySet: retired,
+			givenToken:  sign(before, testInput),
+			expErr:      `key "old" is expired`,
+		},
+		"unknown_key": {
+			givenKeySet: before,
+			givenToken:  signedByNew,
+			expErr:      `unknown key id "new"`,
+		},
+		"other_key_with_same_kid": {
+			givenKeySet: after,
+			givenToken:  sign(impostor, testInput),
+			expErr:      "failed to verify",
+		},
+		"tampered_signature": {
+			givenKeySet: after,
+			givenToken:  tampered,
+			expErr:      "failed to verify",
+		},
+		"expired_token": {
+			givenKeySet: after,
+			givenToken:  sign(after, expiredInput),
+			expErr:      "exp not satisfied",
+		},
+		"malformed": {
+			givenKeySet: after,
+			givenToken:  "abcd",
+			expErr:      "invalid",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result, err := tc.givenKeySet.Parse(tc.givenToken)
+
+			// THEN
+			if tc.expErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testInput.ID, result.ID)
+		})
+	}
+}
+
+func TestKeySet_PublicKeys(t *testing.T) {
+	// GIVEN
+	rsaKey := newRSAKey(t)
+	edKey := newEd25519Key(t)
+	ks := newKeySet(t, "b-rsa",
+		newKey(t, "b-rsa", rsaKey, time.Time{}),
+		newKey(t, "a-ed25519", edKey.Public(), time.Now().Add(time.Hour)),
+		newKey(t, "c-expired", newEd25519Key(t).Public(), time.Now().Add(-time.Minute)),
+		newKey(t, "d-hmac", []byte("secret"), time.Time{}),
+	)
+
+	// WHEN
+	result, err := ks.PublicKeys()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Len())
+	expected := []struct {
+		id        string
+		algorithm jwa.SignatureAlgorithm
+	}{
+		{id: "a-ed25519", algorithm: jwa.EdDSA},
+		{id: "b-rsa", algorithm: jwa.RS256},
+	}
+	for i, exp := range expected {
+		key, ok := result.Get(i)
+		require.True(t, ok)
+		require.Equal(t, exp.id, key.KeyID())
+		require.Equal(t, exp.algorithm.String(), key.Algorithm())
+		require.Equal(t, string(jwk.ForSignature), key.KeyUsage())
+
+		// Only the public keys are published
+		var raw interface{}
+		require.NoError(t, key.Raw(&raw))
+		switch raw.(type) {
+		case *rsa.PublicKey, ed25519.PublicKey:
+		default:
+			t.Fatalf("key %s is not a public key: %T", exp.id, raw)
+		}
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	rsaKey := newRSAKey(t)
+	edKey := newEd25519Key(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	pkix, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		givenFiles    map[string]*pem.Block
+		givenActiveID string
+		expErr        bool
+	}{
+		"success": {
+			givenFiles: map[string]*pem.Block{
+				"new.pem": {Type: "PRIVATE KEY", Bytes: pkcs8},
+				"old.pem": {Type: "PUBLIC KEY", Bytes: pkix, Headers: map[string]string{ExpiresAtHeader: time.Now().Add(time.Hour).Format(time.RFC3339)}},
+				"rsa.pem": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+			},
+			givenActiveID: "new",
+		},
+		"invalid_expires_at": {
+			givenFiles: map[string]*pem.Block{
+				"new.pem": {Type: "PRIVATE KEY", Bytes: pkcs8},
+				"old.pem": {Type: "PUBLIC KEY", Bytes: pkix, Headers: map[string]string{ExpiresAtHeader: "tomorrow"}},
+			},
+			givenActiveID: "new",
+			expErr:        true,
+		},
+		"unsupported_block": {
+			givenFiles: map[string]*pem.Block{
+				"new.pem": {Type: "CERTIFICATE", Bytes: pkcs8},
+			},
+			givenActiveID: "new",
+			expErr:        true,
+		},
+		"active_key_not_found": {
+			givenFiles: map[string]*pem.Block{
+				"old.pem": {Type: "PUBLIC KEY", Bytes: pkix},
+			},
+			givenActiveID: "new",
+			expErr:        true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			dir := t.TempDir()
+			for name, block := range tc.givenFiles {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0600))
+			}
+
+			// WHEN
+			result, err := LoadKeySet(dir, tc.givenActiveID)
+
+			// THEN
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.keys, len(tc.givenFiles))
+			require.Equal(t, jwa.EdDSA, result.keys["new"].Algorithm)
+			require.Equal(t, jwa.RS256, result.keys["old"].Algorithm)
+			require.Nil(t, result.keys["old"].signKey)
+			require.False(t, result.keys["old"].ExpiresAt.IsZero())
+			require.True(t, result.keys["rsa"].ExpiresAt.IsZero())
+
+			_, signed, err := result.Sign(testInput)
+			require.NoError(t, err)
+			_, err = result.Parse(signed)
+			require.NoError(t, err)
+		})
+	}
+}
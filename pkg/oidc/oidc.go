@@ -0,0 +1,319 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+// Config is the configuration of the OpenID Connect client
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// DiscoveryURL is the URL of the discovery document, it is ISSUER/.well-known/openid-configuration if empty
+	DiscoveryURL string
+	Scopes       []string
+	// DefaultRole is the role of the users which are created by their first login
+	DefaultRole string
+}
+
+// ConfigFromEnv returns the configuration from OIDC_ISSUER, OIDC_CLIENT_ID, OIDC_CLIENT_SECRET, OIDC_REDIRECT_URL, OIDC_DISCOVERY_URL, OIDC_SCOPES and OIDC_DEFAULT_ROLE
+func ConfigFromEnv() Config {
+	redirectURL := os.Getenv("OIDC_REDIRECT_URL")
+	if redirectURL == "" {
+		redirectURL = os.Getenv("APP_URL") + "/api/v1/users/oidc/callback"
+	}
+	scopes := []string{"openid", "email", "profile"}
+	if v := os.Getenv("OIDC_SCOPES"); v != "" {
+		scopes = strings.Fields(v)
+	}
+
+	return Config{
+		Issuer:       os.Getenv("OIDC_ISSUER"),
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  redirectURL,
+		DiscoveryURL: os.Getenv("OIDC_DISCOVERY_URL"),
+		Scopes:       scopes,
+		DefaultRole:  os.Getenv("OIDC_DEFAULT_ROLE"),
+	}
+}
+
+// Enabled returns true if the issuer and the client are configured
+func (c Config) Enabled() bool {
+	return c.Issuer != "" && c.ClientID != ""
+}
+
+// Discovery is the part of the discovery document which is used by the client
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an OpenID Connect issuer which is discovered by its discovery document
+type Provider struct {
+	config    Config
+	discovery Discovery
+	client    *http.Client
+}
+
+// NewProvider fetches the discovery document of the issuer
+func NewProvider(ctx context.Context, config Config) (*Provider, error) {
+	p := &Provider{config: config, client: &http.Client{Timeout: 10 * time.Second}}
+
+	discoveryURL := config.DiscoveryURL
+	if discoveryURL == "" {
+		discoveryURL = strings.TrimSuffix(config.Issuer, "/") + "/.well-known/openid-configuration"
+	}
+	if err := p.getJSON(ctx, discoveryURL, &p.discovery); err != nil {
+		return nil, fmt.Errorf("cannot get discovery document: %v", err)
+	}
+
+	// The issuer of the document must be the configured one, otherwise ID tokens of another issuer could be accepted
+	if p.discovery.Issuer != config.Issuer {
+		return nil, fmt.Errorf("issuer %q of discovery document does not match %q", p.discovery.Issuer, config.Issuer)
+	}
+	if p.discovery.AuthorizationEndpoint == "" || p.discovery.TokenEndpoint == "" || p.discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document is incomplete")
+	}
+	return p, nil
+}
+
+var (
+	discoveriesMu sync.Mutex
+	discoveries   = map[string]Discovery{}
+)
+
+// ProviderFromEnv returns the provider configured by ConfigFromEnv, the discovery document of an issuer is only fetched once
+func ProviderFromEnv(ctx context.Context) (*Provider, error) {
+	config := ConfigFromEnv()
+	if !config.Enabled() {
+		return nil, fmt.Errorf("OIDC_ISSUER and OIDC_CLIENT_ID are not set")
+	}
+
+	discoveriesMu.Lock()
+	defer discoveriesMu.Unlock()
+	cacheKey := config.Issuer + "#" + config.DiscoveryURL
+	if discovery, ok := discoveries[cacheKey]; ok {
+		return &Provider{config: config, discovery: discovery, client: &http.Client{Timeout: 10 * time.Second}}, nil
+	}
+	p, err := NewProvider(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	discoveries[cacheKey] = p.discovery
+	return p, nil
+}
+
+// Issuer returns the issuer of the provider
+func (p *Provider) Issuer() string {
+	return p.config.Issuer
+}
+
+// DefaultRole returns the role of the users which are created by their first login, it is empty if not configured
+func (p *Provider) DefaultRole() string {
+	return p.config.DefaultRole
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint which starts the login, the code challenge is the S256 challenge of the code verifier
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(p.discovery.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.discovery.AuthorizationEndpoint + sep + query.Encode()
+}
+
+// TokenResponse is the response of the token endpoint
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Exchange exchanges the authorization code and its code verifier for the tokens of the user
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (TokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return TokenResponse{}, fmt.Errorf("token endpoint returned %d: %s %s", resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+
+	var result TokenResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return TokenResponse{}, err
+	}
+	if result.IDToken == "" {
+		return TokenResponse{}, fmt.Errorf("token response has no id_token")
+	}
+	return result, nil
+}
+
+// Claims are the claims of an ID token which identify the user
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// signatureAlgorithms are the accepted algorithms of ID tokens, HMAC and none are never accepted
+var signatureAlgorithms = map[jwa.SignatureAlgorithm]bool{
+	jwa.RS256: true, jwa.RS384: true, jwa.RS512: true,
+	jwa.PS256: true, jwa.PS384: true, jwa.PS512: true,
+	jwa.ES256: true, jwa.ES384: true, jwa.ES512: true,
+	jwa.EdDSA: true,
+}
+
+// VerifyIDToken verifies the signature of the ID token with the keys of the issuer, its issuer, audience, expiry and nonce, then returns its claims.
+// The keys are fetched for every token, so keys rotated by the issuer are always known.
+func (p *Provider) VerifyIDToken(ctx context.Context, idToken, nonce string) (Claims, error) {
+	// 1. Find the key of the token
+	msg, err := jws.ParseString(idToken)
+	if err != nil {
+		return Claims{}, err
+	}
+	if len(msg.Signatures()) != 1 {
+		return Claims{}, fmt.Errorf("token must have one signature")
+	}
+	headers := msg.Signatures()[0].ProtectedHeaders()
+	alg := headers.Algorithm()
+	if !signatureAlgorithms[alg] {
+		return Claims{}, fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	keys, err := jwk.Fetch(ctx, p.discovery.JWKSURI, jwk.WithHTTPClient(p.client))
+	if err != nil {
+		return Claims{}, fmt.Errorf("cannot get keys of issuer: %v", err)
+	}
+	key, ok := keys.LookupKeyID(headers.KeyID())
+	if !ok {
+		if headers.KeyID() != "" || keys.Len() != 1 {
+			return Claims{}, fmt.Errorf("unknown key id %q", headers.KeyID())
+		}
+		key, _ = keys.Get(0) // the only key of the issuer is used for tokens without kid
+	}
+	if key.Algorithm() != "" && key.Algorithm() != alg.String() {
+		return Claims{}, fmt.Errorf("algorithm %q does not match the key", alg)
+	}
+	var rawKey interface{}
+	if err = key.Raw(&rawKey); err != nil {
+		return Claims{}, err
+	}
+
+	// 2. Verify the signature and the claims
+	token, err := jwt.ParseString(idToken,
+		jwt.WithVerify(alg, rawKey),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithClaimValue("nonce", nonce),
+		jwt.WithAcceptableSkew(time.Minute),
+	)
+	if err != nil {
+		return Claims{}, err
+	}
+	if token.Subject() == "" {
+		return Claims{}, fmt.Errorf("token has no subject")
+	}
+
+	claims := Claims{Subject: token.Subject()}
+	if v, ok := token.Get("email"); ok {
+		claims.Email, _ = v.(string)
+	}
+	if v, ok := token.Get("email_verified"); ok {
+		claims.EmailVerified, _ = v.(bool)
+	}
+	if v, ok := token.Get("name"); ok {
+		claims.Name, _ = v.(string)
+	}
+	return claims, nil
+}
+
+// getJSON gets the JSON document of the URL
+func (p *Provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// NewCodeVerifier returns a random PKCE code verifier
+func NewCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallengeS256 returns the S256 PKCE code challenge of the code verifier
+func CodeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
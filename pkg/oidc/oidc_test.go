@@ -0,0 +1,344 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/oidc"
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/oidc/oidctest"
+)
+
+const (
+	testClientID = "client"
+	testNonce    = "nonce"
+)
+
+// testIssuer serves the discovery document and the keys of an issuer whose ID tokens are signed by the tests
+type testIssuer struct {
+	*httptest.Server
+	key       jwk.Key
+	discovery oidc.Discovery
+}
+
+func newTestKey(t *testing.T, kid string) jwk.Key {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := jwk.New(privateKey)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, kid))
+	require.NoError(t, key.Set(jwk.AlgorithmKey, jwa.EdDSA))
+	return key
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	i := &testIssuer{key: newTestKey(t, "test")}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(i.discovery)
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		publicKey, err := jwk.PublicKeyOf(i.key)
+		require.NoError(t, err)
+		set := jwk.NewSet()
+		set.Add(publicKey)
+		json.NewEncoder(w).Encode(set)
+	})
+	i.Server = httptest.NewServer(mux)
+	t.Cleanup(i.Close)
+	i.discovery = oidc.Discovery{
+		Issuer:                i.URL,
+		AuthorizationEndpoint: i.URL + "/authorize",
+		TokenEndpoint:         i.URL + "/token",
+		JWKSURI:               i.URL + "/jwks",
+	}
+	return i
+}
+
+// claims returns the claims of a valid ID token of the issuer
+func (i *testIssuer) claims() map[string]interface{} {
+	now := time.Now()
+	return map[string]interface{}{
+		jwt.IssuerKey:     i.URL,
+		jwt.SubjectKey:    "sub-1",
+		jwt.AudienceKey:   testClientID,
+		jwt.IssuedAtKey:   now,
+		jwt.ExpirationKey: now.Add(5 * time.Minute),
+		"nonce":           testNonce,
+		"email":           "test@example.com",
+		"email_verified":  true,
+		"name":            "Test",
+	}
+}
+
+// sign signs the claims with the algorithm and the key
+func sign(t *testing.T, claims map[string]interface{}, alg jwa.SignatureAlgorithm, key interface{}) string {
+	token := jwt.New()
+	for k, v := range claims {
+		require.NoError(t, token.Set(k, v))
+	}
+	signed, err := jwt.Sign(token, alg, key)
+	require.NoError(t, err)
+	return string(signed)
+}
+
+func TestNewProvider(t *testing.T) {
+	tcs := map[string]struct {
+		givenDiscovery func(i *testIssuer) oidc.Discovery
+		givenURL       func(i *testIssuer) string
+		expErr         string
+	}{
+		"success": {
+			givenDiscovery: func(i *testIssuer) oidc.Discovery { return i.discovery },
+		},
+		"other_issuer": {
+			givenDiscovery: func(i *testIssuer) oidc.Discovery {
+				d := i.discovery
+				d.Issuer = "https://other.example.com"
+				return d
+			},
+			expErr: "does not match",
+		},
+		"incomplete": {
+			givenDiscovery: func(i *testIssuer) oidc.Discovery {
+				d := i.discovery
+				d.JWKSURI = ""
+				return d
+			},
+			expErr: "discovery document is incomplete",
+		},
+		"not_found": {
+			givenDiscovery: func(i *testIssuer) oidc.Discovery { return i.discovery },
+			givenURL:       func(i *testIssuer) string { return i.URL + "/missing" },
+			expErr:         "cannot get discovery document",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			issuer := newTestIssuer(t)
+			issuer.discovery = tc.givenDiscovery(issuer)
+			config := oidc.Config{Issuer: issuer.URL, ClientID: testClientID}
+			if tc.givenURL != nil {
+				config.DiscoveryURL = tc.givenURL(issuer)
+			}
+
+			// WHEN
+			result, err := oidc.NewProvider(context.Background(), config)
+
+			// THEN
+			if tc.expErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, issuer.URL, result.Issuer())
+		})
+	}
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	// GIVEN
+	issuer := newTestIssuer(t)
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+		Issuer:      issuer.URL,
+		ClientID:    testClientID,
+		RedirectURL: "https://app.example.com/callback",
+		Scopes:      []string{"openid", "email"},
+	})
+	require.NoError(t, err)
+
+	// WHEN
+	result := provider.AuthCodeURL("state", testNonce, "challenge")
+
+	// THEN
+	u, err := url.Parse(result)
+	require.NoError(t, err)
+	require.Equal(t, issuer.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	require.Equal(t, url.Values{
+		"response_type":         {"code"},
+		"client_id":             {testClientID},
+		"redirect_uri":          {"https://app.example.com/callback"},
+		"scope":                 {"openid email"},
+		"state":                 {"state"},
+		"nonce":                 {testNonce},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+	}, u.Query())
+}
+
+func TestProvider_ExchangeVerifyIDToken(t *testing.T) {
+	issuer := oidctest.NewIssuer(testClientID, "secret")
+	defer issuer.Close()
+	givenClaims := oidc.Claims{Subject: "sub-1", Email: "test@example.com", EmailVerified: true, Name: "Test"}
+
+	tcs := map[string]struct {
+		givenClientSecret string
+		givenVerifier     func(verifier string) string
+		givenNonce        string
+		expErr            string
+	}{
+		"success": {
+			givenClientSecret: "secret",
+			givenVerifier:     func(verifier string) string { return verifier },
+			givenNonce:        testNonce,
+		},
+		"wrong_code_verifier": {
+			givenClientSecret: "secret",
+			givenVerifier:     func(verifier string) string { return verifier + "x" },
+			givenNonce:        testNonce,
+			expErr:            "invalid_grant",
+		},
+		"wrong_client_secret": {
+			givenClientSecret: "other",
+			givenVerifier:     func(verifier string) string { return verifier },
+			givenNonce:        testNonce,
+			expErr:            "invalid_client",
+		},
+		"wrong_nonce": {
+			givenClientSecret: "secret",
+			givenVerifier:     func(verifier string) string { return verifier },
+			givenNonce:        "other",
+			expErr:            "nonce",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+				Issuer:       issuer.URL,
+				ClientID:     testClientID,
+				ClientSecret: tc.givenClientSecret,
+				RedirectURL:  "https://app.example.com/callback",
+				Scopes:       []string{"openid"},
+			})
+			require.NoError(t, err)
+			verifier, err := oidc.NewCodeVerifier()
+			require.NoError(t, err)
+			code, _, err := issuer.Authorize(provider.AuthCodeURL("state", testNonce, oidc.CodeChallengeS256(verifier)), givenClaims)
+			require.NoError(t, err)
+
+			// WHEN
+			result, err := provider.Exchange(context.Background(), code, tc.givenVerifier(verifier))
+			var claims oidc.Claims
+			if err == nil {
+				claims, err = provider.VerifyIDToken(context.Background(), result.IDToken, tc.givenNonce)
+			}
+
+			// THEN
+			if tc.expErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, givenClaims, claims)
+
+			// The code can only be redeemed once
+			_, err = provider.Exchange(context.Background(), code, verifier)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{Issuer: issuer.URL, ClientID: testClientID})
+	require.NoError(t, err)
+	claimsWith := func(k string, v interface{}) map[string]interface{} {
+		claims := issuer.claims()
+		if v == nil {
+			delete(claims, k)
+		} else {
+			claims[k] = v
+		}
+		return claims
+	}
+
+	tcs := map[string]struct {
+		givenToken string
+		expErr     string
+	}{
+		"success": {
+			givenToken: sign(t, issuer.claims(), jwa.EdDSA, issuer.key),
+		},
+		"other_issuer": {
+			givenToken: sign(t, claimsWith(jwt.IssuerKey, "https://other.example.com"), jwa.EdDSA, issuer.key),
+			expErr:     "iss not satisfied",
+		},
+		"other_audience": {
+			givenToken: sign(t, claimsWith(jwt.AudienceKey, "other"), jwa.EdDSA, issuer.key),
+			expErr:     "aud not satisfied",
+		},
+		"expired": {
+			givenToken: sign(t, claimsWith(jwt.ExpirationKey, time.Now().Add(-2*time.Minute)), jwa.EdDSA, issuer.key),
+			expErr:     "exp not satisfied",
+		},
+		"other_nonce": {
+			givenToken: sign(t, claimsWith("nonce", "other"), jwa.EdDSA, issuer.key),
+			expErr:     "nonce",
+		},
+		"no_subject": {
+			givenToken: sign(t, claimsWith(jwt.SubjectKey, nil), jwa.EdDSA, issuer.key),
+			expErr:     "token has no subject",
+		},
+		"hmac": {
+			givenToken: sign(t, issuer.claims(), jwa.HS256, []byte("secret")),
+			expErr:     `unsupported algorithm "HS256"`,
+		},
+		"unknown_key": {
+			givenToken: sign(t, issuer.claims(), jwa.EdDSA, newTestKey(t, "other")),
+			expErr:     `unknown key id "other"`,
+		},
+		"other_key_with_same_kid": {
+			givenToken: sign(t, issuer.claims(), jwa.EdDSA, newTestKey(t, "test")),
+			expErr:     "failed to verify",
+		},
+		"malformed": {
+			givenToken: "abcd",
+			expErr:     "invalid",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result, err := provider.VerifyIDToken(context.Background(), tc.givenToken, testNonce)
+
+			// THEN
+			if tc.expErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, oidc.Claims{Subject: "sub-1", Email: "test@example.com", EmailVerified: true, Name: "Test"}, result)
+		})
+	}
+}
+
+func TestCodeChallengeS256(t *testing.T) {
+	// The challenge is BASE64URL(SHA256(verifier)) without padding
+	require.Equal(t, "cumO1aU1ZWIU510KSwc3w7B9mNtZgpV_A5MSlAyBKCU", oidc.CodeChallengeS256("dBjftJeZ4CVP-mJ92ZqWIZ6tN7fk3tXWNPGnStNNPpw"))
+
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+	other, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+	require.Len(t, verifier, 43)
+	require.NotEqual(t, verifier, other)
+}
@@ -0,0 +1,188 @@
+// Package oidctest provides a local OpenID Connect issuer to test the login with an issuer without network access
+package oidctest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/oidc"
+)
+
+// keyID is the kid of the signing key of the issuer
+const keyID = "oidctest"
+
+// Issuer is a stand-in issuer which serves the discovery document, the keys and the token endpoint.
+// The authorization endpoint is not served, Authorize plays the part of the user who signs in.
+type Issuer struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+
+	server *httptest.Server
+	key    jwk.Key
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+// authorization is an authorization request which was approved, it is redeemed once by its code
+type authorization struct {
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	claims        oidc.Claims
+}
+
+// NewIssuer starts an issuer for the client, it must be closed by Close
+func NewIssuer(clientID, clientSecret string) *Issuer {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	key, err := jwk.New(privateKey)
+	if err != nil {
+		panic(err)
+	}
+	key.Set(jwk.KeyIDKey, keyID)
+	key.Set(jwk.AlgorithmKey, jwa.EdDSA)
+
+	i := &Issuer{ClientID: clientID, ClientSecret: clientSecret, key: key, codes: map[string]authorization{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.discovery)
+	mux.HandleFunc("/jwks", i.jwks)
+	mux.HandleFunc("/token", i.token)
+	i.server = httptest.NewServer(mux)
+	i.URL = i.server.URL
+	return i
+}
+
+// Close shuts down the issuer
+func (i *Issuer) Close() {
+	i.server.Close()
+}
+
+// Authorize approves the authorization request of the URL returned by oidc.Provider.AuthCodeURL for the user with the claims.
+// It returns the code and the state which the issuer redirects back to the client with.
+func (i *Issuer) Authorize(authCodeURL string, claims oidc.Claims) (code string, state string, err error) {
+	u, err := url.Parse(authCodeURL)
+	if err != nil {
+		return "", "", err
+	}
+	query := u.Query()
+	if query.Get("client_id") != i.ClientID {
+		return "", "", fmt.Errorf("unknown client %q", query.Get("client_id"))
+	}
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		return "", "", fmt.Errorf("authorization code with PKCE S256 is required")
+	}
+
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	code = base64.RawURLEncoding.EncodeToString(b)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.codes[code] = authorization{
+		redirectURI:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		claims:        claims,
+	}
+	return code, query.Get("state"), nil
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.Discovery{
+		Issuer:                i.URL,
+		AuthorizationEndpoint: i.URL + "/authorize",
+		TokenEndpoint:         i.URL + "/token",
+		JWKSURI:               i.URL + "/jwks",
+	})
+}
+
+func (i *Issuer) jwks(w http.ResponseWriter, r *http.Request) {
+	publicKey, err := jwk.PublicKeyOf(i.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	set := jwk.NewSet()
+	set.Add(publicKey)
+	writeJSON(w, http.StatusOK, set)
+}
+
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	// 1. Authenticate the client
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostForm.Get("client_id")
+	}
+	if clientID != i.ClientID || (i.ClientSecret != "" && clientSecret != i.ClientSecret) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	// 2. Redeem the code, it can only be used once
+	i.mu.Lock()
+	auth, ok := i.codes[r.PostForm.Get("code")]
+	delete(i.codes, r.PostForm.Get("code"))
+	i.mu.Unlock()
+	if !ok || auth.redirectURI != r.PostForm.Get("redirect_uri") || oidc.CodeChallengeS256(r.PostForm.Get("code_verifier")) != auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	// 3. Sign the ID token
+	now := time.Now()
+	token := jwt.New()
+	for k, v := range map[string]interface{}{
+		jwt.IssuerKey:     i.URL,
+		jwt.SubjectKey:    auth.claims.Subject,
+		jwt.AudienceKey:   i.ClientID,
+		jwt.IssuedAtKey:   now,
+		jwt.ExpirationKey: now.Add(5 * time.Minute),
+		"nonce":           auth.nonce,
+		"email":           auth.claims.Email,
+		"email_verified":  auth.claims.EmailVerified,
+		"name":            auth.claims.Name,
+	} {
+		token.Set(k, v)
+	}
+	idToken, err := jwt.Sign(token, jwa.EdDSA, i.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, oidc.TokenResponse{
+		AccessToken: "access-" + auth.claims.Subject,
+		IDToken:     string(idToken),
+		TokenType:   "Bearer",
+		ExpiresIn:   300,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
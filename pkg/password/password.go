@@ -0,0 +1,126 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	// DefaultMinLength is the minimum length of passwords if PASSWORD_MIN_LENGTH is not set
+	DefaultMinLength = 8
+	// DefaultMinCharacterClasses is the number of character classes passwords need if PASSWORD_MIN_CHARACTER_CLASSES is not set
+	DefaultMinCharacterClasses = 2
+)
+
+var (
+	ErrTooShort               = errors.New("password is too short")
+	ErrTooFewCharacterClasses = errors.New("password does not mix enough lowercase letters, uppercase letters, digits and symbols")
+	ErrTooCommon              = errors.New("password is too common")
+)
+
+//go:embed common_passwords.txt
+var defaultCommonPasswords []byte
+
+var (
+	loadDefaultCommonPasswords sync.Once
+	defaultCommonPasswordSet   map[string]bool
+)
+
+// Policy decides whether a new password can be used
+type Policy interface {
+	// Validate returns an error describing the first rule the password breaks
+	Validate(password string) error
+}
+
+// Rules is the password policy of the application
+type Rules struct {
+	MinLength int
+	// MinCharacterClasses is how many of lowercase letters, uppercase letters, digits and symbols the password contains
+	MinCharacterClasses int
+	// CommonPasswords are rejected regardless of the other rules, the keys are lowercase
+	CommonPasswords map[string]bool
+}
+
+// Validate checks the length, the character classes and the common password list in this order
+func (r Rules) Validate(password string) error {
+	if len([]rune(password)) < r.MinLength {
+		return fmt.Errorf("%w, it needs at least %d characters", ErrTooShort, r.MinLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, c := range password {
+		switch {
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsDigit(c):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, ok := range []bool{lower, upper, digit, symbol} {
+		if ok {
+			classes++
+		}
+	}
+	if classes < r.MinCharacterClasses {
+		return fmt.Errorf("%w, it needs %d of them", ErrTooFewCharacterClasses, r.MinCharacterClasses)
+	}
+
+	if r.CommonPasswords[strings.ToLower(password)] {
+		return ErrTooCommon
+	}
+	return nil
+}
+
+// ParseCommonPasswords reads a list with one password per line, empty lines and lines starting with # are skipped
+func ParseCommonPasswords(list []byte) map[string]bool {
+	result := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		result[strings.ToLower(line)] = true
+	}
+	return result
+}
+
+// PolicyFromEnv returns the rules from PASSWORD_MIN_LENGTH, PASSWORD_MIN_CHARACTER_CLASSES and PASSWORD_COMMON_LIST_FILE.
+// The common password list shipped with the application is used if PASSWORD_COMMON_LIST_FILE is not set or cannot be read.
+func PolicyFromEnv() Policy {
+	rules := Rules{
+		MinLength:           DefaultMinLength,
+		MinCharacterClasses: DefaultMinCharacterClasses,
+	}
+	if v, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_LENGTH")); err == nil && v > 0 {
+		rules.MinLength = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_CHARACTER_CLASSES")); err == nil && v >= 0 && v <= 4 {
+		rules.MinCharacterClasses = v
+	}
+
+	if path := os.Getenv("PASSWORD_COMMON_LIST_FILE"); path != "" {
+		if list, err := os.ReadFile(path); err == nil {
+			rules.CommonPasswords = ParseCommonPasswords(list)
+			return rules
+		}
+	}
+	loadDefaultCommonPasswords.Do(func() {
+		defaultCommonPasswordSet = ParseCommonPasswords(defaultCommonPasswords)
+	})
+	rules.CommonPasswords = defaultCommonPasswordSet
+	return rules
+}
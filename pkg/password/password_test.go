@@ -0,0 +1,193 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_Validate(t *testing.T) {
+	rules := Rules{MinLength: 8, MinCharacterClasses: 2, CommonPasswords: map[string]bool{"password1": true}}
+	tcs := map[string]struct {
+		givenRules    Rules
+		givenPassword string
+		expErr        string
+	}{
+		"success": {
+			givenRules:    rules,
+			givenPassword: "Secret-Passw0rd",
+		},
+		"lowercase_and_symbol": {
+			givenRules:    rules,
+			givenPassword: "secret-password",
+		},
+		"too_short": {
+			givenRules:    rules,
+			givenPassword: "Secr3t",
+			expErr:        "password is too short, it needs at least 8 characters",
+		},
+		"length_in_characters": {
+			// 8 characters of 16 bytes are long enough
+			givenRules:    rules,
+			givenPassword: "Mật-khẩu",
+		},
+		"too_few_character_classes": {
+			givenRules:    rules,
+			givenPassword: "secretpassword",
+			expErr:        "password does not mix enough lowercase letters, uppercase letters, digits and symbols, it needs 2 of them",
+		},
+		"all_character_classes": {
+			givenRules:    Rules{MinLength: 8, MinCharacterClasses: 4},
+			givenPassword: "Secret-Passw0rd",
+		},
+		"three_of_all_character_classes": {
+			givenRules:    Rules{MinLength: 8, MinCharacterClasses: 4},
+			givenPassword: "SecretPassw0rd",
+			expErr:        "password does not mix enough lowercase letters, uppercase letters, digits and symbols, it needs 4 of them",
+		},
+		"too_common": {
+			givenRules:    rules,
+			givenPassword: "password1",
+			expErr:        ErrTooCommon.Error(),
+		},
+		"too_common_in_another_case": {
+			givenRules:    rules,
+			givenPassword: "PassWord1",
+			expErr:        ErrTooCommon.Error(),
+		},
+		"too_short_before_too_common": {
+			givenRules:    Rules{MinLength: 12, CommonPasswords: map[string]bool{"password1": true}},
+			givenPassword: "password1",
+			expErr:        "password is too short, it needs at least 12 characters",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			err := tc.givenRules.Validate(tc.givenPassword)
+
+			// THEN
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseCommonPasswords(t *testing.T) {
+	tcs := map[string]struct {
+		given string
+		exp   map[string]bool
+	}{
+		"success": {
+			given: "123456\nqwerty\n",
+			exp:   map[string]bool{"123456": true, "qwerty": true},
+		},
+		"comments_and_empty_lines": {
+			given: "# The common passwords\n\n123456\n   \n# qwerty\n",
+			exp:   map[string]bool{"123456": true},
+		},
+		"lowercase_and_trimmed": {
+			given: "  Password1 \r\nPASSWORD1\n",
+			exp:   map[string]bool{"password1": true},
+		},
+		"empty": {
+			given: "",
+			exp:   map[string]bool{},
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result := ParseCommonPasswords([]byte(tc.given))
+
+			// THEN
+			require.Equal(t, tc.exp, result)
+		})
+	}
+}
+
+func TestPolicyFromEnv(t *testing.T) {
+	listFile := filepath.Join(t.TempDir(), "common_passwords.txt")
+	require.NoError(t, os.WriteFile(listFile, []byte("# Custom list\nLetMeIn-2022\n"), 0o600))
+
+	tcs := map[string]struct {
+		givenMinLength           string
+		givenMinCharacterClasses string
+		givenCommonListFile      string
+		expMinLength             int
+		expMinCharacterClasses   int
+		expCommon                string
+		expNotCommon             string
+	}{
+		"default": {
+			expMinLength:           DefaultMinLength,
+			expMinCharacterClasses: DefaultMinCharacterClasses,
+			expCommon:              "password1",
+			expNotCommon:           "letmein-2022",
+		},
+		"custom": {
+			givenMinLength:           "12",
+			givenMinCharacterClasses: "3",
+			givenCommonListFile:      listFile,
+			expMinLength:             12,
+			expMinCharacterClasses:   3,
+			expCommon:                "letmein-2022",
+			expNotCommon:             "password1",
+		},
+		"no_character_classes": {
+			givenMinCharacterClasses: "0",
+			expMinLength:             DefaultMinLength,
+			expMinCharacterClasses:   0,
+			expCommon:                "password1",
+		},
+		"invalid_numbers": {
+			givenMinLength:           "eight",
+			givenMinCharacterClasses: "two",
+			expMinLength:             DefaultMinLength,
+			expMinCharacterClasses:   DefaultMinCharacterClasses,
+			expCommon:                "password1",
+		},
+		"out_of_range": {
+			givenMinLength:           "0",
+			givenMinCharacterClasses: "5",
+			expMinLength:             DefaultMinLength,
+			expMinCharacterClasses:   DefaultMinCharacterClasses,
+			expCommon:                "password1",
+		},
+		"missing_common_list_file": {
+			givenCommonListFile:    filepath.Join(t.TempDir(), "missing.txt"),
+			expMinLength:           DefaultMinLength,
+			expMinCharacterClasses: DefaultMinCharacterClasses,
+			expCommon:              "password1",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// GIVEN
+			t.Setenv("PASSWORD_MIN_LENGTH", tc.givenMinLength)
+			t.Setenv("PASSWORD_MIN_CHARACTER_CLASSES", tc.givenMinCharacterClasses)
+			t.Setenv("PASSWORD_COMMON_LIST_FILE", tc.givenCommonListFile)
+
+			// WHEN
+			result := PolicyFromEnv()
+
+			// THEN
+			rules, ok := result.(Rules)
+			require.True(t, ok)
+			require.Equal(t, tc.expMinLength, rules.MinLength)
+			require.Equal(t, tc.expMinCharacterClasses, rules.MinCharacterClasses)
+			require.True(t, rules.CommonPasswords[tc.expCommon])
+			if tc.expNotCommon != "" {
+				require.False(t, rules.CommonPasswords[tc.expNotCommon])
+			}
+		})
+	}
+}
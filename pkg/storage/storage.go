@@ -0,0 +1,74 @@
+// Package storage stores files by key on the local filesystem or in an S3-compatible bucket
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Storage driver names of STORAGE_DRIVER
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// DefaultLocalDir is the directory of the local storage if STORAGE_LOCAL_DIR is not set
+const DefaultLocalDir = "docs/files"
+
+var (
+	// ErrNotFound is returned when no object is stored with the key
+	ErrNotFound = errors.New("object not found")
+	// ErrInvalidKey is returned for keys which are empty, absolute or go up a directory
+	ErrInvalidKey = errors.New("object key is invalid")
+)
+
+// Object is a stored file, the body must be closed by the caller
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// Storage stores the objects by their key, a key is a slash separated path such as "products/1/image.jpg"
+type Storage interface {
+	// Put stores the data with the key, an object with the same key is replaced
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns the object with the key or ErrNotFound
+	Get(ctx context.Context, key string) (Object, error)
+	// Delete deletes the object with the key, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// validateKey checks that the key is a relative slash separated path without empty, "." or ".." segments
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// FromEnv returns the storage configured by STORAGE_DRIVER, the local storage is used by default.
+// The local storage stores the files in STORAGE_LOCAL_DIR, the s3 storage is configured by S3ConfigFromEnv.
+func FromEnv() (Storage, error) {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", DriverLocal:
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = DefaultLocalDir
+		}
+		return NewLocal(dir), nil
+	case DriverS3:
+		return NewS3(S3ConfigFromEnv())
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+	}
+}
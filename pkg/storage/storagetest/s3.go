@@ -0,0 +1,207 @@
+// Package storagetest provides a local S3-compatible service to test the s3 storage without network access
+package storagetest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vinhnv1/s3corp-golang-fresher/pkg/storage"
+)
+
+// Object is an object stored by the service
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// S3 is a stand-in for MinIO which serves the PUT, GET and DELETE object requests of one bucket.
+// Like MinIO, the requests must use path-style URLs and be signed with Signature Version 4 by the access key of the service.
+type S3 struct {
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+
+	server *httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]Object
+}
+
+// NewS3 starts the service with an empty bucket, it must be closed by Close
+func NewS3(bucket, accessKeyID, secretAccessKey string) *S3 {
+	s := &S3{Bucket: bucket, AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey, objects: map[string]Object{}}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close stops the service
+func (s *S3) Close() {
+	s.server.Close()
+}
+
+// Config returns the configuration of the s3 storage which uses the service
+func (s *S3) Config() storage.S3Config {
+	return storage.S3Config{
+		Endpoint:        s.server.URL,
+		Region:          storage.DefaultS3Region,
+		Bucket:          s.Bucket,
+		AccessKeyID:     s.AccessKeyID,
+		SecretAccessKey: s.SecretAccessKey,
+	}
+}
+
+// Object returns the object with the key
+func (s *S3) Object(key string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.objects[key]
+	return object, ok
+}
+
+// Keys returns the keys of the stored objects in order
+func (s *S3) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeError writes the error document of S3
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: message})
+}
+
+func (s *S3) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	if code, message := s.verify(r, body); code != "" {
+		writeError(w, http.StatusForbidden, code, message)
+		return
+	}
+
+	path := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if path[0] != s.Bucket || len(path) != 2 {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[path[1]] = Object{Data: body, ContentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, ok := s.objects[path[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Header().Set("Content-Type", object.ContentType)
+		w.Write(object.Data)
+	case http.MethodDelete:
+		delete(s.objects, path[1])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+}
+
+// verify checks the Signature Version 4 authorization of the request, it returns the code of the error if the request is not signed by the access key
+func (s *S3) verify(r *http.Request, body []byte) (string, string) {
+	// 1. Parse the authorization header
+	const algorithm = "AWS4-HMAC-SHA256 "
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, algorithm) {
+		return "AccessDenied", "Signature Version 4 authorization is required"
+	}
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(authorization, algorithm), ",") {
+		if field := strings.SplitN(strings.TrimSpace(part), "=", 2); len(field) == 2 {
+			fields[field[0]] = field[1]
+		}
+	}
+	credential := strings.SplitN(fields["Credential"], "/", 2)
+	if len(credential) != 2 || credential[0] != s.AccessKeyID {
+		return "InvalidAccessKeyId", "The access key ID does not exist"
+	}
+	scope := credential[1]
+	scopeParts := strings.Split(scope, "/")
+	if len(scopeParts) != 4 || scopeParts[2] != "s3" || scopeParts[3] != "aws4_request" {
+		return "AuthorizationHeaderMalformed", "The credential scope is invalid"
+	}
+
+	// 2. The payload must match its hash
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	sum := sha256.Sum256(body)
+	if payloadHash != hex.EncodeToString(sum[:]) {
+		return "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed."
+	}
+
+	// 3. Rebuild the canonical request from the signed headers
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	query := make([]string, 0)
+	for name, values := range r.URL.Query() {
+		for _, value := range values {
+			query = append(query, url.QueryEscape(name)+"="+url.QueryEscape(value))
+		}
+	}
+	sort.Strings(query)
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		strings.Join(query, "&"),
+		canonicalHeaders.String(),
+		fields["SignedHeaders"],
+		payloadHash,
+	}, "\n")
+
+	// 4. Compare the signatures
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + r.Header.Get("X-Amz-Date") + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+	key := []byte("AWS4" + s.SecretAccessKey)
+	for _, part := range scopeParts {
+		key = sign(key, part)
+	}
+	expected := hex.EncodeToString(sign(key, stringToSign))
+	if !hmac.Equal([]byte(expected), []byte(fields["Signature"])) {
+		return "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided."
+	}
+	return "", ""
+}
+
+func sign(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,137 @@
+// Package thumbnail decodes JPEG, PNG and GIF images and scales them down to thumbnails
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// Content types of the supported images
+const (
+	JPEG = "image/jpeg"
+	PNG  = "image/png"
+	GIF  = "image/gif"
+)
+
+// jpegQuality is the quality of the encoded JPEG thumbnails
+const jpegQuality = 85
+
+var (
+	// ErrUnsupportedType is returned when the data is not a JPEG, PNG or GIF image
+	ErrUnsupportedType = errors.New("image type is not supported")
+	// ErrInvalidImage is returned when the data cannot be decoded
+	ErrInvalidImage = errors.New("image cannot be decoded")
+	// ErrTooManyPixels is returned when the image has more pixels than allowed, before it is decoded
+	ErrTooManyPixels = errors.New("image has too many pixels")
+)
+
+// DetectType returns the content type of the image sniffed from its first bytes, the declared type of an upload is not trusted
+func DetectType(data []byte) (string, error) {
+	switch contentType := http.DetectContentType(data); contentType {
+	case JPEG, PNG, GIF:
+		return contentType, nil
+	default:
+		return "", ErrUnsupportedType
+	}
+}
+
+// Decode decodes the image, the dimensions are checked against maxPixels first so large images are not decoded
+func Decode(data []byte, maxPixels int) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	return img, nil
+}
+
+// Fit scales the image down to fit in a square of side pixels, keeping its aspect ratio.
+// Images which already fit are not scaled up. Each pixel is the average of the pixels it covers.
+func Fit(img image.Image, side int) *image.RGBA {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dstW, dstH := srcW, srcH
+	if srcW > side || srcH > side {
+		if srcW >= srcH {
+			dstW, dstH = side, max(1, srcH*side/srcW)
+		} else {
+			dstW, dstH = max(1, srcW*side/srcH), side
+		}
+	}
+
+	// Work on premultiplied RGBA pixels so transparent pixels do not darken their neighbours
+	src := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	if dstW == srcW && dstH == srcH {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			n := (y1 - y0) * (x1 - x0)
+			i := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[i+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+// Type returns the content type of the thumbnails of an image, JPEG for JPEG images and PNG for the others which may be transparent
+func Type(sourceType string) string {
+	if sourceType == JPEG {
+		return JPEG
+	}
+	return PNG
+}
+
+// Encode encodes the thumbnail with the content type returned by Type
+func Encode(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == JPEG {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
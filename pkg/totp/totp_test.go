@@ -0,0 +1,161 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 secret "12345678901234567890" of the test vectors of RFC 6238 Appendix B
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	// The codes of RFC 6238 Appendix B have 8 digits, the last 6 digits are the codes with Digits
+	tcs := map[string]struct {
+		givenTime time.Time
+		expCode   string
+	}{
+		"59": {
+			givenTime: time.Unix(59, 0),
+			expCode:   "287082",
+		},
+		"1111111109": {
+			givenTime: time.Unix(1111111109, 0),
+			expCode:   "081804",
+		},
+		"1111111111": {
+			givenTime: time.Unix(1111111111, 0),
+			expCode:   "050471",
+		},
+		"1234567890": {
+			givenTime: time.Unix(1234567890, 0),
+			expCode:   "005924",
+		},
+		"2000000000": {
+			givenTime: time.Unix(2000000000, 0),
+			expCode:   "279037",
+		},
+		"20000000000": {
+			givenTime: time.Unix(20000000000, 0),
+			expCode:   "353130",
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			result, err := GenerateCode(rfcSecret, Step(tc.givenTime))
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.expCode, result)
+		})
+	}
+}
+
+func TestGenerateCode_InvalidSecret(t *testing.T) {
+	// WHEN
+	_, err := GenerateCode("not base32!", 1)
+
+	// THEN
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+	codeAt := func(step int64) string {
+		code, err := GenerateCode(rfcSecret, step)
+		require.NoError(t, err)
+		return code
+	}
+
+	tcs := map[string]struct {
+		givenSecret string
+		givenCode   string
+		expStep     int64
+		expOK       bool
+	}{
+		"current_step": {
+			givenSecret: rfcSecret,
+			givenCode:   codeAt(current),
+			expStep:     current,
+			expOK:       true,
+		},
+		"previous_step": {
+			givenSecret: rfcSecret,
+			givenCode:   codeAt(current - 1),
+			expStep:     current - 1,
+			expOK:       true,
+		},
+		"next_step": {
+			givenSecret: rfcSecret,
+			givenCode:   codeAt(current + 1),
+			expStep:     current + 1,
+			expOK:       true,
+		},
+		"two_steps_before": {
+			givenSecret: rfcSecret,
+			givenCode:   codeAt(current - 2),
+		},
+		"two_steps_after": {
+			givenSecret: rfcSecret,
+			givenCode:   codeAt(current + 2),
+		},
+		"wrong_code": {
+			givenSecret: rfcSecret,
+			givenCode:   "000000",
+		},
+		"invalid_secret": {
+			givenSecret: "not base32!",
+			givenCode:   codeAt(current),
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			// WHEN
+			step, ok := Validate(tc.givenSecret, tc.givenCode, now)
+
+			// THEN
+			require.Equal(t, tc.expOK, ok)
+			require.Equal(t, tc.expStep, step)
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	// WHEN
+	first, err := GenerateSecret()
+	require.NoError(t, err)
+	second, err := GenerateSecret()
+	require.NoError(t, err)
+
+	// THEN
+	require.NotEqual(t, first, second)
+	key, err := secretEncoding.DecodeString(first)
+	require.NoError(t, err)
+	require.Len(t, key, 20)
+}
+
+func TestURI(t *testing.T) {
+	// WHEN
+	result := URI("S3Corp", "admin@example.com", rfcSecret)
+
+	// THEN
+	u, err := url.Parse(result)
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/S3Corp:admin@example.com", u.Path)
+	require.Equal(t, url.Values{
+		"secret":    {rfcSecret},
+		"issuer":    {"S3Corp"},
+		"algorithm": {"SHA1"},
+		"digits":    {"6"},
+		"period":    {"30"},
+	}, u.Query())
+}